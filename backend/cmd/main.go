@@ -1,11 +1,26 @@
 package main
 
-import "elang-backend/internal/config"
+import (
+	"elang-backend/internal/config"
+	"log"
+	"log/slog"
+	"os"
+)
 
 func main() {
 
 	// Load configurations
 	configs := config.LoadConfigurations()
+	if err := configs.Validate(); err != nil {
+		log.Fatal(err)
+	}
+
+	// Initialize logger before anything else logs
+	logger, err := config.NewLogger(configs.LOG_LEVEL, configs.LOG_FORMAT, os.Stderr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	slog.SetDefault(logger)
 
 	// Initialize database connection
 	dbConfig := config.Config{
@@ -27,9 +42,6 @@ func main() {
 	}
 	database.Seed()
 
-	// Initialize logger
-	logger := config.NewLogger()
-
 	// Create AppConfig
 	appConfig := &config.AppConfig{
 		Log:    logger,
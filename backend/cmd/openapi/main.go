@@ -0,0 +1,29 @@
+// Command openapi writes the OpenAPI document of the HTTP API.
+//
+//	go run ./cmd/openapi -o docs/openapi.json
+package main
+
+import (
+	delivery "elang-backend/internal/delivery/http"
+	"flag"
+	"log"
+	"os"
+)
+
+func main() {
+	out := flag.String("o", "", "output file (default stdout)")
+	flag.Parse()
+
+	spec, err := delivery.OpenAPISpec()
+	if err != nil {
+		log.Fatalf("failed to render OpenAPI document: %v", err)
+	}
+	if *out == "" {
+		_, err = os.Stdout.Write(spec)
+	} else {
+		err = os.WriteFile(*out, spec, 0o644)
+	}
+	if err != nil {
+		log.Fatalf("failed to write OpenAPI document: %v", err)
+	}
+}
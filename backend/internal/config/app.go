@@ -9,35 +9,86 @@ import (
 	"elang-backend/internal/services"
 	"elang-backend/internal/usecase"
 	"log"
+	"log/slog"
 	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
 
 type AppConfig struct {
-	Log    *logrus.Logger
+	Log    *slog.Logger
 	Config *Configurations
 	DB     *gorm.DB
 }
 
 func Bootstrap(Config *AppConfig) {
+	// Route every slog call, and the standard log package, through the configured handler
+	if Config.Log != nil {
+		slog.SetDefault(Config.Log)
+	}
+
 	// Initialize other components if needed
 	repos := initializeRepositories(Config.DB)
 
 	// Initialize services with repositories, logger, and configurations
-	services := initializeServices(repos, Config.Log, Config.Config)
+	services := initializeServices(repos, Config.Config)
+
+	// Move the SBOMs and reports saved before object keys were prefixed to where they are looked up now
+	if _, err := services.RetentionService.MigrateLegacyObjectKeys(context.Background()); err != nil {
+		slog.Error("Failed to migrate legacy object storage keys, documents not moved yet stay unreachable", "error", err)
+	}
+
+	// Prune expired scan results and audit entries in the background
+	services.RetentionService.Start()
+
+	// Run the cron scan schedules in the background
+	services.ScheduleService.Start()
 
 	// Initialize HTTP handlers
-	server := setupHTTPServer(services)
+	server := setupHTTPServer(services, Config.Config)
 
-	// Start HTTP server with graceful shutdown
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	// Start HTTP server with graceful shutdown on SIGINT/SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 	startHTTPServer(ctx, server)
 
+	// Stop background work and release connections once the server no longer accepts requests
+	shutdownServices(services, Config.DB)
+}
+
+// shutdownServices stops monitoring loops, cancels in-flight scans and waits for them
+// (bounded by a timeout) before closing the database connection.
+func shutdownServices(services *Services, db *gorm.DB) {
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	log.Println("🛑 Stopping background jobs...")
+	if err := services.DepedenciesService.Shutdown(shutdownCtx); err != nil {
+		log.Printf("❌ Monitoring jobs did not stop cleanly: %v", err)
+	}
+	if err := services.ApplicationService.Shutdown(shutdownCtx); err != nil {
+		log.Printf("❌ Application background jobs did not stop cleanly: %v", err)
+	}
+	if err := services.RetentionService.Shutdown(shutdownCtx); err != nil {
+		log.Printf("❌ Retention cleanup did not stop cleanly: %v", err)
+	}
+	if err := services.ScheduleService.Shutdown(shutdownCtx); err != nil {
+		log.Printf("❌ Scheduled scans did not stop cleanly: %v", err)
+	}
+
+	// The MinIO client is stateless HTTP and holds nothing that needs closing
+	database := &Database{Connection: db}
+	if err := database.Close(); err != nil {
+		log.Printf("❌ Failed to close database connection: %v", err)
+	} else {
+		log.Println("✅ Database connection closed")
+	}
 }
 
 // startHTTPServer starts the HTTP server with graceful shutdown
@@ -64,7 +115,7 @@ func startHTTPServer(ctx context.Context, server *http.Server) {
 	}
 }
 
-func setupHTTPServer(services *Services) *http.Server {
+func setupHTTPServer(services *Services, cfg *Configurations) *http.Server {
 	router := gin.Default()
 
 	// Add middleware
@@ -73,9 +124,27 @@ func setupHTTPServer(services *Services) *http.Server {
 
 	// Setup routes with simplified handlers
 	routeConfig := &delivery.RouteConfig{
-		Router:              router,
-		AppHandler:          *delivery.NewApplicationHandler(services.ApplicationService),
-		DependenciesHandler: *delivery.NewDependenciesHandler(services.DepedenciesService),
+		Router:                  router,
+		AppHandler:              *delivery.NewApplicationHandler(services.ApplicationService),
+		DependenciesHandler:     *delivery.NewDependenciesHandler(services.DepedenciesService),
+		AdminHandler:            *delivery.NewAdminHandler(services.SeedService),
+		ScheduleHandler:         *delivery.NewScheduleHandler(services.ScheduleService),
+		RemediationHandler:      *delivery.NewRemediationHandler(services.RemediationService),
+		SeverityOverrideHandler: *delivery.NewSeverityOverrideHandler(services.SeverityOverrideService),
+		Auth:                    delivery.AuthConfig{Secret: cfg.JWT_SECRET, Issuer: cfg.JWT_ISSUER, AdminSubjects: cfg.ADMIN_SUBJECTS},
+		RateLimit:               delivery.RateLimitConfig{RequestsPerMinute: cfg.RATE_LIMIT_PER_MINUTE, Burst: cfg.RATE_LIMIT_BURST},
+		MaxBodyBytes:            int64(cfg.MAX_REQUEST_BODY_MB) << 20,
+		IdempotencyTTL:          cfg.IDEMPOTENCY_KEY_TTL,
+		StorageDegraded:         services.ObjectStorageService == nil,
+		CORS: delivery.CORSConfig{
+			AllowedOrigins:   cfg.CORS_ALLOWED_ORIGINS,
+			AllowedMethods:   cfg.CORS_ALLOWED_METHODS,
+			AllowedHeaders:   cfg.CORS_ALLOWED_HEADERS,
+			AllowCredentials: cfg.CORS_ALLOW_CREDENTIALS,
+		},
+	}
+	if cfg.JWT_SECRET == "" {
+		log.Println("⚠️ JWT_SECRET is not set: API authentication and per-user application ownership are disabled")
 	}
 	routeConfig.Setup()
 
@@ -94,10 +163,16 @@ func initializeRepositories(db *gorm.DB) *Repositories {
 		Runtime:          repository.NewRuntimeRepository(db),
 		Framework:        repository.NewFrameworkRepository(db),
 		AuditTrail:       repository.NewAuditTrailRepository(db),
+		ScanResult:       repository.NewScanResultRepository(db),
+		Tag:              repository.NewTagRepository(db),
+		ScanSchedule:     repository.NewScanScheduleRepository(db),
+		Remediation:      repository.NewRemediationRepository(db),
+		SeverityOverride: repository.NewSeverityOverrideRepository(db),
+		UnitOfWork:       repository.NewUnitOfWork(db),
 	}
 }
 
-func initializeServices(repos *Repositories, log *logrus.Logger, cfg *Configurations) *Services {
+func initializeServices(repos *Repositories, cfg *Configurations) *Services {
 	basicRepos := dto.BasicRepositories{
 		AppRepository:              repos.App,
 		DepedencyRepository:        repos.Depedency,
@@ -106,33 +181,145 @@ func initializeServices(repos *Repositories, log *logrus.Logger, cfg *Configurat
 		RunTimeRepository:          repos.Runtime,
 		FrameWorkRepository:        repos.Framework,
 		AuditTrailRepository:       repos.AuditTrail,
+		ScanResultRepository:       repos.ScanResult,
+		TagRepository:              repos.Tag,
+		ScanScheduleRepository:     repos.ScanSchedule,
+		RemediationRepository:      repos.Remediation,
+		SeverityOverrideRepository: repos.SeverityOverride,
+		UnitOfWork:                 repos.UnitOfWork,
+	}
+	// Every outbound client created below sends its requests through the proxy and CA bundle
+	outboundTransport, err := helper.NewOutboundTransport(helper.OutboundHTTPConfig{
+		ProxyURL:     cfg.OUTBOUND_PROXY_URL,
+		CABundleFile: cfg.OUTBOUND_CA_BUNDLE,
+	})
+	if err != nil {
+		log.Fatalf("Invalid outbound HTTP configuration: %v", err)
 	}
+
 	dependencyParser := helper.NewDependencyParser()
-	objectStorageService := usecase.NewMinioUsecase(cfg.MINIO_ENDPOINT, cfg.MINIO_ACCESS_KEY, cfg.MINIO_SECRET_KEY, cfg.MINIO_BUCKET_NAME, cfg.MINIO_USE_SSL)
+	if err := dependencyParser.SetEnabledRuntimes(cfg.ENABLED_RUNTIMES); err != nil {
+		log.Fatalf("Invalid ENABLED_RUNTIMES: %v", err)
+	}
+	if err := dependencyParser.SetDenylist(cfg.DEPENDENCY_DENYLIST); err != nil {
+		log.Fatalf("Invalid DEPENDENCY_DENYLIST: %v", err)
+	}
+	dependencyParser.SetExcludeDev(cfg.EXCLUDE_DEV_DEPENDENCIES)
+	dependencyParser.SetIncludeGoIndirect(cfg.GO_INCLUDE_INDIRECT)
+	dependencyParser.SetMavenRepository(cfg.MAVEN_REPOSITORY_URL, outboundTransport)
+	// Without object storage scans still run; their SBOMs and reports are just not stored
+	var objectStorageService usecase.ObjectStorageInterface
+	storage, err := usecase.NewMinioUsecase(cfg.MINIO_ENDPOINT, cfg.MINIO_ACCESS_KEY, cfg.MINIO_SECRET_KEY, cfg.MINIO_BUCKET_NAME, cfg.MINIO_USE_SSL, cfg.MINIO_KEY_PREFIX)
+	if err != nil {
+		slog.Error("⚠️ Object storage is unreachable, continuing with storage disabled: SBOMs and reports will not be stored until the service is restarted",
+			"endpoint", cfg.MINIO_ENDPOINT, "bucket", cfg.MINIO_BUCKET_NAME, "error", err)
+	} else {
+		objectStorageService = storage
+	}
 
 	var githubApiService usecase.GitHubAPIInterface
-	if cfg.GITHUB_TOKEN != "" {
-		githubApiService = usecase.NewGitHubAPIusecase(cfg.GITHUB_TOKEN)
+	githubAuthenticated := cfg.GITHUB_APP_ID != 0 || cfg.GITHUB_TOKEN != ""
+	githubEndpoints := usecase.GitHubEndpoints{REST: cfg.GITHUB_API_URL, GraphQL: cfg.GITHUB_GRAPHQL_URL}
+	if cfg.GITHUB_APP_ID != 0 {
+		privateKey, err := os.ReadFile(cfg.GITHUB_APP_PRIVATE_KEY_PATH)
+		if err != nil {
+			log.Fatalf("Failed to read GITHUB_APP_PRIVATE_KEY_PATH: %v", err)
+		}
+		tokenProvider, err := usecase.NewGitHubAppTokenProvider(int64(cfg.GITHUB_APP_ID), int64(cfg.GITHUB_APP_INSTALLATION_ID), privateKey)
+		if err != nil {
+			log.Fatalf("Invalid GitHub App configuration: %v", err)
+		}
+		tokenProvider.BaseURL = githubEndpoints.RESTURL()
+		tokenProvider.HTTPClient.Transport = outboundTransport
+		slog.Info("Authenticating to GitHub as app installation", "installation_id", cfg.GITHUB_APP_INSTALLATION_ID)
+		githubApiService = usecase.NewGitHubAPIusecaseWithEndpoints(tokenProvider, githubEndpoints)
+	} else if cfg.GITHUB_TOKEN != "" {
+		githubApiService = usecase.NewGitHubAPIusecaseWithEndpoints(usecase.NewStaticTokenProvider(cfg.GITHUB_TOKEN), githubEndpoints)
 	} else {
-		log.Warn("⚠️ GITHUB_TOKEN is not set. GitHub API service will have limited functionality due to rate limits.")
-		githubApiService = usecase.NewGitHubAPIusecase("") // Initialize with empty token for limited functionality
+		slog.Warn("⚠️ GITHUB_TOKEN is not set. GitHub API service will have limited functionality due to rate limits.")
+		// Initialize with empty token for limited functionality
+		githubApiService = usecase.NewGitHubAPIusecaseWithEndpoints(usecase.NewStaticTokenProvider(""), githubEndpoints)
+	}
+	githubApiService.(*usecase.GithubAPIusecase).HTTPClient.Transport = outboundTransport
+	if cfg.GITHUB_API_URL != "" {
+		slog.Info("Using GitHub API", "rest_url", githubEndpoints.RESTURL(), "graphql_url", githubEndpoints.GraphQLURL())
 	}
 
 	// githubApiService := usecase.NewGitHubAPIusecase(cfg.GITHUB_TOKEN)
+	var vulnerabilitySources []helper.VulnerabilitySource
+	for _, name := range cfg.VULNERABILITY_SOURCES {
+		switch strings.ToLower(name) {
+		case "osv":
+			// One source serves both services, so their scans share its rate limit
+			osv := helper.NewOSVSourceWithClient(&http.Client{Timeout: 30 * time.Second, Transport: outboundTransport})
+			osv.SetRateLimit(cfg.OSV_REQUESTS_PER_SECOND)
+			osv.SetDeclaredRangeChecks(cfg.SCAN_DECLARED_RANGES)
+			vulnerabilitySources = append(vulnerabilitySources, osv)
+		case "github":
+			if !githubAuthenticated {
+				log.Fatalf("VULNERABILITY_SOURCES includes github, which requires GITHUB_TOKEN or a GitHub App")
+			}
+			vulnerabilitySources = append(vulnerabilitySources, usecase.NewGitHubAdvisorySource(githubApiService.(*usecase.GithubAPIusecase)))
+		default:
+			log.Fatalf("Unknown vulnerability source %q in VULNERABILITY_SOURCES (expected osv or github)", name)
+		}
+	}
+	severities, err := helper.NewSeverityPalette(cfg.SEVERITY_LABELS, cfg.SEVERITY_COLORS)
+	if err != nil {
+		log.Fatalf("Invalid SEVERITY_LABELS or SEVERITY_COLORS: %v", err)
+	}
+	serviceConfig := services.ServiceConfig{
+		Scanner: helper.ScannerConfig{
+			Sources:           vulnerabilitySources,
+			MergeSources:      cfg.VULNERABILITY_SOURCES_MERGE,
+			MaxConcurrent:     cfg.SCAN_CONCURRENCY,
+			DependencyTimeout: cfg.DEPENDENCY_SCAN_TIMEOUT,
+		},
+		Policy: helper.PolicyConfig{
+			FailClosed:             cfg.SCAN_FAIL_CLOSED,
+			UnsupportedAsUnchecked: !cfg.SCAN_UNSUPPORTED_AS_WARNING,
+			Severities:             severities,
+		},
+		SBOMTool:                    helper.NewSBOMTool(cfg.SBOM_TOOL_VENDOR, cfg.SBOM_TOOL_NAME),
+		MaxDependenciesPerApp:       cfg.MAX_DEPENDENCIES_PER_APP,
+		GitHubHost:                  githubEndpoints.WebHost(),
+		TrustedRepositoryHosts:      cfg.TRUSTED_REPOSITORY_HOSTS,
+		DefaultBranchFallbacks:      cfg.GITHUB_BRANCH_FALLBACKS,
+		CommitHistoryLimit:          cfg.GITHUB_COMMIT_LIMIT,
+		CountCollaborators:          cfg.GITHUB_COUNT_COLLABORATORS,
+		DependencyProcessingWorkers: cfg.DEPENDENCY_WORKERS,
+		AutoScanOnAdd:               cfg.AUTO_SCAN_ON_ADD,
+	}
 
+	applicationService := services.NewApplicationService(basicRepos, *dependencyParser, objectStorageService, githubApiService, serviceConfig)
 	return &Services{
-		ObjectStorageService: objectStorageService,
-		ApplicationService:   services.NewApplicationService(basicRepos, *dependencyParser, objectStorageService, githubApiService),
-		DepedenciesService:   services.NewDependenciesService(basicRepos, *dependencyParser, objectStorageService),
+		ObjectStorageService:    objectStorageService,
+		ApplicationService:      applicationService,
+		DepedenciesService:      services.NewDependenciesService(basicRepos, *dependencyParser, objectStorageService, serviceConfig),
+		SeedService:             services.NewSeedService(basicRepos),
+		ScheduleService:         services.NewScheduleService(basicRepos, applicationService),
+		RemediationService:      services.NewRemediationService(basicRepos),
+		SeverityOverrideService: services.NewSeverityOverrideService(basicRepos),
+		RetentionService: services.NewRetentionService(basicRepos, objectStorageService, services.RetentionConfig{
+			ScanRetention:   time.Duration(cfg.SCAN_RETENTION_DAYS) * 24 * time.Hour,
+			KeepScansPerApp: cfg.SCAN_RETENTION_KEEP_PER_APP,
+			AuditRetention:  time.Duration(cfg.AUDIT_RETENTION_DAYS) * 24 * time.Hour,
+			Interval:        cfg.RETENTION_CLEANUP_INTERVAL,
+		}),
 	}
 }
 
 type Services struct {
 	// GithubApiService     usecase.GitHubAPIInterface     // GitHub API service
 	// MessagingService     usecase.MessagingInterface     // Messaging service (e.g., Telegram)
-	ObjectStorageService usecase.ObjectStorageInterface // Minio object storage service
-	ApplicationService   services.ApplicationInterface  // Application management service
-	DepedenciesService   services.DependenciesInterface // Scan service for dependency scanning
+	ObjectStorageService    usecase.ObjectStorageInterface     // Minio object storage service
+	ApplicationService      services.ApplicationInterface      // Application management service
+	DepedenciesService      services.DependenciesInterface     // Scan service for dependency scanning
+	RetentionService        services.RetentionInterface        // Periodic cleanup of scan results and audit entries
+	SeedService             services.SeedInterface             // Default runtimes and frameworks
+	ScheduleService         services.ScheduleInterface         // Cron schedules of application scans
+	RemediationService      services.RemediationInterface      // Remediation tickets of findings
+	SeverityOverrideService services.SeverityOverrideInterface // Custom severities of matching vulnerabilities
 }
 
 type Repositories struct {
@@ -143,4 +330,10 @@ type Repositories struct {
 	Runtime          repository.RuntimeRepository           // Manages runtimes
 	Framework        repository.FrameworkRepository         // Manages frameworks
 	AuditTrail       repository.AuditTrailRepository        // Audit trail tracking
+	ScanResult       repository.ScanResultRepository        // Persisted scan results
+	Tag              repository.TagRepository               // Application tags
+	ScanSchedule     repository.ScanScheduleRepository      // Cron schedules of application scans
+	Remediation      repository.RemediationRepository       // Remediation tickets of findings
+	SeverityOverride repository.SeverityOverrideRepository  // Custom severities of matching vulnerabilities
+	UnitOfWork       repository.UnitOfWork                  // Transaction boundary across repositories
 }
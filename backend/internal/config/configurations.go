@@ -1,7 +1,11 @@
 package config
 
 import (
+	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -11,6 +15,10 @@ type Configurations struct {
 	PORT string
 	MODE string
 
+	// Logging configuration
+	LOG_LEVEL  string // debug, info, warn or error
+	LOG_FORMAT string // json or text
+
 	// Database configuration
 	DB_HOST     string
 	DB_PORT     string
@@ -25,12 +33,86 @@ type Configurations struct {
 	MINIO_SECRET_KEY  string
 	MINIO_BUCKET_NAME string
 	MINIO_USE_SSL     bool
+	MINIO_KEY_PREFIX  string // Prepended to every object key, e.g. the environment sharing the bucket
 
 	// GitHub API configuration
-	GITHUB_TOKEN string
+	GITHUB_TOKEN            string
+	GITHUB_BRANCH_FALLBACKS []string // Branches tried in order when a repository's default branch cannot be fetched
+	GITHUB_COMMIT_LIMIT     int      // Latest commits read per repository when dependency metadata is fetched
+	// Count the collaborators of dependency repositories for their maintenance risk; needs push access to them
+	GITHUB_COUNT_COLLABORATORS bool
+	GITHUB_API_URL             string // REST API root, e.g. https://github.example.com/api/v3 for GitHub Enterprise Server
+	GITHUB_GRAPHQL_URL         string // GraphQL endpoint; derived from GITHUB_API_URL when empty
+
+	// GitHub App authentication; takes precedence over GITHUB_TOKEN when GITHUB_APP_ID is set
+	GITHUB_APP_ID               int
+	GITHUB_APP_INSTALLATION_ID  int
+	GITHUB_APP_PRIVATE_KEY_PATH string // PEM private key downloaded from the app's settings
+
+	// Repository URL validation
+	TRUSTED_REPOSITORY_HOSTS []string // Code hosts repository URLs may point to; internal addresses are always rejected
+
+	// Outbound HTTP configuration (OSV and GitHub requests)
+	OUTBOUND_PROXY_URL string // Proxy for outbound requests; empty falls back to HTTP_PROXY/HTTPS_PROXY/NO_PROXY
+	OUTBOUND_CA_BUNDLE string // PEM file of extra trusted CAs, e.g. for a TLS-intercepting proxy
+
+	// Authentication configuration
+	JWT_SECRET     string   // HS256 secret for API bearer tokens; empty disables authentication
+	JWT_ISSUER     string   // Required token issuer; empty accepts any issuer
+	ADMIN_SUBJECTS []string // Token subjects allowed to call the /api/admin endpoints
+
+	// CORS configuration
+	CORS_ALLOWED_ORIGINS   []string // Browser origins allowed to call the API; "*" allows any, empty allows none
+	CORS_ALLOWED_METHODS   []string // Methods cross-origin requests may use
+	CORS_ALLOWED_HEADERS   []string // Request headers cross-origin requests may send
+	CORS_ALLOW_CREDENTIALS bool     // Let allowed origins send cookies and Authorization headers; not with "*"
+
+	// Rate limiting configuration
+	RATE_LIMIT_PER_MINUTE int // Requests per minute per user (or client IP) on scan/upload endpoints; 0 disables limiting
+	RATE_LIMIT_BURST      int // Requests a client may make at once before being limited
+
+	// Request size configuration
+	MAX_REQUEST_BODY_MB int // Largest manifest upload body in MiB, rejected with 413 before it is read; 0 disables the limit
+
+	// Idempotency configuration
+	IDEMPOTENCY_KEY_TTL time.Duration // How long responses to Idempotency-Key requests are replayed; 0 disables the header
 
 	// Messaging service configuration
 	MESSAGING_SERVICE_URL string
+
+	// Scan scope configuration
+	ENABLED_RUNTIMES         []string // Runtimes accepted for upload and scanning; empty means all
+	DEPENDENCY_DENYLIST      []string // Regex patterns of dependency names never sent to OSV
+	EXCLUDE_DEV_DEPENDENCIES bool     // Drop test/development-only dependencies (Maven test scope, devDependencies, ...)
+	GO_INCLUDE_INDIRECT      bool     // Scan the "// indirect" requirements of go.mod files as transitive dependencies
+	MAX_DEPENDENCIES_PER_APP int      // Manifests declaring more dependencies are rejected with 422
+	DEPENDENCY_WORKERS       int      // Dependencies looked up on GitHub at once when applications or dependencies are added
+	AUTO_SCAN_ON_ADD         bool     // Scan new applications once their dependencies are processed, unless the request says otherwise
+
+	// Vulnerability database configuration
+	VULNERABILITY_SOURCES       []string      // Databases queried in priority order: osv, github
+	VULNERABILITY_SOURCES_MERGE bool          // Query every source and merge the results instead of falling back in order
+	DEPENDENCY_SCAN_TIMEOUT     time.Duration // Deadline of one dependency's vulnerability check within a scan
+	SCAN_CONCURRENCY            int           // Dependencies one scan checks at once; 0 uses the default
+	SCAN_FAIL_CLOSED            bool          // Fail scans in which some dependencies could not be checked
+	SCAN_UNSUPPORTED_AS_WARNING bool          // Only warn about dependencies no vulnerability database covers, even when failing closed
+	SCAN_DECLARED_RANGES        bool          // Check manifest version ranges against OSV as a whole instead of only their lower bound
+	OSV_REQUESTS_PER_SECOND     int           // Combined rate of OSV requests across all scans; 0 disables the limit
+	MAVEN_REPOSITORY_URL        string        // Maven repository BOMs imported by pom.xml files are fetched from; empty means Maven Central
+
+	// SBOM metadata
+	SBOM_TOOL_VENDOR string // Vendor credited as the tool in generated SBOMs; empty keeps the default
+	SBOM_TOOL_NAME   string // Tool name in generated SBOMs; its version is the running build's
+
+	// Severity presentation
+	SEVERITY_LABELS []string // severity=label overrides, e.g. medium=Moderate
+	SEVERITY_COLORS []string // severity=color overrides, e.g. critical=#ff0000
+
+	// Retention configuration
+	SCAN_RETENTION_DAYS         int           // Finished scans older than this are pruned; 0 keeps them forever
+	SCAN_RETENTION_KEEP_PER_APP int           // Newest scans per application kept regardless of age
+	AUDIT_RETENTION_DAYS        int           // Non security-relevant audit entries older than this are pruned; 0 keeps them forever
+	RETENTION_CLEANUP_INTERVAL  time.Duration // How often the cleanup job runs
 }
 
 func LoadConfigurations() *Configurations {
@@ -46,32 +128,178 @@ func LoadConfigurations() *Configurations {
 		PORT: os.Getenv("PORT"),
 		MODE: os.Getenv("MODE"),
 
+		// Logging configuration
+		LOG_LEVEL:  getEnvWithDefault("LOG_LEVEL", "info"),
+		LOG_FORMAT: getEnvWithDefault("LOG_FORMAT", "json"),
+
 		// Database configuration
 		DB_HOST:     getEnvWithDefault("DB_HOST", "localhost"),
 		DB_PORT:     getEnvWithDefault("DB_PORT", "5432"),
 		DB_USER:     getEnvWithDefault("DB_USER", "postgres"),
-		DB_PASSWORD: getEnvWithDefault("DB_PASSWORD", ""),
+		DB_PASSWORD: getSecretWithDefault("DB_PASSWORD", ""),
 		DB_NAME:     getEnvWithDefault("DB_NAME", "go_messaging"),
 		DB_SSLMODE:  getEnvWithDefault("DB_SSLMODE", "disable"),
 
 		// Object Storage (Minio) configuration
 		MINIO_ENDPOINT:    getEnvWithDefault("STORAGE_ENDPOINT", "localhost:9000"),
 		MINIO_ACCESS_KEY:  getEnvWithDefault("STORAGE_ACCESS_KEY", "minioadmin"),
-		MINIO_SECRET_KEY:  getEnvWithDefault("STORAGE_SECRET_KEY", "minioadmin"),
+		MINIO_SECRET_KEY:  getSecretWithDefault("STORAGE_SECRET_KEY", "minioadmin"),
 		MINIO_BUCKET_NAME: getEnvWithDefault("BUCKET_NAME", "silent-patch-detector"),
 		MINIO_USE_SSL:     getEnvWithDefault("STRORAGE_SSL", "false") == "true",
+		MINIO_KEY_PREFIX:  getEnvWithDefault("STORAGE_KEY_PREFIX", ""),
 
 		// GitHub API configuration
-		GITHUB_TOKEN: getEnvWithDefault("GITHUB_TOKEN", ""),
+		GITHUB_TOKEN:               getSecretWithDefault("GITHUB_TOKEN", ""),
+		GITHUB_BRANCH_FALLBACKS:    splitEnvList(getEnvWithDefault("GITHUB_BRANCH_FALLBACKS", "main,master")),
+		GITHUB_COMMIT_LIMIT:        getEnvIntWithDefault("GITHUB_COMMIT_LIMIT", 10),
+		GITHUB_COUNT_COLLABORATORS: getEnvWithDefault("GITHUB_COUNT_COLLABORATORS", "false") == "true",
+		GITHUB_API_URL:             getEnvWithDefault("GITHUB_API_URL", ""),
+		GITHUB_GRAPHQL_URL:         getEnvWithDefault("GITHUB_GRAPHQL_URL", ""),
+
+		// GitHub App authentication
+		GITHUB_APP_ID:               getEnvIntWithDefault("GITHUB_APP_ID", 0),
+		GITHUB_APP_INSTALLATION_ID:  getEnvIntWithDefault("GITHUB_APP_INSTALLATION_ID", 0),
+		GITHUB_APP_PRIVATE_KEY_PATH: getEnvWithDefault("GITHUB_APP_PRIVATE_KEY_PATH", ""),
+
+		// Repository URL validation
+		TRUSTED_REPOSITORY_HOSTS: splitEnvList(getEnvWithDefault("TRUSTED_REPOSITORY_HOSTS", "github.com,gitlab.com,bitbucket.org")),
+
+		// Outbound HTTP configuration
+		OUTBOUND_PROXY_URL: getEnvWithDefault("OUTBOUND_PROXY_URL", ""),
+		OUTBOUND_CA_BUNDLE: getEnvWithDefault("OUTBOUND_CA_BUNDLE", ""),
+
+		// Authentication configuration
+		JWT_SECRET:     getSecretWithDefault("JWT_SECRET", ""),
+		JWT_ISSUER:     getEnvWithDefault("JWT_ISSUER", ""),
+		ADMIN_SUBJECTS: splitEnvList(getEnvWithDefault("ADMIN_SUBJECTS", "")),
+
+		// CORS configuration
+		CORS_ALLOWED_ORIGINS:   splitEnvList(getEnvWithDefault("CORS_ALLOWED_ORIGINS", "")),
+		CORS_ALLOWED_METHODS:   splitEnvList(getEnvWithDefault("CORS_ALLOWED_METHODS", "GET,POST,PUT,PATCH,DELETE")),
+		CORS_ALLOWED_HEADERS:   splitEnvList(getEnvWithDefault("CORS_ALLOWED_HEADERS", "Origin,Content-Type,Accept,Authorization,Idempotency-Key")),
+		CORS_ALLOW_CREDENTIALS: getEnvWithDefault("CORS_ALLOW_CREDENTIALS", "false") == "true",
+
+		// Rate limiting configuration
+		RATE_LIMIT_PER_MINUTE: getEnvIntWithDefault("RATE_LIMIT_PER_MINUTE", 30),
+		RATE_LIMIT_BURST:      getEnvIntWithDefault("RATE_LIMIT_BURST", 10),
+
+		// Request size configuration
+		MAX_REQUEST_BODY_MB: getEnvIntWithDefault("MAX_REQUEST_BODY_MB", 8),
+
+		// Idempotency configuration
+		IDEMPOTENCY_KEY_TTL: getEnvDurationWithDefault("IDEMPOTENCY_KEY_TTL", 24*time.Hour),
 
 		// Messaging service configuration
 		MESSAGING_SERVICE_URL: getEnvWithDefault("MESSAGING_SERVICE_URL", ""),
+
+		// Scan scope configuration
+		ENABLED_RUNTIMES:         splitEnvList(getEnvWithDefault("ENABLED_RUNTIMES", "")),
+		DEPENDENCY_DENYLIST:      splitEnvList(getEnvWithDefault("DEPENDENCY_DENYLIST", "")),
+		EXCLUDE_DEV_DEPENDENCIES: getEnvWithDefault("EXCLUDE_DEV_DEPENDENCIES", "false") == "true",
+		GO_INCLUDE_INDIRECT:      getEnvWithDefault("GO_INCLUDE_INDIRECT", "false") == "true",
+		MAX_DEPENDENCIES_PER_APP: getEnvIntWithDefault("MAX_DEPENDENCIES_PER_APP", 5000),
+		DEPENDENCY_WORKERS:       getEnvIntWithDefault("DEPENDENCY_WORKERS", 10),
+		AUTO_SCAN_ON_ADD:         getEnvWithDefault("AUTO_SCAN_ON_ADD", "false") == "true",
+
+		// Vulnerability database configuration
+		VULNERABILITY_SOURCES:       splitEnvList(getEnvWithDefault("VULNERABILITY_SOURCES", "osv")),
+		VULNERABILITY_SOURCES_MERGE: getEnvWithDefault("VULNERABILITY_SOURCES_MERGE", "false") == "true",
+		DEPENDENCY_SCAN_TIMEOUT:     getEnvDurationWithDefault("DEPENDENCY_SCAN_TIMEOUT", 15*time.Second),
+		SCAN_CONCURRENCY:            getEnvIntWithDefault("SCAN_CONCURRENCY", 10),
+		SCAN_FAIL_CLOSED:            getEnvWithDefault("SCAN_FAIL_CLOSED", "false") == "true",
+		SCAN_UNSUPPORTED_AS_WARNING: getEnvWithDefault("SCAN_UNSUPPORTED_AS_WARNING", "true") == "true",
+		SCAN_DECLARED_RANGES:        getEnvWithDefault("SCAN_DECLARED_RANGES", "false") == "true",
+		OSV_REQUESTS_PER_SECOND:     getEnvIntWithDefault("OSV_REQUESTS_PER_SECOND", 10),
+		MAVEN_REPOSITORY_URL:        getEnvWithDefault("MAVEN_REPOSITORY_URL", ""),
+
+		// SBOM metadata
+		SBOM_TOOL_VENDOR: getEnvWithDefault("SBOM_TOOL_VENDOR", ""),
+		SBOM_TOOL_NAME:   getEnvWithDefault("SBOM_TOOL_NAME", ""),
+
+		// Severity presentation
+		SEVERITY_LABELS: splitEnvList(getEnvWithDefault("SEVERITY_LABELS", "")),
+		SEVERITY_COLORS: splitEnvList(getEnvWithDefault("SEVERITY_COLORS", "")),
+
+		// Retention configuration
+		SCAN_RETENTION_DAYS:         getEnvIntWithDefault("SCAN_RETENTION_DAYS", 90),
+		SCAN_RETENTION_KEEP_PER_APP: getEnvIntWithDefault("SCAN_RETENTION_KEEP_PER_APP", 10),
+		AUDIT_RETENTION_DAYS:        getEnvIntWithDefault("AUDIT_RETENTION_DAYS", 365),
+		RETENTION_CLEANUP_INTERVAL:  getEnvDurationWithDefault("RETENTION_CLEANUP_INTERVAL", 24*time.Hour),
 	}
 }
 
+// secretSettings are the sensitive environment variables that can instead be read from the file named by
+// <NAME>_FILE, the way Docker and Kubernetes mount secrets. The variable itself takes precedence when set.
+var secretSettings = []string{"DB_PASSWORD", "STORAGE_SECRET_KEY", "GITHUB_TOKEN", "JWT_SECRET"}
+
 func getEnvWithDefault(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
 	}
 	return defaultValue
 }
+
+// getSecretWithDefault reads a secret from the environment, or from the file named by key_FILE when the
+// variable is unset. An unreadable file falls back to the default; Validate reports it.
+func getSecretWithDefault(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	if path := os.Getenv(key + "_FILE"); path != "" {
+		value, err := readSecretFile(path)
+		if err != nil {
+			log.Printf("Cannot read %s_FILE: %v", key, err)
+			return defaultValue
+		}
+		return value
+	}
+	return defaultValue
+}
+
+// readSecretFile returns a secret file's content without the trailing newline editors and `echo` add
+func readSecretFile(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(content), "\r\n"), nil
+}
+
+// getEnvIntWithDefault reads a non-negative integer, falling back to the default when unset or invalid
+func getEnvIntWithDefault(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed < 0 {
+		log.Printf("Invalid %s=%q, using default %d", key, value, defaultValue)
+		return defaultValue
+	}
+	return parsed
+}
+
+// getEnvDurationWithDefault reads a positive Go duration (e.g. "12h"), falling back to the default when unset or invalid
+func getEnvDurationWithDefault(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil || parsed <= 0 {
+		log.Printf("Invalid %s=%q, using default %s", key, value, defaultValue)
+		return defaultValue
+	}
+	return parsed
+}
+
+// splitEnvList splits a comma-separated environment value into trimmed, non-empty items
+func splitEnvList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -1,11 +1,14 @@
 package config
 
 import (
+	"context"
 	"elang-backend/internal/entity"
+	"elang-backend/internal/model/dto"
+	"elang-backend/internal/repository"
+	"elang-backend/internal/services"
 	"fmt"
 	"log"
 	"os"
-	"strings"
 	"time"
 
 	"gorm.io/driver/postgres"
@@ -45,6 +48,8 @@ func NewDatabase(config Config) (*Database, error) {
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
 		// Logger: gormLogger,
 		Logger: logger.Default.LogMode(logger.Silent),
+		// CreatedAt/UpdatedAt are filled in UTC, like every other timestamp the service writes
+		NowFunc: func() time.Time { return time.Now().UTC() },
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
@@ -78,11 +83,16 @@ func (d *Database) AutoMigrate() error {
 	if err != nil {
 		return fmt.Errorf("failed to migrate core entity: %w", err)
 	}
+	if err := d.migrateFrameworkRuntimes(); err != nil {
+		return err
+	}
 
 	// Additional entity migration
 	err = d.Connection.AutoMigrate(
 		&entity.AppDependency{},
 		&entity.DependencyVersion{},
+		&entity.Tag{},
+		&entity.AppTag{},
 	)
 	if err != nil {
 		return fmt.Errorf("failed to migrate additional entity: %w", err)
@@ -94,88 +104,74 @@ func (d *Database) AutoMigrate() error {
 	err = d.Connection.AutoMigrate(
 		&entity.MonitoringJob{},
 		&entity.AuditTrail{},
+		&entity.ScanResult{},
+		&entity.Finding{},
+		&entity.ScanSchedule{},
+		&entity.Remediation{},
+		&entity.SeverityOverride{},
 	)
 	if err != nil {
 		return fmt.Errorf("failed to migrate enhanced entity: %w", err)
 	}
+	if err := d.migrateFindingDependencyNames(); err != nil {
+		return err
+	}
 	log.Println("✅ Enhanced entity migrated successfully")
 
 	log.Println("✅ Database migration completed successfully")
 	return nil
 }
 
-// Seed seeds initial data into the database
-func (d *Database) Seed() {
-	runTimeTypes := []entity.Runtime{
-		{Name: "Node.js"},
-		{Name: "Python"},
-		{Name: "Java"},
-		{Name: "Go"},
-		{Name: "Ruby"},
-		{Name: "PHP"},
-		{Name: "DotNet"},
-		{Name: "Gradle"},
+// migrateFrameworkRuntimes moves the single runtime frameworks used to have into the framework_runtime join
+// table, so a framework such as Spring Boot can be used with several runtimes
+func (d *Database) migrateFrameworkRuntimes() error {
+	migrator := d.Connection.Migrator()
+	if !migrator.HasColumn("framework", "runtime_id") {
+		return nil
 	}
-
-	// Seed Runtime Types and build a map of name to ID
-	runtimeIDMap := make(map[string]int)
-	for _, rt := range runTimeTypes {
-		var existing entity.Runtime
-		result := d.Connection.Where("name = ?", rt.Name).First(&existing)
-		if result.Error != nil {
-			if result.Error == gorm.ErrRecordNotFound {
-				d.Connection.Create(&rt)
-				log.Printf("Seeded runtime: %s\n", rt.Name)
-				d.Connection.Where("name = ?", rt.Name).First(&existing)
-			}
-			// else log error
-		} else {
-			log.Printf("Runtime %s already exists, skipping seeding.\n", rt.Name)
-		}
-		runtimeIDMap[rt.Name] = existing.ID
+	err := d.Connection.Exec(`INSERT INTO framework_runtime (framework_id, runtime_id)
+		SELECT id, runtime_id FROM framework WHERE runtime_id IS NOT NULL
+		ON CONFLICT DO NOTHING`).Error
+	if err != nil {
+		return fmt.Errorf("failed to migrate framework runtimes: %w", err)
 	}
-
-	frameworks := []struct {
-		Name    string
-		Runtime string // runtime name, not ID
-	}{
-		{Name: "Express", Runtime: "Node.js"},
-		{Name: "Django", Runtime: "Python"},
-		{Name: "Spring", Runtime: "Java"},
-		{Name: "Gin", Runtime: "Go"},
-		{Name: "Rails", Runtime: "Ruby"},
-		{Name: "Laravel", Runtime: "PHP"},
-		{Name: "ASP.NET", Runtime: "DotNet"},
-		{Name: "Flask", Runtime: "Python"},
-		{Name: "React", Runtime: "Node.js"},
-		{Name: "Vue.js", Runtime: "Node.js"},
-		{Name: "Angular", Runtime: "Node.js"},
-		{Name: "Spring Boot", Runtime: "Java"},
-		{Name: "Echo", Runtime: "Go"},
-		{Name: "Symfony", Runtime: "PHP"},
-		{Name: "Ruby on Rails", Runtime: "Ruby"},
-		{Name: "CodeIgniter", Runtime: "PHP"},
-		{Name: "Native", Runtime: "Gradle"},
+	if err := migrator.DropColumn("framework", "runtime_id"); err != nil {
+		return fmt.Errorf("failed to drop framework.runtime_id: %w", err)
 	}
+	log.Println("✅ Framework runtimes moved to framework_runtime")
+	return nil
+}
 
-	// Seed Frameworks (no runtime association, case-insensitive check)
-	for _, fw := range frameworks {
-		name := strings.TrimSpace(fw.Name)
-		var existing entity.Framework
-		result := d.Connection.Where("LOWER(name) = ?", strings.ToLower(name)).First(&existing)
+// migrateFindingDependencyNames renames the dependency of findings and remediations recorded as "<name>:<repo>",
+// as application scans once reported them, to the dependency name scans report now, so they are matched with
+// the findings of later scans. Rows already using the name are left as they are.
+func (d *Database) migrateFindingDependencyNames() error {
+	for _, table := range []string{"finding", "remediation"} {
+		result := d.Connection.Exec(`UPDATE ` + table + ` SET dependency = dependencies.name FROM dependencies
+			WHERE dependencies.repo <> '' AND ` + table + `.dependency = dependencies.name || ':' || dependencies.repo`)
 		if result.Error != nil {
-			if result.Error == gorm.ErrRecordNotFound {
-				framework := entity.Framework{Name: name}
-				d.Connection.Create(&framework)
-				log.Printf("Seeded framework: %s\n", name)
-			} else {
-				log.Printf("Error checking framework %s: %v\n", name, result.Error)
-			}
-		} else {
-			log.Printf("Framework %s already exists, skipping seeding.\n", name)
+			return fmt.Errorf("failed to migrate %s dependency names: %w", table, result.Error)
+		}
+		if result.RowsAffected > 0 {
+			log.Printf("✅ Renamed the dependency of %d %s rows to the dependency name", result.RowsAffected, table)
 		}
 	}
-	log.Println("✅ Database seeding completed successfully.")
+	return nil
+}
+
+// Seed creates the seed runtimes and frameworks missing from the database. It can be re-run at any time
+// through POST /api/admin/seed.
+func (d *Database) Seed() {
+	seeder := services.NewSeedService(dto.BasicRepositories{
+		RunTimeRepository:   repository.NewRuntimeRepository(d.Connection),
+		FrameWorkRepository: repository.NewFrameworkRepository(d.Connection),
+	})
+	result, err := seeder.Seed(context.Background())
+	if err != nil {
+		log.Printf("❌ Database seeding failed: %v", err)
+		return
+	}
+	log.Printf("✅ Database seeding completed: %d runtimes and %d frameworks created", len(result.CreatedRuntimes), len(result.CreatedFrameworks))
 }
 
 // Ping tests the database connection
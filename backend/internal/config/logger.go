@@ -1,23 +1,30 @@
 package config
 
 import (
-	"os"
-	"strconv"
-
-	"github.com/sirupsen/logrus"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
 )
 
-func NewLogger() *logrus.Logger {
-	logger := logrus.New()
+// logFormats are the LOG_FORMAT values NewLogger accepts
+var logFormats = []string{"json", "text"}
 
-	levelStr := os.Getenv("LOG_LEVEL") // e.g. "4" or "info"
-	levelInt, err := strconv.Atoi(levelStr)
-	if err != nil {
-		levelInt = int(logrus.InfoLevel) // default
+// NewLogger builds the slog logger selected by LOG_LEVEL (debug, info, warn or error, optionally with an
+// offset such as info+2) and LOG_FORMAT (json or text), writing to w
+func NewLogger(level, format string, w io.Writer) (*slog.Logger, error) {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("%q is not one of debug, info, warn or error", level)
 	}
+	options := &slog.HandlerOptions{Level: lvl}
 
-	logger.SetLevel(logrus.Level(levelInt))
-	logger.SetFormatter(&logrus.JSONFormatter{})
-
-	return logger
+	switch strings.ToLower(format) {
+	case "json":
+		return slog.New(slog.NewJSONHandler(w, options)), nil
+	case "text":
+		return slog.New(slog.NewTextHandler(w, options)), nil
+	default:
+		return nil, fmt.Errorf("%q is not one of %s", format, strings.Join(logFormats, ", "))
+	}
 }
@@ -0,0 +1,242 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// postgresSSLModes are the sslmode values accepted by PostgreSQL clients
+var postgresSSLModes = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+
+// typedSettings are the environment variables parsed into non-string fields. The loader falls back to the
+// default on an unparsable value, so Validate checks the raw value to report typos instead of hiding them.
+var typedSettings = map[string]func(string) error{
+	"STRORAGE_SSL":                parseBool,
+	"EXCLUDE_DEV_DEPENDENCIES":    parseBool,
+	"GO_INCLUDE_INDIRECT":         parseBool,
+	"VULNERABILITY_SOURCES_MERGE": parseBool,
+	"SCAN_FAIL_CLOSED":            parseBool,
+	"SCAN_UNSUPPORTED_AS_WARNING": parseBool,
+	"SCAN_DECLARED_RANGES":        parseBool,
+	"AUTO_SCAN_ON_ADD":            parseBool,
+	"GITHUB_COUNT_COLLABORATORS":  parseBool,
+	"CORS_ALLOW_CREDENTIALS":      parseBool,
+	"GITHUB_APP_ID":               parseNonNegativeInt,
+	"GITHUB_APP_INSTALLATION_ID":  parseNonNegativeInt,
+	"GITHUB_COMMIT_LIMIT":         parseNonNegativeInt,
+	"OSV_REQUESTS_PER_SECOND":     parseNonNegativeInt,
+	"SCAN_CONCURRENCY":            parseNonNegativeInt,
+	"RATE_LIMIT_PER_MINUTE":       parseNonNegativeInt,
+	"RATE_LIMIT_BURST":            parseNonNegativeInt,
+	"MAX_REQUEST_BODY_MB":         parseNonNegativeInt,
+	"MAX_DEPENDENCIES_PER_APP":    parseNonNegativeInt,
+	"DEPENDENCY_WORKERS":          parseNonNegativeInt,
+	"SCAN_RETENTION_DAYS":         parseNonNegativeInt,
+	"SCAN_RETENTION_KEEP_PER_APP": parseNonNegativeInt,
+	"AUDIT_RETENTION_DAYS":        parseNonNegativeInt,
+	"DEPENDENCY_SCAN_TIMEOUT":     parsePositiveDuration,
+	"RETENTION_CLEANUP_INTERVAL":  parsePositiveDuration,
+	"IDEMPOTENCY_KEY_TTL":         parseNonNegativeDuration,
+}
+
+// ConfigError lists every missing or invalid setting found by Validate
+type ConfigError struct {
+	Problems []string
+}
+
+func (e *ConfigError) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+// Validate checks that the required settings are present and well formed. It reports every problem at
+// once so a misconfigured deployment fails at startup with one actionable message rather than a panic
+// deep in initialization.
+func (c *Configurations) Validate() error {
+	var problems []string
+	required := func(name, value string) {
+		if strings.TrimSpace(value) == "" {
+			problems = append(problems, name+" is required")
+		}
+	}
+	check := func(name string, err error) {
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", name, err))
+		}
+	}
+
+	// Server
+	if c.PORT != "" {
+		check("PORT", parsePort(c.PORT))
+	}
+
+	// Logging
+	_, err := NewLogger(c.LOG_LEVEL, "json", io.Discard)
+	check("LOG_LEVEL", err)
+	_, err = NewLogger("info", c.LOG_FORMAT, io.Discard)
+	check("LOG_FORMAT", err)
+
+	// Database
+	required("DB_HOST", c.DB_HOST)
+	required("DB_USER", c.DB_USER)
+	required("DB_NAME", c.DB_NAME)
+	check("DB_PORT", parsePort(c.DB_PORT))
+	if !containsString(postgresSSLModes, c.DB_SSLMODE) {
+		problems = append(problems, fmt.Sprintf("DB_SSLMODE: %q is not one of %s", c.DB_SSLMODE, strings.Join(postgresSSLModes, ", ")))
+	}
+
+	// Secret files are only read when the variable itself is unset
+	for _, name := range secretSettings {
+		if path := os.Getenv(name + "_FILE"); path != "" && os.Getenv(name) == "" {
+			_, err := readSecretFile(path)
+			check(name+"_FILE", err)
+		}
+	}
+
+	// Object storage
+	required("STORAGE_ENDPOINT", c.MINIO_ENDPOINT)
+	required("STORAGE_ACCESS_KEY", c.MINIO_ACCESS_KEY)
+	required("STORAGE_SECRET_KEY", c.MINIO_SECRET_KEY)
+	required("BUCKET_NAME", c.MINIO_BUCKET_NAME)
+	if c.MINIO_ENDPOINT != "" {
+		check("STORAGE_ENDPOINT", parseHostPort(c.MINIO_ENDPOINT))
+	}
+
+	// GitHub App authentication needs all three settings once GITHUB_APP_ID is set
+	if c.GITHUB_APP_ID > 0 {
+		if c.GITHUB_APP_INSTALLATION_ID <= 0 {
+			problems = append(problems, "GITHUB_APP_INSTALLATION_ID is required when GITHUB_APP_ID is set")
+		}
+		required("GITHUB_APP_PRIVATE_KEY_PATH", c.GITHUB_APP_PRIVATE_KEY_PATH)
+	}
+
+	// URLs
+	if c.GITHUB_API_URL != "" {
+		check("GITHUB_API_URL", parseAbsoluteURL(c.GITHUB_API_URL))
+	}
+	if c.GITHUB_GRAPHQL_URL != "" {
+		check("GITHUB_GRAPHQL_URL", parseAbsoluteURL(c.GITHUB_GRAPHQL_URL))
+	}
+	if c.OUTBOUND_PROXY_URL != "" {
+		check("OUTBOUND_PROXY_URL", parseAbsoluteURL(c.OUTBOUND_PROXY_URL))
+	}
+	if c.MESSAGING_SERVICE_URL != "" {
+		check("MESSAGING_SERVICE_URL", parseAbsoluteURL(c.MESSAGING_SERVICE_URL))
+	}
+
+	// CORS origins are scheme://host[:port]; credentials cannot be offered to every origin
+	for _, origin := range c.CORS_ALLOWED_ORIGINS {
+		if origin != "*" {
+			check("CORS_ALLOWED_ORIGINS", parseOrigin(origin))
+		}
+	}
+	if c.CORS_ALLOW_CREDENTIALS && containsString(c.CORS_ALLOWED_ORIGINS, "*") {
+		problems = append(problems, `CORS_ALLOW_CREDENTIALS cannot be used with CORS_ALLOWED_ORIGINS="*"; list the origins instead`)
+	}
+
+	for _, name := range sortedKeys(typedSettings) {
+		if value := os.Getenv(name); value != "" {
+			check(name, typedSettings[name](value))
+		}
+	}
+
+	if len(problems) > 0 {
+		return &ConfigError{Problems: problems}
+	}
+	return nil
+}
+
+func parsePort(value string) error {
+	port, err := strconv.Atoi(value)
+	if err != nil || port < 1 || port > 65535 {
+		return fmt.Errorf("%q is not a port number between 1 and 65535", value)
+	}
+	return nil
+}
+
+// parseHostPort accepts the host[:port] form MinIO expects, without a scheme
+func parseHostPort(value string) error {
+	if strings.Contains(value, "://") {
+		return fmt.Errorf("%q must be host[:port] without a scheme; use STRORAGE_SSL for https", value)
+	}
+	if !strings.Contains(value, ":") {
+		return nil
+	}
+	host, port, err := net.SplitHostPort(value)
+	if err != nil {
+		return fmt.Errorf("%q is not a valid host[:port]", value)
+	}
+	if host == "" {
+		return fmt.Errorf("%q has no host", value)
+	}
+	return parsePort(port)
+}
+
+func parseAbsoluteURL(value string) error {
+	parsed, err := url.Parse(value)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return fmt.Errorf("%q is not an absolute URL", value)
+	}
+	return nil
+}
+
+// parseOrigin accepts a browser origin: a scheme and host with an optional port, and no path
+func parseOrigin(value string) error {
+	parsed, err := url.Parse(value)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" || strings.TrimSuffix(parsed.Path, "/") != "" || parsed.RawQuery != "" {
+		return fmt.Errorf("%q is not an origin such as https://app.example.com", value)
+	}
+	return nil
+}
+
+func parseBool(value string) error {
+	if value != "true" && value != "false" {
+		return fmt.Errorf("%q must be true or false", value)
+	}
+	return nil
+}
+
+func parseNonNegativeInt(value string) error {
+	if parsed, err := strconv.Atoi(value); err != nil || parsed < 0 {
+		return fmt.Errorf("%q is not a non-negative integer", value)
+	}
+	return nil
+}
+
+func parsePositiveDuration(value string) error {
+	if parsed, err := time.ParseDuration(value); err != nil || parsed <= 0 {
+		return fmt.Errorf("%q is not a positive duration such as 30s or 12h", value)
+	}
+	return nil
+}
+
+func parseNonNegativeDuration(value string) error {
+	if parsed, err := time.ParseDuration(value); err != nil || parsed < 0 {
+		return fmt.Errorf("%q is not a duration such as 30s or 12h, or 0 to disable", value)
+	}
+	return nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(m map[string]func(string) error) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
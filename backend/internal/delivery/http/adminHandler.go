@@ -0,0 +1,38 @@
+package http
+
+import (
+	"elang-backend/internal/model/responses"
+	"elang-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminHandler struct {
+	seedService services.SeedInterface
+}
+
+func NewAdminHandler(seedService services.SeedInterface) *AdminHandler {
+	return &AdminHandler{
+		seedService: seedService,
+	}
+}
+
+// Seed handles re-running the runtime and framework seed
+func (h *AdminHandler) Seed(c *gin.Context) {
+	resp, err := h.seedService.Seed(c.Request.Context())
+	if err != nil {
+		responses.JSONErrorResponse(c, statusCodeFromError(err), "failed to seed database: "+err.Error(), nil)
+		return
+	}
+	responses.JSONSuccessResponse(c, 200, "database seeded", resp)
+}
+
+// SeedStatus handles listing the runtimes and frameworks against the seed definition
+func (h *AdminHandler) SeedStatus(c *gin.Context) {
+	resp, err := h.seedService.SeedStatus(c.Request.Context())
+	if err != nil {
+		responses.JSONErrorResponse(c, statusCodeFromError(err), "failed to get seed status: "+err.Error(), nil)
+		return
+	}
+	responses.JSONSuccessResponse(c, 200, "seed status", resp)
+}
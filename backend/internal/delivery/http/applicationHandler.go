@@ -1,9 +1,14 @@
 package http
 
 import (
+	"context"
+	"elang-backend/internal/helper"
 	"elang-backend/internal/model"
 	"elang-backend/internal/model/responses"
 	"elang-backend/internal/services"
+	"encoding/json"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -19,51 +24,94 @@ func NewApplicationHandler(appService services.ApplicationInterface) *Applicatio
 }
 
 // Add methods to handle application-related requests
+// Accepts either a multipart upload or a JSON body with base64-encoded file content
 func (h *ApplicationHandler) AddApplication(c *gin.Context) {
-	var req model.AddApplicationRequest
-	if err := c.ShouldBind(&req); err != nil {
-		c.JSON(400, gin.H{"error": err.Error()})
-		return
-	}
+	var (
+		req              model.AddApplicationRequest
+		fileName         string
+		content          string
+		gradleProperties string // base64 in JSON requests
+	)
 
-	file, fileHeader, err := c.Request.FormFile("file")
-	if err != nil {
-		responses.JSONErrorResponse(c, 400, "failed to get file: "+err.Error(), nil)
-		return
+	if isJSONRequest(c) {
+		var body model.AddApplicationJSONRequest
+		if status, err := bindManifestJSON(c, &body); err != nil {
+			responses.JSONErrorResponse(c, status, "invalid request: "+err.Error(), nil)
+			return
+		}
+		decoded, status, err := decodeManifestContent(body.ContentBase64)
+		if err != nil {
+			responses.JSONErrorResponse(c, status, err.Error(), nil)
+			return
+		}
+		req = model.AddApplicationRequest{
+			AppName:     body.AppName,
+			RuntimeType: body.Runtime,
+			Framework:   body.Framework,
+			Description: body.Description,
+			AutoScan:    body.AutoScan,
+		}
+		fileName, content, gradleProperties = body.FileName, decoded, body.GradlePropertiesBase64
+	} else {
+		if err := c.ShouldBind(&req); err != nil {
+			c.JSON(bodyErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+
+		file, fileHeader, err := c.Request.FormFile("file")
+		if err != nil {
+			responses.JSONErrorResponse(c, bodyErrorStatus(err), "failed to get file: "+err.Error(), nil)
+			return
+		}
+		defer file.Close()
+
+		fileBytes := make([]byte, fileHeader.Size)
+		_, err = file.Read(fileBytes)
+		if err != nil {
+			responses.JSONErrorResponse(c, 500, "failed to read file: "+err.Error(), nil)
+			return
+		}
+		fileName, content = fileHeader.Filename, string(fileBytes)
 	}
-	defer file.Close()
 
-	fileBytes := make([]byte, fileHeader.Size)
-	_, err = file.Read(fileBytes)
+	properties, status, err := readGradleProperties(c, gradleProperties)
 	if err != nil {
-		responses.JSONErrorResponse(c, 500, "failed to read file: "+err.Error(), nil)
+		responses.JSONErrorResponse(c, status, err.Error(), nil)
 		return
 	}
 
-	ctx := c.Request.Context()
+	ctx := helper.WithGradlePropertiesFile(c.Request.Context(), properties)
+	// ?include_indirect=true also registers the "// indirect" requirements of a go.mod
+	if include, err := strconv.ParseBool(c.Query("include_indirect")); err == nil {
+		ctx = helper.WithGoIndirectDependencies(ctx, include)
+	}
 	result, err := h.applicationService.AddApplication(
 		ctx,
 		req.AppName,
 		req.RuntimeType,
 		req.Framework,
 		req.Description,
-		fileHeader.Filename,
-		string(fileBytes),
+		fileName,
+		content,
+		req.AutoScan,
 	)
 	if err != nil {
-		responses.JSONErrorResponse(c, 500, "failed to add application: "+err.Error(), nil)
+		responses.JSONErrorResponse(c, statusCodeFromError(err), "failed to add application: "+err.Error(), nil)
 		return
 	}
 
 	responses.JSONSuccessResponse(c, 200, "application added successfully", result)
 }
 
+// addApplicationDependencyRequest is the body of POST /api/applications/add/dependencies
+type addApplicationDependencyRequest struct {
+	AppID        string                        `json:"app_id" binding:"required"`
+	Dependencies []model.DependencyInfoRequest `json:"dependencies" binding:"required,dive,required"`
+}
+
 // AddApplicationDependency handles adding new dependencies to an existing application (batch supported)
 func (h *ApplicationHandler) AddApplicationDependency(c *gin.Context) {
-	var req struct {
-		AppID        string                        `json:"app_id" binding:"required"`
-		Dependencies []model.DependencyInfoRequest `json:"dependencies" binding:"required,dive,required"`
-	}
+	var req addApplicationDependencyRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		responses.JSONErrorResponse(c, 400, "invalid request: "+err.Error(), nil)
 		return
@@ -77,6 +125,50 @@ func (h *ApplicationHandler) AddApplicationDependency(c *gin.Context) {
 	responses.JSONSuccessResponse(c, 200, "dependencies processed", resp)
 }
 
+// UpdateApplication handles changing an application's name, description, runtime or framework
+func (h *ApplicationHandler) UpdateApplication(c *gin.Context) {
+	appUID := c.Param("app_id")
+	if appUID == "" {
+		responses.JSONErrorResponse(c, 400, "missing app_id parameter", nil)
+		return
+	}
+	var req model.UpdateApplicationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responses.JSONErrorResponse(c, 400, "invalid request: "+err.Error(), nil)
+		return
+	}
+
+	ctx := c.Request.Context()
+	resp, err := h.applicationService.UpdateApplication(ctx, appUID, &req)
+	if err != nil {
+		responses.JSONErrorResponse(c, statusCodeFromError(err), "failed to update application: "+err.Error(), nil)
+		return
+	}
+	responses.JSONSuccessResponse(c, 200, "application updated", resp)
+}
+
+// CloneApplication handles creating a new application from an existing application's dependencies
+func (h *ApplicationHandler) CloneApplication(c *gin.Context) {
+	appUID := c.Param("app_id")
+	if appUID == "" {
+		responses.JSONErrorResponse(c, 400, "missing app_id parameter", nil)
+		return
+	}
+	var req model.CloneApplicationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responses.JSONErrorResponse(c, 400, "invalid request: "+err.Error(), nil)
+		return
+	}
+
+	ctx := c.Request.Context()
+	resp, err := h.applicationService.CloneApplication(ctx, appUID, req.AppName)
+	if err != nil {
+		responses.JSONErrorResponse(c, statusCodeFromError(err), "failed to clone application: "+err.Error(), nil)
+		return
+	}
+	responses.JSONSuccessResponse(c, 201, "application cloned", resp)
+}
+
 // UpdateApplicationDependency handles batch updates to application dependencies (version, status, GitHub URL)
 func (h *ApplicationHandler) UpdateApplicationDependency(c *gin.Context) {
 	var req model.UpdateApplicationDependencyRequest
@@ -95,12 +187,15 @@ func (h *ApplicationHandler) UpdateApplicationDependency(c *gin.Context) {
 	responses.JSONSuccessResponse(c, 200, "dependencies updated", resp)
 }
 
+// removeApplicationDependencyRequest is the body of PATCH /api/applications/remove/dependencies
+type removeApplicationDependencyRequest struct {
+	AppID         string   `json:"app_id" binding:"required"`
+	DependencyIDs []string `json:"dependencies" binding:"required,dive,required"`
+}
+
 // RemoveApplicationDependency handles batch removal of dependencies from an application
 func (h *ApplicationHandler) RemoveApplicationDependency(c *gin.Context) {
-	var req struct {
-		AppID         string   `json:"app_id" binding:"required"`
-		DependencyIDs []string `json:"dependencies" binding:"required,dive,required"`
-	}
+	var req removeApplicationDependencyRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		responses.JSONErrorResponse(c, 400, "invalid request: "+err.Error(), nil)
 		return
@@ -162,17 +257,205 @@ func (h *ApplicationHandler) RecoverApplication(c *gin.Context) {
 	responses.JSONSuccessResponse(c, 200, "application recovered (activated)", nil)
 }
 
-// ListApplications handles listing all applications
+// ListApplications handles listing all applications, optionally only those with every tag in ?tags=a,b
 func (h *ApplicationHandler) ListApplications(c *gin.Context) {
+	var tags []string
+	for _, value := range c.QueryArray("tags") {
+		tags = append(tags, strings.Split(value, ",")...)
+	}
 	ctx := c.Request.Context()
-	resp, err := h.applicationService.ListApplications(ctx)
+	resp, err := h.applicationService.ListApplications(ctx, tags)
 	if err != nil {
-		responses.JSONErrorResponse(c, 500, "failed to list applications: "+err.Error(), nil)
+		responses.JSONErrorResponse(c, statusCodeFromError(err), "failed to list applications: "+err.Error(), nil)
 		return
 	}
 	responses.JSONSuccessResponse(c, 200, "applications fetched", resp)
 }
 
+// AddApplicationTags handles attaching tags to an application
+func (h *ApplicationHandler) AddApplicationTags(c *gin.Context) {
+	h.changeApplicationTags(c, h.applicationService.AddApplicationTags, "add", "tags added")
+}
+
+// RemoveApplicationTags handles detaching tags from an application
+func (h *ApplicationHandler) RemoveApplicationTags(c *gin.Context) {
+	h.changeApplicationTags(c, h.applicationService.RemoveApplicationTags, "remove", "tags removed")
+}
+
+func (h *ApplicationHandler) changeApplicationTags(c *gin.Context, change func(ctx context.Context, appUID string, tags []string) (*model.ApplicationTagsResponse, error), verb, message string) {
+	appUID := c.Param("app_id")
+	if appUID == "" {
+		responses.JSONErrorResponse(c, 400, "missing app_id parameter", nil)
+		return
+	}
+	var req model.ApplicationTagsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responses.JSONErrorResponse(c, 400, "invalid request: "+err.Error(), nil)
+		return
+	}
+
+	ctx := c.Request.Context()
+	resp, err := change(ctx, appUID, req.Tags)
+	if err != nil {
+		responses.JSONErrorResponse(c, statusCodeFromError(err), "failed to "+verb+" tags: "+err.Error(), nil)
+		return
+	}
+	responses.JSONSuccessResponse(c, 200, message, resp)
+}
+
+// ListRuntimeFrameworks handles listing the frameworks that can be chosen for a runtime (ID or name)
+func (h *ApplicationHandler) ListRuntimeFrameworks(c *gin.Context) {
+	runtime := c.Param("runtime")
+	if runtime == "" {
+		responses.JSONErrorResponse(c, 400, "missing runtime parameter", nil)
+		return
+	}
+	ctx := c.Request.Context()
+	resp, err := h.applicationService.GetFrameworksByRuntime(ctx, runtime)
+	if err != nil {
+		responses.JSONErrorResponse(c, statusCodeFromError(err), "failed to list frameworks: "+err.Error(), nil)
+		return
+	}
+	responses.JSONSuccessResponse(c, 200, "frameworks fetched", resp)
+}
+
+// RefreshDependencyMetadata handles re-fetching a dependency's GitHub metadata
+func (h *ApplicationHandler) RefreshDependencyMetadata(c *gin.Context) {
+	depUID := c.Param("dep_id")
+	if depUID == "" {
+		responses.JSONErrorResponse(c, 400, "missing dep_id parameter", nil)
+		return
+	}
+	ctx := c.Request.Context()
+	resp, err := h.applicationService.RefreshDependencyMetadata(ctx, depUID)
+	if err != nil {
+		responses.JSONErrorResponse(c, statusCodeFromError(err), "failed to refresh dependency: "+err.Error(), nil)
+		return
+	}
+	responses.JSONSuccessResponse(c, 200, "dependency metadata refreshed", resp)
+}
+
+// GetDependencyChangelog handles listing the commits and release notes between two versions of a dependency
+func (h *ApplicationHandler) GetDependencyChangelog(c *gin.Context) {
+	ctx := c.Request.Context()
+	resp, err := h.applicationService.GetDependencyChangelog(ctx, c.Param("dep_id"), c.Query("from"), c.Query("to"))
+	if err != nil {
+		responses.JSONErrorResponse(c, statusCodeFromError(err), "failed to fetch changelog: "+err.Error(), nil)
+		return
+	}
+	responses.JSONSuccessResponse(c, 200, "changelog fetched", resp)
+}
+
+// GetApplicationSBOM handles building the SBOM of an application's current dependencies, independent of any scan
+func (h *ApplicationHandler) GetApplicationSBOM(c *gin.Context) {
+	ctx := c.Request.Context()
+	sbom, err := h.applicationService.GenerateApplicationSBOM(ctx, c.Param("app_id"))
+	if err != nil {
+		responses.JSONErrorResponse(c, statusCodeFromError(err), "failed to generate SBOM: "+err.Error(), nil)
+		return
+	}
+	responses.JSONSuccessResponse(c, 200, "SBOM generated", json.RawMessage(sbom))
+}
+
+// GetDependencyTree handles returning an application's direct and transitive dependencies. Only this split is
+// returned, as flat lists: no parser records which direct dependency pulls in a transitive one, so nodes have no parents.
+func (h *ApplicationHandler) GetDependencyTree(c *gin.Context) {
+	tree, err := h.applicationService.GetDependencyTree(c.Request.Context(), c.Param("app_id"))
+	if err != nil {
+		responses.JSONErrorResponse(c, statusCodeFromError(err), "failed to fetch dependency tree: "+err.Error(), nil)
+		return
+	}
+	responses.JSONSuccessResponse(c, 200, "dependency tree fetched", tree)
+}
+
+// GetApplicationManifest handles returning the dependency manifest an application was added from
+func (h *ApplicationHandler) GetApplicationManifest(c *gin.Context) {
+	manifest, err := h.applicationService.GetApplicationManifest(c.Request.Context(), c.Param("app_id"))
+	if err != nil {
+		responses.JSONErrorResponse(c, statusCodeFromError(err), "failed to fetch manifest: "+err.Error(), nil)
+		return
+	}
+	responses.JSONSuccessResponse(c, 200, "manifest fetched", manifest)
+}
+
+// ReparseApplication handles re-running the current parser over an application's stored manifest
+func (h *ApplicationHandler) ReparseApplication(c *gin.Context) {
+	resp, err := h.applicationService.ReparseApplication(c.Request.Context(), c.Param("app_id"))
+	if err != nil {
+		responses.JSONErrorResponse(c, statusCodeFromError(err), "failed to re-parse manifest: "+err.Error(), nil)
+		return
+	}
+	responses.JSONSuccessResponse(c, 200, "manifest re-parsed", resp)
+}
+
+// GetDependencyMaintenance handles reporting a dependency's repository signals and maintenance risk
+func (h *ApplicationHandler) GetDependencyMaintenance(c *gin.Context) {
+	ctx := c.Request.Context()
+	resp, err := h.applicationService.GetDependencyMaintenance(ctx, c.Param("dep_id"))
+	if err != nil {
+		responses.JSONErrorResponse(c, statusCodeFromError(err), "failed to fetch maintenance signals: "+err.Error(), nil)
+		return
+	}
+	responses.JSONSuccessResponse(c, 200, "maintenance signals fetched", resp)
+}
+
+// BulkUpdateDependencyVersion handles moving every application on one version of a dependency to another
+func (h *ApplicationHandler) BulkUpdateDependencyVersion(c *gin.Context) {
+	var req model.BulkBumpRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responses.JSONErrorResponse(c, 400, "invalid request: "+err.Error(), nil)
+		return
+	}
+
+	ctx := c.Request.Context()
+	resp, err := h.applicationService.BulkUpdateDependencyVersion(ctx, c.Param("owner"), c.Param("repo"), req.FromVersion, req.ToVersion)
+	if err != nil {
+		responses.JSONErrorResponse(c, statusCodeFromError(err), "failed to bump dependency: "+err.Error(), nil)
+		return
+	}
+	responses.JSONSuccessResponse(c, 200, "dependency version bumped", resp)
+}
+
+// CompareApplications handles comparing the dependencies and latest scan risk of the applications ?a= and ?b=
+func (h *ApplicationHandler) CompareApplications(c *gin.Context) {
+	ctx := c.Request.Context()
+	resp, err := h.applicationService.CompareApplications(ctx, c.Query("a"), c.Query("b"))
+	if err != nil {
+		responses.JSONErrorResponse(c, statusCodeFromError(err), "failed to compare applications: "+err.Error(), nil)
+		return
+	}
+	responses.JSONSuccessResponse(c, 200, "applications compared", resp)
+}
+
+// ListApplicationAudit handles listing one page of an application's audit trail
+func (h *ApplicationHandler) ListApplicationAudit(c *gin.Context) {
+	appUID := c.Param("app_id")
+	limit, offset := 0, 0
+	for _, param := range []struct {
+		name   string
+		target *int
+	}{{"limit", &limit}, {"offset", &offset}} {
+		raw := c.Query(param.name)
+		if raw == "" {
+			continue
+		}
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 0 {
+			responses.JSONErrorResponse(c, 400, param.name+" must be a non-negative integer", nil)
+			return
+		}
+		*param.target = parsed
+	}
+
+	ctx := c.Request.Context()
+	resp, err := h.applicationService.ListApplicationAudit(ctx, appUID, limit, offset)
+	if err != nil {
+		responses.JSONErrorResponse(c, statusCodeFromError(err), "failed to list audit trail: "+err.Error(), nil)
+		return
+	}
+	responses.JSONSuccessResponse(c, 200, "audit trail fetched", resp)
+}
+
 // GetApplicationStatus handles fetching the status of a single application
 func (h *ApplicationHandler) GetApplicationStatus(c *gin.Context) {
 	appUID := c.Param("app_id")
@@ -196,11 +479,33 @@ func (h *ApplicationHandler) ScanApplication(c *gin.Context) {
 		responses.JSONErrorResponse(c, 400, "missing app_id parameter", nil)
 		return
 	}
-	ctx := c.Request.Context()
-	resp, err := h.applicationService.ScanApplicationDependencies(ctx, appUID)
+	// ?fail_on=critical&min_score=9 gates this scan differently from the default policy
+	policy, err := scanPolicyOverride(c, "", "")
+	if err != nil {
+		responses.JSONErrorResponse(c, 400, err.Error(), nil)
+		return
+	}
+	ctx := helper.WithScanPolicy(c.Request.Context(), policy)
+	// ?deterministic=true makes the generated SBOM reproducible for diffing and caching
+	if deterministic, _ := strconv.ParseBool(c.Query("deterministic")); deterministic {
+		ctx = helper.WithDeterministicSBOM(ctx, true)
+	}
+
+	// ?wait=true keeps the synchronous behaviour and returns the full result
+	if wait, _ := strconv.ParseBool(c.Query("wait")); wait {
+		resp, err := h.applicationService.ScanApplicationDependencies(ctx, appUID)
+		if err != nil {
+			responses.JSONErrorResponse(c, statusCodeFromError(err), "failed to scan application: "+err.Error(), nil)
+			return
+		}
+		responses.JSONSuccessResponse(c, 200, "application scan completed", resp)
+		return
+	}
+
+	job, err := h.applicationService.StartApplicationScan(ctx, appUID)
 	if err != nil {
-		responses.JSONErrorResponse(c, 500, "failed to scan application: "+err.Error(), nil)
+		responses.JSONErrorResponse(c, statusCodeFromError(err), "failed to start application scan: "+err.Error(), nil)
 		return
 	}
-	responses.JSONSuccessResponse(c, 200, "application scan initiated", resp)
+	responses.JSONSuccessResponse(c, 202, "application scan initiated", job)
 }
@@ -0,0 +1,152 @@
+package http
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"elang-backend/internal/model"
+	"elang-backend/internal/model/responses"
+	"elang-backend/internal/repository"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuthConfig configures JWT authentication of the API. An empty Secret disables authentication.
+type AuthConfig struct {
+	Secret string // HMAC secret used to verify HS256 tokens
+	Issuer string // Required "iss" claim; empty accepts any issuer
+
+	// AdminSubjects are the token subjects allowed to call /api/admin; nobody is when empty
+	AdminSubjects []string
+}
+
+// authUserKey is the gin context key the authenticated user is stored under
+const authUserKey = "auth_user"
+
+// CurrentUser returns the user authenticated by the JWT middleware, if any
+func CurrentUser(c *gin.Context) (*model.AuthUser, bool) {
+	value, exists := c.Get(authUserKey)
+	if !exists {
+		return nil, false
+	}
+	user, ok := value.(*model.AuthUser)
+	return user, ok
+}
+
+// jwtAuthMiddleware rejects requests without a valid bearer token. The token subject becomes the
+// current user, and the request context is scoped so repositories only return that user's applications.
+func jwtAuthMiddleware(cfg AuthConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, found := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !found || strings.TrimSpace(token) == "" {
+			responses.JSONErrorResponse(c, 401, "missing bearer token", nil)
+			c.Abort()
+			return
+		}
+
+		user, err := parseJWT(strings.TrimSpace(token), cfg, time.Now())
+		if err != nil {
+			responses.JSONErrorResponse(c, 401, "invalid token: "+err.Error(), nil)
+			c.Abort()
+			return
+		}
+
+		c.Set(authUserKey, user)
+		c.Request = c.Request.WithContext(repository.WithOwnerScope(c.Request.Context(), user.ID))
+		c.Next()
+	}
+}
+
+// adminOnlyMiddleware restricts a route to the users in cfg.AdminSubjects. It runs after jwtAuthMiddleware;
+// without authentication there are no users to tell apart and every caller is let through, as on the rest of the API.
+func adminOnlyMiddleware(cfg AuthConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cfg.Secret == "" {
+			c.Next()
+			return
+		}
+		user, ok := CurrentUser(c)
+		if !ok || !slices.Contains(cfg.AdminSubjects, user.ID) {
+			responses.JSONErrorResponse(c, 403, "admin access required", nil)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ"`
+}
+
+type jwtClaims struct {
+	Subject   string `json:"sub"`
+	Issuer    string `json:"iss"`
+	ExpiresAt *int64 `json:"exp"`
+	NotBefore *int64 `json:"nbf"`
+}
+
+// parseJWT verifies an HS256 token and returns the user it identifies.
+// Only HS256 is accepted, so unsigned ("none") or asymmetric tokens cannot be substituted.
+func parseJWT(token string, cfg AuthConfig, now time.Time) (*model.AuthUser, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed token")
+	}
+
+	var header jwtHeader
+	if err := decodeJWTSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("malformed header: %w", err)
+	}
+	if header.Alg != "HS256" {
+		return nil, fmt.Errorf("unsupported signing algorithm %q", header.Alg)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errors.New("malformed signature")
+	}
+	mac := hmac.New(sha256.New, []byte(cfg.Secret))
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, errors.New("signature mismatch")
+	}
+
+	var claims jwtClaims
+	if err := decodeJWTSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("malformed claims: %w", err)
+	}
+	if claims.Subject == "" {
+		return nil, errors.New("missing subject")
+	}
+	if cfg.Issuer != "" && claims.Issuer != cfg.Issuer {
+		return nil, errors.New("unexpected issuer")
+	}
+	if claims.ExpiresAt == nil {
+		return nil, errors.New("missing expiry")
+	}
+	expiresAt := time.Unix(*claims.ExpiresAt, 0)
+	if !now.Before(expiresAt) {
+		return nil, errors.New("token expired")
+	}
+	if claims.NotBefore != nil && now.Before(time.Unix(*claims.NotBefore, 0)) {
+		return nil, errors.New("token not yet valid")
+	}
+
+	return &model.AuthUser{ID: claims.Subject, Issuer: claims.Issuer, ExpiresAt: expiresAt.UTC()}, nil
+}
+
+func decodeJWTSegment(segment string, v interface{}) error {
+	raw, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, v)
+}
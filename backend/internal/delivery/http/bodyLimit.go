@@ -0,0 +1,39 @@
+package http
+
+import (
+	"elang-backend/internal/model/responses"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// bodyLimitMiddleware rejects request bodies larger than maxBytes with 413. A declared Content-Length is
+// checked before anything is read; bodies of unknown length are cut off once they pass the limit, which the
+// handlers report through bodyErrorStatus. A non-positive maxBytes disables the limit.
+func bodyLimitMiddleware(maxBytes int64) gin.HandlerFunc {
+	if maxBytes <= 0 {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	return func(c *gin.Context) {
+		if c.Request.ContentLength > maxBytes {
+			responses.JSONErrorResponse(c, http.StatusRequestEntityTooLarge, fmt.Sprintf("request body exceeds %d bytes", maxBytes), nil)
+			c.Abort()
+			return
+		}
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes)
+		c.Next()
+	}
+}
+
+// bodyErrorStatus is the status of a failure to read the request body: 413 when the body limit cut it off,
+// 400 otherwise
+func bodyErrorStatus(err error) int {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return http.StatusRequestEntityTooLarge
+	}
+	return http.StatusBadRequest
+}
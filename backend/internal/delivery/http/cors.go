@@ -0,0 +1,89 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CORSConfig configures which browser origins may call the API. With no AllowedOrigins no cross-origin request
+// is allowed; "*" allows every origin and is only used when configured explicitly.
+type CORSConfig struct {
+	AllowedOrigins   []string // Origins such as https://app.example.com, or "*" for any
+	AllowedMethods   []string // Methods preflight requests may ask for; DefaultCORSMethods when empty
+	AllowedHeaders   []string // Request headers preflight requests may ask for; DefaultCORSHeaders when empty
+	AllowCredentials bool     // Whether browsers may send cookies and Authorization headers; never with "*"
+}
+
+// Methods and headers allowed to cross-origin callers when none are configured
+var (
+	DefaultCORSMethods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+	DefaultCORSHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization", "Idempotency-Key"}
+)
+
+// corsMiddleware answers preflight requests and marks responses readable by the configured origins. Requests
+// from other origins get no CORS headers, so browsers keep their responses from the calling page, and their
+// preflight requests are refused with 403.
+func corsMiddleware(cfg CORSConfig) gin.HandlerFunc {
+	wildcard := false
+	origins := make(map[string]bool, len(cfg.AllowedOrigins))
+	for _, origin := range cfg.AllowedOrigins {
+		if origin == "*" {
+			wildcard = true
+			continue
+		}
+		origins[normalizeOrigin(origin)] = true
+	}
+	methods := cfg.AllowedMethods
+	if len(methods) == 0 {
+		methods = DefaultCORSMethods
+	}
+	headers := cfg.AllowedHeaders
+	if len(headers) == 0 {
+		headers = DefaultCORSHeaders
+	}
+	allowMethods := strings.Join(methods, ", ")
+	allowHeaders := strings.Join(headers, ", ")
+
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin == "" {
+			c.Next()
+			return
+		}
+		preflight := c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != ""
+
+		c.Writer.Header().Add("Vary", "Origin")
+		switch {
+		case wildcard:
+			// Browsers refuse credentialed responses for "*", so credentials are never offered to any origin
+			c.Header("Access-Control-Allow-Origin", "*")
+		case origins[normalizeOrigin(origin)]:
+			c.Header("Access-Control-Allow-Origin", origin)
+			if cfg.AllowCredentials {
+				c.Header("Access-Control-Allow-Credentials", "true")
+			}
+		default:
+			if preflight {
+				c.AbortWithStatus(http.StatusForbidden)
+				return
+			}
+			c.Next()
+			return
+		}
+
+		if preflight {
+			c.Header("Access-Control-Allow-Methods", allowMethods)
+			c.Header("Access-Control-Allow-Headers", allowHeaders)
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+		c.Next()
+	}
+}
+
+// normalizeOrigin compares origins case-insensitively and without a trailing slash
+func normalizeOrigin(origin string) string {
+	return strings.ToLower(strings.TrimSuffix(strings.TrimSpace(origin), "/"))
+}
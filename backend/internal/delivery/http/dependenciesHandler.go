@@ -1,10 +1,14 @@
 package http
 
 import (
+	"context"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/model"
 	"elang-backend/internal/model/responses"
 	"elang-backend/internal/services"
 	"fmt"
 	"log/slog"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
@@ -20,53 +24,192 @@ func NewDependenciesHandler(depService services.DependenciesInterface) *Dependen
 	}
 }
 
+// scanDependenciesFormRequest holds the multipart fields of POST /api/scan/dependencies; the files are read separately
+type scanDependenciesFormRequest struct {
+	AppName     string `form:"name" binding:"required"`
+	Runtime     string `form:"runtime" binding:"required"`
+	Version     string `form:"version"`
+	Description string `form:"description,omitempty"`
+	FailOn      string `form:"fail_on"`   // Comma-separated severities overriding the default policy
+	MinScore    string `form:"min_score"` // Risk score that fails the scan, overriding the default policy
+}
+
+// diffManifestsFormRequest holds the multipart fields of POST /api/manifests/diff; the two files are read separately
+type diffManifestsFormRequest struct {
+	Runtime string `form:"runtime" binding:"required"`
+}
+
 // Add methods to handle scan-related requests
 // For example, a method to initiate a scan
+// Accepts either a multipart upload or a JSON body with base64-encoded file content
 func (h *DependenciesHandler) ScanApplication(c *gin.Context) {
 
-	var req struct {
-		AppName     string `form:"name" binding:"required"`
-		Runtime     string `form:"runtime" binding:"required"`
-		Version     string `form:"version"`
-		Description string `form:"description,omitempty"`
+	var req scanDependenciesFormRequest
+	var files []helper.ManifestFile
+	var gradleProperties string // base64 in JSON requests
+
+	if isJSONRequest(c) {
+		var body model.ScanDependenciesJSONRequest
+		if status, err := bindManifestJSON(c, &body); err != nil {
+			slog.Error("Failed to bind request", "error", err)
+			responses.JSONErrorResponse(c, status, err.Error(), nil)
+			return
+		}
+		decoded, status, err := decodeManifestContent(body.ContentBase64)
+		if err != nil {
+			responses.JSONErrorResponse(c, status, err.Error(), nil)
+			return
+		}
+		req.AppName, req.Runtime, req.Version, req.Description = body.AppName, body.Runtime, body.Version, body.Description
+		req.FailOn = body.FailOn
+		if body.MinScore != nil {
+			req.MinScore = strconv.FormatFloat(*body.MinScore, 'f', -1, 64)
+		}
+		files, gradleProperties = []helper.ManifestFile{{FileName: body.FileName, Content: decoded}}, body.GradlePropertiesBase64
+		for _, additional := range body.AdditionalFiles {
+			decoded, status, err := decodeManifestContent(additional.ContentBase64)
+			if err != nil {
+				responses.JSONErrorResponse(c, status, additional.FileName+": "+err.Error(), nil)
+				return
+			}
+			files = append(files, helper.ManifestFile{FileName: additional.FileName, Content: decoded})
+		}
+	} else {
+		if err := c.ShouldBind(&req); err != nil {
+			slog.Error("Failed to bind request", "error", err)
+			responses.JSONErrorResponse(c, bodyErrorStatus(err), err.Error(), nil)
+			return
+		}
+
+		// Several manifests, e.g. of the packages of a monorepo, are uploaded as repeated file fields
+		var status int
+		var err error
+		if files, status, err = readManifestFiles(c, "file"); err != nil {
+			responses.JSONErrorResponse(c, status, err.Error(), nil)
+			return
+		}
 	}
 
-	if err := c.ShouldBind(&req); err != nil {
-		slog.Error("Failed to bind request", "error", err)
+	properties, status, err := readGradleProperties(c, gradleProperties)
+	if err != nil {
+		responses.JSONErrorResponse(c, status, err.Error(), nil)
+		return
+	}
+
+	policy, err := scanPolicyOverride(c, req.FailOn, req.MinScore)
+	if err != nil {
 		responses.JSONErrorResponse(c, 400, err.Error(), nil)
 		return
 	}
 
-	file, fileHeader, err := c.Request.FormFile("file")
+	ctx := helper.WithGradlePropertiesFile(c.Request.Context(), properties)
+	ctx = helper.WithScanPolicy(ctx, policy)
+	// ?include_indirect=true also scans the "// indirect" requirements of a go.mod
+	if include, err := strconv.ParseBool(c.Query("include_indirect")); err == nil {
+		ctx = helper.WithGoIndirectDependencies(ctx, include)
+	}
+	// ?deterministic=true makes the generated SBOM reproducible for diffing and caching
+	if deterministic, _ := strconv.ParseBool(c.Query("deterministic")); deterministic {
+		ctx = helper.WithDeterministicSBOM(ctx, true)
+	}
+	// Accept: application/x-ndjson streams findings as they complete instead of one buffered response
+	if wantsNDJSON(c) {
+		h.streamScan(c, ctx, req, files)
+		return
+	}
+	result, err := h.scanManifests(ctx, req, files)
 	if err != nil {
-		responses.JSONErrorResponse(c, 400, "failed to get file: "+err.Error(), nil)
+		responses.JSONErrorResponse(c, statusCodeFromError(err), "failed to scan application: "+err.Error(), nil)
 		return
 	}
-	defer file.Close()
 
-	fileBytes := make([]byte, fileHeader.Size)
-	_, err = file.Read(fileBytes)
+	responses.JSONSuccessResponse(c, 200, "application scanned successfully", result)
+}
+
+// scanManifests scans the uploaded manifest, or merges and scans them together when several were uploaded
+func (h *DependenciesHandler) scanManifests(ctx context.Context, req scanDependenciesFormRequest, files []helper.ManifestFile) (interface{}, error) {
+	if len(files) == 1 {
+		return h.dependencyService.ScanDependencies(ctx, req.AppName, req.Runtime, req.Version, req.Description, files[0].FileName, files[0].Content)
+	}
+	return h.dependencyService.ScanMultipleDependencies(ctx, req.AppName, req.Runtime, req.Version, req.Description, files)
+}
+
+// streamScan runs a dependency scan and writes each finding as an NDJSON line as soon as it is known,
+// followed by a summary line with the rest of the scan result
+func (h *DependenciesHandler) streamScan(c *gin.Context, ctx context.Context, req scanDependenciesFormRequest, files []helper.ManifestFile) {
+	stream := &ndjsonStream{c: c}
+	ctx = helper.WithFindingListener(ctx, func(finding model.ScanFinding) {
+		stream.Write(scanStreamLine{Type: "finding", Finding: &finding})
+	})
+
+	result, err := h.scanManifests(ctx, req, files)
 	if err != nil {
-		responses.JSONErrorResponse(c, 500, "failed to read file: "+err.Error(), nil)
+		if !stream.Started() {
+			responses.JSONErrorResponse(c, statusCodeFromError(err), "failed to scan application: "+err.Error(), nil)
+			return
+		}
+		stream.Write(scanStreamLine{Type: "error", Message: "failed to scan application: " + err.Error()})
+		return
+	}
+
+	// Findings were already streamed; the summary carries everything else
+	if scan, ok := result.(model.ScanApplicationResult); ok {
+		scan.Findings = nil
+		result = scan
+	}
+	stream.Write(scanStreamLine{Type: "summary", Summary: result})
+}
+
+// DiffManifests compares an old and a new manifest uploaded as the multipart files old_file and new_file
+// and reports the dependency changes with the vulnerabilities they would introduce
+func (h *DependenciesHandler) DiffManifests(c *gin.Context) {
+	var req diffManifestsFormRequest
+	if err := c.ShouldBind(&req); err != nil {
+		responses.JSONErrorResponse(c, 400, err.Error(), nil)
+		return
+	}
+	oldFileName, oldContent, status, err := readManifestFile(c, "old_file")
+	if err != nil {
+		responses.JSONErrorResponse(c, status, err.Error(), nil)
+		return
+	}
+	newFileName, newContent, status, err := readManifestFile(c, "new_file")
+	if err != nil {
+		responses.JSONErrorResponse(c, status, err.Error(), nil)
 		return
 	}
 
 	ctx := c.Request.Context()
-	result, err := h.dependencyService.ScanDependencies(
-		ctx,
-		req.AppName,
-		req.Runtime,
-		req.Version,
-		req.Description,
-		fileHeader.Filename,
-		string(fileBytes),
-	)
+	result, err := h.dependencyService.DiffManifests(ctx, req.Runtime, oldFileName, oldContent, newFileName, newContent)
 	if err != nil {
-		responses.JSONErrorResponse(c, 500, "failed to scan application: "+err.Error(), nil)
+		responses.JSONErrorResponse(c, statusCodeFromError(err), "failed to diff manifests: "+err.Error(), nil)
 		return
 	}
 
-	responses.JSONSuccessResponse(c, 200, "application scanned successfully", result)
+	responses.JSONSuccessResponse(c, 200, "manifests compared successfully", result)
+}
+
+// ListSeverities returns the canonical severities, most severe first, with their configured labels and colors
+func (h *DependenciesHandler) ListSeverities(c *gin.Context) {
+	responses.JSONSuccessResponse(c, 200, "severities retrieved successfully", h.dependencyService.SeverityStyles())
+}
+
+// CheckDependency looks up vulnerabilities for a single library version without creating an application
+func (h *DependenciesHandler) CheckDependency(c *gin.Context) {
+	var req model.CheckDependencyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responses.JSONErrorResponse(c, 400, "invalid request: "+err.Error(), nil)
+		return
+	}
+
+	ctx := c.Request.Context()
+	result, err := h.dependencyService.CheckDependency(ctx, &req)
+	if err != nil {
+		responses.JSONErrorResponse(c, statusCodeFromError(err), "failed to check dependency: "+err.Error(), nil)
+		return
+	}
+
+	responses.JSONSuccessResponse(c, 200, "dependency checked successfully", result)
 }
 
 // GetSBOM retrieves the SBOM for a given application and SBOM ID
@@ -88,6 +231,133 @@ func (h *DependenciesHandler) GetSBOM(c *gin.Context) {
 	responses.JSONSuccessResponse(c, 200, "SBOM retrieved successfully", sbomData)
 }
 
+// GetScanResult retrieves the full stored result of a scan by its ID
+func (h *DependenciesHandler) GetScanResult(c *gin.Context) {
+	scanID := c.Param("scan_id")
+	if scanID == "" {
+		responses.JSONErrorResponse(c, 400, "scan_id is required", nil)
+		return
+	}
+
+	ctx := c.Request.Context()
+	result, err := h.dependencyService.GetScanResult(ctx, scanID)
+	if err != nil {
+		responses.JSONErrorResponse(c, statusCodeFromError(err), "failed to get scan result: "+err.Error(), nil)
+		return
+	}
+
+	responses.JSONSuccessResponse(c, 200, "scan result retrieved successfully", result)
+}
+
+// EvaluateScanPolicy dry-runs a proposed policy against a stored scan; the policy is not saved
+func (h *DependenciesHandler) EvaluateScanPolicy(c *gin.Context) {
+	scanID := c.Param("scan_id")
+	if scanID == "" {
+		responses.JSONErrorResponse(c, 400, "scan_id is required", nil)
+		return
+	}
+	var req model.EvaluatePolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responses.JSONErrorResponse(c, 400, "invalid request: "+err.Error(), nil)
+		return
+	}
+
+	ctx := c.Request.Context()
+	result, err := h.dependencyService.EvaluateScanPolicy(ctx, scanID, &req)
+	if err != nil {
+		responses.JSONErrorResponse(c, statusCodeFromError(err), "failed to evaluate policy: "+err.Error(), nil)
+		return
+	}
+
+	responses.JSONSuccessResponse(c, 200, "policy evaluated successfully", result)
+}
+
+// GetDashboardSummary returns organization-wide vulnerability totals built from the latest scan of each application
+func (h *DependenciesHandler) GetDashboardSummary(c *gin.Context) {
+	topN := 0
+	if raw := c.Query("top"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			responses.JSONErrorResponse(c, 400, "top must be a positive integer", nil)
+			return
+		}
+		topN = parsed
+	}
+
+	ctx := c.Request.Context()
+	summary, err := h.dependencyService.GetDashboardSummary(ctx, topN)
+	if err != nil {
+		responses.JSONErrorResponse(c, statusCodeFromError(err), "failed to build dashboard summary: "+err.Error(), nil)
+		return
+	}
+
+	responses.JSONSuccessResponse(c, 200, "dashboard summary retrieved successfully", summary)
+}
+
+// ListFindings returns one page of an application's findings across its stored scans
+func (h *DependenciesHandler) ListFindings(c *gin.Context) {
+	var query model.FindingsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		responses.JSONErrorResponse(c, 400, "invalid query: "+err.Error(), nil)
+		return
+	}
+
+	ctx := c.Request.Context()
+	findings, err := h.dependencyService.ListFindings(ctx, c.Param("app_id"), query)
+	if err != nil {
+		responses.JSONErrorResponse(c, statusCodeFromError(err), "failed to list findings: "+err.Error(), nil)
+		return
+	}
+	responses.JSONSuccessResponse(c, 200, "findings retrieved successfully", findings)
+}
+
+// ListDependencies returns one page of the dependency catalog, optionally filtered by name
+func (h *DependenciesHandler) ListDependencies(c *gin.Context) {
+	limit, offset := 0, 0
+	for _, param := range []struct {
+		name   string
+		target *int
+	}{{"limit", &limit}, {"offset", &offset}} {
+		raw := c.Query(param.name)
+		if raw == "" {
+			continue
+		}
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 0 {
+			responses.JSONErrorResponse(c, 400, param.name+" must be a non-negative integer", nil)
+			return
+		}
+		*param.target = parsed
+	}
+
+	ctx := c.Request.Context()
+	catalog, err := h.dependencyService.SearchDependencies(ctx, c.Query("search"), limit, offset)
+	if err != nil {
+		responses.JSONErrorResponse(c, statusCodeFromError(err), "failed to list dependencies: "+err.Error(), nil)
+		return
+	}
+
+	responses.JSONSuccessResponse(c, 200, "dependencies retrieved successfully", catalog)
+}
+
+// GetScanStatus retrieves the progress of a scan by its ID
+func (h *DependenciesHandler) GetScanStatus(c *gin.Context) {
+	scanID := c.Param("scan_id")
+	if scanID == "" {
+		responses.JSONErrorResponse(c, 400, "scan_id is required", nil)
+		return
+	}
+
+	ctx := c.Request.Context()
+	status, err := h.dependencyService.GetScanStatus(ctx, scanID)
+	if err != nil {
+		responses.JSONErrorResponse(c, statusCodeFromError(err), "failed to get scan status: "+err.Error(), nil)
+		return
+	}
+
+	responses.JSONSuccessResponse(c, 200, "scan status retrieved successfully", status)
+}
+
 // MonitorApplicationDepedencies monitors application dependencies for changes
 func (h *DependenciesHandler) MonitorApplicationDepedencies(c *gin.Context) {
 	appUID := c.Param("app_id")
@@ -0,0 +1,24 @@
+package http
+
+import (
+	"elang-backend/internal/services"
+	"errors"
+	"net/http"
+)
+
+// statusCodeFromError maps typed service errors to HTTP status codes.
+// Errors that are not typed are treated as internal server errors.
+func statusCodeFromError(err error) int {
+	switch {
+	case errors.Is(err, services.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrInvalidInput):
+		return http.StatusBadRequest
+	case errors.Is(err, services.ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, services.ErrTooManyDependencies):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,98 @@
+package http
+
+import (
+	"encoding/csv"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"elang-backend/internal/model"
+	"elang-backend/internal/model/responses"
+
+	"github.com/gin-gonic/gin"
+)
+
+// findingsCSVHeader names the columns of the findings CSV export
+var findingsCSVHeader = []string{"dependency", "version", "severity", "vulnerability_ids", "recommendation", "recommended_version"}
+
+// findingsCSV writes findings as CSV rows, flushing each one. The status, headers and header row are sent
+// with the first row, so errors before that can still be answered with a regular JSON error.
+type findingsCSV struct {
+	c       *gin.Context
+	w       *csv.Writer
+	appUID  string
+	started bool
+}
+
+func (s *findingsCSV) start() error {
+	if s.started {
+		return nil
+	}
+	s.started = true
+	s.c.Header("Content-Type", "text/csv; charset=utf-8")
+	s.c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="findings-%s.csv"`, s.appUID))
+	s.c.Header("X-Content-Type-Options", "nosniff")
+	s.c.Status(http.StatusOK)
+	s.w = csv.NewWriter(s.c.Writer)
+	return s.flush(findingsCSVHeader)
+}
+
+// Write sends one finding as a row
+func (s *findingsCSV) Write(finding model.ScanFinding) error {
+	if err := s.start(); err != nil {
+		return err
+	}
+	return s.flush([]string{
+		spreadsheetSafe(finding.Dependency),
+		spreadsheetSafe(finding.Version),
+		finding.Severity,
+		spreadsheetSafe(strings.Join(finding.VulnerabilityIDs, ";")),
+		spreadsheetSafe(finding.Recommendation),
+		spreadsheetSafe(finding.RecommendedVersion),
+	})
+}
+
+func (s *findingsCSV) flush(record []string) error {
+	if err := s.w.Write(record); err != nil {
+		return err
+	}
+	s.w.Flush()
+	if err := s.w.Error(); err != nil {
+		return err
+	}
+	s.c.Writer.Flush()
+	return nil
+}
+
+// spreadsheetSafe keeps a value taken from an uploaded manifest from being run as a formula when the CSV is
+// opened in a spreadsheet: every value starting with a character a spreadsheet reads as the start of a formula,
+// scoped npm names such as @babel/core included, is prefixed with a quote.
+func spreadsheetSafe(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// ExportFindingsCSV streams the findings of an application's latest completed scan as a CSV download
+func (h *DependenciesHandler) ExportFindingsCSV(c *gin.Context) {
+	appUID := c.Param("app_id")
+	stream := &findingsCSV{c: c, appUID: appUID}
+
+	err := h.dependencyService.StreamLatestFindings(c.Request.Context(), appUID, stream.Write)
+	if err == nil {
+		// A scan without findings still gets the header row
+		err = stream.start()
+	}
+	if err == nil {
+		return
+	}
+	if !stream.started {
+		responses.JSONErrorResponse(c, statusCodeFromError(err), "failed to export findings: "+err.Error(), nil)
+		return
+	}
+	// The status is already sent; the client sees a truncated download
+	slog.Warn("Failed to stream findings CSV", "app_id", appUID, "error", err)
+	c.Abort()
+}
@@ -0,0 +1,167 @@
+package http
+
+import (
+	"bytes"
+	"elang-backend/internal/model/responses"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// IdempotencyKeyHeader lets a client retry a request without running it twice
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// idempotentReplayedHeader marks a response replayed from an earlier request with the same key
+	idempotentReplayedHeader = "Idempotent-Replayed"
+	// maxIdempotencyKeyLength bounds the keys kept in memory
+	maxIdempotencyKeyLength = 255
+)
+
+// idempotentResponse is the recorded response of a request; done is false while the request still runs
+type idempotentResponse struct {
+	done        bool
+	status      int
+	contentType string
+	body        []byte
+	expires     time.Time
+}
+
+// idempotencyStore remembers the responses of keyed requests for ttl. Like the rate limiter's buckets,
+// expired entries are swept lazily so the map does not grow without bound.
+type idempotencyStore struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	responses map[string]*idempotentResponse
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func newIdempotencyStore(ttl time.Duration) *idempotencyStore {
+	return &idempotencyStore{
+		ttl:       ttl,
+		responses: make(map[string]*idempotentResponse),
+		now:       time.Now,
+	}
+}
+
+// reserve claims key for a new request. When the key is already known it returns the recorded response
+// instead, which is not done yet if the first request is still running.
+func (s *idempotencyStore) reserve(key string) (*idempotentResponse, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+	if recorded, exists := s.responses[key]; exists && (!recorded.done || now.Before(recorded.expires)) {
+		return recorded, false
+	}
+	s.responses[key] = &idempotentResponse{}
+	return nil, true
+}
+
+// complete records the response of the request that reserved key
+func (s *idempotencyStore) complete(key string, status int, contentType string, body []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.responses[key] = &idempotentResponse{
+		done:        true,
+		status:      status,
+		contentType: contentType,
+		body:        body,
+		expires:     s.now().Add(s.ttl),
+	}
+}
+
+// release forgets key, so a retry runs the request again
+func (s *idempotencyStore) release(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.responses, key)
+}
+
+// sweep drops the recorded responses whose TTL has passed
+func (s *idempotencyStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < s.ttl {
+		return
+	}
+	for key, recorded := range s.responses {
+		if recorded.done && !now.Before(recorded.expires) {
+			delete(s.responses, key)
+		}
+	}
+	s.lastSweep = now
+}
+
+// recordingWriter passes the response through while keeping a copy of its body
+type recordingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *recordingWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *recordingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// idempotencyMiddleware makes requests carrying an Idempotency-Key header run once per key: a repeat within
+// ttl gets the first response back, marked with Idempotent-Replayed, and a repeat while the first is still
+// running gets 409. Keys are scoped to the client (user, or IP without authentication), method and path.
+// Server errors and rate-limited requests are not recorded, so they can be retried. A non-positive ttl
+// disables the middleware.
+func idempotencyMiddleware(ttl time.Duration) gin.HandlerFunc {
+	if ttl <= 0 {
+		return func(c *gin.Context) { c.Next() }
+	}
+	store := newIdempotencyStore(ttl)
+
+	return func(c *gin.Context) {
+		key := strings.TrimSpace(c.GetHeader(IdempotencyKeyHeader))
+		if key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			responses.JSONErrorResponse(c, http.StatusBadRequest, "Idempotency-Key must be at most 255 characters", nil)
+			c.Abort()
+			return
+		}
+
+		scopedKey := strings.Join([]string{clientKey(c), c.Request.Method, c.Request.URL.Path, key}, " ")
+		recorded, reserved := store.reserve(scopedKey)
+		if !reserved {
+			if !recorded.done {
+				responses.JSONErrorResponse(c, http.StatusConflict, "a request with this Idempotency-Key is still being processed", nil)
+				c.Abort()
+				return
+			}
+			c.Header(idempotentReplayedHeader, "true")
+			c.Data(recorded.status, recorded.contentType, recorded.body)
+			c.Abort()
+			return
+		}
+
+		writer := &recordingWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		defer func() {
+			if p := recover(); p != nil {
+				store.release(scopedKey)
+				panic(p)
+			}
+			status := writer.Status()
+			if status >= http.StatusInternalServerError || status == http.StatusTooManyRequests {
+				store.release(scopedKey)
+				return
+			}
+			store.complete(scopedKey, status, writer.Header().Get("Content-Type"), writer.body.Bytes())
+		}()
+		c.Next()
+	}
+}
@@ -0,0 +1,167 @@
+package http
+
+import (
+	"elang-backend/internal/helper"
+	"elang-backend/internal/helper/parser"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// maxManifestSize caps the decoded size of a dependency file submitted as base64 JSON
+	maxManifestSize = parser.MaxContentSize
+	// maxManifestJSONBodySize leaves room for base64 expansion and the remaining JSON fields
+	maxManifestJSONBodySize = maxManifestSize*4/3 + 64<<10
+)
+
+// isJSONRequest reports whether the request carries a JSON body instead of a multipart upload
+func isJSONRequest(c *gin.Context) bool {
+	return c.ContentType() == gin.MIMEJSON
+}
+
+// bindManifestJSON binds a size-limited JSON body into obj.
+// On failure it returns the HTTP status code to respond with.
+func bindManifestJSON(c *gin.Context, obj interface{}) (int, error) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxManifestJSONBodySize)
+	if err := c.ShouldBindJSON(obj); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return http.StatusRequestEntityTooLarge, fmt.Errorf("request body exceeds %d bytes", maxManifestJSONBodySize)
+		}
+		return http.StatusBadRequest, err
+	}
+	return 0, nil
+}
+
+// decodeManifestContent decodes base64 dependency file content and validates it is non-empty UTF-8 text.
+// On failure it returns the HTTP status code to respond with.
+func decodeManifestContent(encoded string) (string, int, error) {
+	encoded = strings.TrimSpace(encoded)
+	if base64.StdEncoding.DecodedLen(len(encoded)) > maxManifestSize+2 {
+		return "", http.StatusRequestEntityTooLarge, fmt.Errorf("dependency file exceeds %d bytes", maxManifestSize)
+	}
+
+	content, err := base64.StdEncoding.Strict().DecodeString(encoded)
+	if err != nil {
+		return "", http.StatusBadRequest, fmt.Errorf("content_base64 is not valid base64: %w", err)
+	}
+	if len(content) > maxManifestSize {
+		return "", http.StatusRequestEntityTooLarge, fmt.Errorf("dependency file exceeds %d bytes", maxManifestSize)
+	}
+	if len(content) == 0 {
+		return "", http.StatusBadRequest, errors.New("content_base64 decodes to an empty file")
+	}
+	if !utf8.Valid(content) {
+		return "", http.StatusBadRequest, errors.New("content_base64 does not decode to UTF-8 text")
+	}
+	return string(content), 0, nil
+}
+
+// readManifestFile reads the uploaded multipart file in field, capped at maxManifestSize.
+// On failure it returns the HTTP status code to respond with.
+func readManifestFile(c *gin.Context, field string) (string, string, int, error) {
+	file, fileHeader, err := c.Request.FormFile(field)
+	if err != nil {
+		return "", "", bodyErrorStatus(err), fmt.Errorf("failed to get %s: %w", field, err)
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(io.LimitReader(file, maxManifestSize+1))
+	if err != nil {
+		return "", "", http.StatusInternalServerError, fmt.Errorf("failed to read %s: %w", field, err)
+	}
+	if len(content) > maxManifestSize {
+		return "", "", http.StatusRequestEntityTooLarge, fmt.Errorf("%s exceeds %d bytes", field, maxManifestSize)
+	}
+	if len(content) == 0 {
+		return "", "", http.StatusBadRequest, fmt.Errorf("%s is empty", field)
+	}
+	return fileHeader.Filename, string(content), 0, nil
+}
+
+// readManifestFiles reads every multipart file uploaded in field, each capped at maxManifestSize. Files keep
+// the path they were uploaded with, e.g. "packages/web/package.json", so the manifests of a monorepo can be told
+// apart. On failure it returns the HTTP status code to respond with.
+func readManifestFiles(c *gin.Context, field string) ([]helper.ManifestFile, int, error) {
+	// Getting the first file parses the multipart form
+	file, _, err := c.Request.FormFile(field)
+	if err != nil {
+		return nil, bodyErrorStatus(err), fmt.Errorf("failed to get %s: %w", field, err)
+	}
+	file.Close()
+
+	var files []helper.ManifestFile
+	for _, header := range c.Request.MultipartForm.File[field] {
+		content, status, err := readManifestHeader(header, field)
+		if err != nil {
+			return nil, status, err
+		}
+		files = append(files, helper.ManifestFile{FileName: uploadedPath(header), Content: content})
+	}
+	return files, 0, nil
+}
+
+// uploadedPath returns the file name a multipart file was uploaded with, including the directories the
+// multipart reader strips
+func uploadedPath(header *multipart.FileHeader) string {
+	_, params, err := mime.ParseMediaType(header.Header.Get("Content-Disposition"))
+	if err != nil || strings.TrimSpace(params["filename"]) == "" {
+		return header.Filename
+	}
+	return strings.TrimSpace(params["filename"])
+}
+
+// readManifestHeader reads one uploaded multipart file, capped at maxManifestSize
+func readManifestHeader(header *multipart.FileHeader, field string) (string, int, error) {
+	file, err := header.Open()
+	if err != nil {
+		return "", http.StatusBadRequest, fmt.Errorf("failed to get %s %s: %w", field, header.Filename, err)
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(io.LimitReader(file, maxManifestSize+1))
+	if err != nil {
+		return "", http.StatusInternalServerError, fmt.Errorf("failed to read %s %s: %w", field, header.Filename, err)
+	}
+	if len(content) > maxManifestSize {
+		return "", http.StatusRequestEntityTooLarge, fmt.Errorf("%s %s exceeds %d bytes", field, header.Filename, maxManifestSize)
+	}
+	if len(content) == 0 {
+		return "", http.StatusBadRequest, fmt.Errorf("%s %s is empty", field, header.Filename)
+	}
+	return string(content), 0, nil
+}
+
+// gradlePropertiesField is the optional upload defining version variables of a Gradle build file
+const gradlePropertiesField = "gradle_properties"
+
+// readGradleProperties returns the optional gradle.properties of a request: the gradle_properties upload of a
+// multipart request, or the decoded encoded field of a JSON one. Without one it returns an empty string.
+// On failure it returns the HTTP status code to respond with.
+func readGradleProperties(c *gin.Context, encoded string) (string, int, error) {
+	if isJSONRequest(c) {
+		if strings.TrimSpace(encoded) == "" {
+			return "", 0, nil
+		}
+		content, status, err := decodeManifestContent(encoded)
+		if err != nil {
+			return "", status, fmt.Errorf("gradle_properties_base64: %w", err)
+		}
+		return content, 0, nil
+	}
+	// The manifest upload read before has already parsed the multipart form
+	if form := c.Request.MultipartForm; form == nil || len(form.File[gradlePropertiesField]) == 0 {
+		return "", 0, nil
+	}
+	_, content, status, err := readManifestFile(c, gradlePropertiesField)
+	return content, status, err
+}
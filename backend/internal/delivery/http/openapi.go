@@ -0,0 +1,602 @@
+package http
+
+//go:generate go run ../../../cmd/openapi -o ../../../docs/openapi.json
+
+import (
+	"elang-backend/internal/helper"
+	"elang-backend/internal/model"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+)
+
+// apiParam is a query parameter of an operation; path parameters are derived from the route
+type apiParam struct {
+	Name        string
+	Type        string // OpenAPI primitive type: string, integer, number, boolean
+	Description string
+	Required    bool
+}
+
+// apiOperation documents one route. Request and response schemas are reflected from the
+// same types the handlers bind and return, so the contract follows the Go structs.
+type apiOperation struct {
+	Method        string
+	Path          string // gin route syntax, e.g. /api/applications/:app_id/scan
+	Tag           string
+	Summary       string
+	Description   string // what the summary leaves out; empty for none
+	Query         []apiParam
+	JSONBody      interface{}         // bound with ShouldBindJSON
+	FormBody      interface{}         // multipart alternative, sent together with a "file" upload
+	FormFiles     []string            // names of the uploaded files of FormBody when not a single "file"
+	OptionalFiles []string            // names of further files FormBody may upload
+	Responses     map[int]interface{} // success status -> value of the envelope's data field; nil for no data
+	Stream        interface{}         // line type of the application/x-ndjson alternative to the 200 response
+	Download      string              // media type of a file sent as the 200 response instead of the JSON envelope
+	RateLimited   bool
+	Idempotent    bool // accepts an Idempotency-Key header
+}
+
+// freeForm marks a response whose data is a loosely typed JSON object
+type freeForm map[string]interface{}
+
+// apiOperations lists every route registered under /api. Keep it in step with router.go;
+// the delivery tests fail when a route is missing here.
+func apiOperations() []apiOperation {
+	deterministic := apiParam{Name: "deterministic", Type: "boolean", Description: "Generate a reproducible SBOM (fixed serial number and timestamps)"}
+	failOn := apiParam{Name: "fail_on", Type: "string", Description: "Comma-separated severities that fail this scan instead of high,critical"}
+	minScore := apiParam{Name: "min_score", Type: "number", Description: "Fail this scan when a dependency's risk score reaches this (0-10)"}
+	includeIndirect := apiParam{Name: "include_indirect", Type: "boolean", Description: "Include the indirect requirements of a go.mod as transitive dependencies, overriding GO_INCLUDE_INDIRECT"}
+
+	return []apiOperation{
+		// Applications
+		{Method: http.MethodPost, Path: "/api/applications/add", Tag: "applications", Summary: "Register an application from a dependency file",
+			Query:    []apiParam{includeIndirect},
+			JSONBody: model.AddApplicationJSONRequest{}, FormBody: model.AddApplicationRequest{}, OptionalFiles: []string{"gradle_properties"},
+			Responses: map[int]interface{}{200: model.AddApplicationResponse{}}, RateLimited: true, Idempotent: true},
+		{Method: http.MethodGet, Path: "/api/applications/list", Tag: "applications", Summary: "List applications",
+			Query:     []apiParam{{Name: "tags", Type: "string", Description: "Comma-separated tags an application must all carry, e.g. team:payments,env:prod"}},
+			Responses: map[int]interface{}{200: model.ListApplicationsResponse{}}},
+		{Method: http.MethodGet, Path: "/api/applications/compare", Tag: "applications", Summary: "Compare two applications' dependencies and the risk of their latest scans",
+			Query: []apiParam{
+				{Name: "a", Type: "string", Required: true, Description: "ID of the first application"},
+				{Name: "b", Type: "string", Required: true, Description: "ID of the second application"},
+			},
+			Responses: map[int]interface{}{200: model.ApplicationComparison{}}},
+		{Method: http.MethodGet, Path: "/api/applications/:app_id/list", Tag: "applications", Summary: "List the dependencies of an application",
+			Responses: map[int]interface{}{200: model.ListApplicationDependencyResponse{}}},
+		{Method: http.MethodPut, Path: "/api/applications/:app_id", Tag: "applications", Summary: "Update application metadata",
+			JSONBody:  model.UpdateApplicationRequest{},
+			Responses: map[int]interface{}{200: model.UpdateApplicationResponse{}}},
+		{Method: http.MethodPost, Path: "/api/applications/:app_id/clone", Tag: "applications", Summary: "Create an application with the runtime, framework and dependencies of an existing one",
+			JSONBody:  model.CloneApplicationRequest{},
+			Responses: map[int]interface{}{201: model.CloneApplicationResponse{}}},
+		{Method: http.MethodPatch, Path: "/api/applications/:app_id/recover", Tag: "applications", Summary: "Reactivate a removed application",
+			Responses: map[int]interface{}{200: nil}},
+		{Method: http.MethodDelete, Path: "/api/applications/:app_id/remove", Tag: "applications", Summary: "Remove (inactivate) an application",
+			Responses: map[int]interface{}{200: nil}},
+		{Method: http.MethodPost, Path: "/api/applications/:app_id/tags", Tag: "applications", Summary: "Add tags to an application",
+			JSONBody:  model.ApplicationTagsRequest{},
+			Responses: map[int]interface{}{200: model.ApplicationTagsResponse{}}},
+		{Method: http.MethodDelete, Path: "/api/applications/:app_id/tags", Tag: "applications", Summary: "Remove tags from an application",
+			JSONBody:  model.ApplicationTagsRequest{},
+			Responses: map[int]interface{}{200: model.ApplicationTagsResponse{}}},
+		{Method: http.MethodGet, Path: "/api/applications/:app_id/status", Tag: "applications", Summary: "Get the status of an application",
+			Responses: map[int]interface{}{200: freeForm{}}},
+		{Method: http.MethodGet, Path: "/api/applications/:app_id/audit", Tag: "applications", Summary: "List the audit trail of an application, newest first",
+			Query: []apiParam{
+				{Name: "limit", Type: "integer", Description: "Page size, 50 by default and at most 200"},
+				{Name: "offset", Type: "integer", Description: "Number of entries to skip"},
+			},
+			Responses: map[int]interface{}{200: model.ApplicationAuditResponse{}}},
+		{Method: http.MethodGet, Path: "/api/applications/:app_id/findings", Tag: "scans", Summary: "Query the findings of an application's stored scans, newest scan first",
+			Query: []apiParam{
+				{Name: "severity", Type: "string", Description: "Comma-separated severities, e.g. critical,high"},
+				{Name: "since", Type: "string", Description: "Only scans completed at or after this RFC 3339 time or YYYY-MM-DD date"},
+				{Name: "dependency", Type: "string", Description: "Case-insensitive substring of the dependency name; % and _ match literally"},
+				{Name: "vulnerability", Type: "string", Description: "Vulnerability ID, e.g. GHSA-29mw-wpgm-hmr9"},
+				{Name: "limit", Type: "integer", Description: "Page size, 50 by default and at most 500"},
+				{Name: "offset", Type: "integer", Description: "Number of findings to skip"},
+			},
+			Responses: map[int]interface{}{200: model.FindingsResponse{}}},
+		{Method: http.MethodGet, Path: "/api/applications/:app_id/findings.csv", Tag: "scans", Summary: "Download the findings of the application's latest completed scan as CSV",
+			Responses: map[int]interface{}{200: nil}, Download: "text/csv"},
+		{Method: http.MethodGet, Path: "/api/applications/:app_id/sbom", Tag: "applications", Summary: "Build a CycloneDX SBOM of the application's current dependencies with the vulnerabilities of its latest scan",
+			Responses: map[int]interface{}{200: json.RawMessage{}}},
+		{Method: http.MethodGet, Path: "/api/applications/:app_id/tree", Tag: "applications", Summary: "Group the application's dependencies into direct and transitive ones with the vulnerabilities of its latest scan",
+			Description: "Only the direct/transitive split is returned, as two flat lists ordered by name. Nodes have no parents: transitive dependencies come from go.mod `// indirect` requirements, which do not say which direct dependency pulls them in.",
+			Responses:   map[int]interface{}{200: model.DependencyTree{}}},
+		{Method: http.MethodGet, Path: "/api/applications/:app_id/manifest", Tag: "applications", Summary: "Get the dependency manifest the application was added from",
+			Responses: map[int]interface{}{200: model.ApplicationManifest{}}},
+		{Method: http.MethodPost, Path: "/api/applications/:app_id/reparse", Tag: "applications", Summary: "Re-run the current parser over the stored manifest and link the dependencies it now yields",
+			Responses: map[int]interface{}{200: model.ReparseApplicationResponse{}}, RateLimited: true},
+		{Method: http.MethodGet, Path: "/api/runtimes/:runtime/frameworks", Tag: "applications", Summary: "List the frameworks valid for a runtime (ID or name)",
+			Responses: map[int]interface{}{200: model.ListRuntimeFrameworksResponse{}}},
+
+		// Application dependencies
+		{Method: http.MethodPost, Path: "/api/applications/add/dependencies", Tag: "dependencies", Summary: "Add dependencies to an application",
+			JSONBody:  addApplicationDependencyRequest{},
+			Responses: map[int]interface{}{200: freeForm{}}, RateLimited: true},
+		{Method: http.MethodPatch, Path: "/api/applications/update/dependencies", Tag: "dependencies", Summary: "Update dependency versions and repositories",
+			JSONBody:  model.UpdateApplicationDependencyRequest{},
+			Responses: map[int]interface{}{200: model.UpdateApplicationDependencyResponse{}}},
+		{Method: http.MethodPatch, Path: "/api/applications/remove/dependencies", Tag: "dependencies", Summary: "Remove dependencies from an application",
+			JSONBody:  removeApplicationDependencyRequest{},
+			Responses: map[int]interface{}{201: freeForm{}}},
+		{Method: http.MethodPost, Path: "/api/check", Tag: "dependencies", Summary: "Check a single library version for vulnerabilities",
+			JSONBody:  model.CheckDependencyRequest{},
+			Responses: map[int]interface{}{200: helper.DependencyVulnerabilityResult{}}, RateLimited: true},
+		{Method: http.MethodPost, Path: "/api/manifests/diff", Tag: "dependencies", Summary: "Preview the dependency and vulnerability changes between two manifests",
+			FormBody: diffManifestsFormRequest{}, FormFiles: []string{"old_file", "new_file"},
+			Responses: map[int]interface{}{200: model.ManifestDiffResult{}}, RateLimited: true},
+		{Method: http.MethodGet, Path: "/api/dependencies", Tag: "dependencies", Summary: "Search the dependency catalog with the number of applications using each dependency",
+			Query: []apiParam{
+				{Name: "search", Type: "string", Description: "Case-insensitive substring of the dependency name; % and _ match literally"},
+				{Name: "limit", Type: "integer", Description: "Page size, 20 by default and at most 100"},
+				{Name: "offset", Type: "integer", Description: "Number of dependencies to skip"},
+			},
+			Responses: map[int]interface{}{200: model.DependencyCatalogResponse{}}},
+		{Method: http.MethodPost, Path: "/api/dependencies/:dep_id/refresh", Tag: "dependencies", Summary: "Re-fetch a dependency's default branch, latest commit and tags from GitHub",
+			Responses: map[int]interface{}{200: model.DependencyMetadataResponse{}}, RateLimited: true},
+		{Method: http.MethodGet, Path: "/api/dependencies/:dep_id/changelog", Tag: "dependencies", Summary: "List the commits and release notes between two versions of a dependency",
+			Query: []apiParam{
+				{Name: "from", Type: "string", Description: "Version to upgrade from, matched against the repository's tags", Required: true},
+				{Name: "to", Type: "string", Description: "Version to upgrade to, matched against the repository's tags", Required: true},
+			},
+			Responses: map[int]interface{}{200: model.DependencyChangelogResponse{}}, RateLimited: true},
+		{Method: http.MethodGet, Path: "/api/dependencies/:dep_id/maintenance", Tag: "dependencies", Summary: "Report a dependency's repository signals and maintenance risk",
+			Responses: map[int]interface{}{200: model.DependencyMaintenanceResponse{}}},
+		{Method: http.MethodPost, Path: "/api/dependencies/github/:owner/:repo/bulk-bump", Tag: "dependencies", Summary: "Move every application on one version of a dependency to another",
+			JSONBody:  model.BulkBumpRequest{},
+			Responses: map[int]interface{}{200: model.BulkBumpResponse{}}, RateLimited: true},
+
+		// Scans
+		{Method: http.MethodGet, Path: "/api/applications/:app_id/scan", Tag: "scans", Summary: "Scan an application; queued unless wait=true",
+			Query: []apiParam{
+				{Name: "wait", Type: "boolean", Description: "Run the scan synchronously and return its result"},
+				deterministic, failOn, minScore,
+			},
+			Responses:   map[int]interface{}{200: model.ScanApplicationResult{}, 202: model.ScanJobStatus{}},
+			RateLimited: true, Idempotent: true},
+		{Method: http.MethodPost, Path: "/api/scan/dependencies", Tag: "scans", Summary: "Scan dependency files without registering an application; repeated file fields are merged",
+			Query:    []apiParam{deterministic, failOn, minScore, includeIndirect},
+			JSONBody: model.ScanDependenciesJSONRequest{}, FormBody: scanDependenciesFormRequest{}, OptionalFiles: []string{"gradle_properties"},
+			Responses: map[int]interface{}{200: model.ScanApplicationResult{}}, Stream: scanStreamLine{}, RateLimited: true, Idempotent: true},
+		{Method: http.MethodGet, Path: "/api/applications/:app_id/schedules", Tag: "scans", Summary: "List the cron scan schedules of an application",
+			Responses: map[int]interface{}{200: model.ListScanSchedulesResponse{}}},
+		{Method: http.MethodPost, Path: "/api/applications/:app_id/schedules", Tag: "scans", Summary: "Scan an application whenever a cron expression (UTC) matches",
+			JSONBody:  model.CreateScanScheduleRequest{},
+			Responses: map[int]interface{}{201: model.ScanScheduleResponse{}}},
+		{Method: http.MethodPut, Path: "/api/applications/:app_id/schedules/:schedule_id", Tag: "scans", Summary: "Change a scan schedule's cron expression or enable/disable it",
+			JSONBody:  model.UpdateScanScheduleRequest{},
+			Responses: map[int]interface{}{200: model.ScanScheduleResponse{}}},
+		{Method: http.MethodDelete, Path: "/api/applications/:app_id/schedules/:schedule_id", Tag: "scans", Summary: "Remove a scan schedule",
+			Responses: map[int]interface{}{200: nil}},
+		{Method: http.MethodGet, Path: "/api/applications/:app_id/remediations", Tag: "scans", Summary: "List the remediations tracked for an application's findings",
+			Query:     []apiParam{{Name: "status", Type: "string", Description: "Only remediations with this status: open, in_progress or resolved"}},
+			Responses: map[int]interface{}{200: model.ListRemediationsResponse{}}},
+		{Method: http.MethodPost, Path: "/api/applications/:app_id/remediations", Tag: "scans", Summary: "Track the remediation of a vulnerability of a dependency, e.g. with a link to its ticket",
+			JSONBody:  model.CreateRemediationRequest{},
+			Responses: map[int]interface{}{201: model.RemediationResponse{}}},
+		{Method: http.MethodPut, Path: "/api/applications/:app_id/remediations/:remediation_id", Tag: "scans", Summary: "Change a remediation's status, ticket or notes",
+			JSONBody:  model.UpdateRemediationRequest{},
+			Responses: map[int]interface{}{200: model.RemediationResponse{}}},
+		{Method: http.MethodDelete, Path: "/api/applications/:app_id/remediations/:remediation_id", Tag: "scans", Summary: "Stop tracking a remediation",
+			Responses: map[int]interface{}{200: nil}},
+		{Method: http.MethodGet, Path: "/api/scan/dependencies/:app_name/:sbom_id", Tag: "scans", Summary: "Download a stored SBOM (base64 encoded)",
+			Responses: map[int]interface{}{200: []byte{}}},
+		{Method: http.MethodGet, Path: "/api/scans/:scan_id", Tag: "scans", Summary: "Get a stored scan result",
+			Responses: map[int]interface{}{200: model.ScanApplicationResult{}}},
+		{Method: http.MethodGet, Path: "/api/scans/:scan_id/status", Tag: "scans", Summary: "Get the progress of a scan",
+			Responses: map[int]interface{}{200: model.ScanJobStatus{}}},
+		{Method: http.MethodPost, Path: "/api/scans/:scan_id/evaluate", Tag: "scans", Summary: "Dry-run a policy against a stored scan without saving it",
+			JSONBody:  model.EvaluatePolicyRequest{},
+			Responses: map[int]interface{}{200: model.PolicyEvaluation{}}},
+		{Method: http.MethodGet, Path: "/api/dashboard/summary", Tag: "scans", Summary: "Organization-wide rollup of the latest scan per application",
+			Query:     []apiParam{{Name: "top", Type: "integer", Description: "Number of dependencies and applications to rank"}},
+			Responses: map[int]interface{}{200: model.DashboardSummary{}}},
+		{Method: http.MethodGet, Path: "/api/severities", Tag: "scans", Summary: "List the severities with their display labels and colors",
+			Responses: map[int]interface{}{200: []helper.SeverityStyle{}}},
+		{Method: http.MethodGet, Path: "/api/severity-overrides", Tag: "scans", Summary: "List the severity overrides in the order scans break ties between equally specific ones",
+			Query:     []apiParam{{Name: "app_id", Type: "string", Description: "Also list the overrides of this application, which come first"}},
+			Responses: map[int]interface{}{200: model.ListSeverityOverridesResponse{}}},
+		{Method: http.MethodPost, Path: "/api/severity-overrides", Tag: "scans", Summary: "Set the severity of the vulnerabilities matching a CWE, CVE pattern or ecosystem",
+			JSONBody:  model.CreateSeverityOverrideRequest{},
+			Responses: map[int]interface{}{201: model.SeverityOverrideResponse{}}},
+		{Method: http.MethodDelete, Path: "/api/severity-overrides/:override_id", Tag: "scans", Summary: "Remove a severity override",
+			Responses: map[int]interface{}{200: nil}},
+
+		// Monitoring
+		{Method: http.MethodPost, Path: "/api/scan/:app_id/start", Tag: "monitoring", Summary: "Start monitoring an application's dependencies",
+			Responses: map[int]interface{}{200: nil}},
+		{Method: http.MethodPost, Path: "/api/scan/:app_id/stop", Tag: "monitoring", Summary: "Stop monitoring an application's dependencies",
+			Responses: map[int]interface{}{200: nil}},
+		{Method: http.MethodGet, Path: "/api/scan/:app_id/status", Tag: "monitoring", Summary: "Get the monitoring status of an application",
+			Responses: map[int]interface{}{200: freeForm{}}},
+
+		// Administration
+		{Method: http.MethodPost, Path: "/api/admin/seed", Tag: "admin", Summary: "Create the seed runtimes and frameworks missing from the database",
+			Responses: map[int]interface{}{200: model.SeedResult{}}},
+		{Method: http.MethodGet, Path: "/api/admin/seed/status", Tag: "admin", Summary: "List the runtimes and frameworks and the seed entries missing from the database",
+			Responses: map[int]interface{}{200: model.SeedStatusResponse{}}},
+	}
+}
+
+// OpenAPISpec renders the OpenAPI 3 document for the HTTP API
+func OpenAPISpec() ([]byte, error) {
+	spec, err := json.MarshalIndent(buildOpenAPIDocument(apiOperations()), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(spec, '\n'), nil
+}
+
+func buildOpenAPIDocument(ops []apiOperation) map[string]interface{} {
+	schemas := newSchemaRegistry()
+	schemas.components["ErrorResponse"] = map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"success": map[string]interface{}{"type": "boolean"},
+			"message": map[string]interface{}{"type": "string"},
+			"error":   map[string]interface{}{},
+		},
+	}
+
+	paths := map[string]interface{}{}
+	for _, op := range ops {
+		path, params := openAPIPath(op.Path)
+		for _, q := range op.Query {
+			param := map[string]interface{}{
+				"name": q.Name, "in": "query", "description": q.Description,
+				"schema": map[string]interface{}{"type": q.Type},
+			}
+			if q.Required {
+				param["required"] = true
+			}
+			params = append(params, param)
+		}
+		if op.Idempotent {
+			params = append(params, map[string]interface{}{
+				"name": IdempotencyKeyHeader, "in": "header",
+				"description": "Client-chosen key; retries with the same key replay the first response instead of running again",
+				"schema":      map[string]interface{}{"type": "string", "maxLength": maxIdempotencyKeyLength},
+			})
+		}
+
+		operation := map[string]interface{}{
+			"tags":        []string{op.Tag},
+			"summary":     op.Summary,
+			"operationId": operationID(op),
+			"responses":   openAPIResponses(schemas, op),
+		}
+		if op.Description != "" {
+			operation["description"] = op.Description
+		}
+		if len(params) > 0 {
+			operation["parameters"] = params
+		}
+		if body := openAPIRequestBody(schemas, op); body != nil {
+			operation["requestBody"] = body
+		}
+
+		item, ok := paths[path].(map[string]interface{})
+		if !ok {
+			item = map[string]interface{}{}
+			paths[path] = item
+		}
+		item[strings.ToLower(op.Method)] = operation
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":       "Elang API",
+			"version":     "1.0",
+			"description": "Dependency monitoring and vulnerability scanning. Every response is wrapped in {success, message, data}; errors use ErrorResponse.",
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": schemas.components,
+			"securitySchemes": map[string]interface{}{
+				"bearerAuth": map[string]interface{}{"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+			},
+		},
+		// Only enforced when the server is started with JWT_SECRET
+		"security": []interface{}{map[string]interface{}{"bearerAuth": []string{}}},
+	}
+}
+
+// openAPIPath converts a gin route to OpenAPI syntax and returns its path parameters
+func openAPIPath(route string) (string, []interface{}) {
+	segments := strings.Split(route, "/")
+	var params []interface{}
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			name := segment[1:]
+			segments[i] = "{" + name + "}"
+			params = append(params, map[string]interface{}{
+				"name": name, "in": "path", "required": true,
+				"schema": map[string]interface{}{"type": "string"},
+			})
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
+
+// operationID derives a stable identifier such as getApiApplicationsAppIdScan
+func operationID(op apiOperation) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(op.Method))
+	upper := true
+	for _, r := range op.Path {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func openAPIRequestBody(schemas *schemaRegistry, op apiOperation) map[string]interface{} {
+	content := map[string]interface{}{}
+	if op.JSONBody != nil {
+		content["application/json"] = map[string]interface{}{"schema": schemas.schemaFor(reflect.TypeOf(op.JSONBody))}
+	}
+	if op.FormBody != nil {
+		form := schemas.objectSchema(reflect.TypeOf(op.FormBody), "form")
+		files := op.FormFiles
+		if len(files) == 0 {
+			files = []string{"file"}
+		}
+		required, _ := form["required"].([]string)
+		for _, file := range files {
+			form["properties"].(map[string]interface{})[file] = map[string]interface{}{"type": "string", "format": "binary"}
+			required = append(required, file)
+		}
+		for _, file := range op.OptionalFiles {
+			form["properties"].(map[string]interface{})[file] = map[string]interface{}{"type": "string", "format": "binary"}
+		}
+		form["required"] = required
+		content["multipart/form-data"] = map[string]interface{}{"schema": form}
+	}
+	if len(content) == 0 {
+		return nil
+	}
+	return map[string]interface{}{"required": true, "content": content}
+}
+
+func openAPIResponses(schemas *schemaRegistry, op apiOperation) map[string]interface{} {
+	responses := map[string]interface{}{}
+	for status, data := range op.Responses {
+		dataSchema := map[string]interface{}{"nullable": true}
+		if data != nil {
+			dataSchema = schemas.schemaFor(reflect.TypeOf(data))
+		}
+		content := map[string]interface{}{"application/json": map[string]interface{}{"schema": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"success": map[string]interface{}{"type": "boolean"},
+				"message": map[string]interface{}{"type": "string"},
+				"data":    dataSchema,
+			},
+		}}}
+		if status == http.StatusOK && op.Download != "" {
+			content = map[string]interface{}{op.Download: map[string]interface{}{"schema": map[string]interface{}{"type": "string", "format": "binary"}}}
+		}
+		if status == http.StatusOK && op.Stream != nil {
+			// Each line of the stream is one document of this schema
+			content[mimeNDJSON] = map[string]interface{}{"schema": schemas.schemaFor(reflect.TypeOf(op.Stream))}
+		}
+		responses[strconv.Itoa(status)] = map[string]interface{}{
+			"description": http.StatusText(status),
+			"content":     content,
+		}
+	}
+
+	errorContent := map[string]interface{}{"application/json": map[string]interface{}{
+		"schema": map[string]interface{}{"$ref": "#/components/schemas/ErrorResponse"},
+	}}
+	if op.RateLimited {
+		responses["429"] = map[string]interface{}{"description": "Rate limit exceeded; see the Retry-After header", "content": errorContent}
+	}
+	if op.Idempotent {
+		responses["409"] = map[string]interface{}{"description": "A request with the same Idempotency-Key is still running", "content": errorContent}
+	}
+	responses["default"] = map[string]interface{}{"description": "Error", "content": errorContent}
+	return responses
+}
+
+// schemaRegistry turns Go types into OpenAPI schemas, registering named structs as components
+type schemaRegistry struct {
+	components map[string]interface{}
+	names      map[reflect.Type]string
+}
+
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{components: map[string]interface{}{}, names: map[reflect.Type]string{}}
+}
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+func (r *schemaRegistry) schemaFor(t reflect.Type) map[string]interface{} {
+	if t == timeType {
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+	if t == rawMessageType {
+		return map[string]interface{}{} // any JSON value
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return r.schemaFor(t.Elem())
+	case reflect.Interface:
+		return map[string]interface{}{}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "format": "byte"}
+		}
+		return map[string]interface{}{"type": "array", "items": r.schemaFor(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": r.schemaFor(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return r.objectSchema(t, "json")
+		}
+		return map[string]interface{}{"$ref": "#/components/schemas/" + r.register(t)}
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// register adds a named struct to the components once and returns its component name
+func (r *schemaRegistry) register(t reflect.Type) string {
+	if name, ok := r.names[t]; ok {
+		return name
+	}
+	name := exportedName(t.Name())
+	if _, taken := r.components[name]; taken {
+		pkg := t.PkgPath()[strings.LastIndex(t.PkgPath(), "/")+1:]
+		name = exportedName(pkg) + name
+	}
+	r.names[t] = name
+	r.components[name] = nil // reserve the name before recursing into self-referencing fields
+	r.components[name] = r.objectSchema(t, "json")
+	return name
+}
+
+// objectSchema describes a struct's fields as named by tagKey ("json" or "form").
+// Fields with a binding:"required" tag are listed as required.
+func (r *schemaRegistry) objectSchema(t reflect.Type, tagKey string) map[string]interface{} {
+	properties := map[string]interface{}{}
+	var required []string
+	r.collectFields(t, tagKey, properties, &required)
+	schema := map[string]interface{}{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		sort.Strings(required)
+		schema["required"] = required
+	}
+	return schema
+}
+
+func (r *schemaRegistry) collectFields(t reflect.Type, tagKey string, properties map[string]interface{}, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag := field.Tag.Get(tagKey)
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			r.collectFields(field.Type, tagKey, properties, required)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		properties[name] = r.schemaFor(field.Type)
+		if strings.Contains(field.Tag.Get("binding"), "required") {
+			*required = append(*required, name)
+		}
+	}
+}
+
+func exportedName(name string) string {
+	if name == "" {
+		return name
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+var (
+	openAPIOnce sync.Once
+	openAPIJSON []byte
+	openAPIErr  error
+)
+
+// openAPIDocument serves the OpenAPI document at /docs/openapi.json
+func openAPIDocument(c *gin.Context) {
+	openAPIOnce.Do(func() { openAPIJSON, openAPIErr = OpenAPISpec() })
+	if openAPIErr != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": "failed to render OpenAPI document: " + openAPIErr.Error()})
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", openAPIJSON)
+}
+
+// SwaggerUIVersion is the swagger-ui-dist release the /docs page loads. After changing it, run
+// `make swagger-ui` to regenerate the integrity hashes of its assets.
+const SwaggerUIVersion = "5.17.14"
+
+// SwaggerUIAssets are the swagger-ui-dist files the /docs page loads
+var SwaggerUIAssets = []string{"swagger-ui.css", "swagger-ui-bundle.js"}
+
+// SwaggerUIAssetURL is the CDN URL of a file of the pinned Swagger UI release
+func SwaggerUIAssetURL(file string) string {
+	return "https://unpkg.com/swagger-ui-dist@" + SwaggerUIVersion + "/" + file
+}
+
+// swaggerUIAssetAttributes are the src or href attribute of a Swagger UI asset together with its integrity
+// hash, so browsers refuse a file the CDN serves altered. Without a hash generated for the pinned release
+// the asset is loaded unchecked.
+func swaggerUIAssetAttributes(attribute, file string) string {
+	url := SwaggerUIAssetURL(file)
+	attributes := attribute + `="` + url + `" crossorigin="anonymous"`
+	if hash, ok := swaggerUIIntegrity[url]; ok {
+		attributes += ` integrity="` + hash + `"`
+	}
+	return attributes
+}
+
+// swaggerUIPage loads the pinned Swagger UI release from a CDN and points it at the document served next to it
+var swaggerUIPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Elang API</title>
+  <link rel="stylesheet" ` + swaggerUIAssetAttributes("href", "swagger-ui.css") + `>
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script ` + swaggerUIAssetAttributes("src", "swagger-ui-bundle.js") + `></script>
+  <script>
+    window.onload = function () {
+      window.ui = SwaggerUIBundle({ url: "docs/openapi.json", dom_id: "#swagger-ui" });
+    };
+  </script>
+</body>
+</html>
+`
+
+// swaggerUI serves an interactive Swagger UI for the API at /docs
+func swaggerUI(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(swaggerUIPage))
+}
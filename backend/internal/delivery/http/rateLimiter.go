@@ -0,0 +1,122 @@
+package http
+
+import (
+	"elang-backend/internal/model/responses"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimitConfig configures the token-bucket limiter applied to the heavy scan endpoints.
+// A zero RequestsPerMinute disables rate limiting.
+type RateLimitConfig struct {
+	RequestsPerMinute int // Sustained requests per minute allowed per client
+	Burst             int // Requests a client may make at once; defaults to RequestsPerMinute when zero
+}
+
+// idleBucketTTL is how long an untouched bucket is kept before it is evicted
+const idleBucketTTL = 10 * time.Minute
+
+type tokenBucket struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+// rateLimiter keeps one token bucket per client key. Buckets refill continuously at rate tokens
+// per second up to burst, and idle buckets are evicted so the map does not grow without bound.
+type rateLimiter struct {
+	mu        sync.Mutex
+	rate      float64
+	burst     float64
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func newRateLimiter(cfg RateLimitConfig) *rateLimiter {
+	burst := cfg.Burst
+	if burst <= 0 {
+		burst = cfg.RequestsPerMinute
+	}
+	return &rateLimiter{
+		rate:    float64(cfg.RequestsPerMinute) / 60,
+		burst:   float64(burst),
+		buckets: make(map[string]*tokenBucket),
+		now:     time.Now,
+	}
+}
+
+// allow takes a token from key's bucket. When the bucket is empty it reports how long the
+// client has to wait for the next token.
+func (l *rateLimiter) allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	bucket, exists := l.buckets[key]
+	if !exists {
+		bucket = &tokenBucket{tokens: l.burst, lastSeen: now}
+		l.buckets[key] = bucket
+	} else {
+		elapsed := now.Sub(bucket.lastSeen).Seconds()
+		bucket.tokens = math.Min(l.burst, bucket.tokens+elapsed*l.rate)
+		bucket.lastSeen = now
+	}
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return true, 0
+	}
+	wait := time.Duration((1 - bucket.tokens) / l.rate * float64(time.Second))
+	return false, wait
+}
+
+// sweep drops buckets that have been idle long enough to be full again anyway
+func (l *rateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < idleBucketTTL {
+		return
+	}
+	for key, bucket := range l.buckets {
+		if now.Sub(bucket.lastSeen) >= idleBucketTTL {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
+
+// clientKey identifies the caller: the authenticated user, or the client IP when authentication is disabled
+func clientKey(c *gin.Context) string {
+	if user, ok := CurrentUser(c); ok {
+		return "user:" + user.ID
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// rateLimitMiddleware limits requests per authenticated user, or per client IP when authentication
+// is disabled. Rejected requests get 429 with a Retry-After header in whole seconds.
+func rateLimitMiddleware(cfg RateLimitConfig) gin.HandlerFunc {
+	if cfg.RequestsPerMinute <= 0 {
+		return func(c *gin.Context) { c.Next() }
+	}
+	limiter := newRateLimiter(cfg)
+
+	return func(c *gin.Context) {
+		allowed, wait := limiter.allow(clientKey(c))
+		if !allowed {
+			retryAfter := int(math.Ceil(wait.Seconds()))
+			if retryAfter < 1 {
+				retryAfter = 1
+			}
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+			responses.JSONErrorResponse(c, 429, "rate limit exceeded, retry later", nil)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
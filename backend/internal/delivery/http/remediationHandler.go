@@ -0,0 +1,68 @@
+package http
+
+import (
+	"elang-backend/internal/model"
+	"elang-backend/internal/model/responses"
+	"elang-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RemediationHandler struct {
+	remediationService services.RemediationInterface
+}
+
+func NewRemediationHandler(remediationService services.RemediationInterface) *RemediationHandler {
+	return &RemediationHandler{
+		remediationService: remediationService,
+	}
+}
+
+// CreateRemediation handles tracking the remediation of a finding
+func (h *RemediationHandler) CreateRemediation(c *gin.Context) {
+	var req model.CreateRemediationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responses.JSONErrorResponse(c, 400, "invalid request: "+err.Error(), nil)
+		return
+	}
+	resp, err := h.remediationService.CreateRemediation(c.Request.Context(), c.Param("app_id"), req)
+	if err != nil {
+		responses.JSONErrorResponse(c, statusCodeFromError(err), "failed to create remediation: "+err.Error(), nil)
+		return
+	}
+	responses.JSONSuccessResponse(c, 201, "remediation created", resp)
+}
+
+// ListRemediations handles listing the remediations of an application
+func (h *RemediationHandler) ListRemediations(c *gin.Context) {
+	resp, err := h.remediationService.ListRemediations(c.Request.Context(), c.Param("app_id"), c.Query("status"))
+	if err != nil {
+		responses.JSONErrorResponse(c, statusCodeFromError(err), "failed to list remediations: "+err.Error(), nil)
+		return
+	}
+	responses.JSONSuccessResponse(c, 200, "remediations fetched", resp)
+}
+
+// UpdateRemediation handles changing the status, ticket or notes of a remediation
+func (h *RemediationHandler) UpdateRemediation(c *gin.Context) {
+	var req model.UpdateRemediationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responses.JSONErrorResponse(c, 400, "invalid request: "+err.Error(), nil)
+		return
+	}
+	resp, err := h.remediationService.UpdateRemediation(c.Request.Context(), c.Param("app_id"), c.Param("remediation_id"), req)
+	if err != nil {
+		responses.JSONErrorResponse(c, statusCodeFromError(err), "failed to update remediation: "+err.Error(), nil)
+		return
+	}
+	responses.JSONSuccessResponse(c, 200, "remediation updated", resp)
+}
+
+// DeleteRemediation handles removing a remediation
+func (h *RemediationHandler) DeleteRemediation(c *gin.Context) {
+	if err := h.remediationService.DeleteRemediation(c.Request.Context(), c.Param("app_id"), c.Param("remediation_id")); err != nil {
+		responses.JSONErrorResponse(c, statusCodeFromError(err), "failed to delete remediation: "+err.Error(), nil)
+		return
+	}
+	responses.JSONSuccessResponse(c, 200, "remediation deleted", nil)
+}
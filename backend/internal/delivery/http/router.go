@@ -1,11 +1,29 @@
 package http
 
-import "github.com/gin-gonic/gin"
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
 
 type RouteConfig struct {
-	Router              *gin.Engine
-	AppHandler          ApplicationHandler
-	DependenciesHandler DependenciesHandler
+	Router                  *gin.Engine
+	AppHandler              ApplicationHandler
+	DependenciesHandler     DependenciesHandler
+	AdminHandler            AdminHandler
+	ScheduleHandler         ScheduleHandler
+	RemediationHandler      RemediationHandler
+	SeverityOverrideHandler SeverityOverrideHandler
+	Auth                    AuthConfig      // JWT authentication for /api; disabled when Auth.Secret is empty
+	RateLimit               RateLimitConfig // Limits for the heavy scan and upload endpoints; disabled when RequestsPerMinute is zero
+	MaxBodyBytes            int64           // Largest request body accepted by the manifest upload endpoints; unlimited when zero
+	IdempotencyTTL          time.Duration   // How long Idempotency-Key responses are replayed; disabled when zero
+	CORS                    CORSConfig      // Browser origins allowed to call the API; none when CORS.AllowedOrigins is empty
+	StorageDegraded         bool            // Object storage was unreachable at startup, so /health reports the service degraded
+
+	heavyLimiter gin.HandlerFunc
+	bodyLimiter  gin.HandlerFunc
+	idempotent   gin.HandlerFunc
 }
 
 // Setup initializes all routes and applies global middleware.
@@ -13,19 +31,89 @@ func (c *RouteConfig) Setup() {
 	// Apply global middleware
 	c.Router.Use(gin.Logger())
 	c.Router.Use(gin.Recovery())
-	c.Router.Use(corsMiddleware()) // Add CORS support
+	c.Router.Use(corsMiddleware(c.CORS))
 
 	// Health check endpoint (no auth required)
-	c.Router.GET("/health", healthCheck)
+	c.Router.GET("/health", c.healthCheck)
+
+	// API contract (OpenAPI 3) and Swagger UI (no auth required)
+	c.Router.GET("/docs", swaggerUI)
+	c.Router.GET("/docs/openapi.json", openAPIDocument)
+
+	// One shared budget for the endpoints that parse manifests or fan out to GitHub/OSV
+	c.heavyLimiter = rateLimitMiddleware(c.RateLimit)
+
+	// Uploads are refused before their body is buffered, and multipart files up to the limit stay in memory
+	c.bodyLimiter = bodyLimitMiddleware(c.MaxBodyBytes)
+	if c.MaxBodyBytes > 0 && c.MaxBodyBytes < c.Router.MaxMultipartMemory {
+		c.Router.MaxMultipartMemory = c.MaxBodyBytes
+	}
+
+	// Retried application and scan requests carrying the same Idempotency-Key run once
+	c.idempotent = idempotencyMiddleware(c.IdempotencyTTL)
 
 	// Main API group
 	api := c.Router.Group("/api")
+	if c.Auth.Secret != "" {
+		api.Use(jwtAuthMiddleware(c.Auth))
+	}
 	{
 		// Application Management APIs (CRUD and monitoring control)
 		c.setupApplicationRoutes(api)
 
 		// Dependencies related routes
 		c.setupDependenciesRoute(api)
+
+		// Frameworks that can be chosen for a runtime (ID or name)
+		api.GET("/runtimes/:runtime/frameworks", c.AppHandler.ListRuntimeFrameworks)
+
+		// Single dependency vulnerability lookup
+		api.POST("/check", c.heavyLimiter, c.DependenciesHandler.CheckDependency)
+
+		// Pre-merge preview of the dependency and vulnerability changes between two manifests
+		api.POST("/manifests/diff", c.heavyLimiter, c.bodyLimiter, c.DependenciesHandler.DiffManifests)
+
+		// Stored scan results
+		c.setupScanResultRoutes(api)
+
+		// Organization-wide rollup of the latest scan per application
+		api.GET("/dashboard/summary", c.DependenciesHandler.GetDashboardSummary)
+
+		// Dependency catalog across all applications
+		api.GET("/dependencies", c.DependenciesHandler.ListDependencies)
+
+		// Re-fetch one dependency's GitHub metadata
+		api.POST("/dependencies/:dep_id/refresh", c.heavyLimiter, c.AppHandler.RefreshDependencyMetadata)
+
+		// Commits and release notes between two versions of a dependency
+		api.GET("/dependencies/:dep_id/changelog", c.heavyLimiter, c.AppHandler.GetDependencyChangelog)
+
+		// Stored repository signals (archived, last commit, stars, collaborators) and maintenance risk of a dependency
+		api.GET("/dependencies/:dep_id/maintenance", c.AppHandler.GetDependencyMaintenance)
+
+		// Move every application on one version of a GitHub dependency to another. The static "github" segment keeps
+		// the owner wildcard from clashing with :dep_id above, which the router would reject.
+		api.POST("/dependencies/github/:owner/:repo/bulk-bump", c.heavyLimiter, c.AppHandler.BulkUpdateDependencyVersion)
+
+		// Display labels and colors of the severities
+		api.GET("/severities", c.DependenciesHandler.ListSeverities)
+
+		// Custom severities of the vulnerabilities matching a CWE, CVE pattern or ecosystem, applied by later scans
+		api.GET("/severity-overrides", c.SeverityOverrideHandler.ListSeverityOverrides)
+		api.POST("/severity-overrides", c.SeverityOverrideHandler.CreateSeverityOverride)
+		api.DELETE("/severity-overrides/:override_id", c.SeverityOverrideHandler.DeleteSeverityOverride)
+
+		// Operator endpoints
+		c.setupAdminRoutes(api)
+	}
+}
+
+// setupAdminRoutes registers operator endpoints under /api/admin, restricted to the admin subjects.
+func (c *RouteConfig) setupAdminRoutes(api *gin.RouterGroup) {
+	admin := api.Group("/admin", adminOnlyMiddleware(c.Auth))
+	{
+		admin.POST("/seed", c.AdminHandler.Seed)             // Create missing seed runtimes and frameworks
+		admin.GET("/seed/status", c.AdminHandler.SeedStatus) // Compare the runtimes and frameworks with the seed
 	}
 }
 
@@ -34,20 +122,42 @@ func (c *RouteConfig) setupApplicationRoutes(api *gin.RouterGroup) {
 	apps := api.Group("/applications")
 	{
 		// Application CRUD operations
-		apps.POST("/add", c.AppHandler.AddApplication)                    // Add new application
-		apps.GET("/list", c.AppHandler.ListApplications)                  // List all applications
-		apps.GET("/:app_id/list", c.AppHandler.ListApplicationDependency) // List dependencies for an application
-		apps.PATCH("/:app_id/recover", c.AppHandler.RecoverApplication)   // Recover a deleted application
-		apps.DELETE("/:app_id/remove", c.AppHandler.RemoveApplication)    // Remove an application
+		apps.POST("/add", c.heavyLimiter, c.bodyLimiter, c.idempotent, c.AppHandler.AddApplication) // Add new application
+		apps.GET("/list", c.AppHandler.ListApplications)                                            // List all applications
+		apps.GET("/compare", c.AppHandler.CompareApplications)                                      // Compare two applications' dependencies and risk
+		apps.GET("/:app_id/list", c.AppHandler.ListApplicationDependency)                           // List dependencies for an application
+		apps.PUT("/:app_id", c.AppHandler.UpdateApplication)                                        // Update application metadata
+		apps.POST("/:app_id/clone", c.AppHandler.CloneApplication)                                  // Copy an application's dependencies into a new one
+		apps.PATCH("/:app_id/recover", c.AppHandler.RecoverApplication)                             // Recover a deleted application
+		apps.DELETE("/:app_id/remove", c.AppHandler.RemoveApplication)                              // Remove an application
+		apps.POST("/:app_id/tags", c.AppHandler.AddApplicationTags)                                 // Tag an application
+		apps.DELETE("/:app_id/tags", c.AppHandler.RemoveApplicationTags)                            // Untag an application
 
 		// Dependency management for applications
-		apps.POST("/add/dependencies", c.AppHandler.AddApplicationDependency)        // Add dependencies to an application
-		apps.PATCH("/update/dependencies", c.AppHandler.UpdateApplicationDependency) // Update application dependencies
-		apps.PATCH("/remove/dependencies", c.AppHandler.RemoveApplicationDependency) // Remove dependencies from an application
+		apps.POST("/add/dependencies", c.heavyLimiter, c.AppHandler.AddApplicationDependency) // Add dependencies to an application
+		apps.PATCH("/update/dependencies", c.AppHandler.UpdateApplicationDependency)          // Update application dependencies
+		apps.PATCH("/remove/dependencies", c.AppHandler.RemoveApplicationDependency)          // Remove dependencies from an application
 
 		// Monitoring control
-		apps.GET("/:app_id/status", c.AppHandler.GetApplicationStatus) // Get application status
-		apps.GET("/:app_id/scan", c.AppHandler.ScanApplication)        // Scan application dependencies (OSV)
+		apps.GET("/:app_id/status", c.AppHandler.GetApplicationStatus)                        // Get application status
+		apps.GET("/:app_id/audit", c.AppHandler.ListApplicationAudit)                         // List the application's audit trail
+		apps.GET("/:app_id/findings", c.DependenciesHandler.ListFindings)                     // Query findings across the application's scans
+		apps.GET("/:app_id/findings.csv", c.DependenciesHandler.ExportFindingsCSV)            // Latest scan's findings as a CSV download
+		apps.GET("/:app_id/sbom", c.AppHandler.GetApplicationSBOM)                            // SBOM of the current dependencies and their last known vulnerabilities
+		apps.GET("/:app_id/tree", c.AppHandler.GetDependencyTree)                             // Direct and transitive dependencies with their last known vulnerabilities
+		apps.GET("/:app_id/manifest", c.AppHandler.GetApplicationManifest)                    // Manifest the application was added from
+		apps.POST("/:app_id/reparse", c.heavyLimiter, c.AppHandler.ReparseApplication)        // Re-run the current parser over the stored manifest
+		apps.GET("/:app_id/scan", c.heavyLimiter, c.idempotent, c.AppHandler.ScanApplication) // Scan application dependencies (OSV), async unless ?wait=true
+
+		// Cron schedules of point-in-time scans, separate from interval monitoring
+		apps.GET("/:app_id/schedules", c.ScheduleHandler.ListSchedules)
+		apps.POST("/:app_id/schedules", c.ScheduleHandler.CreateSchedule)
+		apps.PUT("/:app_id/schedules/:schedule_id", c.ScheduleHandler.UpdateSchedule)
+		apps.DELETE("/:app_id/schedules/:schedule_id", c.ScheduleHandler.DeleteSchedule)
+		apps.GET("/:app_id/remediations", c.RemediationHandler.ListRemediations)
+		apps.POST("/:app_id/remediations", c.RemediationHandler.CreateRemediation)
+		apps.PUT("/:app_id/remediations/:remediation_id", c.RemediationHandler.UpdateRemediation)
+		apps.DELETE("/:app_id/remediations/:remediation_id", c.RemediationHandler.DeleteRemediation)
 	}
 }
 
@@ -56,7 +166,7 @@ func (c *RouteConfig) setupDependenciesRoute(api *gin.RouterGroup) {
 	scan := api.Group("/scan")
 	{
 		// Scan application dependencies (OSV)
-		scan.POST("/dependencies", c.DependenciesHandler.ScanApplication)
+		scan.POST("/dependencies", c.heavyLimiter, c.bodyLimiter, c.idempotent, c.DependenciesHandler.ScanApplication)
 		// Get SBOM by its ID
 		scan.GET("/dependencies/:app_name/:sbom_id", c.DependenciesHandler.GetSBOM)
 
@@ -69,28 +179,34 @@ func (c *RouteConfig) setupDependenciesRoute(api *gin.RouterGroup) {
 	}
 }
 
-// corsMiddleware provides CORS support for cross-origin requests.
-// Allows all origins and common HTTP methods/headers.
-func corsMiddleware() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		c.Header("Access-Control-Allow-Origin", "*")
-		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(204)
-			return
-		}
-		c.Next()
+// setupScanResultRoutes registers endpoints for persisted scan results under /api/scans.
+func (c *RouteConfig) setupScanResultRoutes(api *gin.RouterGroup) {
+	scans := api.Group("/scans")
+	{
+		// Get the full stored scan result by its ID
+		scans.GET("/:scan_id", c.DependenciesHandler.GetScanResult)
+		// Get the status of a scan (queued, running, completed, failed)
+		scans.GET("/:scan_id/status", c.DependenciesHandler.GetScanStatus)
+		// Dry-run a proposed policy against a stored scan
+		scans.POST("/:scan_id/evaluate", c.DependenciesHandler.EvaluateScanPolicy)
 	}
 }
 
 // healthCheck provides a simple health check endpoint.
-// Returns service status and enabled features.
-func healthCheck(c *gin.Context) {
-	c.JSON(200, gin.H{
-		"status":  "healthy",
+// Returns service status, the state of its components and enabled features. A degraded
+// service still answers 200: it serves requests, only without the failed component.
+func (c *RouteConfig) healthCheck(ctx *gin.Context) {
+	status, storage := "healthy", "available"
+	if c.StorageDegraded {
+		status, storage = "degraded", "degraded"
+	}
+	ctx.JSON(200, gin.H{
+		"status":  status,
 		"service": "elang-v1",
 		"version": "1.0",
+		"components": gin.H{
+			"storage": storage,
+		},
 		"features": gin.H{
 			"enhanced_security_detection": true,
 			"progressive_monitoring":      true,
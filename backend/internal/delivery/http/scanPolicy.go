@@ -0,0 +1,19 @@
+package http
+
+import (
+	"elang-backend/internal/helper"
+
+	"github.com/gin-gonic/gin"
+)
+
+// scanPolicyOverride reads an optional fail_on/min_score override of the default scan policy. failOn and
+// minScore are the request body's fields, which take precedence over the query parameters of the same names.
+func scanPolicyOverride(c *gin.Context, failOn, minScore string) (*helper.ScanPolicyOverride, error) {
+	if failOn == "" {
+		failOn = c.Query("fail_on")
+	}
+	if minScore == "" {
+		minScore = c.Query("min_score")
+	}
+	return helper.ParseScanPolicyOverride(failOn, minScore)
+}
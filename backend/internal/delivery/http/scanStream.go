@@ -0,0 +1,67 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+
+	"elang-backend/internal/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+// mimeNDJSON selects streamed scan output, one JSON document per line
+const mimeNDJSON = "application/x-ndjson"
+
+// scanStreamLine is one line of a streamed scan: a finding as soon as its dependency was checked,
+// then a final summary (the scan result without findings), or an error if the scan failed midway
+type scanStreamLine struct {
+	Type    string             `json:"type"` // finding, summary or error
+	Finding *model.ScanFinding `json:"finding,omitempty"`
+	Summary interface{}        `json:"summary,omitempty"`
+	Message string             `json:"message,omitempty"`
+}
+
+// wantsNDJSON reports whether the client asked for streamed NDJSON output via the Accept header
+func wantsNDJSON(c *gin.Context) bool {
+	for _, part := range strings.Split(c.GetHeader("Accept"), ",") {
+		mediaType, _, _ := strings.Cut(part, ";")
+		if strings.EqualFold(strings.TrimSpace(mediaType), mimeNDJSON) {
+			return true
+		}
+	}
+	return false
+}
+
+// ndjsonStream writes lines to the response and flushes each one. The status and headers are sent
+// with the first line, so errors before that can still be answered with a regular JSON error.
+type ndjsonStream struct {
+	mu      sync.Mutex
+	c       *gin.Context
+	started bool
+}
+
+func (s *ndjsonStream) Started() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.started
+}
+
+// Write sends one line; it is safe to call from the scanner's goroutines
+func (s *ndjsonStream) Write(line scanStreamLine) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.started {
+		s.c.Header("Content-Type", mimeNDJSON)
+		s.c.Header("X-Content-Type-Options", "nosniff")
+		s.c.Status(http.StatusOK)
+		s.started = true
+	}
+	if err := json.NewEncoder(s.c.Writer).Encode(line); err != nil {
+		slog.Warn("Failed to write streamed scan line", "type", line.Type, "error", err)
+		return
+	}
+	s.c.Writer.Flush()
+}
@@ -0,0 +1,68 @@
+package http
+
+import (
+	"elang-backend/internal/model"
+	"elang-backend/internal/model/responses"
+	"elang-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ScheduleHandler struct {
+	scheduleService services.ScheduleInterface
+}
+
+func NewScheduleHandler(scheduleService services.ScheduleInterface) *ScheduleHandler {
+	return &ScheduleHandler{
+		scheduleService: scheduleService,
+	}
+}
+
+// CreateSchedule handles scheduling scans of an application
+func (h *ScheduleHandler) CreateSchedule(c *gin.Context) {
+	var req model.CreateScanScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responses.JSONErrorResponse(c, 400, "invalid request: "+err.Error(), nil)
+		return
+	}
+	resp, err := h.scheduleService.CreateSchedule(c.Request.Context(), c.Param("app_id"), req)
+	if err != nil {
+		responses.JSONErrorResponse(c, statusCodeFromError(err), "failed to create scan schedule: "+err.Error(), nil)
+		return
+	}
+	responses.JSONSuccessResponse(c, 201, "scan schedule created", resp)
+}
+
+// ListSchedules handles listing the scan schedules of an application
+func (h *ScheduleHandler) ListSchedules(c *gin.Context) {
+	resp, err := h.scheduleService.ListSchedules(c.Request.Context(), c.Param("app_id"))
+	if err != nil {
+		responses.JSONErrorResponse(c, statusCodeFromError(err), "failed to list scan schedules: "+err.Error(), nil)
+		return
+	}
+	responses.JSONSuccessResponse(c, 200, "scan schedules fetched", resp)
+}
+
+// UpdateSchedule handles changing or enabling/disabling a scan schedule
+func (h *ScheduleHandler) UpdateSchedule(c *gin.Context) {
+	var req model.UpdateScanScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responses.JSONErrorResponse(c, 400, "invalid request: "+err.Error(), nil)
+		return
+	}
+	resp, err := h.scheduleService.UpdateSchedule(c.Request.Context(), c.Param("app_id"), c.Param("schedule_id"), req)
+	if err != nil {
+		responses.JSONErrorResponse(c, statusCodeFromError(err), "failed to update scan schedule: "+err.Error(), nil)
+		return
+	}
+	responses.JSONSuccessResponse(c, 200, "scan schedule updated", resp)
+}
+
+// DeleteSchedule handles removing a scan schedule
+func (h *ScheduleHandler) DeleteSchedule(c *gin.Context) {
+	if err := h.scheduleService.DeleteSchedule(c.Request.Context(), c.Param("app_id"), c.Param("schedule_id")); err != nil {
+		responses.JSONErrorResponse(c, statusCodeFromError(err), "failed to delete scan schedule: "+err.Error(), nil)
+		return
+	}
+	responses.JSONSuccessResponse(c, 200, "scan schedule deleted", nil)
+}
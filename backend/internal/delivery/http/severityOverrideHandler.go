@@ -0,0 +1,53 @@
+package http
+
+import (
+	"elang-backend/internal/model"
+	"elang-backend/internal/model/responses"
+	"elang-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SeverityOverrideHandler struct {
+	severityOverrideService services.SeverityOverrideInterface
+}
+
+func NewSeverityOverrideHandler(severityOverrideService services.SeverityOverrideInterface) *SeverityOverrideHandler {
+	return &SeverityOverrideHandler{
+		severityOverrideService: severityOverrideService,
+	}
+}
+
+// CreateSeverityOverride handles storing a custom severity for matching vulnerabilities
+func (h *SeverityOverrideHandler) CreateSeverityOverride(c *gin.Context) {
+	var req model.CreateSeverityOverrideRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responses.JSONErrorResponse(c, 400, "invalid request: "+err.Error(), nil)
+		return
+	}
+	resp, err := h.severityOverrideService.CreateSeverityOverride(c.Request.Context(), req)
+	if err != nil {
+		responses.JSONErrorResponse(c, statusCodeFromError(err), "failed to create severity override: "+err.Error(), nil)
+		return
+	}
+	responses.JSONSuccessResponse(c, 201, "severity override created", resp)
+}
+
+// ListSeverityOverrides handles listing the severity overrides, those of an application included when given
+func (h *SeverityOverrideHandler) ListSeverityOverrides(c *gin.Context) {
+	resp, err := h.severityOverrideService.ListSeverityOverrides(c.Request.Context(), c.Query("app_id"))
+	if err != nil {
+		responses.JSONErrorResponse(c, statusCodeFromError(err), "failed to list severity overrides: "+err.Error(), nil)
+		return
+	}
+	responses.JSONSuccessResponse(c, 200, "severity overrides fetched", resp)
+}
+
+// DeleteSeverityOverride handles removing a severity override
+func (h *SeverityOverrideHandler) DeleteSeverityOverride(c *gin.Context) {
+	if err := h.severityOverrideService.DeleteSeverityOverride(c.Request.Context(), c.Param("override_id")); err != nil {
+		responses.JSONErrorResponse(c, statusCodeFromError(err), "failed to delete severity override: "+err.Error(), nil)
+		return
+	}
+	responses.JSONSuccessResponse(c, 200, "severity override deleted", nil)
+}
@@ -14,8 +14,15 @@ type App struct {
 	Description *string   `gorm:"type:text" db:"description" json:"description"`
 	IsDeleted   bool      `gorm:"not null;default:false" db:"is_deleted" json:"is_deleted"`
 	Status      string    `gorm:"type:text" db:"status" json:"status"`
-	CreatedAt   time.Time `db:"created_at" json:"created_at"`
-	UpdatedAt   time.Time `db:"updated_at" json:"updated_at"`
+	OwnerID     *string   `gorm:"type:text;index" db:"owner_id" json:"owner_id,omitempty"`
+	// ManifestFileName and ManifestObjectKey locate the dependency manifest the application was added from in
+	// object storage; nil when it could not be stored
+	ManifestFileName  *string `gorm:"type:text" db:"manifest_file_name" json:"manifest_file_name,omitempty"`
+	ManifestObjectKey *string `gorm:"type:text" db:"manifest_object_key" json:"manifest_object_key,omitempty"`
+	// GradlePropertiesObjectKey locates the gradle.properties uploaded with the manifest; nil when there was none
+	GradlePropertiesObjectKey *string   `gorm:"type:text" db:"gradle_properties_object_key" json:"gradle_properties_object_key,omitempty"`
+	CreatedAt                 time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt                 time.Time `db:"updated_at" json:"updated_at"`
 }
 
 func (App) TableName() string {
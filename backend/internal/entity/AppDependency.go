@@ -15,6 +15,8 @@ type AppDependency struct {
 	UsedCommitSHA           *string     `gorm:"type:varchar(64)" db:"used_commit_sha" json:"used_commit_sha"`
 	UsedVersion             string      `gorm:"type:varchar(128);not null" db:"used_version" json:"used_version"`
 	UsedTag                 *string     `gorm:"type:varchar(128)" db:"used_tag" json:"used_tag"`
+	Ecosystem               *string     `gorm:"type:varchar(64)" db:"ecosystem" json:"ecosystem,omitempty"`
+	Transitive              bool        `gorm:"not null;default:false" db:"transitive" json:"transitive"` // pulled in by other dependencies rather than declared
 	IsMonitored             bool        `gorm:"not null;default:false" db:"is_monitored" json:"is_monitored"`
 	MonitoringEnabled       bool        `gorm:"not null;default:true" db:"monitoring_enabled" json:"monitoring_enabled"`
 	PollingIntervalMinutes  int         `gorm:"not null;default:60" db:"polling_interval_minutes" json:"polling_interval_minutes"`
@@ -26,6 +28,9 @@ type AppDependency struct {
 	TotalChecksCount        int         `gorm:"default:0" db:"total_checks_count" json:"total_checks_count"`
 	LastSecurityDetectionAt *time.Time  `db:"last_security_detection_at" json:"last_security_detection_at"`
 	LastSecurityScore       int         `gorm:"default:0" db:"last_security_score" json:"last_security_score"`
+	ReleasesBehind          *int        `db:"releases_behind" json:"releases_behind"`
+	StalenessDays           *int        `db:"staleness_days" json:"staleness_days"`
+	StalenessCheckedAt      *time.Time  `db:"staleness_checked_at" json:"staleness_checked_at"`
 	CreatedAt               time.Time   `db:"created_at" json:"created_at"`
 	UpdatedAt               time.Time   `db:"updated_at" json:"updated_at"`
 }
@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AppTag attaches a tag to an application
+type AppTag struct {
+	AppID     uuid.UUID `gorm:"primaryKey;type:uuid" db:"app_id" json:"app_id"`
+	App       *App      `gorm:"foreignKey:AppID;references:ID;constraint:OnDelete:CASCADE" json:"-"`
+	TagID     uuid.UUID `gorm:"primaryKey;type:uuid;index" db:"tag_id" json:"tag_id"`
+	Tag       *Tag      `gorm:"foreignKey:TagID;references:ID;constraint:OnDelete:CASCADE" json:"-"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+func (AppTag) TableName() string {
+	return "app_tags"
+}
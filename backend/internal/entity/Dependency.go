@@ -17,8 +17,17 @@ type Dependency struct {
 	LastTagAt     *time.Time `db:"last_tag_at" json:"last_tag_at"`
 	RepositoryURL *string    `gorm:"type:text" db:"repository_url" json:"repository_url"`
 	DefaultBranch *string    `gorm:"type:text;default:'main'" db:"default_branch" json:"default_branch"`
-	CreatedAt     time.Time  `db:"created_at" json:"created_at"`
-	UpdatedAt     time.Time  `db:"updated_at" json:"updated_at"`
+	// Repository signals used to judge whether the dependency is still maintained; nil until fetched
+	Archived         *bool      `db:"archived" json:"archived"`
+	Stars            *int       `db:"stars" json:"stars"`
+	Collaborators    *int       `db:"collaborators" json:"collaborators"`
+	SignalsCheckedAt *time.Time `db:"signals_checked_at" json:"signals_checked_at"`
+	// ETag and Last-Modified of the GitHub repository response the metadata above was last fetched after, sent
+	// back so GitHub answers an unchanged repository with 304 Not Modified
+	GitHubETag         *string   `gorm:"column:github_etag;type:text" db:"github_etag" json:"-"`
+	GitHubLastModified *string   `gorm:"column:github_last_modified;type:text" db:"github_last_modified" json:"-"`
+	CreatedAt          time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt          time.Time `db:"updated_at" json:"updated_at"`
 }
 
 func (Dependency) TableName() string {
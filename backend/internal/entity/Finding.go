@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Finding is one vulnerability reported for a dependency by a completed application scan. Findings are
+// copied out of the scan's Result payload so an application's scan history can be queried by severity,
+// dependency or vulnerability, and are removed together with their scan.
+type Finding struct {
+	ID              uuid.UUID `gorm:"primaryKey;type:uuid" db:"id" json:"id"`
+	ScanID          uuid.UUID `gorm:"type:uuid;not null;index" db:"scan_id" json:"scan_id"`
+	AppID           uuid.UUID `gorm:"type:uuid;not null;index:idx_finding_app_scanned" db:"app_id" json:"app_id"`
+	Dependency      string    `gorm:"type:text;not null" db:"dependency" json:"dependency"`
+	Version         string    `gorm:"type:text" db:"version" json:"version"`
+	VulnerabilityID string    `gorm:"type:varchar(255);not null;index" db:"vulnerability_id" json:"vulnerability_id"`
+	Severity        string    `gorm:"type:varchar(32);not null" db:"severity" json:"severity"`
+	// OriginalSeverity is the advisory severity when a severity override changed Severity
+	OriginalSeverity   string  `gorm:"type:varchar(32)" db:"original_severity" json:"original_severity,omitempty"`
+	RiskScore          float64 `db:"risk_score" json:"risk_score"`
+	RecommendedVersion string  `gorm:"type:text" db:"recommended_version" json:"recommended_version"`
+	Recommendation     string  `gorm:"type:text" db:"recommendation" json:"recommendation,omitempty"`
+	// ScannedAt is when the scan completed
+	ScannedAt time.Time `gorm:"not null;index:idx_finding_app_scanned" db:"scanned_at" json:"scanned_at"`
+}
+
+func (Finding) TableName() string {
+	return "finding"
+}
@@ -3,6 +3,10 @@ package entity
 type Framework struct {
 	ID   int    `db:"id" json:"id"`
 	Name string `db:"name" json:"name"`
+
+	// Runtimes are the runtimes the framework can be used with, e.g. Spring Boot with both Java (Maven) and
+	// Gradle; frameworks without any are accepted for every runtime
+	Runtimes []Runtime `gorm:"many2many:framework_runtime;" json:"runtimes,omitempty"`
 }
 
 func (Framework) TableName() string {
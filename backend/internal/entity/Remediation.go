@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Remediation states; a remediation is resolved by hand or once a newer scan no longer reports its vulnerability
+const (
+	RemediationOpen       = "open"
+	RemediationInProgress = "in_progress"
+	RemediationResolved   = "resolved"
+)
+
+// Remediation tracks the fix of one vulnerability of a dependency in an application, typically linked to a
+// ticket in an external tracker such as Jira
+type Remediation struct {
+	ID              uuid.UUID `gorm:"primaryKey;type:uuid" db:"id" json:"id"`
+	AppID           uuid.UUID `gorm:"type:uuid;not null;index" db:"app_id" json:"app_id"`
+	App             *App      `gorm:"foreignKey:AppID;references:ID;constraint:OnDelete:CASCADE" json:"-"`
+	Dependency      string    `gorm:"type:text;not null" db:"dependency" json:"dependency"`
+	VulnerabilityID string    `gorm:"type:varchar(255);not null;index" db:"vulnerability_id" json:"vulnerability_id"`
+	Status          string    `gorm:"type:varchar(32);not null;index" db:"status" json:"status"`
+
+	ExternalTicketURL string `gorm:"type:text" db:"external_ticket_url" json:"external_ticket_url"`
+	Notes             string `gorm:"type:text" db:"notes" json:"notes"`
+
+	// ResolvedAt is when the remediation was resolved; ResolvedByScanID is set when a scan resolved it
+	ResolvedAt       *time.Time `db:"resolved_at" json:"resolved_at"`
+	ResolvedByScanID *uuid.UUID `gorm:"type:uuid" db:"resolved_by_scan_id" json:"resolved_by_scan_id"`
+
+	CreatedBy string    `gorm:"type:varchar(255)" db:"created_by" json:"created_by"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+}
+
+func (Remediation) TableName() string {
+	return "remediation"
+}
@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ScanResult stores the outcome of a vulnerability scan so it can be retrieved later by its scan ID
+type ScanResult struct {
+	ID    uuid.UUID  `gorm:"primaryKey;type:uuid" db:"id" json:"id"`
+	AppID *uuid.UUID `gorm:"type:uuid;index" db:"app_id" json:"app_id"` // nil for ad-hoc manifest scans
+	// OwnerID is the authenticated user who ran an ad-hoc scan; application scans belong to their application's owner
+	OwnerID  *string `gorm:"type:text;index" db:"owner_id" json:"owner_id,omitempty"`
+	AppName  string  `gorm:"type:text" db:"app_name" json:"app_name"`
+	ScanType string  `gorm:"type:text" db:"scan_type" json:"scan_type"` // application, adhoc
+	Status   string  `gorm:"type:text" db:"status" json:"status"`       // queued, running, completed, failed
+
+	// Summary counters kept as columns for cheap filtering
+	PolicyStatus         string `gorm:"type:text" db:"policy_status" json:"policy_status"`
+	TotalDependencies    int    `db:"total_dependencies" json:"total_dependencies"`
+	TotalVulnerabilities int    `db:"total_vulnerabilities" json:"total_vulnerabilities"`
+	Critical             int    `db:"critical" json:"critical"`
+	High                 int    `db:"high" json:"high"`
+	Medium               int    `db:"medium" json:"medium"`
+	Low                  int    `db:"low" json:"low"`
+
+	// Full ScanApplicationResult payload, empty until the scan completes
+	Result       []byte  `gorm:"type:jsonb" db:"result" json:"result"`
+	ErrorMessage *string `gorm:"type:text" db:"error_message" json:"error_message"`
+
+	// Object storage key of the SBOM generated by this scan, removed together with the row
+	SBOMObjectKey *string `gorm:"type:text" db:"sbom_object_key" json:"sbom_object_key"`
+
+	// Findings of Result, stored in their own table by the repository when set
+	Findings []Finding `gorm:"-" db:"-" json:"-"`
+
+	StartedAt   *time.Time `db:"started_at" json:"started_at"`
+	CompletedAt *time.Time `db:"completed_at" json:"completed_at"`
+
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+}
+
+func (ScanResult) TableName() string {
+	return "scan_result"
+}
@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ScanSchedule runs a point-in-time scan of an application whenever its cron expression matches,
+// independently of the interval-based monitoring loop
+type ScanSchedule struct {
+	ID             uuid.UUID `gorm:"primaryKey;type:uuid" db:"id" json:"id"`
+	AppID          uuid.UUID `gorm:"type:uuid;not null;index" db:"app_id" json:"app_id"`
+	App            *App      `gorm:"foreignKey:AppID;references:ID;constraint:OnDelete:CASCADE" json:"-"`
+	CronExpression string    `gorm:"type:varchar(128);not null" db:"cron_expression" json:"cron_expression"`
+	Enabled        bool      `gorm:"not null;index" db:"enabled" json:"enabled"`
+
+	// NextRunAt is when the scheduler runs the scan next; nil while the schedule is disabled
+	NextRunAt  *time.Time `gorm:"index" db:"next_run_at" json:"next_run_at"`
+	LastRunAt  *time.Time `db:"last_run_at" json:"last_run_at"`
+	LastScanID *uuid.UUID `gorm:"type:uuid" db:"last_scan_id" json:"last_scan_id"`
+	LastError  *string    `gorm:"type:text" db:"last_error" json:"last_error"`
+
+	CreatedBy string    `gorm:"type:varchar(255)" db:"created_by" json:"created_by"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+}
+
+func (ScanSchedule) TableName() string {
+	return "scan_schedule"
+}
@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SeverityOverride sets the effective severity of the vulnerabilities it matches by CWE, CVE pattern or
+// ecosystem, for example to rate remote code execution higher than its CVSS score implies. Overrides belong to
+// an owner; those without an application apply to every application of the owner.
+type SeverityOverride struct {
+	ID         uuid.UUID  `gorm:"primaryKey;type:uuid" db:"id" json:"id"`
+	OwnerID    string     `gorm:"type:varchar(255);not null;default:'';index" db:"owner_id" json:"owner_id"`
+	AppID      *uuid.UUID `gorm:"type:uuid;index" db:"app_id" json:"app_id"`
+	App        *App       `gorm:"foreignKey:AppID;references:ID;constraint:OnDelete:CASCADE" json:"-"`
+	CWE        string     `gorm:"type:varchar(32)" db:"cwe" json:"cwe"`
+	CVEPattern string     `gorm:"type:varchar(255)" db:"cve_pattern" json:"cve_pattern"`
+	Ecosystem  string     `gorm:"type:varchar(64)" db:"ecosystem" json:"ecosystem"`
+	Severity   string     `gorm:"type:varchar(32);not null" db:"severity" json:"severity"`
+	Reason     string     `gorm:"type:text" db:"reason" json:"reason"`
+
+	CreatedBy string    `gorm:"type:varchar(255)" db:"created_by" json:"created_by"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+}
+
+func (SeverityOverride) TableName() string {
+	return "severity_override"
+}
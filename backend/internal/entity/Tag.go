@@ -0,0 +1,18 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Tag is a label such as "team:payments" or "env:prod" used to group applications
+type Tag struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:uuid" db:"id" json:"id"`
+	Name      string    `gorm:"type:varchar(64);not null;uniqueIndex" db:"name" json:"name"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+func (Tag) TableName() string {
+	return "tag"
+}
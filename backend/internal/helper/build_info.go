@@ -0,0 +1,65 @@
+package helper
+
+import (
+	"runtime/debug"
+	"strings"
+)
+
+// Version is the scanner build, stamped at build time with
+// -ldflags "-X elang-backend/internal/helper.Version=v1.4.0". When empty, BuildVersion falls back to the
+// module version or VCS revision Go records in the binary.
+var Version = ""
+
+// Tool recorded in SBOM metadata when none is configured
+const (
+	DefaultSBOMToolVendor = "Silent Patch Detector"
+	DefaultSBOMToolName   = "dependency-vulnerability-scanner"
+)
+
+// NewSBOMTool returns the tool SBOMs credit as having produced them, with the version of the running build;
+// an empty vendor or name keeps the default
+func NewSBOMTool(vendor, name string) CycloneDXTool {
+	tool := CycloneDXTool{Vendor: DefaultSBOMToolVendor, Name: DefaultSBOMToolName, Version: BuildVersion()}
+	if vendor = strings.TrimSpace(vendor); vendor != "" {
+		tool.Vendor = vendor
+	}
+	if name = strings.TrimSpace(name); name != "" {
+		tool.Name = name
+	}
+	return tool
+}
+
+// BuildVersion returns Version when stamped, else the main module version of a `go install`ed binary, else the
+// VCS revision the binary was built from (suffixed -dirty for uncommitted changes), else "dev"
+func BuildVersion() string {
+	if Version != "" {
+		return Version
+	}
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "dev"
+	}
+	if info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	var revision string
+	var modified bool
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			revision = setting.Value
+		case "vcs.modified":
+			modified = setting.Value == "true"
+		}
+	}
+	if revision == "" {
+		return "dev"
+	}
+	if len(revision) > 12 {
+		revision = revision[:12]
+	}
+	if modified {
+		revision += "-dirty"
+	}
+	return revision
+}
@@ -5,27 +5,31 @@ import (
 	"elang-backend/internal/helper/parser"
 	"elang-backend/internal/model"
 
-	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
-	"net/http"
-	"net/url"
-
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 )
 
 // CVEHelper provides vulnerability checking functionality for dependencies
 type CVEHelper struct {
-	httpClient *http.Client
-	timeout    time.Duration
-	normalizer *DependencyNameNormalizer
+	timeout      time.Duration
+	normalizer   *DependencyNameNormalizer
+	sources      []VulnerabilitySource // queried in order
+	mergeSources bool                  // query every source and merge, instead of stopping at the first that answers
 }
 
-// OSVQuery represents the OSV API query structure
+// OSVQuery represents the OSV API query structure. A query is either by package and version or,
+// for dependencies pinned to a commit, by commit alone.
 type OSVQuery struct {
-	Package OSVPackage `json:"package"`
-	Version string     `json:"version"`
+	Package *OSVPackage `json:"package,omitempty"`
+	Version string      `json:"version,omitempty"`
+	Commit  string      `json:"commit,omitempty"`
+	// PageToken requests the page after the one that returned it as next_page_token
+	PageToken string `json:"page_token,omitempty"`
 }
 
 type OSVPackage struct {
@@ -36,14 +40,24 @@ type OSVPackage struct {
 // OSVResponse represents the OSV API response
 type OSVResponse struct {
 	Vulns []OSVVulnerability `json:"vulns"`
+	// NextPageToken is set when OSV has more vulnerabilities than fit in one response
+	NextPageToken string `json:"next_page_token,omitempty"`
 }
 
 type OSVVulnerability struct {
-	ID         string         `json:"id"`
-	Summary    string         `json:"summary"`
-	Details    string         `json:"details"`
-	Affects    []OSVAffected  `json:"affected"`
-	References []OSVReference `json:"references"`
+	ID               string              `json:"id"`
+	Aliases          []string            `json:"aliases,omitempty"`
+	Summary          string              `json:"summary"`
+	Details          string              `json:"details"`
+	Affects          []OSVAffected       `json:"affected"`
+	References       []OSVReference      `json:"references"`
+	DatabaseSpecific OSVDatabaseSpecific `json:"database_specific"`
+}
+
+// OSVDatabaseSpecific holds the advisory database fields OSV passes through
+// (GHSA advisories populate cwe_ids here)
+type OSVDatabaseSpecific struct {
+	CWEIDs []string `json:"cwe_ids,omitempty"`
 }
 
 type OSVAffected struct {
@@ -57,8 +71,9 @@ type OSVRange struct {
 }
 
 type OSVEvent struct {
-	Introduced string `json:"introduced,omitempty"`
-	Fixed      string `json:"fixed,omitempty"`
+	Introduced   string `json:"introduced,omitempty"`
+	Fixed        string `json:"fixed,omitempty"`
+	LastAffected string `json:"last_affected,omitempty"`
 }
 
 type OSVReference struct {
@@ -66,53 +81,56 @@ type OSVReference struct {
 	URL  string `json:"url"`
 }
 
-// NewCVEHelper creates a new CVE helper instance
+// NewCVEHelper creates a new CVE helper instance querying OSV
 func NewCVEHelper() *CVEHelper {
+	return NewCVEHelperWithSources(false)
+}
+
+// NewCVEHelperWithSources creates a CVE helper that queries the given sources in order. With merge set every
+// source is queried and the results are combined; otherwise the first source that answers wins. No sources
+// means OSV.
+func NewCVEHelperWithSources(merge bool, sources ...VulnerabilitySource) *CVEHelper {
+	if len(sources) == 0 {
+		sources = []VulnerabilitySource{NewOSVSource()}
+	}
 	return &CVEHelper{
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		timeout:    30 * time.Second,
-		normalizer: NewDependencyNameNormalizer(),
+		timeout:      30 * time.Second,
+		normalizer:   NewDependencyNameNormalizer(),
+		sources:      sources,
+		mergeSources: merge,
 	}
 }
 
-// CVESeverity represents the severity levels of vulnerabilities
-type CVESeverity string
-
-const (
-	SeverityCritical CVESeverity = "CRITICAL"
-	SeverityHigh     CVESeverity = "HIGH"
-	SeverityMedium   CVESeverity = "MEDIUM"
-	SeverityLow      CVESeverity = "LOW"
-	SeverityInfo     CVESeverity = "INFO"
-	SeverityUnknown  CVESeverity = "UNKNOWN"
-)
-
 // VulnerabilityInfo represents detailed vulnerability information
 type VulnerabilityInfo struct {
-	ID                    string      `json:"id"`
-	CVE                   string      `json:"cve"`
-	Summary               string      `json:"summary"`
-	Description           string      `json:"description"`
-	Severity              CVESeverity `json:"severity"`
-	Score                 float64     `json:"score"`
-	AffectedVersions      []string    `json:"affected_versions"`
-	PatchedVersions       []string    `json:"patched_versions"`
-	References            []string    `json:"references"`
-	PublishedDate         time.Time   `json:"published_date"`
-	ModifiedDate          time.Time   `json:"modified_date"`
-	VectorString          string      `json:"vector_string"`
-	AttackVector          string      `json:"attack_vector"`
-	AttackComplexity      string      `json:"attack_complexity"`
-	PrivilegesRequired    string      `json:"privileges_required"`
-	UserInteraction       string      `json:"user_interaction"`
-	Scope                 string      `json:"scope"`
-	ConfidentialityImpact string      `json:"confidentiality_impact"`
-	IntegrityImpact       string      `json:"integrity_impact"`
-	AvailabilityImpact    string      `json:"availability_impact"`
-	ExploitabilityScore   float64     `json:"exploitability_score"`
-	ImpactScore           float64     `json:"impact_score"`
+	ID                    string         `json:"id"`
+	CVE                   string         `json:"cve"`
+	Aliases               []string       `json:"aliases,omitempty"`
+	Source                string         `json:"source,omitempty"` // database that reported it, e.g. osv or github
+	Summary               string         `json:"summary"`
+	Description           string         `json:"description"`
+	Severity              CVESeverity    `json:"severity"`
+	Score                 float64        `json:"score"`
+	AffectedVersions      []string       `json:"affected_versions"`
+	AffectedRanges        []VersionRange `json:"affected_ranges,omitempty"`
+	PatchedVersions       []string       `json:"patched_versions"`
+	Cwes                  []string       `json:"cwes,omitempty"`
+	References            []string       `json:"references"`
+	PublishedDate         time.Time      `json:"published_date"`
+	ModifiedDate          time.Time      `json:"modified_date"`
+	VectorString          string         `json:"vector_string"`
+	AttackVector          string         `json:"attack_vector"`
+	AttackComplexity      string         `json:"attack_complexity"`
+	PrivilegesRequired    string         `json:"privileges_required"`
+	UserInteraction       string         `json:"user_interaction"`
+	Scope                 string         `json:"scope"`
+	ConfidentialityImpact string         `json:"confidentiality_impact"`
+	IntegrityImpact       string         `json:"integrity_impact"`
+	AvailabilityImpact    string         `json:"availability_impact"`
+	ExploitabilityScore   float64        `json:"exploitability_score"`
+	ImpactScore           float64        `json:"impact_score"`
+	// PartialRange marks a vulnerability that affects only some of the versions a declared range allows
+	PartialRange bool `json:"partial_range,omitempty"`
 }
 
 // DependencyVulnerabilityResult contains vulnerability results for a dependency
@@ -127,10 +145,33 @@ type DependencyVulnerabilityResult struct {
 	LowCount        int                   `json:"low_count"`
 	RiskScore       float64               `json:"risk_score"`
 	Recommendations []string              `json:"recommendations"`
-	CheckedAt       time.Time             `json:"checked_at"`
-	Error           string                `json:"error,omitempty"`
+	// RecommendedVersion is the lowest version above the current one that no known vulnerability affects;
+	// empty when there is none
+	RecommendedVersion string `json:"recommended_version,omitempty"`
+	// RemediationImpact is the size of the upgrade to RecommendedVersion, one of the Remediation* values;
+	// empty when the dependency is not vulnerable
+	RemediationImpact string    `json:"remediation_impact,omitempty"`
+	CheckedAt         time.Time `json:"checked_at"`
+	Error             string    `json:"error,omitempty"`
+	// UncheckedReason says why the dependency could not be checked, one of the Unchecked* reasons; empty
+	// when it was checked
+	UncheckedReason string `json:"unchecked_reason,omitempty"`
 }
 
+// Reasons a dependency could not be checked for vulnerabilities
+const (
+	// UncheckedUnsupportedEcosystem marks a dependency whose runtime maps to no advisory database ecosystem
+	UncheckedUnsupportedEcosystem = "unsupported_ecosystem"
+	// UncheckedInvalidDependency marks a dependency missing the name or version a lookup needs
+	UncheckedInvalidDependency = "invalid_dependency"
+	// UncheckedCheckFailed marks a dependency whose lookup failed, e.g. because the database was unreachable
+	UncheckedCheckFailed = "check_failed"
+	// UncheckedTimedOut marks a dependency whose lookup did not finish within the scan's per-dependency timeout
+	UncheckedTimedOut = "timed_out"
+	// UncheckedUnresolvedVersion marks a dependency whose version is a build variable none of the uploaded files define
+	UncheckedUnresolvedVersion = "unresolved_version"
+)
+
 // BatchVulnerabilityResult contains results for multiple dependencies
 type BatchVulnerabilityResult struct {
 	Dependencies           []DependencyVulnerabilityResult `json:"dependencies"`
@@ -164,13 +205,27 @@ func (c *CVEHelper) CheckDependencyVulnerabilities(ctx context.Context, dep pars
 		Dependency:      normalizedDep, // Use normalized dependency in result
 		Vulnerabilities: []VulnerabilityInfo{},
 		IsVulnerable:    false,
-		CheckedAt:       time.Now(),
+		CheckedAt:       time.Now().UTC(),
+	}
+
+	// A build variable that could not be resolved is no version the databases know; querying it would only
+	// report the dependency as free of vulnerabilities
+	if normalizedDep.Version == parser.UnresolvedVersion {
+		result.Error = "version could not be resolved from the build files"
+		result.UncheckedReason = UncheckedUnresolvedVersion
+		slog.Warn("Skipping vulnerability check of dependency with an unresolved version", "name", normalizedDep.Name)
+		return result, nil
 	}
 
 	// Only log warnings and errors for important traceability
 	if !c.normalizer.ValidateForCVECheck(normalizedDep) {
 		result.Error = fmt.Sprintf("Invalid dependency for CVE check: name='%s', version='%s', runtime='%s'",
 			normalizedDep.Name, normalizedDep.Version, normalizedDep.Runtime)
+		result.UncheckedReason = UncheckedInvalidDependency
+		if strings.TrimSpace(normalizedDep.Name) != "" && strings.TrimSpace(normalizedDep.Version) != "" {
+			result.Error = fmt.Sprintf("no vulnerability database covers runtime '%s'", normalizedDep.Runtime)
+			result.UncheckedReason = UncheckedUnsupportedEcosystem
+		}
 		slog.Warn("Invalid dependency for CVE check",
 			"name", normalizedDep.Name,
 			"version", normalizedDep.Version,
@@ -178,39 +233,24 @@ func (c *CVEHelper) CheckDependencyVulnerabilities(ctx context.Context, dep pars
 		return result, nil
 	}
 
-	// Check multiple vulnerability databases with alternative names
-	osvVulns, err := c.checkOSVDatabase(ctx, normalizedDep)
+	// Check the configured vulnerability databases with alternative names
+	vulns, err := c.queryWithAlternatives(ctx, normalizedDep)
 	if err != nil {
-		// Try with alternative names if the primary check failed
-		alternatives := c.normalizer.GetSuggestedNames(normalizedDep)
-		for _, altName := range alternatives[1:] { // Skip first one as it was already tried
-			altDep := normalizedDep
-			altDep.Name = altName
-			altVulns, altErr := c.checkOSVDatabase(ctx, altDep)
-			if altErr == nil && len(altVulns) > 0 {
-				// slog.Info("Found vulnerabilities with alternative name",
-				// 	"original", normalizedDep.Name,
-				// 	"alternative", altName,
-				// 	"count", len(altVulns))
-				osvVulns = altVulns
-				err = nil
-				break
-			}
+		slog.Warn("Failed to check vulnerability databases", "dependency", normalizedDep.Name, "error", err)
+		result.Error = fmt.Sprintf("vulnerability check failed: %v", err)
+		result.UncheckedReason = UncheckedCheckFailed
+		if errors.Is(err, ErrUnsupportedEcosystem) {
+			result.UncheckedReason = UncheckedUnsupportedEcosystem
 		}
 	}
-	if err != nil {
-		slog.Warn("Failed to check OSV database", "dependency", normalizedDep.Name, "error", err)
-		result.Error = fmt.Sprintf("OSV check failed: %v", err)
-	}
-
-	// Convert OSV vulnerabilities to our format
-	for _, osvVuln := range osvVulns {
-		vuln := c.convertOSVToVulnerabilityInfo(osvVuln, normalizedDep)
-		result.Vulnerabilities = append(result.Vulnerabilities, vuln)
-	}
+	result.Vulnerabilities = append(result.Vulnerabilities, vulns...)
 
 	// Update statistics
 	c.updateVulnerabilityStats(result)
+	if result.IsVulnerable {
+		result.RecommendedVersion = RecommendedVersion(normalizedDep.Version, result.Vulnerabilities)
+		result.RemediationImpact = RemediationImpact(normalizedDep.Version, result.RecommendedVersion)
+	}
 
 	// Generate recommendations
 	result.Recommendations = c.generateRecommendations(result)
@@ -230,7 +270,7 @@ func (c *CVEHelper) CheckBatchVulnerabilities(ctx context.Context, dependencies
 		TotalDependencies:      len(dependencies),
 		VulnerableDependencies: 0,
 		TotalVulnerabilities:   0,
-		CheckedAt:              time.Now(),
+		CheckedAt:              time.Now().UTC(),
 	}
 
 	slog.Info("Starting batch vulnerability check", "total_dependencies", len(dependencies))
@@ -245,7 +285,7 @@ func (c *CVEHelper) CheckBatchVulnerabilities(ctx context.Context, dependencies
 			depResult = &DependencyVulnerabilityResult{
 				Dependency: dep,
 				Error:      err.Error(),
-				CheckedAt:  time.Now(),
+				CheckedAt:  time.Now().UTC(),
 			}
 		}
 
@@ -270,125 +310,93 @@ func (c *CVEHelper) CheckBatchVulnerabilities(ctx context.Context, dependencies
 	return result, nil
 }
 
-// checkOSVDatabase queries the OSV (Open Source Vulnerabilities) database
-func (c *CVEHelper) checkOSVDatabase(ctx context.Context, dep parser.DependencyInfo) ([]OSVVulnerability, error) {
-	ecosystem := c.getEcosystemForRuntime(dep.Runtime)
-	if ecosystem == "" {
-		return nil, fmt.Errorf("unsupported runtime: %s", dep.Runtime)
-	}
-
-	// Ensure the dependency is normalized before querying
-	normalizedDep := c.normalizer.NormalizeDependencyInfo(dep)
-
-	// slog.Debug("Querying OSV database",
-	// 	"name", normalizedDep.Name,
-	// 	"version", normalizedDep.Version,
-	// 	"ecosystem", ecosystem,
-	// 	"original_name", dep.Name)
-
-	// Prepare query for OSV API
-	query := map[string]interface{}{
-		"package": map[string]string{
-			"name":      normalizedDep.Name,
-			"ecosystem": ecosystem,
-		},
-		"version": normalizedDep.Version,
-	}
-
-	queryBytes, err := json.Marshal(query)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal query: %w", err)
-	}
-
-	// Create request
-	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.osv.dev/v1/query", strings.NewReader(string(queryBytes)))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("User-Agent", "SilentPatchDetector/1.0")
-
-	// Execute request
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
+// EcosystemForRuntime maps runtime types to their primary OSV ecosystem; "" means the runtime is not supported
+func EcosystemForRuntime(runtime string) string {
+	if ecosystems := EcosystemsForRuntime(runtime); len(ecosystems) > 0 {
+		return ecosystems[0]
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("OSV API returned status %d", resp.StatusCode)
-	}
-
-	// Parse response
-	var osvResp OSVResponse
-	if err := json.NewDecoder(resp.Body).Decode(&osvResp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
-
-	return osvResp.Vulns, nil
+	return ""
 }
 
-// getEcosystemForRuntime maps runtime types to OSV ecosystems
-func (c *CVEHelper) getEcosystemForRuntime(runtime string) string {
+// EcosystemsForRuntime returns the OSV ecosystems a runtime's packages may be published under, in the order
+// they are queried; nil means the runtime is not supported
+func EcosystemsForRuntime(runtime string) []string {
 	switch strings.ToLower(runtime) {
 	case "go":
-		return "Go"
+		return []string{"Go"}
 	case "node", "npm":
-		return "npm"
+		return []string{"npm"}
 	case "python", "pip":
-		return "PyPI"
+		return []string{"PyPI"}
 	case "java", "maven":
-		return "Maven"
+		return []string{"Maven"}
 	case "gradle":
-		return "Maven" // Gradle uses Maven ecosystem
+		// Gradle resolves Maven artifacts, but some Android libraries are only advised under Android
+		return []string{"Maven", "Android"}
 	case "dotnet", "nuget":
-		return "NuGet"
+		return []string{"NuGet"}
 	case "ruby", "gem":
-		return "RubyGems"
+		return []string{"RubyGems"}
 	case "php", "composer":
-		return "Packagist"
+		return []string{"Packagist"}
 	case "rust", "cargo":
-		return "crates.io"
+		return []string{"crates.io"}
 	default:
-		return ""
+		return nil
 	}
 }
 
 // convertOSVToVulnerabilityInfo converts OSV vulnerability to our format
-func (c *CVEHelper) convertOSVToVulnerabilityInfo(osvVuln OSVVulnerability, dep parser.DependencyInfo) VulnerabilityInfo {
+func convertOSVToVulnerabilityInfo(osvVuln OSVVulnerability, dep parser.DependencyInfo) VulnerabilityInfo {
 	vuln := VulnerabilityInfo{
 		ID:               osvVuln.ID,
 		Summary:          osvVuln.Summary,
 		Description:      osvVuln.Details,
-		PublishedDate:    time.Now(), // Default to current time since not available in existing structure
-		ModifiedDate:     time.Now(), // Default to current time since not available in existing structure
+		PublishedDate:    time.Now().UTC(), // Default to current time since not available in existing structure
+		ModifiedDate:     time.Now().UTC(), // Default to current time since not available in existing structure
 		AffectedVersions: []string{},
 		PatchedVersions:  []string{},
+		Cwes:             []string{},
 		References:       []string{},
 		Severity:         SeverityMedium, // Default severity since not available in existing structure
 		Score:            5.0,            // Default score
+		Source:           "osv",
 	}
 
-	// Extract CVE ID from ID if it contains CVE
+	// Extract CVE ID from ID if it contains CVE, otherwise from the aliases (GHSA and Go advisories list it there)
 	if strings.Contains(strings.ToUpper(osvVuln.ID), "CVE-") {
 		vuln.CVE = osvVuln.ID
 	}
+	for _, alias := range osvVuln.Aliases {
+		if vuln.CVE == "" && strings.HasPrefix(strings.ToUpper(alias), "CVE-") {
+			vuln.CVE = alias
+		}
+		vuln.Aliases = append(vuln.Aliases, alias)
+	}
 
 	// Extract affected and patched versions from existing structure
 	for _, affected := range osvVuln.Affects {
 		for _, r := range affected.Ranges {
+			vuln.AffectedRanges = append(vuln.AffectedRanges, versionRangesFromEvents(r)...)
 			for _, event := range r.Events {
 				if event.Introduced != "" {
 					vuln.AffectedVersions = append(vuln.AffectedVersions, event.Introduced)
 				}
-				if event.Fixed != "" {
+				if event.Fixed != "" && !containsString(vuln.PatchedVersions, event.Fixed) {
 					vuln.PatchedVersions = append(vuln.PatchedVersions, event.Fixed)
 				}
 			}
 		}
 	}
 
+	// Extract CWE IDs from the advisory database (GHSA)
+	for _, cwe := range osvVuln.DatabaseSpecific.CWEIDs {
+		cwe = strings.ToUpper(strings.TrimSpace(cwe))
+		if cwe != "" && !containsString(vuln.Cwes, cwe) {
+			vuln.Cwes = append(vuln.Cwes, cwe)
+		}
+	}
+
 	// Extract references
 	for _, ref := range osvVuln.References {
 		vuln.References = append(vuln.References, ref.URL)
@@ -439,7 +447,7 @@ func (c *CVEHelper) updateBatchStats(result *BatchVulnerabilityResult) {
 
 		// Update highest severity
 		for _, vuln := range dep.Vulnerabilities {
-			if c.severityPriority(vuln.Severity) > c.severityPriority(highestSeverity) {
+			if vuln.Severity.Rank() > highestSeverity.Rank() {
 				highestSeverity = vuln.Severity
 			}
 		}
@@ -451,22 +459,6 @@ func (c *CVEHelper) updateBatchStats(result *BatchVulnerabilityResult) {
 	result.HighestSeverity = highestSeverity
 }
 
-// severityPriority returns numeric priority for severity comparison
-func (c *CVEHelper) severityPriority(severity CVESeverity) int {
-	switch severity {
-	case SeverityCritical:
-		return 4
-	case SeverityHigh:
-		return 3
-	case SeverityMedium:
-		return 2
-	case SeverityLow:
-		return 1
-	default:
-		return 0
-	}
-}
-
 // generateRecommendations generates security recommendations based on vulnerabilities
 func (c *CVEHelper) generateRecommendations(result *DependencyVulnerabilityResult) []string {
 	recommendations := []string{}
@@ -480,9 +472,13 @@ func (c *CVEHelper) generateRecommendations(result *DependencyVulnerabilityResul
 		recommendations = append(recommendations, "URGENT: Update this dependency immediately due to critical/high severity vulnerabilities.")
 	}
 
-	// Suggest version updates
+	// Suggest version updates, naming the recommended version when the advisories allow computing one
 	if len(result.Vulnerabilities) > 0 {
-		recommendations = append(recommendations, "Review patched versions and update to the latest secure version.")
+		if result.RecommendedVersion != "" {
+			recommendations = append(recommendations, fmt.Sprintf("Upgrade %s to version %s or later to fix all known vulnerabilities.", result.Dependency.Name, result.RecommendedVersion))
+		} else {
+			recommendations = append(recommendations, "Review patched versions and update to the latest secure version.")
+		}
 	}
 
 	if result.RiskScore > 7.0 {
@@ -500,43 +496,178 @@ func (c *CVEHelper) generateRecommendations(result *DependencyVulnerabilityResul
 	return recommendations
 }
 
-// GetVulnerabilityByID retrieves detailed information about a specific vulnerability
-func (c *CVEHelper) GetVulnerabilityByID(ctx context.Context, vulnID string) (*VulnerabilityInfo, error) {
-	encodedID := url.QueryEscape(vulnID)
-	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("https://api.osv.dev/v1/vulns/%s", encodedID), nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+// RecommendedVersion returns the lowest fixed version above currentVersion that none of vulns affects,
+// so one upgrade resolves all of them even when their affected ranges overlap or a fix for one falls in
+// another's range. Vulnerabilities without affected ranges count as fixed from their lowest patched version
+// above currentVersion. Returns an empty string when no such version is known.
+func RecommendedVersion(currentVersion string, vulns []VulnerabilityInfo) string {
+	var candidates []string
+	for _, vuln := range vulns {
+		for _, patched := range vuln.PatchedVersions {
+			if currentVersion != "" && compareVersions(patched, currentVersion) <= 0 {
+				continue
+			}
+			if !containsString(candidates, patched) {
+				candidates = append(candidates, patched)
+			}
+		}
 	}
+	sort.SliceStable(candidates, func(i, j int) bool { return compareVersions(candidates[i], candidates[j]) < 0 })
 
-	req.Header.Set("User-Agent", "SilentPatchDetector/1.0")
+	for _, candidate := range candidates {
+		clear := true
+		for _, vuln := range vulns {
+			if vulnerabilityAffects(vuln, currentVersion, candidate) {
+				clear = false
+				break
+			}
+		}
+		if clear {
+			return candidate
+		}
+	}
+	return ""
+}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
+// Remediation impacts classify the upgrade from a vulnerable version to its recommended version
+const (
+	RemediationPatch   = "patch"
+	RemediationMinor   = "minor"
+	RemediationMajor   = "major"
+	RemediationUnknown = "unknown"
+)
+
+// RemediationImpact returns whether upgrading currentVersion to recommendedVersion changes the major, minor
+// or only the patch version. Versions that are not dotted numbers, a missing recommendation and downgrades
+// are RemediationUnknown.
+func RemediationImpact(currentVersion, recommendedVersion string) string {
+	current, ok := semverCore(currentVersion)
+	if !ok {
+		return RemediationUnknown
+	}
+	recommended, ok := semverCore(recommendedVersion)
+	if !ok || compareVersions(recommendedVersion, currentVersion) <= 0 {
+		return RemediationUnknown
+	}
+	switch {
+	case recommended[0] != current[0]:
+		return RemediationMajor
+	case recommended[1] != current[1]:
+		return RemediationMinor
+	default:
+		return RemediationPatch
 	}
-	defer resp.Body.Close()
+}
 
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("vulnerability not found: %s", vulnID)
+// semverCore parses the major, minor and patch numbers of version, ignoring a leading "v" and any
+// pre-release or build suffix; missing minor and patch numbers count as 0
+func semverCore(version string) ([3]int, bool) {
+	var core [3]int
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if i := strings.IndexAny(version, "-+"); i >= 0 {
+		version = version[:i]
+	}
+	if version == "" {
+		return core, false
+	}
+	parts := strings.Split(version, ".")
+	if len(parts) > 3 {
+		return core, false
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return core, false
+		}
+		core[i] = n
+	}
+	return core, true
+}
+
+// vulnerabilityAffects reports whether version is still affected by vuln after upgrading from currentVersion
+func vulnerabilityAffects(vuln VulnerabilityInfo, currentVersion, version string) bool {
+	if len(vuln.AffectedRanges) > 0 {
+		for _, r := range vuln.AffectedRanges {
+			if r.Contains(version) {
+				return true
+			}
+		}
+		return false
 	}
+	for _, patched := range vuln.PatchedVersions {
+		if (currentVersion == "" || compareVersions(patched, currentVersion) > 0) && compareVersions(patched, version) <= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// compareVersions compares two dotted version strings numerically segment by segment,
+// ignoring a leading "v". Non-numeric segments fall back to string comparison.
+func compareVersions(a, b string) int {
+	partsA := strings.FieldsFunc(strings.TrimPrefix(strings.TrimSpace(a), "v"), isVersionSeparator)
+	partsB := strings.FieldsFunc(strings.TrimPrefix(strings.TrimSpace(b), "v"), isVersionSeparator)
+
+	for i := 0; i < len(partsA) || i < len(partsB); i++ {
+		segA, segB := "0", "0"
+		if i < len(partsA) {
+			segA = partsA[i]
+		}
+		if i < len(partsB) {
+			segB = partsB[i]
+		}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("OSV API returned status %d", resp.StatusCode)
+		numA, errA := strconv.Atoi(segA)
+		numB, errB := strconv.Atoi(segB)
+		switch {
+		case errA == nil && errB == nil:
+			if numA != numB {
+				if numA < numB {
+					return -1
+				}
+				return 1
+			}
+		case errA == nil:
+			// A release segment sorts after a pre-release label (1.0.0 > 1.0.0-rc1)
+			return 1
+		case errB == nil:
+			return -1
+		default:
+			if cmp := strings.Compare(segA, segB); cmp != 0 {
+				return cmp
+			}
+		}
 	}
+	return 0
+}
 
-	var osvVuln OSVVulnerability
-	if err := json.NewDecoder(resp.Body).Decode(&osvVuln); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+func isVersionSeparator(r rune) bool {
+	return r == '.' || r == '-' || r == '+'
+}
+
+func containsString(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
 	}
+	return false
+}
 
-	// Convert to our format (using empty dependency as we don't have context)
-	vuln := c.convertOSVToVulnerabilityInfo(osvVuln, parser.DependencyInfo{})
-	return &vuln, nil
+// GetVulnerabilityByID retrieves detailed information about a specific vulnerability from the helper's OSV
+// source, or a default one when it queries none
+func (c *CVEHelper) GetVulnerabilityByID(ctx context.Context, vulnID string) (*VulnerabilityInfo, error) {
+	for _, source := range c.sources {
+		if osv, ok := source.(*OSVSource); ok {
+			return osv.GetVulnerability(ctx, vulnID)
+		}
+	}
+	return NewOSVSource().GetVulnerability(ctx, vulnID)
 }
 
 // FilterVulnerabilitiesBySeverity filters vulnerabilities by minimum severity level
 func (c *CVEHelper) FilterVulnerabilitiesBySeverity(result *BatchVulnerabilityResult, minSeverity CVESeverity) *BatchVulnerabilityResult {
-	minPriority := c.severityPriority(minSeverity)
+	minPriority := minSeverity.Rank()
 
 	filtered := &BatchVulnerabilityResult{
 		Dependencies:      make([]DependencyVulnerabilityResult, 0),
@@ -553,7 +684,7 @@ func (c *CVEHelper) FilterVulnerabilitiesBySeverity(result *BatchVulnerabilityRe
 		}
 
 		for _, vuln := range dep.Vulnerabilities {
-			if c.severityPriority(vuln.Severity) >= minPriority {
+			if vuln.Severity.Rank() >= minPriority {
 				filteredDep.Vulnerabilities = append(filteredDep.Vulnerabilities, vuln)
 			}
 		}
@@ -566,58 +697,210 @@ func (c *CVEHelper) FilterVulnerabilitiesBySeverity(result *BatchVulnerabilityRe
 	return filtered
 }
 
-// AggregateVulnerabilitySummary calculates the summary from findings
+// AggregateVulnerabilitySummary calculates the summary from findings. A finding whose check errored
+// counts as unchecked rather than as free of vulnerabilities, or as unsupported when no advisory database
+// covers its ecosystem.
 func AggregateVulnerabilitySummary(findings []model.ScanFinding) model.ScanSummary {
-	severityCount := map[string]int{
-		"critical": 0,
-		"high":     0,
-		"medium":   0,
-		"low":      0,
-		"none":     0,
-		"ignored":  0,
-	}
-	totalVulns := 0
+	summary := model.ScanSummary{TotalDependencies: len(findings)}
 
 	for _, f := range findings {
-		sev := strings.ToLower(f.Severity)
-
 		vulnCount := len(f.VulnerabilityIDs)
-		totalVulns += vulnCount
+		summary.TotalVulnerabilities += vulnCount
+		if f.UncheckedReason == UncheckedUnsupportedEcosystem {
+			summary.Unsupported++
+		} else if f.Error != "" {
+			summary.Unchecked++
+		}
 
 		if vulnCount == 0 {
-			severityCount["none"]++
-		} else if sev == "critical" || sev == "high" || sev == "medium" || sev == "low" {
-			severityCount[sev]++
-		} else {
-			severityCount["ignored"]++
+			if f.Error == "" {
+				summary.None++
+			}
+			continue
+		}
+		switch ParseSeverity(f.Severity) {
+		case SeverityCritical:
+			summary.Critical++
+		case SeverityHigh:
+			summary.High++
+		case SeverityMedium:
+			summary.Medium++
+		case SeverityLow:
+			summary.Low++
+		default:
+			summary.Ignored++
 		}
 	}
+	return summary
+}
 
-	return model.ScanSummary{
-		TotalDependencies:    len(findings),
-		TotalVulnerabilities: totalVulns,
-		Critical:             severityCount["critical"],
-		High:                 severityCount["high"],
-		Medium:               severityCount["medium"],
-		Low:                  severityCount["low"],
-		Ignored:              severityCount["ignored"],
-		None:                 severityCount["none"],
-	}
+// UncheckedDependencies lists the findings that could not be checked, with the reason, so a client can tell
+// them apart from dependencies without vulnerabilities
+func UncheckedDependencies(findings []model.ScanFinding) []model.UncheckedDependency {
+	var unchecked []model.UncheckedDependency
+	for _, f := range findings {
+		if f.Error == "" {
+			continue
+		}
+		reason := f.UncheckedReason
+		if reason == "" {
+			reason = UncheckedCheckFailed
+		}
+		unchecked = append(unchecked, model.UncheckedDependency{
+			Dependency: f.Dependency,
+			Version:    f.Version,
+			Reason:     reason,
+			Detail:     f.Error,
+		})
+	}
+	return unchecked
+}
+
+// PolicySeverities are the severities a policy can fail on
+var PolicySeverities = []string{SeverityCritical.String(), SeverityHigh.String(), SeverityMedium.String(), SeverityLow.String()}
+
+// IsPolicySeverity reports whether severity, in any casing, is one of PolicySeverities
+func IsPolicySeverity(severity string) bool {
+	return ParseSeverity(severity).Rank() > 0
+}
+
+// PolicyConfig is how EvaluatePolicy treats dependencies that could not be checked and names severities in
+// its reasons. The zero value fails open and only calls out dependencies of unsupported ecosystems.
+type PolicyConfig struct {
+	// FailClosed fails a scan in which some dependencies could not be checked. Fail-open passes such a scan
+	// when nothing blocking was found in the checked ones.
+	FailClosed bool
+	// UnsupportedAsUnchecked treats dependencies of an ecosystem no advisory database covers like those
+	// whose check failed, instead of only naming them in the reason of a passing scan
+	UnsupportedAsUnchecked bool
+	// Severities labels the severities named in reasons
+	Severities SeverityPalette
 }
 
-// EvaluatePolicy determines fail/pass status based on summary and policy
-func EvaluatePolicy(summary model.ScanSummary, failOn []string) (status, reason string) {
+// EvaluatePolicy determines fail/pass status based on summary and policy. Unchecked dependencies fail the
+// scan in fail-closed mode and are called out in the reason of a passing one. Unsupported ones are only
+// called out unless config counts them as unchecked.
+func EvaluatePolicy(summary model.ScanSummary, failOn []string, config PolicyConfig) (status, reason string) {
+	counts := map[CVESeverity]int{
+		SeverityCritical: summary.Critical,
+		SeverityHigh:     summary.High,
+		SeverityMedium:   summary.Medium,
+		SeverityLow:      summary.Low,
+	}
 	for _, sev := range failOn {
-		switch sev {
-		case "critical":
-			if summary.Critical > 0 {
-				return "fail", "Critical severity vulnerabilities found"
-			}
-		case "high":
-			if summary.High > 0 {
-				return "fail", "High severity vulnerabilities found"
-			}
+		severity := ParseSeverity(sev)
+		if counts[severity] > 0 {
+			return "fail", config.Severities.Style(severity).Label + " severity vulnerabilities found"
+		}
+	}
+	uncheckedCount := summary.Unchecked
+	if config.UnsupportedAsUnchecked {
+		uncheckedCount += summary.Unsupported
+	}
+	if uncheckedCount > 0 {
+		unchecked := fmt.Sprintf("%d of %d dependencies could not be checked for vulnerabilities", uncheckedCount, summary.TotalDependencies)
+		if config.FailClosed {
+			return "fail", unchecked
 		}
+		return "pass", "No blocking vulnerabilities found, but " + unchecked
+	}
+	if summary.Unsupported > 0 {
+		return "pass", fmt.Sprintf("No blocking vulnerabilities found, but %d of %d dependencies use an ecosystem no vulnerability database covers",
+			summary.Unsupported, summary.TotalDependencies)
 	}
 	return "pass", "No blocking vulnerabilities found"
 }
+
+// EvaluatePolicyWithScore applies EvaluatePolicy and additionally fails when a finding's risk score
+// reaches minScore; a minScore of 0 disables the score check
+func EvaluatePolicyWithScore(summary model.ScanSummary, findings []model.ScanFinding, failOn []string, minScore float64, config PolicyConfig) (status, reason string) {
+	if status, reason = EvaluatePolicy(summary, failOn, config); status == "fail" || minScore <= 0 {
+		return status, reason
+	}
+	for _, finding := range findings {
+		if finding.RiskScore >= minScore {
+			return "fail", fmt.Sprintf("%s has risk score %.1f, at or above %.1f", finding.Dependency, finding.RiskScore, minScore)
+		}
+	}
+	return status, reason
+}
+
+// DefaultPolicyFailOn returns the severities a scan fails on unless the request overrides them
+func DefaultPolicyFailOn() []string {
+	return []string{SeverityHigh.String(), SeverityCritical.String()}
+}
+
+// NormalizePolicySeverities validates severities against PolicySeverities and returns them in canonical casing
+func NormalizePolicySeverities(severities []string) ([]string, error) {
+	normalized := make([]string, 0, len(severities))
+	for _, severity := range severities {
+		if !IsPolicySeverity(severity) {
+			return nil, fmt.Errorf("unknown fail_on severity %q, expected one of %s", severity, strings.Join(PolicySeverities, ", "))
+		}
+		normalized = append(normalized, ParseSeverity(severity).String())
+	}
+	return normalized, nil
+}
+
+// ScanPolicyOverride replaces the default policy for a single scan, so pipelines can gate the same
+// application differently per stage
+type ScanPolicyOverride struct {
+	FailOn   []string // Severities that fail the scan; the default when empty
+	MinScore float64  // Risk score that fails the scan; 0 disables the check
+}
+
+// ParseScanPolicyOverride parses comma-separated fail_on severities and a min_score as sent by API callers.
+// It returns nil when neither is set.
+func ParseScanPolicyOverride(failOn, minScore string) (*ScanPolicyOverride, error) {
+	failOn, minScore = strings.TrimSpace(failOn), strings.TrimSpace(minScore)
+	if failOn == "" && minScore == "" {
+		return nil, nil
+	}
+
+	var override ScanPolicyOverride
+	if failOn != "" {
+		var severities []string
+		for _, severity := range strings.Split(failOn, ",") {
+			if severity = strings.TrimSpace(severity); severity != "" {
+				severities = append(severities, severity)
+			}
+		}
+		normalized, err := NormalizePolicySeverities(severities)
+		if err != nil {
+			return nil, err
+		}
+		override.FailOn = normalized
+	}
+	if minScore != "" {
+		score, err := strconv.ParseFloat(minScore, 64)
+		if err != nil || score < 0 || score > 10 {
+			return nil, fmt.Errorf("min_score must be a number between 0 and 10, got %q", minScore)
+		}
+		override.MinScore = score
+	}
+	return &override, nil
+}
+
+type scanPolicyKey struct{}
+
+// WithScanPolicy evaluates scans run for this request against override instead of the default policy
+func WithScanPolicy(ctx context.Context, override *ScanPolicyOverride) context.Context {
+	if override == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, scanPolicyKey{}, *override)
+}
+
+// ScanPolicyFor returns the policy scans run for this request are evaluated against: the override attached by
+// WithScanPolicy, or DefaultPolicyFailOn without a score check
+func ScanPolicyFor(ctx context.Context) (failOn []string, minScore float64) {
+	override, ok := ctx.Value(scanPolicyKey{}).(ScanPolicyOverride)
+	if !ok {
+		return DefaultPolicyFailOn(), 0
+	}
+	failOn = override.FailOn
+	if len(failOn) == 0 {
+		failOn = DefaultPolicyFailOn()
+	}
+	return failOn, override.MinScore
+}
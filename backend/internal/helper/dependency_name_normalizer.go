@@ -18,7 +18,7 @@ func NewDependencyNameNormalizer() *DependencyNameNormalizer {
 // NormalizeDependencyInfo normalizes a DependencyInfo for CVE checking
 func (n *DependencyNameNormalizer) NormalizeDependencyInfo(dep parser.DependencyInfo) parser.DependencyInfo {
 	normalized := dep // Copy the struct
-	normalized.Name = n.NormalizeName(dep.Name, dep.Runtime)
+	normalized.Name = n.NormalizeName(dep.Name, nameRuntime(dep))
 	normalized.Version = n.NormalizeVersion(dep.Version)
 	return normalized
 }
@@ -49,21 +49,27 @@ func (n *DependencyNameNormalizer) NormalizeName(name, runtime string) string {
 	}
 }
 
-// normalizeGoName normalizes Go module names for OSV compatibility
+// goMajorVersionSuffix matches the major-version element of a Go module path (e.g. /v2, /v3)
+var goMajorVersionSuffix = regexp.MustCompile(`^v\d+$`)
+
+// normalizeGoName normalizes Go module names for OSV compatibility.
+// OSV's Go ecosystem keys advisories by the full module path, so the major-version
+// suffix (e.g. github.com/foo/bar/v2) is part of the name and must be preserved.
 func (n *DependencyNameNormalizer) normalizeGoName(name string) string {
 	// Go modules in OSV use full import paths
 	name = strings.TrimSpace(name)
 
-	// Remove version suffixes like /v2, /v3, etc.
-	versionSuffixRegex := regexp.MustCompile(`/v\d+$`)
-	name = versionSuffixRegex.ReplaceAllString(name, "")
-
 	// Ensure github.com packages are properly formatted
 	if strings.Contains(name, "github.com") && !strings.HasPrefix(name, "github.com/") {
 		parts := strings.Split(name, "/")
 		for i, part := range parts {
 			if part == "github.com" && i+2 < len(parts) {
-				return strings.Join(parts[i:i+3], "/")
+				end := i + 3
+				// Keep the major-version suffix directly after owner/repo
+				if end < len(parts) && goMajorVersionSuffix.MatchString(parts[end]) {
+					end++
+				}
+				return strings.Join(parts[i:end], "/")
 			}
 		}
 	}
@@ -75,18 +81,19 @@ func (n *DependencyNameNormalizer) normalizeGoName(name string) string {
 func (n *DependencyNameNormalizer) normalizeNodeName(name string) string {
 	name = strings.TrimSpace(name)
 
-	// Remove version information if present in the name
-	// Examples: @babel/core@7.22.9 -> @babel/core
-	if strings.Contains(name, "@") && !strings.HasPrefix(name, "@") {
-		// Not a scoped package, so @ indicates version
-		name = strings.Split(name, "@")[0]
-	} else if strings.HasPrefix(name, "@") {
-		// Scoped package, check for version after the package name
-		parts := strings.Split(name, "@")
-		if len(parts) > 2 { // @scope/package@version
-			name = parts[0] + "@" + parts[1] // @scope/package
+	// Remove version information if present in the name. The version starts at the first "@" after the
+	// package path; for scoped packages that path begins after "@scope/".
+	// Examples: @babel/core@7.22.9 -> @babel/core, lodash@^4.17.0 -> lodash
+	pathStart := 0
+	if strings.HasPrefix(name, "@") {
+		pathStart = 1
+		if slash := strings.Index(name, "/"); slash > 0 {
+			pathStart = slash + 1
 		}
 	}
+	if at := strings.Index(name[pathStart:], "@"); at >= 0 {
+		name = name[:pathStart+at]
+	}
 
 	// OSV expects exact npm package names, including scoped packages
 	// Examples:
@@ -290,38 +297,10 @@ func (n *DependencyNameNormalizer) normalizeRustName(name string) string {
 	return name
 }
 
-// NormalizeVersion normalizes version strings for consistency
+// NormalizeVersion normalizes version strings for consistency. Constraints are reduced to a single
+// version while pre-release and build metadata are preserved (see NormalizeSemVer).
 func (n *DependencyNameNormalizer) NormalizeVersion(version string) string {
-	version = strings.TrimSpace(version)
-
-	// Remove common version prefixes and constraints
-	version = strings.TrimPrefix(version, "^")
-	version = strings.TrimPrefix(version, "~")
-	version = strings.TrimPrefix(version, ">=")
-	version = strings.TrimPrefix(version, "<=")
-	version = strings.TrimPrefix(version, ">")
-	version = strings.TrimPrefix(version, "<")
-	version = strings.TrimPrefix(version, "=")
-	version = strings.TrimPrefix(version, "v")
-
-	// Handle version ranges - take the first version
-	if strings.Contains(version, " - ") {
-		parts := strings.Split(version, " - ")
-		version = strings.TrimSpace(parts[0])
-	}
-
-	// Handle OR conditions - take the first version
-	if strings.Contains(version, " || ") {
-		parts := strings.Split(version, " || ")
-		version = strings.TrimSpace(parts[0])
-	}
-
-	// Remove any trailing spaces or comments
-	if idx := strings.Index(version, " "); idx != -1 {
-		version = version[:idx]
-	}
-
-	return strings.TrimSpace(version)
+	return NormalizeSemVer(version)
 }
 
 // NormalizeBatch normalizes a batch of dependencies
@@ -347,7 +326,7 @@ func (n *DependencyNameNormalizer) ValidateForCVECheck(dep parser.DependencyInfo
 		return false
 	}
 
-	// Check if runtime is supported
+	// Check if runtime is supported; an ecosystem override names the database to query itself
 	supportedRuntimes := map[string]bool{
 		"go":       true,
 		"node":     true,
@@ -367,21 +346,23 @@ func (n *DependencyNameNormalizer) ValidateForCVECheck(dep parser.DependencyInfo
 		"cargo":    true,
 	}
 
-	return supportedRuntimes[strings.ToLower(dep.Runtime)]
+	// A commit query needs no ecosystem
+	return dep.Ecosystem != "" || supportedRuntimes[strings.ToLower(dep.Runtime)] || CommitForDependency(dep) != ""
 }
 
 // GetCVECompatibleName returns the CVE-database compatible name for a dependency
 func (n *DependencyNameNormalizer) GetCVECompatibleName(dep parser.DependencyInfo) string {
-	return n.NormalizeName(dep.Name, dep.Runtime)
+	return n.NormalizeName(dep.Name, nameRuntime(dep))
 }
 
 // GetSuggestedNames returns alternative name suggestions for CVE checking
 func (n *DependencyNameNormalizer) GetSuggestedNames(dep parser.DependencyInfo) []string {
 	suggestions := []string{}
-	baseName := n.NormalizeName(dep.Name, dep.Runtime)
+	runtime := nameRuntime(dep)
+	baseName := n.NormalizeName(dep.Name, runtime)
 	suggestions = append(suggestions, baseName)
 
-	switch strings.ToLower(dep.Runtime) {
+	switch strings.ToLower(runtime) {
 	case "python", "pip":
 		// Add both hyphen and underscore variations
 		if strings.Contains(baseName, "-") {
@@ -1,10 +1,13 @@
 package helper
 
 import (
+	"context"
 	"elang-backend/internal/helper/parser"
 	"fmt"
+	"log/slog"
+	"net/http"
 	"path/filepath"
-
+	"regexp"
 	"strings"
 )
 
@@ -25,6 +28,7 @@ const (
 	RuntimeRuby    = parser.RuntimeRuby
 	RuntimePHP     = parser.RuntimePHP
 	RuntimeRust    = parser.RuntimeRust
+	RuntimeManual  = parser.RuntimeManual
 	RuntimeUnknown = parser.RuntimeUnknown
 )
 
@@ -35,12 +39,22 @@ type GitHubAPIInterface = parser.GitHubAPIInterface
 type DependencyParser struct {
 	parsers   map[parser.RuntimeType]parser.RuntimeParser
 	githubAPI parser.GitHubAPIInterface // Optional: for repository verification
+
+	// Scan scope controls (optional)
+	enabledRuntimes map[parser.RuntimeType]bool // Runtimes allowed to be parsed; empty means all
+	denylist        []*regexp.Regexp            // Dependency name patterns dropped from parse results
+	excludeDev      bool                        // Drop development and test-only dependencies from parse results
+	goIndirect      bool                        // Include the indirect requirements of go.mod files as transitive dependencies
+
+	gradleProperties map[string]string // Uploaded gradle.properties, for version variables a build file does not assign
+	mavenBOMs        *MavenBOMResolver // Fetches the BOMs a pom.xml imports; shared by the parser's copies
 }
 
 // NewDependencyParser creates a new instance of DependencyParser
 func NewDependencyParser() *DependencyParser {
 	dp := &DependencyParser{
-		parsers: make(map[parser.RuntimeType]parser.RuntimeParser),
+		parsers:   make(map[parser.RuntimeType]parser.RuntimeParser),
+		mavenBOMs: NewMavenBOMResolver(DefaultMavenRepositoryURL, nil),
 	}
 
 	// Register parsers for different runtimes
@@ -53,6 +67,7 @@ func NewDependencyParser() *DependencyParser {
 	dp.parsers[parser.RuntimeRuby] = parser.NewRubyParser()
 	dp.parsers[parser.RuntimePHP] = parser.NewPHPParser()
 	dp.parsers[parser.RuntimeRust] = parser.NewRustParser()
+	dp.parsers[parser.RuntimeManual] = parser.NewManualParser()
 
 	return dp
 }
@@ -64,6 +79,161 @@ func NewDependencyParserWithGitHub(githubAPI parser.GitHubAPIInterface) *Depende
 	return dp
 }
 
+type gradlePropertiesKey struct{}
+
+// WithGradlePropertiesFile attaches the content of a gradle.properties uploaded with a build file to the request
+func WithGradlePropertiesFile(ctx context.Context, content string) context.Context {
+	return context.WithValue(ctx, gradlePropertiesKey{}, content)
+}
+
+// GradlePropertiesFile returns the gradle.properties content attached to the request, or an empty string
+func GradlePropertiesFile(ctx context.Context) string {
+	content, _ := ctx.Value(gradlePropertiesKey{}).(string)
+	return content
+}
+
+// WithGradleProperties returns a copy of the parser that resolves Gradle version variables a build file does
+// not assign from the content of a gradle.properties file. Blank content returns the parser itself.
+func (dp *DependencyParser) WithGradleProperties(content string) *DependencyParser {
+	if strings.TrimSpace(content) == "" {
+		return dp
+	}
+	withProperties := *dp
+	withProperties.gradleProperties = parser.ParseGradleProperties(content)
+	return &withProperties
+}
+
+type goIndirectKey struct{}
+
+// WithGoIndirectDependencies overrides, for one request, whether the indirect requirements of a go.mod are parsed
+func WithGoIndirectDependencies(ctx context.Context, include bool) context.Context {
+	return context.WithValue(ctx, goIndirectKey{}, include)
+}
+
+// WithGoIndirect returns a copy of the parser that includes or leaves out indirect Go requirements as the
+// request asked with WithGoIndirectDependencies. A request that did not ask returns the parser itself.
+func (dp *DependencyParser) WithGoIndirect(ctx context.Context) *DependencyParser {
+	include, ok := ctx.Value(goIndirectKey{}).(bool)
+	if !ok || include == dp.goIndirect {
+		return dp
+	}
+	withIndirect := *dp
+	withIndirect.goIndirect = include
+	return &withIndirect
+}
+
+// SetEnabledRuntimes restricts parsing to the given runtimes. Names are matched case-insensitively
+// and may be display names ("Node.js") or runtime types ("node"). An empty list enables every runtime.
+func (dp *DependencyParser) SetEnabledRuntimes(runtimes []string) error {
+	enabled := make(map[parser.RuntimeType]bool)
+	for _, name := range runtimes {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		runtime := toRuntimeType(name)
+		if _, exists := dp.parsers[runtime]; !exists {
+			return fmt.Errorf("unknown runtime %q in enabled runtimes", name)
+		}
+		enabled[runtime] = true
+	}
+	dp.enabledRuntimes = enabled
+	return nil
+}
+
+// SetDenylist sets regular expressions matched against dependency names;
+// matching dependencies are dropped from parse results so they never reach OSV.
+func (dp *DependencyParser) SetDenylist(patterns []string) error {
+	var denylist []*regexp.Regexp
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid denylist pattern %q: %w", pattern, err)
+		}
+		denylist = append(denylist, re)
+	}
+	dp.denylist = denylist
+	return nil
+}
+
+// SetExcludeDev controls whether dependencies the manifest marks as development or test only
+// (Maven test scope, Gradle test configurations, npm devDependencies, ...) are dropped from parse results
+func (dp *DependencyParser) SetExcludeDev(exclude bool) {
+	dp.excludeDev = exclude
+}
+
+// SetIncludeGoIndirect controls whether the requirements a go.mod marks "// indirect" are parsed, as
+// transitive dependencies, so the whole module graph is scanned rather than only the direct requirements
+func (dp *DependencyParser) SetIncludeGoIndirect(include bool) {
+	dp.goIndirect = include
+}
+
+// SetMavenRepository sets the Maven repository the BOMs imported by pom.xml files are fetched from, e.g. a Nexus
+// or Artifactory mirror of Maven Central, and the transport they are fetched through. An empty URL means
+// DefaultMavenRepositoryURL and a nil transport DefaultOutboundTransport.
+func (dp *DependencyParser) SetMavenRepository(url string, transport http.RoundTripper) {
+	dp.mavenBOMs = NewMavenBOMResolver(url, transport)
+}
+
+// IsRuntimeEnabled reports whether a runtime has a parser and is allowed by the enabled runtimes list
+func (dp *DependencyParser) IsRuntimeEnabled(runtime string) bool {
+	runtimeType := toRuntimeType(runtime)
+	if _, exists := dp.parsers[runtimeType]; !exists {
+		return false
+	}
+	if len(dp.enabledRuntimes) == 0 {
+		return true
+	}
+	return dp.enabledRuntimes[runtimeType]
+}
+
+// filterExcluded removes dependencies whose name matches a denylist pattern,
+// and development dependencies when they are excluded
+func (dp *DependencyParser) filterExcluded(deps []parser.DependencyInfo) []parser.DependencyInfo {
+	if len(dp.denylist) == 0 && !dp.excludeDev {
+		return deps
+	}
+	kept := deps[:0]
+	for _, dep := range deps {
+		if dp.isDenylisted(dep.Name) {
+			slog.Info("Dropping denylisted dependency", "dependency", dep.Name)
+			continue
+		}
+		if dp.excludeDev && dep.Dev {
+			slog.Debug("Dropping development dependency", "dependency", dep.Name, "scope", dep.Scope)
+			continue
+		}
+		kept = append(kept, dep)
+	}
+	return kept
+}
+
+func (dp *DependencyParser) isDenylisted(name string) bool {
+	for _, re := range dp.denylist {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveRuntimeType resolves a display name ("Node.js") or runtime type ("node") to a RuntimeType
+func ResolveRuntimeType(name string) parser.RuntimeType {
+	return toRuntimeType(name)
+}
+
+// toRuntimeType resolves a display name ("Node.js") or runtime type ("node") to a RuntimeType
+func toRuntimeType(name string) parser.RuntimeType {
+	if rt := GetRuntimeTypeCI(name); rt != parser.RuntimeUnknown {
+		return rt
+	}
+	return parser.RuntimeType(strings.ToLower(strings.TrimSpace(name)))
+}
+
 // DetectRuntime detects the runtime based on file content and filename
 func (dp *DependencyParser) DetectRuntime(filename, content string) parser.RuntimeType {
 	filename = strings.ToLower(filepath.Base(filename))
@@ -95,27 +265,93 @@ func (dp *DependencyParser) DetectRuntime(filename, content string) parser.Runti
 		return parser.RuntimeDotNet
 	}
 
-	// Content-based detection as fallback
-	if strings.Contains(content, "module ") && strings.Contains(content, "require") {
-		return parser.RuntimeGo
-	}
-	if strings.Contains(content, "\"dependencies\"") && strings.Contains(content, "\"name\"") {
-		return parser.RuntimeNode
-	}
-	if strings.Contains(content, "<project") && strings.Contains(content, "<dependencies>") {
-		return parser.RuntimeJava
-	}
+	// Content-based detection as fallback, for renamed or extensionless uploads
+	return detectRuntimeFromContent(content)
+}
 
+var (
+	// composerPackageKeyRegex matches a Composer "vendor/package" key, which npm names never look like
+	composerPackageKeyRegex = regexp.MustCompile(`"[a-z0-9][a-z0-9_.-]*/[a-z0-9][a-z0-9_.-]*"\s*:`)
+	gemLineRegex            = regexp.MustCompile(`(?m)^\s*gem\s+["']`)
+	tomlDependenciesRegex   = regexp.MustCompile(`(?m)^\[(?:dev-|build-)?dependencies\]\s*$`)
+	goModuleRegex           = regexp.MustCompile(`(?m)^module\s+\S+`)
+	gradleDependencyRegex   = regexp.MustCompile(`(?m)^\s*(?:implementation|api|compileOnly|runtimeOnly|testImplementation|compile|testCompile)\s*[\('"]`)
+	// requirementLineRegex matches a pinned or bounded pip requirement such as "requests==2.31.0"
+	requirementLineRegex = regexp.MustCompile(`(?m)^[A-Za-z0-9][A-Za-z0-9._-]*(?:\[[^\]]*\])?\s*(?:==|>=|<=|~=|!=|===)\s*[0-9]`)
+)
+
+// contentHeuristics recognize a manifest by its content, in order: markup and JSON formats are told apart
+// first, then the line-based ones, with the loosest (pip requirements) last
+var contentHeuristics = []struct {
+	runtime parser.RuntimeType
+	matches func(content string) bool
+}{
+	{parser.RuntimeDotNet, func(c string) bool {
+		return strings.Contains(c, "<PackageReference") || (strings.Contains(c, "<packages>") && strings.Contains(c, "<package id="))
+	}},
+	{parser.RuntimeJava, func(c string) bool {
+		return strings.Contains(c, "<project") && (strings.Contains(c, "<dependencies>") || strings.Contains(c, "<modelVersion>"))
+	}},
+	{parser.RuntimePHP, func(c string) bool {
+		return strings.Contains(c, `"require"`) && (strings.Contains(c, `"php"`) || composerPackageKeyRegex.MatchString(c))
+	}},
+	{parser.RuntimeNode, func(c string) bool {
+		return strings.Contains(c, `"dependencies"`) || strings.Contains(c, `"devDependencies"`)
+	}},
+	{parser.RuntimePython, func(c string) bool {
+		return strings.Contains(c, "[tool.poetry") || strings.Contains(c, "[project]") ||
+			(strings.Contains(c, "[packages]") && strings.Contains(c, "[[source]]"))
+	}},
+	{parser.RuntimeRust, func(c string) bool {
+		return tomlDependenciesRegex.MatchString(c)
+	}},
+	{parser.RuntimeGradle, func(c string) bool {
+		return strings.Contains(c, "dependencies {") && gradleDependencyRegex.MatchString(c)
+	}},
+	{parser.RuntimeGo, func(c string) bool {
+		return goModuleRegex.MatchString(c) && strings.Contains(c, "require")
+	}},
+	{parser.RuntimeRuby, func(c string) bool {
+		return gemLineRegex.MatchString(c)
+	}},
+	{parser.RuntimePython, func(c string) bool {
+		return requirementLineRegex.MatchString(c)
+	}},
+}
+
+// detectRuntimeFromContent recognizes a manifest whose filename gave no hint
+func detectRuntimeFromContent(content string) parser.RuntimeType {
+	for _, heuristic := range contentHeuristics {
+		if heuristic.matches(content) {
+			return heuristic.runtime
+		}
+	}
 	return parser.RuntimeUnknown
 }
 
 // ParseDependencyFile parses a dependency file and returns dependency information
 func (dp *DependencyParser) ParseDependencyFile(filename, content string, runtimeHint ...parser.RuntimeType) parser.ParseResult {
+	return dp.ParseDependencyFileContext(context.Background(), filename, content, runtimeHint...)
+}
+
+// ParseDependencyFileContext is ParseDependencyFile for a request: fetching the BOMs a pom.xml imports stops
+// when ctx is done
+func (dp *DependencyParser) ParseDependencyFileContext(ctx context.Context, filename, content string, runtimeHint ...parser.RuntimeType) parser.ParseResult {
 	var runtime parser.RuntimeType
 
+	// Bound the work a single upload can cause before any detection or parsing runs
+	if len(content) > parser.MaxContentSize {
+		return parser.ParseResult{
+			Success: false,
+			Error:   fmt.Sprintf("dependency file is %d bytes, exceeding the %d byte limit", len(content), parser.MaxContentSize),
+			Runtime: string(parser.RuntimeUnknown),
+		}
+	}
+
 	// Use runtime hint if provided, otherwise detect
 	if len(runtimeHint) > 0 && runtimeHint[0] != parser.RuntimeUnknown {
-		runtime = runtimeHint[0]
+		// Accept display names ("Node.js") as well as runtime types ("node")
+		runtime = toRuntimeType(string(runtimeHint[0]))
 	} else {
 		runtime = dp.DetectRuntime(filename, content)
 	}
@@ -137,7 +373,30 @@ func (dp *DependencyParser) ParseDependencyFile(filename, content string, runtim
 		}
 	}
 
-	dependencies, err := runtimeParser.Parse(content)
+	if !dp.IsRuntimeEnabled(string(runtime)) {
+		return parser.ParseResult{
+			Success: false,
+			Error:   fmt.Sprintf("runtime %s is disabled", runtime),
+			Runtime: string(runtime),
+		}
+	}
+
+	var dependencies []parser.DependencyInfo
+	var err error
+	switch typed := runtimeParser.(type) {
+	case *parser.GoParser:
+		dependencies, err = typed.ParseWithIndirect(content, dp.goIndirect)
+	case *parser.GradleParser:
+		dependencies, err = typed.ParseWithProperties(content, dp.gradleProperties)
+	case *parser.JavaParser:
+		// Versions left to imported BOMs such as Spring Boot's or Jackson's are read from the Maven repository
+		dependencies, err = typed.ParseWithManagedVersions(content, dp.mavenBOMs.Resolve(ctx, parser.MavenBOMImports(content)))
+	default:
+		dependencies, err = runtimeParser.Parse(content)
+	}
+	if err == nil {
+		err = canonicalizeEcosystems(dependencies)
+	}
 	if err != nil {
 		return parser.ParseResult{
 			Success: false,
@@ -147,15 +406,33 @@ func (dp *DependencyParser) ParseDependencyFile(filename, content string, runtim
 	}
 
 	return parser.ParseResult{
-		Dependencies: dependencies,
+		Dependencies: dp.filterExcluded(dependencies),
 		Runtime:      string(runtime),
 		Success:      true,
 	}
 }
 
-// ParseDependencyFileWithGitHub parses a dependency file and verifies GitHub repositories
-func (dp *DependencyParser) ParseDependencyFileWithGitHub(filename, content string, runtimeHint ...parser.RuntimeType) parser.ParseResult {
-	result := dp.ParseDependencyFile(filename, content, runtimeHint...)
+// canonicalizeEcosystems validates the ecosystems a manifest names for its dependencies, such as those of a
+// manual dependency list, and rewrites them to OSV's spelling
+func canonicalizeEcosystems(deps []parser.DependencyInfo) error {
+	for i := range deps {
+		if deps[i].Ecosystem == "" {
+			continue
+		}
+		ecosystem, err := ParseEcosystem(deps[i].Ecosystem)
+		if err != nil {
+			return fmt.Errorf("dependency %s: %w", deps[i].Name, err)
+		}
+		deps[i].Ecosystem = ecosystem
+	}
+	return nil
+}
+
+// ParseDependencyFileWithGitHub parses a dependency file and fills in the GitHub repository of each dependency.
+// With verifyGitHub, and a parser created with NewDependencyParserWithGitHub, every repository is also checked
+// to exist, one GitHub request per dependency; otherwise the repositories are only derived from the names.
+func (dp *DependencyParser) ParseDependencyFileWithGitHub(ctx context.Context, filename, content string, verifyGitHub bool, runtimeHint ...parser.RuntimeType) parser.ParseResult {
+	result := dp.ParseDependencyFileContext(ctx, filename, content, runtimeHint...)
 
 	if !result.Success {
 		return result
@@ -163,14 +440,15 @@ func (dp *DependencyParser) ParseDependencyFileWithGitHub(filename, content stri
 
 	// Enhance dependencies with GitHub repository information
 	for i := range result.Dependencies {
-		dp.enhanceWithGitHubInfo(&result.Dependencies[i])
+		dp.enhanceWithGitHubInfo(&result.Dependencies[i], verifyGitHub)
 	}
 
 	return result
 }
 
-// enhanceWithGitHubInfo adds GitHub repository information to a dependency
-func (dp *DependencyParser) enhanceWithGitHubInfo(dep *parser.DependencyInfo) {
+// enhanceWithGitHubInfo adds GitHub repository information to a dependency, dropping it again when verify is
+// set and GitHub does not know the repository
+func (dp *DependencyParser) enhanceWithGitHubInfo(dep *parser.DependencyInfo, verify bool) {
 	// First, try to construct GitHub URL from known patterns
 	githubURL := dp.constructGitHubURL(dep)
 	dep.GitHubURL = githubURL
@@ -179,7 +457,7 @@ func (dp *DependencyParser) enhanceWithGitHubInfo(dep *parser.DependencyInfo) {
 		dep.IsGitHubRepo = true
 
 		// If we have GitHub API access, verify the repository exists
-		if dp.githubAPI != nil && dep.Owner != "" && dep.Repo != "" {
+		if verify && dp.githubAPI != nil && dep.Owner != "" && dep.Repo != "" {
 			_, err := dp.githubAPI.GetDefaultBranch(dep.Owner, dep.Repo)
 			if err != nil {
 				dep.IsGitHubRepo = false
@@ -277,6 +555,7 @@ var RuntimeNameToType = map[string]parser.RuntimeType{
 	"Ruby":    parser.RuntimeRuby,
 	"PHP":     parser.RuntimePHP,
 	"Rust":    parser.RuntimeRust,
+	"Manual":  parser.RuntimeManual,
 }
 
 // RuntimeTypeToName maps internal RuntimeType constants to human-readable names
@@ -290,6 +569,7 @@ var RuntimeTypeToName = map[parser.RuntimeType]string{
 	parser.RuntimeRuby:    "Ruby",
 	parser.RuntimePHP:     "PHP",
 	parser.RuntimeRust:    "Rust",
+	parser.RuntimeManual:  "Manual",
 	parser.RuntimeUnknown: "Unknown",
 }
 
@@ -0,0 +1,80 @@
+package helper
+
+import (
+	"elang-backend/internal/helper/parser"
+	"fmt"
+	"strings"
+)
+
+// osvEcosystems lists the ecosystems defined by the OSV schema (https://ossf.github.io/osv-schema/#affectedpackage-field)
+var osvEcosystems = []string{
+	"AlmaLinux", "Alpine", "Android", "Bitnami", "Chainguard", "ConanCenter", "CRAN", "crates.io", "Debian",
+	"GHC", "GitHub Actions", "Go", "Hackage", "Hex", "Linux", "Mageia", "Maven", "MinimOS", "npm", "NuGet",
+	"openEuler", "openSUSE", "OSS-Fuzz", "Packagist", "Photon OS", "Pub", "PyPI", "Red Hat", "Rocky Linux",
+	"RubyGems", "SUSE", "SwiftURL", "Ubuntu", "Wolfi",
+}
+
+// ParseEcosystem validates an OSV ecosystem name in any casing and returns its canonical spelling, e.g.
+// "pypi" becomes "PyPI". Distribution ecosystems may carry a release suffix such as "Debian:12".
+func ParseEcosystem(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	base, release, hasRelease := strings.Cut(value, ":")
+	for _, ecosystem := range osvEcosystems {
+		if strings.EqualFold(base, ecosystem) {
+			if hasRelease {
+				if strings.TrimSpace(release) == "" {
+					break
+				}
+				return ecosystem + ":" + strings.TrimSpace(release), nil
+			}
+			return ecosystem, nil
+		}
+	}
+	return "", fmt.Errorf("unknown OSV ecosystem %q", value)
+}
+
+// EcosystemForDependency returns the OSV ecosystem a dependency is looked up in: its Ecosystem override
+// when set, otherwise the ecosystem of its runtime
+func EcosystemForDependency(dep parser.DependencyInfo) string {
+	if dep.Ecosystem != "" {
+		return dep.Ecosystem
+	}
+	return EcosystemForRuntime(dep.Runtime)
+}
+
+// EcosystemsForDependency returns the OSV ecosystems a dependency is looked up in, in order: only its
+// Ecosystem override when set, otherwise the candidate ecosystems of its runtime
+func EcosystemsForDependency(dep parser.DependencyInfo) []string {
+	if dep.Ecosystem != "" {
+		return []string{dep.Ecosystem}
+	}
+	return EcosystemsForRuntime(dep.Runtime)
+}
+
+// nameRuntime returns the runtime whose naming rules apply to dep, following its ecosystem override so
+// that e.g. an npm tool in a Java application is normalized like an npm package
+func nameRuntime(dep parser.DependencyInfo) string {
+	if dep.Ecosystem == "" {
+		return dep.Runtime
+	}
+	switch dep.Ecosystem {
+	case "Go":
+		return "go"
+	case "npm":
+		return "npm"
+	case "PyPI":
+		return "pip"
+	case "Maven":
+		return "maven"
+	case "NuGet":
+		return "nuget"
+	case "RubyGems":
+		return "gem"
+	case "Packagist":
+		return "composer"
+	case "crates.io":
+		return "cargo"
+	default:
+		return ""
+	}
+}
@@ -2,29 +2,71 @@ package helper
 
 import (
 	"fmt"
+	"net/url"
 	"regexp"
 	"strings"
 )
 
+var githubRepoURLRegex = regexp.MustCompile(`(?i)^https?://(www\.)?([^/]+)/([^/]+)/([^/]+)$`)
+
 type GitHubRepoParts struct {
+	Host  string // host the repository is browsed on; empty means github.com
 	Owner string
 	Repo  string
 }
 
+// URL returns the repository's URL on its host
+func (p GitHubRepoParts) URL() string {
+	host := p.Host
+	if host == "" {
+		host = "github.com"
+	}
+	return fmt.Sprintf("https://%s/%s/%s", host, p.Owner, p.Repo)
+}
+
+// CanonicalRepoFromInfo reads the canonical owner/repo from a GitHub repository payload.
+// For renamed or transferred repositories GitHub redirects to the new location, and
+// full_name reflects the name the repository lives under now. The host is taken from html_url, so it
+// is only empty when the payload has none.
+func CanonicalRepoFromInfo(info map[string]interface{}) (GitHubRepoParts, bool) {
+	var host string
+	if htmlURL, ok := info["html_url"].(string); ok {
+		if parsed, err := url.Parse(htmlURL); err == nil {
+			host = strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+		}
+	}
+	if fullName, ok := info["full_name"].(string); ok {
+		if owner, repo, found := strings.Cut(fullName, "/"); found && owner != "" && repo != "" {
+			return GitHubRepoParts{Host: host, Owner: owner, Repo: repo}, true
+		}
+	}
+	name, _ := info["name"].(string)
+	ownerInfo, _ := info["owner"].(map[string]interface{})
+	login, _ := ownerInfo["login"].(string)
+	if login != "" && name != "" {
+		return GitHubRepoParts{Host: host, Owner: login, Repo: name}, true
+	}
+	return GitHubRepoParts{}, false
+}
+
 // ExtractGitHubOwnerRepo extracts the owner and repo from a GitHub URL.
-// Example: https://github.com/gin-gonic/gin -> gin-gonic, gin
-func ExtractGitHubOwnerRepo(url string) (GitHubRepoParts, bool) {
+// Example: https://github.com/gin-gonic/gin -> gin-gonic, gin. URLs on enterpriseHost, a GitHub Enterprise
+// Server's host such as "github.example.com", are accepted as well when it is not empty.
+func ExtractGitHubOwnerRepo(repoURL, enterpriseHost string) (GitHubRepoParts, bool) {
 	// Remove trailing .git or slashes
-	url = strings.TrimSuffix(url, ".git")
-	url = strings.TrimRight(url, "/")
+	repoURL = strings.TrimSuffix(repoURL, ".git")
+	repoURL = strings.TrimRight(repoURL, "/")
 
-	// Regex for https://github.com/owner/repo (with or without www)
-	re := regexp.MustCompile(`(?i)^https?://(www\.)?github\.com/([^/]+)/([^/]+)$`)
-	matches := re.FindStringSubmatch(url)
-	if len(matches) == 4 {
-		return GitHubRepoParts{Owner: matches[2], Repo: matches[3]}, true
+	// https://host/owner/repo (with or without www)
+	matches := githubRepoURLRegex.FindStringSubmatch(repoURL)
+	if len(matches) != 5 {
+		return GitHubRepoParts{}, false
 	}
-	return GitHubRepoParts{}, false
+	host := strings.ToLower(matches[2])
+	if host != "github.com" && (enterpriseHost == "" || host != strings.ToLower(strings.TrimSpace(enterpriseHost))) {
+		return GitHubRepoParts{}, false
+	}
+	return GitHubRepoParts{Host: host, Owner: matches[3], Repo: matches[4]}, true
 }
 
 // GetCommitSHAFromVersion finds the commit SHA for a given version from a list of tags.
@@ -92,16 +134,17 @@ func GetCommitSHAFromVersion(version string, tags []map[string]interface{}) (str
 }
 
 // NormalizeVersion removes common prefixes from version strings to allow better matching.
-// It handles prefixes like "v", "r", "release-", "version-", etc.
+// It handles prefixes like "v", "r", "release-", "version-", etc., and comparison operators such as
+// "^" or ">=". Pre-release and build segments are kept, so "v1.0.0-RC.1" matches the tag "1.0.0-rc.1".
 func NormalizeVersion(version string) string {
 	if version == "" {
 		return ""
 	}
 
-	// Trim whitespace first
-	normalized := strings.TrimSpace(version)
+	// Trim whitespace and any constraint operator first
+	normalized := trimVersionOperator(strings.TrimSpace(version))
 
-	// Remove common prefixes (case-insensitive)
+	// Remove common word prefixes (case-insensitive)
 	prefixes := []string{
 		"version-",
 		"version_",
@@ -109,22 +152,23 @@ func NormalizeVersion(version string) string {
 		"release_",
 		"tag-",
 		"tag_",
-		"v",
-		"r",
 	}
 
 	// Convert to lowercase for comparison, but preserve original case for result
 	lowerNormalized := strings.ToLower(normalized)
 
 	for _, prefix := range prefixes {
-		lowerPrefix := strings.ToLower(prefix)
-		if strings.HasPrefix(lowerNormalized, lowerPrefix) {
+		if strings.HasPrefix(lowerNormalized, prefix) {
 			// Remove the prefix from the original (preserving case)
 			normalized = normalized[len(prefix):]
 			break // Only remove the first matching prefix
 		}
 	}
 
+	// Single letter prefixes only count when a version number follows ("v1.2", "r2"), not in "rc1"
+	normalized = trimVersionPrefix(normalized, "v")
+	normalized = trimVersionPrefix(normalized, "r")
+
 	return strings.ToLower(strings.TrimSpace(normalized))
 }
 
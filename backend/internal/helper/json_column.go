@@ -0,0 +1,40 @@
+package helper
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// EncodeJSONColumn marshals value for a JSON column. A nil value stays nil (SQL NULL). A value that cannot
+// be marshalled is returned with the error as {"marshal_error": "..."}, which is still worth storing: the
+// row then says that something was recorded rather than looking like it had no value.
+func EncodeJSONColumn(value interface{}) ([]byte, error) {
+	if value == nil {
+		return nil, nil
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		placeholder, _ := json.Marshal(map[string]string{"marshal_error": err.Error()})
+		return placeholder, err
+	}
+	return encoded, nil
+}
+
+// DecodeJSONColumn returns a stored JSON column for embedding in a response. An empty column decodes to
+// null, and a malformed one, e.g. truncated, to {"decode_error": "...", "raw": "<stored text>"} instead of
+// failing the whole response.
+func DecodeJSONColumn(raw []byte) json.RawMessage {
+	if len(raw) == 0 {
+		return nil
+	}
+	if json.Valid(raw) {
+		return json.RawMessage(raw)
+	}
+	var probe interface{}
+	decodeErr := json.Unmarshal(raw, &probe)
+	if decodeErr == nil {
+		decodeErr = fmt.Errorf("invalid JSON")
+	}
+	marker, _ := json.Marshal(map[string]string{"decode_error": decodeErr.Error(), "raw": string(raw)})
+	return marker
+}
@@ -0,0 +1,72 @@
+package helper
+
+import (
+	"fmt"
+	"time"
+)
+
+// Maintenance risk levels of a dependency's repository
+const (
+	MaintenanceRiskHigh    = "high"
+	MaintenanceRiskMedium  = "medium"
+	MaintenanceRiskLow     = "low"
+	MaintenanceRiskUnknown = "unknown"
+)
+
+// Days without a commit after which a repository counts as inactive, and then as abandoned
+const (
+	InactiveAfterDays  = 180
+	AbandonedAfterDays = 365
+)
+
+// MaintenanceSignals are the repository facts a maintenance risk is judged on; nil fields were not fetched
+type MaintenanceSignals struct {
+	Archived      *bool
+	LastCommitAt  *time.Time
+	Stars         *int
+	Collaborators *int
+}
+
+// DaysSinceLastCommit returns the whole days between the last commit and now, or nil when it is unknown
+func (s MaintenanceSignals) DaysSinceLastCommit(now time.Time) *int {
+	if s.LastCommitAt == nil {
+		return nil
+	}
+	days := int(now.Sub(*s.LastCommitAt).Hours() / 24)
+	return &days
+}
+
+// AssessMaintenanceRisk judges how likely a dependency is to be abandoned: high for an archived repository or one
+// without a commit for AbandonedAfterDays, medium for one inactive for InactiveAfterDays or with a single
+// collaborator, low otherwise, and unknown when neither the archived flag nor the last commit is known. The
+// reasons explain every signal that raised the level.
+func AssessMaintenanceRisk(signals MaintenanceSignals, now time.Time) (string, []string) {
+	if signals.Archived == nil && signals.LastCommitAt == nil {
+		return MaintenanceRiskUnknown, nil
+	}
+
+	risk := MaintenanceRiskLow
+	var reasons []string
+	raise := func(level, reason string) {
+		if level == MaintenanceRiskHigh || risk == MaintenanceRiskLow {
+			risk = level
+		}
+		reasons = append(reasons, reason)
+	}
+
+	if signals.Archived != nil && *signals.Archived {
+		raise(MaintenanceRiskHigh, "repository is archived")
+	}
+	if days := signals.DaysSinceLastCommit(now); days != nil {
+		switch {
+		case *days > AbandonedAfterDays:
+			raise(MaintenanceRiskHigh, fmt.Sprintf("no commit for %d days", *days))
+		case *days > InactiveAfterDays:
+			raise(MaintenanceRiskMedium, fmt.Sprintf("no commit for %d days", *days))
+		}
+	}
+	if signals.Collaborators != nil && *signals.Collaborators == 1 {
+		raise(MaintenanceRiskMedium, "single collaborator")
+	}
+	return risk, reasons
+}
@@ -0,0 +1,84 @@
+package helper
+
+import (
+	"context"
+	"elang-backend/internal/helper/parser"
+	"elang-backend/internal/model"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ManifestFile is one dependency file of a multi-manifest (monorepo) scan
+type ManifestFile struct {
+	FileName string
+	Content  string
+}
+
+type ConflictingVersion = model.ConflictingVersion
+type VersionConflict = model.VersionConflict
+
+// MultiParseResult is the merged result of parsing several manifests
+type MultiParseResult struct {
+	// Dependencies holds one entry per distinct name and version, so each version is scanned on its own
+	Dependencies []parser.DependencyInfo `json:"dependencies"`
+	// Conflicts lists the dependencies declared at more than one version, ordered by name
+	Conflicts []VersionConflict `json:"conflicts,omitempty"`
+}
+
+// ParseMultipleDependencyFiles parses every manifest and merges their dependencies. A dependency declared
+// at the same version in several files is kept once; one declared at different versions is kept once per
+// version and reported as a conflict. The first manifest that cannot be parsed fails the whole merge.
+func (dp *DependencyParser) ParseMultipleDependencyFiles(files []ManifestFile, runtimeHint ...parser.RuntimeType) (*MultiParseResult, error) {
+	return dp.ParseMultipleDependencyFilesContext(context.Background(), files, runtimeHint...)
+}
+
+// ParseMultipleDependencyFilesContext is ParseMultipleDependencyFiles for a request: fetching the BOMs a pom.xml
+// imports stops when ctx is done
+func (dp *DependencyParser) ParseMultipleDependencyFilesContext(ctx context.Context, files []ManifestFile, runtimeHint ...parser.RuntimeType) (*MultiParseResult, error) {
+	type versionKey struct{ dependency, version string }
+
+	result := &MultiParseResult{}
+	index := map[versionKey]int{}         // position of each distinct version in result.Dependencies
+	sources := map[versionKey][]string{}  // files declaring each distinct version
+	versions := map[string][]versionKey{} // distinct versions of each dependency, in first-seen order
+
+	for _, file := range files {
+		parsed := dp.ParseDependencyFileContext(ctx, file.FileName, file.Content, runtimeHint...)
+		if !parsed.Success {
+			return nil, fmt.Errorf("failed to parse %s: %s", file.FileName, parsed.Error)
+		}
+		for _, dep := range parsed.Dependencies {
+			dependency := EcosystemForDependency(dep) + "\x00" + dep.Name
+			key := versionKey{dependency: dependency, version: strings.TrimSpace(dep.Version)}
+			if _, seen := index[key]; !seen {
+				index[key] = len(result.Dependencies)
+				result.Dependencies = append(result.Dependencies, dep)
+				versions[dependency] = append(versions[dependency], key)
+			}
+			if !containsString(sources[key], file.FileName) {
+				sources[key] = append(sources[key], file.FileName)
+			}
+		}
+	}
+
+	for _, keys := range versions {
+		if len(keys) < 2 {
+			continue
+		}
+		first := result.Dependencies[index[keys[0]]]
+		conflict := VersionConflict{Name: first.Name, Ecosystem: EcosystemForDependency(first)}
+		for _, key := range keys {
+			conflict.Versions = append(conflict.Versions, ConflictingVersion{Version: key.version, Files: sources[key]})
+		}
+		result.Conflicts = append(result.Conflicts, conflict)
+	}
+	sort.Slice(result.Conflicts, func(i, j int) bool {
+		if result.Conflicts[i].Name != result.Conflicts[j].Name {
+			return result.Conflicts[i].Name < result.Conflicts[j].Name
+		}
+		return result.Conflicts[i].Ecosystem < result.Conflicts[j].Ecosystem
+	})
+
+	return result, nil
+}
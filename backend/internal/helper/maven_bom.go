@@ -0,0 +1,178 @@
+package helper
+
+import (
+	"container/list"
+	"context"
+	"elang-backend/internal/helper/parser"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultMavenRepositoryURL is Maven Central, where imported BOMs are fetched from unless configured otherwise
+const DefaultMavenRepositoryURL = "https://repo1.maven.org/maven2"
+
+const (
+	// mavenBOMFetchTimeout bounds fetching all the BOMs one pom imports
+	mavenBOMFetchTimeout = 30 * time.Second
+	// maxMavenBOMDepth stops following BOMs that import further BOMs
+	maxMavenBOMDepth = 5
+	// maxMavenBOMSize caps a fetched BOM
+	maxMavenBOMSize = 4 << 20
+	// maxCachedMavenBOMBytes caps the pom content behind the BOMs a resolver keeps
+	maxCachedMavenBOMBytes = 32 << 20
+)
+
+// MavenBOMResolver fetches the BOMs poms import from a Maven repository. Released artifacts never change, so
+// fetched BOMs are kept, the least recently used evicted first once their poms add up to maxCachedMavenBOMBytes.
+// It is safe for concurrent use.
+type MavenBOMResolver struct {
+	repositoryURL string
+	client        *http.Client
+
+	mu          sync.Mutex
+	cached      map[string]*list.Element
+	order       list.List // of *cachedMavenBOM, most recently used first
+	cachedBytes int
+}
+
+// cachedMavenBOM is a fetched BOM with the size of the pom it was parsed from
+type cachedMavenBOM struct {
+	url  string
+	bom  *parser.MavenBOM
+	size int
+}
+
+// NewMavenBOMResolver fetches BOMs from repositoryURL, e.g. a Nexus or Artifactory mirror of Maven Central,
+// through transport. An empty URL means DefaultMavenRepositoryURL and a nil transport DefaultOutboundTransport.
+func NewMavenBOMResolver(repositoryURL string, transport http.RoundTripper) *MavenBOMResolver {
+	repositoryURL = strings.TrimRight(strings.TrimSpace(repositoryURL), "/")
+	if repositoryURL == "" {
+		repositoryURL = DefaultMavenRepositoryURL
+	}
+	if transport == nil {
+		transport = DefaultOutboundTransport()
+	}
+	return &MavenBOMResolver{
+		repositoryURL: repositoryURL,
+		client:        &http.Client{Transport: transport},
+		cached:        make(map[string]*list.Element),
+	}
+}
+
+// RepositoryURL returns the repository BOMs are fetched from
+func (r *MavenBOMResolver) RepositoryURL() string {
+	return r.repositoryURL
+}
+
+// mavenPOMURL locates the pom of coordinate in the repository's standard layout
+func mavenPOMURL(repository string, coordinate parser.MavenCoordinate) string {
+	return fmt.Sprintf("%s/%s/%s/%s/%s-%s.pom", repository, strings.ReplaceAll(coordinate.GroupID, ".", "/"),
+		coordinate.ArtifactID, coordinate.Version, coordinate.ArtifactID, coordinate.Version)
+}
+
+// cachedBOM returns the BOM fetched from url before, if it is still kept
+func (r *MavenBOMResolver) cachedBOM(url string) (*parser.MavenBOM, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	element, ok := r.cached[url]
+	if !ok {
+		return nil, false
+	}
+	r.order.MoveToFront(element)
+	return element.Value.(*cachedMavenBOM).bom, true
+}
+
+// keep caches a BOM parsed from size bytes of pom, evicting the least recently used ones beyond the byte limit
+func (r *MavenBOMResolver) keep(url string, bom *parser.MavenBOM, size int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.cached[url]; ok {
+		return
+	}
+	r.cached[url] = r.order.PushFront(&cachedMavenBOM{url: url, bom: bom, size: size})
+	r.cachedBytes += size
+	for r.cachedBytes > maxCachedMavenBOMBytes && r.order.Len() > 1 {
+		oldest := r.order.Remove(r.order.Back()).(*cachedMavenBOM)
+		delete(r.cached, oldest.url)
+		r.cachedBytes -= oldest.size
+	}
+}
+
+// fetch downloads and parses the pom of coordinate, from the cache when it was fetched before
+func (r *MavenBOMResolver) fetch(ctx context.Context, coordinate parser.MavenCoordinate) (*parser.MavenBOM, error) {
+	url := mavenPOMURL(r.repositoryURL, coordinate)
+	if cached, ok := r.cachedBOM(url); ok {
+		return cached, nil
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("maven repository returned status: %s", resp.Status)
+	}
+	content, err := io.ReadAll(io.LimitReader(resp.Body, maxMavenBOMSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(content) > maxMavenBOMSize {
+		return nil, fmt.Errorf("BOM exceeds %d bytes", maxMavenBOMSize)
+	}
+	bom, ok := parser.ParseMavenBOM(string(content))
+	if !ok {
+		return nil, fmt.Errorf("BOM is not a valid pom")
+	}
+	r.keep(url, bom, len(content))
+	return bom, nil
+}
+
+// Resolve fetches the BOMs a pom imports, and the BOMs those import, and returns their managed versions by
+// groupId:artifactId. As in Maven, the first BOM to manage an artifact decides its version. BOMs that cannot be
+// fetched, also once ctx is done, are skipped with a warning, so the dependencies relying on them stay unresolved.
+func (r *MavenBOMResolver) Resolve(ctx context.Context, imports []parser.MavenCoordinate) map[string]string {
+	if len(imports) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, mavenBOMFetchTimeout)
+	defer cancel()
+
+	managed := map[string]string{}
+	visited := map[string]bool{}
+	var collect func(coordinates []parser.MavenCoordinate, depth int)
+	collect = func(coordinates []parser.MavenCoordinate, depth int) {
+		for _, coordinate := range coordinates {
+			if visited[coordinate.String()] {
+				continue
+			}
+			visited[coordinate.String()] = true
+
+			bom, err := r.fetch(ctx, coordinate)
+			if err != nil {
+				slog.Warn("Failed to fetch imported Maven BOM, dependencies it manages stay unresolved",
+					"bom", coordinate.String(), "repository", r.repositoryURL, "error", err)
+				continue
+			}
+			for key, version := range bom.Managed {
+				if _, ok := managed[key]; !ok {
+					managed[key] = version
+				}
+			}
+			if depth < maxMavenBOMDepth {
+				collect(bom.Imports, depth+1)
+			}
+		}
+	}
+	collect(imports, 1)
+	return managed
+}
@@ -0,0 +1,75 @@
+package helper
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// OutboundHTTPConfig configures how requests to external APIs (OSV, GitHub) leave the service,
+// e.g. through a corporate egress proxy that intercepts TLS
+type OutboundHTTPConfig struct {
+	ProxyURL     string                                // Proxy for all outbound requests; empty uses HTTP_PROXY/HTTPS_PROXY/NO_PROXY
+	CABundleFile string                                // PEM file of additional trusted CAs, added to the system pool
+	Proxy        func(*http.Request) (*url.URL, error) // Custom proxy selection; takes precedence over ProxyURL
+}
+
+// defaultTransport is shared by the clients created without a transport of their own
+var defaultTransport http.RoundTripper = defaultOutboundTransport()
+
+// defaultOutboundTransport honours the standard proxy environment variables
+func defaultOutboundTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+	return transport
+}
+
+// NewOutboundTransport builds an HTTP transport that applies cfg's proxy and CA bundle
+func NewOutboundTransport(cfg OutboundHTTPConfig) (*http.Transport, error) {
+	transport := defaultOutboundTransport()
+
+	switch {
+	case cfg.Proxy != nil:
+		transport.Proxy = cfg.Proxy
+	case cfg.ProxyURL != "":
+		proxyURL, err := url.Parse(cfg.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		switch proxyURL.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return nil, fmt.Errorf("invalid proxy URL %q: scheme must be http, https or socks5", cfg.ProxyURL)
+		}
+		if proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q: missing host", cfg.ProxyURL)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if cfg.CABundleFile != "" {
+		pem, err := os.ReadFile(cfg.CABundleFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("CA bundle %s contains no PEM certificates", cfg.CABundleFile)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+
+	return transport, nil
+}
+
+// DefaultOutboundTransport returns the transport of clients to external APIs that were not given one built by
+// NewOutboundTransport. It only honours the standard proxy environment variables.
+func DefaultOutboundTransport() http.RoundTripper {
+	return defaultTransport
+}
@@ -2,6 +2,7 @@ package parser
 
 import (
 	"regexp"
+	"strings"
 )
 
 // DotNetParser handles parsing of .NET project files
@@ -17,24 +18,80 @@ func (p *DotNetParser) GetRuntime() RuntimeType {
 	return RuntimeDotNet
 }
 
-// Parse parses .csproj files
+// Regex patterns for NuGet references in SDK-style project files and packages.config
+var (
+	// <PackageReference Include="..." Version="..." /> or a block with child elements such as <PrivateAssets>
+	dotnetPackageReferenceRegex = regexp.MustCompile(`(?s)<PackageReference\b([^>]*?)(?:/>|>(.*?)</PackageReference>)`)
+
+	// <package id="..." version="..." developmentDependency="true" /> in packages.config
+	dotnetPackagesConfigRegex = regexp.MustCompile(`<package\b([^>]*?)/?>`)
+
+	dotnetAttributeRegex = regexp.MustCompile(`(\w+)\s*=\s*"([^"]*)"`)
+)
+
+// Parse parses .csproj files and packages.config. References with PrivateAssets="all" and packages
+// flagged developmentDependency are build or test tooling that does not ship, so they are marked as Dev.
 func (p *DotNetParser) Parse(content string) ([]DependencyInfo, error) {
 	var dependencies []DependencyInfo
 
 	// Parse PackageReference elements
-	packageRefRegex := regexp.MustCompile(`<PackageReference\s+Include="([^"]+)"\s+Version="([^"]+)"`)
-	matches := packageRefRegex.FindAllStringSubmatch(content, -1)
+	for _, match := range dotnetPackageReferenceRegex.FindAllStringSubmatch(content, -1) {
+		attrs := parseXMLAttributes(match[1])
+		name := attrs["include"]
+		version := attrs["version"]
+		if version == "" {
+			version = xmlChildText(match[2], "Version")
+		}
+		if name == "" || version == "" {
+			continue
+		}
 
-	for _, match := range matches {
-		if len(match) >= 3 {
-			depInfo := p.ParseDependency(match[1], match[2])
-			dependencies = append(dependencies, *depInfo)
+		privateAssets := attrs["privateassets"]
+		if privateAssets == "" {
+			privateAssets = xmlChildText(match[2], "PrivateAssets")
 		}
+
+		depInfo := p.ParseDependency(name, version)
+		depInfo.Dev = strings.EqualFold(privateAssets, "all")
+		dependencies = append(dependencies, *depInfo)
+	}
+
+	// Parse packages.config entries
+	for _, match := range dotnetPackagesConfigRegex.FindAllStringSubmatch(content, -1) {
+		attrs := parseXMLAttributes(match[1])
+		if attrs["id"] == "" || attrs["version"] == "" {
+			continue
+		}
+		depInfo := p.ParseDependency(attrs["id"], attrs["version"])
+		depInfo.Dev = strings.EqualFold(attrs["developmentdependency"], "true")
+		dependencies = append(dependencies, *depInfo)
 	}
 
 	return dependencies, nil
 }
 
+// parseXMLAttributes returns the attributes of an element keyed by lower-cased name
+func parseXMLAttributes(raw string) map[string]string {
+	attrs := make(map[string]string)
+	for _, match := range dotnetAttributeRegex.FindAllStringSubmatch(raw, -1) {
+		attrs[strings.ToLower(match[1])] = strings.TrimSpace(match[2])
+	}
+	return attrs
+}
+
+// xmlChildText returns the trimmed text of the first <tag> child in body
+func xmlChildText(body, tag string) string {
+	_, rest, found := strings.Cut(body, "<"+tag+">")
+	if !found {
+		return ""
+	}
+	text, _, found := strings.Cut(rest, "</"+tag+">")
+	if !found {
+		return ""
+	}
+	return strings.TrimSpace(text)
+}
+
 // ParseDependency parses a single .NET dependency
 func (p *DotNetParser) ParseDependency(name, version string) *DependencyInfo {
 	return &DependencyInfo{
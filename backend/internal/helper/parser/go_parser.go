@@ -1,11 +1,12 @@
 package parser
 
 import (
-	"bufio"
-	"regexp"
+	"bytes"
 	"strings"
 )
 
+var requireKeyword = []byte("require")
+
 // GoParser handles parsing of Go module files
 type GoParser struct{}
 
@@ -19,61 +20,98 @@ func (p *GoParser) GetRuntime() RuntimeType {
 	return RuntimeGo
 }
 
-// Parse parses go.mod files
+// Parse parses the direct requirements of a go.mod file
 func (p *GoParser) Parse(content string) ([]DependencyInfo, error) {
-	var dependencies []DependencyInfo
-
-	// Regex patterns for go.mod parsing
-	requireBlockRegex := regexp.MustCompile(`require\s*\(\s*([\s\S]*?)\s*\)`)
-	requireLineRegex := regexp.MustCompile(`require\s+([^\s]+)\s+([^\s]+)`)
-	dependencyRegex := regexp.MustCompile(`^\s*([^\s]+)\s+([^\s/]+(?:\s+//\s*indirect)?)`)
+	return p.ParseWithIndirect(content, false)
+}
 
-	// Handle require blocks
-	requireBlocks := requireBlockRegex.FindAllStringSubmatch(content, -1)
-	for _, block := range requireBlocks {
-		blockContent := block[1]
-		scanner := bufio.NewScanner(strings.NewReader(blockContent))
+// ParseWithIndirect parses go.mod files in a single pass over the lines, so large files (or a go.sum
+// passed by mistake) are not rescanned by whole-content regexes. Requirements marked "// indirect" are
+// skipped unless includeIndirect is set, in which case they are returned as Transitive. go.mod lists the
+// version of every module the build selects, so no go.sum is needed for exact versions.
+func (p *GoParser) ParseWithIndirect(content string, includeIndirect bool) ([]DependencyInfo, error) {
+	var dependencies []DependencyInfo
+	add := func(requirement string) {
+		if depInfo := p.parseRequirement(requirement); depInfo != nil && (includeIndirect || !depInfo.Transitive) {
+			dependencies = append(dependencies, *depInfo)
+		}
+	}
+	inRequireBlock := false
 
-		for scanner.Scan() {
-			line := strings.TrimSpace(scanner.Text())
-			if line == "" || strings.HasPrefix(line, "//") {
-				continue
-			}
+	scanner := newLineScanner(content)
+	for scanner.Scan() {
+		// Outside a require block only require directives matter; skip other lines (e.g. go.sum hashes) without copying them
+		if !inRequireBlock && !bytes.Contains(scanner.Bytes(), requireKeyword) {
+			continue
+		}
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "//") {
+			continue
+		}
 
-			// Skip indirect dependencies
-			if strings.Contains(line, "// indirect") {
+		if inRequireBlock {
+			if strings.HasPrefix(line, ")") {
+				inRequireBlock = false
 				continue
 			}
 
-			// Remove inline comments
-			if idx := strings.Index(line, "//"); idx != -1 {
-				line = strings.TrimSpace(line[:idx])
-			}
-
-			matches := dependencyRegex.FindStringSubmatch(line)
-			if len(matches) >= 3 {
-				depInfo := p.ParseDependency(matches[1], matches[2])
-				if depInfo != nil {
-					dependencies = append(dependencies, *depInfo)
-				}
-			}
+			add(line)
+			continue
 		}
-	}
 
-	// Handle single require lines
-	requireLines := requireLineRegex.FindAllStringSubmatch(content, -1)
-	for _, match := range requireLines {
-		if len(match) >= 3 && !strings.Contains(match[2], "indirect") {
-			depInfo := p.ParseDependency(match[1], match[2])
-			if depInfo != nil {
-				dependencies = append(dependencies, *depInfo)
+		rest, isRequire := strings.CutPrefix(line, "require")
+		if !isRequire || (rest != "" && !strings.HasPrefix(rest, "(") && !strings.HasPrefix(rest, " ") && !strings.HasPrefix(rest, "\t")) {
+			continue
+		}
+		rest = strings.TrimSpace(rest)
+		if strings.HasPrefix(rest, "(") {
+			// A block may also open and close on one line: require ( module v1.0.0 )
+			rest = strings.TrimSpace(strings.TrimPrefix(rest, "("))
+			if before, closed := strings.CutSuffix(rest, ")"); closed {
+				add(before)
+				continue
 			}
+			inRequireBlock = true
+			add(rest)
+			continue
 		}
+
+		// Handle single require lines
+		add(rest)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
 	}
 
 	return dependencies, nil
 }
 
+// parseRequirement parses a "module version" requirement, marking it Transitive when its trailing comment
+// is the "// indirect" go mod tidy adds
+func (p *GoParser) parseRequirement(line string) *DependencyInfo {
+	indirect := false
+	if idx := strings.Index(line, "//"); idx != -1 {
+		indirect = isIndirectComment(line[idx+2:])
+		line = line[:idx]
+	}
+	fields := strings.Fields(line)
+	if len(fields) < 2 || strings.Contains(fields[1], "/") {
+		return nil
+	}
+	depInfo := p.ParseDependency(fields[0], fields[1])
+	if depInfo != nil {
+		depInfo.Transitive = indirect
+	}
+	return depInfo
+}
+
+// isIndirectComment reports whether a requirement's comment marks it indirect: "indirect", optionally followed
+// by "; " and further text
+func isIndirectComment(comment string) bool {
+	comment = strings.TrimSpace(comment)
+	return comment == "indirect" || strings.HasPrefix(comment, "indirect;")
+}
+
 // ParseDependency parses a single Go dependency
 func (p *GoParser) ParseDependency(name, version string) *DependencyInfo {
 	// Handle indirect dependencies - skip them
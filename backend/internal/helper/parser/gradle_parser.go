@@ -2,10 +2,17 @@ package parser
 
 import (
 	"fmt"
+	"log/slog"
 	"regexp"
+	"sort"
 	"strings"
 )
 
+// UnresolvedVersion is recorded for a dependency whose version is a build variable that none of the uploaded
+// files define, or a Maven dependency whose version was to come from a BOM that could not be read. Such
+// dependencies are not checked for vulnerabilities.
+const UnresolvedVersion = "unresolved"
+
 // GradleParser handles parsing of Gradle build files
 type GradleParser struct{}
 
@@ -19,101 +26,193 @@ func (p *GradleParser) GetRuntime() RuntimeType {
 	return RuntimeGradle
 }
 
-// Parse parses build.gradle and build.gradle.kts files
-func (p *GradleParser) Parse(content string) ([]DependencyInfo, error) {
-	var dependencies []DependencyInfo
-
-	// Regex patterns for Gradle dependency parsing
-	// Handle various Gradle dependency configurations: implementation, api, compile, etc.
+// Regex patterns for Gradle dependency parsing, matched against one line at a time.
+// Handle various Gradle dependency configurations: implementation, api, compile, etc.
+var (
 	// Pattern 1: Single line dependencies like: implementation 'group:artifact:version'
-	singleLineRegex := regexp.MustCompile(`(?m)^\s*(implementation|api|compile|testImplementation|androidTestImplementation|debugImplementation|releaseImplementation|compileOnly|runtimeOnly|annotationProcessor|kapt|ksp)\s+['"]([\w\.-]+):([\w\.-]+):([^'"]+)['"]`)
+	gradleSingleLineRegex = regexp.MustCompile(`^\s*(implementation|api|compile|testImplementation|androidTestImplementation|debugImplementation|releaseImplementation|compileOnly|runtimeOnly|annotationProcessor|kapt|ksp)\s+['"]([\w\.-]+):([\w\.-]+):([^'"]+)['"]`)
 
 	// Pattern 2: Dependencies with configurations like: implementation('group:artifact:version') { ... }
-	configBlockRegex := regexp.MustCompile(`(?m)^\s*(implementation|api|compile|testImplementation|androidTestImplementation|debugImplementation|releaseImplementation|compileOnly|runtimeOnly|annotationProcessor|kapt|ksp)\s*\(\s*['"]([\w\.-]+):([\w\.-]+):([^'"]+)['"]\s*\)`)
+	gradleConfigBlockRegex = regexp.MustCompile(`^\s*(implementation|api|compile|testImplementation|androidTestImplementation|debugImplementation|releaseImplementation|compileOnly|runtimeOnly|annotationProcessor|kapt|ksp)\s*\(\s*['"]([\w\.-]+):([\w\.-]+):([^'"]+)['"]\s*\)`)
 
 	// Pattern 3: Platform/BOM dependencies like: implementation platform('group:artifact:version')
-	platformRegex := regexp.MustCompile(`(?m)^\s*(implementation|api|compile|testImplementation|androidTestImplementation|debugImplementation|releaseImplementation|compileOnly|runtimeOnly)\s+platform\s*\(\s*['"]([\w\.-]+):([\w\.-]+):([^'"]+)['"]\s*\)`)
+	gradlePlatformRegex = regexp.MustCompile(`^\s*(implementation|api|compile|testImplementation|androidTestImplementation|debugImplementation|releaseImplementation|compileOnly|runtimeOnly)\s+platform\s*\(\s*['"]([\w\.-]+):([\w\.-]+):([^'"]+)['"]\s*\)`)
 
-	// Pattern 4: Variable-based versions like: implementation "group:artifact:$version"
-	variableVersionRegex := regexp.MustCompile(`(?m)^\s*(implementation|api|compile|testImplementation|androidTestImplementation|debugImplementation|releaseImplementation|compileOnly|runtimeOnly|annotationProcessor|kapt|ksp)\s+["']([\w\.-]+):([\w\.-]+):\$(\w+)["']`)
+	// Pattern 4: Variable-based versions like: implementation "group:artifact:$version" or ("group:artifact:${libs.version}")
+	gradleVariableVersionRegex = regexp.MustCompile(`^\s*(implementation|api|compile|testImplementation|androidTestImplementation|debugImplementation|releaseImplementation|compileOnly|runtimeOnly|annotationProcessor|kapt|ksp)\s*(?:\(\s*|\s)["']([\w\.-]+):([\w\.-]+):([^'"]*\$[^'"]*)["']`)
 
 	// Pattern 5: Project dependencies like: implementation project(':module')
-	projectRegex := regexp.MustCompile(`(?m)^\s*(implementation|api|compile|testImplementation|androidTestImplementation|debugImplementation|releaseImplementation|compileOnly|runtimeOnly)\s+project\s*\(\s*['"]:([^'"]+)['"]\s*\)`)
-
-	// Parse single line dependencies
-	matches := singleLineRegex.FindAllStringSubmatch(content, -1)
-	for _, match := range matches {
-		if len(match) >= 5 {
-			groupId := strings.TrimSpace(match[2])
-			artifactId := strings.TrimSpace(match[3])
-			version := strings.TrimSpace(match[4])
-
-			depInfo := p.ParseDependency(fmt.Sprintf("%s:%s", groupId, artifactId), version)
-			depInfo.Owner = groupId
-			depInfo.Repo = artifactId
-			dependencies = append(dependencies, *depInfo)
+	gradleProjectRegex = regexp.MustCompile(`^\s*(implementation|api|compile|testImplementation|androidTestImplementation|debugImplementation|releaseImplementation|compileOnly|runtimeOnly)\s+project\s*\(\s*['"]:([^'"]+)['"]\s*\)`)
+)
+
+// Patterns for the variable assignments a version can refer to: ext { name = '1.0' }, ext.name = '1.0',
+// def/val name = "1.0", set('name', '1.0') and extra["name"] = "1.0"
+var (
+	gradleAssignmentRegex      = regexp.MustCompile(`^\s*(?:(?:project\.|rootProject\.)?ext\.|def\s+|val\s+|var\s+)?(\w+)\s*=\s*['"]([^'"$]+)['"]\s*;?\s*$`)
+	gradleSetAssignmentRegex   = regexp.MustCompile(`^\s*(?:ext\.)?set\s*\(\s*['"](\w+)['"]\s*,\s*['"]([^'"$]+)['"]\s*\)`)
+	gradleExtraAssignmentRegex = regexp.MustCompile(`^\s*(?:ext|extra)\[\s*['"](\w+)['"]\s*\]\s*=\s*['"]([^'"$]+)['"]`)
+
+	// gradleVariableReferenceRegex matches $name and ${name} references inside a version string
+	gradleVariableReferenceRegex = regexp.MustCompile(`\$\{\s*([\w.]+)\s*\}|\$(\w+)`)
+)
+
+// Parse parses build.gradle and build.gradle.kts files, resolving version variables from the assignments in
+// the file itself
+func (p *GradleParser) Parse(content string) ([]DependencyInfo, error) {
+	return p.ParseWithProperties(content, nil)
+}
+
+// ParseWithProperties parses a build file like Parse, falling back to properties, such as those of an uploaded
+// gradle.properties, for version variables the file does not assign. Each line is recorded by the first pattern
+// it matches, so a variable-based version is not also reported as a literal single-line version. Versions
+// referring to a variable defined nowhere are recorded as UnresolvedVersion.
+func (p *GradleParser) ParseWithProperties(content string, properties map[string]string) ([]DependencyInfo, error) {
+	var dependencies []DependencyInfo
+
+	variables, err := gradleVariables(content)
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range properties {
+		if _, assigned := variables[name]; !assigned {
+			variables[name] = value
 		}
 	}
+	unresolved := map[string]bool{}
+
+	scanner := newLineScanner(content)
+	for scanner.Scan() {
+		line := scanner.Text()
+		// Every supported notation contains a colon; skip everything else without running the regexes
+		if !strings.Contains(line, ":") {
+			continue
+		}
+
+		// Parse project dependencies
+		if match := gradleProjectRegex.FindStringSubmatch(line); match != nil {
+			moduleName := strings.TrimSpace(match[2])
 
-	// Parse dependencies with configuration blocks
-	configMatches := configBlockRegex.FindAllStringSubmatch(content, -1)
-	for _, match := range configMatches {
-		if len(match) >= 5 {
-			groupId := strings.TrimSpace(match[2])
-			artifactId := strings.TrimSpace(match[3])
-			version := strings.TrimSpace(match[4])
-
-			depInfo := p.ParseDependency(fmt.Sprintf("%s:%s", groupId, artifactId), version)
-			depInfo.Owner = groupId
-			depInfo.Repo = artifactId
+			depInfo := p.ParseDependency(fmt.Sprintf("project:%s", moduleName), "local")
+			depInfo.Owner = "" // Local project, no owner
+			depInfo.Repo = moduleName
+			setGradleConfiguration(depInfo, match[1])
 			dependencies = append(dependencies, *depInfo)
+			continue
+		}
+
+		// Parse variable-based versions, substituting the variables' values
+		if match := gradleVariableVersionRegex.FindStringSubmatch(line); match != nil {
+			version, missing := resolveGradleVersion(strings.TrimSpace(match[4]), variables)
+			for _, name := range missing {
+				unresolved[name] = true
+			}
+			dependencies = append(dependencies, p.coordinateDependency(match[1], match[2], match[3], version))
+			continue
 		}
+
+		// Parse platform, configuration block and single line dependencies
+		for _, re := range []*regexp.Regexp{gradlePlatformRegex, gradleConfigBlockRegex, gradleSingleLineRegex} {
+			if match := re.FindStringSubmatch(line); match != nil {
+				dependencies = append(dependencies, p.coordinateDependency(match[1], match[2], match[3], strings.TrimSpace(match[4])))
+				break
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
 	}
 
-	// Parse platform dependencies
-	platformMatches := platformRegex.FindAllStringSubmatch(content, -1)
-	for _, match := range platformMatches {
-		if len(match) >= 5 {
-			groupId := strings.TrimSpace(match[2])
-			artifactId := strings.TrimSpace(match[3])
-			version := strings.TrimSpace(match[4])
-
-			depInfo := p.ParseDependency(fmt.Sprintf("%s:%s", groupId, artifactId), version)
-			depInfo.Owner = groupId
-			depInfo.Repo = artifactId
-			dependencies = append(dependencies, *depInfo)
+	if len(unresolved) > 0 {
+		names := make([]string, 0, len(unresolved))
+		for name := range unresolved {
+			names = append(names, name)
 		}
+		sort.Strings(names)
+		slog.Warn("Gradle version variables are not defined in the uploaded files; their dependencies are not checked",
+			"variables", names)
 	}
+	return dependencies, nil
+}
 
-	// Parse variable-based versions (we'll store the variable name as version)
-	variableMatches := variableVersionRegex.FindAllStringSubmatch(content, -1)
-	for _, match := range variableMatches {
-		if len(match) >= 5 {
-			groupId := strings.TrimSpace(match[2])
-			artifactId := strings.TrimSpace(match[3])
-			variable := strings.TrimSpace(match[4])
-
-			depInfo := p.ParseDependency(fmt.Sprintf("%s:%s", groupId, artifactId), fmt.Sprintf("$%s", variable))
-			depInfo.Owner = groupId
-			depInfo.Repo = artifactId
-			dependencies = append(dependencies, *depInfo)
+// gradleVariables collects the string values assigned to variables and extra properties in a build file
+func gradleVariables(content string) (map[string]string, error) {
+	variables := map[string]string{}
+	scanner := newLineScanner(content)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.Contains(line, "=") && !strings.Contains(line, "set") {
+			continue
+		}
+		for _, re := range []*regexp.Regexp{gradleAssignmentRegex, gradleSetAssignmentRegex, gradleExtraAssignmentRegex} {
+			if match := re.FindStringSubmatch(line); match != nil {
+				variables[match[1]] = strings.TrimSpace(match[2])
+				break
+			}
 		}
 	}
+	return variables, scanner.Err()
+}
 
-	// Parse project dependencies
-	projectMatches := projectRegex.FindAllStringSubmatch(content, -1)
-	for _, match := range projectMatches {
-		if len(match) >= 3 {
-			moduleName := strings.TrimSpace(match[2])
+// resolveGradleVersion substitutes the $name and ${name} references in version. References may be qualified
+// with project., rootProject., ext. or extra.; a version with any undefined reference is UnresolvedVersion,
+// returned with the undefined names.
+func resolveGradleVersion(version string, variables map[string]string) (string, []string) {
+	var missing []string
+	resolved := gradleVariableReferenceRegex.ReplaceAllStringFunc(version, func(reference string) string {
+		match := gradleVariableReferenceRegex.FindStringSubmatch(reference)
+		name := match[1] + match[2]
+		for _, prefix := range []string{"rootProject.", "project.", "ext.", "extra."} {
+			name = strings.TrimPrefix(name, prefix)
+		}
+		if value, ok := variables[name]; ok {
+			return value
+		}
+		missing = append(missing, name)
+		return reference
+	})
+	if len(missing) > 0 {
+		return UnresolvedVersion, missing
+	}
+	return resolved, nil
+}
 
-			depInfo := p.ParseDependency(fmt.Sprintf("project:%s", moduleName), "local")
-			depInfo.Owner = "" // Local project, no owner
-			depInfo.Repo = moduleName
-			dependencies = append(dependencies, *depInfo)
+// ParseGradleProperties reads the key=value (or key: value) pairs of a gradle.properties file, skipping
+// blank lines and # or ! comments
+func ParseGradleProperties(content string) map[string]string {
+	properties := map[string]string{}
+	scanner := newLineScanner(content)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") {
+			continue
 		}
+		separator := strings.IndexAny(line, "=:")
+		if separator <= 0 {
+			continue
+		}
+		key := strings.TrimSpace(line[:separator])
+		properties[key] = strings.TrimSpace(line[separator+1:])
 	}
+	return properties
+}
 
-	return dependencies, nil
+// coordinateDependency builds a dependency declared in configuration from group:artifact:version coordinates
+func (p *GradleParser) coordinateDependency(configuration, group, artifact, version string) DependencyInfo {
+	groupId := strings.TrimSpace(group)
+	artifactId := strings.TrimSpace(artifact)
+
+	depInfo := p.ParseDependency(fmt.Sprintf("%s:%s", groupId, artifactId), version)
+	depInfo.Owner = groupId
+	depInfo.Repo = artifactId
+	setGradleConfiguration(depInfo, configuration)
+	return *depInfo
+}
+
+// setGradleConfiguration records the declaring configuration as the dependency scope.
+// Test configurations (testImplementation, androidTestImplementation, ...) are marked as Dev.
+func setGradleConfiguration(dep *DependencyInfo, configuration string) {
+	dep.Scope = configuration
+	dep.Dev = strings.HasPrefix(configuration, "test") || strings.HasPrefix(configuration, "androidTest")
 }
 
 // ParseDependency parses a single Gradle dependency
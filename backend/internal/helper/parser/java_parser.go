@@ -19,30 +19,60 @@ func (p *JavaParser) GetRuntime() RuntimeType {
 	return RuntimeJava
 }
 
-// Parse parses Maven pom.xml files
+// mavenDependencyRegex matches a <dependency> block in the conventional groupId, artifactId, version, scope order
+var mavenDependencyRegex = regexp.MustCompile(`(?s)<dependency[^>]*>\s*<groupId>\s*([^<\s]+)\s*</groupId>\s*<artifactId>\s*([^<\s]+)\s*</artifactId>\s*(?:<version>\s*([^<\s]+)\s*</version>\s*)?(?:<scope>\s*([^<\s]*)\s*</scope>\s*)?</dependency>`)
+
+// Parse parses Maven pom.xml files. Each dependency carries its <scope> (compile when omitted);
+// test-scoped dependencies are marked as Dev.
 func (p *JavaParser) Parse(content string) ([]DependencyInfo, error) {
+	return p.ParseWithManagedVersions(content, nil)
+}
+
+// ParseWithManagedVersions parses a pom.xml like Parse, taking the version of a dependency that declares none
+// from the pom's own <dependencyManagement> or else from managed, the groupId:artifactId versions of the BOMs
+// it imports. ${property} references are resolved from the pom's <properties>. When the pom imports BOMs, a
+// dependency left without a version, or one referring to an undefined property, is recorded as
+// UnresolvedVersion rather than checked without a version.
+func (p *JavaParser) ParseWithManagedVersions(content string, managed map[string]string) ([]DependencyInfo, error) {
 	var dependencies []DependencyInfo
 
-	// Enhanced regex patterns for XML parsing
-	// Handle multi-line dependency blocks with more flexible whitespace
-	dependencyBlock := regexp.MustCompile(`(?s)<dependency[^>]*>\s*<groupId>\s*([^<\s]+)\s*</groupId>\s*<artifactId>\s*([^<\s]+)\s*</artifactId>\s*(?:<version>\s*([^<\s]+)\s*</version>\s*)?(?:<scope>[^<]*</scope>\s*)?</dependency>`)
+	properties := map[string]string{}
+	local := &MavenBOM{Managed: map[string]string{}}
+	if pom, ok := decodeMavenPOM(content); ok {
+		properties = pom.properties()
+		local = pom.bom()
+	}
+	importsBOMs := len(local.Imports) > 0
 
-	matches := dependencyBlock.FindAllStringSubmatch(content, -1)
+	matches := mavenDependencyRegex.FindAllStringSubmatch(content, -1)
 
 	for _, match := range matches {
-		if len(match) >= 3 {
-			groupId := strings.TrimSpace(match[1])
-			artifactId := strings.TrimSpace(match[2])
-			version := ""
-			if len(match) >= 4 && match[3] != "" {
-				version = strings.TrimSpace(match[3])
-			}
-
-			depInfo := p.ParseDependency(fmt.Sprintf("%s:%s", groupId, artifactId), version)
-			depInfo.Owner = groupId
-			depInfo.Repo = artifactId
-			dependencies = append(dependencies, *depInfo)
+		groupId := strings.TrimSpace(match[1])
+		artifactId := strings.TrimSpace(match[2])
+		version := strings.TrimSpace(match[3])
+
+		switch key := groupId + ":" + artifactId; {
+		case version != "":
+			version = resolveMavenProperties(version, properties)
+		case local.Managed[key] != "":
+			version = local.Managed[key]
+		case managed[key] != "":
+			version = managed[key]
+		case importsBOMs:
+			version = UnresolvedVersion
 		}
+
+		scope := strings.ToLower(strings.TrimSpace(match[4]))
+		if scope == "" {
+			scope = "compile"
+		}
+
+		depInfo := p.ParseDependency(fmt.Sprintf("%s:%s", groupId, artifactId), version)
+		depInfo.Owner = groupId
+		depInfo.Repo = artifactId
+		depInfo.Scope = scope
+		depInfo.Dev = scope == "test"
+		dependencies = append(dependencies, *depInfo)
 	}
 
 	return dependencies, nil
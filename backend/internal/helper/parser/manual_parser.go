@@ -0,0 +1,90 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// manualRepoRegex matches a GitHub owner/repo reference such as "madler/zlib"
+var manualRepoRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9-]*/[A-Za-z0-9._-]+$`)
+
+// ManualParser handles hand-written dependency lists for projects without a standard manifest, such as
+// C/C++ libraries vendored as git submodules. Each line names one dependency as "owner/repo@version" or
+// "name version", optionally followed by the OSV ecosystem to look it up in:
+//
+//	# vendored libraries
+//	madler/zlib@v1.3.1
+//	openssl 3.0.7 Debian:12
+//	requests@2.31.0 PyPI
+//	nlohmann/json@9cca280a4d0ccf0c08f47a99aa71d1b0e52f8d03
+//
+// A dependency without an ecosystem can only be checked when its version is a commit SHA.
+type ManualParser struct{}
+
+// NewManualParser creates a new instance of ManualParser
+func NewManualParser() *ManualParser {
+	return &ManualParser{}
+}
+
+// GetRuntime returns the runtime type for manual dependency lists
+func (p *ManualParser) GetRuntime() RuntimeType {
+	return RuntimeManual
+}
+
+// Parse parses a manual dependency list. Unlike generated manifests the list is written by hand, so a
+// malformed line fails the parse with its line number instead of being skipped.
+func (p *ManualParser) Parse(content string) ([]DependencyInfo, error) {
+	var dependencies []DependencyInfo
+
+	scanner := newLineScanner(content)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if idx := strings.Index(line, "#"); idx != -1 {
+			line = strings.TrimSpace(line[:idx])
+		}
+		if line == "" {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		var name, version, ecosystem string
+		if at := strings.LastIndex(fields[0], "@"); at > 0 {
+			// name@version [ecosystem]; a leading @ belongs to an npm scope
+			name, version = fields[0][:at], fields[0][at+1:]
+			ecosystem = strings.Join(fields[1:], " ")
+		} else if len(fields) >= 2 {
+			// name version [ecosystem]
+			name, version = fields[0], fields[1]
+			ecosystem = strings.Join(fields[2:], " ")
+		}
+		if name == "" || version == "" {
+			return nil, fmt.Errorf("line %d: expected \"owner/repo@version\" or \"name version\", got %q", lineNumber, line)
+		}
+
+		depInfo := p.ParseDependency(name, version)
+		depInfo.Ecosystem = ecosystem
+		dependencies = append(dependencies, *depInfo)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return dependencies, nil
+}
+
+// ParseDependency parses a single manually listed dependency; an owner/repo name is taken as a GitHub repository
+func (p *ManualParser) ParseDependency(name, version string) *DependencyInfo {
+	dep := &DependencyInfo{
+		Name:    name,
+		Repo:    name,
+		Version: version,
+		Runtime: string(RuntimeManual),
+	}
+	if manualRepoRegex.MatchString(name) {
+		dep.Owner, dep.Repo, _ = strings.Cut(name, "/")
+		dep.GitHubURL = "https://github.com/" + name
+		dep.IsGitHubRepo = true
+	}
+	return dep
+}
@@ -0,0 +1,185 @@
+package parser
+
+import (
+	"encoding/xml"
+	"regexp"
+	"strings"
+)
+
+// MavenCoordinate identifies one version of a Maven artifact
+type MavenCoordinate struct {
+	GroupID    string
+	ArtifactID string
+	Version    string
+}
+
+// Key returns the groupId:artifactId the managed versions of a BOM are looked up by
+func (c MavenCoordinate) Key() string {
+	return c.GroupID + ":" + c.ArtifactID
+}
+
+func (c MavenCoordinate) String() string {
+	return c.Key() + ":" + c.Version
+}
+
+// MavenBOM is what a pom declares in its <dependencyManagement>: the versions it manages by groupId:artifactId
+// and the further BOMs it imports, whose versions apply after its own
+type MavenBOM struct {
+	Managed map[string]string
+	Imports []MavenCoordinate
+}
+
+// mavenPOM is the part of a pom.xml read to resolve managed versions
+type mavenPOM struct {
+	GroupID    string          `xml:"groupId"`
+	ArtifactID string          `xml:"artifactId"`
+	Version    string          `xml:"version"`
+	Parent     mavenParent     `xml:"parent"`
+	Properties mavenProperties `xml:"properties"`
+	Managed    []mavenManaged  `xml:"dependencyManagement>dependencies>dependency"`
+}
+
+type mavenParent struct {
+	GroupID string `xml:"groupId"`
+	Version string `xml:"version"`
+}
+
+type mavenManaged struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Version    string `xml:"version"`
+	Type       string `xml:"type"`
+	Scope      string `xml:"scope"`
+}
+
+// mavenProperties collects the free-form children of <properties>
+type mavenProperties map[string]string
+
+func (p *mavenProperties) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	*p = mavenProperties{}
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch element := token.(type) {
+		case xml.StartElement:
+			var value string
+			if err := d.DecodeElement(&value, &element); err != nil {
+				return err
+			}
+			(*p)[element.Name.Local] = strings.TrimSpace(value)
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// decodeMavenPOM reads the properties and dependency management of a pom. It returns false for content that is
+// not well-formed XML, which is then parsed without them.
+func decodeMavenPOM(content string) (*mavenPOM, bool) {
+	var pom mavenPOM
+	if err := xml.Unmarshal([]byte(content), &pom); err != nil {
+		return nil, false
+	}
+	return &pom, true
+}
+
+// properties returns the pom's <properties> together with the project.* values Maven defines
+func (pom *mavenPOM) properties() map[string]string {
+	properties := make(map[string]string, len(pom.Properties)+4)
+	for name, value := range pom.Properties {
+		properties[name] = value
+	}
+	groupID, version := strings.TrimSpace(pom.GroupID), strings.TrimSpace(pom.Version)
+	if groupID == "" {
+		groupID = strings.TrimSpace(pom.Parent.GroupID)
+	}
+	if version == "" {
+		version = strings.TrimSpace(pom.Parent.Version)
+	}
+	properties["project.groupId"] = groupID
+	properties["project.version"] = version
+	properties["project.parent.version"] = strings.TrimSpace(pom.Parent.Version)
+	return properties
+}
+
+// bom splits the pom's dependency management into the versions it manages and the BOMs it imports
+func (pom *mavenPOM) bom() *MavenBOM {
+	properties := pom.properties()
+	bom := &MavenBOM{Managed: map[string]string{}}
+	for _, managed := range pom.Managed {
+		coordinate := MavenCoordinate{
+			GroupID:    resolveMavenProperties(strings.TrimSpace(managed.GroupID), properties),
+			ArtifactID: resolveMavenProperties(strings.TrimSpace(managed.ArtifactID), properties),
+			Version:    resolveMavenProperties(strings.TrimSpace(managed.Version), properties),
+		}
+		if !isResolvedMavenValue(coordinate.GroupID) || !isResolvedMavenValue(coordinate.ArtifactID) || coordinate.Version == UnresolvedVersion {
+			continue
+		}
+		if strings.EqualFold(strings.TrimSpace(managed.Scope), "import") && strings.EqualFold(strings.TrimSpace(managed.Type), "pom") {
+			if coordinate.Version != "" {
+				bom.Imports = append(bom.Imports, coordinate)
+			}
+			continue
+		}
+		// The first declaration of an artifact wins, as in Maven
+		if _, ok := bom.Managed[coordinate.Key()]; !ok && coordinate.Version != "" {
+			bom.Managed[coordinate.Key()] = coordinate.Version
+		}
+	}
+	return bom
+}
+
+// ParseMavenBOM reads the managed versions and imports of a BOM, or of any pom with a <dependencyManagement>
+// section. Property references are resolved from the pom's own <properties>; entries referring to properties
+// it does not define are left out.
+func ParseMavenBOM(content string) (*MavenBOM, bool) {
+	pom, ok := decodeMavenPOM(content)
+	if !ok {
+		return nil, false
+	}
+	return pom.bom(), true
+}
+
+// MavenBOMImports returns the BOMs a pom imports into its dependency management (scope import, type pom)
+func MavenBOMImports(content string) []MavenCoordinate {
+	bom, ok := ParseMavenBOM(content)
+	if !ok {
+		return nil
+	}
+	return bom.Imports
+}
+
+// isResolvedMavenValue reports whether a coordinate part is set and free of undefined property references
+func isResolvedMavenValue(value string) bool {
+	return value != "" && value != UnresolvedVersion
+}
+
+// mavenPropertyReferenceRegex matches a ${name} reference in a pom
+var mavenPropertyReferenceRegex = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// resolveMavenProperties substitutes the ${name} references in value, repeatedly for properties defined in
+// terms of others. A value with any undefined reference is UnresolvedVersion.
+func resolveMavenProperties(value string, properties map[string]string) string {
+	for range 5 {
+		if !strings.Contains(value, "${") {
+			return value
+		}
+		unresolved := false
+		value = mavenPropertyReferenceRegex.ReplaceAllStringFunc(value, func(reference string) string {
+			if resolved, ok := properties[mavenPropertyReferenceRegex.FindStringSubmatch(reference)[1]]; ok && resolved != "" {
+				return resolved
+			}
+			unresolved = true
+			return reference
+		})
+		if unresolved {
+			return UnresolvedVersion
+		}
+	}
+	if strings.Contains(value, "${") {
+		return UnresolvedVersion
+	}
+	return value
+}
@@ -72,6 +72,8 @@ func (p *NodeParser) Parse(content string) ([]DependencyInfo, error) {
 	// Parse dev dependencies
 	for name, version := range packageJSON.DevDependencies {
 		if depInfo := p.ParseDependency(name, version); depInfo != nil {
+			depInfo.Scope = "dev"
+			depInfo.Dev = true
 			dependencies = append(dependencies, *depInfo)
 		}
 	}
@@ -43,6 +43,8 @@ func (p *PHPParser) Parse(content string) ([]DependencyInfo, error) {
 	// Parse dev dependencies
 	for name, version := range composerJSON.RequireDev {
 		depInfo := p.ParseDependency(name, version)
+		depInfo.Scope = "dev"
+		depInfo.Dev = true
 		dependencies = append(dependencies, *depInfo)
 	}
 
@@ -1,11 +1,17 @@
 package parser
 
 import (
-	"bufio"
 	"regexp"
 	"strings"
 )
 
+var (
+	// Captures full version specs like >=4.2.0,<5.0
+	pythonRequirementRegex = regexp.MustCompile(`^([a-zA-Z0-9\-_.]+)((?:[><=~!]+[^,;\s]+(?:\s*,\s*[><=~!]+[^,;\s]+)*)).*$`)
+	pythonOperatorRegex    = regexp.MustCompile(`^[><=~!]+\s*`)
+	pythonExactPinRegex    = regexp.MustCompile(`^===?\s*[^,*]+$`)
+)
+
 // PythonParser handles parsing of Python dependency files
 type PythonParser struct{}
 
@@ -23,7 +29,7 @@ func (p *PythonParser) GetRuntime() RuntimeType {
 func (p *PythonParser) Parse(content string) ([]DependencyInfo, error) {
 	var dependencies []DependencyInfo
 
-	scanner := bufio.NewScanner(strings.NewReader(content))
+	scanner := newLineScanner(content)
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
 		if line == "" || strings.HasPrefix(line, "#") {
@@ -41,16 +47,14 @@ func (p *PythonParser) Parse(content string) ([]DependencyInfo, error) {
 		}
 
 		// Handle various requirement formats with complex version specifications
-		// Enhanced regex to capture full version specs like >=4.2.0,<5.0
-		versionRegex := regexp.MustCompile(`^([a-zA-Z0-9\-_.]+)((?:[><=~!]+[^,;\s]+(?:\s*,\s*[><=~!]+[^,;\s]+)*)).*$`)
-		matches := versionRegex.FindStringSubmatch(line)
+		matches := pythonRequirementRegex.FindStringSubmatch(line)
 
 		if len(matches) >= 3 {
 			packageName := matches[1]
 			versionSpec := matches[2]
 
 			// Clean version spec by removing operators and keeping only the version number
-			cleanVersion := regexp.MustCompile(`^[><=~!]+\s*`).ReplaceAllString(versionSpec, "")
+			cleanVersion := pythonOperatorRegex.ReplaceAllString(versionSpec, "")
 			// For complex specs like ">=4.2.0,<5.0", take the first version
 			if idx := strings.Index(cleanVersion, ","); idx != -1 {
 				cleanVersion = strings.TrimSpace(cleanVersion[:idx])
@@ -58,6 +62,10 @@ func (p *PythonParser) Parse(content string) ([]DependencyInfo, error) {
 
 			depInfo := p.ParseDependency(packageName, cleanVersion)
 			if depInfo != nil {
+				// Anything but an exact "==" pin allows a range of versions
+				if !pythonExactPinRegex.MatchString(versionSpec) {
+					depInfo.Constraint = strings.TrimSpace(versionSpec)
+				}
 				dependencies = append(dependencies, *depInfo)
 			}
 		} else {
@@ -70,6 +78,9 @@ func (p *PythonParser) Parse(content string) ([]DependencyInfo, error) {
 			}
 		}
 	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
 
 	return dependencies, nil
 }
@@ -0,0 +1,17 @@
+package parser
+
+import (
+	"bufio"
+	"strings"
+)
+
+// MaxContentSize is the largest manifest, in bytes, the parsers accept
+const MaxContentSize = 5 << 20
+
+// newLineScanner returns a line scanner over content whose buffer can hold any line of an
+// accepted manifest; bufio's 64KB default would stop silently on minified or generated files.
+func newLineScanner(content string) *bufio.Scanner {
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 4096), MaxContentSize+1)
+	return scanner
+}
@@ -9,6 +9,24 @@ type DependencyInfo struct {
 	Runtime      string `json:"runtime"`
 	GitHubURL    string `json:"github_url,omitempty"`
 	IsGitHubRepo bool   `json:"is_github_repo"`
+
+	// Scope is the manifest's own scope or configuration (Maven <scope>, Gradle configuration, "dev" for
+	// npm devDependencies); empty when the manifest does not declare one
+	Scope string `json:"scope,omitempty"`
+	// Dev marks dependencies only needed for development or tests, which production gating can exclude
+	Dev bool `json:"dev,omitempty"`
+	// Transitive marks dependencies pulled in by other dependencies rather than required by the application
+	// itself, such as the "// indirect" requirements of a go.mod
+	Transitive bool `json:"transitive,omitempty"`
+	// Ecosystem overrides the OSV ecosystem derived from Runtime, for dependencies from another
+	// ecosystem than their application (e.g. an npm tool in a Java application)
+	Ecosystem string `json:"ecosystem,omitempty"`
+	// Commit is the full git commit SHA the version resolved to, when known. OSV is queried by commit
+	// instead of version for dependencies pinned to a commit rather than a release.
+	Commit string `json:"commit,omitempty"`
+	// Constraint is the version range a manifest declared, e.g. ">=4.2.0,<5.0", when it allows more than one
+	// version; Version then holds its lower bound. Empty for exact pins and lockfiles.
+	Constraint string `json:"constraint,omitempty"`
 }
 
 // GitHubRepoInfo contains verified GitHub repository information
@@ -42,6 +60,7 @@ const (
 	RuntimeRuby    RuntimeType = "ruby"
 	RuntimePHP     RuntimeType = "php"
 	RuntimeRust    RuntimeType = "rust"
+	RuntimeManual  RuntimeType = "manual" // hand-written dependency lists with a per-line ecosystem
 	RuntimeUnknown RuntimeType = "unknown"
 )
 
@@ -0,0 +1,70 @@
+package helper
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// DefaultTrustedRepositoryHosts are the code hosts repository URLs may point to unless configured otherwise
+var DefaultTrustedRepositoryHosts = []string{"github.com", "gitlab.com", "bitbucket.org"}
+
+// ErrUntrustedRepositoryURL is returned for repository URLs that must not be stored or fetched server-side
+var ErrUntrustedRepositoryURL = errors.New("untrusted repository URL")
+
+func normalizeHosts(hosts []string) map[string]bool {
+	normalized := make(map[string]bool, len(hosts))
+	for _, host := range hosts {
+		if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+			normalized[strings.TrimPrefix(host, "www.")] = true
+		}
+	}
+	return normalized
+}
+
+// ValidateRepositoryURL checks a user supplied repository URL before it is stored or requested: it must be
+// an http(s) URL without credentials on one of trustedHosts (a "www." prefix is allowed), and never a loopback,
+// private or link-local address such as a cloud metadata endpoint, even when that host is listed. No trusted
+// hosts means DefaultTrustedRepositoryHosts.
+func ValidateRepositoryURL(raw string, trustedHosts []string) error {
+	parsed, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUntrustedRepositoryURL, err)
+	}
+	if parsed.Scheme != "https" && parsed.Scheme != "http" {
+		return fmt.Errorf("%w: scheme must be http or https", ErrUntrustedRepositoryURL)
+	}
+	if parsed.User != nil {
+		return fmt.Errorf("%w: credentials are not allowed", ErrUntrustedRepositoryURL)
+	}
+	if port := parsed.Port(); port != "" && port != "80" && port != "443" {
+		return fmt.Errorf("%w: port %s is not allowed", ErrUntrustedRepositoryURL, port)
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(parsed.Hostname()), ".")
+	if host == "" {
+		return fmt.Errorf("%w: missing host", ErrUntrustedRepositoryURL)
+	}
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return fmt.Errorf("%w: %s is a loopback host", ErrUntrustedRepositoryURL, host)
+	}
+	if ip := net.ParseIP(host); ip != nil && isInternalIP(ip) {
+		return fmt.Errorf("%w: %s is an internal address", ErrUntrustedRepositoryURL, host)
+	}
+
+	trusted := normalizeHosts(trustedHosts)
+	if len(trusted) == 0 {
+		trusted = normalizeHosts(DefaultTrustedRepositoryHosts)
+	}
+	if !trusted[strings.TrimPrefix(host, "www.")] {
+		return fmt.Errorf("%w: host %s is not trusted", ErrUntrustedRepositoryURL, host)
+	}
+	return nil
+}
+
+func isInternalIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified()
+}
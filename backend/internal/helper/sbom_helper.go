@@ -1,10 +1,13 @@
 package helper
 
 import (
+	"context"
 	"elang-backend/internal/model"
 	"encoding/json"
 	"fmt"
-
+	"net/url"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -144,6 +147,25 @@ type EnhancedSBOMData struct {
 	HighCount     int
 	MediumCount   int
 	LowCount      int
+	// Tool is credited in the metadata; the zero value means NewSBOMTool("", "")
+	Tool CycloneDXTool
+
+	// Deterministic derives the serial number from the SBOM content instead of a random UUID,
+	// so identical input (including ScanTimestamp) produces byte-identical output
+	Deterministic bool
+}
+
+type deterministicSBOMKey struct{}
+
+// WithDeterministicSBOM marks SBOMs generated for this request as deterministic
+func WithDeterministicSBOM(ctx context.Context, deterministic bool) context.Context {
+	return context.WithValue(ctx, deterministicSBOMKey{}, deterministic)
+}
+
+// DeterministicSBOM reports whether SBOMs generated for this request should be deterministic
+func DeterministicSBOM(ctx context.Context) bool {
+	deterministic, _ := ctx.Value(deterministicSBOMKey{}).(bool)
+	return deterministic
 }
 
 // DependencyWithVulnerabilities contains dependency info with its vulnerabilities
@@ -157,6 +179,8 @@ type DependencyWithVulnerabilities struct {
 	IsGitHub        bool
 	Vulnerabilities []VulnerabilityInfo
 	RiskScore       float64
+	// RemediationImpact is the size of the upgrade fixing the vulnerabilities, see RemediationImpact
+	RemediationImpact string
 }
 
 // GenerateEnhancedCycloneDXSBOM generates a comprehensive CycloneDX SBOM with vulnerability data
@@ -166,18 +190,18 @@ func GenerateEnhancedCycloneDXSBOM(data EnhancedSBOMData) ([]byte, error) {
 		timestamp = time.Now().UTC()
 	}
 
+	tool := data.Tool
+	if tool == (CycloneDXTool{}) {
+		tool = NewSBOMTool("", "")
+	}
+
 	bom := CycloneDXSBOM{
-		BomFormat:    "CycloneDX",
-		SpecVersion:  "1.5",
-		Version:      1,
-		SerialNumber: "urn:uuid:" + uuid.New().String(),
+		BomFormat:   "CycloneDX",
+		SpecVersion: "1.5",
+		Version:     1,
 		Metadata: CycloneDXMetadata{
-			Timestamp: timestamp.Format(time.RFC3339),
-			Tools: []CycloneDXTool{{
-				Vendor:  "Silent Patch Detector",
-				Name:    "dependency-vulnerability-scanner",
-				Version: "2.0.0",
-			}},
+			Timestamp: timestamp.UTC().Format(time.RFC3339),
+			Tools:     []CycloneDXTool{tool},
 			Component: CycloneDXComponentMeta{
 				Type:    "application",
 				Name:    data.AppName,
@@ -186,7 +210,7 @@ func GenerateEnhancedCycloneDXSBOM(data EnhancedSBOMData) ([]byte, error) {
 					{Name: "app:id", Value: data.AppID},
 					{Name: "app:runtime", Value: data.Runtime},
 					{Name: "app:framework", Value: data.Framework},
-					{Name: "scan:timestamp", Value: timestamp.Format(time.RFC3339)},
+					{Name: "scan:timestamp", Value: timestamp.UTC().Format(time.RFC3339)},
 					{Name: "scan:total_findings", Value: fmt.Sprintf("%d", data.TotalFindings)},
 					{Name: "scan:critical_count", Value: fmt.Sprintf("%d", data.CriticalCount)},
 					{Name: "scan:high_count", Value: fmt.Sprintf("%d", data.HighCount)},
@@ -209,7 +233,7 @@ func GenerateEnhancedCycloneDXSBOM(data EnhancedSBOMData) ([]byte, error) {
 		componentRefs[bomRef] = true
 
 		// Determine package URL (purl) based on runtime
-		purl := generatePurl(dep.Runtime, dep.Owner, dep.Repo, dep.Name, dep.Version)
+		purl := GeneratePurl(dep.Runtime, dep.Owner, dep.Repo, dep.Name, dep.Version)
 
 		// Build external references
 		var externalRefs []CycloneDXExternalRef
@@ -229,6 +253,9 @@ func GenerateEnhancedCycloneDXSBOM(data EnhancedSBOMData) ([]byte, error) {
 			{Name: "dependency:risk_score", Value: fmt.Sprintf("%.2f", dep.RiskScore)},
 			{Name: "dependency:vulnerability_count", Value: fmt.Sprintf("%d", len(dep.Vulnerabilities))},
 		}
+		if dep.RemediationImpact != "" {
+			properties = append(properties, CycloneDXProperty{Name: "dependency:remediation_impact", Value: dep.RemediationImpact})
+		}
 
 		component := CycloneDXComponent{
 			BomRef:       bomRef,
@@ -256,7 +283,7 @@ func GenerateEnhancedCycloneDXSBOM(data EnhancedSBOMData) ([]byte, error) {
 						URL:  "https://nvd.nist.gov/",
 					},
 					Score:    vuln.Score,
-					Severity: string(vuln.Severity),
+					Severity: vuln.Severity.String(),
 					Method:   "CVSSv3",
 					Vector:   vuln.VectorString,
 				})
@@ -270,16 +297,33 @@ func GenerateEnhancedCycloneDXSBOM(data EnhancedSBOMData) ([]byte, error) {
 				})
 			}
 
-			// Build affected versions
+			// Build affected versions: the affected ranges with their fixed versions when known, otherwise
+			// the versions in which the vulnerability was introduced
 			var affects []CycloneDXAffect
-			if len(vuln.AffectedVersions) > 0 {
-				var versionRanges []CycloneDXVersionRange
+			var versionRanges []CycloneDXVersionRange
+			if len(vuln.AffectedRanges) > 0 {
+				scheme := versScheme(purl)
+				for _, r := range vuln.AffectedRanges {
+					versionRanges = append(versionRanges, CycloneDXVersionRange{
+						Range:  r.Vers(scheme),
+						Status: "affected",
+					})
+				}
+				for _, fixed := range vuln.PatchedVersions {
+					versionRanges = append(versionRanges, CycloneDXVersionRange{
+						Version: fixed,
+						Status:  "unaffected",
+					})
+				}
+			} else {
 				for _, affectedVer := range vuln.AffectedVersions {
 					versionRanges = append(versionRanges, CycloneDXVersionRange{
 						Version: affectedVer,
 						Status:  "affected",
 					})
 				}
+			}
+			if len(versionRanges) > 0 {
 				affects = append(affects, CycloneDXAffect{
 					Ref:      bomRef,
 					Versions: versionRanges,
@@ -288,11 +332,11 @@ func GenerateEnhancedCycloneDXSBOM(data EnhancedSBOMData) ([]byte, error) {
 
 			published := ""
 			if !vuln.PublishedDate.IsZero() {
-				published = vuln.PublishedDate.Format(time.RFC3339)
+				published = vuln.PublishedDate.UTC().Format(time.RFC3339)
 			}
 			updated := ""
 			if !vuln.ModifiedDate.IsZero() {
-				updated = vuln.ModifiedDate.Format(time.RFC3339)
+				updated = vuln.ModifiedDate.UTC().Format(time.RFC3339)
 			}
 
 			cycloneDXVuln := CycloneDXVulnerability{
@@ -305,6 +349,7 @@ func GenerateEnhancedCycloneDXSBOM(data EnhancedSBOMData) ([]byte, error) {
 				Description: vuln.Summary,
 				Detail:      vuln.Description,
 				Ratings:     ratings,
+				Cwes:        cweNumbers(vuln.Cwes),
 				Advisories:  advisories,
 				Published:   published,
 				Updated:     updated,
@@ -321,14 +366,46 @@ func GenerateEnhancedCycloneDXSBOM(data EnhancedSBOMData) ([]byte, error) {
 	for ref := range componentRefs {
 		dependsOn = append(dependsOn, ref)
 	}
+	sort.Strings(dependsOn)
 	bom.Dependencies = append(bom.Dependencies, CycloneDXDependencyNode{
 		Ref:       appRef,
 		DependsOn: dependsOn,
 	})
 
+	// Order components and vulnerabilities by bom-ref so output does not depend on input order
+	sort.SliceStable(bom.Components, func(i, j int) bool {
+		return bom.Components[i].BomRef < bom.Components[j].BomRef
+	})
+	sort.SliceStable(bom.Vulnerabilities, func(i, j int) bool {
+		return bom.Vulnerabilities[i].BomRef < bom.Vulnerabilities[j].BomRef
+	})
+
+	if data.Deterministic {
+		// Name-based UUID over the content without a serial; covers app ID, scan timestamp and findings
+		content, err := json.Marshal(bom)
+		if err != nil {
+			return nil, err
+		}
+		bom.SerialNumber = "urn:uuid:" + uuid.NewSHA1(uuid.NameSpaceURL, content).String()
+	} else {
+		bom.SerialNumber = "urn:uuid:" + uuid.New().String()
+	}
+
 	return json.MarshalIndent(bom, "", "  ")
 }
 
+// cweNumbers converts CWE identifiers such as "CWE-79" to the numeric form CycloneDX expects
+func cweNumbers(cwes []string) []int {
+	var numbers []int
+	for _, cwe := range cwes {
+		id := strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(cwe)), "CWE-")
+		if n, err := strconv.Atoi(id); err == nil {
+			numbers = append(numbers, n)
+		}
+	}
+	return numbers
+}
+
 // GenerateCycloneDXSBOM generates a CycloneDX SBOM from scan result (legacy support)
 func GenerateCycloneDXSBOM(scanResult model.ScanApplicationResult) ([]byte, error) {
 	// Convert ScanApplicationResult to EnhancedSBOMData
@@ -356,9 +433,10 @@ func GenerateCycloneDXSBOM(scanResult model.ScanApplicationResult) ([]byte, erro
 		}
 
 		dep := DependencyWithVulnerabilities{
-			Name:    name,
-			Owner:   group,
-			Version: finding.Version,
+			Name:              name,
+			Owner:             group,
+			Version:           finding.Version,
+			RemediationImpact: finding.RemediationImpact,
 		}
 
 		// Create vulnerabilities from finding
@@ -367,7 +445,7 @@ func GenerateCycloneDXSBOM(scanResult model.ScanApplicationResult) ([]byte, erro
 				ID:       vulnID,
 				CVE:      vulnID,
 				Summary:  finding.Recommendation,
-				Severity: CVESeverity(strings.ToUpper(finding.Severity)),
+				Severity: ParseSeverity(finding.Severity),
 			}
 			dep.Vulnerabilities = append(dep.Vulnerabilities, vuln)
 		}
@@ -388,49 +466,104 @@ func generateVulnBomRef(vulnID, componentRef string) string {
 	return fmt.Sprintf("vuln:%s:%s", vulnID, componentRef)
 }
 
-// generatePurl generates a package URL based on runtime/ecosystem
-func generatePurl(runtime, owner, repo, name, version string) string {
-	runtimeLower := strings.ToLower(runtime)
+// versScheme returns the version range scheme matching a purl's type, "generic" when it has none
+func versScheme(purl string) string {
+	if purlType, _, ok := strings.Cut(strings.TrimPrefix(purl, "pkg:"), "/"); ok && strings.HasPrefix(purl, "pkg:") && purlType != "" {
+		return purlType
+	}
+	return "generic"
+}
 
-	switch {
-	case strings.Contains(runtimeLower, "java") || strings.Contains(runtimeLower, "maven"):
-		if owner != "" {
-			return fmt.Sprintf("pkg:maven/%s/%s@%s", owner, name, version)
+// GeneratePurl generates a package URL (https://github.com/package-url/purl-spec) for a dependency
+// based on its runtime/ecosystem. Namespace and name segments are percent-encoded per the spec,
+// so scoped npm packages become pkg:npm/%40scope/name and Go modules keep their full module path.
+func GeneratePurl(runtime, owner, repo, name, version string) string {
+	switch strings.ToLower(strings.TrimSpace(runtime)) {
+	case "java", "maven", "gradle":
+		// Maven coordinates are groupId:artifactId
+		if group, artifact, ok := strings.Cut(name, ":"); ok {
+			return buildPurl("maven", group, artifact, version)
 		}
-		return fmt.Sprintf("pkg:maven/%s@%s", name, version)
+		return buildPurl("maven", owner, name, version)
 
-	case strings.Contains(runtimeLower, "node") || strings.Contains(runtimeLower, "npm"):
-		if owner != "" {
-			return fmt.Sprintf("pkg:npm/%s/%s@%s", owner, name, version)
+	case "node", "node.js", "nodejs", "npm":
+		// Only the npm scope is a namespace; the GitHub owner is not part of the package identity
+		if strings.HasPrefix(name, "@") {
+			if scope, pkg, ok := strings.Cut(name, "/"); ok {
+				return buildPurl("npm", scope, pkg, version)
+			}
 		}
-		return fmt.Sprintf("pkg:npm/%s@%s", name, version)
+		return buildPurl("npm", "", name, version)
 
-	case strings.Contains(runtimeLower, "python") || strings.Contains(runtimeLower, "pip"):
-		return fmt.Sprintf("pkg:pypi/%s@%s", name, version)
+	case "python", "pip", "pypi":
+		// PyPI names are case-insensitive and treat underscores as dashes
+		return buildPurl("pypi", "", strings.ReplaceAll(strings.ToLower(name), "_", "-"), version)
 
-	case strings.Contains(runtimeLower, "go") || strings.Contains(runtimeLower, "golang"):
-		if repo != "" && owner != "" {
-			return fmt.Sprintf("pkg:golang/%s/%s@%s", owner, repo, version)
+	case "go", "golang":
+		// The canonical Go purl uses the full module path (e.g. github.com/foo/bar/v2)
+		modulePath := name
+		if !strings.Contains(modulePath, "/") && owner != "" && repo != "" {
+			modulePath = fmt.Sprintf("github.com/%s/%s", owner, repo)
 		}
-		return fmt.Sprintf("pkg:golang/%s@%s", name, version)
+		if idx := strings.LastIndex(modulePath, "/"); idx > 0 {
+			return buildPurl("golang", modulePath[:idx], modulePath[idx+1:], version)
+		}
+		return buildPurl("golang", "", modulePath, version)
 
-	case strings.Contains(runtimeLower, "ruby") || strings.Contains(runtimeLower, "gem"):
-		return fmt.Sprintf("pkg:gem/%s@%s", name, version)
+	case "ruby", "gem":
+		return buildPurl("gem", "", name, version)
 
-	case strings.Contains(runtimeLower, "rust") || strings.Contains(runtimeLower, "cargo"):
-		return fmt.Sprintf("pkg:cargo/%s@%s", name, version)
+	case "rust", "cargo":
+		return buildPurl("cargo", "", name, version)
 
-	case strings.Contains(runtimeLower, "php") || strings.Contains(runtimeLower, "composer"):
-		if owner != "" {
-			return fmt.Sprintf("pkg:composer/%s/%s@%s", owner, name, version)
+	case "php", "composer":
+		// Composer packages are vendor/package
+		if vendor, pkg, ok := strings.Cut(name, "/"); ok {
+			return buildPurl("composer", strings.ToLower(vendor), strings.ToLower(pkg), version)
 		}
-		return fmt.Sprintf("pkg:composer/%s@%s", name, version)
+		return buildPurl("composer", "", strings.ToLower(name), version)
 
-	case strings.Contains(runtimeLower, "nuget") || strings.Contains(runtimeLower, ".net"):
-		return fmt.Sprintf("pkg:nuget/%s@%s", name, version)
+	case "dotnet", ".net", "nuget":
+		return buildPurl("nuget", "", name, version)
 
 	default:
-		// Generic package URL
-		return fmt.Sprintf("pkg:generic/%s@%s", name, version)
+		// Unknown ecosystem: fall back to the GitHub purl type when the repository is known
+		if owner != "" && repo != "" {
+			return buildPurl("github", strings.ToLower(owner), strings.ToLower(repo), version)
+		}
+		return buildPurl("generic", "", name, version)
 	}
 }
+
+// buildPurl assembles a purl from its components, percent-encoding each namespace segment,
+// the name and the version. None of the supported ecosystems need qualifiers for their
+// default package type, so none are emitted.
+func buildPurl(purlType, namespace, name, version string) string {
+	var b strings.Builder
+	b.WriteString("pkg:")
+	b.WriteString(purlType)
+	b.WriteString("/")
+
+	if namespace != "" {
+		for _, segment := range strings.Split(strings.Trim(namespace, "/"), "/") {
+			if segment == "" {
+				continue
+			}
+			b.WriteString(purlEscape(segment))
+			b.WriteString("/")
+		}
+	}
+	b.WriteString(purlEscape(name))
+
+	if version != "" {
+		b.WriteString("@")
+		b.WriteString(purlEscape(version))
+	}
+
+	return b.String()
+}
+
+// purlEscape percent-encodes a purl component, keeping the characters the spec allows unencoded
+func purlEscape(s string) string {
+	return strings.ReplaceAll(url.PathEscape(s), "@", "%40")
+}
@@ -0,0 +1,58 @@
+package helper
+
+import "strings"
+
+// versionOperators are the comparison operators that may precede a version in manifests
+// (npm, PEP 440, RubyGems, Cargo), longest first so "~>" and ">=" are not cut in half
+var versionOperators = []string{"===", "==", "~=", "~>", ">=", "<=", "!=", "^", "~", ">", "<", "="}
+
+// NormalizeSemVer reduces a version or version constraint to the single version OSV should be queried with.
+// Comparison operators and a leading "v" are removed, ranges resolve to their lower bound
+// ("1.2.0 - 2.0.0", ">=1.2.0 <2.0.0", ">=1.2.0, <2.0.0") and alternatives to their first entry ("1.x || 2.x").
+// Pre-release and build metadata are kept intact, so "v1.0.0-rc.1+build.5" becomes "1.0.0-rc.1+build.5".
+func NormalizeSemVer(version string) string {
+	normalized := strings.TrimSpace(version)
+
+	// Alternatives: the first one wins
+	if first, _, found := strings.Cut(normalized, "||"); found {
+		normalized = strings.TrimSpace(first)
+	}
+	// Hyphen ranges need surrounding spaces; "1.0.0-rc.1" is a pre-release, not a range
+	if lower, _, found := strings.Cut(normalized, " - "); found {
+		normalized = strings.TrimSpace(lower)
+	}
+	// Comma separated constraint lists start with the lower bound
+	if first, _, found := strings.Cut(normalized, ","); found {
+		normalized = strings.TrimSpace(first)
+	}
+
+	normalized = trimVersionOperator(normalized)
+
+	// Anything after the version itself is another constraint or a comment
+	fields := strings.Fields(normalized)
+	if len(fields) == 0 {
+		return ""
+	}
+	return trimVersionPrefix(fields[0], "v")
+}
+
+// trimVersionOperator removes one leading comparison operator and the whitespace after it
+func trimVersionOperator(version string) string {
+	for _, op := range versionOperators {
+		if strings.HasPrefix(version, op) {
+			return strings.TrimSpace(version[len(op):])
+		}
+	}
+	return version
+}
+
+// trimVersionPrefix removes a case-insensitive letter prefix such as "v" only when a digit follows,
+// so "v1.2.0" becomes "1.2.0" while names like "rc1" or "vendor" are left alone
+func trimVersionPrefix(version, prefix string) string {
+	if len(version) > len(prefix) &&
+		strings.EqualFold(version[:len(prefix)], prefix) &&
+		version[len(prefix)] >= '0' && version[len(prefix)] <= '9' {
+		return version[len(prefix):]
+	}
+	return version
+}
@@ -0,0 +1,183 @@
+package helper
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// CVESeverity is the canonical, lower case severity used throughout the scan pipeline: vulnerabilities,
+// findings, summaries, policies and SBOM ratings. Values from advisory databases or clients are converted
+// with ParseSeverity where they enter the system.
+type CVESeverity string
+
+const (
+	SeverityCritical CVESeverity = "critical"
+	SeverityHigh     CVESeverity = "high"
+	SeverityMedium   CVESeverity = "medium"
+	SeverityLow      CVESeverity = "low"
+	SeverityInfo     CVESeverity = "info"
+	SeverityNone     CVESeverity = "none" // a finding without vulnerabilities
+	SeverityUnknown  CVESeverity = "unknown"
+)
+
+// ParseSeverity converts a severity in any casing, including GitHub's "MODERATE", to its canonical form.
+// Unrecognized values become SeverityUnknown.
+func ParseSeverity(value string) CVESeverity {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "critical":
+		return SeverityCritical
+	case "high":
+		return SeverityHigh
+	case "medium", "moderate":
+		return SeverityMedium
+	case "low":
+		return SeverityLow
+	case "info", "informational":
+		return SeverityInfo
+	case "none":
+		return SeverityNone
+	default:
+		return SeverityUnknown
+	}
+}
+
+// UnmarshalJSON parses the severity so payloads stored before severities were canonical decode consistently
+func (s *CVESeverity) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*s = ParseSeverity(value)
+	return nil
+}
+
+func (s CVESeverity) String() string {
+	return string(s)
+}
+
+// Rank orders the severities that count towards policies, from 1 (low) to 4 (critical); all others are 0
+func (s CVESeverity) Rank() int {
+	switch s {
+	case SeverityCritical:
+		return 4
+	case SeverityHigh:
+		return 3
+	case SeverityMedium:
+		return 2
+	case SeverityLow:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// SeverityStyle is how a severity is presented to people: its display label and color
+type SeverityStyle struct {
+	Severity CVESeverity `json:"severity"`
+	Label    string      `json:"label"`
+	Color    string      `json:"color"`
+	Rank     int         `json:"rank"`
+}
+
+var severityOrder = []CVESeverity{SeverityCritical, SeverityHigh, SeverityMedium, SeverityLow, SeverityInfo, SeverityNone, SeverityUnknown}
+
+var defaultSeverityStyles = map[CVESeverity]SeverityStyle{
+	SeverityCritical: {Label: "Critical", Color: "#b71c1c"},
+	SeverityHigh:     {Label: "High", Color: "#e65100"},
+	SeverityMedium:   {Label: "Medium", Color: "#f9a825"},
+	SeverityLow:      {Label: "Low", Color: "#1565c0"},
+	SeverityInfo:     {Label: "Info", Color: "#607d8b"},
+	SeverityNone:     {Label: "None", Color: "#2e7d32"},
+	SeverityUnknown:  {Label: "Unknown", Color: "#9e9e9e"},
+}
+
+// SeverityPalette is the presentation of every severity. The zero value holds the default labels and colors.
+type SeverityPalette struct {
+	styles map[CVESeverity]SeverityStyle
+}
+
+// NewSeverityPalette overrides the default display labels and colors of severities. Both lists hold
+// "severity=value" entries, e.g. "medium=Moderate" or "critical=#ff0000"; severities that are not
+// listed keep their defaults.
+func NewSeverityPalette(labels, colors []string) (SeverityPalette, error) {
+	styles := make(map[CVESeverity]SeverityStyle, len(defaultSeverityStyles))
+	for severity, style := range defaultSeverityStyles {
+		styles[severity] = style
+	}
+	apply := func(entries []string, set func(*SeverityStyle, string)) error {
+		for _, entry := range entries {
+			name, value, ok := strings.Cut(entry, "=")
+			severity := ParseSeverity(name)
+			if !ok || strings.TrimSpace(value) == "" || (severity == SeverityUnknown && !strings.EqualFold(strings.TrimSpace(name), "unknown")) {
+				return fmt.Errorf("invalid severity style %q, expected severity=value", entry)
+			}
+			style := styles[severity]
+			set(&style, strings.TrimSpace(value))
+			styles[severity] = style
+		}
+		return nil
+	}
+	if err := apply(labels, func(style *SeverityStyle, value string) { style.Label = value }); err != nil {
+		return SeverityPalette{}, err
+	}
+	if err := apply(colors, func(style *SeverityStyle, value string) { style.Color = value }); err != nil {
+		return SeverityPalette{}, err
+	}
+	return SeverityPalette{styles: styles}, nil
+}
+
+// Style returns the presentation of the severity
+func (p SeverityPalette) Style(s CVESeverity) SeverityStyle {
+	styles := p.styles
+	if styles == nil {
+		styles = defaultSeverityStyles
+	}
+	style, ok := styles[s]
+	if !ok {
+		style = styles[SeverityUnknown]
+	}
+	style.Severity, style.Rank = s, s.Rank()
+	return style
+}
+
+// Styles lists the presentation of every severity from most to least severe
+func (p SeverityPalette) Styles() []SeverityStyle {
+	styles := make([]SeverityStyle, 0, len(severityOrder))
+	for _, severity := range severityOrder {
+		styles = append(styles, p.Style(severity))
+	}
+	return styles
+}
+
+// Style returns the default presentation of the severity
+func (s CVESeverity) Style() SeverityStyle {
+	return SeverityPalette{}.Style(s)
+}
+
+// Label returns the default display label, e.g. "Critical"
+func (s CVESeverity) Label() string {
+	return s.Style().Label
+}
+
+// Color returns the default display color, e.g. "#b71c1c"
+func (s CVESeverity) Color() string {
+	return s.Style().Color
+}
+
+// FindingSeverity returns the severity of a dependency's finding: its most severe vulnerability, or
+// SeverityNone when it has none that count towards policies
+func (result *DependencyVulnerabilityResult) FindingSeverity() CVESeverity {
+	switch {
+	case result.CriticalCount > 0:
+		return SeverityCritical
+	case result.HighCount > 0:
+		return SeverityHigh
+	case result.MediumCount > 0:
+		return SeverityMedium
+	case result.LowCount > 0:
+		return SeverityLow
+	default:
+		return SeverityNone
+	}
+}
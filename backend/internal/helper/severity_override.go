@@ -0,0 +1,174 @@
+package helper
+
+import (
+	"context"
+	"elang-backend/internal/helper/parser"
+	"elang-backend/internal/model"
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// SeverityOverrideRule sets the effective severity of the vulnerabilities it matches, raising or lowering the
+// severity their advisory gives them. A rule matches a vulnerability when every criterion it sets matches:
+// CWE is one of the vulnerability's CWEs, CVEPattern is a glob such as "CVE-2024-*" matching its ID, CVE or an
+// alias, and Ecosystem is the OSV ecosystem its dependency is looked up in.
+type SeverityOverrideRule struct {
+	ID         string
+	CWE        string
+	CVEPattern string
+	Ecosystem  string
+	Severity   CVESeverity
+}
+
+// NormalizeCWE returns the canonical spelling of a CWE identifier, e.g. "cwe-94" and "94" become "CWE-94"
+func NormalizeCWE(value string) (string, error) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	number := strings.TrimPrefix(value, "CWE-")
+	if n, err := strconv.Atoi(number); err != nil || n <= 0 {
+		return "", fmt.Errorf("invalid CWE %q, expected e.g. CWE-94", value)
+	}
+	return "CWE-" + number, nil
+}
+
+// ValidateCVEPattern checks that a CVE pattern is a valid glob
+func ValidateCVEPattern(pattern string) error {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return fmt.Errorf("invalid cve_pattern %q: %w", pattern, err)
+	}
+	return nil
+}
+
+// Matches reports whether the rule applies to a vulnerability of a dependency looked up in ecosystem
+func (r SeverityOverrideRule) Matches(vuln VulnerabilityInfo, ecosystem string) bool {
+	if r.CWE == "" && r.CVEPattern == "" && r.Ecosystem == "" {
+		return false
+	}
+	if r.Ecosystem != "" && !strings.EqualFold(r.Ecosystem, ecosystem) {
+		return false
+	}
+	if r.CWE != "" && !containsFold(vuln.Cwes, r.CWE) {
+		return false
+	}
+	if r.CVEPattern != "" {
+		pattern := strings.ToUpper(r.CVEPattern)
+		matched := false
+		for _, id := range append([]string{vuln.ID, vuln.CVE}, vuln.Aliases...) {
+			if ok, _ := path.Match(pattern, strings.ToUpper(id)); ok && id != "" {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// specificity is how many criteria the rule sets; a rule setting more of them describes fewer vulnerabilities
+func (r SeverityOverrideRule) specificity() int {
+	count := 0
+	for _, criterion := range []string{r.CWE, r.CVEPattern, r.Ecosystem} {
+		if criterion != "" {
+			count++
+		}
+	}
+	return count
+}
+
+// matchingRule returns the most specific of rules matching a vulnerability, the earliest of those equally specific
+func matchingRule(rules []SeverityOverrideRule, vuln VulnerabilityInfo, ecosystem string) (SeverityOverrideRule, bool) {
+	var best SeverityOverrideRule
+	found := false
+	for _, rule := range rules {
+		if (!found || rule.specificity() > best.specificity()) && rule.Matches(vuln, ecosystem) {
+			best, found = rule, true
+		}
+	}
+	return best, found
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+type severityOverridesKey struct{}
+
+// WithSeverityOverrides applies rules to the findings of scans run with ctx. The matching rule setting the most
+// criteria decides a vulnerability's severity; among equally specific ones, the earliest in rules does.
+func WithSeverityOverrides(ctx context.Context, rules []SeverityOverrideRule) context.Context {
+	if len(rules) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, severityOverridesKey{}, rules)
+}
+
+// SeverityOverridesFor returns the rules attached to ctx by WithSeverityOverrides
+func SeverityOverridesFor(ctx context.Context) []SeverityOverrideRule {
+	rules, _ := ctx.Value(severityOverridesKey{}).([]SeverityOverrideRule)
+	return rules
+}
+
+// ApplySeverityOverrides returns vulns with the effective severity rules give each of them, and recomputes a
+// finding's severity from those. When that changes the severity, the advisory severity is kept in
+// OriginalSeverity; the IDs of the rules that matched are recorded either way. vulns itself is not modified:
+// a copy is returned when a rule matched.
+func ApplySeverityOverrides(finding *model.ScanFinding, dep parser.DependencyInfo, vulns []VulnerabilityInfo, rules []SeverityOverrideRule) []VulnerabilityInfo {
+	if len(rules) == 0 || len(vulns) == 0 {
+		return vulns
+	}
+	ecosystem := EcosystemForDependency(dep)
+	effective := SeverityNone
+	var applied []string
+	var overridden []VulnerabilityInfo
+	for i, vuln := range vulns {
+		severity := vuln.Severity
+		if rule, ok := matchingRule(rules, vuln, ecosystem); ok {
+			severity = rule.Severity
+			if !containsString(applied, rule.ID) {
+				applied = append(applied, rule.ID)
+			}
+			if overridden == nil {
+				overridden = slices.Clone(vulns)
+			}
+			overridden[i].Severity = severity
+		}
+		if severity.Rank() > effective.Rank() {
+			effective = severity
+		}
+	}
+	if len(applied) == 0 {
+		return vulns
+	}
+	finding.SeverityOverrideIDs = applied
+	if effective.String() != finding.Severity {
+		finding.OriginalSeverity = finding.Severity
+		finding.Severity = effective.String()
+	}
+	return overridden
+}
+
+// CountSeverities counts vulns by severity
+func CountSeverities(vulns []VulnerabilityInfo) (critical, high, medium, low int) {
+	for _, vuln := range vulns {
+		switch vuln.Severity {
+		case SeverityCritical:
+			critical++
+		case SeverityHigh:
+			high++
+		case SeverityMedium:
+			medium++
+		case SeverityLow:
+			low++
+		}
+	}
+	return critical, high, medium, low
+}
@@ -2,6 +2,7 @@ package helper
 
 import (
 	"context"
+	"errors"
 
 	"elang-backend/internal/helper/parser"
 	"elang-backend/internal/model"
@@ -9,26 +10,63 @@ import (
 	"log/slog"
 
 	"sync"
+	"time"
 )
 
+// DefaultDependencyScanTimeout bounds the vulnerability check of one dependency within a scan
+const DefaultDependencyScanTimeout = 15 * time.Second
+
+// DefaultScanConcurrency is how many dependencies a scan checks at once unless configured otherwise
+const DefaultScanConcurrency = 10
+
+// ScannerConfig configures a SharedScanner. The zero value checks DefaultScanConcurrency dependencies at once
+// against OSV.
+type ScannerConfig struct {
+	Sources           []VulnerabilitySource // advisory databases in priority order; none means OSV
+	MergeSources      bool                  // query every source and combine the results, see NewCVEHelperWithSources
+	MaxConcurrent     int                   // dependencies checked at once; zero or less means DefaultScanConcurrency
+	DependencyTimeout time.Duration         // zero or less means DefaultDependencyScanTimeout
+}
+
 // SharedScanner provides reusable scanning functionality across services
 type SharedScanner struct {
-	cveService    *CVEHelper
-	maxConcurrent int
+	cveService        *CVEHelper
+	maxConcurrent     int
+	dependencyTimeout time.Duration // a dependency still unchecked after this is abandoned and reported as errored
 }
 
 // NewSharedScanner creates a new shared scanner with controlled concurrency
-func NewSharedScanner(maxConcurrent int) *SharedScanner {
-	if maxConcurrent <= 0 {
-		maxConcurrent = 10 // default
+func NewSharedScanner(config ScannerConfig) *SharedScanner {
+	if config.MaxConcurrent <= 0 {
+		config.MaxConcurrent = DefaultScanConcurrency
+	}
+	if config.DependencyTimeout <= 0 {
+		config.DependencyTimeout = DefaultDependencyScanTimeout
 	}
 	return &SharedScanner{
-		cveService:    NewCVEHelper(),
-		maxConcurrent: maxConcurrent,
+		cveService:        NewCVEHelperWithSources(config.MergeSources, config.Sources...),
+		maxConcurrent:     config.MaxConcurrent,
+		dependencyTimeout: config.DependencyTimeout,
 	}
 }
 
-// ScanDependenciesWithControl scans dependencies with controlled concurrency using semaphore pattern
+type findingListenerKey struct{}
+
+// WithFindingListener registers a callback that receives every finding of scans run with ctx as soon as
+// its dependency has been checked, e.g. to stream results. Calls may come from several goroutines.
+func WithFindingListener(ctx context.Context, listener func(model.ScanFinding)) context.Context {
+	return context.WithValue(ctx, findingListenerKey{}, listener)
+}
+
+// NotifyFinding passes a finding to the listener registered with WithFindingListener, if any
+func NotifyFinding(ctx context.Context, finding model.ScanFinding) {
+	if listener, ok := ctx.Value(findingListenerKey{}).(func(model.ScanFinding)); ok && listener != nil {
+		listener(finding)
+	}
+}
+
+// ScanDependenciesWithControl scans dependencies with controlled concurrency using semaphore pattern.
+// findings[i] and depsWithVulns[i] describe the same dependency.
 func (ss *SharedScanner) ScanDependenciesWithControl(
 	ctx context.Context,
 	dependencies []DependencyInfo,
@@ -45,6 +83,7 @@ func (ss *SharedScanner) ScanDependenciesWithControl(
 	)
 
 	findings = make([]model.ScanFinding, 0)
+	overrides := SeverityOverridesFor(ctx)
 	depsWithVulns = make([]DependencyWithVulnerabilities, 0)
 
 	// Process each dependency with controlled concurrency
@@ -66,24 +105,23 @@ func (ss *SharedScanner) ScanDependenciesWithControl(
 			default:
 			}
 
-			// Perform vulnerability check
-			result, err := ss.cveService.CheckDependencyVulnerabilities(ctx, dependency)
+			// Perform vulnerability check; a hung lookup only holds the worker slot until the deadline
+			depCtx, cancel := context.WithTimeout(ctx, ss.dependencyTimeout)
+			result, err := ss.cveService.CheckDependencyVulnerabilities(depCtx, dependency)
+			timedOut := errors.Is(depCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil
+			cancel()
 			if err != nil {
 				slog.Warn("Failed to check vulnerabilities", "dependency", dependency.Name, "error", err)
 				return
 			}
+			if timedOut {
+				slog.Warn("Dependency scan timed out", "dependency", dependency.Name, "timeout", ss.dependencyTimeout)
+				result.Error = fmt.Sprintf("vulnerability check timed out after %s", ss.dependencyTimeout)
+				result.UncheckedReason = UncheckedTimedOut
+			}
 
 			// Determine severity
-			severity := "none"
-			if result.CriticalCount > 0 {
-				severity = "critical"
-			} else if result.HighCount > 0 {
-				severity = "high"
-			} else if result.MediumCount > 0 {
-				severity = "medium"
-			} else if result.LowCount > 0 {
-				severity = "low"
-			}
+			severity := result.FindingSeverity()
 
 			// Extract vulnerability IDs
 			var vulnIDs []string
@@ -99,36 +137,53 @@ func (ss *SharedScanner) ScanDependenciesWithControl(
 
 			// Create finding
 			finding := model.ScanFinding{
-				Dependency:       dependency.Name,
-				Version:          dependency.Version,
-				Severity:         severity,
-				VulnerabilityIDs: vulnIDs,
-				Recommendation:   recommendation,
+				Dependency:         dependency.Name,
+				Version:            dependency.Version,
+				Severity:           severity.String(),
+				VulnerabilityIDs:   vulnIDs,
+				Recommendation:     recommendation,
+				RecommendedVersion: result.RecommendedVersion,
+				RemediationImpact:  result.RemediationImpact,
+				RiskScore:          result.RiskScore,
+				Error:              result.Error,
+				UncheckedReason:    result.UncheckedReason,
+				AffectedRanges:     FindingAffectedRanges(dependency.Version, result.Vulnerabilities),
+				Constraint:         dependency.Constraint,
+				PartialRangeIDs:    PartialRangeIDs(result.Vulnerabilities),
+			}
+			// Overridden severities are what the SBOM and severity totals report
+			vulns := ApplySeverityOverrides(&finding, dependency, result.Vulnerabilities, overrides)
+			critical, high, medium, low := result.CriticalCount, result.HighCount, result.MediumCount, result.LowCount
+			if len(finding.SeverityOverrideIDs) > 0 {
+				critical, high, medium, low = CountSeverities(vulns)
 			}
 
 			// Create enhanced dependency with vulnerabilities
 			depWithVuln := DependencyWithVulnerabilities{
-				Name:            dependency.Name,
-				Version:         dependency.Version,
-				Owner:           dependency.Owner,
-				Repo:            dependency.Repo,
-				RepositoryURL:   dependency.GitHubURL,
-				Runtime:         dependency.Runtime,
-				IsGitHub:        dependency.IsGitHubRepo,
-				Vulnerabilities: result.Vulnerabilities,
-				RiskScore:       result.RiskScore,
+				Name:              dependency.Name,
+				Version:           dependency.Version,
+				Owner:             dependency.Owner,
+				Repo:              dependency.Repo,
+				RepositoryURL:     dependency.GitHubURL,
+				Runtime:           dependency.Runtime,
+				IsGitHub:          dependency.IsGitHubRepo,
+				Vulnerabilities:   vulns,
+				RiskScore:         result.RiskScore,
+				RemediationImpact: result.RemediationImpact,
 			}
 
 			// Update results (thread-safe)
 			mu.Lock()
 			findings = append(findings, finding)
 			depsWithVulns = append(depsWithVulns, depWithVuln)
-			totalCritical += result.CriticalCount
-			totalHigh += result.HighCount
-			totalMedium += result.MediumCount
-			totalLow += result.LowCount
+			totalCritical += critical
+			totalHigh += high
+			totalMedium += medium
+			totalLow += low
 			mu.Unlock()
 
+			NotifyFinding(ctx, finding)
+
 			slog.Debug("Dependency scanned",
 				"dependency", dependency.Name,
 				"vulnerabilities", len(result.Vulnerabilities),
@@ -0,0 +1,75 @@
+package helper
+
+import (
+	"strings"
+	"time"
+	"unicode"
+)
+
+// StaleReleasesBehind is how many newer releases make a used version stale
+const StaleReleasesBehind = 5
+
+// DependencyStaleness measures how far a used version trails the latest release of its repository
+type DependencyStaleness struct {
+	ReleasesBehind int // releases newer than the used version
+	Days           int // days between the used version's tag and the latest release; 0 when a date is unknown
+}
+
+// IsStale reports whether the used version is StaleReleasesBehind or more releases behind
+func (s DependencyStaleness) IsStale() bool {
+	return s.ReleasesBehind >= StaleReleasesBehind
+}
+
+// MeasureStaleness compares the tag of usedVersion with the repository's releases, the tags whose
+// normalized name is a plain dotted version. commitDate resolves a tag's commit SHA to its date and is only
+// called when the used version is behind. It reports false when usedVersion matches none of the tags.
+func MeasureStaleness(usedVersion string, tags []map[string]interface{}, commitDate func(sha string) (time.Time, error)) (DependencyStaleness, bool) {
+	usedTag := FindBestMatchingTag(usedVersion, tags)
+	if usedTag == "" {
+		return DependencyStaleness{}, false
+	}
+	used := NormalizeVersion(usedTag)
+
+	var staleness DependencyStaleness
+	var usedSHA, latestSHA, latest string
+	for _, tag := range tags {
+		name, _ := tag["name"].(string)
+		sha, _ := tag["commit_sha"].(string)
+		if name == usedTag {
+			usedSHA = sha
+		}
+		version := NormalizeVersion(name)
+		if !isReleaseVersion(version) || compareVersions(version, used) <= 0 {
+			continue
+		}
+		staleness.ReleasesBehind++
+		if latest == "" || compareVersions(version, latest) > 0 {
+			latest, latestSHA = version, sha
+		}
+	}
+	if staleness.ReleasesBehind == 0 || usedSHA == "" || latestSHA == "" {
+		return staleness, true
+	}
+
+	usedAt, err := commitDate(usedSHA)
+	if err != nil {
+		return staleness, true
+	}
+	latestAt, err := commitDate(latestSHA)
+	if err != nil {
+		return staleness, true
+	}
+	if gap := latestAt.Sub(usedAt); gap > 0 {
+		staleness.Days = int(gap.Hours() / 24)
+	}
+	return staleness, true
+}
+
+// isReleaseVersion reports whether a normalized version is a plain release such as "1.4.2",
+// excluding pre-releases ("1.5.0-rc.1") and tags that are not versions at all
+func isReleaseVersion(version string) bool {
+	if version == "" || !unicode.IsDigit(rune(version[0])) {
+		return false
+	}
+	return strings.IndexFunc(version, func(r rune) bool { return r != '.' && !unicode.IsDigit(r) }) < 0
+}
@@ -0,0 +1,776 @@
+package helper
+
+import (
+	"context"
+	"elang-backend/internal/helper/parser"
+	"elang-backend/internal/model"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/time/rate"
+)
+
+// VulnerabilitySource looks up the known vulnerabilities of one dependency version in an advisory database
+type VulnerabilitySource interface {
+	// Name identifies the source in results and logs, e.g. "osv" or "github"
+	Name() string
+	QueryVulnerabilities(ctx context.Context, dep parser.DependencyInfo) ([]VulnerabilityInfo, error)
+}
+
+// ErrUnsupportedEcosystem is returned by a source that has no ecosystem to look the dependency up in
+var ErrUnsupportedEcosystem = errors.New("unsupported runtime")
+
+// querySources asks the configured sources for the vulnerabilities of dep. It only fails when every
+// queried source failed, so one database being down does not fail the scan.
+func (c *CVEHelper) querySources(ctx context.Context, dep parser.DependencyInfo) ([]VulnerabilityInfo, error) {
+	var (
+		merged    []VulnerabilityInfo
+		succeeded bool
+		errs      []error
+	)
+	for _, source := range c.sources {
+		vulns, err := source.QueryVulnerabilities(ctx, dep)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", source.Name(), err))
+			continue
+		}
+		if !c.mergeSources {
+			return vulns, nil
+		}
+		succeeded = true
+		merged = MergeVulnerabilities(merged, vulns)
+	}
+	if !succeeded {
+		return nil, errors.Join(errs...)
+	}
+	return merged, nil
+}
+
+// queryWithAlternatives queries the sources under dep's name and its suggested alternatives (hyphen and
+// underscore spellings for Python and Rust, case variants for .NET) concurrently. The answers of every name
+// that succeeded are merged, primary name first, so an advisory filed under several spellings is counted
+// once. It only fails when every name failed, with the primary name's error.
+func (c *CVEHelper) queryWithAlternatives(ctx context.Context, dep parser.DependencyInfo) ([]VulnerabilityInfo, error) {
+	names := c.normalizer.GetSuggestedNames(dep)
+	// A commit query does not depend on the package name
+	if len(names) <= 1 || CommitForDependency(dep) != "" {
+		return c.querySources(ctx, dep)
+	}
+
+	vulns := make([][]VulnerabilityInfo, len(names))
+	errs := make([]error, len(names))
+	var g errgroup.Group
+	for i, name := range names {
+		altDep := dep
+		altDep.Name = name
+		g.Go(func() error {
+			vulns[i], errs[i] = c.querySources(ctx, altDep)
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	var merged []VulnerabilityInfo
+	succeeded := false
+	for i := range names {
+		if errs[i] != nil {
+			continue
+		}
+		succeeded = true
+		merged = MergeVulnerabilities(merged, vulns[i])
+	}
+	if !succeeded {
+		return nil, errs[0]
+	}
+	if merged == nil {
+		merged = []VulnerabilityInfo{}
+	}
+	return merged, nil
+}
+
+// MergeVulnerabilities appends the vulnerabilities of extra to base, skipping the ones base already holds
+// under any of their IDs (OSV, GHSA or CVE aliases). Duplicates contribute their aliases, patched versions
+// and references, and the more severe rating wins.
+func MergeVulnerabilities(base, extra []VulnerabilityInfo) []VulnerabilityInfo {
+	index := map[string]int{}
+	for i, vuln := range base {
+		for _, id := range vulnerabilityIDs(vuln) {
+			index[id] = i
+		}
+	}
+
+	for _, vuln := range extra {
+		existing := -1
+		for _, id := range vulnerabilityIDs(vuln) {
+			if i, ok := index[id]; ok {
+				existing = i
+				break
+			}
+		}
+		if existing < 0 {
+			base = append(base, vuln)
+			existing = len(base) - 1
+		} else {
+			mergeVulnerability(&base[existing], vuln)
+		}
+		for _, id := range vulnerabilityIDs(base[existing]) {
+			index[id] = existing
+		}
+	}
+	return base
+}
+
+func mergeVulnerability(into *VulnerabilityInfo, other VulnerabilityInfo) {
+	for _, alias := range append([]string{other.ID}, other.Aliases...) {
+		if alias != "" && alias != into.ID && !containsString(into.Aliases, alias) {
+			into.Aliases = append(into.Aliases, alias)
+		}
+	}
+	if into.CVE == "" {
+		into.CVE = other.CVE
+	}
+	if len(into.AffectedRanges) == 0 {
+		into.AffectedRanges = other.AffectedRanges
+	}
+	for _, patched := range other.PatchedVersions {
+		if !containsString(into.PatchedVersions, patched) {
+			into.PatchedVersions = append(into.PatchedVersions, patched)
+		}
+	}
+	for _, ref := range other.References {
+		if !containsString(into.References, ref) {
+			into.References = append(into.References, ref)
+		}
+	}
+	for _, cwe := range other.Cwes {
+		if !containsString(into.Cwes, cwe) {
+			into.Cwes = append(into.Cwes, cwe)
+		}
+	}
+	if other.Severity.Rank() > into.Severity.Rank() {
+		into.Severity, into.Score = other.Severity, other.Score
+	}
+}
+
+// vulnerabilityIDs returns every identifier a vulnerability is known by, upper-cased for comparison
+func vulnerabilityIDs(vuln VulnerabilityInfo) []string {
+	ids := make([]string, 0, len(vuln.Aliases)+2)
+	for _, id := range append([]string{vuln.ID, vuln.CVE}, vuln.Aliases...) {
+		if id = strings.ToUpper(strings.TrimSpace(id)); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// VersionInRange reports whether version satisfies a comma separated list of constraints such as
+// ">= 1.0.0, < 1.2.3" or "= 2.0.0", the format GitHub uses for vulnerable version ranges
+func VersionInRange(version, constraints string) bool {
+	version = strings.TrimSpace(version)
+	if version == "" {
+		return false
+	}
+	for _, clause := range strings.Split(constraints, ",") {
+		clause = strings.TrimSpace(clause)
+		if clause == "" {
+			continue
+		}
+		op := "="
+		for _, candidate := range []string{">=", "<=", "!=", "=", ">", "<"} {
+			if strings.HasPrefix(clause, candidate) {
+				op = candidate
+				clause = strings.TrimSpace(clause[len(candidate):])
+				break
+			}
+		}
+		cmp := compareVersions(version, clause)
+		var ok bool
+		switch op {
+		case ">=":
+			ok = cmp >= 0
+		case "<=":
+			ok = cmp <= 0
+		case ">":
+			ok = cmp > 0
+		case "<":
+			ok = cmp < 0
+		case "!=":
+			ok = cmp != 0
+		default:
+			ok = cmp == 0
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// VersionRange is one affected interval of a vulnerability: versions from Introduced ("0" for all earlier
+// versions) up to but excluding Fixed, or up to and including LastAffected. Without either bound every
+// version from Introduced on is affected.
+type VersionRange struct {
+	Introduced   string `json:"introduced"`
+	Fixed        string `json:"fixed,omitempty"`
+	LastAffected string `json:"last_affected,omitempty"`
+}
+
+// Contains reports whether version falls in the range
+func (r VersionRange) Contains(version string) bool {
+	if r.Introduced != "" && r.Introduced != "0" && compareVersions(version, r.Introduced) < 0 {
+		return false
+	}
+	if r.Fixed != "" && compareVersions(version, r.Fixed) >= 0 {
+		return false
+	}
+	if r.LastAffected != "" && compareVersions(version, r.LastAffected) > 0 {
+		return false
+	}
+	return true
+}
+
+// versionRangesFromEvents pairs the introduced events of an OSV range with the fixed or last_affected
+// event that follows them. GIT ranges hold commit hashes rather than versions and are skipped.
+func versionRangesFromEvents(r OSVRange) []VersionRange {
+	if strings.EqualFold(r.Type, "GIT") {
+		return nil
+	}
+	var (
+		ranges  []VersionRange
+		current *VersionRange
+	)
+	for _, event := range r.Events {
+		switch {
+		case event.Introduced != "":
+			if current != nil {
+				ranges = append(ranges, *current)
+			}
+			current = &VersionRange{Introduced: event.Introduced}
+		case event.Fixed != "" || event.LastAffected != "":
+			if current == nil {
+				current = &VersionRange{Introduced: "0"}
+			}
+			current.Fixed, current.LastAffected = event.Fixed, event.LastAffected
+			ranges = append(ranges, *current)
+			current = nil
+		}
+	}
+	if current != nil {
+		ranges = append(ranges, *current)
+	}
+	return ranges
+}
+
+// VersionRangeFromConstraints converts a GitHub vulnerable version range such as ">= 1.0.0, < 1.2.3" to
+// a VersionRange; a lower bound given with ">" is treated as inclusive and "*" matches every version
+func VersionRangeFromConstraints(constraints string) VersionRange {
+	r := VersionRange{Introduced: "0"}
+	for _, clause := range strings.Split(constraints, ",") {
+		clause = strings.TrimSpace(clause)
+		switch {
+		case strings.HasPrefix(clause, ">="):
+			r.Introduced = strings.TrimSpace(clause[2:])
+		case strings.HasPrefix(clause, "<="):
+			r.LastAffected = strings.TrimSpace(clause[2:])
+		case strings.HasPrefix(clause, ">"):
+			r.Introduced = strings.TrimSpace(clause[1:])
+		case strings.HasPrefix(clause, "<"):
+			r.Fixed = strings.TrimSpace(clause[1:])
+		case strings.HasPrefix(clause, "="):
+			r.Introduced = strings.TrimSpace(clause[1:])
+			r.LastAffected = r.Introduced
+		case clause != "" && clause != "*":
+			r.Introduced, r.LastAffected = clause, clause
+		}
+	}
+	return r
+}
+
+// String renders the range in the notation VersionRangeFromConstraints reads, e.g. ">=1.2.0, <1.4.1".
+// A range without bounds covers every version and renders as "*".
+func (r VersionRange) String() string {
+	if r.LastAffected != "" && r.LastAffected == r.Introduced {
+		return "=" + r.Introduced
+	}
+	var clauses []string
+	if r.Introduced != "" && r.Introduced != "0" {
+		clauses = append(clauses, ">="+r.Introduced)
+	}
+	if r.Fixed != "" {
+		clauses = append(clauses, "<"+r.Fixed)
+	}
+	if r.LastAffected != "" {
+		clauses = append(clauses, "<="+r.LastAffected)
+	}
+	if len(clauses) == 0 {
+		return "*"
+	}
+	return strings.Join(clauses, ", ")
+}
+
+// Vers renders the range as a CycloneDX version range (https://github.com/package-url/purl-spec/blob/master/VERSION-RANGE-SPEC.rst)
+// of the given versioning scheme, e.g. "vers:npm/>=1.2.0|<1.4.1"
+func (r VersionRange) Vers(scheme string) string {
+	constraints := r.String()
+	switch {
+	case constraints == "*":
+	case strings.HasPrefix(constraints, "="):
+		constraints = constraints[1:]
+	default:
+		constraints = strings.ReplaceAll(constraints, ", ", "|")
+	}
+	return "vers:" + scheme + "/" + constraints
+}
+
+// lowerBound returns the inclusive lower bound of the range, "" when it is unbounded
+func (r VersionRange) lowerBound() string {
+	if r.Introduced == "0" {
+		return ""
+	}
+	return r.Introduced
+}
+
+// Overlaps reports whether at least one version falls in both ranges
+func (r VersionRange) Overlaps(other VersionRange) bool {
+	// The higher lower bound is the first version the ranges could share
+	lower := r.lowerBound()
+	if o := other.lowerBound(); lower == "" || (o != "" && compareVersions(o, lower) > 0) {
+		lower = o
+	}
+	if lower == "" {
+		lower = "0"
+	}
+	return r.Contains(lower) && other.Contains(lower)
+}
+
+// Covers reports whether every version of other also falls in r
+func (r VersionRange) Covers(other VersionRange) bool {
+	if lower := r.lowerBound(); lower != "" {
+		if o := other.lowerBound(); o == "" || compareVersions(o, lower) < 0 {
+			return false
+		}
+	}
+	switch {
+	case r.Fixed == "" && r.LastAffected == "":
+		return true
+	case other.Fixed != "":
+		limit := r.Fixed
+		if limit == "" {
+			limit = r.LastAffected
+		}
+		return compareVersions(other.Fixed, limit) <= 0
+	case other.LastAffected != "":
+		if r.Fixed != "" {
+			return compareVersions(other.LastAffected, r.Fixed) < 0
+		}
+		return compareVersions(other.LastAffected, r.LastAffected) <= 0
+	}
+	return false
+}
+
+// ConstraintRange converts a declared version constraint such as ">=4.2.0,<5.0", "~=1.4.2" or "==2.*" to the
+// range of versions it allows. "!=" exclusions are ignored, so the range may be slightly wider than the
+// constraint. It reports false when the constraint has no clause to build a range from.
+func ConstraintRange(constraint string) (VersionRange, bool) {
+	var clauses []string
+	for _, clause := range strings.Split(constraint, ",") {
+		clause = strings.TrimSpace(clause)
+		switch {
+		case clause == "" || strings.HasPrefix(clause, "!="):
+		case strings.HasPrefix(clause, "~="):
+			// A compatible release allows later versions of all but its last component: ~=1.4.2 is >=1.4.2, <1.5
+			lower := strings.TrimSpace(clause[2:])
+			clauses = append(clauses, ">="+lower)
+			if i := strings.LastIndex(lower, "."); i > 0 {
+				if upper := bumpLastComponent(lower[:i]); upper != "" {
+					clauses = append(clauses, "<"+upper)
+				}
+			}
+		case strings.HasPrefix(clause, "=="):
+			version := strings.TrimSpace(strings.TrimLeft(clause, "="))
+			prefix, wildcard := strings.CutSuffix(version, ".*")
+			if !wildcard {
+				clauses = append(clauses, "="+version)
+				continue
+			}
+			clauses = append(clauses, ">="+prefix)
+			if upper := bumpLastComponent(prefix); upper != "" {
+				clauses = append(clauses, "<"+upper)
+			}
+		default:
+			clauses = append(clauses, clause)
+		}
+	}
+	if len(clauses) == 0 {
+		return VersionRange{}, false
+	}
+	return VersionRangeFromConstraints(strings.Join(clauses, ", ")), true
+}
+
+// bumpLastComponent increments the last component of a version, so "1.4" becomes "1.5". It returns "" when
+// that component is not a number.
+func bumpLastComponent(version string) string {
+	parts := strings.Split(version, ".")
+	last, err := strconv.Atoi(parts[len(parts)-1])
+	if err != nil {
+		return ""
+	}
+	parts[len(parts)-1] = strconv.Itoa(last + 1)
+	return strings.Join(parts, ".")
+}
+
+// VulnerabilitiesInRange keeps the vulnerabilities affecting at least one version of declared and sets
+// PartialRange on those that do not affect all of them. Vulnerabilities without version ranges are kept and
+// flagged, since it is unknown which of the declared versions they affect.
+func VulnerabilitiesInRange(vulns []VulnerabilityInfo, declared VersionRange) []VulnerabilityInfo {
+	kept := make([]VulnerabilityInfo, 0, len(vulns))
+	for _, vuln := range vulns {
+		overlaps, covers := len(vuln.AffectedRanges) == 0, false
+		for _, r := range vuln.AffectedRanges {
+			overlaps = overlaps || r.Overlaps(declared)
+			covers = covers || r.Covers(declared)
+		}
+		if !overlaps {
+			continue
+		}
+		vuln.PartialRange = !covers
+		kept = append(kept, vuln)
+	}
+	return kept
+}
+
+// PartialRangeIDs returns the IDs of the vulnerabilities that affect only part of a declared range
+func PartialRangeIDs(vulns []VulnerabilityInfo) []string {
+	var ids []string
+	for _, vuln := range vulns {
+		if vuln.PartialRange {
+			ids = append(ids, vuln.ID)
+		}
+	}
+	return ids
+}
+
+// FindingAffectedRanges lists the affected ranges of a dependency's vulnerabilities for its scan finding,
+// flagging those that contain the scanned version
+func FindingAffectedRanges(version string, vulns []VulnerabilityInfo) []model.AffectedRange {
+	var ranges []model.AffectedRange
+	for _, vuln := range vulns {
+		for _, r := range vuln.AffectedRanges {
+			ranges = append(ranges, model.AffectedRange{
+				VulnerabilityID: vuln.ID,
+				Introduced:      r.Introduced,
+				Fixed:           r.Fixed,
+				LastAffected:    r.LastAffected,
+				Range:           r.String(),
+				IncludesVersion: version != "" && r.Contains(version),
+			})
+		}
+	}
+	return ranges
+}
+
+// NewOSVQuery builds the package and version OSV query of a normalized dependency in one ecosystem. Go
+// pseudo-versions are queried this way too, since Go advisories only carry SEMVER ranges.
+func NewOSVQuery(dep parser.DependencyInfo, ecosystem string) OSVQuery {
+	return OSVQuery{Package: &OSVPackage{Name: dep.Name, Ecosystem: ecosystem}, Version: dep.Version}
+}
+
+// CommitForDependency returns the full commit hash a dependency is pinned to, which OSV additionally matches
+// against the fixing commits of GIT ranges. It is "" for releases and for commits only known abbreviated, e.g.
+// from a pseudo-version whose full hash was not resolved, since OSV only matches full hashes.
+func CommitForDependency(dep parser.DependencyInfo) string {
+	revision := commitFromVersion(dep.Version)
+	if revision == "" {
+		return ""
+	}
+	if full := strings.ToLower(strings.TrimSpace(dep.Commit)); len(full) == fullSHALength && strings.HasPrefix(full, revision) {
+		return full
+	}
+	if len(revision) == fullSHALength {
+		return revision
+	}
+	return ""
+}
+
+// fullSHALength is the length of a full (SHA-1) git commit hash
+const fullSHALength = 40
+
+// commitFromVersion extracts the git revision of a version that is a SHA itself ("3f4e2a1") or a Go
+// pseudo-version ("v0.0.0-20240101120000-3f4e2a1b5c6d", "v1.2.4-0.20240101120000-3f4e2a1b5c6d")
+func commitFromVersion(version string) string {
+	version = strings.ToLower(strings.TrimSpace(version))
+	if isGitSHA(version) && !isAllDigits(version) {
+		return version
+	}
+	// A pseudo-version ends in a 14 digit UTC timestamp and a 12 character revision
+	parts := strings.Split(strings.TrimSuffix(version, "+incompatible"), "-")
+	if len(parts) < 3 {
+		return ""
+	}
+	revision, timestamp := parts[len(parts)-1], parts[len(parts)-2]
+	timestamp = timestamp[strings.LastIndex(timestamp, ".")+1:]
+	if len(revision) != 12 || !isGitSHA(revision) || len(timestamp) != 14 || !isAllDigits(timestamp) {
+		return ""
+	}
+	return revision
+}
+
+// isGitSHA reports whether value looks like a full or abbreviated (at least 7 characters) git commit hash
+func isGitSHA(value string) bool {
+	if len(value) < 7 || len(value) > 40 {
+		return false
+	}
+	for _, r := range value {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+func isAllDigits(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return value != ""
+}
+
+// DefaultOSVRequestsPerSecond is the default ceiling on OSV requests across all scans of the process
+const DefaultOSVRequestsPerSecond = 10
+
+// OSVSource queries the OSV (Open Source Vulnerabilities) database at api.osv.dev. Every request of a source
+// is throttled by its rate limit, so the scans sharing a source share one request budget.
+type OSVSource struct {
+	httpClient     *http.Client
+	normalizer     *DependencyNameNormalizer
+	limiter        *rate.Limiter
+	declaredRanges bool // check dependencies declared with a version range against the whole range
+}
+
+// NewOSVSource creates an OSV source using DefaultOutboundTransport
+func NewOSVSource() *OSVSource {
+	return NewOSVSourceWithClient(&http.Client{
+		Timeout:   30 * time.Second,
+		Transport: DefaultOutboundTransport(),
+	})
+}
+
+// NewOSVSourceWithClient creates an OSV source sending its requests through httpClient, at most
+// DefaultOSVRequestsPerSecond of them
+func NewOSVSourceWithClient(httpClient *http.Client) *OSVSource {
+	return &OSVSource{
+		httpClient: httpClient,
+		normalizer: NewDependencyNameNormalizer(),
+		limiter:    rate.NewLimiter(rate.Limit(DefaultOSVRequestsPerSecond), DefaultOSVRequestsPerSecond),
+	}
+}
+
+// SetRateLimit sets the combined rate of the source's requests, allowing bursts of up to one second's worth.
+// Zero or less removes the limit.
+func (s *OSVSource) SetRateLimit(requestsPerSecond int) {
+	if requestsPerSecond <= 0 {
+		s.limiter.SetLimit(rate.Inf)
+		return
+	}
+	s.limiter.SetLimit(rate.Limit(requestsPerSecond))
+	s.limiter.SetBurst(requestsPerSecond)
+}
+
+// SetDeclaredRangeChecks sets whether a dependency declared with a version range, such as a requirements.txt
+// line ">=4.2.0,<5.0", is checked against every version of the range rather than only its lower bound
+func (s *OSVSource) SetDeclaredRangeChecks(enabled bool) {
+	s.declaredRanges = enabled
+}
+
+func (s *OSVSource) Name() string {
+	return "osv"
+}
+
+// QueryVulnerabilities queries OSV for the vulnerabilities affecting dep's version. The candidate ecosystems
+// of its runtime are tried in order until one reports vulnerabilities. A dependency pinned to a full commit hash
+// is also queried by that commit, and both answers are merged; without an ecosystem only the commit is queried.
+func (s *OSVSource) QueryVulnerabilities(ctx context.Context, dep parser.DependencyInfo) ([]VulnerabilityInfo, error) {
+	ecosystems := EcosystemsForDependency(dep)
+	commit := CommitForDependency(dep)
+	if len(ecosystems) == 0 && commit == "" {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedEcosystem, dep.Runtime)
+	}
+
+	// Ensure the dependency is normalized before querying
+	normalizedDep := s.normalizer.NormalizeDependencyInfo(dep)
+
+	// A failed ecosystem only counts when no later one answers, since a clean answer there could be wrong
+	vulns := []VulnerabilityInfo{}
+	var lastErr error
+	for _, ecosystem := range ecosystems {
+		found, err := s.queryEcosystem(ctx, normalizedDep, ecosystem)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			lastErr = err
+			continue
+		}
+		if len(found) > 0 {
+			vulns, lastErr = found, nil
+			break
+		}
+	}
+	if lastErr != nil {
+		return nil, lastErr
+	}
+
+	if commit != "" {
+		osvVulns, err := s.queryAll(ctx, OSVQuery{Commit: commit})
+		if err != nil {
+			return nil, err
+		}
+		byCommit := make([]VulnerabilityInfo, 0, len(osvVulns))
+		for _, osvVuln := range osvVulns {
+			byCommit = append(byCommit, convertOSVToVulnerabilityInfo(osvVuln, normalizedDep))
+		}
+		vulns = MergeVulnerabilities(vulns, byCommit)
+	}
+	return vulns, nil
+}
+
+// GetVulnerability retrieves detailed information about a specific vulnerability
+func (s *OSVSource) GetVulnerability(ctx context.Context, vulnID string) (*VulnerabilityInfo, error) {
+	encodedID := url.QueryEscape(vulnID)
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("https://api.osv.dev/v1/vulns/%s", encodedID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("User-Agent", "SilentPatchDetector/1.0")
+
+	if err := s.limiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("waiting for OSV rate limit: %w", err)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("vulnerability not found: %s", vulnID)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OSV API returned status %d", resp.StatusCode)
+	}
+
+	var osvVuln OSVVulnerability
+	if err := json.NewDecoder(resp.Body).Decode(&osvVuln); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	// Convert to our format (using empty dependency as we don't have context)
+	vuln := convertOSVToVulnerabilityInfo(osvVuln, parser.DependencyInfo{})
+	return &vuln, nil
+}
+
+// queryEcosystem reads every page of OSV's vulnerabilities of normalizedDep in one ecosystem
+func (s *OSVSource) queryEcosystem(ctx context.Context, normalizedDep parser.DependencyInfo, ecosystem string) ([]VulnerabilityInfo, error) {
+	// Prepare query for OSV API. Without a version OSV returns every vulnerability of the package, which is
+	// narrowed to the declared range once the response is read.
+	query := NewOSVQuery(normalizedDep, ecosystem)
+	declared, checkRange := VersionRange{}, false
+	if s.declaredRanges && normalizedDep.Constraint != "" {
+		if declared, checkRange = ConstraintRange(normalizedDep.Constraint); checkRange {
+			query.Version = ""
+		}
+	}
+
+	osvVulns, err := s.queryAll(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	vulns := make([]VulnerabilityInfo, 0, len(osvVulns))
+	for _, osvVuln := range osvVulns {
+		vulns = append(vulns, convertOSVToVulnerabilityInfo(osvVuln, normalizedDep))
+	}
+	if checkRange {
+		vulns = VulnerabilitiesInRange(vulns, declared)
+	}
+	return vulns, nil
+}
+
+// queryAll reads every page of an OSV query; heavily reported packages are returned over several pages
+func (s *OSVSource) queryAll(ctx context.Context, query OSVQuery) ([]OSVVulnerability, error) {
+	var osvVulns []OSVVulnerability
+	seenTokens := map[string]bool{}
+	for page := 1; ; page++ {
+		osvResp, err := s.queryPage(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		osvVulns = append(osvVulns, osvResp.Vulns...)
+		if osvResp.NextPageToken == "" {
+			return osvVulns, nil
+		}
+		if seenTokens[osvResp.NextPageToken] || page >= maxOSVPages {
+			return nil, fmt.Errorf("OSV kept paginating after %d pages", page)
+		}
+		seenTokens[osvResp.NextPageToken] = true
+		query.PageToken = osvResp.NextPageToken
+	}
+}
+
+// maxOSVPages bounds the pages read for one query, so a server that never stops paginating cannot hang a scan
+const maxOSVPages = 50
+
+// queryPage sends one OSV query and decodes the page it returns
+func (s *OSVSource) queryPage(ctx context.Context, query OSVQuery) (*OSVResponse, error) {
+	queryBytes, err := json.Marshal(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal query: %w", err)
+	}
+
+	// Create request
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.osv.dev/v1/query", strings.NewReader(string(queryBytes)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "SilentPatchDetector/1.0")
+
+	// Wait for the shared request budget; a scan cancelled or timed out while waiting gives up here
+	if err := s.limiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("waiting for OSV rate limit: %w", err)
+	}
+
+	// Execute request
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OSV API returned status %d", resp.StatusCode)
+	}
+
+	// Parse response
+	var osvResp OSVResponse
+	if err := json.NewDecoder(resp.Body).Decode(&osvResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &osvResp, nil
+}
@@ -1,10 +1,17 @@
 package model
 
+import (
+	"encoding/json"
+	"time"
+)
+
 type AddApplicationRequest struct {
 	AppName     string `form:"app_name" binding:"required"`
 	RuntimeType string `form:"runtime_type" binding:"required"`
 	Framework   string `form:"framework" binding:"required"`
 	Description string `form:"description"`
+	// AutoScan scans the application once its dependencies are processed; omitted uses the server default
+	AutoScan *bool `form:"auto_scan"`
 	// File will be handled as multipart.FileHeader in handler, not here
 }
 
@@ -21,10 +28,32 @@ type ApplicationDependencyDetail struct {
 	Owner         string  `json:"owner"`
 	Repo          string  `json:"repo"`
 	UsedVersion   string  `json:"used_version"`
+	Ecosystem     string  `json:"ecosystem,omitempty"` // OSV ecosystem override, empty when the runtime's is used
+	Transitive    bool    `json:"transitive"`          // pulled in by other dependencies rather than declared
 	IsMonitored   bool    `json:"is_monitored"`
 	RepositoryURL string  `json:"repository_url"`
 	LastTag       *string `json:"latest_tag,omitempty"`
 	DefaultBranch *string `json:"default_branch,omitempty"`
+
+	// VersionResolved reports whether UsedVersion corresponds to a real upstream tag; ResolvedTag is that tag.
+	// Unresolved versions are kept as declared but could not be verified against the repository.
+	VersionResolved bool   `json:"version_resolved"`
+	ResolvedTag     string `json:"resolved_tag,omitempty"`
+
+	// ReleasesBehind and StalenessDays measure how far UsedVersion trails the repository's latest release,
+	// as of StalenessCheckedAt; they are absent until GitHub metadata has been fetched for the version.
+	// IsStale flags versions that are many releases behind.
+	ReleasesBehind     *int       `json:"releases_behind,omitempty"`
+	StalenessDays      *int       `json:"staleness_days,omitempty"`
+	IsStale            bool       `json:"is_stale"`
+	StalenessCheckedAt *time.Time `json:"staleness_checked_at,omitempty"`
+
+	// FirstSeenAt is when the application started using the dependency
+	FirstSeenAt time.Time `json:"first_seen_at"`
+
+	// UpdatedAt is the last change to this application's use of the dependency; send it back
+	// with an update to have it rejected if someone else changed the dependency in the meantime
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 type AddApplicationResponse struct {
@@ -35,6 +64,9 @@ type AddApplicationResponse struct {
 	Description     string      `json:"description"`
 	Status          string      `json:"status"`
 	DependencyParse interface{} `json:"dependency_parse"`
+	DependencyCount int         `json:"dependency_count"`
+	AutoScan        bool        `json:"auto_scan"`
+	Warnings        []string    `json:"warnings,omitempty"`
 	Message         string      `json:"message"`
 }
 
@@ -51,6 +83,34 @@ type ApplicationSummary struct {
 	Framework   string `json:"framework"`
 	Status      string `json:"status"`
 	Description string `json:"description"`
+	// Tags are sorted by name and empty when the application has none
+	Tags []string `json:"tags"`
+}
+
+// ApplicationTagsRequest adds or removes tags such as "team:payments" or "env:prod". Tags are
+// case-insensitive and stored lower-cased.
+type ApplicationTagsRequest struct {
+	Tags []string `json:"tags" binding:"required,min=1,dive,required"`
+}
+
+// ApplicationTagsResponse lists the tags of an application after a change
+type ApplicationTagsResponse struct {
+	AppID   string   `json:"app_id"`
+	Tags    []string `json:"tags"`
+	Message string   `json:"message"`
+}
+
+// ListRuntimeFrameworksResponse lists the frameworks valid for one runtime
+type ListRuntimeFrameworksResponse struct {
+	RuntimeID  int                `json:"runtime_id"`
+	Runtime    string             `json:"runtime"`
+	Frameworks []FrameworkSummary `json:"frameworks"`
+	Message    string             `json:"message"`
+}
+
+type FrameworkSummary struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
 }
 
 type ApplicationStatus struct {
@@ -60,3 +120,168 @@ type ApplicationStatus struct {
 	DependencyCount int    `json:"dependency_count"`
 	LastUpdated     string `json:"last_updated,omitempty"`
 }
+
+// UpdateApplicationRequest changes application metadata; omitted fields are left unchanged
+type UpdateApplicationRequest struct {
+	AppName     *string `json:"app_name"`
+	RuntimeType *string `json:"runtime_type"`
+	Framework   *string `json:"framework"`
+	Description *string `json:"description"`
+}
+
+type UpdateApplicationResponse struct {
+	AppID             string   `json:"app_id"`
+	AppName           string   `json:"app_name"`
+	RuntimeType       string   `json:"runtime_type"`
+	Framework         string   `json:"framework"`
+	Description       string   `json:"description"`
+	Status            string   `json:"status"`
+	RescanRecommended bool     `json:"rescan_recommended"`
+	Warnings          []string `json:"warnings,omitempty"`
+	Message           string   `json:"message"`
+}
+
+// CloneApplicationRequest names the application created by POST /api/applications/:app_id/clone
+type CloneApplicationRequest struct {
+	AppName string `json:"app_name" binding:"required"`
+}
+
+type CloneApplicationResponse struct {
+	AppID           string `json:"app_id"`
+	AppName         string `json:"app_name"`
+	SourceAppID     string `json:"source_app_id"`
+	RuntimeType     string `json:"runtime_type"`
+	Framework       string `json:"framework"`
+	Description     string `json:"description"`
+	Status          string `json:"status"`
+	DependencyCount int    `json:"dependency_count"`
+	Message         string `json:"message"`
+}
+
+// AuditEntry is one recorded action on an application. The JSON columns are returned as stored; a malformed
+// one is replaced by {"decode_error": "...", "raw": "..."} rather than failing the whole listing.
+type AuditEntry struct {
+	ID               string          `json:"id"`
+	Action           string          `json:"action"`
+	OldValues        json.RawMessage `json:"old_values"`
+	NewValues        json.RawMessage `json:"new_values"`
+	Context          json.RawMessage `json:"context"`
+	PerformedBy      string          `json:"performed_by"`
+	PerformedAt      time.Time       `json:"performed_at"`
+	SecurityRelevant bool            `json:"security_relevant"`
+	RiskLevel        string          `json:"risk_level,omitempty"`
+}
+
+// ApplicationAuditResponse is one page of an application's audit trail, newest first
+type ApplicationAuditResponse struct {
+	AppID   string       `json:"app_id"`
+	Entries []AuditEntry `json:"entries"`
+	Limit   int          `json:"limit"`
+	Offset  int          `json:"offset"`
+}
+
+// AddApplicationJSONRequest is the JSON alternative to the multipart AddApplication upload
+type AddApplicationJSONRequest struct {
+	AppName       string `json:"app_name" binding:"required"`
+	Runtime       string `json:"runtime" binding:"required"`
+	Framework     string `json:"framework" binding:"required"`
+	Description   string `json:"description"`
+	FileName      string `json:"file_name" binding:"required"`
+	ContentBase64 string `json:"content_base64" binding:"required"`
+	AutoScan      *bool  `json:"auto_scan"`
+	// GradlePropertiesBase64 is an optional gradle.properties defining version variables of a Gradle build file
+	GradlePropertiesBase64 string `json:"gradle_properties_base64,omitempty"`
+}
+
+// ApplicationManifest is the dependency manifest an application was added from, as it was submitted
+type ApplicationManifest struct {
+	AppID    string `json:"app_id"`
+	FileName string `json:"file_name"`
+	Content  string `json:"content"`
+	Size     int    `json:"size"` // bytes
+}
+
+// DependencyTree splits the dependencies of an application into the direct ones its manifest declares and the
+// transitive ones they pull in
+type DependencyTree struct {
+	AppID      string               `json:"app_id"`
+	AppName    string               `json:"app_name"`
+	Direct     []DependencyTreeNode `json:"direct"`
+	Transitive []DependencyTreeNode `json:"transitive"`
+}
+
+// DependencyTreeNode is one dependency of a DependencyTree with the vulnerabilities the application's latest
+// completed scan found for the version in use. It has no parent links, as no parser records them.
+type DependencyTreeNode struct {
+	DependencyID    string `json:"dependency_id"`
+	Name            string `json:"name"`
+	Version         string `json:"version"`
+	Ecosystem       string `json:"ecosystem,omitempty"`
+	RepositoryURL   string `json:"repository_url,omitempty"`
+	Vulnerabilities int    `json:"vulnerabilities"`
+	// HighestSeverity is the most severe of the vulnerabilities; empty when there are none
+	HighestSeverity string `json:"highest_severity,omitempty"`
+}
+
+// ReparseApplicationResponse compares what the current parser reads from an application's stored manifest with
+// the dependencies linked to the application. Added and changed dependencies are linked in the background.
+type ReparseApplicationResponse struct {
+	AppID           string                     `json:"app_id"`
+	FileName        string                     `json:"file_name"`
+	DependencyCount int                        `json:"dependency_count"` // dependencies parsed from the manifest
+	Added           []ManifestDependencyChange `json:"added"`
+	Changed         []ManifestDependencyChange `json:"changed"`
+	// Missing are linked dependencies the manifest no longer yields, e.g. ones added through the API; they are kept
+	Missing   []ManifestDependencyChange `json:"missing"`
+	Unchanged int                        `json:"unchanged"`
+	Warnings  []string                   `json:"warnings,omitempty"`
+	Message   string                     `json:"message"`
+}
+
+// ApplicationComparison sets the dependencies of two applications side by side, with the risk their latest
+// completed scans found
+type ApplicationComparison struct {
+	A ComparedApplication `json:"a"`
+	B ComparedApplication `json:"b"`
+
+	Shared  []SharedDependency   `json:"shared"`
+	OnlyInA []ComparedDependency `json:"only_in_a"`
+	OnlyInB []ComparedDependency `json:"only_in_b"`
+
+	// HigherRisk names the application whose latest scan found more critical, then more high, vulnerabilities:
+	// "a", "b", "equal", or "unknown" when either application has no completed scan
+	HigherRisk string `json:"higher_risk"`
+}
+
+// ComparedApplication is one side of an ApplicationComparison
+type ComparedApplication struct {
+	AppID        string `json:"app_id"`
+	AppName      string `json:"app_name"`
+	Dependencies int    `json:"dependencies"`
+	// LatestScan is the summary of the application's latest completed scan, absent when it was never scanned
+	LatestScan *ComparedScan `json:"latest_scan,omitempty"`
+}
+
+// ComparedScan summarizes the scan an application's risk is compared on
+type ComparedScan struct {
+	ScanID       string                  `json:"scan_id"`
+	PolicyStatus string                  `json:"policy_status"`
+	Severities   DashboardSeverityTotals `json:"severities"`
+	ScannedAt    time.Time               `json:"scanned_at"`
+}
+
+// SharedDependency is a dependency both applications use, with the version each uses
+type SharedDependency struct {
+	DependencyID string `json:"dependency_id"`
+	Name         string `json:"name"`
+	VersionA     string `json:"version_a"`
+	VersionB     string `json:"version_b"`
+	SameVersion  bool   `json:"same_version"`
+}
+
+// ComparedDependency is a dependency only one of the compared applications uses
+type ComparedDependency struct {
+	DependencyID string `json:"dependency_id"`
+	Name         string `json:"name"`
+	Version      string `json:"version"`
+}
@@ -0,0 +1,10 @@
+package model
+
+import "time"
+
+// AuthUser is the authenticated caller, taken from a verified JWT
+type AuthUser struct {
+	ID        string    `json:"id"` // JWT subject; owns the applications the user creates
+	Issuer    string    `json:"issuer,omitempty"`
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+}
@@ -1,5 +1,7 @@
 package model
 
+import "time"
+
 type ScanSummary struct {
 	TotalDependencies    int `json:"total_dependencies"`
 	TotalVulnerabilities int `json:"total_vulnerabilities"`
@@ -9,17 +11,47 @@ type ScanSummary struct {
 	Low                  int `json:"low"`
 	Ignored              int `json:"ignored"`
 	None                 int `json:"none"`
+	// Unchecked counts dependencies whose vulnerability check failed, e.g. because OSV was unreachable
+	Unchecked int `json:"unchecked"`
+	// Unsupported counts dependencies that were not checked because no vulnerability database covers
+	// their ecosystem
+	Unsupported int `json:"unsupported"`
 }
 
 type ScanPolicy struct {
 	FailOn []string `json:"fail_on"`
-	Status string   `json:"status"`
-	Reason string   `json:"reason"`
+	// MinScore is the risk score that failed the scan, 0 when the score was not checked
+	MinScore float64 `json:"min_score"`
+	// FailClosed records whether dependencies that could not be checked fail the scan
+	FailClosed bool   `json:"fail_closed"`
+	Status     string `json:"status"`
+	Reason     string `json:"reason"`
+}
+
+// EvaluatePolicyRequest is a proposed policy to try against a stored scan without saving it
+type EvaluatePolicyRequest struct {
+	FailOn   []string `json:"fail_on"`   // severities that fail the scan: critical, high, medium, low
+	MinScore float64  `json:"min_score"` // fail when a dependency's risk score reaches this (0-10); 0 disables the check
+}
+
+// PolicyEvaluation is the outcome of a dry-run policy evaluation
+type PolicyEvaluation struct {
+	ScanID   string      `json:"scan_id"`
+	FailOn   []string    `json:"fail_on"`
+	MinScore float64     `json:"min_score"`
+	Status   string      `json:"status"` // pass or fail
+	Reason   string      `json:"reason"`
+	Summary  ScanSummary `json:"summary"`
+	// StoredStatus is the verdict recorded when the scan ran, for comparison
+	StoredStatus string `json:"stored_status"`
 }
 
 type ScanArtifacts struct {
 	VulnerabilityReport string `json:"vulnerability_report"`
 	SBOM                string `json:"sbom"`
+
+	// SBOMObjectKey is the storage key behind SBOM, kept on the scan record for retention cleanup
+	SBOMObjectKey string `json:"-"`
 }
 
 type ScanFinding struct {
@@ -28,16 +60,128 @@ type ScanFinding struct {
 	Severity         string   `json:"severity"`
 	VulnerabilityIDs []string `json:"vulnerability_ids"`
 	Recommendation   string   `json:"recommendation"`
+	// RecommendedVersion is the lowest upgrade that resolves every vulnerability of the dependency
+	RecommendedVersion string `json:"recommended_version,omitempty"`
+	// RemediationImpact is whether moving to RecommendedVersion is a patch, minor or major upgrade, or
+	// unknown when no upgrade is known or the versions are not semver
+	RemediationImpact string  `json:"remediation_impact,omitempty"`
+	RiskScore         float64 `json:"risk_score,omitempty"` // average score of the dependency's vulnerabilities
+	Error             string  `json:"error,omitempty"`      // set when the dependency could not be checked, e.g. it timed out
+	// UncheckedReason classifies Error: unsupported_ecosystem, invalid_dependency, check_failed, timed_out or
+	// unresolved_version
+	UncheckedReason string `json:"unchecked_reason,omitempty"`
+	// AffectedRanges are the version ranges in which the vulnerabilities apply, so a client can show why the
+	// version is affected
+	AffectedRanges []AffectedRange `json:"affected_ranges,omitempty"`
+	// Constraint is the version range the manifest declared, when it allows more than one version; Version
+	// is then its lower bound
+	Constraint string `json:"constraint,omitempty"`
+	// PartialRangeIDs are the vulnerabilities affecting only part of Constraint, with declared range checks on
+	PartialRangeIDs []string `json:"partial_range_ids,omitempty"`
+	// MaintenanceRisk is how likely the dependency's repository is to be abandoned (high, medium, low or unknown),
+	// set by application scans from its stored GitHub signals, with the reasons that raised it
+	MaintenanceRisk    string   `json:"maintenance_risk,omitempty"`
+	MaintenanceReasons []string `json:"maintenance_reasons,omitempty"`
+	// OriginalSeverity is the severity the advisories gave the finding when severity overrides changed Severity;
+	// SeverityOverrideIDs are the overrides that matched its vulnerabilities
+	OriginalSeverity    string   `json:"original_severity,omitempty"`
+	SeverityOverrideIDs []string `json:"severity_override_ids,omitempty"`
+}
+
+// AffectedRange is one version interval of a finding's vulnerability: from Introduced ("0" for all earlier
+// versions) up to but excluding Fixed, or up to and including LastAffected
+type AffectedRange struct {
+	VulnerabilityID string `json:"vulnerability_id"`
+	Introduced      string `json:"introduced"`
+	Fixed           string `json:"fixed,omitempty"`
+	LastAffected    string `json:"last_affected,omitempty"`
+	Range           string `json:"range"`            // e.g. ">=1.2.0, <1.4.1"
+	IncludesVersion bool   `json:"includes_version"` // whether the finding's version lies in the range
 }
 
 type ScanApplicationResult struct {
-	AppID      string        `json:"app_id"`
-	AppName    string        `json:"app_name"`
-	ScanStatus string        `json:"scan_status"`
-	Summary    ScanSummary   `json:"summary"`
-	Policies   ScanPolicy    `json:"policies"`
-	Artifacts  ScanArtifacts `json:"artifacts"`
-	Findings   []ScanFinding `json:"findings"`
+	ScanID          string        `json:"scan_id"`
+	AppID           string        `json:"app_id"`
+	AppName         string        `json:"app_name"`
+	ScanStatus      string        `json:"scan_status"`
+	Summary         ScanSummary   `json:"summary"`
+	Policies        ScanPolicy    `json:"policies"`
+	Artifacts       ScanArtifacts `json:"artifacts"`
+	Findings        []ScanFinding `json:"findings"`
+	DependencyCount int           `json:"dependency_count"`
+	Warnings        []string      `json:"warnings,omitempty"`
+	ScannedAt       time.Time     `json:"scanned_at"`
+	// Unchecked lists the dependencies that were not checked for vulnerabilities, so they are not mistaken
+	// for dependencies without any
+	Unchecked []UncheckedDependency `json:"unchecked,omitempty"`
+	// Conflicts lists the dependencies several scanned manifests pin to different versions
+	Conflicts []VersionConflict `json:"conflicts,omitempty"`
+}
+
+// ConflictingVersion is one of the versions a conflicting dependency is pinned to, with the files pinning it
+type ConflictingVersion struct {
+	Version string   `json:"version"`
+	Files   []string `json:"files"`
+}
+
+// VersionConflict is a dependency pinned to different versions by different manifests
+type VersionConflict struct {
+	Name      string               `json:"name"`
+	Ecosystem string               `json:"ecosystem,omitempty"`
+	Versions  []ConflictingVersion `json:"versions"`
+}
+
+// UncheckedDependency is a dependency a scan could not check, with the reason
+type UncheckedDependency struct {
+	Dependency string `json:"dependency"`
+	Version    string `json:"version"`
+	Reason     string `json:"reason"` // unsupported_ecosystem, invalid_dependency, check_failed, timed_out or unresolved_version
+	Detail     string `json:"detail"`
+}
+
+// ScanJobStatus reports the progress of a scan started asynchronously
+type ScanJobStatus struct {
+	ScanID      string     `json:"scan_id"`
+	AppID       string     `json:"app_id,omitempty"`
+	AppName     string     `json:"app_name"`
+	Status      string     `json:"status"` // queued, running, completed, failed
+	Error       string     `json:"error,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// ScanDependenciesJSONRequest is the JSON alternative to the multipart dependency scan upload
+type ScanDependenciesJSONRequest struct {
+	AppName       string `json:"app_name" binding:"required"`
+	Runtime       string `json:"runtime" binding:"required"`
+	Version       string `json:"version"`
+	Description   string `json:"description"`
+	FileName      string `json:"file_name" binding:"required"`
+	ContentBase64 string `json:"content_base64" binding:"required"`
+	// AdditionalFiles are further manifests, e.g. of the other packages of a monorepo, scanned together with
+	// the first one
+	AdditionalFiles []ManifestFileJSON `json:"additional_files,omitempty" binding:"omitempty,dive"`
+	// GradlePropertiesBase64 is an optional gradle.properties defining version variables of a Gradle build file
+	GradlePropertiesBase64 string `json:"gradle_properties_base64,omitempty"`
+	// FailOn and MinScore override the default policy for this scan; FailOn is comma-separated severities
+	FailOn   string   `json:"fail_on,omitempty"`
+	MinScore *float64 `json:"min_score,omitempty"`
+}
+
+// ManifestFileJSON is one more dependency file of a JSON scan request
+type ManifestFileJSON struct {
+	FileName      string `json:"file_name" binding:"required"`
+	ContentBase64 string `json:"content_base64" binding:"required"`
+}
+
+// CheckDependencyRequest asks for a vulnerability lookup of a single library version
+type CheckDependencyRequest struct {
+	Name    string `json:"name" binding:"required"`
+	Version string `json:"version" binding:"required"`
+	Runtime string `json:"runtime" binding:"required"`
+	Owner   string `json:"owner"`
+	Repo    string `json:"repo"`
 }
 
 type DependencyInfoRequest struct {
@@ -47,6 +191,8 @@ type DependencyInfoRequest struct {
 	Version       string `json:"version" binding:"required"`
 	RepositoryURL string `json:"repository_url"`
 	IsGitHubRepo  bool   `json:"is_github_repo" default:"true"`
+	// Ecosystem optionally overrides the OSV ecosystem of the application's runtime for this dependency
+	Ecosystem string `json:"ecosystem,omitempty"`
 }
 
 type UpdateApplicationDependencyRequest struct {
@@ -60,11 +206,265 @@ type UpdateDependencyItem struct {
 	Repo          string `json:"repo,omitempty"`           // Optional
 	UsedVersion   string `json:"used_version"`             // Required
 	RepositoryURL string `json:"repository_url,omitempty"` // Optional
+
+	// Optional: the updated_at returned by the dependency listing; the update is rejected
+	// with a conflict when the dependency has changed since
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
 }
 
 type UpdateApplicationDependencyResponse struct {
-	AppID   string   `json:"app_id"`
-	Updated []string `json:"updated"`
-	Failed  []string `json:"failed"`
-	Message string   `json:"message"`
+	AppID     string                     `json:"app_id"`
+	Updated   []string                   `json:"updated"`
+	Failed    []DependencyUpdateFailure  `json:"failed"`
+	Conflicts []DependencyUpdateConflict `json:"conflicts,omitempty"`
+	Message   string                     `json:"message"`
+	Warnings  []string                   `json:"warnings,omitempty"` // problems that did not fail any update, e.g. GitHub rate limits
+}
+
+// Reasons a dependency update fails, reported in DependencyUpdateFailure.Reason
+const (
+	UpdateFailureInvalidID            = "invalid_id"
+	UpdateFailureEmptyVersion         = "empty_version"
+	UpdateFailureNotFound             = "not_found"
+	UpdateFailureInvalidRepositoryURL = "invalid_repository_url"
+	UpdateFailureGitHubFetchFailed    = "github_fetch_failed"
+	UpdateFailureConflict             = "conflict"
+	UpdateFailureDBError              = "db_error"
+)
+
+// DependencyUpdateFailure is a dependency that was left unchanged, with why
+type DependencyUpdateFailure struct {
+	DependencyID string `json:"dependency_id"`
+	Reason       string `json:"reason"` // one of the UpdateFailure* reasons
+	Error        string `json:"error"`
+}
+
+// DependencyUpdateConflict explains why a dependency in Failed was rejected as a stale update
+type DependencyUpdateConflict struct {
+	DependencyID string `json:"dependency_id"`
+	Status       int    `json:"status"` // 409
+	Reason       string `json:"reason"`
+}
+
+// BulkBumpRequest moves every application on a dependency's FromVersion to ToVersion
+type BulkBumpRequest struct {
+	FromVersion string `json:"from_version" binding:"required"`
+	ToVersion   string `json:"to_version" binding:"required"`
+}
+
+// BulkBumpResponse reports how each application using the old version was updated
+type BulkBumpResponse struct {
+	DependencyID string           `json:"dependency_id"`
+	Name         string           `json:"name"`
+	FromVersion  string           `json:"from_version"`
+	ToVersion    string           `json:"to_version"`              // the matching tag when GitHub knows one
+	ToCommitSHA  string           `json:"to_commit_sha,omitempty"` // commit of ToVersion, "" when it could not be resolved
+	Applications []BulkBumpResult `json:"applications"`
+	Updated      int              `json:"updated"`
+	Failed       int              `json:"failed"`
+	Message      string           `json:"message"`
+}
+
+// BulkBumpResult is the outcome for one application: updated, conflict or failed
+type BulkBumpResult struct {
+	AppID           string `json:"app_id"`
+	AppName         string `json:"app_name"`
+	PreviousVersion string `json:"previous_version"`
+	Status          string `json:"status"`
+	Error           string `json:"error,omitempty"`
+}
+
+// ManifestDiffResult previews how replacing one manifest with another changes its dependencies and
+// which vulnerabilities the new manifest would introduce or resolve
+type ManifestDiffResult struct {
+	Runtime                   string                     `json:"runtime"`
+	Added                     []ManifestDependencyChange `json:"added"`
+	Removed                   []ManifestDependencyChange `json:"removed"`
+	Changed                   []ManifestDependencyChange `json:"changed"`
+	Unchanged                 int                        `json:"unchanged"`
+	NewVulnerabilities        []ManifestVulnerability    `json:"new_vulnerabilities"`
+	ResolvedVulnerabilities   []ManifestVulnerability    `json:"resolved_vulnerabilities"` // fixed by version changes
+	IntroducesVulnerabilities bool                       `json:"introduces_vulnerabilities"`
+	HighestNewSeverity        string                     `json:"highest_new_severity,omitempty"`
+	ComparedAt                time.Time                  `json:"compared_at"`
+}
+
+// ManifestDependencyChange is a dependency that was added, removed or moved to another version
+type ManifestDependencyChange struct {
+	Name       string `json:"name"`
+	OldVersion string `json:"old_version,omitempty"`
+	NewVersion string `json:"new_version,omitempty"`
+}
+
+// ManifestVulnerability is a vulnerability of one dependency version in a manifest diff
+type ManifestVulnerability struct {
+	ID         string `json:"id"`
+	Dependency string `json:"dependency"`
+	Version    string `json:"version"`
+	Severity   string `json:"severity"`
+	Summary    string `json:"summary,omitempty"`
+}
+
+// RetentionCleanupResult summarizes one run of the retention cleanup job
+type RetentionCleanupResult struct {
+	ScansDeleted     int       `json:"scans_deleted"`
+	ArtifactsDeleted int       `json:"artifacts_deleted"`
+	ArtifactErrors   int       `json:"artifact_errors"`
+	AuditCleaned     bool      `json:"audit_cleaned"`
+	RanAt            time.Time `json:"ran_at"`
+}
+
+// FindingsQuery filters the stored findings of an application; empty fields do not filter
+type FindingsQuery struct {
+	Severity      string `form:"severity"`      // comma-separated severities, e.g. "critical,high"
+	Since         string `form:"since"`         // RFC 3339 time or YYYY-MM-DD date of the oldest scan to include
+	Dependency    string `form:"dependency"`    // case-insensitive substring of the dependency name
+	Vulnerability string `form:"vulnerability"` // vulnerability ID, e.g. GHSA-xxxx-xxxx-xxxx
+	Limit         int    `form:"limit"`
+	Offset        int    `form:"offset"`
+}
+
+// FindingsResponse is one page of an application's findings across its scan history, newest scan first
+type FindingsResponse struct {
+	AppID    string          `json:"app_id"`
+	Findings []FindingRecord `json:"findings"`
+	Total    int64           `json:"total"`
+	Limit    int             `json:"limit"`
+	Offset   int             `json:"offset"`
+}
+
+// FindingRecord is one vulnerability of a dependency as reported by one scan
+type FindingRecord struct {
+	ScanID             string    `json:"scan_id"`
+	ScannedAt          time.Time `json:"scanned_at"`
+	Dependency         string    `json:"dependency"`
+	Version            string    `json:"version"`
+	VulnerabilityID    string    `json:"vulnerability_id"`
+	Severity           string    `json:"severity"` // severity of the dependency's finding in that scan
+	RiskScore          float64   `json:"risk_score,omitempty"`
+	RecommendedVersion string    `json:"recommended_version,omitempty"`
+	// Remediation is the latest remediation tracked for the vulnerability in the dependency, if any
+	Remediation *FindingRemediation `json:"remediation,omitempty"`
+}
+
+// DashboardSummary rolls up the latest completed scan of every application
+type DashboardSummary struct {
+	TotalApplications         int                     `json:"total_applications"`
+	ScannedApplications       int                     `json:"scanned_applications"`
+	FailingApplications       int                     `json:"failing_applications"`
+	Vulnerabilities           DashboardSeverityTotals `json:"vulnerabilities"`
+	TopVulnerableDependencies []DashboardDependency   `json:"top_vulnerable_dependencies"`
+	MostAtRiskApplications    []DashboardApplication  `json:"most_at_risk_applications"`
+	GeneratedAt               time.Time               `json:"generated_at"`
+}
+
+type DashboardSeverityTotals struct {
+	Total    int `json:"total"`
+	Critical int `json:"critical"`
+	High     int `json:"high"`
+	Medium   int `json:"medium"`
+	Low      int `json:"low"`
+}
+
+// DashboardDependency is a vulnerable dependency and how many applications use a vulnerable version of it
+type DashboardDependency struct {
+	Dependency      string `json:"dependency"`
+	Applications    int    `json:"applications"`
+	Vulnerabilities int    `json:"vulnerabilities"`
+	HighestSeverity string `json:"highest_severity"`
+}
+
+// DashboardApplication summarizes the latest scan of one application
+type DashboardApplication struct {
+	AppID        string                  `json:"app_id"`
+	AppName      string                  `json:"app_name"`
+	PolicyStatus string                  `json:"policy_status"`
+	ScanID       string                  `json:"scan_id"`
+	Severities   DashboardSeverityTotals `json:"vulnerabilities"`
+	ScannedAt    time.Time               `json:"scanned_at"`
+}
+
+// DependencyCatalogResponse is one page of the dependency catalog
+type DependencyCatalogResponse struct {
+	Dependencies []DependencyCatalogItem `json:"dependencies"`
+	Total        int64                   `json:"total"`
+	Limit        int                     `json:"limit"`
+	Offset       int                     `json:"offset"`
+}
+
+// DependencyMetadataResponse is a dependency's GitHub metadata after a refresh
+type DependencyMetadataResponse struct {
+	DependencyID  string     `json:"dependency_id"`
+	Name          string     `json:"name"`
+	Owner         string     `json:"owner"`
+	Repo          string     `json:"repo"`
+	RepositoryURL *string    `json:"repository_url,omitempty"`
+	DefaultBranch *string    `json:"default_branch,omitempty"`
+	LastCommitSHA *string    `json:"last_commit_sha,omitempty"`
+	LastCommitAt  *time.Time `json:"last_commit_at,omitempty"`
+	LastTag       *string    `json:"last_tag,omitempty"`
+	CommitChanged bool       `json:"commit_changed"` // the latest commit differs from the one stored before the refresh
+	RefreshedAt   time.Time  `json:"refreshed_at"`
+}
+
+// DependencyMaintenanceResponse is the stored repository signals of a dependency and the maintenance risk judged on them
+type DependencyMaintenanceResponse struct {
+	DependencyID        string     `json:"dependency_id"`
+	Name                string     `json:"name"`
+	Owner               string     `json:"owner"`
+	Repo                string     `json:"repo"`
+	Archived            *bool      `json:"archived"`
+	Stars               *int       `json:"stars"`
+	Collaborators       *int       `json:"collaborators"` // only known when the GitHub token may list them
+	LastCommitAt        *time.Time `json:"last_commit_at"`
+	DaysSinceLastCommit *int       `json:"days_since_last_commit"`
+	CheckedAt           *time.Time `json:"checked_at"` // when the signals were last fetched, nil if never
+	Risk                string     `json:"risk"`       // high, medium, low or unknown
+	Reasons             []string   `json:"reasons"`
+}
+
+// DependencyChangelogResponse lists what changed in a dependency between two of its tags
+type DependencyChangelogResponse struct {
+	DependencyID string             `json:"dependency_id"`
+	Name         string             `json:"name"`
+	Owner        string             `json:"owner"`
+	Repo         string             `json:"repo"`
+	From         string             `json:"from"` // the tags matching the requested versions
+	To           string             `json:"to"`
+	Status       string             `json:"status"` // ahead, behind, diverged or identical, as reported by GitHub
+	TotalCommits int                `json:"total_commits"`
+	CompareURL   string             `json:"compare_url,omitempty"`
+	Commits      []ChangelogCommit  `json:"commits"`
+	Releases     []ChangelogRelease `json:"releases"`
+}
+
+// ChangelogCommit is one commit between the two tags of a changelog
+type ChangelogCommit struct {
+	SHA     string `json:"sha"`
+	Message string `json:"message"`
+	Author  string `json:"author,omitempty"`
+	Date    string `json:"date,omitempty"`
+	URL     string `json:"url,omitempty"`
+}
+
+// ChangelogRelease is the notes of a GitHub release published for a tag between the two tags of a changelog
+type ChangelogRelease struct {
+	Tag         string `json:"tag"`
+	Name        string `json:"name,omitempty"`
+	Body        string `json:"body"`
+	URL         string `json:"url,omitempty"`
+	Prerelease  bool   `json:"prerelease"`
+	PublishedAt string `json:"published_at,omitempty"`
+}
+
+// DependencyCatalogItem is a known dependency and how many applications use it
+type DependencyCatalogItem struct {
+	DependencyID     string     `json:"dependency_id"`
+	Name             string     `json:"name"`
+	Owner            string     `json:"owner"`
+	Repo             string     `json:"repo"`
+	RepositoryURL    *string    `json:"repository_url,omitempty"`
+	LastTag          *string    `json:"last_tag,omitempty"`
+	LastTagAt        *time.Time `json:"last_tag_at,omitempty"`
+	ApplicationCount int        `json:"application_count"`
 }
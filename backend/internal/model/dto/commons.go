@@ -13,6 +13,12 @@ type BasicRepositories struct {
 	RunTimeRepository          repository.RuntimeRepository
 	FrameWorkRepository        repository.FrameworkRepository
 	AuditTrailRepository       repository.AuditTrailRepository
+	ScanResultRepository       repository.ScanResultRepository
+	TagRepository              repository.TagRepository
+	ScanScheduleRepository     repository.ScanScheduleRepository
+	RemediationRepository      repository.RemediationRepository
+	SeverityOverrideRepository repository.SeverityOverrideRepository
+	UnitOfWork                 repository.UnitOfWork
 }
 
 // BasicServices groups all service interfaces needed for basic operations
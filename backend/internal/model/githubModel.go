@@ -61,6 +61,10 @@ type CommitSummary struct {
 	URL         string `json:"url"`
 	HTMLURL     string `json:"html_url"`
 	CommentsURL string `json:"comments_url"`
+	Commit      struct {
+		Message string       `json:"message"`
+		Author  CommitPerson `json:"author"`
+	} `json:"commit"`
 }
 
 // CompareFileChange represents a file changed in the compare result.
@@ -72,3 +76,14 @@ type CompareFileChange struct {
 	Changes   int    `json:"changes"`
 	Patch     string `json:"patch"`
 }
+
+// Release represents a GitHub release and its notes.
+type Release struct {
+	TagName     string `json:"tag_name"`
+	Name        string `json:"name"`
+	Body        string `json:"body"`
+	HTMLURL     string `json:"html_url"`
+	Draft       bool   `json:"draft"`
+	Prerelease  bool   `json:"prerelease"`
+	PublishedAt string `json:"published_at"`
+}
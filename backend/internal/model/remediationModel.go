@@ -0,0 +1,47 @@
+package model
+
+import "time"
+
+// CreateRemediationRequest starts tracking the fix of one vulnerability of a dependency in an application
+type CreateRemediationRequest struct {
+	Dependency        string `json:"dependency" binding:"required"`       // dependency name as reported by scans
+	VulnerabilityID   string `json:"vulnerability_id" binding:"required"` // e.g. GHSA-xxxx-xxxx-xxxx or CVE-2024-1234
+	Status            string `json:"status"`                              // open (default), in_progress or resolved
+	ExternalTicketURL string `json:"external_ticket_url"`                 // http(s) link to the ticket, e.g. in Jira
+	Notes             string `json:"notes"`
+}
+
+// UpdateRemediationRequest changes a remediation; omitted fields are kept
+type UpdateRemediationRequest struct {
+	Status            *string `json:"status"`
+	ExternalTicketURL *string `json:"external_ticket_url"`
+	Notes             *string `json:"notes"`
+}
+
+// RemediationResponse is a tracked remediation of a finding
+type RemediationResponse struct {
+	ID                string     `json:"id"`
+	AppID             string     `json:"app_id"`
+	Dependency        string     `json:"dependency"`
+	VulnerabilityID   string     `json:"vulnerability_id"`
+	Status            string     `json:"status"`
+	ExternalTicketURL string     `json:"external_ticket_url,omitempty"`
+	Notes             string     `json:"notes,omitempty"`
+	ResolvedAt        *time.Time `json:"resolved_at,omitempty"`
+	// ResolvedByScanID is the scan that no longer reported the vulnerability, when a scan resolved it
+	ResolvedByScanID string    `json:"resolved_by_scan_id,omitempty"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// ListRemediationsResponse lists the remediations of an application, oldest first
+type ListRemediationsResponse struct {
+	Remediations []RemediationResponse `json:"remediations"`
+}
+
+// FindingRemediation is the remediation tracked for a finding's vulnerability
+type FindingRemediation struct {
+	ID                string `json:"id"`
+	Status            string `json:"status"`
+	ExternalTicketURL string `json:"external_ticket_url,omitempty"`
+}
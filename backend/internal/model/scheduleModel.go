@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+// CreateScanScheduleRequest schedules scans of an application with a cron expression evaluated in UTC
+type CreateScanScheduleRequest struct {
+	Cron    string `json:"cron" binding:"required"` // e.g. "0 2 * * MON" for Mondays at 02:00 UTC
+	Enabled *bool  `json:"enabled"`                 // defaults to true
+}
+
+// UpdateScanScheduleRequest changes a schedule; omitted fields are kept
+type UpdateScanScheduleRequest struct {
+	Cron    *string `json:"cron"`
+	Enabled *bool   `json:"enabled"`
+}
+
+// ScanScheduleResponse is a scan schedule with the outcome of its last run
+type ScanScheduleResponse struct {
+	ID        string     `json:"id"`
+	AppID     string     `json:"app_id"`
+	Cron      string     `json:"cron"`
+	Enabled   bool       `json:"enabled"`
+	NextRunAt *time.Time `json:"next_run_at,omitempty"` // absent while the schedule is disabled
+	LastRunAt *time.Time `json:"last_run_at,omitempty"`
+	// LastScanID is the stored scan of the last run, retrievable through GET /api/scans/:scan_id
+	LastScanID string    `json:"last_scan_id,omitempty"`
+	LastError  string    `json:"last_error,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ListScanSchedulesResponse lists the schedules of an application
+type ListScanSchedulesResponse struct {
+	Schedules []ScanScheduleResponse `json:"schedules"`
+}
@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+// SeedResult reports which seed runtimes and frameworks a seed run created and which were already present
+type SeedResult struct {
+	CreatedRuntimes    []string  `json:"created_runtimes"`
+	ExistingRuntimes   []string  `json:"existing_runtimes"`
+	CreatedFrameworks  []string  `json:"created_frameworks"`
+	LinkedFrameworks   []string  `json:"linked_frameworks"` // existing frameworks now associated with more of their runtimes
+	ExistingFrameworks []string  `json:"existing_frameworks"`
+	SeededAt           time.Time `json:"seeded_at"`
+}
+
+// SeedStatusResponse lists the runtimes and frameworks in the database and the seed entries missing from it
+type SeedStatusResponse struct {
+	Runtimes             []SeedRuntimeStatus `json:"runtimes"`
+	UnassignedFrameworks []string            `json:"unassigned_frameworks"` // frameworks without a known runtime
+	MissingRuntimes      []string            `json:"missing_runtimes"`
+	MissingFrameworks    []string            `json:"missing_frameworks"`
+}
+
+// SeedRuntimeStatus is a runtime and the names of its frameworks
+type SeedRuntimeStatus struct {
+	ID         int      `json:"id"`
+	Name       string   `json:"name"`
+	Frameworks []string `json:"frameworks"`
+}
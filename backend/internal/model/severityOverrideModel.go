@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+// CreateSeverityOverrideRequest sets the severity of the vulnerabilities matching every given criterion. At
+// least one of CWE, CVEPattern and Ecosystem is required. Without AppID the override applies to all of the
+// caller's applications and uploaded manifests.
+type CreateSeverityOverrideRequest struct {
+	AppID      string `json:"app_id"`
+	CWE        string `json:"cwe"`                         // e.g. CWE-94
+	CVEPattern string `json:"cve_pattern"`                 // glob matched against vulnerability IDs and aliases, e.g. CVE-2024-*
+	Ecosystem  string `json:"ecosystem"`                   // OSV ecosystem, e.g. npm
+	Severity   string `json:"severity" binding:"required"` // critical, high, medium or low
+	Reason     string `json:"reason"`
+}
+
+// SeverityOverrideResponse is a stored severity override
+type SeverityOverrideResponse struct {
+	ID         string    `json:"id"`
+	AppID      string    `json:"app_id,omitempty"` // empty when the override applies to every application
+	CWE        string    `json:"cwe,omitempty"`
+	CVEPattern string    `json:"cve_pattern,omitempty"`
+	Ecosystem  string    `json:"ecosystem,omitempty"`
+	Severity   string    `json:"severity"`
+	Reason     string    `json:"reason,omitempty"`
+	CreatedBy  string    `json:"created_by"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ListSeverityOverridesResponse lists severity overrides in the order scans apply them: those of the
+// application first, then those of every application, oldest first. The first match decides a severity.
+type ListSeverityOverridesResponse struct {
+	Overrides []SeverityOverrideResponse `json:"overrides"`
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"elang-backend/internal/entity"
+	"errors"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -16,15 +17,20 @@ func NewAppRepository(db *gorm.DB) ApplicationRepository {
 	return &appRepository{db: db}
 }
 
+// scoped returns the database handle for ctx with the owner filter applied
+func (r *appRepository) scoped(ctx context.Context) *gorm.DB {
+	return scopeToOwner(ctx, dbFromContext(ctx, r.db))
+}
+
 func (r *appRepository) Create(ctx context.Context, app *entity.App) error {
-	return r.db.WithContext(ctx).Create(app).Error
+	return dbFromContext(ctx, r.db).Create(app).Error
 }
 
 func (r *appRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.App, error) {
 	var app entity.App
-	err := r.db.WithContext(ctx).First(&app, "id = ?", id).Error
-	if err == gorm.ErrRecordNotFound {
-		return nil, nil
+	err := r.scoped(ctx).First(&app, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotFound
 	}
 	if err != nil {
 		return nil, err
@@ -34,21 +40,21 @@ func (r *appRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.App,
 
 func (r *appRepository) GetAll(ctx context.Context) ([]*entity.App, error) {
 	var result []*entity.App
-	err := r.db.WithContext(ctx).Find(&result).Error
+	err := r.scoped(ctx).Find(&result).Error
 	return result, err
 }
 
 func (r *appRepository) Update(ctx context.Context, app *entity.App) error {
-	return r.db.WithContext(ctx).Save(app).Error
+	return dbFromContext(ctx, r.db).Save(app).Error
 }
 
 func (r *appRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&entity.App{}, "id = ?", id).Error
+	return r.scoped(ctx).Delete(&entity.App{}, "id = ?", id).Error
 }
 
 func (r *appRepository) GetByName(ctx context.Context, name string) (*entity.App, error) {
 	var app entity.App
-	err := r.db.WithContext(ctx).Where("name = ?", name).First(&app).Error
+	err := r.scoped(ctx).Where("name = ?", name).First(&app).Error
 	if err == gorm.ErrRecordNotFound {
 		return nil, nil
 	}
@@ -60,11 +66,25 @@ func (r *appRepository) GetByName(ctx context.Context, name string) (*entity.App
 
 func (r *appRepository) GetByStatus(ctx context.Context, status string) ([]*entity.App, error) {
 	var result []*entity.App
-	err := r.db.WithContext(ctx).Where("status = ?", status).Find(&result).Error
+	err := r.scoped(ctx).Where("status = ?", status).Find(&result).Error
+	return result, err
+}
+
+// GetByTags returns the applications carrying every one of the given tags
+func (r *appRepository) GetByTags(ctx context.Context, tags []string) ([]*entity.App, error) {
+	tagged := dbFromContext(ctx, r.db).Model(&entity.AppTag{}).
+		Select("app_tags.app_id").
+		Joins("JOIN tag ON tag.id = app_tags.tag_id").
+		Where("tag.name IN ?", tags).
+		Group("app_tags.app_id").
+		Having("COUNT(DISTINCT tag.id) = ?", len(tags))
+
+	var result []*entity.App
+	err := r.scoped(ctx).Where("id IN (?)", tagged).Find(&result).Error
 	return result, err
 }
 
 // UpdateStatus updates only the status field of an app by ID.
 func (r *appRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status string) error {
-	return r.db.WithContext(ctx).Model(&entity.App{}).Where("id = ?", id).Update("status", status).Error
+	return r.scoped(ctx).Model(&entity.App{}).Where("id = ?", id).Update("status", status).Error
 }
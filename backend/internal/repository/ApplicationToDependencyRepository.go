@@ -3,6 +3,8 @@ package repository
 import (
 	"context"
 	"elang-backend/internal/entity"
+	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -17,14 +19,14 @@ func NewAppDependencyRepository(db *gorm.DB) AppDependencyRepository {
 }
 
 func (r *appDependencyRepository) Create(ctx context.Context, appDep *entity.AppDependency) error {
-	return r.db.WithContext(ctx).Create(appDep).Error
+	return dbFromContext(ctx, r.db).Create(appDep).Error
 }
 
 func (r *appDependencyRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.AppDependency, error) {
 	var appDep entity.AppDependency
-	err := r.db.WithContext(ctx).First(&appDep, "id = ?", id).Error
-	if err == gorm.ErrRecordNotFound {
-		return nil, nil
+	err := dbFromContext(ctx, r.db).First(&appDep, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotFound
 	}
 	if err != nil {
 		return nil, err
@@ -34,34 +36,49 @@ func (r *appDependencyRepository) GetByID(ctx context.Context, id uuid.UUID) (*e
 
 func (r *appDependencyRepository) GetByAppID(ctx context.Context, appID uuid.UUID) ([]*entity.AppDependency, error) {
 	var result []*entity.AppDependency
-	err := r.db.WithContext(ctx).Where("app_id = ?", appID).Find(&result).Error
+	err := dbFromContext(ctx, r.db).Where("app_id = ?", appID).Find(&result).Error
 	return result, err
 }
 
 func (r *appDependencyRepository) GetByDependencyID(ctx context.Context, depID uuid.UUID) ([]*entity.AppDependency, error) {
 	var result []*entity.AppDependency
-	err := r.db.WithContext(ctx).Where("dependency_id = ?", depID).Find(&result).Error
+	err := dbFromContext(ctx, r.db).Where("dependency_id = ?", depID).Find(&result).Error
 	return result, err
 }
 
 func (r *appDependencyRepository) Update(ctx context.Context, appDep *entity.AppDependency) error {
-	return r.db.WithContext(ctx).Save(appDep).Error
+	return dbFromContext(ctx, r.db).Save(appDep).Error
+}
+
+// UpdateIfUnchanged saves appDep only if its stored updated_at still equals expectedUpdatedAt.
+// It reports false, without writing, when another writer changed the row first.
+func (r *appDependencyRepository) UpdateIfUnchanged(ctx context.Context, appDep *entity.AppDependency, expectedUpdatedAt time.Time) (bool, error) {
+	result := dbFromContext(ctx, r.db).
+		Model(appDep).
+		Where("updated_at = ?", expectedUpdatedAt).
+		Select("*").
+		Omit("created_at").
+		Updates(appDep)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
 }
 
 func (r *appDependencyRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&entity.AppDependency{}, "id = ?", id).Error
+	return dbFromContext(ctx, r.db).Delete(&entity.AppDependency{}, "id = ?", id).Error
 }
 
 func (r *appDependencyRepository) GetByStatus(ctx context.Context, status string) ([]*entity.AppDependency, error) {
 	var result []*entity.AppDependency
-	err := r.db.WithContext(ctx).Where("status = ?", status).Find(&result).Error
+	err := dbFromContext(ctx, r.db).Where("status = ?", status).Find(&result).Error
 	return result, err
 }
 
 // GetByAppAndDependencyID fetches the AppDependency by app and dependency IDs
 func (r *appDependencyRepository) GetByAppAndDependencyID(ctx context.Context, appID, depID uuid.UUID) (*entity.AppDependency, error) {
 	var appDep entity.AppDependency
-	err := r.db.WithContext(ctx).Where("app_id = ? AND dependency_id = ?", appID, depID).First(&appDep).Error
+	err := dbFromContext(ctx, r.db).Where("app_id = ? AND dependency_id = ?", appID, depID).First(&appDep).Error
 	if err == gorm.ErrRecordNotFound {
 		return nil, nil
 	}
@@ -20,7 +20,7 @@ func NewAuditTrailRepository(db *gorm.DB) AuditTrailRepository {
 }
 
 func (r *auditTrailRepository) Create(ctx context.Context, audit *entity.AuditTrail) error {
-	return r.db.WithContext(ctx).Create(audit).Error
+	return dbFromContext(ctx, r.db).Create(audit).Error
 }
 
 func (r *auditTrailRepository) LogAction(ctx context.Context, entityType string, entityID uuid.UUID, action string, oldValues, newValues interface{}, performedBy string) error {
@@ -49,7 +49,7 @@ func (r *auditTrailRepository) LogAction(ctx context.Context, entityType string,
 		OldValues:        oldValuesBytes,
 		NewValues:        newValuesBytes,
 		PerformedBy:      performedBy,
-		PerformedAt:      time.Now(),
+		PerformedAt:      time.Now().UTC(),
 		SecurityRelevant: false,
 	}
 	return r.Create(ctx, audit)
@@ -73,7 +73,7 @@ func (r *auditTrailRepository) LogSecurityEvent(ctx context.Context, entityType
 		Action:           action,
 		Context:          contextBytes,
 		PerformedBy:      performedBy,
-		PerformedAt:      time.Now(),
+		PerformedAt:      time.Now().UTC(),
 		SecurityRelevant: true,
 		RiskLevel:        &riskLevel,
 	}
@@ -82,7 +82,7 @@ func (r *auditTrailRepository) LogSecurityEvent(ctx context.Context, entityType
 
 func (r *auditTrailRepository) GetByEntity(ctx context.Context, entityType string, entityID uuid.UUID, limit, offset int) ([]*entity.AuditTrail, error) {
 	var audits []*entity.AuditTrail
-	query := r.db.WithContext(ctx).Where("entity_type = ? AND entity_id = ?", entityType, entityID).Order("performed_at DESC")
+	query := dbFromContext(ctx, r.db).Where("entity_type = ? AND entity_id = ?", entityType, entityID).Order("performed_at DESC")
 	if limit > 0 {
 		query = query.Limit(limit)
 	}
@@ -95,7 +95,7 @@ func (r *auditTrailRepository) GetByEntity(ctx context.Context, entityType strin
 
 func (r *auditTrailRepository) GetSecurityEvents(ctx context.Context, limit, offset int) ([]*entity.AuditTrail, error) {
 	var audits []*entity.AuditTrail
-	query := r.db.WithContext(ctx).Where("security_relevant = true").Order("performed_at DESC")
+	query := dbFromContext(ctx, r.db).Where("security_relevant = true").Order("performed_at DESC")
 	if limit > 0 {
 		query = query.Limit(limit)
 	}
@@ -108,7 +108,7 @@ func (r *auditTrailRepository) GetSecurityEvents(ctx context.Context, limit, off
 
 func (r *auditTrailRepository) GetByTimeRange(ctx context.Context, startTime, endTime time.Time, limit, offset int) ([]*entity.AuditTrail, error) {
 	var audits []*entity.AuditTrail
-	query := r.db.WithContext(ctx).Where("performed_at BETWEEN ? AND ?", startTime, endTime).Order("performed_at DESC")
+	query := dbFromContext(ctx, r.db).Where("performed_at BETWEEN ? AND ?", startTime, endTime).Order("performed_at DESC")
 	if limit > 0 {
 		query = query.Limit(limit)
 	}
@@ -120,5 +120,5 @@ func (r *auditTrailRepository) GetByTimeRange(ctx context.Context, startTime, en
 }
 
 func (r *auditTrailRepository) CleanupOldRecords(ctx context.Context, olderThan time.Time) error {
-	return r.db.WithContext(ctx).Where("performed_at < ? AND security_relevant = false", olderThan).Delete(&entity.AuditTrail{}).Error
+	return dbFromContext(ctx, r.db).Where("performed_at < ? AND security_relevant = false", olderThan).Delete(&entity.AuditTrail{}).Error
 }
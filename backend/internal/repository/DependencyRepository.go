@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"elang-backend/internal/entity"
+	"errors"
 	"strings"
 
 	"github.com/google/uuid"
@@ -18,14 +19,14 @@ func NewDependencyRepository(db *gorm.DB) DependencyRepository {
 }
 
 func (r *dependencyRepository) Create(ctx context.Context, dep *entity.Dependency) error {
-	return r.db.WithContext(ctx).Create(dep).Error
+	return dbFromContext(ctx, r.db).Create(dep).Error
 }
 
 func (r *dependencyRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Dependency, error) {
 	var dep entity.Dependency
-	err := r.db.WithContext(ctx).First(&dep, "id = ?", id).Error
-	if err == gorm.ErrRecordNotFound {
-		return nil, nil
+	err := dbFromContext(ctx, r.db).First(&dep, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotFound
 	}
 	if err != nil {
 		return nil, err
@@ -35,7 +36,7 @@ func (r *dependencyRepository) GetByID(ctx context.Context, id uuid.UUID) (*enti
 
 func (r *dependencyRepository) GetByOwnerRepo(ctx context.Context, owner, repo string) (*entity.Dependency, error) {
 	var dep entity.Dependency
-	err := r.db.WithContext(ctx).Where("owner = ? AND repo = ?", owner, repo).First(&dep).Error
+	err := dbFromContext(ctx, r.db).Where("owner = ? AND repo = ?", owner, repo).First(&dep).Error
 	if err == gorm.ErrRecordNotFound {
 		return nil, nil
 	}
@@ -47,7 +48,7 @@ func (r *dependencyRepository) GetByOwnerRepo(ctx context.Context, owner, repo s
 
 func (r *dependencyRepository) GetByOwnerRepoCI(ctx context.Context, owner, repo string) (*entity.Dependency, error) {
 	var dep entity.Dependency
-	err := r.db.WithContext(ctx).
+	err := dbFromContext(ctx, r.db).
 		Where("LOWER(owner) = ? AND LOWER(repo) = ?", strings.ToLower(owner), strings.ToLower(repo)).
 		First(&dep).Error
 	if err == gorm.ErrRecordNotFound {
@@ -61,38 +62,83 @@ func (r *dependencyRepository) GetByOwnerRepoCI(ctx context.Context, owner, repo
 
 func (r *dependencyRepository) GetAll(ctx context.Context) ([]*entity.Dependency, error) {
 	var result []*entity.Dependency
-	err := r.db.WithContext(ctx).Find(&result).Error
+	err := dbFromContext(ctx, r.db).Find(&result).Error
 	return result, err
 }
 
 func (r *dependencyRepository) Update(ctx context.Context, dep *entity.Dependency) error {
-	return r.db.WithContext(ctx).Save(dep).Error
+	return dbFromContext(ctx, r.db).Save(dep).Error
 }
 
 func (r *dependencyRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&entity.Dependency{}, "id = ?", id).Error
+	return dbFromContext(ctx, r.db).Delete(&entity.Dependency{}, "id = ?", id).Error
 }
 
 func (r *dependencyRepository) SearchByName(ctx context.Context, name string) ([]*entity.Dependency, error) {
 	var result []*entity.Dependency
+	err := dbFromContext(ctx, r.db).Where(r.nameLikeClause("name"), containsPattern(name)).Find(&result).Error
+	return result, err
+}
 
-	// Use database-agnostic LIKE operation
-	// SQLite uses LIKE (case-insensitive by default), PostgreSQL uses ILIKE
-	dialectName := r.db.Dialector.Name()
-	var query string
-	if dialectName == "postgres" {
-		query = "name ILIKE ?"
-	} else {
-		query = "name LIKE ?"
+// nameLikeClause returns a case-insensitive, database-agnostic LIKE condition on column, for a pattern built
+// by containsPattern: SQLite uses LIKE (case-insensitive by default), PostgreSQL uses ILIKE
+func (r *dependencyRepository) nameLikeClause(column string) string {
+	if r.db.Dialector.Name() == "postgres" {
+		return column + ` ILIKE ? ESCAPE '\'`
 	}
+	return column + ` LIKE ? ESCAPE '\'`
+}
 
-	err := r.db.WithContext(ctx).Where(query, "%"+name+"%").Find(&result).Error
-	return result, err
+// likeEscaper escapes the LIKE wildcards, and the escape character itself, of user input
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// containsPattern is the LIKE pattern matching values that contain s literally
+func containsPattern(s string) string {
+	return "%" + likeEscaper.Replace(s) + "%"
+}
+
+func (r *dependencyRepository) SearchPage(ctx context.Context, name string, limit, offset int) ([]*DependencyUsage, int64, error) {
+	db := dbFromContext(ctx, r.db)
+	usage := scopeToOwner(ctx, db.Table("app_dependencies").
+		Select("app_dependencies.dependency_id, COUNT(DISTINCT app_dependencies.app_id) AS app_count").
+		Joins("JOIN app ON app.id = app_dependencies.app_id").
+		Where("app.is_deleted = ?", false)).
+		Group("app_dependencies.dependency_id")
+
+	// An owner only sees the dependencies their own applications use
+	join := "LEFT JOIN"
+	if _, ok := OwnerScope(ctx); ok {
+		join = "JOIN"
+	}
+	query := db.Model(&entity.Dependency{}).
+		Joins(join+" (?) AS app_usage ON app_usage.dependency_id = dependencies.id", usage)
+	if name != "" {
+		query = query.Where(r.nameLikeClause("dependencies.name"), containsPattern(name))
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	query = query.
+		Select("dependencies.*, COALESCE(app_usage.app_count, 0) AS app_count").
+		Order("LOWER(dependencies.name), dependencies.id")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+
+	var result []*DependencyUsage
+	err := query.Scan(&result).Error
+	return result, total, err
 }
 
 func (r *dependencyRepository) GetByNameCI(ctx context.Context, name string) (*entity.Dependency, error) {
 	var dep entity.Dependency
-	err := r.db.WithContext(ctx).Where("LOWER(name) = ?", strings.ToLower(name)).First(&dep).Error
+	err := dbFromContext(ctx, r.db).Where("LOWER(name) = ?", strings.ToLower(name)).First(&dep).Error
 	if err == gorm.ErrRecordNotFound {
 		return nil, nil
 	}
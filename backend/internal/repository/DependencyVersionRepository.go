@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"elang-backend/internal/entity"
+	"errors"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -17,14 +18,14 @@ func NewDependencyVersionRepository(db *gorm.DB) DependencyVersionRepository {
 }
 
 func (r *dependencyVersionRepository) Create(ctx context.Context, ver *entity.DependencyVersion) error {
-	return r.db.WithContext(ctx).Create(ver).Error
+	return dbFromContext(ctx, r.db).Create(ver).Error
 }
 
 func (r *dependencyVersionRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.DependencyVersion, error) {
 	var ver entity.DependencyVersion
-	err := r.db.WithContext(ctx).First(&ver, "id = ?", id).Error
-	if err == gorm.ErrRecordNotFound {
-		return nil, nil
+	err := dbFromContext(ctx, r.db).First(&ver, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotFound
 	}
 	if err != nil {
 		return nil, err
@@ -34,20 +35,20 @@ func (r *dependencyVersionRepository) GetByID(ctx context.Context, id uuid.UUID)
 
 func (r *dependencyVersionRepository) GetByDependencyID(ctx context.Context, depID uuid.UUID) ([]*entity.DependencyVersion, error) {
 	var result []*entity.DependencyVersion
-	err := r.db.WithContext(ctx).Where("dependency_id = ?", depID).Order("commit_at DESC").Find(&result).Error
+	err := dbFromContext(ctx, r.db).Where("dependency_id = ?", depID).Order("commit_at DESC").Find(&result).Error
 	return result, err
 }
 
 func (r *dependencyVersionRepository) Update(ctx context.Context, ver *entity.DependencyVersion) error {
-	return r.db.WithContext(ctx).Save(ver).Error
+	return dbFromContext(ctx, r.db).Save(ver).Error
 }
 
 func (r *dependencyVersionRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&entity.DependencyVersion{}, "id = ?", id).Error
+	return dbFromContext(ctx, r.db).Delete(&entity.DependencyVersion{}, "id = ?", id).Error
 }
 
 func (r *dependencyVersionRepository) GetByTag(ctx context.Context, tag string) ([]*entity.DependencyVersion, error) {
 	var result []*entity.DependencyVersion
-	err := r.db.WithContext(ctx).Where("tag = ?", tag).Find(&result).Error
+	err := dbFromContext(ctx, r.db).Where("tag = ?", tag).Find(&result).Error
 	return result, err
 }
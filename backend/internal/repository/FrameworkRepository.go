@@ -3,9 +3,11 @@ package repository
 import (
 	"context"
 	"elang-backend/internal/entity"
+	"errors"
 	"strings"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type frameworkRepository struct {
@@ -17,14 +19,14 @@ func NewFrameworkRepository(db *gorm.DB) FrameworkRepository {
 }
 
 func (r *frameworkRepository) Create(ctx context.Context, framework *entity.Framework) error {
-	return r.db.WithContext(ctx).Create(framework).Error
+	return dbFromContext(ctx, r.db).Create(framework).Error
 }
 
 func (r *frameworkRepository) GetByID(ctx context.Context, id int) (*entity.Framework, error) {
 	var fw entity.Framework
-	err := r.db.WithContext(ctx).First(&fw, "id = ?", id).Error
-	if err == gorm.ErrRecordNotFound {
-		return nil, nil
+	err := dbFromContext(ctx, r.db).Preload("Runtimes").First(&fw, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotFound
 	}
 	if err != nil {
 		return nil, err
@@ -34,21 +36,21 @@ func (r *frameworkRepository) GetByID(ctx context.Context, id int) (*entity.Fram
 
 func (r *frameworkRepository) GetAll(ctx context.Context) ([]*entity.Framework, error) {
 	var result []*entity.Framework
-	err := r.db.WithContext(ctx).Find(&result).Error
+	err := dbFromContext(ctx, r.db).Preload("Runtimes").Find(&result).Error
 	return result, err
 }
 
 func (r *frameworkRepository) Update(ctx context.Context, framework *entity.Framework) error {
-	return r.db.WithContext(ctx).Save(framework).Error
+	return dbFromContext(ctx, r.db).Save(framework).Error
 }
 
 func (r *frameworkRepository) Delete(ctx context.Context, id int) error {
-	return r.db.WithContext(ctx).Delete(&entity.Framework{}, "id = ?", id).Error
+	return dbFromContext(ctx, r.db).Delete(&entity.Framework{}, "id = ?", id).Error
 }
 
 func (r *frameworkRepository) GetByName(ctx context.Context, name string) (*entity.Framework, error) {
 	var fw entity.Framework
-	err := r.db.WithContext(ctx).Where("name = ?", name).First(&fw).Error
+	err := dbFromContext(ctx, r.db).Preload("Runtimes").Where("name = ?", name).First(&fw).Error
 	if err == gorm.ErrRecordNotFound {
 		return nil, nil
 	}
@@ -60,7 +62,7 @@ func (r *frameworkRepository) GetByName(ctx context.Context, name string) (*enti
 
 func (r *frameworkRepository) GetByNameCI(ctx context.Context, name string) (*entity.Framework, error) {
 	var fw entity.Framework
-	err := r.db.WithContext(ctx).Where("LOWER(name) = ?", strings.ToLower(name)).First(&fw).Error
+	err := dbFromContext(ctx, r.db).Preload("Runtimes").Where("LOWER(name) = ?", strings.ToLower(name)).First(&fw).Error
 	if err == gorm.ErrRecordNotFound {
 		return nil, nil
 	}
@@ -69,3 +71,18 @@ func (r *frameworkRepository) GetByNameCI(ctx context.Context, name string) (*en
 	}
 	return &fw, nil
 }
+
+// GetByRuntimeID returns the frameworks associated with a runtime, ordered by name
+func (r *frameworkRepository) GetByRuntimeID(ctx context.Context, runtimeID int) ([]*entity.Framework, error) {
+	var result []*entity.Framework
+	err := dbFromContext(ctx, r.db).
+		Joins("JOIN framework_runtime ON framework_runtime.framework_id = framework.id").
+		Where("framework_runtime.runtime_id = ?", runtimeID).Order("framework.name").Find(&result).Error
+	return result, err
+}
+
+// LinkRuntime associates a framework with one more runtime; linking it again changes nothing
+func (r *frameworkRepository) LinkRuntime(ctx context.Context, frameworkID, runtimeID int) error {
+	return dbFromContext(ctx, r.db).Table("framework_runtime").Clauses(clause.OnConflict{DoNothing: true}).
+		Create(map[string]interface{}{"framework_id": frameworkID, "runtime_id": runtimeID}).Error
+}
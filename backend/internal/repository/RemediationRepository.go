@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type remediationRepository struct {
+	db *gorm.DB
+}
+
+func NewRemediationRepository(db *gorm.DB) RemediationRepository {
+	return &remediationRepository{db: db}
+}
+
+func (r *remediationRepository) Create(ctx context.Context, remediation *entity.Remediation) error {
+	return dbFromContext(ctx, r.db).Create(remediation).Error
+}
+
+func (r *remediationRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Remediation, error) {
+	var remediation entity.Remediation
+	err := dbFromContext(ctx, r.db).First(&remediation, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &remediation, nil
+}
+
+// GetByAppID returns the remediations of an application, oldest first; with statuses, only those in one of them
+func (r *remediationRepository) GetByAppID(ctx context.Context, appID uuid.UUID, statuses ...string) ([]*entity.Remediation, error) {
+	query := dbFromContext(ctx, r.db).Where("app_id = ?", appID)
+	if len(statuses) > 0 {
+		query = query.Where("status IN ?", statuses)
+	}
+	var remediations []*entity.Remediation
+	err := query.Order("created_at, id").Find(&remediations).Error
+	return remediations, err
+}
+
+func (r *remediationRepository) Update(ctx context.Context, remediation *entity.Remediation) error {
+	return dbFromContext(ctx, r.db).Save(remediation).Error
+}
+
+func (r *remediationRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return dbFromContext(ctx, r.db).Delete(&entity.Remediation{}, "id = ?", id).Error
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"elang-backend/internal/entity"
+	"errors"
 	"strings"
 
 	"gorm.io/gorm"
@@ -17,14 +18,14 @@ func NewRuntimeRepository(db *gorm.DB) RuntimeRepository {
 }
 
 func (r *runtimeRepository) Create(ctx context.Context, runtime *entity.Runtime) error {
-	return r.db.WithContext(ctx).Create(runtime).Error
+	return dbFromContext(ctx, r.db).Create(runtime).Error
 }
 
 func (r *runtimeRepository) GetByID(ctx context.Context, id int) (*entity.Runtime, error) {
 	var rt entity.Runtime
-	err := r.db.WithContext(ctx).First(&rt, "id = ?", id).Error
-	if err == gorm.ErrRecordNotFound {
-		return nil, nil
+	err := dbFromContext(ctx, r.db).First(&rt, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotFound
 	}
 	if err != nil {
 		return nil, err
@@ -34,21 +35,21 @@ func (r *runtimeRepository) GetByID(ctx context.Context, id int) (*entity.Runtim
 
 func (r *runtimeRepository) GetAll(ctx context.Context) ([]*entity.Runtime, error) {
 	var result []*entity.Runtime
-	err := r.db.WithContext(ctx).Find(&result).Error
+	err := dbFromContext(ctx, r.db).Find(&result).Error
 	return result, err
 }
 
 func (r *runtimeRepository) Update(ctx context.Context, runtime *entity.Runtime) error {
-	return r.db.WithContext(ctx).Save(runtime).Error
+	return dbFromContext(ctx, r.db).Save(runtime).Error
 }
 
 func (r *runtimeRepository) Delete(ctx context.Context, id int) error {
-	return r.db.WithContext(ctx).Delete(&entity.Runtime{}, "id = ?", id).Error
+	return dbFromContext(ctx, r.db).Delete(&entity.Runtime{}, "id = ?", id).Error
 }
 
 func (r *runtimeRepository) GetByName(ctx context.Context, name string) (*entity.Runtime, error) {
 	var rt entity.Runtime
-	err := r.db.WithContext(ctx).Where("name = ?", name).First(&rt).Error
+	err := dbFromContext(ctx, r.db).Where("name = ?", name).First(&rt).Error
 	if err == gorm.ErrRecordNotFound {
 		return nil, nil
 	}
@@ -60,7 +61,7 @@ func (r *runtimeRepository) GetByName(ctx context.Context, name string) (*entity
 
 func (r *runtimeRepository) GetByNameCI(ctx context.Context, name string) (*entity.Runtime, error) {
 	var rt entity.Runtime
-	err := r.db.WithContext(ctx).Where("LOWER(name) = ?", strings.ToLower(name)).First(&rt).Error
+	err := dbFromContext(ctx, r.db).Where("LOWER(name) = ?", strings.ToLower(name)).First(&rt).Error
 	if err == gorm.ErrRecordNotFound {
 		return nil, nil
 	}
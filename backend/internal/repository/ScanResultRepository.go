@@ -0,0 +1,238 @@
+package repository
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type scanResultRepository struct {
+	db *gorm.DB
+}
+
+func NewScanResultRepository(db *gorm.DB) ScanResultRepository {
+	return &scanResultRepository{db: db}
+}
+
+func (r *scanResultRepository) Create(ctx context.Context, scan *entity.ScanResult) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(scan).Error; err != nil {
+			return err
+		}
+		return replaceFindings(tx, scan)
+	})
+}
+
+func (r *scanResultRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.ScanResult, error) {
+	var scan entity.ScanResult
+	err := dbFromContext(ctx, r.db).First(&scan, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &scan, nil
+}
+
+// GetByAppID returns the scans of an application, newest first.
+func (r *scanResultRepository) GetByAppID(ctx context.Context, appID uuid.UUID, limit, offset int) ([]*entity.ScanResult, error) {
+	var scans []*entity.ScanResult
+	query := dbFromContext(ctx, r.db).Where("app_id = ?", appID).Order("created_at DESC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+	err := query.Find(&scans).Error
+	return scans, err
+}
+
+func (r *scanResultRepository) Update(ctx context.Context, scan *entity.ScanResult) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(scan).Error; err != nil {
+			return err
+		}
+		return replaceFindings(tx, scan)
+	})
+}
+
+// replaceFindings stores scan.Findings in place of the scan's previous findings; nil leaves them untouched
+func replaceFindings(tx *gorm.DB, scan *entity.ScanResult) error {
+	if scan.Findings == nil {
+		return nil
+	}
+	if err := tx.Where("scan_id = ?", scan.ID).Delete(&entity.Finding{}).Error; err != nil {
+		return err
+	}
+	if len(scan.Findings) == 0 {
+		return nil
+	}
+	for i := range scan.Findings {
+		scan.Findings[i].ScanID = scan.ID
+	}
+	return tx.CreateInBatches(scan.Findings, 500).Error
+}
+
+// QueryFindings returns one page of an application's stored findings matching filter, newest scan first,
+// together with the number of matching findings
+func (r *scanResultRepository) QueryFindings(ctx context.Context, filter FindingFilter) ([]*entity.Finding, int64, error) {
+	query := dbFromContext(ctx, r.db).Model(&entity.Finding{}).Where("app_id = ?", filter.AppID)
+	if filter.ScanID != uuid.Nil {
+		query = query.Where("scan_id = ?", filter.ScanID)
+	}
+	if len(filter.Severities) > 0 {
+		query = query.Where("severity IN ?", filter.Severities)
+	}
+	if filter.Since != nil {
+		query = query.Where("scanned_at >= ?", *filter.Since)
+	}
+	if filter.Dependency != "" {
+		query = query.Where(`LOWER(dependency) LIKE ? ESCAPE '\'`, containsPattern(strings.ToLower(filter.Dependency)))
+	}
+	if filter.VulnerabilityID != "" {
+		query = query.Where("LOWER(vulnerability_id) = ?", strings.ToLower(filter.VulnerabilityID))
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	query = query.Order("scanned_at DESC, dependency, version, vulnerability_id, id")
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+	var findings []*entity.Finding
+	err := query.Find(&findings).Error
+	return findings, total, err
+}
+
+// GetLatestCompletedPerApp returns the newest completed scan of every application that is not deleted.
+// Applications are restricted to the caller's own when ctx carries an owner scope.
+func (r *scanResultRepository) GetLatestCompletedPerApp(ctx context.Context) ([]*entity.ScanResult, error) {
+	db := dbFromContext(ctx, r.db)
+
+	latest := db.Model(&entity.ScanResult{}).
+		Select("app_id, MAX(created_at) AS latest_at").
+		Where("status = ? AND app_id IS NOT NULL", "completed").
+		Group("app_id")
+
+	query := db.Model(&entity.ScanResult{}).
+		Select("scan_result.*").
+		Joins("JOIN (?) AS latest ON latest.app_id = scan_result.app_id AND latest.latest_at = scan_result.created_at", latest).
+		Joins("JOIN app ON app.id = scan_result.app_id AND app.is_deleted = ?", false).
+		Where("scan_result.status = ?", "completed")
+	if ownerID, ok := OwnerScope(ctx); ok {
+		query = query.Where("app.owner_id = ?", ownerID)
+	}
+
+	var scans []*entity.ScanResult
+	if err := query.Order("scan_result.app_id, scan_result.id").Find(&scans).Error; err != nil {
+		return nil, err
+	}
+
+	// Two scans finishing with the same timestamp would both match; keep one per application
+	unique := scans[:0]
+	seen := make(map[uuid.UUID]bool, len(scans))
+	for _, scan := range scans {
+		if seen[*scan.AppID] {
+			continue
+		}
+		seen[*scan.AppID] = true
+		unique = append(unique, scan)
+	}
+	return unique, nil
+}
+
+// GetLatestCompletedByAppID returns the newest completed scan of an application, or ErrNotFound when it has none.
+// The stored result is not loaded.
+func (r *scanResultRepository) GetLatestCompletedByAppID(ctx context.Context, appID uuid.UUID) (*entity.ScanResult, error) {
+	var scan entity.ScanResult
+	err := dbFromContext(ctx, r.db).Omit("result").
+		Where("app_id = ? AND status = ?", appID, "completed").
+		Order("created_at DESC").
+		First(&scan).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &scan, nil
+}
+
+// CleanupOldRecords deletes finished scans created before olderThan and returns the deleted rows
+// so their stored artifacts can be removed. The newest keepPerApp scans of each application are
+// always kept regardless of age; queued and running scans are never deleted.
+func (r *scanResultRepository) CleanupOldRecords(ctx context.Context, olderThan time.Time, keepPerApp int) ([]*entity.ScanResult, error) {
+	db := dbFromContext(ctx, r.db)
+
+	var candidates []*entity.ScanResult
+	err := db.Omit("result").
+		Where("created_at < ? AND status IN ?", olderThan, []string{"completed", "failed"}).
+		Order("created_at DESC").
+		Find(&candidates).Error
+	if err != nil || len(candidates) == 0 {
+		return nil, err
+	}
+
+	// Collect the newest scans of every affected application, which must survive the cleanup
+	keep := make(map[uuid.UUID]bool)
+	if keepPerApp > 0 {
+		checked := make(map[uuid.UUID]bool)
+		for _, scan := range candidates {
+			if scan.AppID == nil || checked[*scan.AppID] {
+				continue
+			}
+			checked[*scan.AppID] = true
+
+			var newestIDs []uuid.UUID
+			err := db.Model(&entity.ScanResult{}).
+				Where("app_id = ?", *scan.AppID).
+				Order("created_at DESC").
+				Limit(keepPerApp).
+				Pluck("id", &newestIDs).Error
+			if err != nil {
+				return nil, err
+			}
+			for _, id := range newestIDs {
+				keep[id] = true
+			}
+		}
+	}
+
+	var (
+		expired []*entity.ScanResult
+		ids     []uuid.UUID
+	)
+	for _, scan := range candidates {
+		if !keep[scan.ID] {
+			expired = append(expired, scan)
+			ids = append(ids, scan.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("scan_id IN ?", ids).Delete(&entity.Finding{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id IN ?", ids).Delete(&entity.ScanResult{}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return expired, nil
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type scanScheduleRepository struct {
+	db *gorm.DB
+}
+
+func NewScanScheduleRepository(db *gorm.DB) ScanScheduleRepository {
+	return &scanScheduleRepository{db: db}
+}
+
+func (r *scanScheduleRepository) Create(ctx context.Context, schedule *entity.ScanSchedule) error {
+	return dbFromContext(ctx, r.db).Create(schedule).Error
+}
+
+func (r *scanScheduleRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.ScanSchedule, error) {
+	var schedule entity.ScanSchedule
+	err := dbFromContext(ctx, r.db).First(&schedule, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+// GetByAppID returns the schedules of an application, oldest first
+func (r *scanScheduleRepository) GetByAppID(ctx context.Context, appID uuid.UUID) ([]*entity.ScanSchedule, error) {
+	var schedules []*entity.ScanSchedule
+	err := dbFromContext(ctx, r.db).Where("app_id = ?", appID).Order("created_at, id").Find(&schedules).Error
+	return schedules, err
+}
+
+func (r *scanScheduleRepository) Update(ctx context.Context, schedule *entity.ScanSchedule) error {
+	return dbFromContext(ctx, r.db).Save(schedule).Error
+}
+
+func (r *scanScheduleRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return dbFromContext(ctx, r.db).Delete(&entity.ScanSchedule{}, "id = ?", id).Error
+}
+
+// GetDue returns the enabled schedules of applications that are not deleted whose next run is at or before
+// now, earliest first
+func (r *scanScheduleRepository) GetDue(ctx context.Context, now time.Time) ([]*entity.ScanSchedule, error) {
+	var schedules []*entity.ScanSchedule
+	err := dbFromContext(ctx, r.db).
+		Joins("JOIN app ON app.id = scan_schedule.app_id AND app.is_deleted = ?", false).
+		Where("scan_schedule.enabled = ? AND scan_schedule.next_run_at <= ?", true, now).
+		Order("scan_schedule.next_run_at, scan_schedule.id").
+		Find(&schedules).Error
+	return schedules, err
+}
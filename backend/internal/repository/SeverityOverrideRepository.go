@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type severityOverrideRepository struct {
+	db *gorm.DB
+}
+
+func NewSeverityOverrideRepository(db *gorm.DB) SeverityOverrideRepository {
+	return &severityOverrideRepository{db: db}
+}
+
+func (r *severityOverrideRepository) Create(ctx context.Context, override *entity.SeverityOverride) error {
+	return dbFromContext(ctx, r.db).Create(override).Error
+}
+
+func (r *severityOverrideRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.SeverityOverride, error) {
+	var override entity.SeverityOverride
+	err := dbFromContext(ctx, r.db).First(&override, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &override, nil
+}
+
+// GetByOwner returns the overrides of an owner that apply to every application, oldest first
+func (r *severityOverrideRepository) GetByOwner(ctx context.Context, ownerID string) ([]*entity.SeverityOverride, error) {
+	var overrides []*entity.SeverityOverride
+	err := dbFromContext(ctx, r.db).Where("owner_id = ? AND app_id IS NULL", ownerID).
+		Order("created_at, id").Find(&overrides).Error
+	return overrides, err
+}
+
+// GetByAppID returns the overrides of one application, oldest first
+func (r *severityOverrideRepository) GetByAppID(ctx context.Context, appID uuid.UUID) ([]*entity.SeverityOverride, error) {
+	var overrides []*entity.SeverityOverride
+	err := dbFromContext(ctx, r.db).Where("app_id = ?", appID).Order("created_at, id").Find(&overrides).Error
+	return overrides, err
+}
+
+func (r *severityOverrideRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return dbFromContext(ctx, r.db).Delete(&entity.SeverityOverride{}, "id = ?", id).Error
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type tagRepository struct {
+	db *gorm.DB
+}
+
+func NewTagRepository(db *gorm.DB) TagRepository {
+	return &tagRepository{db: db}
+}
+
+// GetOrCreate returns the tag with the given name, creating it when it does not exist yet
+func (r *tagRepository) GetOrCreate(ctx context.Context, name string) (*entity.Tag, error) {
+	db := dbFromContext(ctx, r.db)
+	// A concurrent request may create the same tag; the unique name makes the insert a no-op then
+	tag := &entity.Tag{ID: uuid.New(), Name: name}
+	if err := db.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "name"}}, DoNothing: true}).Create(tag).Error; err != nil {
+		return nil, err
+	}
+	var stored entity.Tag
+	if err := db.Where("name = ?", name).First(&stored).Error; err != nil {
+		return nil, err
+	}
+	return &stored, nil
+}
+
+func (r *tagRepository) GetByName(ctx context.Context, name string) (*entity.Tag, error) {
+	var tag entity.Tag
+	err := dbFromContext(ctx, r.db).Where("name = ?", name).First(&tag).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// AddToApp attaches a tag to an application; attaching it twice is not an error
+func (r *tagRepository) AddToApp(ctx context.Context, appID, tagID uuid.UUID) error {
+	return dbFromContext(ctx, r.db).Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&entity.AppTag{AppID: appID, TagID: tagID}).Error
+}
+
+func (r *tagRepository) RemoveFromApp(ctx context.Context, appID, tagID uuid.UUID) error {
+	return dbFromContext(ctx, r.db).Delete(&entity.AppTag{}, "app_id = ? AND tag_id = ?", appID, tagID).Error
+}
+
+// GetByAppID returns the tags of an application, ordered by name
+func (r *tagRepository) GetByAppID(ctx context.Context, appID uuid.UUID) ([]*entity.Tag, error) {
+	var result []*entity.Tag
+	err := dbFromContext(ctx, r.db).
+		Joins("JOIN app_tags ON app_tags.tag_id = tag.id").
+		Where("app_tags.app_id = ?", appID).
+		Order("tag.name").
+		Find(&result).Error
+	return result, err
+}
+
+// GetNamesByAppIDs returns the tag names of each of the given applications, ordered by name.
+// Applications without tags are absent from the map.
+func (r *tagRepository) GetNamesByAppIDs(ctx context.Context, appIDs []uuid.UUID) (map[uuid.UUID][]string, error) {
+	result := make(map[uuid.UUID][]string)
+	if len(appIDs) == 0 {
+		return result, nil
+	}
+	var rows []struct {
+		AppID uuid.UUID
+		Name  string
+	}
+	err := dbFromContext(ctx, r.db).Model(&entity.AppTag{}).
+		Select("app_tags.app_id, tag.name").
+		Joins("JOIN tag ON tag.id = app_tags.tag_id").
+		Where("app_tags.app_id IN ?", appIDs).
+		Order("tag.name").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		result[row.AppID] = append(result[row.AppID], row.Name)
+	}
+	return result, nil
+}
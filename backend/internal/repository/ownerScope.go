@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// ownerContextKey is the context key under which the authenticated owner is stored
+type ownerContextKey struct{}
+
+// WithOwnerScope restricts application queries made with ctx to applications owned by ownerID
+func WithOwnerScope(ctx context.Context, ownerID string) context.Context {
+	return context.WithValue(ctx, ownerContextKey{}, ownerID)
+}
+
+// OwnerScope returns the owner that application queries made with ctx are restricted to.
+// Contexts without an owner (background jobs, unauthenticated deployments) are unrestricted.
+func OwnerScope(ctx context.Context) (string, bool) {
+	ownerID, ok := ctx.Value(ownerContextKey{}).(string)
+	return ownerID, ok && ownerID != ""
+}
+
+// scopeToOwner filters app queries by owner when ctx carries one
+func scopeToOwner(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if ownerID, ok := OwnerScope(ctx); ok {
+		return db.Where("owner_id = ?", ownerID)
+	}
+	return db
+}
@@ -3,11 +3,17 @@ package repository
 import (
 	"context"
 	"elang-backend/internal/entity"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// ErrNotFound is returned by GetByID when no record has the given ID, so callers can tell a missing
+// record from a database failure. Lookups by name or other attributes (GetByName, GetByNameCI,
+// GetByOwnerRepo, ...) are existence checks and keep returning (nil, nil) when nothing matches.
+var ErrNotFound = errors.New("record not found")
+
 // Repository interfaces for each entity
 type RuntimeRepository interface {
 	Create(ctx context.Context, runtime *entity.Runtime) error
@@ -27,6 +33,9 @@ type FrameworkRepository interface {
 	Delete(ctx context.Context, id int) error
 	GetByName(ctx context.Context, name string) (*entity.Framework, error)
 	GetByNameCI(ctx context.Context, name string) (*entity.Framework, error)
+	GetByRuntimeID(ctx context.Context, runtimeID int) ([]*entity.Framework, error)
+	// LinkRuntime lets a framework be used with one more runtime
+	LinkRuntime(ctx context.Context, frameworkID, runtimeID int) error
 }
 
 type ApplicationRepository interface {
@@ -38,6 +47,17 @@ type ApplicationRepository interface {
 	GetByName(ctx context.Context, name string) (*entity.App, error)
 	GetByStatus(ctx context.Context, status string) ([]*entity.App, error)
 	UpdateStatus(ctx context.Context, id uuid.UUID, status string) error
+	// GetByTags returns the applications carrying every one of the given tags
+	GetByTags(ctx context.Context, tags []string) ([]*entity.App, error)
+}
+
+type TagRepository interface {
+	GetOrCreate(ctx context.Context, name string) (*entity.Tag, error)
+	GetByName(ctx context.Context, name string) (*entity.Tag, error)
+	AddToApp(ctx context.Context, appID, tagID uuid.UUID) error
+	RemoveFromApp(ctx context.Context, appID, tagID uuid.UUID) error
+	GetByAppID(ctx context.Context, appID uuid.UUID) ([]*entity.Tag, error)
+	GetNamesByAppIDs(ctx context.Context, appIDs []uuid.UUID) (map[uuid.UUID][]string, error)
 }
 
 type DependencyRepository interface {
@@ -49,15 +69,27 @@ type DependencyRepository interface {
 	Delete(ctx context.Context, id uuid.UUID) error
 	GetByNameCI(ctx context.Context, name string) (*entity.Dependency, error)
 	SearchByName(ctx context.Context, name string) ([]*entity.Dependency, error)
+	// SearchPage returns one page of the dependencies whose name contains name literally (all when empty),
+	// ordered by name case-insensitively, with the number of applications using each, and the total number of
+	// matches. With an owner scope only dependencies of the owner's applications are matched and counted.
+	SearchPage(ctx context.Context, name string, limit, offset int) ([]*DependencyUsage, int64, error)
 	GetByOwnerRepoCI(ctx context.Context, owner, repo string) (*entity.Dependency, error)
 }
 
+// DependencyUsage is a dependency with the number of (non-deleted) applications using it. When ctx carries
+// an owner scope only that owner's applications are counted.
+type DependencyUsage struct {
+	entity.Dependency `gorm:"embedded"`
+	AppCount          int `gorm:"column:app_count"`
+}
+
 type AppDependencyRepository interface {
 	Create(ctx context.Context, appDep *entity.AppDependency) error
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.AppDependency, error)
 	GetByAppID(ctx context.Context, appID uuid.UUID) ([]*entity.AppDependency, error)
 	GetByDependencyID(ctx context.Context, depID uuid.UUID) ([]*entity.AppDependency, error)
 	Update(ctx context.Context, appDep *entity.AppDependency) error
+	UpdateIfUnchanged(ctx context.Context, appDep *entity.AppDependency, expectedUpdatedAt time.Time) (bool, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 	GetByStatus(ctx context.Context, status string) ([]*entity.AppDependency, error)
 	GetByAppAndDependencyID(ctx context.Context, appID, depID uuid.UUID) (*entity.AppDependency, error)
@@ -81,3 +113,58 @@ type AuditTrailRepository interface {
 	GetByTimeRange(ctx context.Context, startTime, endTime time.Time, limit, offset int) ([]*entity.AuditTrail, error)
 	CleanupOldRecords(ctx context.Context, olderThan time.Time) error
 }
+
+type ScanResultRepository interface {
+	Create(ctx context.Context, scan *entity.ScanResult) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.ScanResult, error)
+	GetByAppID(ctx context.Context, appID uuid.UUID, limit, offset int) ([]*entity.ScanResult, error)
+	Update(ctx context.Context, scan *entity.ScanResult) error
+	GetLatestCompletedPerApp(ctx context.Context) ([]*entity.ScanResult, error)
+	GetLatestCompletedByAppID(ctx context.Context, appID uuid.UUID) (*entity.ScanResult, error)
+	CleanupOldRecords(ctx context.Context, olderThan time.Time, keepPerApp int) ([]*entity.ScanResult, error)
+	QueryFindings(ctx context.Context, filter FindingFilter) ([]*entity.Finding, int64, error)
+}
+
+// FindingFilter selects stored findings of one application; zero fields do not filter
+type FindingFilter struct {
+	AppID           uuid.UUID
+	ScanID          uuid.UUID  // findings of this scan only
+	Severities      []string   // canonical severities, any of which matches
+	Since           *time.Time // scans completed at or after
+	Dependency      string     // case-insensitive substring of the dependency name, wildcards matching literally
+	VulnerabilityID string     // case-insensitive vulnerability ID
+	Limit, Offset   int
+}
+
+type ScanScheduleRepository interface {
+	Create(ctx context.Context, schedule *entity.ScanSchedule) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.ScanSchedule, error)
+	GetByAppID(ctx context.Context, appID uuid.UUID) ([]*entity.ScanSchedule, error)
+	Update(ctx context.Context, schedule *entity.ScanSchedule) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// GetDue returns the enabled schedules whose next run is at or before now
+	GetDue(ctx context.Context, now time.Time) ([]*entity.ScanSchedule, error)
+}
+
+type RemediationRepository interface {
+	Create(ctx context.Context, remediation *entity.Remediation) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Remediation, error)
+	// GetByAppID returns the remediations of an application, only those in one of statuses when given
+	GetByAppID(ctx context.Context, appID uuid.UUID, statuses ...string) ([]*entity.Remediation, error)
+	Update(ctx context.Context, remediation *entity.Remediation) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type SeverityOverrideRepository interface {
+	Create(ctx context.Context, override *entity.SeverityOverride) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.SeverityOverride, error)
+	// GetByOwner returns the overrides of an owner that apply to all of its applications
+	GetByOwner(ctx context.Context, ownerID string) ([]*entity.SeverityOverride, error)
+	GetByAppID(ctx context.Context, appID uuid.UUID) ([]*entity.SeverityOverride, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// UnitOfWork groups repository calls into a single database transaction
+type UnitOfWork interface {
+	Do(ctx context.Context, fn func(txCtx context.Context) error) error
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"cmp"
 	"context"
 	"elang-backend/internal/entity"
 	"elang-backend/internal/helper"
@@ -9,9 +10,12 @@ import (
 	"elang-backend/internal/model/dto"
 	"elang-backend/internal/repository"
 	"elang-backend/internal/usecase"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -22,7 +26,7 @@ import (
 
 type ApplicationService struct {
 	depedencyParserService helper.DependencyParser
-	cveService             *helper.CVEHelper
+	sharedScanner          *helper.SharedScanner
 	githubApiService       usecase.GitHubAPIInterface
 	objectStorageService   usecase.ObjectStorageInterface
 
@@ -34,17 +38,88 @@ type ApplicationService struct {
 	runTimeRepository          repository.RuntimeRepository
 	frameWorkRepository        repository.FrameworkRepository
 	auditTrailRepository       repository.AuditTrailRepository
+	scanResultRepository       repository.ScanResultRepository
+	tagRepository              repository.TagRepository
+	remediationRepository      repository.RemediationRepository
+	severityOverrideRepository repository.SeverityOverrideRepository
+	unitOfWork                 repository.UnitOfWork
+
+	// Branches tried when GitHub cannot report a repository's default branch
+	branchFallbacks []string
+	// Latest commits read per repository when metadata is fetched
+	commitHistoryLimit int
+	// Most dependencies a manifest may declare
+	maxDependencies int
+	// Dependencies whose GitHub metadata is fetched at once
+	processingWorkers int
+	// Whether new applications are scanned once their dependencies are processed, unless AddApplication says otherwise
+	autoScanOnAdd bool
+	// Whether the collaborators of dependency repositories are counted along with their other signals
+	countCollaborators bool
+	// How scans with unchecked dependencies are judged
+	policy helper.PolicyConfig
+	// Tool credited in generated SBOMs
+	sbomTool helper.CycloneDXTool
+	// GitHub Enterprise Server host recognized in repository URLs next to github.com, if any
+	githubHost string
+	// Hosts user supplied repository URLs may point to
+	trustedHosts []string
+
+	// Background work (dependency processing, async scans) runs under rootCtx so Shutdown can cancel it
+	rootCtx        context.Context
+	cancelRoot     context.CancelFunc
+	backgroundJobs sync.WaitGroup
+}
+
+// DefaultDependencyProcessingWorkers bounds how many dependencies are processed at once when none is configured
+const DefaultDependencyProcessingWorkers = 10
+
+// gradlePropertiesFileName is the name the gradle.properties uploaded with a manifest is stored under
+const gradlePropertiesFileName = "gradle.properties"
+
+// errorCollector gathers the errors of concurrent workers. Unlike a channel sized up front it never blocks,
+// however many errors each worker reports.
+type errorCollector struct {
+	mu   sync.Mutex
+	errs []error
+}
+
+// Add records err; nil is ignored
+func (c *errorCollector) Add(err error) {
+	if err == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.errs = append(c.errs, err)
+}
+
+// Messages returns the recorded errors' messages in the order they were added
+func (c *errorCollector) Messages() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	messages := make([]string, 0, len(c.errs))
+	for _, err := range c.errs {
+		messages = append(messages, err.Error())
+	}
+	return messages
 }
 
 func NewApplicationService(basicRepo dto.BasicRepositories,
 	dependencyParser helper.DependencyParser,
 	objectStorageService usecase.ObjectStorageInterface,
 	githubApiService usecase.GitHubAPIInterface,
+	config ServiceConfig,
 ) ApplicationInterface {
+	config = config.withDefaults()
+	rootCtx, cancelRoot := context.WithCancel(context.Background())
 	return &ApplicationService{
+		rootCtx:    rootCtx,
+		cancelRoot: cancelRoot,
+
 		objectStorageService:   objectStorageService,
 		depedencyParserService: dependencyParser,
-		cveService:             helper.NewCVEHelper(),
+		sharedScanner:          helper.NewSharedScanner(config.Scanner),
 		githubApiService:       githubApiService,
 
 		appRepository:              basicRepo.AppRepository,
@@ -54,15 +129,36 @@ func NewApplicationService(basicRepo dto.BasicRepositories,
 		runTimeRepository:          basicRepo.RunTimeRepository,
 		frameWorkRepository:        basicRepo.FrameWorkRepository,
 		auditTrailRepository:       basicRepo.AuditTrailRepository,
+		scanResultRepository:       basicRepo.ScanResultRepository,
+		tagRepository:              basicRepo.TagRepository,
+		remediationRepository:      basicRepo.RemediationRepository,
+		severityOverrideRepository: basicRepo.SeverityOverrideRepository,
+		unitOfWork:                 basicRepo.UnitOfWork,
+
+		branchFallbacks:    config.DefaultBranchFallbacks,
+		commitHistoryLimit: config.CommitHistoryLimit,
+		maxDependencies:    config.MaxDependenciesPerApp,
+		processingWorkers:  config.DependencyProcessingWorkers,
+		autoScanOnAdd:      config.AutoScanOnAdd,
+		countCollaborators: config.CountCollaborators,
+		policy:             config.Policy,
+		sbomTool:           config.SBOMTool,
+		githubHost:         config.GitHubHost,
+		trustedHosts:       config.TrustedRepositoryHosts,
 	}
 }
 
-func (m *ApplicationService) AddApplication(ctx context.Context, appName, runtimeType, framework, description, fileName, content string) (*model.AddApplicationResponse, error) {
+func (m *ApplicationService) AddApplication(ctx context.Context, appName, runtimeType, framework, description, fileName, content string, autoScan *bool) (*model.AddApplicationResponse, error) {
 	// Check for empty inputs
 	if content == "" || fileName == "" || runtimeType == "" || appName == "" {
 		return nil, fmt.Errorf("content, file name, runtime type, and application name cannot be empty")
 	}
 
+	// Reject runtimes disabled by configuration before touching the database
+	if !m.depedencyParserService.IsRuntimeEnabled(runtimeType) {
+		return nil, fmt.Errorf("runtime %s is not supported or disabled: %w", runtimeType, ErrInvalidInput)
+	}
+
 	// Check for valid runtime type (case-insensitive)
 	runtime, err := m.runTimeRepository.GetByNameCI(ctx, runtimeType)
 	if err != nil {
@@ -80,6 +176,9 @@ func (m *ApplicationService) AddApplication(ctx context.Context, appName, runtim
 	if frameworkEntity == nil {
 		return nil, fmt.Errorf("framework %s not found for runtime %s", framework, runtimeType)
 	}
+	if !frameworkBelongsToRuntime(frameworkEntity, runtime.ID) {
+		return nil, fmt.Errorf("framework %s does not belong to runtime %s: %w", frameworkEntity.Name, runtime.Name, ErrInvalidInput)
+	}
 
 	// Check if app already exists
 	app, err := m.appRepository.GetByName(ctx, appName)
@@ -90,6 +189,18 @@ func (m *ApplicationService) AddApplication(ctx context.Context, appName, runtim
 		return nil, fmt.Errorf("application with name %s already exists", appName)
 	}
 
+	// Parse the manifest before creating anything so unparseable files are rejected up front. Repositories are
+	// not verified on GitHub here, a request per dependency would hold up the response: processDependency
+	// verifies them in the background and drops the URLs GitHub does not know.
+	deps := m.depedencyParserService.WithGradleProperties(helper.GradlePropertiesFile(ctx)).WithGoIndirect(ctx).
+		ParseDependencyFileWithGitHub(ctx, fileName, content, false, helper.GetRuntimeTypeCI(runtimeType))
+	if !deps.Success {
+		return nil, fmt.Errorf("failed to parse %s: %s: %w", fileName, deps.Error, ErrInvalidInput)
+	}
+	if err := checkDependencyLimit(fileName, len(deps.Dependencies), m.maxDependencies); err != nil {
+		return nil, err
+	}
+
 	// Create and save new application
 	newApp := &entity.App{
 		ID:          uuid.New(),
@@ -99,58 +210,84 @@ func (m *ApplicationService) AddApplication(ctx context.Context, appName, runtim
 		Description: &description,
 		Status:      "inactive",
 	}
-	if err := m.appRepository.Create(ctx, newApp); err != nil {
-		return nil, fmt.Errorf("failed to create application: %w", err)
+	if ownerID, ok := repository.OwnerScope(ctx); ok {
+		newApp.OwnerID = &ownerID
 	}
-
-	// Audit trail: Application created
-	err = m.auditApplicationAction(ctx, newApp.ID, "application_created", nil, map[string]interface{}{
-		"app_name":     appName,
-		"runtime_type": runtimeType,
-		"framework":    framework,
-		"description":  description,
-		"file_name":    fileName,
+	var warnings []string
+	storedKeys, err := m.storeManifest(ctx, newApp, fileName, content, helper.GradlePropertiesFile(ctx))
+	if err != nil {
+		slog.Warn("Failed to store manifest, the application cannot be re-parsed", "app_name", appName, "file_name", fileName, "error", err)
+		warnings = append(warnings, ManifestNotStoredWarning)
+	}
+	// The application row and its creation audit entry are committed together
+	err = m.inTransaction(ctx, func(txCtx context.Context) error {
+		if err := m.appRepository.Create(txCtx, newApp); err != nil {
+			return fmt.Errorf("failed to create application: %w", err)
+		}
+		// Audit trail: Application created
+		if err := m.auditApplicationAction(txCtx, newApp.ID, "application_created", nil, map[string]interface{}{
+			"app_name":     appName,
+			"runtime_type": runtimeType,
+			"framework":    framework,
+			"description":  description,
+			"file_name":    fileName,
+		}); err != nil {
+			return fmt.Errorf("failed to create audit trail for application: %w", err)
+		}
+		return nil
 	})
 	if err != nil {
-		slog.Warn("Failed to create audit trail for application creation", "error", err)
+		// No application refers to the stored objects now
+		m.deleteManifestObjects(ctx, newApp, storedKeys)
+		return nil, err
+	}
+
+	scanWhenProcessed := m.autoScanOnAdd
+	if autoScan != nil {
+		scanWhenProcessed = *autoScan
 	}
 
 	// Dependencies: process in background
-	deps := m.depedencyParserService.ParseDependencyFileWithGitHub(fileName, content, helper.GetRuntimeTypeCI(runtimeType))
+	m.backgroundJobs.Add(1)
 	go func() {
-		bgCtx := context.Background()
-		var (
-			wg    sync.WaitGroup
-			errCh = make(chan error, len(deps.Dependencies))
-		)
-		for _, dep := range deps.Dependencies {
-			wg.Add(1)
-			depCopy := dep
-			go func(dep helper.DependencyInfo) {
-				defer wg.Done()
-				m.processDependency(bgCtx, dep, newApp, errCh)
-			}(depCopy)
-		}
-		wg.Wait()
-		close(errCh)
-		var depErrors []string
-		for err := range errCh {
-			if err != nil {
-				depErrors = append(depErrors, err.Error())
-			}
-		}
+		defer m.backgroundJobs.Done()
+		bgCtx := m.rootCtx
+		depErrors := m.processDependencies(bgCtx, deps.Dependencies, newApp)
 		// Update app status after processing
 		finalStatus := "active"
 		if len(depErrors) > 0 {
 			finalStatus = "inactive"
 		}
 		// Only update the status field to avoid overwriting other fields
-		if err := m.appRepository.UpdateStatus(bgCtx, newApp.ID, finalStatus); err != nil {
+		if err := m.appRepository.UpdateStatus(context.WithoutCancel(bgCtx), newApp.ID, finalStatus); err != nil {
 			slog.Error("failed to update app status after dependency processing", "error", err)
 		}
+		if len(depErrors) > 0 {
+			// Dependencies that were linked stay committed; record the failures so they can be
+			// re-submitted through AddApplicationDependency before recovering the application
+			if err := m.auditApplicationAction(context.WithoutCancel(bgCtx), newApp.ID, "dependency_processing_failed", nil, map[string]interface{}{
+				"failed_count": len(depErrors),
+				"total_count":  len(deps.Dependencies),
+				"errors":       depErrors,
+			}); err != nil {
+				slog.Warn("Failed to create audit trail for dependency processing failure", "error", err)
+			}
+		}
+		// A partially linked application would get a misleading first scan, so only complete ones are scanned
+		if scanWhenProcessed && len(depErrors) == 0 {
+			m.runFirstScan(bgCtx, newApp)
+		}
 	}()
 
 	message := "Application created, dependency processing started in background."
+	if scanWhenProcessed {
+		message = "Application created, dependency processing started in background; a first scan runs once it completes."
+	}
+	if len(deps.Dependencies) == 0 {
+		slog.Warn("Manifest contains no dependencies", "app_name", appName, "file_name", fileName, "runtime", runtimeType)
+		message = "Application created without dependencies."
+		warnings = append(warnings, NoDependenciesWarning)
+	}
 	response := &model.AddApplicationResponse{
 		AppID:           fmt.Sprintf("%v", newApp.ID),
 		AppName:         newApp.Name,
@@ -159,12 +296,118 @@ func (m *ApplicationService) AddApplication(ctx context.Context, appName, runtim
 		Description:     description,
 		Status:          newApp.Status,
 		DependencyParse: deps.Dependencies,
+		DependencyCount: len(deps.Dependencies),
+		AutoScan:        scanWhenProcessed,
+		Warnings:        warnings,
 		Message:         message,
 	}
 
 	return response, nil
 }
 
+// processDependencies links deps to app, a bounded number at a time, and returns the messages of the
+// dependencies that failed
+func (m *ApplicationService) processDependencies(ctx context.Context, deps []helper.DependencyInfo, app *entity.App) []string {
+	var (
+		wg        sync.WaitGroup
+		errs      errorCollector
+		semaphore = make(chan struct{}, m.processingWorkers)
+	)
+	for _, dep := range deps {
+		wg.Add(1)
+		// Acquire a worker slot so large manifests do not start a goroutine per dependency
+		semaphore <- struct{}{}
+		go func(dep helper.DependencyInfo) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			m.processDependency(ctx, dep, app, &errs)
+		}(dep)
+	}
+	wg.Wait()
+	return errs.Messages()
+}
+
+// storeManifest keeps the manifest an application is added from, and the gradle.properties uploaded with it, in
+// object storage, so it can be retrieved and re-parsed later, and records where on app. It returns the keys of the
+// stored objects, or the reason when the manifest was not stored, in which case nothing is kept. Nothing is stored
+// while object storage is disabled, which /health already reports.
+func (m *ApplicationService) storeManifest(ctx context.Context, app *entity.App, fileName, content, gradleProperties string) ([]string, error) {
+	if m.objectStorageService == nil {
+		return nil, nil
+	}
+	if len(content) > parser.MaxContentSize {
+		return nil, fmt.Errorf("manifest exceeds %d bytes", parser.MaxContentSize)
+	}
+	storageCtx := appStorageContext(ctx, app)
+	key, err := m.objectStorageService.SaveManifest(storageCtx, app.ID.String(), fileName, []byte(content))
+	if err != nil {
+		return nil, err
+	}
+	keys := []string{key}
+	var propertiesKey string
+	if gradleProperties != "" {
+		if propertiesKey, err = m.objectStorageService.SaveManifest(storageCtx, app.ID.String(), gradlePropertiesFileName, []byte(gradleProperties)); err != nil {
+			m.deleteManifestObjects(ctx, app, keys)
+			return nil, fmt.Errorf("failed to store gradle.properties: %w", err)
+		}
+		keys = append(keys, propertiesKey)
+		app.GradlePropertiesObjectKey = &propertiesKey
+	}
+	app.ManifestFileName, app.ManifestObjectKey = &fileName, &key
+	return keys, nil
+}
+
+// deleteManifestObjects removes objects storeManifest stored for an application that was not created
+func (m *ApplicationService) deleteManifestObjects(ctx context.Context, app *entity.App, keys []string) {
+	for _, key := range keys {
+		if err := m.objectStorageService.DeleteManifest(appStorageContext(context.WithoutCancel(ctx), app), key); err != nil {
+			slog.Warn("Failed to delete stored manifest of an application that was not created", "app_id", app.ID, "object_key", key, "error", err)
+		}
+	}
+}
+
+// runFirstScan scans a newly added application once its dependencies are linked and stores the result. Like any
+// stored scan, a failed policy reports the application "vulnerable" until a later scan passes.
+func (m *ApplicationService) runFirstScan(ctx context.Context, app *entity.App) {
+	// The result must still be stored when the scan finishes during shutdown
+	storeCtx := context.WithoutCancel(ctx)
+
+	result, err := m.runApplicationScan(ctx, app, uuid.New())
+	if err != nil {
+		slog.Error("Automatic first scan failed", "app_id", app.ID, "error", err)
+		return
+	}
+	if err := persistScanResult(storeCtx, m.scanResultRepository, &app.ID, "application", result); err != nil {
+		slog.Error("Failed to persist automatic first scan", "scan_id", result.ScanID, "error", err)
+	} else {
+		resolveFixedRemediations(storeCtx, m.remediationRepository, app.ID, result)
+	}
+}
+
+// reportedStatus is the status app is reported with: an active application whose latest completed scan failed
+// its policy is "vulnerable". It is derived on every read, so a later passing scan clears it.
+func reportedStatus(app *entity.App, latest *entity.ScanResult) string {
+	if app.Status == "active" && latest != nil && latest.PolicyStatus == "fail" {
+		return "vulnerable"
+	}
+	return app.Status
+}
+
+// latestCompletedScan returns the newest completed scan of an application, or nil when it has none
+func (m *ApplicationService) latestCompletedScan(ctx context.Context, appID uuid.UUID) (*entity.ScanResult, error) {
+	if m.scanResultRepository == nil {
+		return nil, nil
+	}
+	scan, err := m.scanResultRepository.GetLatestCompletedByAppID(ctx, appID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch latest scan: %w", err)
+	}
+	return scan, nil
+}
+
 func (m *ApplicationService) AddApplicationDependency(ctx context.Context, appUID string, deps []model.DependencyInfoRequest) (interface{}, error) {
 	// Parse app UUID
 	appID, err := uuid.Parse(appUID)
@@ -175,42 +418,27 @@ func (m *ApplicationService) AddApplicationDependency(ctx context.Context, appUI
 	// Check if app exists
 	app, err := m.appRepository.GetByID(ctx, appID)
 	if err != nil {
-		return nil, fmt.Errorf("app not found: %w", err)
+		return nil, lookupError(err, "application "+appUID)
 	}
 
+	// GitHub lookups are the slow part, so they run concurrently; the database is then updated one dependency at a
+	// time in request order, which keeps two entries for the same repository from racing to create it
+	enriched := m.enrichDependencyInfos(deps)
+
 	results := make(map[string]interface{})
-	successful, failed := 0, 0
-
-	for _, depInfo := range deps {
-		// Validate GitHub repo info if flagged
-		slog.Info("Adding dependency", "name", depInfo.Name, "owner", depInfo.Owner, "repo", depInfo.Repo, "version", depInfo.Version, "is_github", depInfo.IsGitHubRepo)
-		if depInfo.IsGitHubRepo {
-			owner, repo, valid := depInfo.Owner, depInfo.Repo, false
-			if depInfo.RepositoryURL != "" {
-				parts, isValid := helper.ExtractGitHubOwnerRepo(depInfo.RepositoryURL)
-				if isValid {
-					owner, repo, valid = parts.Owner, parts.Repo, true
-				}
-			}
-			if !valid && owner != "" && repo != "" {
-				valid = true
-			}
-			if valid {
-				repoInfo, err := m.githubApiService.GetRepoInfo(owner, repo)
-				if err == nil && repoInfo != nil {
-					depInfo.Owner, depInfo.Repo = owner, repo
-					depInfo.RepositoryURL = fmt.Sprintf("https://github.com/%s/%s", owner, repo)
-					depInfo.IsGitHubRepo = true
-				} else {
-					depInfo.IsGitHubRepo = false
-					depInfo.RepositoryURL = ""
-					slog.Warn("Invalid GitHub repo, marking as non-GitHub", "owner", owner, "repo", repo)
-				}
-			} else {
-				depInfo.IsGitHubRepo = false
-				depInfo.RepositoryURL = ""
-				slog.Warn("No valid GitHub info, marking as non-GitHub repo")
+	successful, failed, githubSkipped := 0, 0, 0
+
+	for _, item := range enriched {
+		depInfo, ecosystem, defaultBranch := item.info, item.ecosystem, item.defaultBranch
+		if item.githubSkipped {
+			githubSkipped++
+		}
+		if item.err != nil {
+			results[fmt.Sprintf("%s/%s", depInfo.Owner, depInfo.Repo)] = map[string]interface{}{
+				"status": "failed", "error": item.err.Error(),
 			}
+			failed++
+			continue
 		}
 
 		// Lookup dependency
@@ -224,11 +452,6 @@ func (m *ApplicationService) AddApplicationDependency(ctx context.Context, appUI
 		}
 
 		slog.Info("Processing dependency", "name", depInfo.Name, "owner", depInfo.Owner, "repo", depInfo.Repo, "version", depInfo.Version, "is_github", depInfo.IsGitHubRepo)
-		// Get default branch if GitHub repo
-		var defaultBranch string
-		if depInfo.IsGitHubRepo {
-			defaultBranch, _ = m.githubApiService.GetDefaultBranch(depInfo.Owner, depInfo.Repo)
-		}
 
 		// Create dependency if not found
 		if dependency == nil {
@@ -280,18 +503,12 @@ func (m *ApplicationService) AddApplicationDependency(ctx context.Context, appUI
 			continue
 		}
 
-		// Find matching tag if GitHub repo
-		if depInfo.IsGitHubRepo {
-			if matchedVersion, err := m.githubApiService.FindMatchingTag(depInfo.Owner, depInfo.Repo, depInfo.Version); err == nil && matchedVersion != "" {
-				depInfo.Version = matchedVersion
-			}
-		}
-
 		appDependency := &entity.AppDependency{
 			ID:           uuid.New(),
 			AppID:        appID,
 			DependencyID: dependency.ID,
 			UsedVersion:  depInfo.Version,
+			Ecosystem:    ecosystem,
 			IsMonitored:  false,
 		}
 		if err := m.appToDepedencyRepository.Create(ctx, appDependency); err != nil {
@@ -309,7 +526,7 @@ func (m *ApplicationService) AddApplicationDependency(ctx context.Context, appUI
 		successful++
 	}
 
-	return map[string]interface{}{
+	response := map[string]interface{}{
 		"app_id":   app.ID.String(),
 		"app_name": app.Name,
 		"results":  results,
@@ -318,7 +535,121 @@ func (m *ApplicationService) AddApplicationDependency(ctx context.Context, appUI
 			"successful": successful,
 			"failed":     failed,
 		},
-	}, nil
+	}
+	if githubSkipped > 0 {
+		response["warnings"] = []string{anonymousRateLimitWarning(githubSkipped)}
+	}
+	return response, nil
+}
+
+// enrichedDependency is a requested dependency after validation and its GitHub lookups
+type enrichedDependency struct {
+	info          model.DependencyInfoRequest
+	ecosystem     *string
+	defaultBranch string
+	githubSkipped bool  // GitHub refused the lookups, so the dependency is added without its metadata
+	err           error // why the dependency cannot be added
+}
+
+// anonymousRateLimitWarning is the one warning a response carries when GitHub's anonymous rate limit left
+// skipped dependencies without their GitHub metadata
+func anonymousRateLimitWarning(skipped int) string {
+	return fmt.Sprintf("%v: %d dependencies were saved without GitHub metadata; configure a GitHub token and refresh them", usecase.ErrGitHubAnonymousRateLimit, skipped)
+}
+
+// enrichDependencyInfos validates deps and resolves their GitHub repository, default branch and matching tag,
+// at most processingWorkers at a time. The results are in the order of deps.
+func (m *ApplicationService) enrichDependencyInfos(deps []model.DependencyInfoRequest) []enrichedDependency {
+	var (
+		enriched  = make([]enrichedDependency, len(deps))
+		wg        sync.WaitGroup
+		semaphore = make(chan struct{}, m.processingWorkers)
+	)
+	for i, depInfo := range deps {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(i int, depInfo model.DependencyInfoRequest) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			// Each worker writes only its own slot
+			enriched[i] = m.enrichDependencyInfo(depInfo)
+		}(i, depInfo)
+	}
+	wg.Wait()
+	return enriched
+}
+
+// enrichDependencyInfo validates one requested dependency and, when it is on GitHub, resolves its canonical
+// repository, default branch and the tag matching its version. Repositories GitHub does not know are kept as
+// non-GitHub dependencies.
+func (m *ApplicationService) enrichDependencyInfo(depInfo model.DependencyInfoRequest) enrichedDependency {
+	slog.Info("Adding dependency", "name", depInfo.Name, "owner", depInfo.Owner, "repo", depInfo.Repo, "version", depInfo.Version, "is_github", depInfo.IsGitHubRepo)
+	result := enrichedDependency{info: depInfo}
+	if depInfo.Ecosystem != "" {
+		parsed, err := helper.ParseEcosystem(depInfo.Ecosystem)
+		if err != nil {
+			result.err = err
+			return result
+		}
+		result.ecosystem = &parsed
+	}
+	// Never store or fetch a URL pointing at an untrusted or internal host
+	if depInfo.RepositoryURL != "" {
+		if err := helper.ValidateRepositoryURL(depInfo.RepositoryURL, m.trustedHosts); err != nil {
+			result.err = err
+			return result
+		}
+	}
+	if !depInfo.IsGitHubRepo {
+		return result
+	}
+
+	parts, valid := helper.GitHubRepoParts{Owner: depInfo.Owner, Repo: depInfo.Repo}, false
+	if depInfo.RepositoryURL != "" {
+		if fromURL, isValid := helper.ExtractGitHubOwnerRepo(depInfo.RepositoryURL, m.githubHost); isValid {
+			parts, valid = fromURL, true
+		}
+	}
+	if !valid && parts.Owner != "" && parts.Repo != "" {
+		valid = true
+	}
+	owner, repo := parts.Owner, parts.Repo
+	if !valid {
+		depInfo.IsGitHubRepo = false
+		depInfo.RepositoryURL = ""
+		slog.Warn("No valid GitHub info, marking as non-GitHub repo")
+		result.info = depInfo
+		return result
+	}
+	repoInfo, err := m.githubApiService.GetRepoInfo(owner, repo)
+	if errors.Is(err, usecase.ErrGitHubAnonymousRateLimit) {
+		// The repository may well exist; keep it as given and leave its metadata for a later refresh
+		depInfo.Owner, depInfo.Repo = owner, repo
+		depInfo.RepositoryURL = parts.URL()
+		result.info, result.githubSkipped = depInfo, true
+		return result
+	}
+	if err != nil || repoInfo == nil {
+		depInfo.IsGitHubRepo = false
+		depInfo.RepositoryURL = ""
+		slog.Warn("Invalid GitHub repo, marking as non-GitHub", "owner", owner, "repo", repo)
+		result.info = depInfo
+		return result
+	}
+	// Store moved repositories under the name GitHub redirected to
+	if canonical, ok := canonicalRepo(repoInfo, parts); ok {
+		parts = canonical
+		owner, repo = canonical.Owner, canonical.Repo
+	}
+	depInfo.Owner, depInfo.Repo = owner, repo
+	depInfo.RepositoryURL = parts.URL()
+
+	result.defaultBranch, _ = m.githubApiService.GetDefaultBranch(owner, repo)
+	if matchedVersion, err := m.githubApiService.FindMatchingTag(owner, repo, depInfo.Version); err == nil && matchedVersion != "" {
+		depInfo.Version = matchedVersion
+	}
+	result.info = depInfo
+	return result
 }
 
 func (m *ApplicationService) ListApplicationDependency(ctx context.Context, appUID string) (*model.ListApplicationDependencyResponse, error) {
@@ -329,10 +660,7 @@ func (m *ApplicationService) ListApplicationDependency(ctx context.Context, appU
 	}
 	app, err := m.appRepository.GetByID(ctx, appID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch app: %w", err)
-	}
-	if app == nil {
-		return nil, fmt.Errorf("application not found")
+		return nil, lookupError(err, "application "+appUID)
 	}
 
 	// Get all app dependencies for this app
@@ -344,20 +672,34 @@ func (m *ApplicationService) ListApplicationDependency(ctx context.Context, appU
 	var depDetails []model.ApplicationDependencyDetail
 	for _, appDep := range appDeps {
 		dep, err := m.depedencyRepository.GetByID(ctx, appDep.DependencyID)
-		if err != nil || dep == nil {
+		if errors.Is(err, repository.ErrNotFound) {
 			continue // skip missing dependency
 		}
+		if err != nil {
+			return nil, lookupError(err, "dependency "+appDep.DependencyID.String())
+		}
 
+		resolvedTag := resolvedVersionTag(appDep, dep)
 		depDetails = append(depDetails, model.ApplicationDependencyDetail{
-			DependencyID:  dep.ID.String(),
-			Name:          dep.Name,
-			Owner:         dep.Owner,
-			Repo:          dep.Repo,
-			UsedVersion:   appDep.UsedVersion,
-			IsMonitored:   appDep.IsMonitored,
-			RepositoryURL: derefString(dep.RepositoryURL),
-			LastTag:       dep.LastTag,
-			DefaultBranch: dep.DefaultBranch,
+			DependencyID:       dep.ID.String(),
+			Name:               dep.Name,
+			Owner:              dep.Owner,
+			Repo:               dep.Repo,
+			UsedVersion:        appDep.UsedVersion,
+			Ecosystem:          derefString(appDep.Ecosystem),
+			Transitive:         appDep.Transitive,
+			IsMonitored:        appDep.IsMonitored,
+			RepositoryURL:      derefString(dep.RepositoryURL),
+			LastTag:            dep.LastTag,
+			DefaultBranch:      dep.DefaultBranch,
+			VersionResolved:    resolvedTag != "",
+			ResolvedTag:        resolvedTag,
+			ReleasesBehind:     appDep.ReleasesBehind,
+			StalenessDays:      appDep.StalenessDays,
+			IsStale:            appDep.ReleasesBehind != nil && *appDep.ReleasesBehind >= helper.StaleReleasesBehind,
+			StalenessCheckedAt: appDep.StalenessCheckedAt,
+			FirstSeenAt:        appDep.CreatedAt,
+			UpdatedAt:          appDep.UpdatedAt,
 		})
 	}
 
@@ -369,58 +711,221 @@ func (m *ApplicationService) ListApplicationDependency(ctx context.Context, appU
 	}, nil
 }
 
+// CompareApplications sets the current dependencies of two applications side by side: those both use, with
+// the version each uses, and those only one uses. Their risk is compared on the severity counts of each
+// application's latest completed scan, so no scan is run.
+func (m *ApplicationService) CompareApplications(ctx context.Context, appA, appB string) (*model.ApplicationComparison, error) {
+	if appA == "" || appB == "" {
+		return nil, fmt.Errorf("applications a and b are both required: %w", ErrInvalidInput)
+	}
+	if appA == appB {
+		return nil, fmt.Errorf("cannot compare application %s with itself: %w", appA, ErrInvalidInput)
+	}
+	sideA, depsA, err := m.comparedApplication(ctx, appA)
+	if err != nil {
+		return nil, err
+	}
+	sideB, depsB, err := m.comparedApplication(ctx, appB)
+	if err != nil {
+		return nil, err
+	}
+
+	comparison := &model.ApplicationComparison{
+		A:       *sideA,
+		B:       *sideB,
+		Shared:  []model.SharedDependency{},
+		OnlyInA: []model.ComparedDependency{},
+		OnlyInB: []model.ComparedDependency{},
+	}
+	inB := make(map[string]model.ApplicationDependencyDetail, len(depsB))
+	for _, dep := range depsB {
+		inB[dep.DependencyID] = dep
+	}
+	inA := make(map[string]bool, len(depsA))
+	for _, dep := range depsA {
+		inA[dep.DependencyID] = true
+		other, shared := inB[dep.DependencyID]
+		if !shared {
+			comparison.OnlyInA = append(comparison.OnlyInA, model.ComparedDependency{DependencyID: dep.DependencyID, Name: dep.Name, Version: dep.UsedVersion})
+			continue
+		}
+		comparison.Shared = append(comparison.Shared, model.SharedDependency{
+			DependencyID: dep.DependencyID,
+			Name:         dep.Name,
+			VersionA:     dep.UsedVersion,
+			VersionB:     other.UsedVersion,
+			SameVersion:  dep.UsedVersion == other.UsedVersion,
+		})
+	}
+	for _, dep := range depsB {
+		if !inA[dep.DependencyID] {
+			comparison.OnlyInB = append(comparison.OnlyInB, model.ComparedDependency{DependencyID: dep.DependencyID, Name: dep.Name, Version: dep.UsedVersion})
+		}
+	}
+	slices.SortFunc(comparison.Shared, func(x, y model.SharedDependency) int { return strings.Compare(x.Name, y.Name) })
+	slices.SortFunc(comparison.OnlyInA, func(x, y model.ComparedDependency) int { return strings.Compare(x.Name, y.Name) })
+	slices.SortFunc(comparison.OnlyInB, func(x, y model.ComparedDependency) int { return strings.Compare(x.Name, y.Name) })
+
+	comparison.HigherRisk = higherRisk(sideA.LatestScan, sideB.LatestScan)
+	return comparison, nil
+}
+
+// comparedApplication loads one side of a comparison: the application's dependencies and the summary of its
+// latest completed scan
+func (m *ApplicationService) comparedApplication(ctx context.Context, appUID string) (*model.ComparedApplication, []model.ApplicationDependencyDetail, error) {
+	appID, err := uuid.Parse(appUID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid app ID %q: %w", appUID, ErrInvalidInput)
+	}
+	deps, err := m.ListApplicationDependency(ctx, appUID)
+	if err != nil {
+		return nil, nil, err
+	}
+	side := &model.ComparedApplication{AppID: deps.AppID, AppName: deps.AppName, Dependencies: len(deps.Dependencies)}
+
+	scan, err := m.scanResultRepository.GetLatestCompletedByAppID(ctx, appID)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, nil, fmt.Errorf("failed to fetch latest scan of application %s: %w", appUID, err)
+	}
+	if scan != nil {
+		scannedAt := scan.CreatedAt
+		if scan.CompletedAt != nil {
+			scannedAt = *scan.CompletedAt
+		}
+		side.LatestScan = &model.ComparedScan{
+			ScanID:       scan.ID.String(),
+			PolicyStatus: scan.PolicyStatus,
+			Severities: model.DashboardSeverityTotals{
+				Total:    scan.TotalVulnerabilities,
+				Critical: scan.Critical,
+				High:     scan.High,
+				Medium:   scan.Medium,
+				Low:      scan.Low,
+			},
+			ScannedAt: scannedAt.UTC(),
+		}
+	}
+	return side, deps.Dependencies, nil
+}
+
+// higherRisk names the scan with more critical, then more high, vulnerabilities
+func higherRisk(a, b *model.ComparedScan) string {
+	if a == nil || b == nil {
+		return "unknown"
+	}
+	switch {
+	case a.Severities.Critical != b.Severities.Critical:
+		if a.Severities.Critical > b.Severities.Critical {
+			return "a"
+		}
+		return "b"
+	case a.Severities.High != b.Severities.High:
+		if a.Severities.High > b.Severities.High {
+			return "a"
+		}
+		return "b"
+	}
+	return "equal"
+}
+
 func (m *ApplicationService) UpdateApplicationDependency(ctx context.Context, appUID string, input *model.UpdateApplicationDependencyRequest) (*model.UpdateApplicationDependencyResponse, error) {
 	appID, err := uuid.Parse(appUID)
 	if err != nil {
 		return nil, fmt.Errorf("invalid app ID: %w", err)
 	}
-	app, err := m.appRepository.GetByID(ctx, appID)
-	if err != nil || app == nil {
-		return nil, fmt.Errorf("application not found")
+	if _, err := m.appRepository.GetByID(ctx, appID); err != nil {
+		return nil, lookupError(err, "application "+appUID)
+	}
+
+	var (
+		updated       []string
+		failed        []model.DependencyUpdateFailure
+		conflicts     []model.DependencyUpdateConflict
+		githubSkipped int // updates saved without GitHub metadata because the anonymous rate limit is exhausted
+	)
+	fail := func(depID, reason, message string) {
+		failed = append(failed, model.DependencyUpdateFailure{DependencyID: depID, Reason: reason, Error: message})
+	}
+	conflict := func(depID, reason string) {
+		fail(depID, model.UpdateFailureConflict, reason)
+		conflicts = append(conflicts, model.DependencyUpdateConflict{DependencyID: depID, Status: 409, Reason: reason})
 	}
 
-	var updated, failed []string
 	for _, upd := range input.Updates {
 		depID, err := uuid.Parse(upd.DependencyID)
 		if err != nil {
-			failed = append(failed, upd.DependencyID)
+			fail(upd.DependencyID, model.UpdateFailureInvalidID, fmt.Sprintf("%q is not a valid dependency ID", upd.DependencyID))
 			continue
 		}
 
 		// Make sure at least UsedVersion is provided
 		if upd.UsedVersion == "" {
-			failed = append(failed, upd.DependencyID)
+			fail(upd.DependencyID, model.UpdateFailureEmptyVersion, "used_version is required")
 			continue
 		}
 
 		// Check if the app-dependency relationship exists
 		appDep, err := m.appToDepedencyRepository.GetByAppAndDependencyID(ctx, appID, depID)
-		if err != nil || appDep == nil {
-			failed = append(failed, upd.DependencyID)
+		if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && appDep == nil) {
+			fail(upd.DependencyID, model.UpdateFailureNotFound, "dependency is not used by this application")
+			continue
+		}
+		if err != nil {
+			fail(upd.DependencyID, model.UpdateFailureDBError, fmt.Sprintf("failed to load dependency: %v", err))
+			continue
+		}
+
+		// Reject edits based on an outdated listing before doing any GitHub work
+		if upd.UpdatedAt != nil && !upd.UpdatedAt.Equal(appDep.UpdatedAt) {
+			conflict(upd.DependencyID, fmt.Sprintf("dependency was modified at %s, after the supplied updated_at", appDep.UpdatedAt.UTC().Format(time.RFC3339Nano)))
 			continue
 		}
+		expectedUpdatedAt := appDep.UpdatedAt
 
-		var versionCommitSHA string
+		var used usedVersionMetadata
 		if upd.RepositoryURL != "" {
+			// Never store or fetch a URL pointing at an untrusted or internal host
+			if err := helper.ValidateRepositoryURL(upd.RepositoryURL, m.trustedHosts); err != nil {
+				slog.Warn("Rejected repository URL", "dependency_id", upd.DependencyID, "error", err)
+				fail(upd.DependencyID, model.UpdateFailureInvalidRepositoryURL, err.Error())
+				continue
+			}
 			// only fetch metadata if GitHub URL is provided
-			parts, isValid := helper.ExtractGitHubOwnerRepo(upd.RepositoryURL)
+			parts, isValid := helper.ExtractGitHubOwnerRepo(upd.RepositoryURL, m.githubHost)
+			var repoInfo map[string]interface{}
+			var err error
 			if isValid {
 				// Fetch repo info to validate URL
-				repoInfo, err := m.githubApiService.GetRepoInfo(parts.Owner, parts.Repo)
-				if err == nil && repoInfo != nil {
-					depedency, err := m.depedencyRepository.GetByID(ctx, appDep.DependencyID)
-					if err == nil && depedency != nil {
-						// Update repository URL if changed
-						var version string
-						versionCommitSHA, version, err = m.fetchAndUpdateDependencyMetadata(ctx, depedency, parts.Owner, parts.Repo, upd.UsedVersion, upd.RepositoryURL)
-						if err == nil && version != "" {
-							upd.UsedVersion = version // update to matched version if found
-						}
-					} else {
-						slog.Warn("Dependency not found when updating metadata", "dependency_id", appDep.DependencyID)
-					}
-				} else {
+				repoInfo, err = m.githubApiService.GetRepoInfo(parts.Owner, parts.Repo)
+			}
+			if errors.Is(err, usecase.ErrGitHubAnonymousRateLimit) {
+				// Still record the new version; the repository URL and metadata wait for a refresh
+				slog.Warn("Skipping GitHub metadata of dependency", "dependency_id", upd.DependencyID, "error", err)
+				githubSkipped++
+			} else if isValid {
+				if err != nil || repoInfo == nil {
 					slog.Warn("Failed to fetch repository info from GitHub", "owner", parts.Owner, "repo", parts.Repo, "error", err)
+					fail(upd.DependencyID, model.UpdateFailureGitHubFetchFailed, fmt.Sprintf("failed to fetch repository %s/%s from GitHub: %v", parts.Owner, parts.Repo, err))
+					continue
+				}
+				// Store moved repositories under the name GitHub redirected to
+				if canonical, ok := canonicalRepo(repoInfo, parts); ok {
+					parts = canonical
+					upd.RepositoryURL = canonical.URL()
+				}
+				depedency, err := m.depedencyRepository.GetByID(ctx, appDep.DependencyID)
+				if err != nil {
+					fail(upd.DependencyID, model.UpdateFailureDBError, fmt.Sprintf("failed to load dependency: %v", err))
+					continue
+				}
+				// Update repository URL if changed
+				used, err = m.fetchAndUpdateDependencyMetadata(ctx, depedency, parts.Owner, parts.Repo, upd.UsedVersion, upd.RepositoryURL)
+				if err != nil {
+					fail(upd.DependencyID, model.UpdateFailureDBError, fmt.Sprintf("failed to store dependency metadata: %v", err))
+					continue
+				}
+				if used.Version != "" {
+					upd.UsedVersion = used.Version // update to matched version if found
 				}
 			} else {
 				slog.Warn("Invalid GitHub URL provided, skipping metadata fetch", "url", upd.RepositoryURL)
@@ -428,91 +933,369 @@ func (m *ApplicationService) UpdateApplicationDependency(ctx context.Context, ap
 		}
 
 		appDep.UsedVersion = upd.UsedVersion
-		if versionCommitSHA != "" {
-			appDep.UsedCommitSHA = &versionCommitSHA
+		if used.CommitSHA != "" {
+			appDep.UsedCommitSHA = &used.CommitSHA
 		}
-		if err := m.appToDepedencyRepository.Update(ctx, appDep); err != nil {
-			failed = append(failed, upd.DependencyID)
+		applyStaleness(appDep, used.Staleness)
+		// Only write if nobody else updated the row while the metadata was being fetched
+		saved, err := m.appToDepedencyRepository.UpdateIfUnchanged(ctx, appDep, expectedUpdatedAt)
+		if err != nil {
+			fail(upd.DependencyID, model.UpdateFailureDBError, fmt.Sprintf("failed to update dependency: %v", err))
+			continue
+		}
+		if !saved {
+			conflict(upd.DependencyID, "dependency was modified concurrently by another request")
 			continue
 		}
 		updated = append(updated, upd.DependencyID)
 	}
 
 	msg := fmt.Sprintf("Updated: %d, Failed: %d", len(updated), len(failed))
-	return &model.UpdateApplicationDependencyResponse{
-		AppID:   appID.String(),
-		Updated: updated,
-		Failed:  failed,
-		Message: msg,
-	}, nil
+	resp := &model.UpdateApplicationDependencyResponse{
+		AppID:     appID.String(),
+		Updated:   updated,
+		Failed:    failed,
+		Conflicts: conflicts,
+		Message:   msg,
+	}
+	if githubSkipped > 0 {
+		resp.Warnings = []string{anonymousRateLimitWarning(githubSkipped)}
+	}
+	return resp, nil
 }
 
-func (m *ApplicationService) RemoveApplicationDependency(ctx context.Context, appUID string, deps []string) (interface{}, error) {
-	// Parse app UUID
-	appID, err := uuid.Parse(appUID)
+// RefreshDependencyMetadata re-fetches a dependency's default branch, latest commit and tags from GitHub. Applications
+// using the dependency are left untouched; a DependencyVersion is recorded when the latest commit changed.
+func (m *ApplicationService) RefreshDependencyMetadata(ctx context.Context, depUID string) (*model.DependencyMetadataResponse, error) {
+	depID, err := uuid.Parse(depUID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid app ID: %w", err)
+		return nil, fmt.Errorf("invalid dependency ID %q: %w", depUID, ErrInvalidInput)
 	}
-
-	// Check if app exists
-	app, err := m.appRepository.GetByID(ctx, appID)
+	dep, err := m.depedencyRepository.GetByID(ctx, depID)
 	if err != nil {
-		return nil, fmt.Errorf("app not found: %w", err)
+		return nil, lookupError(err, "dependency "+depUID)
 	}
 
-	// Prepare
-	results := make(map[string]interface{})
-	successful := 0
-	failed := 0
+	parts := helper.GitHubRepoParts{Owner: dep.Owner, Repo: dep.Repo}
+	if fromURL, ok := helper.ExtractGitHubOwnerRepo(derefString(dep.RepositoryURL), m.githubHost); ok {
+		parts = fromURL
+	}
+	if parts.Owner == "" || parts.Repo == "" {
+		return nil, fmt.Errorf("dependency %s has no GitHub repository: %w", dep.Name, ErrInvalidInput)
+	}
 
-	// Process each dependency ID
-	for _, depIDStr := range deps {
-		depID, err := uuid.Parse(depIDStr)
-		if err != nil {
-			results[depIDStr] = map[string]interface{}{
-				"status": "failed",
-				"error":  "invalid dependency ID format",
-			}
-			failed++
-			continue
-		}
+	// Fail instead of reporting stale metadata as refreshed when GitHub cannot be reached
+	repoInfo, err := m.githubApiService.GetRepoInfo(parts.Owner, parts.Repo)
+	if err != nil || repoInfo == nil {
+		return nil, fmt.Errorf("failed to fetch repository %s/%s from GitHub: %v", parts.Owner, parts.Repo, err)
+	}
+	var newRepoURL string
+	if canonical, ok := canonicalRepo(repoInfo, parts); ok {
+		parts, newRepoURL = canonical, canonical.URL()
+	}
 
-		// Check if the app-dependency relationship exists
-		appDep, err := m.appToDepedencyRepository.GetByAppAndDependencyID(ctx, appID, depID)
-		if err != nil && err != gorm.ErrRecordNotFound {
-			results[depIDStr] = map[string]interface{}{
-				"status": "failed",
-				"error":  fmt.Sprintf("database error checking app dependency: %v", err),
-			}
-			failed++
-			continue
-		}
-		if appDep == nil {
-			results[depIDStr] = map[string]interface{}{
-				"status": "skipped",
-				"reason": "dependency not associated with this application",
-			}
-			continue
-		}
+	previousCommitSHA := derefString(dep.LastCommitSHA)
+	if _, err := m.fetchAndUpdateDependencyMetadata(ctx, dep, parts.Owner, parts.Repo, "", newRepoURL); err != nil {
+		return nil, fmt.Errorf("failed to update dependency metadata: %w", err)
+	}
 
-		// Delete the app-dependency relationship
-		err = m.appToDepedencyRepository.Delete(ctx, appDep.ID)
-		if err != nil {
-			results[depIDStr] = map[string]interface{}{
-				"status": "failed",
-				"error":  fmt.Sprintf("failed to remove app dependency: %v", err),
-			}
-			failed++
-			continue
-		}
+	return &model.DependencyMetadataResponse{
+		DependencyID:  dep.ID.String(),
+		Name:          dep.Name,
+		Owner:         dep.Owner,
+		Repo:          dep.Repo,
+		RepositoryURL: dep.RepositoryURL,
+		DefaultBranch: dep.DefaultBranch,
+		LastCommitSHA: dep.LastCommitSHA,
+		LastCommitAt:  dep.LastCommitAt,
+		LastTag:       dep.LastTag,
+		CommitChanged: derefString(dep.LastCommitSHA) != previousCommitSHA,
+		RefreshedAt:   time.Now().UTC(),
+	}, nil
+}
 
-		results[depIDStr] = map[string]interface{}{
-			"status": "success",
-		}
-		successful++
+// GetDependencyMaintenance reports the stored repository signals of a dependency and the maintenance risk judged on
+// them, as an early warning of abandoned dependencies. GitHub is not queried; the signals are fetched whenever
+// the dependency's metadata is, e.g. through RefreshDependencyMetadata.
+func (m *ApplicationService) GetDependencyMaintenance(ctx context.Context, depUID string) (*model.DependencyMaintenanceResponse, error) {
+	depID, err := uuid.Parse(depUID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid dependency ID %q: %w", depUID, ErrInvalidInput)
 	}
+	dep, err := m.depedencyRepository.GetByID(ctx, depID)
+	if err != nil {
+		return nil, lookupError(err, "dependency "+depUID)
+	}
+
+	now := time.Now().UTC()
+	signals := maintenanceSignals(dep)
+	risk, reasons := helper.AssessMaintenanceRisk(signals, now)
+	if reasons == nil {
+		reasons = []string{}
+	}
+	return &model.DependencyMaintenanceResponse{
+		DependencyID:        dep.ID.String(),
+		Name:                dep.Name,
+		Owner:               dep.Owner,
+		Repo:                dep.Repo,
+		Archived:            dep.Archived,
+		Stars:               dep.Stars,
+		Collaborators:       dep.Collaborators,
+		LastCommitAt:        dep.LastCommitAt,
+		DaysSinceLastCommit: signals.DaysSinceLastCommit(now),
+		CheckedAt:           dep.SignalsCheckedAt,
+		Risk:                risk,
+		Reasons:             reasons,
+	}, nil
+}
 
-	return map[string]interface{}{
+// GetDependencyChangelog lists the commits and release notes between two versions of a dependency, e.g. the version
+// an application uses and the latest one, so the effort and risk of an upgrade can be judged before making it. Both
+// versions must match a tag of the dependency's GitHub repository.
+func (m *ApplicationService) GetDependencyChangelog(ctx context.Context, depUID, fromVersion, toVersion string) (*model.DependencyChangelogResponse, error) {
+	depID, err := uuid.Parse(depUID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid dependency ID %q: %w", depUID, ErrInvalidInput)
+	}
+	fromVersion, toVersion = strings.TrimSpace(fromVersion), strings.TrimSpace(toVersion)
+	if fromVersion == "" || toVersion == "" {
+		return nil, fmt.Errorf("from and to are required: %w", ErrInvalidInput)
+	}
+	dep, err := m.depedencyRepository.GetByID(ctx, depID)
+	if err != nil {
+		return nil, lookupError(err, "dependency "+depUID)
+	}
+
+	parts := helper.GitHubRepoParts{Owner: dep.Owner, Repo: dep.Repo}
+	if fromURL, ok := helper.ExtractGitHubOwnerRepo(derefString(dep.RepositoryURL), m.githubHost); ok {
+		parts = fromURL
+	}
+	if parts.Owner == "" || parts.Repo == "" {
+		return nil, fmt.Errorf("dependency %s has no GitHub repository: %w", dep.Name, ErrInvalidInput)
+	}
+
+	tags, err := m.githubApiService.ListTags(parts.Owner, parts.Repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch tags of %s/%s from GitHub: %v", parts.Owner, parts.Repo, err)
+	}
+	fromTag, toTag, err := helper.ValidateTagsExist(fromVersion, toVersion, tags)
+	if err != nil {
+		return nil, fmt.Errorf("%s/%s: %v: %w", parts.Owner, parts.Repo, err, ErrNotFound)
+	}
+	compare, err := m.githubApiService.CompareCommits(parts.Owner, parts.Repo, fromTag, toTag)
+	if err != nil || compare == nil {
+		return nil, fmt.Errorf("failed to compare %s...%s of %s/%s on GitHub: %v", fromTag, toTag, parts.Owner, parts.Repo, err)
+	}
+
+	resp := &model.DependencyChangelogResponse{
+		DependencyID: dep.ID.String(),
+		Name:         dep.Name,
+		Owner:        parts.Owner,
+		Repo:         parts.Repo,
+		From:         fromTag,
+		To:           toTag,
+		Status:       compare.Status,
+		TotalCommits: compare.TotalCommits,
+		CompareURL:   compare.HTMLURL,
+		Commits:      make([]model.ChangelogCommit, 0, len(compare.Commits)),
+		Releases:     []model.ChangelogRelease{},
+	}
+	inRange := make(map[string]bool, len(compare.Commits))
+	for _, commit := range compare.Commits {
+		inRange[commit.SHA] = true
+		resp.Commits = append(resp.Commits, model.ChangelogCommit{
+			SHA:     commit.SHA,
+			Message: commit.Commit.Message,
+			Author:  commit.Commit.Author.Name,
+			Date:    commit.Commit.Author.Date,
+			URL:     commit.HTMLURL,
+		})
+	}
+
+	// Release notes are extra detail: the commit list is still returned when they cannot be fetched
+	releases, err := m.githubApiService.ListReleases(parts.Owner, parts.Repo)
+	if err != nil {
+		slog.Warn("Failed to fetch releases from GitHub", "owner", parts.Owner, "repo", parts.Repo, "error", err)
+		return resp, nil
+	}
+	// A release belongs to the changelog when its tag points at one of the compared commits, which includes to
+	// but not from
+	tagCommits := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		name, _ := tag["name"].(string)
+		sha, _ := tag["commit_sha"].(string)
+		tagCommits[name] = sha
+	}
+	for _, release := range releases {
+		if !inRange[tagCommits[release.TagName]] {
+			continue
+		}
+		resp.Releases = append(resp.Releases, model.ChangelogRelease{
+			Tag:         release.TagName,
+			Name:        release.Name,
+			Body:        release.Body,
+			URL:         release.HTMLURL,
+			Prerelease:  release.Prerelease,
+			PublishedAt: release.PublishedAt,
+		})
+	}
+	return resp, nil
+}
+
+// BulkUpdateDependencyVersion moves every application that uses owner/repo at fromVersion to toVersion. The new
+// version's commit is resolved once; each application row is then written only if nobody changed it meanwhile.
+func (m *ApplicationService) BulkUpdateDependencyVersion(ctx context.Context, owner, repo, fromVersion, toVersion string) (*model.BulkBumpResponse, error) {
+	owner, repo = strings.TrimSpace(owner), strings.TrimSpace(repo)
+	fromVersion, toVersion = strings.TrimSpace(fromVersion), strings.TrimSpace(toVersion)
+	if owner == "" || repo == "" || fromVersion == "" || toVersion == "" {
+		return nil, fmt.Errorf("owner, repo, from_version and to_version are required: %w", ErrInvalidInput)
+	}
+	if helper.VersionsMatch(fromVersion, toVersion) {
+		return nil, fmt.Errorf("from_version and to_version are the same version: %w", ErrInvalidInput)
+	}
+
+	dep, err := m.depedencyRepository.GetByOwnerRepoCI(ctx, owner, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up dependency %s/%s: %w", owner, repo, err)
+	}
+	if dep == nil {
+		return nil, fmt.Errorf("dependency %s/%s: %w", owner, repo, ErrNotFound)
+	}
+	appDeps, err := m.appToDepedencyRepository.GetByDependencyID(ctx, dep.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applications using %s/%s: %w", owner, repo, err)
+	}
+
+	// Resolve the new version's tag and commit once for every application
+	used := usedVersionMetadata{Version: toVersion}
+	if m.githubApiService != nil {
+		if resolved, err := m.fetchAndUpdateDependencyMetadata(ctx, dep, dep.Owner, dep.Repo, toVersion, ""); err == nil {
+			used = resolved
+		} else {
+			slog.Warn("Failed to resolve bumped version on GitHub", "dependency", dep.Name, "version", toVersion, "error", err)
+		}
+	}
+
+	resp := &model.BulkBumpResponse{
+		DependencyID: dep.ID.String(),
+		Name:         dep.Name,
+		FromVersion:  fromVersion,
+		ToVersion:    used.Version,
+		ToCommitSHA:  used.CommitSHA,
+		Applications: []model.BulkBumpResult{},
+	}
+	for _, appDep := range appDeps {
+		if !helper.VersionsMatch(appDep.UsedVersion, fromVersion) {
+			continue
+		}
+		// Applications the caller cannot see are left alone
+		app, err := m.appRepository.GetByID(ctx, appDep.AppID)
+		if err != nil || app == nil {
+			continue
+		}
+
+		result := model.BulkBumpResult{AppID: app.ID.String(), AppName: app.Name, PreviousVersion: appDep.UsedVersion}
+		expectedUpdatedAt := appDep.UpdatedAt
+		appDep.UsedVersion = used.Version
+		appDep.UsedCommitSHA = nil
+		if used.CommitSHA != "" {
+			commitSHA := used.CommitSHA
+			appDep.UsedCommitSHA = &commitSHA
+		}
+		applyStaleness(appDep, used.Staleness)
+
+		saved, err := m.appToDepedencyRepository.UpdateIfUnchanged(ctx, appDep, expectedUpdatedAt)
+		switch {
+		case err != nil:
+			result.Status, result.Error = "failed", err.Error()
+		case !saved:
+			result.Status, result.Error = "conflict", "dependency was modified concurrently by another request"
+		default:
+			result.Status = "updated"
+			if err := m.auditApplicationAction(ctx, app.ID, "dependency_version_bumped",
+				map[string]interface{}{"dependency": dep.Name, "version": result.PreviousVersion},
+				map[string]interface{}{"dependency": dep.Name, "version": used.Version, "commit_sha": used.CommitSHA}); err != nil {
+				slog.Warn("Failed to audit dependency version bump", "app_id", app.ID, "error", err)
+			}
+		}
+		if result.Status == "updated" {
+			resp.Updated++
+		} else {
+			resp.Failed++
+		}
+		resp.Applications = append(resp.Applications, result)
+	}
+
+	resp.Message = fmt.Sprintf("Updated: %d, Failed: %d", resp.Updated, resp.Failed)
+	return resp, nil
+}
+
+func (m *ApplicationService) RemoveApplicationDependency(ctx context.Context, appUID string, deps []string) (interface{}, error) {
+	// Parse app UUID
+	appID, err := uuid.Parse(appUID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid app ID: %w", err)
+	}
+
+	// Check if app exists
+	app, err := m.appRepository.GetByID(ctx, appID)
+	if err != nil {
+		return nil, lookupError(err, "application "+appUID)
+	}
+
+	// Prepare
+	results := make(map[string]interface{})
+	successful := 0
+	failed := 0
+
+	// Process each dependency ID
+	for _, depIDStr := range deps {
+		depID, err := uuid.Parse(depIDStr)
+		if err != nil {
+			results[depIDStr] = map[string]interface{}{
+				"status": "failed",
+				"error":  "invalid dependency ID format",
+			}
+			failed++
+			continue
+		}
+
+		// Check if the app-dependency relationship exists
+		appDep, err := m.appToDepedencyRepository.GetByAppAndDependencyID(ctx, appID, depID)
+		if err != nil && err != gorm.ErrRecordNotFound {
+			results[depIDStr] = map[string]interface{}{
+				"status": "failed",
+				"error":  fmt.Sprintf("database error checking app dependency: %v", err),
+			}
+			failed++
+			continue
+		}
+		if appDep == nil {
+			results[depIDStr] = map[string]interface{}{
+				"status": "skipped",
+				"reason": "dependency not associated with this application",
+			}
+			continue
+		}
+
+		// Delete the app-dependency relationship
+		err = m.appToDepedencyRepository.Delete(ctx, appDep.ID)
+		if err != nil {
+			results[depIDStr] = map[string]interface{}{
+				"status": "failed",
+				"error":  fmt.Sprintf("failed to remove app dependency: %v", err),
+			}
+			failed++
+			continue
+		}
+
+		results[depIDStr] = map[string]interface{}{
+			"status": "success",
+		}
+		successful++
+	}
+
+	return map[string]interface{}{
 		"app_id":   app.ID.String(),
 		"app_name": app.Name,
 		"results":  results,
@@ -524,45 +1307,207 @@ func (m *ApplicationService) RemoveApplicationDependency(ctx context.Context, ap
 	}, nil
 }
 
-func (m *ApplicationService) RemoveApplication(ctx context.Context, appUID string) error {
-	// Find the app by ID (UUID)
+// UpdateApplication changes an application's name, description, runtime or framework.
+// Changing the runtime changes the OSV ecosystem its dependencies map to, so a rescan is recommended.
+func (m *ApplicationService) UpdateApplication(ctx context.Context, appUID string, req *model.UpdateApplicationRequest) (*model.UpdateApplicationResponse, error) {
 	appID, err := uuid.Parse(appUID)
 	if err != nil {
-		return fmt.Errorf("invalid app ID: %w", err)
+		return nil, fmt.Errorf("invalid app ID: %w", ErrInvalidInput)
 	}
 	app, err := m.appRepository.GetByID(ctx, appID)
 	if err != nil {
-		return fmt.Errorf("failed to fetch app: %w", err)
+		return nil, lookupError(err, "application "+appUID)
+	}
+
+	var runtimeName, frameworkName string
+	if app.RuntimeID != nil {
+		if runtime, _ := m.runTimeRepository.GetByID(ctx, *app.RuntimeID); runtime != nil {
+			runtimeName = runtime.Name
+		}
 	}
-	if app == nil {
-		return fmt.Errorf("application not found")
+	if app.FrameworkID != nil {
+		if framework, _ := m.frameWorkRepository.GetByID(ctx, *app.FrameworkID); framework != nil {
+			frameworkName = framework.Name
+		}
 	}
 
-	return m.appRepository.UpdateStatus(ctx, appID, "inactive")
+	oldValues := map[string]interface{}{}
+	newValues := map[string]interface{}{}
+	runtimeChanged := false
+
+	if req.AppName != nil {
+		name := strings.TrimSpace(*req.AppName)
+		if name == "" {
+			return nil, fmt.Errorf("application name cannot be empty: %w", ErrInvalidInput)
+		}
+		if name != app.Name {
+			existing, err := m.appRepository.GetByName(ctx, name)
+			if err != nil {
+				return nil, err
+			}
+			if existing != nil && existing.ID != app.ID {
+				return nil, fmt.Errorf("application with name %s already exists: %w", name, ErrConflict)
+			}
+			oldValues["app_name"], newValues["app_name"] = app.Name, name
+			app.Name = name
+		}
+	}
+
+	if req.RuntimeType != nil {
+		if !m.depedencyParserService.IsRuntimeEnabled(*req.RuntimeType) {
+			return nil, fmt.Errorf("runtime %s is not supported or disabled: %w", *req.RuntimeType, ErrInvalidInput)
+		}
+		runtime, err := m.runTimeRepository.GetByNameCI(ctx, *req.RuntimeType)
+		if err != nil {
+			return nil, err
+		}
+		if runtime == nil {
+			return nil, fmt.Errorf("runtime type %s not found: %w", *req.RuntimeType, ErrInvalidInput)
+		}
+		if app.RuntimeID == nil || *app.RuntimeID != runtime.ID {
+			oldValues["runtime_type"], newValues["runtime_type"] = runtimeName, runtime.Name
+			app.RuntimeID = &runtime.ID
+			runtimeName = runtime.Name
+			runtimeChanged = true
+		}
+	}
+
+	if req.Framework != nil {
+		framework, err := m.frameWorkRepository.GetByNameCI(ctx, *req.Framework)
+		if err != nil {
+			return nil, err
+		}
+		if framework == nil {
+			return nil, fmt.Errorf("framework %s not found: %w", *req.Framework, ErrInvalidInput)
+		}
+		if app.FrameworkID == nil || *app.FrameworkID != framework.ID {
+			oldValues["framework"], newValues["framework"] = frameworkName, framework.Name
+			app.FrameworkID = &framework.ID
+			frameworkName = framework.Name
+		}
+	}
+
+	// The resulting runtime and framework must still fit together when either one changed
+	if (req.RuntimeType != nil || req.Framework != nil) && app.RuntimeID != nil && app.FrameworkID != nil {
+		framework, err := m.frameWorkRepository.GetByID(ctx, *app.FrameworkID)
+		if err != nil && !errors.Is(err, repository.ErrNotFound) {
+			return nil, lookupError(err, "framework")
+		}
+		if err == nil && !frameworkBelongsToRuntime(framework, *app.RuntimeID) {
+			return nil, fmt.Errorf("framework %s does not belong to runtime %s: %w", framework.Name, runtimeName, ErrInvalidInput)
+		}
+	}
+
+	if req.Description != nil && *req.Description != derefString(app.Description) {
+		oldValues["description"], newValues["description"] = derefString(app.Description), *req.Description
+		description := *req.Description
+		app.Description = &description
+	}
+
+	message := "No changes to apply."
+	if len(newValues) > 0 {
+		// The metadata change and its audit entry are committed together
+		err = m.inTransaction(ctx, func(txCtx context.Context) error {
+			if err := m.appRepository.Update(txCtx, app); err != nil {
+				return fmt.Errorf("failed to update application: %w", err)
+			}
+			if err := m.auditApplicationAction(txCtx, app.ID, "application_updated", oldValues, newValues); err != nil {
+				return fmt.Errorf("failed to create audit trail for application: %w", err)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		message = "Application updated."
+	}
+
+	response := &model.UpdateApplicationResponse{
+		AppID:             app.ID.String(),
+		AppName:           app.Name,
+		RuntimeType:       runtimeName,
+		Framework:         frameworkName,
+		Description:       derefString(app.Description),
+		Status:            app.Status,
+		RescanRecommended: runtimeChanged,
+		Message:           message,
+	}
+	if runtimeChanged {
+		response.Warnings = append(response.Warnings, "runtime changed — rescan recommended since the dependency ecosystem mapping changed")
+	}
+	return response, nil
 }
 
-func (m *ApplicationService) RecoverApplication(ctx context.Context, appUID string) error {
+func (m *ApplicationService) RemoveApplication(ctx context.Context, appUID string) error {
 	// Find the app by ID (UUID)
 	appID, err := uuid.Parse(appUID)
 	if err != nil {
 		return fmt.Errorf("invalid app ID: %w", err)
 	}
-	app, err := m.appRepository.GetByID(ctx, appID)
+	if _, err := m.appRepository.GetByID(ctx, appID); err != nil {
+		return lookupError(err, "application "+appUID)
+	}
+
+	return m.appRepository.UpdateStatus(ctx, appID, "inactive")
+}
+
+func (m *ApplicationService) RecoverApplication(ctx context.Context, appUID string) error {
+	// Find the app by ID (UUID)
+	appID, err := uuid.Parse(appUID)
 	if err != nil {
-		return fmt.Errorf("failed to fetch app: %w", err)
+		return fmt.Errorf("invalid app ID: %w", err)
 	}
-	if app == nil {
-		return fmt.Errorf("application not found")
+	if _, err := m.appRepository.GetByID(ctx, appID); err != nil {
+		return lookupError(err, "application "+appUID)
 	}
 	return m.appRepository.UpdateStatus(ctx, appID, "active")
 }
 
-func (m *ApplicationService) ListApplications(ctx context.Context) (*model.ListApplicationsResponse, error) {
-	apps, err := m.appRepository.GetAll(ctx)
+func (m *ApplicationService) ListApplications(ctx context.Context, tags []string) (*model.ListApplicationsResponse, error) {
+	tags, err := normalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+
+	var apps []*entity.App
+	if len(tags) > 0 {
+		if m.tagRepository == nil {
+			return nil, fmt.Errorf("tags are not available")
+		}
+		apps, err = m.appRepository.GetByTags(ctx, tags)
+	} else {
+		apps, err = m.appRepository.GetAll(ctx)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch applications: %w", err)
 	}
 
+	// The latest scans deciding which applications are reported vulnerable are read in one query
+	latestScans := map[uuid.UUID]*entity.ScanResult{}
+	if m.scanResultRepository != nil {
+		scans, err := m.scanResultRepository.GetLatestCompletedPerApp(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch latest scans: %w", err)
+		}
+		for _, scan := range scans {
+			if scan.AppID != nil {
+				latestScans[*scan.AppID] = scan
+			}
+		}
+	}
+
+	// Tags of all listed applications are read in one query
+	appTags := map[uuid.UUID][]string{}
+	if m.tagRepository != nil {
+		appIDs := make([]uuid.UUID, 0, len(apps))
+		for _, app := range apps {
+			appIDs = append(appIDs, app.ID)
+		}
+		if appTags, err = m.tagRepository.GetNamesByAppIDs(ctx, appIDs); err != nil {
+			return nil, fmt.Errorf("failed to fetch application tags: %w", err)
+		}
+	}
+
 	var summaries []model.ApplicationSummary
 	for _, app := range apps {
 		runtimeName := ""
@@ -584,8 +1529,9 @@ func (m *ApplicationService) ListApplications(ctx context.Context) (*model.ListA
 			AppName:     app.Name,
 			RuntimeType: runtimeName,
 			Framework:   frameworkName,
-			Status:      app.Status,
+			Status:      reportedStatus(app, latestScans[app.ID]),
 			Description: derefString(app.Description),
+			Tags:        nonNilStrings(appTags[app.ID]),
 		})
 	}
 
@@ -595,171 +1541,595 @@ func (m *ApplicationService) ListApplications(ctx context.Context) (*model.ListA
 	}, nil
 }
 
-func (m *ApplicationService) GetApplicationStatus(ctx context.Context, appUID string) (map[string]interface{}, error) {
-	appID, err := uuid.Parse(appUID)
+// CloneApplication creates an application with the runtime, framework, description and dependency links of
+// an existing one. Used versions and monitoring settings are copied; monitoring history is not. The clone
+// starts "inactive" while the dependencies' GitHub metadata is refreshed in the background.
+func (m *ApplicationService) CloneApplication(ctx context.Context, sourceAppUID, newName string) (*model.CloneApplicationResponse, error) {
+	sourceID, err := uuid.Parse(sourceAppUID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid app ID: %w", err)
+		return nil, fmt.Errorf("invalid app ID: %w", ErrInvalidInput)
 	}
-	app, err := m.appRepository.GetByID(ctx, appID)
-	if err != nil || app == nil {
-		return nil, fmt.Errorf("application not found")
+	newName = strings.TrimSpace(newName)
+	if newName == "" {
+		return nil, fmt.Errorf("application name cannot be empty: %w", ErrInvalidInput)
 	}
-	appDeps, err := m.appToDepedencyRepository.GetByAppID(ctx, appID)
+	source, err := m.appRepository.GetByID(ctx, sourceID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch app dependencies: %w", err)
+		return nil, lookupError(err, "application "+sourceAppUID)
 	}
-	lastUpdated := ""
-	if !app.UpdatedAt.IsZero() {
-		lastUpdated = app.UpdatedAt.Format(time.RFC3339)
+	existing, err := m.appRepository.GetByName(ctx, newName)
+	if err != nil {
+		return nil, err
 	}
-	status := model.ApplicationStatus{
-		AppID:           app.ID.String(),
-		AppName:         app.Name,
-		Status:          app.Status,
-		DependencyCount: len(appDeps),
-		LastUpdated:     lastUpdated,
+	if existing != nil {
+		return nil, fmt.Errorf("application with name %s already exists: %w", newName, ErrConflict)
 	}
-	return map[string]interface{}{"status": status}, nil
-}
-
-func (m *ApplicationService) ScanApplicationDependencies(ctx context.Context, appUID string) (interface{}, error) {
-	appID, err := uuid.Parse(appUID)
+	sourceDeps, err := m.appToDepedencyRepository.GetByAppID(ctx, source.ID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid app ID: %w", err)
+		return nil, fmt.Errorf("failed to fetch dependencies of application %s: %w", sourceAppUID, err)
 	}
-	app, err := m.appRepository.GetByID(ctx, appID)
-	if err != nil || app == nil {
-		return nil, fmt.Errorf("application not found")
+
+	clone := &entity.App{
+		ID:          uuid.New(),
+		Name:        newName,
+		RuntimeID:   source.RuntimeID,
+		FrameworkID: source.FrameworkID,
+		Description: source.Description,
+		Status:      "inactive",
 	}
-	appDeps, err := m.appToDepedencyRepository.GetByAppID(ctx, appID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch app dependencies: %w", err)
+	if ownerID, ok := repository.OwnerScope(ctx); ok {
+		clone.OwnerID = &ownerID
+	}
+	cloneDeps := make([]*entity.AppDependency, 0, len(sourceDeps))
+	for _, appDep := range sourceDeps {
+		cloneDeps = append(cloneDeps, &entity.AppDependency{
+			ID:                     uuid.New(),
+			AppID:                  clone.ID,
+			DependencyID:           appDep.DependencyID,
+			UsedCommitSHA:          appDep.UsedCommitSHA,
+			UsedVersion:            appDep.UsedVersion,
+			UsedTag:                appDep.UsedTag,
+			Ecosystem:              appDep.Ecosystem,
+			Transitive:             appDep.Transitive,
+			IsMonitored:            appDep.IsMonitored,
+			MonitoringEnabled:      appDep.MonitoringEnabled,
+			PollingIntervalMinutes: appDep.PollingIntervalMinutes,
+			ReleasesBehind:         appDep.ReleasesBehind,
+			StalenessDays:          appDep.StalenessDays,
+			StalenessCheckedAt:     appDep.StalenessCheckedAt,
+		})
 	}
 
-	runtime, err := m.runTimeRepository.GetByID(ctx, *app.RuntimeID)
-	if err != nil || runtime == nil {
-		return nil, fmt.Errorf("failed to fetch runtime info for application")
+	// The application, its dependency links and the audit entry are committed together
+	err = m.inTransaction(ctx, func(txCtx context.Context) error {
+		if err := m.appRepository.Create(txCtx, clone); err != nil {
+			return fmt.Errorf("failed to create application: %w", err)
+		}
+		for _, appDep := range cloneDeps {
+			if err := m.appToDepedencyRepository.Create(txCtx, appDep); err != nil {
+				return fmt.Errorf("failed to copy dependency %s: %w", appDep.DependencyID, err)
+			}
+		}
+		return m.auditApplicationAction(txCtx, clone.ID, "application_cloned", nil, map[string]interface{}{
+			"app_name":         newName,
+			"source_app_id":    source.ID.String(),
+			"source_app_name":  source.Name,
+			"dependency_count": len(cloneDeps),
+		})
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	framework, err := m.frameWorkRepository.GetByID(ctx, *app.FrameworkID)
-	frameworkName := ""
-	if err == nil && framework != nil {
-		frameworkName = framework.Name
+	m.backgroundJobs.Add(1)
+	go func() {
+		defer m.backgroundJobs.Done()
+		m.enrichClonedDependencies(m.rootCtx, clone, cloneDeps)
+	}()
+
+	var runtimeName, frameworkName string
+	if clone.RuntimeID != nil {
+		if runtime, _ := m.runTimeRepository.GetByID(ctx, *clone.RuntimeID); runtime != nil {
+			runtimeName = runtime.Name
+		}
 	}
+	if clone.FrameworkID != nil {
+		if framework, _ := m.frameWorkRepository.GetByID(ctx, *clone.FrameworkID); framework != nil {
+			frameworkName = framework.Name
+		}
+	}
+	return &model.CloneApplicationResponse{
+		AppID:           clone.ID.String(),
+		AppName:         clone.Name,
+		SourceAppID:     source.ID.String(),
+		RuntimeType:     runtimeName,
+		Framework:       frameworkName,
+		Description:     derefString(clone.Description),
+		Status:          clone.Status,
+		DependencyCount: len(cloneDeps),
+		Message:         "Application cloned, dependency metadata is being refreshed in background.",
+	}, nil
+}
 
+// enrichClonedDependencies refreshes the GitHub metadata of a cloned application's dependencies and the
+// commit and staleness of the versions it uses, then activates the application. The dependency links were
+// copied already, so a failed refresh only leaves the copied metadata in place.
+func (m *ApplicationService) enrichClonedDependencies(ctx context.Context, app *entity.App, appDeps []*entity.AppDependency) {
 	var (
-		wg            sync.WaitGroup
-		mu            sync.Mutex
-		findings      []model.ScanFinding
-		depsWithVulns []helper.DependencyWithVulnerabilities
-		totalCritical int
-		totalHigh     int
-		totalMedium   int
-		totalLow      int
+		wg        sync.WaitGroup
+		semaphore = make(chan struct{}, m.processingWorkers)
 	)
-
 	for _, appDep := range appDeps {
 		wg.Add(1)
-		go func(ad *entity.AppDependency) {
+		semaphore <- struct{}{}
+		go func(appDep *entity.AppDependency) {
 			defer wg.Done()
-			dep, err := m.depedencyRepository.GetByID(ctx, ad.DependencyID)
-			if err != nil || dep == nil || dep.Owner == "" || dep.Repo == "" {
-				return
-			}
-
-			depInfo := parser.DependencyInfo{
-				Name:         dep.Name,
-				Owner:        dep.Owner,
-				Repo:         dep.Repo,
-				GitHubURL:    derefString(dep.RepositoryURL),
-				Version:      ad.UsedVersion,
-				IsGitHubRepo: dep.Owner != "" && dep.Repo != "",
-				Runtime:      runtime.Name,
+			defer func() { <-semaphore }()
+			if err := m.refreshUsedVersion(ctx, appDep); err != nil {
+				slog.Warn("Failed to refresh cloned dependency", "app_id", app.ID, "dependency_id", appDep.DependencyID, "error", err)
 			}
-
-			result, err := m.cveService.CheckDependencyVulnerabilities(ctx, depInfo)
-			if err != nil {
-				slog.Warn("Failed to check vulnerabilities", "dependency", dep.Name, "error", err)
-				return
-			}
-
-			severity := "low" // default
-			if result.CriticalCount > 0 {
-				severity = "critical"
-			} else if result.HighCount > 0 {
-				severity = "high"
-			} else if result.MediumCount > 0 {
-				severity = "medium"
-			} else if result.LowCount > 0 {
-				severity = "low"
-			}
-
-			var vulnIDs []string
-			for _, v := range result.Vulnerabilities {
-				vulnIDs = append(vulnIDs, v.ID)
-			}
-
-			recommendation := ""
-			if len(result.Recommendations) > 0 {
-				recommendation = result.Recommendations[0]
-			}
-
-			finding := model.ScanFinding{
-				Dependency:       dep.Name + ":" + dep.Repo,
-				Version:          ad.UsedVersion,
-				Severity:         severity,
-				VulnerabilityIDs: vulnIDs,
-				Recommendation:   recommendation,
-			}
-
-			// Create enhanced dependency with vulnerabilities for SBOM
-			depWithVuln := helper.DependencyWithVulnerabilities{
-				Name:            dep.Name,
-				Version:         ad.UsedVersion,
-				Owner:           dep.Owner,
-				Repo:            dep.Repo,
-				RepositoryURL:   derefString(dep.RepositoryURL),
-				Runtime:         runtime.Name,
-				IsGitHub:        dep.Owner != "" && dep.Repo != "",
-				Vulnerabilities: result.Vulnerabilities,
-				RiskScore:       result.RiskScore,
-			}
-
-			mu.Lock()
-			findings = append(findings, finding)
-			depsWithVulns = append(depsWithVulns, depWithVuln)
-			totalCritical += result.CriticalCount
-			totalHigh += result.HighCount
-			totalMedium += result.MediumCount
-			totalLow += result.LowCount
-			mu.Unlock()
 		}(appDep)
 	}
 	wg.Wait()
 
-	summary := helper.AggregateVulnerabilitySummary(findings)
-	failOn := []string{"high", "critical"}
-	policyStatus, policyReason := helper.EvaluatePolicy(summary, failOn)
+	if err := m.appRepository.UpdateStatus(context.WithoutCancel(ctx), app.ID, "active"); err != nil {
+		slog.Error("failed to update app status after cloning", "app_id", app.ID, "error", err)
+	}
+}
 
-	artifacts := model.ScanArtifacts{
-		VulnerabilityReport: fmt.Sprintf("https://your-app/api/scans/%s/report", app.ID.String()),
-		SBOM:                fmt.Sprintf("https://your-app/api/scans/%s/sbom", app.ID.String()),
+// refreshUsedVersion re-fetches the metadata of appDep's dependency and records the commit and staleness of
+// the version appDep uses
+func (m *ApplicationService) refreshUsedVersion(ctx context.Context, appDep *entity.AppDependency) error {
+	if m.githubApiService == nil {
+		return nil
+	}
+	dep, err := m.depedencyRepository.GetByID(ctx, appDep.DependencyID)
+	if err != nil {
+		return err
+	}
+	parts := helper.GitHubRepoParts{Owner: dep.Owner, Repo: dep.Repo}
+	if fromURL, ok := helper.ExtractGitHubOwnerRepo(derefString(dep.RepositoryURL), m.githubHost); ok {
+		parts = fromURL
+	}
+	if parts.Owner == "" || parts.Repo == "" {
+		return nil
+	}
+	used, err := m.fetchAndUpdateDependencyMetadata(ctx, dep, parts.Owner, parts.Repo, appDep.UsedVersion, "")
+	if err != nil {
+		return err
 	}
+	if used.CommitSHA != "" {
+		appDep.UsedCommitSHA = &used.CommitSHA
+	}
+	applyStaleness(appDep, used.Staleness)
+	return m.appToDepedencyRepository.Update(context.WithoutCancel(ctx), appDep)
+}
 
-	result := model.ScanApplicationResult{
-		AppID:      app.ID.String(),
-		AppName:    app.Name,
-		ScanStatus: "completed",
-		Summary:    summary,
-		Policies:   model.ScanPolicy{FailOn: failOn, Status: policyStatus, Reason: policyReason},
-		Artifacts:  artifacts,
-		Findings:   findings,
+// AddApplicationTags attaches tags to an application. Tags it already carries are left as they are.
+func (m *ApplicationService) AddApplicationTags(ctx context.Context, appUID string, tags []string) (*model.ApplicationTagsResponse, error) {
+	return m.changeApplicationTags(ctx, appUID, tags, true)
+}
+
+// RemoveApplicationTags detaches tags from an application. Tags it does not carry are ignored.
+func (m *ApplicationService) RemoveApplicationTags(ctx context.Context, appUID string, tags []string) (*model.ApplicationTagsResponse, error) {
+	return m.changeApplicationTags(ctx, appUID, tags, false)
+}
+
+// changeApplicationTags adds or removes tags and audits the change in one transaction
+func (m *ApplicationService) changeApplicationTags(ctx context.Context, appUID string, tags []string, add bool) (*model.ApplicationTagsResponse, error) {
+	appID, err := uuid.Parse(appUID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid app ID: %w", ErrInvalidInput)
+	}
+	tags, err = normalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+	if len(tags) == 0 {
+		return nil, fmt.Errorf("at least one tag is required: %w", ErrInvalidInput)
+	}
+	if m.tagRepository == nil {
+		return nil, fmt.Errorf("tags are not available")
+	}
+	if _, err := m.appRepository.GetByID(ctx, appID); err != nil {
+		return nil, lookupError(err, "application "+appUID)
+	}
+
+	var current []string
+	err = m.inTransaction(ctx, func(txCtx context.Context) error {
+		before, err := m.tagNames(txCtx, appID)
+		if err != nil {
+			return err
+		}
+		for _, name := range tags {
+			if add {
+				tag, err := m.tagRepository.GetOrCreate(txCtx, name)
+				if err != nil {
+					return fmt.Errorf("failed to create tag %s: %w", name, err)
+				}
+				if err := m.tagRepository.AddToApp(txCtx, appID, tag.ID); err != nil {
+					return fmt.Errorf("failed to add tag %s: %w", name, err)
+				}
+				continue
+			}
+			tag, err := m.tagRepository.GetByName(txCtx, name)
+			if err != nil {
+				return fmt.Errorf("failed to fetch tag %s: %w", name, err)
+			}
+			if tag == nil {
+				continue
+			}
+			if err := m.tagRepository.RemoveFromApp(txCtx, appID, tag.ID); err != nil {
+				return fmt.Errorf("failed to remove tag %s: %w", name, err)
+			}
+		}
+		if current, err = m.tagNames(txCtx, appID); err != nil {
+			return err
+		}
+		if !slices.Equal(before, current) {
+			action := "application_tags_added"
+			if !add {
+				action = "application_tags_removed"
+			}
+			if err := m.auditApplicationAction(txCtx, appID, action, map[string]interface{}{"tags": before}, map[string]interface{}{"tags": current}); err != nil {
+				return fmt.Errorf("failed to create audit trail for application: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	message := "Tags added."
+	if !add {
+		message = "Tags removed."
+	}
+	return &model.ApplicationTagsResponse{
+		AppID:   appUID,
+		Tags:    current,
+		Message: message,
+	}, nil
+}
+
+// tagNames returns the sorted tag names of an application, never nil
+func (m *ApplicationService) tagNames(ctx context.Context, appID uuid.UUID) ([]string, error) {
+	tags, err := m.tagRepository.GetByAppID(ctx, appID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch application tags: %w", err)
+	}
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+	return names, nil
+}
+
+// GetFrameworksByRuntime lists the frameworks associated with a runtime. The runtime is looked up
+// by numeric ID first and otherwise by name (case-insensitive).
+func (m *ApplicationService) GetFrameworksByRuntime(ctx context.Context, runtime string) (*model.ListRuntimeFrameworksResponse, error) {
+	runtime = strings.TrimSpace(runtime)
+	if runtime == "" {
+		return nil, fmt.Errorf("runtime is required: %w", ErrInvalidInput)
+	}
+
+	var runtimeEntity *entity.Runtime
+	var err error
+	if id, convErr := strconv.Atoi(runtime); convErr == nil {
+		runtimeEntity, err = m.runTimeRepository.GetByID(ctx, id)
+		if errors.Is(err, repository.ErrNotFound) {
+			runtimeEntity, err = nil, nil
+		}
+	} else {
+		runtimeEntity, err = m.runTimeRepository.GetByNameCI(ctx, runtime)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch runtime: %w", err)
+	}
+	if runtimeEntity == nil {
+		return nil, fmt.Errorf("runtime %s: %w", runtime, ErrNotFound)
+	}
+
+	frameworks, err := m.frameWorkRepository.GetByRuntimeID(ctx, runtimeEntity.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch frameworks: %w", err)
+	}
+	summaries := make([]model.FrameworkSummary, 0, len(frameworks))
+	for _, fw := range frameworks {
+		summaries = append(summaries, model.FrameworkSummary{ID: fw.ID, Name: fw.Name})
+	}
+
+	return &model.ListRuntimeFrameworksResponse{
+		RuntimeID:  runtimeEntity.ID,
+		Runtime:    runtimeEntity.Name,
+		Frameworks: summaries,
+		Message:    "Frameworks fetched successfully.",
+	}, nil
+}
+
+// Audit trail page sizes
+const (
+	DefaultAuditPageSize = 50
+	MaxAuditPageSize     = 200
+)
+
+// ListApplicationAudit returns one page of the audit entries recorded for an application, newest first
+func (m *ApplicationService) ListApplicationAudit(ctx context.Context, appUID string, limit, offset int) (*model.ApplicationAuditResponse, error) {
+	if limit <= 0 {
+		limit = DefaultAuditPageSize
+	}
+	if limit > MaxAuditPageSize {
+		return nil, fmt.Errorf("limit must be at most %d: %w", MaxAuditPageSize, ErrInvalidInput)
+	}
+	if offset < 0 {
+		return nil, fmt.Errorf("offset must not be negative: %w", ErrInvalidInput)
+	}
+	appID, err := uuid.Parse(appUID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid app ID: %w", ErrInvalidInput)
+	}
+	if _, err := m.appRepository.GetByID(ctx, appID); err != nil {
+		return nil, lookupError(err, "application "+appUID)
+	}
+	if m.auditTrailRepository == nil {
+		return nil, errors.New("audit trail repository is not configured")
+	}
+
+	audits, err := m.auditTrailRepository.GetByEntity(ctx, "app", appID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch audit trail: %w", err)
+	}
+	resp := &model.ApplicationAuditResponse{
+		AppID:   appID.String(),
+		Entries: make([]model.AuditEntry, 0, len(audits)),
+		Limit:   limit,
+		Offset:  offset,
+	}
+	for _, audit := range audits {
+		resp.Entries = append(resp.Entries, model.AuditEntry{
+			ID:               audit.ID.String(),
+			Action:           audit.Action,
+			OldValues:        helper.DecodeJSONColumn(audit.OldValues),
+			NewValues:        helper.DecodeJSONColumn(audit.NewValues),
+			Context:          helper.DecodeJSONColumn(audit.Context),
+			PerformedBy:      audit.PerformedBy,
+			PerformedAt:      audit.PerformedAt,
+			SecurityRelevant: audit.SecurityRelevant,
+			RiskLevel:        derefString(audit.RiskLevel),
+		})
+	}
+	return resp, nil
+}
+
+func (m *ApplicationService) GetApplicationStatus(ctx context.Context, appUID string) (map[string]interface{}, error) {
+	appID, err := uuid.Parse(appUID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid app ID: %w", err)
+	}
+	app, err := m.appRepository.GetByID(ctx, appID)
+	if err != nil {
+		return nil, lookupError(err, "application "+appUID)
+	}
+	appDeps, err := m.appToDepedencyRepository.GetByAppID(ctx, appID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch app dependencies: %w", err)
+	}
+	latest, err := m.latestCompletedScan(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+	lastUpdated := ""
+	if !app.UpdatedAt.IsZero() {
+		lastUpdated = app.UpdatedAt.UTC().Format(time.RFC3339)
+	}
+	status := model.ApplicationStatus{
+		AppID:           app.ID.String(),
+		AppName:         app.Name,
+		Status:          reportedStatus(app, latest),
+		DependencyCount: len(appDeps),
+		LastUpdated:     lastUpdated,
+	}
+	return map[string]interface{}{"status": status}, nil
+}
+
+func (m *ApplicationService) ScanApplicationDependencies(ctx context.Context, appUID string) (interface{}, error) {
+	app, err := m.getScanTarget(ctx, appUID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := m.runApplicationScan(ctx, app, uuid.New())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := persistScanResult(ctx, m.scanResultRepository, &app.ID, "application", result); err != nil {
+		slog.Error("Failed to persist scan result", "scan_id", result.ScanID, "error", err)
+	} else {
+		resolveFixedRemediations(ctx, m.remediationRepository, app.ID, result)
+	}
+
+	return result, nil
+}
+
+// StartApplicationScan queues a scan of the application's dependencies and runs it in the background.
+// The returned scan ID can be polled through GetScanStatus and GetScanResult.
+func (m *ApplicationService) StartApplicationScan(ctx context.Context, appUID string) (*model.ScanJobStatus, error) {
+	app, err := m.getScanTarget(ctx, appUID)
+	if err != nil {
+		return nil, err
+	}
+	if m.scanResultRepository == nil {
+		return nil, fmt.Errorf("scan result storage not available")
+	}
+
+	scan := &entity.ScanResult{
+		ID:        uuid.New(),
+		AppID:     &app.ID,
+		AppName:   app.Name,
+		ScanType:  "application",
+		Status:    "queued",
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := m.scanResultRepository.Create(ctx, scan); err != nil {
+		return nil, fmt.Errorf("failed to create scan job: %w", err)
+	}
+
+	// Snapshot the queued status before the background job starts mutating the record
+	status := toScanJobStatus(scan)
+
+	// Per-request SBOM and policy options travel with the job, which runs outside the request context
+	failOn, minScore := helper.ScanPolicyFor(ctx)
+	jobCtx := helper.WithDeterministicSBOM(m.rootCtx, helper.DeterministicSBOM(ctx))
+	jobCtx = helper.WithScanPolicy(jobCtx, &helper.ScanPolicyOverride{FailOn: failOn, MinScore: minScore})
+
+	m.backgroundJobs.Add(1)
+	go func() {
+		defer m.backgroundJobs.Done()
+		m.executeScanJob(jobCtx, app, scan)
+	}()
+
+	return status, nil
+}
+
+// Shutdown cancels background dependency processing and scans, then waits for them to exit
+func (m *ApplicationService) Shutdown(ctx context.Context) error {
+	m.cancelRoot()
+	return waitWithContext(ctx, &m.backgroundJobs)
+}
+
+// Wait blocks until the background work started so far has finished, without cancelling it, so callers such
+// as tests can observe the outcome of AddApplication's dependency processing
+func (m *ApplicationService) Wait(ctx context.Context) error {
+	return waitWithContext(ctx, &m.backgroundJobs)
+}
+
+// executeScanJob runs a queued scan and records its progress on the stored scan record
+func (m *ApplicationService) executeScanJob(ctx context.Context, app *entity.App, scan *entity.ScanResult) {
+	// Status writes must still land when the scan itself is cancelled during shutdown
+	storeCtx := context.WithoutCancel(ctx)
+
+	startedAt := time.Now().UTC()
+	scan.Status = "running"
+	scan.StartedAt = &startedAt
+	if err := m.scanResultRepository.Update(storeCtx, scan); err != nil {
+		slog.Error("Failed to mark scan as running", "scan_id", scan.ID, "error", err)
+	}
+
+	result, err := m.runApplicationScan(ctx, app, scan.ID)
+	completedAt := time.Now().UTC()
+	scan.CompletedAt = &completedAt
+	if err != nil {
+		slog.Error("Background scan failed", "scan_id", scan.ID, "app_id", app.ID, "error", err)
+		errMsg := err.Error()
+		scan.Status = "failed"
+		scan.ErrorMessage = &errMsg
+	} else if err := applyScanResult(scan, result); err != nil {
+		errMsg := err.Error()
+		scan.Status = "failed"
+		scan.ErrorMessage = &errMsg
+	}
+
+	if err := m.scanResultRepository.Update(storeCtx, scan); err != nil {
+		slog.Error("Failed to store scan result", "scan_id", scan.ID, "error", err)
+		return
+	}
+	if scan.Status == "completed" {
+		resolveFixedRemediations(storeCtx, m.remediationRepository, app.ID, result)
+	}
+}
+
+// getScanTarget resolves the application to scan from its ID. Applications without a runtime cannot
+// be scanned, since the runtime decides the ecosystem their dependencies are looked up in.
+func (m *ApplicationService) getScanTarget(ctx context.Context, appUID string) (*entity.App, error) {
+	appID, err := uuid.Parse(appUID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid app ID: %w", ErrInvalidInput)
+	}
+	app, err := m.appRepository.GetByID(ctx, appID)
+	if err != nil {
+		return nil, lookupError(err, "application "+appUID)
+	}
+	if app.RuntimeID == nil {
+		return nil, fmt.Errorf("application %s has no runtime; set one before scanning: %w", app.Name, ErrInvalidInput)
+	}
+	return app, nil
+}
+
+// runApplicationScan checks every dependency of the application against OSV and builds the scan result
+func (m *ApplicationService) runApplicationScan(ctx context.Context, app *entity.App, scanID uuid.UUID) (model.ScanApplicationResult, error) {
+	appDeps, err := m.appToDepedencyRepository.GetByAppID(ctx, app.ID)
+	if err != nil {
+		return model.ScanApplicationResult{}, fmt.Errorf("failed to fetch app dependencies: %w", err)
+	}
+
+	if app.RuntimeID == nil {
+		return model.ScanApplicationResult{}, fmt.Errorf("application %s has no runtime: %w", app.Name, ErrInvalidInput)
+	}
+	runtime, err := m.runTimeRepository.GetByID(ctx, *app.RuntimeID)
+	if err != nil {
+		return model.ScanApplicationResult{}, lookupError(err, "runtime of application "+app.Name)
+	}
+
+	// The framework is informational; applications without one are scanned with an empty name
+	frameworkName := ""
+	if app.FrameworkID != nil {
+		if framework, err := m.frameWorkRepository.GetByID(ctx, *app.FrameworkID); err == nil && framework != nil {
+			frameworkName = framework.Name
+		}
+	}
+
+	// Dependencies are resolved up front, then checked by the shared scanner, which bounds how many lookups run at once
+	depInfos := make([]helper.DependencyInfo, 0, len(appDeps))
+	signals := make(map[string]helper.MaintenanceSignals, len(appDeps)) // by owner/repo
+	for _, ad := range appDeps {
+		dep, err := m.depedencyRepository.GetByID(ctx, ad.DependencyID)
+		if err != nil || dep.Owner == "" || dep.Repo == "" {
+			continue
+		}
+		signals[reparseKey(dep.Owner, dep.Repo, dep.Name)] = maintenanceSignals(dep)
+		depInfos = append(depInfos, parser.DependencyInfo{
+			Name:         dep.Name,
+			Owner:        dep.Owner,
+			Repo:         dep.Repo,
+			GitHubURL:    derefString(dep.RepositoryURL),
+			Version:      ad.UsedVersion,
+			IsGitHubRepo: true,
+			Runtime:      runtime.Name,
+			Ecosystem:    derefString(ad.Ecosystem),
+			Commit:       derefString(ad.UsedCommitSHA),
+		})
+	}
+
+	// The application's severity overrides adjust its findings before the summary and policy see them
+	ctx, err = withSeverityOverrides(ctx, m.severityOverrideRepository, derefString(app.OwnerID), &app.ID)
+	if err != nil {
+		return model.ScanApplicationResult{}, err
+	}
+	findings, depsWithVulns, totalCritical, totalHigh, totalMedium, totalLow := m.sharedScanner.ScanDependenciesWithControl(ctx, depInfos)
+
+	// Abandoned dependencies are flagged alongside their vulnerabilities
+	now := time.Now().UTC()
+	for i := range findings {
+		if depSignals, ok := signals[reparseKey(depsWithVulns[i].Owner, depsWithVulns[i].Repo, depsWithVulns[i].Name)]; ok {
+			findings[i].MaintenanceRisk, findings[i].MaintenanceReasons = helper.AssessMaintenanceRisk(depSignals, now)
+		}
+	}
+
+	summary := helper.AggregateVulnerabilitySummary(findings)
+	failOn, minScore := helper.ScanPolicyFor(ctx)
+	policyStatus, policyReason := helper.EvaluatePolicyWithScore(summary, findings, failOn, minScore, m.policy)
+
+	artifacts := model.ScanArtifacts{
+		VulnerabilityReport: fmt.Sprintf("https://your-app/api/scans/%s/report", scanID.String()),
+		SBOM:                fmt.Sprintf("https://your-app/api/scans/%s/sbom", scanID.String()),
 	}
 
 	// Generate enhanced SBOM from comprehensive vulnerability data
 	enhancedSBOMData := helper.EnhancedSBOMData{
 		AppID:   app.ID.String(),
 		AppName: app.Name,
+		Tool:    m.sbomTool,
 		// AppVersion:    "1.0.0", // You can fetch this from app metadata if available
 		Runtime:       runtime.Name,
 		Framework:     frameworkName,
@@ -770,6 +2140,7 @@ func (m *ApplicationService) ScanApplicationDependencies(ctx context.Context, ap
 		HighCount:     totalHigh,
 		MediumCount:   totalMedium,
 		LowCount:      totalLow,
+		Deterministic: helper.DeterministicSBOM(ctx),
 	}
 
 	sbomBytes, err := helper.GenerateEnhancedCycloneDXSBOM(enhancedSBOMData)
@@ -782,84 +2153,475 @@ func (m *ApplicationService) ScanApplicationDependencies(ctx context.Context, ap
 			"total_components", len(depsWithVulns),
 			"total_vulnerabilities", len(findings))
 
-		// Save SBOM to object storage if service is available
-		if m.objectStorageService != nil {
-			sbomKey, err := m.objectStorageService.SaveSBOM(ctx, app.ID.String(), app.Name, sbomBytes, "json")
-			if err != nil {
-				slog.Error("Failed to save SBOM to object storage", "error", err)
-			} else {
-				slog.Info("SBOM saved to object storage successfully", "key", sbomKey)
-				// Update the SBOM artifact URL with the actual storage key
-				artifacts.SBOM = fmt.Sprintf("https://your-app/api/sbom/%s", sbomKey)
-			}
-		} else {
-			slog.Warn("Object storage service not available, SBOM not persisted")
+		// Save SBOM to object storage if service is available
+		if m.objectStorageService != nil {
+			// Keyed by scan ID so every scan owns its SBOM object and retention can delete it safely
+			sbomKey, err := m.objectStorageService.SaveSBOM(appStorageContext(ctx, app), scanID.String(), app.Name, sbomBytes, "json")
+			if err != nil {
+				slog.Error("Failed to save SBOM to object storage", "error", err)
+			} else {
+				slog.Info("SBOM saved to object storage successfully", "key", sbomKey)
+				// Update the SBOM artifact URL with the actual storage key
+				artifacts.SBOM = fmt.Sprintf("https://your-app/api/sbom/%s", sbomKey)
+				artifacts.SBOMObjectKey = sbomKey
+			}
+		} else {
+			slog.Warn("Object storage service not available, SBOM not persisted")
+		}
+	}
+
+	result := model.ScanApplicationResult{
+		ScanID:          scanID.String(),
+		AppID:           app.ID.String(),
+		AppName:         app.Name,
+		ScanStatus:      "completed",
+		Summary:         summary,
+		Policies:        model.ScanPolicy{FailOn: failOn, MinScore: minScore, FailClosed: m.policy.FailClosed, Status: policyStatus, Reason: policyReason},
+		Artifacts:       artifacts,
+		Findings:        findings,
+		Unchecked:       helper.UncheckedDependencies(findings),
+		DependencyCount: len(appDeps),
+		ScannedAt:       time.Now().UTC(),
+	}
+	if len(appDeps) == 0 {
+		result.Warnings = append(result.Warnings, NoDependenciesWarning)
+	}
+
+	return result, nil
+}
+
+func (m *ApplicationService) GetApplicationSBOM(ctx context.Context, appUID string) ([]byte, error) {
+	appID, err := uuid.Parse(appUID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid app ID: %w", err)
+	}
+
+	app, err := m.appRepository.GetByID(ctx, appID)
+	if err != nil {
+		return nil, lookupError(err, "application "+appUID)
+	}
+
+	if m.objectStorageService == nil {
+		return nil, fmt.Errorf("object storage service not available")
+	}
+
+	// List all SBOMs for this app
+	ctx = appStorageContext(ctx, app)
+	sbomKeys, err := m.objectStorageService.ListSBOMs(ctx, app.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list SBOMs: %w", err)
+	}
+
+	if len(sbomKeys) == 0 {
+		return nil, fmt.Errorf("no SBOM found for application")
+	}
+
+	// Get the latest SBOM (last in the list, assuming chronological order)
+	latestSBOMKey := sbomKeys[len(sbomKeys)-1]
+	sbomData, err := m.objectStorageService.GetSBOM(ctx, latestSBOMKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve SBOM: %w", err)
+	}
+
+	return sbomData, nil
+}
+
+func (m *ApplicationService) ListApplicationSBOMs(ctx context.Context, appUID string) ([]string, error) {
+	appID, err := uuid.Parse(appUID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid app ID: %w", err)
+	}
+
+	app, err := m.appRepository.GetByID(ctx, appID)
+	if err != nil {
+		return nil, lookupError(err, "application "+appUID)
+	}
+
+	if m.objectStorageService == nil {
+		return nil, fmt.Errorf("object storage service not available")
+	}
+
+	sbomKeys, err := m.objectStorageService.ListSBOMs(appStorageContext(ctx, app), app.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list SBOMs: %w", err)
+	}
+
+	return sbomKeys, nil
+}
+
+// GetApplicationManifest returns the dependency manifest the application was added from, byte for byte
+func (m *ApplicationService) GetApplicationManifest(ctx context.Context, appUID string) (*model.ApplicationManifest, error) {
+	app, content, err := m.storedManifest(ctx, appUID)
+	if err != nil {
+		return nil, err
+	}
+	return &model.ApplicationManifest{
+		AppID:    app.ID.String(),
+		FileName: *app.ManifestFileName,
+		Content:  string(content),
+		Size:     len(content),
+	}, nil
+}
+
+// ReparseApplication runs the current parser over the application's stored manifest, e.g. after a parser learned
+// a new file format, and links the dependencies it now yields that are missing or at another version. Linked
+// dependencies the manifest no longer yields are reported but kept, since they may have been added through the API.
+func (m *ApplicationService) ReparseApplication(ctx context.Context, appUID string) (*model.ReparseApplicationResponse, error) {
+	app, content, err := m.storedManifest(ctx, appUID)
+	if err != nil {
+		return nil, err
+	}
+	if app.RuntimeID == nil {
+		return nil, fmt.Errorf("application %s has no runtime: %w", app.Name, ErrInvalidInput)
+	}
+	runtime, err := m.runTimeRepository.GetByID(ctx, *app.RuntimeID)
+	if err != nil {
+		return nil, lookupError(err, "runtime of application "+app.Name)
+	}
+
+	var gradleProperties string
+	if app.GradlePropertiesObjectKey != nil {
+		properties, err := m.objectStorageService.GetManifest(appStorageContext(ctx, app), *app.GradlePropertiesObjectKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve gradle.properties: %w", err)
+		}
+		gradleProperties = string(properties)
+	}
+
+	fileName := *app.ManifestFileName
+	deps := m.depedencyParserService.WithGradleProperties(gradleProperties).WithGoIndirect(ctx).
+		ParseDependencyFileWithGitHub(ctx, fileName, string(content), false, helper.GetRuntimeTypeCI(runtime.Name))
+	if !deps.Success {
+		return nil, fmt.Errorf("failed to parse %s: %s: %w", fileName, deps.Error, ErrInvalidInput)
+	}
+	if err := checkDependencyLimit(fileName, len(deps.Dependencies), m.maxDependencies); err != nil {
+		return nil, err
+	}
+
+	linked, err := m.linkedDependencyVersions(ctx, app.ID)
+	if err != nil {
+		return nil, err
+	}
+	resp := &model.ReparseApplicationResponse{
+		AppID:           app.ID.String(),
+		FileName:        fileName,
+		DependencyCount: len(deps.Dependencies),
+		Added:           []model.ManifestDependencyChange{},
+		Changed:         []model.ManifestDependencyChange{},
+		Missing:         []model.ManifestDependencyChange{},
+	}
+	var pending []helper.DependencyInfo
+	parsed := make(map[string]bool, len(deps.Dependencies))
+	for _, dep := range deps.Dependencies {
+		// Linked dependencies carry the owner/repo of their GitHub repository, which the parser only knows by URL,
+		// e.g. google/guava for a Gradle dependency parsed as com.google.guava/guava
+		key := reparseKey(dep.Owner, dep.Repo, dep.Name)
+		if parts, ok := helper.ExtractGitHubOwnerRepo(dep.GitHubURL, m.githubHost); ok {
+			if _, linkedByURL := linked[reparseKey(parts.Owner, parts.Repo, dep.Name)]; linkedByURL || dep.Owner == "" {
+				key = reparseKey(parts.Owner, parts.Repo, dep.Name)
+			}
+			if dep.Owner == "" {
+				dep.Owner, dep.Repo = parts.Owner, parts.Repo
+			}
+		}
+		if parsed[key] {
+			continue
+		}
+		parsed[key] = true
+		current, ok := linked[key]
+		switch {
+		case !ok:
+			resp.Added = append(resp.Added, model.ManifestDependencyChange{Name: dep.Name, NewVersion: dep.Version})
+		case current.version != dep.Version:
+			resp.Changed = append(resp.Changed, model.ManifestDependencyChange{Name: dep.Name, OldVersion: current.version, NewVersion: dep.Version})
+		default:
+			resp.Unchanged++
+			continue
+		}
+		pending = append(pending, dep)
+	}
+	for key, current := range linked {
+		if !parsed[key] {
+			resp.Missing = append(resp.Missing, model.ManifestDependencyChange{Name: current.name, OldVersion: current.version})
+		}
+	}
+	slices.SortFunc(resp.Missing, func(x, y model.ManifestDependencyChange) int { return strings.Compare(x.Name, y.Name) })
+	if len(deps.Dependencies) == 0 {
+		resp.Warnings = append(resp.Warnings, NoDependenciesWarning)
+	}
+
+	if err := m.auditApplicationAction(ctx, app.ID, "application_reparsed", nil, map[string]interface{}{
+		"file_name": fileName,
+		"added":     len(resp.Added),
+		"changed":   len(resp.Changed),
+		"missing":   len(resp.Missing),
+	}); err != nil {
+		slog.Warn("Failed to create audit trail for re-parse", "app_id", app.ID, "error", err)
+	}
+
+	if len(pending) == 0 {
+		resp.Message = "Application dependencies already match the manifest."
+		return resp, nil
+	}
+	m.backgroundJobs.Add(1)
+	go func() {
+		defer m.backgroundJobs.Done()
+		if depErrors := m.processDependencies(m.rootCtx, pending, app); len(depErrors) > 0 {
+			slog.Warn("Failed to link re-parsed dependencies", "app_id", app.ID, "failed_count", len(depErrors), "errors", depErrors)
+		}
+	}()
+	resp.Message = "Re-parsed manifest, linking added and changed dependencies in background."
+	return resp, nil
+}
+
+// storedManifest returns the application and the content of the manifest it was added from
+func (m *ApplicationService) storedManifest(ctx context.Context, appUID string) (*entity.App, []byte, error) {
+	appID, err := uuid.Parse(appUID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid app ID: %w", ErrInvalidInput)
+	}
+	app, err := m.appRepository.GetByID(ctx, appID)
+	if err != nil {
+		return nil, nil, lookupError(err, "application "+appUID)
+	}
+	if app.ManifestObjectKey == nil || app.ManifestFileName == nil {
+		return nil, nil, fmt.Errorf("application %s has no stored manifest: %w", app.Name, ErrNotFound)
+	}
+	if m.objectStorageService == nil {
+		return nil, nil, fmt.Errorf("object storage service not available")
+	}
+	content, err := m.objectStorageService.GetManifest(appStorageContext(ctx, app), *app.ManifestObjectKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to retrieve manifest: %w", err)
+	}
+	return app, content, nil
+}
+
+// linkedDependency is the name and used version of a dependency linked to an application
+type linkedDependency struct {
+	name    string
+	version string
+}
+
+// linkedDependencyVersions returns the dependencies linked to an application by reparseKey
+func (m *ApplicationService) linkedDependencyVersions(ctx context.Context, appID uuid.UUID) (map[string]linkedDependency, error) {
+	appDeps, err := m.appToDepedencyRepository.GetByAppID(ctx, appID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch app dependencies: %w", err)
+	}
+	linked := make(map[string]linkedDependency, len(appDeps))
+	for _, appDep := range appDeps {
+		dep, err := m.depedencyRepository.GetByID(ctx, appDep.DependencyID)
+		if err != nil {
+			return nil, lookupError(err, "dependency "+appDep.DependencyID.String())
 		}
+		linked[reparseKey(dep.Owner, dep.Repo, dep.Name)] = linkedDependency{name: dep.Name, version: appDep.UsedVersion}
 	}
+	return linked, nil
+}
 
-	return result, nil
+// reparseKey identifies a dependency the way processDependency does: by owner/repo, or by name when the
+// repository is unknown
+func reparseKey(owner, repo, name string) string {
+	if repo != "" {
+		return strings.ToLower(owner + "/" + repo)
+	}
+	return strings.ToLower(name)
 }
 
-func (m *ApplicationService) GetApplicationSBOM(ctx context.Context, appUID string) ([]byte, error) {
+// GenerateApplicationSBOM builds a CycloneDX SBOM of the application's current dependencies from the stored
+// records instead of a scan. Each component carries the vulnerabilities the latest completed scan found for the
+// version in use; dependencies whose version changed since then, or that were never scanned, list none.
+func (m *ApplicationService) GenerateApplicationSBOM(ctx context.Context, appUID string) ([]byte, error) {
 	appID, err := uuid.Parse(appUID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid app ID: %w", err)
+		return nil, fmt.Errorf("invalid app ID: %w", ErrInvalidInput)
 	}
-
 	app, err := m.appRepository.GetByID(ctx, appID)
-	if err != nil || app == nil {
-		return nil, fmt.Errorf("application not found")
+	if err != nil {
+		return nil, lookupError(err, "application "+appUID)
 	}
 
-	if m.objectStorageService == nil {
-		return nil, fmt.Errorf("object storage service not available")
+	runtimeName, frameworkName := "", ""
+	if app.RuntimeID != nil {
+		if runtime, err := m.runTimeRepository.GetByID(ctx, *app.RuntimeID); err == nil && runtime != nil {
+			runtimeName = runtime.Name
+		}
+	}
+	if app.FrameworkID != nil {
+		if framework, err := m.frameWorkRepository.GetByID(ctx, *app.FrameworkID); err == nil && framework != nil {
+			frameworkName = framework.Name
+		}
 	}
 
-	// List all SBOMs for this app
-	sbomKeys, err := m.objectStorageService.ListSBOMs(ctx, app.Name)
+	appDeps, err := m.appToDepedencyRepository.GetByAppID(ctx, appID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list SBOMs: %w", err)
+		return nil, fmt.Errorf("failed to fetch app dependencies: %w", err)
+	}
+	known, err := m.lastKnownVulnerabilities(ctx, appID)
+	if err != nil {
+		return nil, err
 	}
 
-	if len(sbomKeys) == 0 {
-		return nil, fmt.Errorf("no SBOM found for application")
+	data := helper.EnhancedSBOMData{
+		AppID:         app.ID.String(),
+		AppName:       app.Name,
+		Tool:          m.sbomTool,
+		Runtime:       runtimeName,
+		Framework:     frameworkName,
+		ScanTimestamp: time.Now().UTC(),
+		Deterministic: helper.DeterministicSBOM(ctx),
 	}
+	for _, appDep := range appDeps {
+		dep, err := m.depedencyRepository.GetByID(ctx, appDep.DependencyID)
+		if errors.Is(err, repository.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch dependency %s: %w", appDep.DependencyID, err)
+		}
 
-	// Get the latest SBOM (last in the list, assuming chronological order)
-	latestSBOMKey := sbomKeys[len(sbomKeys)-1]
-	sbomData, err := m.objectStorageService.GetSBOM(ctx, latestSBOMKey)
-	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve SBOM: %w", err)
+		component := helper.DependencyWithVulnerabilities{
+			Name:          dep.Name,
+			Version:       appDep.UsedVersion,
+			Owner:         dep.Owner,
+			Repo:          dep.Repo,
+			RepositoryURL: derefString(dep.RepositoryURL),
+			Runtime:       runtimeName,
+			IsGitHub:      dep.Owner != "" && dep.Repo != "",
+		}
+		for _, finding := range known[dep.Name+"@"+appDep.UsedVersion] {
+			severity := helper.ParseSeverity(finding.Severity)
+			component.Vulnerabilities = append(component.Vulnerabilities, helper.VulnerabilityInfo{
+				ID:       finding.VulnerabilityID,
+				CVE:      finding.VulnerabilityID,
+				Severity: severity,
+				Score:    finding.RiskScore,
+			})
+			component.RiskScore = max(component.RiskScore, finding.RiskScore)
+			data.TotalFindings++
+			switch severity {
+			case helper.SeverityCritical:
+				data.CriticalCount++
+			case helper.SeverityHigh:
+				data.HighCount++
+			case helper.SeverityMedium:
+				data.MediumCount++
+			case helper.SeverityLow:
+				data.LowCount++
+			}
+		}
+		data.Dependencies = append(data.Dependencies, component)
 	}
 
-	return sbomData, nil
+	return helper.GenerateEnhancedCycloneDXSBOM(data)
 }
 
-func (m *ApplicationService) ListApplicationSBOMs(ctx context.Context, appUID string) ([]string, error) {
+// GetDependencyTree groups the application's dependencies into direct and transitive ones, each with the
+// vulnerabilities the latest completed scan found for the version in use. Manifests do not say which direct
+// dependency pulls in a transitive one, so both groups are flat lists ordered by name.
+func (m *ApplicationService) GetDependencyTree(ctx context.Context, appUID string) (*model.DependencyTree, error) {
 	appID, err := uuid.Parse(appUID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid app ID: %w", err)
+		return nil, fmt.Errorf("invalid app ID: %w", ErrInvalidInput)
 	}
-
 	app, err := m.appRepository.GetByID(ctx, appID)
-	if err != nil || app == nil {
-		return nil, fmt.Errorf("application not found")
+	if err != nil {
+		return nil, lookupError(err, "application "+appUID)
+	}
+	appDeps, err := m.appToDepedencyRepository.GetByAppID(ctx, appID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch app dependencies: %w", err)
+	}
+	known, err := m.lastKnownVulnerabilities(ctx, appID)
+	if err != nil {
+		return nil, err
 	}
 
-	if m.objectStorageService == nil {
-		return nil, fmt.Errorf("object storage service not available")
+	tree := &model.DependencyTree{
+		AppID:      app.ID.String(),
+		AppName:    app.Name,
+		Direct:     []model.DependencyTreeNode{},
+		Transitive: []model.DependencyTreeNode{},
 	}
+	for _, appDep := range appDeps {
+		dep, err := m.depedencyRepository.GetByID(ctx, appDep.DependencyID)
+		if errors.Is(err, repository.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch dependency %s: %w", appDep.DependencyID, err)
+		}
 
-	sbomKeys, err := m.objectStorageService.ListSBOMs(ctx, app.Name)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list SBOMs: %w", err)
+		node := model.DependencyTreeNode{
+			DependencyID:  dep.ID.String(),
+			Name:          dep.Name,
+			Version:       appDep.UsedVersion,
+			Ecosystem:     derefString(appDep.Ecosystem),
+			RepositoryURL: derefString(dep.RepositoryURL),
+		}
+		highest := helper.SeverityUnknown
+		for _, finding := range known[dep.Name+"@"+appDep.UsedVersion] {
+			node.Vulnerabilities++
+			if severity := helper.ParseSeverity(finding.Severity); node.HighestSeverity == "" || severity.Rank() > highest.Rank() {
+				highest = severity
+				node.HighestSeverity = severity.String()
+			}
+		}
+		if appDep.Transitive {
+			tree.Transitive = append(tree.Transitive, node)
+		} else {
+			tree.Direct = append(tree.Direct, node)
+		}
 	}
+	byName := func(x, y model.DependencyTreeNode) int {
+		return cmp.Or(strings.Compare(x.Name, y.Name), strings.Compare(x.Version, y.Version))
+	}
+	slices.SortFunc(tree.Direct, byName)
+	slices.SortFunc(tree.Transitive, byName)
+	return tree, nil
+}
 
-	return sbomKeys, nil
+// lastKnownVulnerabilities returns the findings of the application's latest completed scan keyed by
+// "name@version", or none when it was never scanned
+func (m *ApplicationService) lastKnownVulnerabilities(ctx context.Context, appID uuid.UUID) (map[string][]*entity.Finding, error) {
+	scan, err := m.latestCompletedScan(ctx, appID)
+	if err != nil || scan == nil {
+		return nil, err
+	}
+	findings, _, err := m.scanResultRepository.QueryFindings(ctx, repository.FindingFilter{AppID: appID, ScanID: scan.ID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch findings of scan %s: %w", scan.ID, err)
+	}
+	known := make(map[string][]*entity.Finding, len(findings))
+	for _, finding := range findings {
+		key := finding.Dependency + "@" + finding.Version
+		known[key] = append(known[key], finding)
+	}
+	return known, nil
 }
 
 // processDependency processes a single dependency for an application
-func (m *ApplicationService) processDependency(ctx context.Context, dep helper.DependencyInfo, app *entity.App, errCh chan<- error) {
+func (m *ApplicationService) processDependency(ctx context.Context, dep helper.DependencyInfo, app *entity.App, errs *errorCollector) {
+	// Follow GitHub repository redirects so renamed/transferred repos are stored under their canonical name.
+	// Manifests are parsed without asking GitHub, so a repository guessed from the dependency name is verified
+	// here: one GitHub does not know is stored without a repository URL and gets no metadata.
+	var previous *helper.GitHubRepoParts
+	var missing *helper.GitHubRepoParts
+	if parts, isValid := helper.ExtractGitHubOwnerRepo(dep.GitHubURL, m.githubHost); isValid {
+		canonical, moved, err := m.resolveMovedRepository(parts)
+		switch {
+		case errors.Is(err, usecase.ErrGitHubRepositoryNotFound):
+			slog.Info("GitHub repository of dependency not found, storing it without a repository URL",
+				"dependency", dep.Name, "repository", parts.Owner+"/"+parts.Repo)
+			missing = &parts
+			dep.GitHubURL, dep.IsGitHubRepo = "", false
+		case moved:
+			previous = &helper.GitHubRepoParts{Owner: dep.Owner, Repo: dep.Repo}
+			dep.Owner, dep.Repo, dep.GitHubURL = canonical.Owner, canonical.Repo, canonical.URL()
+		}
+	}
+
 	lookupOwner := dep.Owner
 	lookupRepo := dep.Repo
 	if lookupOwner == "" || lookupRepo == "" {
@@ -870,23 +2632,39 @@ func (m *ApplicationService) processDependency(ctx context.Context, dep helper.D
 	var dependency *entity.Dependency
 	existingDep, err := m.depedencyRepository.GetByOwnerRepoCI(ctx, dep.Owner, dep.Repo)
 	if err != nil && err != gorm.ErrRecordNotFound {
-		errCh <- fmt.Errorf("failed to check existing dependency %s/%s: %w", dep.Owner, dep.Repo, err)
+		errs.Add(fmt.Errorf("failed to check existing dependency %s/%s: %w", dep.Owner, dep.Repo, err))
 		return
 	}
+	if existingDep == nil && previous != nil {
+		// A dependency stored under the old name is renamed in place instead of duplicated
+		existingDep, err = m.renameMovedDependency(ctx, *previous, dep)
+		if err != nil {
+			errs.Add(err)
+			return
+		}
+	}
 
 	// If not found, create new dependency
-	var versionCommitSHA string
+	var used usedVersionMetadata
 
 	if existingDep != nil {
 		dependency = existingDep
+		if missing != nil {
+			if err := m.clearMissingRepositoryURL(ctx, dependency, *missing); err != nil {
+				errs.Add(err)
+				return
+			}
+		}
 	} else {
 		// Create new dependency
 		dependency = &entity.Dependency{
-			ID:            uuid.New(),
-			Name:          dep.Name,
-			Owner:         dep.Owner,
-			Repo:          dep.Repo,
-			RepositoryURL: &dep.GitHubURL,
+			ID:    uuid.New(),
+			Name:  dep.Name,
+			Owner: dep.Owner,
+			Repo:  dep.Repo,
+		}
+		if dep.GitHubURL != "" {
+			dependency.RepositoryURL = &dep.GitHubURL
 		}
 		// err = m.depedencyRepository.Create(ctx, dependency)
 		if err := m.depedencyRepository.Create(ctx, dependency); err != nil {
@@ -894,85 +2672,201 @@ func (m *ApplicationService) processDependency(ctx context.Context, dep helper.D
 			if strings.Contains(err.Error(), "unique") || strings.Contains(err.Error(), "UNIQUE") {
 				dependency, err = m.depedencyRepository.GetByOwnerRepoCI(ctx, lookupOwner, lookupRepo)
 				if err != nil || dependency == nil {
-					errCh <- fmt.Errorf("dependency create race: %w", err)
+					errs.Add(fmt.Errorf("dependency create race: %w", err))
 					return
 				}
 			} else {
 				slog.Error("failed to create dependency", "error", err)
-				errCh <- err
+				errs.Add(err)
 				return
 			}
 		}
 		// If GitHub URL is valid, fetch and update metadata
 		if dep.GitHubURL != "" {
-			parts, isValid := helper.ExtractGitHubOwnerRepo(dep.GitHubURL)
+			parts, isValid := helper.ExtractGitHubOwnerRepo(dep.GitHubURL, m.githubHost)
 			if isValid {
-				var version string
-				versionCommitSHA, version, err = m.fetchAndUpdateDependencyMetadata(ctx, dependency, parts.Owner, parts.Repo, dep.Version, dep.GitHubURL)
-				if err == nil && version != "" {
-					dep.Version = version // update to matched version if found
+				used, err = m.fetchAndUpdateDependencyMetadata(ctx, dependency, parts.Owner, parts.Repo, dep.Version, dep.GitHubURL)
+				if err == nil && used.Version != "" {
+					dep.Version = used.Version // update to matched version if found
 				}
 			}
 		}
 	}
 
-	// Check if app-dependency relationship already exists
-	existingAppDep, err := m.appToDepedencyRepository.GetByAppAndDependencyID(ctx, app.ID, dependency.ID)
-	if err != nil && err != gorm.ErrRecordNotFound {
-		errCh <- fmt.Errorf("failed to check app dependency relationship: %w", err)
-		return
-	}
+	// Each dependency link is committed on its own so a failure does not roll back the others
+	err = m.inTransaction(ctx, func(txCtx context.Context) error {
+		// Check if app-dependency relationship already exists
+		existingAppDep, err := m.appToDepedencyRepository.GetByAppAndDependencyID(txCtx, app.ID, dependency.ID)
+		if err != nil && err != gorm.ErrRecordNotFound {
+			return fmt.Errorf("failed to check app dependency relationship: %w", err)
+		}
 
-	if existingAppDep != nil {
-		// Update version if different
-		if existingAppDep.UsedVersion != dep.Version {
-			existingAppDep.UsedVersion = dep.Version
-			err = m.appToDepedencyRepository.Update(ctx, existingAppDep)
-			if err != nil {
-				errCh <- fmt.Errorf("failed to update app dependency version: %w", err)
-				return
+		if existingAppDep != nil {
+			// Update version if different
+			if existingAppDep.UsedVersion != dep.Version || existingAppDep.Transitive != dep.Transitive {
+				if existingAppDep.UsedVersion != dep.Version {
+					existingAppDep.UsedVersion = dep.Version
+					applyStaleness(existingAppDep, used.Staleness)
+				}
+				existingAppDep.Transitive = dep.Transitive
+				if err := m.appToDepedencyRepository.Update(txCtx, existingAppDep); err != nil {
+					return fmt.Errorf("failed to update app dependency version: %w", err)
+				}
 			}
+			return nil
 		}
-		return
+
+		// Create app-dependency relationship
+		appDependency := &entity.AppDependency{
+			ID:            uuid.New(),
+			AppID:         app.ID,
+			DependencyID:  dependency.ID,
+			UsedVersion:   dep.Version,
+			Transitive:    dep.Transitive,
+			IsMonitored:   false,
+			MonitorStatus: nil,
+		}
+		// Set UsedCommitSHA if we resolved it
+		if used.CommitSHA != "" {
+			appDependency.UsedCommitSHA = &used.CommitSHA
+		}
+		applyStaleness(appDependency, used.Staleness)
+
+		if err := m.appToDepedencyRepository.Create(txCtx, appDependency); err != nil {
+			return fmt.Errorf("failed to create app dependency: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		errs.Add(err)
 	}
+}
 
-	// Create app-dependency relationship
-	appDependency := &entity.AppDependency{
-		ID:            uuid.New(),
-		AppID:         app.ID,
-		DependencyID:  dependency.ID,
-		UsedVersion:   dep.Version,
-		IsMonitored:   false,
-		MonitorStatus: nil,
+// resolveMovedRepository asks GitHub for the repository and reports whether it now lives under a different owner/repo.
+// The lookup error is returned so callers can tell a repository GitHub does not know from one it could not be asked about.
+func (m *ApplicationService) resolveMovedRepository(parts helper.GitHubRepoParts) (helper.GitHubRepoParts, bool, error) {
+	if m.githubApiService == nil {
+		return parts, false, nil
 	}
-	// Set UsedCommitSHA if we resolved it
-	if versionCommitSHA != "" {
-		appDependency.UsedCommitSHA = &versionCommitSHA
+	repoInfo, err := m.githubApiService.GetRepoInfo(parts.Owner, parts.Repo)
+	if err != nil || repoInfo == nil {
+		return parts, false, err
 	}
+	canonical, ok := canonicalRepo(repoInfo, parts)
+	if !ok || (strings.EqualFold(canonical.Owner, parts.Owner) && strings.EqualFold(canonical.Repo, parts.Repo)) {
+		return parts, false, nil
+	}
+	slog.Info("GitHub repository has moved", "from", parts.Owner+"/"+parts.Repo, "to", canonical.Owner+"/"+canonical.Repo)
+	return canonical, true, nil
+}
 
-	err = m.appToDepedencyRepository.Create(ctx, appDependency)
-	if err != nil {
-		errCh <- fmt.Errorf("failed to create app dependency: %w", err)
-		return
+// canonicalRepo reads the canonical repository from a GitHub payload, on the host of the requested one when
+// the payload does not say
+func canonicalRepo(repoInfo map[string]interface{}, requested helper.GitHubRepoParts) (helper.GitHubRepoParts, bool) {
+	canonical, ok := helper.CanonicalRepoFromInfo(repoInfo)
+	if ok && canonical.Host == "" {
+		canonical.Host = requested.Host
 	}
+	return canonical, ok
+}
 
+// clearMissingRepositoryURL drops the repository URL of a stored dependency when it points at a repository GitHub
+// reported as not found. A URL pointing elsewhere, e.g. one corrected by a user, is kept.
+func (m *ApplicationService) clearMissingRepositoryURL(ctx context.Context, dependency *entity.Dependency, missing helper.GitHubRepoParts) error {
+	if dependency.RepositoryURL == nil {
+		return nil
+	}
+	stored, ok := helper.ExtractGitHubOwnerRepo(*dependency.RepositoryURL, m.githubHost)
+	if !ok || !strings.EqualFold(stored.Owner, missing.Owner) || !strings.EqualFold(stored.Repo, missing.Repo) {
+		return nil
+	}
+	dependency.RepositoryURL = nil
+	if err := m.depedencyRepository.Update(ctx, dependency); err != nil {
+		return fmt.Errorf("failed to clear repository URL of dependency %s: %w", dependency.Name, err)
+	}
+	return nil
 }
 
-// fetchAndUpdateDependencyMetadata fetches GitHub metadata and updates the Dependency entity. Returns version commit SHA if found.
-func (m *ApplicationService) fetchAndUpdateDependencyMetadata(ctx context.Context, dep *entity.Dependency, owner, repo, version, newRepoURL string) (string, string, error) {
-	var defaultBranch, lastCommitSHA, lastCommitTime, latestTag string
+// renameMovedDependency updates a dependency stored under a repository's previous name to its canonical owner/repo/URL.
+// Returns nil when no dependency exists under the previous name.
+func (m *ApplicationService) renameMovedDependency(ctx context.Context, previous helper.GitHubRepoParts, dep helper.DependencyInfo) (*entity.Dependency, error) {
+	existing, err := m.depedencyRepository.GetByOwnerRepoCI(ctx, previous.Owner, previous.Repo)
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return nil, fmt.Errorf("failed to check existing dependency %s/%s: %w", previous.Owner, previous.Repo, err)
+	}
+	if existing == nil {
+		return nil, nil
+	}
+
+	repoURL := dep.GitHubURL
+	existing.Owner, existing.Repo, existing.RepositoryURL = dep.Owner, dep.Repo, &repoURL
+	if err := m.depedencyRepository.Update(ctx, existing); err != nil {
+		return nil, fmt.Errorf("failed to rename moved dependency %s/%s: %w", previous.Owner, previous.Repo, err)
+	}
+	return existing, nil
+}
 
-	// Fetch default branch
+// fetchLatestCommits returns the branch commits were read from together with those commits, and whether GitHub
+// reported that branch as the repository's default. Without a reported default branch the configured fallback
+// branches are tried; the first with commits is only a guess for this request and must not be stored as the default.
+func (m *ApplicationService) fetchLatestCommits(owner, repo string) (string, bool, []map[string]interface{}) {
+	candidates := m.branchFallbacks
 	defaultBranch, err := m.githubApiService.GetDefaultBranch(owner, repo)
-	if err != nil {
-		slog.Error("failed to fetch default branch from GitHub", "error", err)
+	reported := err == nil && defaultBranch != ""
+	if reported {
+		candidates = []string{defaultBranch}
+	} else {
+		slog.Warn("failed to fetch default branch from GitHub, trying fallback branches", "owner", owner, "repo", repo, "fallbacks", candidates, "error", err)
 	}
 
-	// Fetch latest commit
-	listCommits, err := m.githubApiService.GetListCommits(owner, repo, defaultBranch)
-	if err != nil {
-		slog.Error("failed to fetch commits from GitHub", "error", err)
+	for _, branch := range candidates {
+		commits, err := m.githubApiService.GetListCommits(owner, repo, branch, m.commitHistoryLimit)
+		if err != nil {
+			slog.Warn("failed to fetch commits from GitHub", "owner", owner, "repo", repo, "branch", branch, "error", err)
+			continue
+		}
+		if len(commits) > 0 {
+			return branch, reported, commits
+		}
+	}
+	return "", false, nil
+}
+
+// usedVersionMetadata is what fetchAndUpdateDependencyMetadata learned about the version an application uses
+type usedVersionMetadata struct {
+	CommitSHA string                      // commit of the version's tag, "" when not found
+	Version   string                      // the matching tag name, or the version as given
+	Staleness *helper.DependencyStaleness // nil when the version matches no tag
+}
+
+// fetchAndUpdateDependencyMetadata fetches GitHub metadata and updates the Dependency entity. Returns the used version's
+// commit SHA, matching tag and staleness when found.
+// Only fields that were fetched successfully are written, so a failed lookup never replaces good stored metadata with empty values.
+// The repository is first asked for conditionally on the validators stored with the metadata: when GitHub reports it
+// unchanged, which does not count against the rate limit, the stored commit, tag and signals are kept as they are.
+func (m *ApplicationService) fetchAndUpdateDependencyMetadata(ctx context.Context, dep *entity.Dependency, owner, repo, version, newRepoURL string) (usedVersionMetadata, error) {
+	var lastCommitSHA, lastCommitTime, latestTag string
+	previousCommitSHA := derefString(dep.LastCommitSHA)
+
+	stored := usecase.GitHubValidators{ETag: derefString(dep.GitHubETag), LastModified: derefString(dep.GitHubLastModified)}
+	repoInfo, validators, infoErr := m.githubApiService.GetRepoInfoIfModified(owner, repo, stored)
+	if errors.Is(infoErr, usecase.ErrGitHubNotModified) && previousCommitSHA != "" && (newRepoURL == "" || newRepoURL == derefString(dep.RepositoryURL)) {
+		slog.Debug("GitHub repository not modified, keeping stored metadata", "owner", owner, "repo", repo)
+		if version == "" {
+			return usedVersionMetadata{}, nil
+		}
+		listTags, err := m.githubApiService.ListTags(owner, repo)
+		if err != nil {
+			slog.Error("failed to fetch tags from GitHub", "error", err)
+		}
+		return m.resolveUsedVersion(owner, repo, version, listTags), nil
+	}
+	if infoErr != nil {
+		validators = usecase.GitHubValidators{}
 	}
+
+	// Fetch latest commit from the default branch, or the first fallback branch that has commits
+	branch, reportedDefault, listCommits := m.fetchLatestCommits(owner, repo)
 	if len(listCommits) > 0 {
 		commit := listCommits[0]
 		lastCommitSHA, _ = commit["oid"].(string)
@@ -988,53 +2882,151 @@ func (m *ApplicationService) fetchAndUpdateDependencyMetadata(ctx context.Contex
 		latestTag, _ = listTags[0]["name"].(string)
 	}
 
-	// find exact matching tag for the specified version
-	matchingTag, err := m.githubApiService.FindMatchingTag(owner, repo, version)
-	if err == nil && matchingTag != "" {
-		version = matchingTag
-	}
-
-	// Get commit SHA for the specified version (tag/branch)
-	var versionCommitSHA string
-	shaCommit, isFound := helper.GetCommitSHAFromVersion(version, listTags)
-	if isFound {
-		versionCommitSHA = shaCommit
-	}
+	used := m.resolveUsedVersion(owner, repo, version, listTags)
 
-	// Update Dependency entity fields
+	// Update Dependency entity fields; owner/repo are the canonical (post-redirect) values
+	dep.Owner, dep.Repo = owner, repo
 	if newRepoURL != "" {
 		dep.RepositoryURL = &newRepoURL
 	}
-	dep.DefaultBranch = &defaultBranch
-	dep.LastCommitSHA = &lastCommitSHA
-	if lastCommitTime != "" {
-		t, err := time.Parse(time.RFC3339, strings.ReplaceAll(lastCommitTime, " ", "T"))
-		if err == nil {
-			dep.LastCommitAt = &t
+	if reportedDefault {
+		dep.DefaultBranch = &branch
+	}
+	if lastCommitSHA != "" {
+		dep.LastCommitSHA = &lastCommitSHA
+		if lastCommitTime != "" {
+			t, err := time.Parse(time.RFC3339, strings.ReplaceAll(lastCommitTime, " ", "T"))
+			if err == nil {
+				t = t.UTC()
+				dep.LastCommitAt = &t
+			}
 		}
 	}
-	dep.LastTag = &latestTag
+	if latestTag != "" {
+		dep.LastTag = &latestTag
+	}
+	// The validators only vouch for the metadata when its commits were fetched along with them
+	if lastCommitSHA != "" && !validators.IsZero() {
+		dep.GitHubETag, dep.GitHubLastModified = &validators.ETag, &validators.LastModified
+	}
+	if infoErr == nil {
+		m.recordRepositorySignals(dep, owner, repo, repoInfo)
+	} else if !errors.Is(infoErr, usecase.ErrGitHubNotModified) {
+		slog.Warn("failed to fetch repository signals from GitHub", "owner", owner, "repo", repo, "error", infoErr)
+	}
 	if err := m.depedencyRepository.Update(ctx, dep); err != nil {
-		return versionCommitSHA, version, err
+		return used, err
 	}
 
-	// Optionally, create a new DependencyVersion record
-	if lastCommitSHA != "" {
+	// Record a new DependencyVersion when the latest commit changed
+	if lastCommitSHA != "" && lastCommitSHA != previousCommitSHA {
 		commitTime, _ := time.Parse(time.RFC3339, strings.ReplaceAll(lastCommitTime, " ", "T"))
 		depVersion := &entity.DependencyVersion{
 			ID:           uuid.New(),
 			DependencyID: dep.ID,
 			CommitSHA:    lastCommitSHA,
-			CommitAt:     commitTime,
-			Tag:          &latestTag,
-			Branch:       &defaultBranch,
+			CommitAt:     commitTime.UTC(),
+			Branch:       &branch,
+		}
+		if latestTag != "" {
+			depVersion.Tag = &latestTag
 		}
 		if err := m.depedencyVersionRepository.Create(ctx, depVersion); err != nil {
 			slog.Error("failed to create dependency version", "error", err)
 		}
 	}
 
-	return versionCommitSHA, version, nil
+	return used, nil
+}
+
+// resolveUsedVersion returns the tag, commit and staleness of the version an application uses, from the
+// repository's tags
+func (m *ApplicationService) resolveUsedVersion(owner, repo, version string, listTags []map[string]interface{}) usedVersionMetadata {
+	// find exact matching tag for the specified version
+	if version != "" {
+		matchingTag, err := m.githubApiService.FindMatchingTag(owner, repo, version)
+		if err == nil && matchingTag != "" {
+			version = matchingTag
+		}
+	}
+
+	// Get commit SHA for the specified version (tag/branch)
+	used := usedVersionMetadata{Version: version}
+	shaCommit, isFound := helper.GetCommitSHAFromVersion(version, listTags)
+	if isFound {
+		used.CommitSHA = shaCommit
+	}
+	if staleness, ok := helper.MeasureStaleness(version, listTags, m.tagCommitDate(owner, repo)); ok {
+		used.Staleness = &staleness
+	}
+	return used
+}
+
+// recordRepositorySignals stores the archived flag and stars of owner/repo from its repoInfo on dep, from which its
+// maintenance risk is judged, and its collaborator count when those are counted. Signals GitHub does not return
+// are left as they were.
+func (m *ApplicationService) recordRepositorySignals(dep *entity.Dependency, owner, repo string, repoInfo map[string]interface{}) {
+	if repoInfo == nil {
+		return
+	}
+	if archived, ok := repoInfo["archived"].(bool); ok {
+		dep.Archived = &archived
+	}
+	if stars, ok := repoInfo["stargazers_count"].(float64); ok {
+		count := int(stars)
+		dep.Stars = &count
+	}
+	if m.countCollaborators {
+		if collaborators, err := m.githubApiService.ListCollaborators(owner, repo); err == nil {
+			count := len(collaborators)
+			dep.Collaborators = &count
+		}
+	}
+	now := time.Now().UTC()
+	dep.SignalsCheckedAt = &now
+}
+
+// maintenanceSignals returns the stored repository signals of dep
+func maintenanceSignals(dep *entity.Dependency) helper.MaintenanceSignals {
+	return helper.MaintenanceSignals{
+		Archived:      dep.Archived,
+		LastCommitAt:  dep.LastCommitAt,
+		Stars:         dep.Stars,
+		Collaborators: dep.Collaborators,
+	}
+}
+
+// tagCommitDate returns a lookup of the commit date of a tag's commit in owner/repo
+func (m *ApplicationService) tagCommitDate(owner, repo string) func(sha string) (time.Time, error) {
+	return func(sha string) (time.Time, error) {
+		detail, err := m.githubApiService.GetCommitsDetail(owner, repo, sha)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if detail == nil {
+			return time.Time{}, fmt.Errorf("commit %s not found", sha)
+		}
+		date, err := time.Parse(time.RFC3339, detail.Committer.Date)
+		return date.UTC(), err
+	}
+}
+
+// applyStaleness records the measured staleness of the used version on the application's dependency
+func applyStaleness(appDep *entity.AppDependency, staleness *helper.DependencyStaleness) {
+	if staleness == nil {
+		return
+	}
+	now := time.Now().UTC()
+	releasesBehind, days := staleness.ReleasesBehind, staleness.Days
+	appDep.ReleasesBehind, appDep.StalenessDays, appDep.StalenessCheckedAt = &releasesBehind, &days, &now
+}
+
+// inTransaction runs fn atomically when a unit of work is configured, otherwise it runs fn directly
+func (m *ApplicationService) inTransaction(ctx context.Context, fn func(txCtx context.Context) error) error {
+	if m.unitOfWork == nil {
+		return fn(ctx)
+	}
+	return m.unitOfWork.Do(ctx, fn)
 }
 
 // auditApplicationAction audits application-related actions
@@ -1049,24 +3041,14 @@ func (m *ApplicationService) createAuditTrailEntry(ctx context.Context, entityTy
 		return nil
 	}
 
-	// Marshal oldValues and newValues to JSON bytes
-	var oldValuesBytes, newValuesBytes []byte
-	var err error
-
-	if oldValues != nil {
-		oldValuesBytes, err = json.Marshal(oldValues)
-		if err != nil {
-			slog.Warn("Failed to marshal old values for audit trail", "error", err)
-			oldValuesBytes = nil
-		}
+	// Marshal oldValues and newValues to JSON bytes; a value that cannot be marshalled is stored as a placeholder
+	oldValuesBytes, err := helper.EncodeJSONColumn(oldValues)
+	if err != nil {
+		slog.Warn("Failed to marshal old values for audit trail", "error", err)
 	}
-
-	if newValues != nil {
-		newValuesBytes, err = json.Marshal(newValues)
-		if err != nil {
-			slog.Warn("Failed to marshal new values for audit trail", "error", err)
-			newValuesBytes = nil
-		}
+	newValuesBytes, err := helper.EncodeJSONColumn(newValues)
+	if err != nil {
+		slog.Warn("Failed to marshal new values for audit trail", "error", err)
 	}
 
 	// Marshal context to JSON bytes
@@ -1075,10 +3057,9 @@ func (m *ApplicationService) createAuditTrailEntry(ctx context.Context, entityTy
 		"timestamp":  time.Now().UTC(),
 		"session_id": uuid.New().String(), // Could be extracted from context
 	}
-	contextBytes, err := json.Marshal(contextData)
+	contextBytes, err := helper.EncodeJSONColumn(contextData)
 	if err != nil {
 		slog.Warn("Failed to marshal context for audit trail", "error", err)
-		contextBytes = nil
 	}
 
 	auditEntry := &entity.AuditTrail{
@@ -1098,7 +3079,61 @@ func (m *ApplicationService) createAuditTrailEntry(ctx context.Context, entityTy
 	return m.auditTrailRepository.Create(ctx, auditEntry)
 }
 
+// frameworkBelongsToRuntime reports whether a framework may be used with a runtime.
+// Frameworks without a runtime association predate it and are accepted for any runtime.
+func frameworkBelongsToRuntime(framework *entity.Framework, runtimeID int) bool {
+	if len(framework.Runtimes) == 0 {
+		return true
+	}
+	for _, runtime := range framework.Runtimes {
+		if runtime.ID == runtimeID {
+			return true
+		}
+	}
+	return false
+}
+
+// resolvedVersionTag returns the upstream tag the used version was resolved to, or "" when it is unverified.
+// A version is resolved when its commit SHA was found from the repository's tags (the used version is then
+// the matching tag name) or when it matches the latest tag of the repository.
+func resolvedVersionTag(appDep *entity.AppDependency, dep *entity.Dependency) string {
+	if appDep.UsedCommitSHA != nil && *appDep.UsedCommitSHA != "" {
+		return appDep.UsedVersion
+	}
+	if dep.LastTag != nil && helper.VersionsMatch(appDep.UsedVersion, *dep.LastTag) {
+		return *dep.LastTag
+	}
+	return ""
+}
+
 // derefString safely dereferences a *string, returns "" if nil
+// tagPattern accepts tags such as "team:payments", "env:prod" or "tier-1", up to 64 characters
+var tagPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._:/-]{0,63}$`)
+
+// normalizeTags trims and lower-cases tags, drops duplicates and rejects malformed ones
+func normalizeTags(tags []string) ([]string, error) {
+	var result []string
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || slices.Contains(result, tag) {
+			continue
+		}
+		if !tagPattern.MatchString(tag) {
+			return nil, fmt.Errorf("tag %q must be 1-64 letters, digits or . _ : / - and start with a letter or digit: %w", tag, ErrInvalidInput)
+		}
+		result = append(result, tag)
+	}
+	return result, nil
+}
+
+// nonNilStrings returns values, or an empty slice when it is nil so it encodes as []
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
 func derefString(s *string) string {
 	if s != nil {
 		return *s
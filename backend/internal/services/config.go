@@ -0,0 +1,73 @@
+package services
+
+import (
+	"elang-backend/internal/helper"
+	"elang-backend/internal/usecase"
+	"strings"
+)
+
+// ServiceConfig holds the settings the application and dependency services are created with. The zero value
+// uses the default of every setting.
+type ServiceConfig struct {
+	Scanner helper.ScannerConfig // advisory databases and concurrency of scans
+	Policy  helper.PolicyConfig  // how scans with unchecked dependencies are judged, and how severities are named
+	// SBOMTool is credited in generated SBOMs; the zero value means helper.NewSBOMTool("", "")
+	SBOMTool helper.CycloneDXTool
+	// MaxDependenciesPerApp bounds how many dependencies one manifest may declare; zero or less means
+	// DefaultMaxDependenciesPerApp
+	MaxDependenciesPerApp int
+
+	// GitHubHost is the host of a GitHub Enterprise Server, e.g. "github.example.com", whose repository URLs
+	// are recognized next to github.com's
+	GitHubHost string
+	// TrustedRepositoryHosts are the hosts repository URLs are accepted from; none means
+	// helper.DefaultTrustedRepositoryHosts
+	TrustedRepositoryHosts []string
+	// DefaultBranchFallbacks are tried, in order, when GitHub cannot report a repository's default branch;
+	// none means "main", then "master"
+	DefaultBranchFallbacks []string
+	// CommitHistoryLimit is how many of a repository's latest commits are read when dependency metadata is
+	// fetched; zero or less means usecase.DefaultCommitListLimit, and it is capped at usecase.MaxCommitListLimit
+	CommitHistoryLimit int
+	// CountCollaborators lists the collaborators of dependency repositories when their metadata is fetched, for
+	// the maintenance risk. GitHub only lists them to tokens with push access, so it is off by default.
+	CountCollaborators bool
+	// DependencyProcessingWorkers is how many dependencies are looked up on GitHub at once when an application
+	// is added or cloned, or dependencies are added to it; zero or less means DefaultDependencyProcessingWorkers
+	DependencyProcessingWorkers int
+	// AutoScanOnAdd scans a new application once its dependencies are processed, when the AddApplication
+	// request does not say
+	AutoScanOnAdd bool
+}
+
+// defaultBranchFallbacks are tried in order when GitHub cannot report a repository's default branch
+var defaultBranchFallbacks = []string{"main", "master"}
+
+// withDefaults fills in the settings left at their zero value
+func (c ServiceConfig) withDefaults() ServiceConfig {
+	if c.SBOMTool == (helper.CycloneDXTool{}) {
+		c.SBOMTool = helper.NewSBOMTool("", "")
+	}
+	if c.MaxDependenciesPerApp <= 0 {
+		c.MaxDependenciesPerApp = DefaultMaxDependenciesPerApp
+	}
+	c.GitHubHost = strings.ToLower(strings.TrimSpace(c.GitHubHost))
+	var fallbacks []string
+	for _, branch := range c.DefaultBranchFallbacks {
+		if branch = strings.TrimSpace(branch); branch != "" {
+			fallbacks = append(fallbacks, branch)
+		}
+	}
+	if len(fallbacks) == 0 {
+		fallbacks = defaultBranchFallbacks
+	}
+	c.DefaultBranchFallbacks = fallbacks
+	if c.CommitHistoryLimit <= 0 {
+		c.CommitHistoryLimit = usecase.DefaultCommitListLimit
+	}
+	c.CommitHistoryLimit = min(c.CommitHistoryLimit, usecase.MaxCommitListLimit)
+	if c.DependencyProcessingWorkers <= 0 {
+		c.DependencyProcessingWorkers = DefaultDependencyProcessingWorkers
+	}
+	return c
+}
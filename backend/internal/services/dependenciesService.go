@@ -9,8 +9,11 @@ import (
 	"elang-backend/internal/model/dto"
 	"elang-backend/internal/repository"
 	"elang-backend/internal/usecase"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -18,6 +21,13 @@ import (
 	"github.com/google/uuid"
 )
 
+// NoDependenciesWarning is reported when a manifest parses to zero dependencies
+const NoDependenciesWarning = "no dependencies detected — check file format/runtime"
+
+// ManifestNotStoredWarning is reported when the manifest of a new application could not be kept in object
+// storage, so the application cannot be re-parsed later
+const ManifestNotStoredWarning = "manifest not stored — the application cannot be re-parsed"
+
 // MonitoringJobContext holds context for active monitoring jobs
 type MonitoringJobContext struct {
 	Job        *entity.MonitoringJob
@@ -44,28 +54,47 @@ type DependenciesService struct {
 	cveService             *helper.CVEHelper
 	objectStorageService   usecase.ObjectStorageInterface
 	sharedScanner          *helper.SharedScanner
+	maxDependencies        int                  // most dependencies a manifest may declare
+	policy                 helper.PolicyConfig  // how scans with unchecked dependencies are judged
+	sbomTool               helper.CycloneDXTool // tool credited in generated SBOMs
 
 	appRepository       repository.ApplicationRepository
 	depedencyRepository repository.DependencyRepository
 	appDepedencyRepo    repository.AppDependencyRepository
 	runTimeRepository   repository.RuntimeRepository
+	scanResultRepo      repository.ScanResultRepository
+	remediationRepo     repository.RemediationRepository
+	severityOverrides   repository.SeverityOverrideRepository
 
 	activeJobs   map[uuid.UUID]*MonitoringJobContext // Save active monitoring jobs
 	jobsMutex    sync.RWMutex                        // Mutex to protect access to activeJobs
 	shutdownChan chan struct{}                       // Channel to signal shutdown
+	shutdownOnce sync.Once                           // Guards closing shutdownChan
 	workerPool   chan struct{}                       // For controlling concurrency
+
+	rootCtx     context.Context    // Parent context for monitoring scans, cancelled on shutdown
+	cancelRoot  context.CancelFunc // Cancels rootCtx
+	monitorJobs sync.WaitGroup     // Tracks running monitoring loops
 }
 
 func NewDependenciesService(basicRepo dto.BasicRepositories,
 	dependencyParser helper.DependencyParser,
-	objectStorageService usecase.ObjectStorageInterface) DependenciesInterface {
+	objectStorageService usecase.ObjectStorageInterface,
+	config ServiceConfig) DependenciesInterface {
+	config = config.withDefaults()
+	rootCtx, cancelRoot := context.WithCancel(context.Background())
 	return &DependenciesService{
+		rootCtx:                rootCtx,
+		cancelRoot:             cancelRoot,
 		depedencyParserService: dependencyParser,
-		cveService:             helper.NewCVEHelper(),
-		sharedScanner:          helper.NewSharedScanner(10), // default max 10 concurrent scans
+		cveService:             helper.NewCVEHelperWithSources(config.Scanner.MergeSources, config.Scanner.Sources...),
+		sharedScanner:          helper.NewSharedScanner(config.Scanner),
 		activeJobs:             make(map[uuid.UUID]*MonitoringJobContext),
 		shutdownChan:           make(chan struct{}),
 		workerPool:             make(chan struct{}, 5), // default max 5 concurrent jobs
+		maxDependencies:        config.MaxDependenciesPerApp,
+		policy:                 config.Policy,
+		sbomTool:               config.SBOMTool,
 
 		objectStorageService: objectStorageService,
 
@@ -73,6 +102,9 @@ func NewDependenciesService(basicRepo dto.BasicRepositories,
 		depedencyRepository: basicRepo.DepedencyRepository,
 		appDepedencyRepo:    basicRepo.AppToDepedencyRepository,
 		runTimeRepository:   basicRepo.RunTimeRepository,
+		scanResultRepo:      basicRepo.ScanResultRepository,
+		remediationRepo:     basicRepo.RemediationRepository,
+		severityOverrides:   basicRepo.SeverityOverrideRepository,
 	}
 }
 
@@ -82,17 +114,71 @@ func (s *DependenciesService) ScanDependencies(ctx context.Context, appName, run
 		return nil, fmt.Errorf("appName, version, and content are required")
 	}
 
-	if !isRuntimeSupported(runtime) {
-		return nil, fmt.Errorf("runtime %s is not supported", runtime)
+	if !s.depedencyParserService.IsRuntimeEnabled(runtime) {
+		return nil, fmt.Errorf("runtime %s is not supported or disabled: %w", runtime, ErrInvalidInput)
 	}
 
 	// Parse dependencies from the provided content
-	deps := s.depedencyParserService.ParseDependencyFile(fileName, content, parser.RuntimeType(runtime))
-	if len(deps.Dependencies) == 0 {
-		return nil, fmt.Errorf("no dependencies found in the provided content")
+	deps := s.depedencyParserService.WithGradleProperties(helper.GradlePropertiesFile(ctx)).WithGoIndirect(ctx).ParseDependencyFileContext(ctx, fileName, content, parser.RuntimeType(runtime))
+	if !deps.Success {
+		return nil, fmt.Errorf("failed to parse %s: %s: %w", fileName, deps.Error, ErrInvalidInput)
+	}
+	if err := checkDependencyLimit(fileName, len(deps.Dependencies), s.maxDependencies); err != nil {
+		return nil, err
 	}
+	return s.scanParsedDependencies(ctx, appName, runtime, version, fileName, deps.Dependencies, nil)
+}
 
-	findings, depsWithVulns, totalCritical, totalHigh, totalMedium, totalLow := s.sharedScanner.ScanDependenciesWithControl(ctx, deps.Dependencies)
+// ScanMultipleDependencies scans several manifests of one application, such as the packages of a monorepo, as
+// one. Their dependencies are merged; a dependency the manifests pin to different versions is scanned once per
+// version and reported in the result's conflicts.
+func (s *DependenciesService) ScanMultipleDependencies(ctx context.Context, appName, runtime, version, description string, files []helper.ManifestFile) (interface{}, error) {
+	if len(files) == 1 {
+		return s.ScanDependencies(ctx, appName, runtime, version, description, files[0].FileName, files[0].Content)
+	}
+	if appName == "" || len(files) == 0 || runtime == "" {
+		return nil, fmt.Errorf("appName, runtime, and at least one file are required: %w", ErrInvalidInput)
+	}
+	fileNames := make([]string, len(files))
+	for i, file := range files {
+		if file.Content == "" {
+			return nil, fmt.Errorf("%s is empty: %w", file.FileName, ErrInvalidInput)
+		}
+		fileNames[i] = file.FileName
+	}
+
+	if !s.depedencyParserService.IsRuntimeEnabled(runtime) {
+		return nil, fmt.Errorf("runtime %s is not supported or disabled: %w", runtime, ErrInvalidInput)
+	}
+
+	merged, err := s.depedencyParserService.WithGradleProperties(helper.GradlePropertiesFile(ctx)).WithGoIndirect(ctx).ParseMultipleDependencyFilesContext(ctx, files, parser.RuntimeType(runtime))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", err, ErrInvalidInput)
+	}
+	fileName := strings.Join(fileNames, ", ")
+	if err := checkDependencyLimit(fileName, len(merged.Dependencies), s.maxDependencies); err != nil {
+		return nil, err
+	}
+	return s.scanParsedDependencies(ctx, appName, runtime, version, fileName, merged.Dependencies, merged.Conflicts)
+}
+
+// scanParsedDependencies scans the dependencies parsed from the manifests named fileName, stores the SBOM and
+// the result, and returns the result
+func (s *DependenciesService) scanParsedDependencies(ctx context.Context, appName, runtime, version, fileName string, dependencies []parser.DependencyInfo, conflicts []model.VersionConflict) (model.ScanApplicationResult, error) {
+	// An empty manifest is still scanned so the caller gets a result with an explicit warning
+	var warnings []string
+	if len(dependencies) == 0 {
+		slog.Warn("Manifest contains no dependencies", "file_name", fileName, "runtime", runtime)
+		warnings = append(warnings, NoDependenciesWarning)
+	}
+
+	// Uploaded manifests belong to no application, so only the caller's overrides for every application apply
+	ownerID, _ := repository.OwnerScope(ctx)
+	ctx, err := withSeverityOverrides(ctx, s.severityOverrides, ownerID, nil)
+	if err != nil {
+		return model.ScanApplicationResult{}, err
+	}
+	findings, depsWithVulns, totalCritical, totalHigh, totalMedium, totalLow := s.sharedScanner.ScanDependenciesWithControl(ctx, dependencies)
 
 	// START SCANNING PROCESS
 	// TEMPORARY: Using previous scanning logic for reference
@@ -100,8 +186,8 @@ func (s *DependenciesService) ScanDependencies(ctx context.Context, appName, run
 
 	// Aggregate summary and evaluate policies
 	summary := helper.AggregateVulnerabilitySummary(findings)
-	failOn := []string{"high", "critical"}
-	policyStatus, policyReason := helper.EvaluatePolicy(summary, failOn)
+	failOn, minScore := helper.ScanPolicyFor(ctx)
+	policyStatus, policyReason := helper.EvaluatePolicyWithScore(summary, findings, failOn, minScore, s.policy)
 
 	scanID := uuid.New().String()
 
@@ -110,20 +196,11 @@ func (s *DependenciesService) ScanDependencies(ctx context.Context, appName, run
 		SBOM:                fmt.Sprintf("https://your-app/api/scans/%s/sbom", scanID),
 	}
 
-	result := model.ScanApplicationResult{
-		AppID:      scanID,
-		AppName:    appName,
-		ScanStatus: "completed",
-		Summary:    summary,
-		Policies:   model.ScanPolicy{FailOn: failOn, Status: policyStatus, Reason: policyReason},
-		Artifacts:  artifacts,
-		Findings:   findings,
-	}
-
 	// Generate enhanced SBOM from comprehensive vulnerability data
 	enhancedSBOMData := helper.EnhancedSBOMData{
 		AppID:         scanID,
 		AppName:       appName,
+		Tool:          s.sbomTool,
 		AppVersion:    version, // You can fetch this from app metadata if available
 		Runtime:       runtime,
 		Dependencies:  depsWithVulns,
@@ -133,6 +210,7 @@ func (s *DependenciesService) ScanDependencies(ctx context.Context, appName, run
 		HighCount:     totalHigh,
 		MediumCount:   totalMedium,
 		LowCount:      totalLow,
+		Deterministic: helper.DeterministicSBOM(ctx),
 	}
 
 	sbomBytes, err := helper.GenerateEnhancedCycloneDXSBOM(enhancedSBOMData)
@@ -147,21 +225,784 @@ func (s *DependenciesService) ScanDependencies(ctx context.Context, appName, run
 
 		// Save SBOM to object storage if service is available
 		if s.objectStorageService != nil {
-			sbomKey, err := s.objectStorageService.SaveSBOM(ctx, scanID, appName, sbomBytes, "json")
+			sbomKey, err := s.objectStorageService.SaveSBOM(callerStorageContext(ctx), scanID, appName, sbomBytes, "json")
 			if err != nil {
 				slog.Error("Failed to save SBOM to object storage", "error", err)
 			} else {
 				slog.Info("SBOM saved to object storage successfully", "key", sbomKey)
 				// Update the SBOM artifact URL with the actual storage key
 				artifacts.SBOM = fmt.Sprintf("https://your-app/api/sbom/%s", sbomKey)
+				artifacts.SBOMObjectKey = sbomKey
 			}
 		} else {
 			slog.Warn("Object storage service not available, SBOM not persisted")
 		}
 	}
+
+	result := model.ScanApplicationResult{
+		ScanID:          scanID,
+		AppID:           scanID,
+		AppName:         appName,
+		ScanStatus:      "completed",
+		Summary:         summary,
+		Policies:        model.ScanPolicy{FailOn: failOn, MinScore: minScore, FailClosed: s.policy.FailClosed, Status: policyStatus, Reason: policyReason},
+		Artifacts:       artifacts,
+		Findings:        findings,
+		Unchecked:       helper.UncheckedDependencies(findings),
+		DependencyCount: len(dependencies),
+		Warnings:        warnings,
+		Conflicts:       conflicts,
+		ScannedAt:       time.Now().UTC(),
+	}
+
+	if err := persistScanResult(ctx, s.scanResultRepo, nil, "adhoc", result); err != nil {
+		slog.Error("Failed to persist scan result", "scan_id", scanID, "error", err)
+	}
+	return result, nil
+}
+
+// SeverityStyles lists the presentation of every severity with the labels and colors the service was configured with
+func (s *DependenciesService) SeverityStyles() []helper.SeverityStyle {
+	return s.policy.Severities.Styles()
+}
+
+// CheckDependency looks up vulnerabilities for one library version. Nothing is stored;
+// the dependency is normalized and checked against OSV like any scanned dependency.
+func (s *DependenciesService) CheckDependency(ctx context.Context, req *model.CheckDependencyRequest) (*helper.DependencyVulnerabilityResult, error) {
+	name, version := strings.TrimSpace(req.Name), strings.TrimSpace(req.Version)
+	if name == "" || version == "" || strings.TrimSpace(req.Runtime) == "" {
+		return nil, fmt.Errorf("name, version and runtime are required: %w", ErrInvalidInput)
+	}
+	if !s.depedencyParserService.IsRuntimeEnabled(req.Runtime) {
+		return nil, fmt.Errorf("runtime %s is not supported or disabled: %w", req.Runtime, ErrInvalidInput)
+	}
+
+	dep := parser.DependencyInfo{
+		Name:    name,
+		Owner:   req.Owner,
+		Repo:    req.Repo,
+		Version: version,
+		Runtime: string(helper.ResolveRuntimeType(req.Runtime)),
+	}
+	return s.cveService.CheckDependencyVulnerabilities(ctx, dep)
+}
+
+// DiffManifests compares two versions of a manifest of the same runtime and checks the added and
+// version-changed dependencies for vulnerabilities. For changed dependencies the old version is checked
+// too, so only vulnerabilities the new manifest introduces are reported as new. Nothing is stored.
+func (s *DependenciesService) DiffManifests(ctx context.Context, runtime, oldFileName, oldContent, newFileName, newContent string) (*model.ManifestDiffResult, error) {
+	if strings.TrimSpace(runtime) == "" || oldContent == "" || newContent == "" {
+		return nil, fmt.Errorf("runtime and both manifests are required: %w", ErrInvalidInput)
+	}
+	if !s.depedencyParserService.IsRuntimeEnabled(runtime) {
+		return nil, fmt.Errorf("runtime %s is not supported or disabled: %w", runtime, ErrInvalidInput)
+	}
+
+	oldDeps := s.depedencyParserService.ParseDependencyFileContext(ctx, oldFileName, oldContent, parser.RuntimeType(runtime))
+	if !oldDeps.Success {
+		return nil, fmt.Errorf("failed to parse old manifest %s: %s: %w", oldFileName, oldDeps.Error, ErrInvalidInput)
+	}
+	newDeps := s.depedencyParserService.ParseDependencyFileContext(ctx, newFileName, newContent, parser.RuntimeType(runtime))
+	if !newDeps.Success {
+		return nil, fmt.Errorf("failed to parse new manifest %s: %s: %w", newFileName, newDeps.Error, ErrInvalidInput)
+	}
+	if err := checkDependencyLimit(oldFileName, len(oldDeps.Dependencies), s.maxDependencies); err != nil {
+		return nil, err
+	}
+	if err := checkDependencyLimit(newFileName, len(newDeps.Dependencies), s.maxDependencies); err != nil {
+		return nil, err
+	}
+
+	result := &model.ManifestDiffResult{
+		Runtime:                 runtime,
+		Added:                   []model.ManifestDependencyChange{},
+		Removed:                 []model.ManifestDependencyChange{},
+		Changed:                 []model.ManifestDependencyChange{},
+		NewVulnerabilities:      []model.ManifestVulnerability{},
+		ResolvedVulnerabilities: []model.ManifestVulnerability{},
+		ComparedAt:              time.Now().UTC(),
+	}
+
+	// Dependencies are matched by normalized name so spelling differences (e.g. PyPI case) are not changes
+	normalizer := helper.NewDependencyNameNormalizer()
+	oldByName := make(map[string]parser.DependencyInfo, len(oldDeps.Dependencies))
+	for _, dep := range oldDeps.Dependencies {
+		key := normalizer.NormalizeName(dep.Name, dep.Runtime)
+		if _, seen := oldByName[key]; !seen {
+			oldByName[key] = dep
+		}
+	}
+
+	var toScan []parser.DependencyInfo
+	seen := make(map[string]bool, len(newDeps.Dependencies))
+	for _, dep := range newDeps.Dependencies {
+		key := normalizer.NormalizeName(dep.Name, dep.Runtime)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		old, existed := oldByName[key]
+		switch {
+		case !existed:
+			result.Added = append(result.Added, model.ManifestDependencyChange{Name: dep.Name, NewVersion: dep.Version})
+			toScan = append(toScan, dep)
+		case helper.VersionsMatch(old.Version, dep.Version):
+			result.Unchanged++
+		default:
+			result.Changed = append(result.Changed, model.ManifestDependencyChange{Name: dep.Name, OldVersion: old.Version, NewVersion: dep.Version})
+			toScan = append(toScan, old, dep)
+		}
+	}
+	for key, dep := range oldByName {
+		if !seen[key] {
+			result.Removed = append(result.Removed, model.ManifestDependencyChange{Name: dep.Name, OldVersion: dep.Version})
+		}
+	}
+	sort.Slice(result.Removed, func(i, j int) bool { return result.Removed[i].Name < result.Removed[j].Name })
+
+	_, scanned, _, _, _, _ := s.sharedScanner.ScanDependenciesWithControl(ctx, toScan)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	vulnsByVersion := make(map[string][]helper.VulnerabilityInfo, len(scanned))
+	for _, dep := range scanned {
+		vulnsByVersion[dep.Name+"@"+dep.Version] = dep.Vulnerabilities
+	}
+
+	for _, added := range result.Added {
+		result.NewVulnerabilities = append(result.NewVulnerabilities,
+			manifestVulnerabilities(added.Name, added.NewVersion, vulnsByVersion[added.Name+"@"+added.NewVersion], nil)...)
+	}
+	for _, changed := range result.Changed {
+		oldName := oldByName[normalizer.NormalizeName(changed.Name, runtime)].Name
+		before := vulnsByVersion[oldName+"@"+changed.OldVersion]
+		after := vulnsByVersion[changed.Name+"@"+changed.NewVersion]
+		result.NewVulnerabilities = append(result.NewVulnerabilities, manifestVulnerabilities(changed.Name, changed.NewVersion, after, before)...)
+		result.ResolvedVulnerabilities = append(result.ResolvedVulnerabilities, manifestVulnerabilities(oldName, changed.OldVersion, before, after)...)
+	}
+
+	for _, vuln := range result.NewVulnerabilities {
+		if helper.ParseSeverity(vuln.Severity).Rank() > helper.ParseSeverity(result.HighestNewSeverity).Rank() {
+			result.HighestNewSeverity = vuln.Severity
+		}
+	}
+	result.IntroducesVulnerabilities = len(result.NewVulnerabilities) > 0
 	return result, nil
 }
 
+// manifestVulnerabilities lists the vulnerabilities of one dependency version that are not in except,
+// comparing by every ID a vulnerability is known under
+func manifestVulnerabilities(name, version string, vulns, except []helper.VulnerabilityInfo) []model.ManifestVulnerability {
+	known := make(map[string]bool)
+	for _, vuln := range except {
+		for _, id := range append([]string{vuln.ID, vuln.CVE}, vuln.Aliases...) {
+			known[strings.ToUpper(id)] = true
+		}
+	}
+
+	result := []model.ManifestVulnerability{}
+	for _, vuln := range vulns {
+		if known[strings.ToUpper(vuln.ID)] || (vuln.CVE != "" && known[strings.ToUpper(vuln.CVE)]) || anyKnown(known, vuln.Aliases) {
+			continue
+		}
+		result = append(result, model.ManifestVulnerability{
+			ID:         vuln.ID,
+			Dependency: name,
+			Version:    version,
+			Severity:   vuln.Severity.String(),
+			Summary:    vuln.Summary,
+		})
+	}
+	return result
+}
+
+func anyKnown(known map[string]bool, ids []string) bool {
+	for _, id := range ids {
+		if known[strings.ToUpper(id)] {
+			return true
+		}
+	}
+	return false
+}
+
+// GetScanResult returns the complete stored result of a previous scan
+func (s *DependenciesService) GetScanResult(ctx context.Context, scanID string) (*model.ScanApplicationResult, error) {
+	id, err := uuid.Parse(scanID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid scan ID: %w", ErrInvalidInput)
+	}
+	if s.scanResultRepo == nil {
+		return nil, fmt.Errorf("scan result storage not available")
+	}
+
+	scan, err := s.getAccessibleScan(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Scans that have not finished yet have no stored payload
+	if len(scan.Result) == 0 {
+		result := model.ScanApplicationResult{
+			ScanID:     scan.ID.String(),
+			AppName:    scan.AppName,
+			ScanStatus: scan.Status,
+		}
+		if scan.AppID != nil {
+			result.AppID = scan.AppID.String()
+		}
+		return &result, nil
+	}
+
+	var result model.ScanApplicationResult
+	if err := json.Unmarshal(scan.Result, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode stored scan result: %w", err)
+	}
+	return &result, nil
+}
+
+// EvaluateScanPolicy runs a proposed policy against the summary of a completed scan and reports the
+// verdict. Nothing is stored, so teams can tune their gates against real results first.
+func (s *DependenciesService) EvaluateScanPolicy(ctx context.Context, scanID string, req *model.EvaluatePolicyRequest) (*model.PolicyEvaluation, error) {
+	failOn, err := helper.NormalizePolicySeverities(req.FailOn)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", err, ErrInvalidInput)
+	}
+	if req.MinScore < 0 || req.MinScore > 10 {
+		return nil, fmt.Errorf("min_score must be between 0 and 10: %w", ErrInvalidInput)
+	}
+
+	result, err := s.GetScanResult(ctx, scanID)
+	if err != nil {
+		return nil, err
+	}
+	if result.ScanStatus != "" && result.ScanStatus != "completed" {
+		return nil, fmt.Errorf("scan %s is %s, policies can only be evaluated on completed scans: %w", scanID, result.ScanStatus, ErrConflict)
+	}
+
+	status, reason := helper.EvaluatePolicyWithScore(result.Summary, result.Findings, failOn, req.MinScore, s.policy)
+	return &model.PolicyEvaluation{
+		ScanID:       result.ScanID,
+		FailOn:       failOn,
+		MinScore:     req.MinScore,
+		Status:       status,
+		Reason:       reason,
+		Summary:      result.Summary,
+		StoredStatus: result.Policies.Status,
+	}, nil
+}
+
+// getAccessibleScan loads a scan visible to the caller. Scans of applications that belong to other
+// users, and ad-hoc scans other users ran, are reported as not found, like scans that do not exist.
+func (s *DependenciesService) getAccessibleScan(ctx context.Context, id uuid.UUID) (*entity.ScanResult, error) {
+	scan, err := s.scanResultRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, lookupError(err, "scan "+id.String())
+	}
+	ownerID, scoped := repository.OwnerScope(ctx)
+	if !scoped {
+		return scan, nil
+	}
+	if scan.AppID == nil {
+		if scan.OwnerID == nil || *scan.OwnerID != ownerID {
+			return nil, fmt.Errorf("scan %s: %w", id, ErrNotFound)
+		}
+		return scan, nil
+	}
+	if s.appRepository == nil {
+		return scan, nil
+	}
+	if _, err := s.appRepository.GetByID(ctx, *scan.AppID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, fmt.Errorf("scan %s: %w", id, ErrNotFound)
+		}
+		return nil, lookupError(err, "application of scan "+id.String())
+	}
+	return scan, nil
+}
+
+// GetScanStatus returns the progress of a scan (queued, running, completed, failed)
+func (s *DependenciesService) GetScanStatus(ctx context.Context, scanID string) (*model.ScanJobStatus, error) {
+	id, err := uuid.Parse(scanID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid scan ID: %w", ErrInvalidInput)
+	}
+	if s.scanResultRepo == nil {
+		return nil, fmt.Errorf("scan result storage not available")
+	}
+
+	scan, err := s.getAccessibleScan(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toScanJobStatus(scan), nil
+}
+
+// Dependency catalog page sizes
+const (
+	DefaultDependencyPageSize = 20
+	MaxDependencyPageSize     = 100
+)
+
+// SearchDependencies returns one page of the dependencies whose name contains search, ordered by name.
+// Each dependency carries the number of applications visible to the caller that use it.
+func (s *DependenciesService) SearchDependencies(ctx context.Context, search string, limit, offset int) (*model.DependencyCatalogResponse, error) {
+	if limit <= 0 {
+		limit = DefaultDependencyPageSize
+	}
+	if limit > MaxDependencyPageSize {
+		return nil, fmt.Errorf("limit must be at most %d: %w", MaxDependencyPageSize, ErrInvalidInput)
+	}
+	if offset < 0 {
+		return nil, fmt.Errorf("offset must not be negative: %w", ErrInvalidInput)
+	}
+	if s.depedencyRepository == nil {
+		return nil, errors.New("dependency repository is not configured")
+	}
+
+	usages, total, err := s.depedencyRepository.SearchPage(ctx, strings.TrimSpace(search), limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search dependencies: %w", err)
+	}
+
+	catalog := &model.DependencyCatalogResponse{
+		Dependencies: make([]model.DependencyCatalogItem, 0, len(usages)),
+		Total:        total,
+		Limit:        limit,
+		Offset:       offset,
+	}
+	for _, usage := range usages {
+		catalog.Dependencies = append(catalog.Dependencies, model.DependencyCatalogItem{
+			DependencyID:     usage.ID.String(),
+			Name:             usage.Name,
+			Owner:            usage.Owner,
+			Repo:             usage.Repo,
+			RepositoryURL:    usage.RepositoryURL,
+			LastTag:          usage.LastTag,
+			LastTagAt:        usage.LastTagAt,
+			ApplicationCount: usage.AppCount,
+		})
+	}
+	return catalog, nil
+}
+
+// Findings page sizes
+const (
+	DefaultFindingsPageSize = 50
+	MaxFindingsPageSize     = 500
+)
+
+// ListFindings returns one page of the findings recorded by an application's completed scans that match query,
+// newest scan first, so for example the critical findings of the last 30 days or the history of one
+// vulnerability can be followed. Each vulnerability of a finding is listed separately.
+func (s *DependenciesService) ListFindings(ctx context.Context, appUID string, query model.FindingsQuery) (*model.FindingsResponse, error) {
+	limit, offset := query.Limit, query.Offset
+	if limit <= 0 {
+		limit = DefaultFindingsPageSize
+	}
+	if limit > MaxFindingsPageSize {
+		return nil, fmt.Errorf("limit must be at most %d: %w", MaxFindingsPageSize, ErrInvalidInput)
+	}
+	if offset < 0 {
+		return nil, fmt.Errorf("offset must not be negative: %w", ErrInvalidInput)
+	}
+	appID, err := uuid.Parse(appUID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid app ID: %w", ErrInvalidInput)
+	}
+	if _, err := s.appRepository.GetByID(ctx, appID); err != nil {
+		return nil, lookupError(err, "application "+appUID)
+	}
+	if s.scanResultRepo == nil {
+		return nil, fmt.Errorf("scan result storage not available")
+	}
+
+	filter := repository.FindingFilter{
+		AppID:           appID,
+		Dependency:      strings.TrimSpace(query.Dependency),
+		VulnerabilityID: strings.TrimSpace(query.Vulnerability),
+		Limit:           limit,
+		Offset:          offset,
+	}
+	for _, value := range strings.Split(query.Severity, ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		severity := helper.ParseSeverity(value)
+		if severity == helper.SeverityUnknown && !strings.EqualFold(value, string(helper.SeverityUnknown)) {
+			return nil, fmt.Errorf("unknown severity %q: %w", value, ErrInvalidInput)
+		}
+		filter.Severities = append(filter.Severities, severity.String())
+	}
+	if since := strings.TrimSpace(query.Since); since != "" {
+		parsed, err := parseSince(since)
+		if err != nil {
+			return nil, err
+		}
+		filter.Since = &parsed
+	}
+
+	findings, total, err := s.scanResultRepo.QueryFindings(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query findings: %w", err)
+	}
+	remediations, err := findingRemediations(ctx, s.remediationRepo, appID)
+	if err != nil {
+		return nil, err
+	}
+	resp := &model.FindingsResponse{
+		AppID:    appID.String(),
+		Findings: make([]model.FindingRecord, 0, len(findings)),
+		Total:    total,
+		Limit:    limit,
+		Offset:   offset,
+	}
+	for _, finding := range findings {
+		resp.Findings = append(resp.Findings, model.FindingRecord{
+			ScanID:             finding.ScanID.String(),
+			ScannedAt:          finding.ScannedAt,
+			Dependency:         finding.Dependency,
+			Version:            finding.Version,
+			VulnerabilityID:    finding.VulnerabilityID,
+			Severity:           finding.Severity,
+			RiskScore:          finding.RiskScore,
+			RecommendedVersion: finding.RecommendedVersion,
+			Remediation:        remediations[remediationKey(finding.Dependency, finding.VulnerabilityID)],
+		})
+	}
+	return resp, nil
+}
+
+// findingsExportPageSize is how many stored findings StreamLatestFindings reads at a time
+const findingsExportPageSize = 500
+
+// StreamLatestFindings passes the vulnerable dependencies of an application's latest completed scan to write one
+// at a time, by name and version, and stops at the first error write returns. They are read from the stored
+// findings a page at a time, so large scans are never held in memory. Nothing is written when the application
+// does not exist or has no completed scan.
+func (s *DependenciesService) StreamLatestFindings(ctx context.Context, appUID string, write func(model.ScanFinding) error) error {
+	appID, err := uuid.Parse(appUID)
+	if err != nil {
+		return fmt.Errorf("invalid app ID: %w", ErrInvalidInput)
+	}
+	if _, err := s.appRepository.GetByID(ctx, appID); err != nil {
+		return lookupError(err, "application "+appUID)
+	}
+	if s.scanResultRepo == nil {
+		return fmt.Errorf("scan result storage not available")
+	}
+
+	latest, err := s.scanResultRepo.GetLatestCompletedByAppID(ctx, appID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return fmt.Errorf("application %s has no completed scan: %w", appUID, ErrNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to fetch latest scan: %w", err)
+	}
+
+	// Stored findings hold one vulnerability each; those of the same dependency version are adjacent and merged
+	var pending *model.ScanFinding
+	for offset := 0; ; offset += findingsExportPageSize {
+		records, _, err := s.scanResultRepo.QueryFindings(ctx, repository.FindingFilter{
+			AppID: appID, ScanID: latest.ID, Limit: findingsExportPageSize, Offset: offset,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to fetch findings of scan %s: %w", latest.ID, err)
+		}
+		for _, record := range records {
+			if pending != nil && pending.Dependency == record.Dependency && pending.Version == record.Version {
+				pending.VulnerabilityIDs = append(pending.VulnerabilityIDs, record.VulnerabilityID)
+				continue
+			}
+			if pending != nil {
+				if err := write(*pending); err != nil {
+					return err
+				}
+			}
+			pending = &model.ScanFinding{
+				Dependency:         record.Dependency,
+				Version:            record.Version,
+				Severity:           record.Severity,
+				VulnerabilityIDs:   []string{record.VulnerabilityID},
+				Recommendation:     record.Recommendation,
+				RecommendedVersion: record.RecommendedVersion,
+			}
+		}
+		if len(records) < findingsExportPageSize {
+			break
+		}
+	}
+	if pending == nil {
+		return nil
+	}
+	return write(*pending)
+}
+
+// parseSince accepts an RFC 3339 time or a YYYY-MM-DD date, which means midnight UTC
+func parseSince(value string) (time.Time, error) {
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed.UTC(), nil
+	}
+	if parsed, err := time.Parse(time.DateOnly, value); err == nil {
+		return parsed, nil
+	}
+	return time.Time{}, fmt.Errorf("since %q must be an RFC 3339 time or a YYYY-MM-DD date: %w", value, ErrInvalidInput)
+}
+
+// Dashboard top-N list sizes
+const (
+	DefaultDashboardTopN = 5
+	MaxDashboardTopN     = 50
+)
+
+// GetDashboardSummary aggregates the latest completed scan of every application visible to the caller
+// into organization-wide totals. It reads persisted scan results only and never triggers a scan.
+func (s *DependenciesService) GetDashboardSummary(ctx context.Context, topN int) (*model.DashboardSummary, error) {
+	if topN <= 0 {
+		topN = DefaultDashboardTopN
+	}
+	if topN > MaxDashboardTopN {
+		return nil, fmt.Errorf("top must be at most %d: %w", MaxDashboardTopN, ErrInvalidInput)
+	}
+	if s.scanResultRepo == nil || s.appRepository == nil {
+		return nil, fmt.Errorf("scan result storage not available")
+	}
+
+	apps, err := s.appRepository.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applications: %w", err)
+	}
+	scans, err := s.scanResultRepo.GetLatestCompletedPerApp(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch latest scans: %w", err)
+	}
+
+	summary := &model.DashboardSummary{
+		ScannedApplications:       len(scans),
+		TopVulnerableDependencies: []model.DashboardDependency{},
+		MostAtRiskApplications:    []model.DashboardApplication{},
+		GeneratedAt:               time.Now().UTC(),
+	}
+	for _, app := range apps {
+		if !app.IsDeleted {
+			summary.TotalApplications++
+		}
+	}
+
+	dependencies := make(map[string]*model.DashboardDependency)
+	for _, scan := range scans {
+		if scan.PolicyStatus == "fail" {
+			summary.FailingApplications++
+		}
+		summary.Vulnerabilities.Total += scan.TotalVulnerabilities
+		summary.Vulnerabilities.Critical += scan.Critical
+		summary.Vulnerabilities.High += scan.High
+		summary.Vulnerabilities.Medium += scan.Medium
+		summary.Vulnerabilities.Low += scan.Low
+
+		scannedAt := scan.CreatedAt
+		if scan.CompletedAt != nil {
+			scannedAt = *scan.CompletedAt
+		}
+		summary.MostAtRiskApplications = append(summary.MostAtRiskApplications, model.DashboardApplication{
+			AppID:        scan.AppID.String(),
+			AppName:      scan.AppName,
+			PolicyStatus: scan.PolicyStatus,
+			ScanID:       scan.ID.String(),
+			Severities: model.DashboardSeverityTotals{
+				Total:    scan.TotalVulnerabilities,
+				Critical: scan.Critical,
+				High:     scan.High,
+				Medium:   scan.Medium,
+				Low:      scan.Low,
+			},
+			ScannedAt: scannedAt.UTC(),
+		})
+
+		if len(scan.Result) == 0 {
+			continue
+		}
+		var result model.ScanApplicationResult
+		if err := json.Unmarshal(scan.Result, &result); err != nil {
+			slog.Warn("Skipping undecodable scan result in dashboard", "scan_id", scan.ID, "error", err)
+			continue
+		}
+		// Count each application once per dependency, even if it lists several vulnerable versions
+		counted := make(map[string]bool)
+		for _, finding := range result.Findings {
+			if len(finding.VulnerabilityIDs) == 0 {
+				continue
+			}
+			dep, ok := dependencies[finding.Dependency]
+			if !ok {
+				dep = &model.DashboardDependency{Dependency: finding.Dependency}
+				dependencies[finding.Dependency] = dep
+			}
+			if !counted[finding.Dependency] {
+				counted[finding.Dependency] = true
+				dep.Applications++
+			}
+			dep.Vulnerabilities += len(finding.VulnerabilityIDs)
+			if severity := helper.ParseSeverity(finding.Severity); severity.Rank() > helper.ParseSeverity(dep.HighestSeverity).Rank() {
+				dep.HighestSeverity = severity.String()
+			}
+		}
+	}
+
+	for _, dep := range dependencies {
+		summary.TopVulnerableDependencies = append(summary.TopVulnerableDependencies, *dep)
+	}
+	sort.Slice(summary.TopVulnerableDependencies, func(i, j int) bool {
+		a, b := summary.TopVulnerableDependencies[i], summary.TopVulnerableDependencies[j]
+		if a.Applications != b.Applications {
+			return a.Applications > b.Applications
+		}
+		if a.Vulnerabilities != b.Vulnerabilities {
+			return a.Vulnerabilities > b.Vulnerabilities
+		}
+		return a.Dependency < b.Dependency
+	})
+	if len(summary.TopVulnerableDependencies) > topN {
+		summary.TopVulnerableDependencies = summary.TopVulnerableDependencies[:topN]
+	}
+
+	// Most at risk: most critical findings first, then high, medium and low
+	sort.Slice(summary.MostAtRiskApplications, func(i, j int) bool {
+		a, b := summary.MostAtRiskApplications[i].Severities, summary.MostAtRiskApplications[j].Severities
+		switch {
+		case a.Critical != b.Critical:
+			return a.Critical > b.Critical
+		case a.High != b.High:
+			return a.High > b.High
+		case a.Medium != b.Medium:
+			return a.Medium > b.Medium
+		case a.Low != b.Low:
+			return a.Low > b.Low
+		}
+		return summary.MostAtRiskApplications[i].AppName < summary.MostAtRiskApplications[j].AppName
+	})
+	// Applications without findings are not at risk
+	atRisk := summary.MostAtRiskApplications[:0]
+	for _, app := range summary.MostAtRiskApplications {
+		if app.Severities.Total > 0 && len(atRisk) < topN {
+			atRisk = append(atRisk, app)
+		}
+	}
+	summary.MostAtRiskApplications = atRisk
+
+	return summary, nil
+}
+
+// persistScanResult stores the full scan result together with its summary counters
+func persistScanResult(ctx context.Context, repo repository.ScanResultRepository, appID *uuid.UUID, scanType string, result model.ScanApplicationResult) error {
+	if repo == nil {
+		return nil
+	}
+	scanID, err := uuid.Parse(result.ScanID)
+	if err != nil {
+		return fmt.Errorf("invalid scan ID: %w", err)
+	}
+
+	scan := &entity.ScanResult{
+		ID:        scanID,
+		AppID:     appID,
+		AppName:   result.AppName,
+		ScanType:  scanType,
+		CreatedAt: result.ScannedAt,
+	}
+	// Ad-hoc scans belong to the caller who ran them
+	if ownerID, ok := repository.OwnerScope(ctx); ok && appID == nil {
+		scan.OwnerID = &ownerID
+	}
+	if err := applyScanResult(scan, result); err != nil {
+		return err
+	}
+	return repo.Create(ctx, scan)
+}
+
+// appStorageContext keeps the stored documents of app in its owner's key space, whichever request or
+// background job handles it
+func appStorageContext(ctx context.Context, app *entity.App) context.Context {
+	return usecase.WithStorageTenant(ctx, derefString(app.OwnerID))
+}
+
+// callerStorageContext keeps the documents of ad-hoc scans in the authenticated caller's key space
+func callerStorageContext(ctx context.Context) context.Context {
+	ownerID, _ := repository.OwnerScope(ctx)
+	return usecase.WithStorageTenant(ctx, ownerID)
+}
+
+// applyScanResult copies a finished scan's summary counters and full payload onto its stored record
+func applyScanResult(scan *entity.ScanResult, result model.ScanApplicationResult) error {
+	payload, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to marshal scan result: %w", err)
+	}
+
+	scan.Status = result.ScanStatus
+	scan.PolicyStatus = result.Policies.Status
+	scan.TotalDependencies = result.Summary.TotalDependencies
+	scan.TotalVulnerabilities = result.Summary.TotalVulnerabilities
+	scan.Critical = result.Summary.Critical
+	scan.High = result.Summary.High
+	scan.Medium = result.Summary.Medium
+	scan.Low = result.Summary.Low
+	scan.Result = payload
+	if result.Artifacts.SBOMObjectKey != "" {
+		scan.SBOMObjectKey = &result.Artifacts.SBOMObjectKey
+	}
+	if scan.AppID != nil {
+		scan.Findings = findingRecords(*scan.AppID, result)
+	}
+	return nil
+}
+
+// findingRecords flattens a scan's findings into one record per vulnerability, so an application's scan history
+// can be queried by vulnerability
+func findingRecords(appID uuid.UUID, result model.ScanApplicationResult) []entity.Finding {
+	scannedAt := result.ScannedAt
+	if scannedAt.IsZero() {
+		scannedAt = time.Now().UTC()
+	}
+	records := []entity.Finding{}
+	for _, finding := range result.Findings {
+		for _, vulnID := range finding.VulnerabilityIDs {
+			records = append(records, entity.Finding{
+				ID:                 uuid.New(),
+				AppID:              appID,
+				Dependency:         finding.Dependency,
+				Version:            finding.Version,
+				VulnerabilityID:    vulnID,
+				Severity:           finding.Severity,
+				OriginalSeverity:   finding.OriginalSeverity,
+				RiskScore:          finding.RiskScore,
+				RecommendedVersion: finding.RecommendedVersion,
+				Recommendation:     finding.Recommendation,
+				ScannedAt:          scannedAt,
+			})
+		}
+	}
+	return records
+}
+
+// toScanJobStatus converts a stored scan record into its status response
+func toScanJobStatus(scan *entity.ScanResult) *model.ScanJobStatus {
+	status := &model.ScanJobStatus{
+		ScanID:      scan.ID.String(),
+		AppName:     scan.AppName,
+		Status:      scan.Status,
+		Error:       derefString(scan.ErrorMessage),
+		CreatedAt:   scan.CreatedAt,
+		StartedAt:   scan.StartedAt,
+		CompletedAt: scan.CompletedAt,
+	}
+	if scan.AppID != nil {
+		status.AppID = scan.AppID.String()
+	}
+	return status
+}
+
 func (s *DependenciesService) GetSBOMById(ctx context.Context, appName, scanID string) ([]byte, error) {
 	// Input validation
 	if scanID == "" || appName == "" {
@@ -172,6 +1013,7 @@ func (s *DependenciesService) GetSBOMById(ctx context.Context, appName, scanID s
 	}
 
 	// List all SBOMs for the app
+	ctx = callerStorageContext(ctx)
 	sbomKeys, err := s.objectStorageService.ListSBOMs(ctx, appName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list SBOMs: %w", err)
@@ -203,13 +1045,18 @@ func (s *DependenciesService) StartMonitoringApplication(ctx context.Context, ap
 	if err != nil {
 		return err
 	}
+	if app.RuntimeID == nil {
+		return fmt.Errorf("application %s has no runtime; set one before monitoring: %w", app.Name, ErrInvalidInput)
+	}
 	runtime, err := s.runTimeRepository.GetByID(ctx, *app.RuntimeID)
 	if err != nil {
-		return fmt.Errorf("failed to get runtime: %w", err)
+		return lookupError(err, "runtime of application "+app.Name)
 	}
 
 	// Here you would add logic to start monitoring the application,
+	s.monitorJobs.Add(1)
 	go func() {
+		defer s.monitorJobs.Done()
 		jobID := uuid.New()
 		stopChan := make(chan struct{}) // Create a stop channel
 
@@ -219,15 +1066,15 @@ func (s *DependenciesService) StartMonitoringApplication(ctx context.Context, ap
 				ID:        jobID,
 				AppIDs:    []uuid.UUID{app.ID},
 				Status:    "running",
-				CreatedAt: time.Now(),
+				CreatedAt: time.Now().UTC(),
 				CreatedBy: "system",
 			},
 			Progress: &JobProgress{
 				CompletedChecks:    0,
 				FailedChecks:       0,
 				SecurityDetections: 0,
-				StartTime:          time.Now(),
-				LastUpdate:         time.Now(),
+				StartTime:          time.Now().UTC(),
+				LastUpdate:         time.Now().UTC(),
 				CurrentOperation:   "initializing",
 			},
 			StopChan: stopChan,
@@ -258,14 +1105,17 @@ func (s *DependenciesService) StartMonitoringApplication(ctx context.Context, ap
 			case <-stopChan:
 				slog.Info("Monitoring job stopped", "job_id", jobID.String(), "app_id", app.ID.String())
 				return
+			case <-s.shutdownChan:
+				slog.Info("Monitoring job stopped for shutdown", "job_id", jobID.String(), "app_id", app.ID.String())
+				return
 			case <-ticker.C:
 				slog.Info("Monitoring application dependencies", "app_id", appID, "app_name", app.Name)
 
 				jobContext.Progress.CurrentOperation = "scanning"
-				jobContext.Progress.LastUpdate = time.Now()
+				jobContext.Progress.LastUpdate = time.Now().UTC()
 				jobContext.Progress.FailedChecks = 0
 
-				context := context.Background()
+				context := s.rootCtx
 
 				// Here you would implement the actual monitoring logic,
 				appDeps, err := s.appDepedencyRepo.GetByAppID(context, app.ID)
@@ -283,31 +1133,40 @@ func (s *DependenciesService) StartMonitoringApplication(ctx context.Context, ap
 				var depedenciesInfoList []parser.DependencyInfo
 				for _, dep := range appDeps {
 					depedenciesData, err := s.depedencyRepository.GetByID(context, dep.DependencyID)
-					if err != nil {
-						slog.Error("Failed to get dependency", "error", err)
+					if errors.Is(err, repository.ErrNotFound) {
+						slog.Error("Dependency not found", "dependency_id", dep.DependencyID.String())
 						jobContext.Progress.FailedChecks++
 						continue
 					}
-					if depedenciesData == nil {
-						slog.Error("Dependency not found", "dependency_id", dep.DependencyID.String())
+					if err != nil {
+						slog.Error("Failed to get dependency", "error", err)
 						jobContext.Progress.FailedChecks++
 						continue
 					}
 					depedenciesInfoList = append(depedenciesInfoList, parser.DependencyInfo{
-						Name:    depedenciesData.Name,
-						Version: dep.UsedVersion,
-						Runtime: runtime.Name,
+						Name:      depedenciesData.Name,
+						Version:   dep.UsedVersion,
+						Runtime:   runtime.Name,
+						Ecosystem: derefString(dep.Ecosystem),
+						Commit:    derefString(dep.UsedCommitSHA),
 					})
 				}
 
+				context, err = withSeverityOverrides(context, s.severityOverrides, derefString(app.OwnerID), &app.ID)
+				if err != nil {
+					slog.Error("Failed to get severity overrides", "app_id", appID, "error", err)
+					jobContext.Progress.FailedChecks++
+					continue
+				}
+
 				// Perform scanning with controlled concurrency
 				findings, depsWithVulns, totalCritical, totalHigh, totalMedium, totalLow := s.sharedScanner.ScanDependenciesWithControl(context, depedenciesInfoList)
 				jobContext.Progress.CompletedChecks = len(findings)
 
 				// Aggregate summary and evaluate policies
 				summary := helper.AggregateVulnerabilitySummary(findings)
-				failOn := []string{"high", "critical"}
-				policyStatus, policyReason := helper.EvaluatePolicy(summary, failOn)
+				failOn := []string{helper.SeverityHigh.String(), helper.SeverityCritical.String()}
+				policyStatus, policyReason := helper.EvaluatePolicy(summary, failOn, s.policy)
 
 				// Generate a unique scan ID for this monitoring scan
 				scanID := uuid.New().String()
@@ -321,9 +1180,10 @@ func (s *DependenciesService) StartMonitoringApplication(ctx context.Context, ap
 					AppName:    app.Name,
 					ScanStatus: "completed",
 					Summary:    summary,
-					Policies:   model.ScanPolicy{FailOn: failOn, Status: policyStatus, Reason: policyReason},
+					Policies:   model.ScanPolicy{FailOn: failOn, FailClosed: s.policy.FailClosed, Status: policyStatus, Reason: policyReason},
 					Artifacts:  artifacts,
 					Findings:   findings,
+					Unchecked:  helper.UncheckedDependencies(findings),
 				}
 				_ = result // You can store or process the result as needed
 
@@ -331,6 +1191,7 @@ func (s *DependenciesService) StartMonitoringApplication(ctx context.Context, ap
 				enhancedSBOMData := helper.EnhancedSBOMData{
 					AppID:         scanID,
 					AppName:       app.Name,
+					Tool:          s.sbomTool,
 					Runtime:       runtime.Name,
 					Dependencies:  depsWithVulns,
 					ScanTimestamp: time.Now().UTC(),
@@ -352,7 +1213,7 @@ func (s *DependenciesService) StartMonitoringApplication(ctx context.Context, ap
 						"total_vulnerabilities", len(findings))
 				}
 				if s.objectStorageService != nil {
-					sbomKey, err := s.objectStorageService.SaveSBOM(context, scanID, app.Name, sbomBytes, "json")
+					sbomKey, err := s.objectStorageService.SaveSBOM(appStorageContext(context, app), scanID, app.Name, sbomBytes, "json")
 					if err != nil {
 						slog.Error("Failed to save SBOM to object storage", "error", err)
 					} else {
@@ -373,7 +1234,7 @@ func (s *DependenciesService) StartMonitoringApplication(ctx context.Context, ap
 					"medium", totalMedium,
 					"low", totalLow,
 				)
-				jobContext.Progress.LastUpdate = time.Now()
+				jobContext.Progress.LastUpdate = time.Now().UTC()
 				jobContext.Progress.CurrentOperation = "idle"
 			}
 		}
@@ -440,15 +1301,29 @@ func (s *DependenciesService) GetMonitoringStatus(ctx context.Context, appID str
 	return status, nil
 }
 
-func isRuntimeSupported(runtime string) bool {
-	runtime = strings.ToLower(runtime)
-	supportedRuntimes := []string{"node.js", "python", "java", "go", "ruby", "php", "dotnet", "gradle"}
-	for _, r := range supportedRuntimes {
-		if r == runtime {
-			return true
-		}
+// Shutdown stops every monitoring loop, cancels in-flight monitoring scans and waits for them to exit
+func (s *DependenciesService) Shutdown(ctx context.Context) error {
+	s.shutdownOnce.Do(func() {
+		close(s.shutdownChan)
+		s.cancelRoot()
+	})
+	return waitWithContext(ctx, &s.monitorJobs)
+}
+
+// waitWithContext waits for the group to finish or returns the context error when it expires first
+func waitWithContext(ctx context.Context, wg *sync.WaitGroup) error {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("timed out waiting for background jobs: %w", ctx.Err())
 	}
-	return false
 }
 
 func (s *DependenciesService) getAppByID(ctx context.Context, appID string) (*entity.App, error) {
@@ -460,10 +1335,7 @@ func (s *DependenciesService) getAppByID(ctx context.Context, appID string) (*en
 
 	app, err := s.appRepository.GetByID(ctx, appUID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get application: %w", err)
-	}
-	if app == nil {
-		return nil, fmt.Errorf("application not found")
+		return nil, lookupError(err, "application "+appID)
 	}
 	return app, nil
 }
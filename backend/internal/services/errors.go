@@ -0,0 +1,38 @@
+package services
+
+import (
+	"elang-backend/internal/repository"
+	"errors"
+	"fmt"
+)
+
+// Typed errors returned by services so the delivery layer can map them to HTTP status codes.
+// Wrap them with fmt.Errorf("...: %w", ErrNotFound) to keep the context in the message.
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrInvalidInput = errors.New("invalid input")
+	ErrConflict     = errors.New("conflict")
+	// ErrTooManyDependencies rejects manifests with more dependencies than ServiceConfig.MaxDependenciesPerApp allows
+	ErrTooManyDependencies = errors.New("too many dependencies")
+)
+
+// lookupError translates an error from a repository GetByID: a missing record becomes ErrNotFound,
+// anything else is reported as a failure to fetch, so database errors are not mistaken for a 404
+func lookupError(err error, subject string) error {
+	if errors.Is(err, repository.ErrNotFound) {
+		return fmt.Errorf("%s: %w", subject, ErrNotFound)
+	}
+	return fmt.Errorf("failed to fetch %s: %w", subject, err)
+}
+
+// DefaultMaxDependenciesPerApp bounds how many dependencies one manifest may declare
+const DefaultMaxDependenciesPerApp = 5000
+
+// checkDependencyLimit rejects a parsed manifest declaring more than limit dependencies before any
+// per-dependency work (goroutines, database rows, OSV queries) is started for it
+func checkDependencyLimit(fileName string, count, limit int) error {
+	if count > limit {
+		return fmt.Errorf("%s declares %d dependencies, more than the limit of %d: %w", fileName, count, limit, ErrTooManyDependencies)
+	}
+	return nil
+}
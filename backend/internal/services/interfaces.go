@@ -3,12 +3,17 @@ package services
 import (
 	"context"
 	"elang-backend/internal/entity"
+	"elang-backend/internal/helper"
 	"elang-backend/internal/model"
 )
 
 type ApplicationInterface interface {
-	// Add or intialize Application -> input app name , depedency file , runtime type , description
-	AddApplication(ctx context.Context, appName, runtimeType, framework, description, fileName, content string) (*model.AddApplicationResponse, error)
+	// Add or intialize Application -> input app name , depedency file , runtime type , description. autoScan says
+	// whether it is scanned once its dependencies are processed; nil means ServiceConfig.AutoScanOnAdd
+	AddApplication(ctx context.Context, appName, runtimeType, framework, description, fileName, content string, autoScan *bool) (*model.AddApplicationResponse, error)
+
+	// Update Application metadata (name, description, runtime, framework)
+	UpdateApplication(ctx context.Context, appUID string, req *model.UpdateApplicationRequest) (*model.UpdateApplicationResponse, error)
 
 	// Add depedency to Application (batch)
 	AddApplicationDependency(ctx context.Context, appUID string, deps []model.DependencyInfoRequest) (interface{}, error)
@@ -28,20 +33,68 @@ type ApplicationInterface interface {
 	// Recover Application or Reactivate Application
 	RecoverApplication(ctx context.Context, appUID string) error
 
-	// List Applications
-	ListApplications(ctx context.Context) (*model.ListApplicationsResponse, error)
+	// List Applications, only those carrying every given tag when tags is not empty
+	ListApplications(ctx context.Context, tags []string) (*model.ListApplicationsResponse, error)
+
+	// Create a new Application with the runtime, framework and dependencies of an existing one
+	CloneApplication(ctx context.Context, sourceAppUID, newName string) (*model.CloneApplicationResponse, error)
+
+	// Attach tags to an Application, creating tags that do not exist yet
+	AddApplicationTags(ctx context.Context, appUID string, tags []string) (*model.ApplicationTagsResponse, error)
+
+	// Detach tags from an Application
+	RemoveApplicationTags(ctx context.Context, appUID string, tags []string) (*model.ApplicationTagsResponse, error)
+
+	// List the frameworks that can be chosen for a runtime, given by ID or name
+	GetFrameworksByRuntime(ctx context.Context, runtime string) (*model.ListRuntimeFrameworksResponse, error)
+
+	// Re-fetch a dependency's GitHub metadata without changing the applications using it
+	RefreshDependencyMetadata(ctx context.Context, depUID string) (*model.DependencyMetadataResponse, error)
+
+	// List the commits and release notes between two tagged versions of a dependency
+	GetDependencyChangelog(ctx context.Context, depUID, fromVersion, toVersion string) (*model.DependencyChangelogResponse, error)
+	// Judge from the stored repository signals of a dependency how likely it is to be abandoned
+	GetDependencyMaintenance(ctx context.Context, depUID string) (*model.DependencyMaintenanceResponse, error)
+
+	// Move every application using owner/repo at fromVersion to toVersion
+	BulkUpdateDependencyVersion(ctx context.Context, owner, repo, fromVersion, toVersion string) (*model.BulkBumpResponse, error)
+
+	// Compare the dependencies of two Applications and the risk of their latest scans
+	CompareApplications(ctx context.Context, appA, appB string) (*model.ApplicationComparison, error)
+
+	// List the audit trail of an Application, newest first
+	ListApplicationAudit(ctx context.Context, appUID string, limit, offset int) (*model.ApplicationAuditResponse, error)
 
 	// // Get Monitoring Status of Application
 	GetApplicationStatus(ctx context.Context, appUID string) (map[string]interface{}, error)
 
+	// Scan Application dependencies synchronously and return the result
 	ScanApplicationDependencies(ctx context.Context, appUID string) (interface{}, error)
 
+	// Queue a scan of Application dependencies and run it in the background
+	StartApplicationScan(ctx context.Context, appUID string) (*model.ScanJobStatus, error)
+
 	// Get SBOM for an application
 	GetApplicationSBOM(ctx context.Context, appUID string) ([]byte, error)
 
+	// Build an SBOM of the application's current dependencies and their last known vulnerabilities
+	GenerateApplicationSBOM(ctx context.Context, appUID string) ([]byte, error)
+
+	// Group the application's dependencies into direct and transitive ones with their last known vulnerabilities
+	GetDependencyTree(ctx context.Context, appUID string) (*model.DependencyTree, error)
+
 	// List all SBOMs for an application
 	ListApplicationSBOMs(ctx context.Context, appUID string) ([]string, error)
 
+	// Get the dependency manifest an application was added from
+	GetApplicationManifest(ctx context.Context, appUID string) (*model.ApplicationManifest, error)
+
+	// Run the current parser over an application's stored manifest and link what it now yields
+	ReparseApplication(ctx context.Context, appUID string) (*model.ReparseApplicationResponse, error)
+
+	// Cancel background processing and scans and wait for them to finish
+	Shutdown(ctx context.Context) error
+
 	// // Get Monitoring Status of All Applications
 	// GetAllApplicationsStatus(ctx context.Context) (map[string]interface{}, error)
 }
@@ -50,9 +103,42 @@ type DependenciesInterface interface {
 	// Scan Application for vulnerabilities by checking dependency versions in OSV
 	ScanDependencies(ctx context.Context, appName, runtime, version, description, fileName, content string) (interface{}, error)
 
+	// Scan several manifests of one application as one, reporting dependencies they pin to different versions
+	ScanMultipleDependencies(ctx context.Context, appName, runtime, version, description string, files []helper.ManifestFile) (interface{}, error)
+
+	// List every severity, most severe first, with its configured label and color
+	SeverityStyles() []helper.SeverityStyle
+
+	// Check a single dependency version for vulnerabilities without creating an application
+	CheckDependency(ctx context.Context, req *model.CheckDependencyRequest) (*helper.DependencyVulnerabilityResult, error)
+
+	// Compare two manifests of the same runtime and report the dependency changes and the vulnerabilities they introduce
+	DiffManifests(ctx context.Context, runtime, oldFileName, oldContent, newFileName, newContent string) (*model.ManifestDiffResult, error)
+
 	// Get SBOM by its ID
 	GetSBOMById(ctx context.Context, appName, sbomID string) ([]byte, error)
 
+	// Get the full stored result of a scan by its ID
+	GetScanResult(ctx context.Context, scanID string) (*model.ScanApplicationResult, error)
+
+	// Get the status of a scan (queued, running, completed, failed)
+	GetScanStatus(ctx context.Context, scanID string) (*model.ScanJobStatus, error)
+
+	// Evaluate a proposed policy against a stored scan without persisting it
+	EvaluateScanPolicy(ctx context.Context, scanID string, req *model.EvaluatePolicyRequest) (*model.PolicyEvaluation, error)
+
+	// Search the dependency catalog by name, one page at a time, with the number of applications using each dependency
+	SearchDependencies(ctx context.Context, search string, limit, offset int) (*model.DependencyCatalogResponse, error)
+
+	// Query the findings of an application's stored scans, one page at a time
+	ListFindings(ctx context.Context, appUID string, query model.FindingsQuery) (*model.FindingsResponse, error)
+
+	// Pass the findings of an application's latest completed scan to write one at a time
+	StreamLatestFindings(ctx context.Context, appUID string, write func(model.ScanFinding) error) error
+
+	// Aggregate the latest scan of every application into organization-wide totals
+	GetDashboardSummary(ctx context.Context, topN int) (*model.DashboardSummary, error)
+
 	// Start monitoring an application
 	StartMonitoringApplication(ctx context.Context, appUID string) error
 
@@ -61,6 +147,9 @@ type DependenciesInterface interface {
 
 	// Get monitoring status of an application
 	GetMonitoringStatus(ctx context.Context, appUID string) (map[string]interface{}, error)
+
+	// Stop all monitoring loops and wait for in-flight scans to finish
+	Shutdown(ctx context.Context) error
 }
 
 type DepedencyMonitoringInterface interface {
@@ -73,3 +162,73 @@ type DepedencyMonitoringInterface interface {
 	// GetMonitoringStatus retrieves the monitoring status of an application
 	GetMonitoringStatus(ctx context.Context, app *entity.App) (map[string]interface{}, error)
 }
+
+type SeedInterface interface {
+	// Create the seed runtimes and frameworks that do not exist yet
+	Seed(ctx context.Context) (*model.SeedResult, error)
+
+	// List the runtimes and frameworks in the database and the seed entries missing from it
+	SeedStatus(ctx context.Context) (*model.SeedStatusResponse, error)
+}
+
+type ScheduleInterface interface {
+	// Start the loop that runs the due scan schedules in the background
+	Start()
+
+	// Schedule scans of an application with a cron expression
+	CreateSchedule(ctx context.Context, appUID string, req model.CreateScanScheduleRequest) (*model.ScanScheduleResponse, error)
+
+	// List the scan schedules of an application
+	ListSchedules(ctx context.Context, appUID string) (*model.ListScanSchedulesResponse, error)
+
+	// Change the cron expression of a scan schedule or enable/disable it
+	UpdateSchedule(ctx context.Context, appUID, scheduleUID string, req model.UpdateScanScheduleRequest) (*model.ScanScheduleResponse, error)
+
+	// Remove a scan schedule
+	DeleteSchedule(ctx context.Context, appUID, scheduleUID string) error
+
+	// Scan the applications whose schedules are due once
+	RunDueSchedules(ctx context.Context) (int, error)
+
+	// Stop the scheduler loop and wait for running scheduled scans to finish
+	Shutdown(ctx context.Context) error
+}
+
+type RemediationInterface interface {
+	// Start tracking the fix of a vulnerability of one of an application's dependencies
+	CreateRemediation(ctx context.Context, appUID string, req model.CreateRemediationRequest) (*model.RemediationResponse, error)
+
+	// List the remediations of an application, optionally only those with a status
+	ListRemediations(ctx context.Context, appUID, status string) (*model.ListRemediationsResponse, error)
+
+	// Change the status, external ticket or notes of a remediation
+	UpdateRemediation(ctx context.Context, appUID, remediationUID string, req model.UpdateRemediationRequest) (*model.RemediationResponse, error)
+
+	// Stop tracking a remediation
+	DeleteRemediation(ctx context.Context, appUID, remediationUID string) error
+}
+
+type SeverityOverrideInterface interface {
+	// Store a custom severity for the vulnerabilities matching a CWE, CVE pattern or ecosystem
+	CreateSeverityOverride(ctx context.Context, req model.CreateSeverityOverrideRequest) (*model.SeverityOverrideResponse, error)
+
+	// List the overrides applying to every application, preceded by those of one application when given
+	ListSeverityOverrides(ctx context.Context, appUID string) (*model.ListSeverityOverridesResponse, error)
+
+	// Remove a severity override
+	DeleteSeverityOverride(ctx context.Context, overrideUID string) error
+}
+
+type RetentionInterface interface {
+	// Start the periodic cleanup loop in the background
+	Start()
+
+	// Prune expired scan results, their stored artifacts and old audit entries once
+	RunCleanup(ctx context.Context) (*model.RetentionCleanupResult, error)
+
+	// Move the documents saved before object keys were prefixed to where they are looked up now
+	MigrateLegacyObjectKeys(ctx context.Context) (int, error)
+
+	// Stop the cleanup loop and wait for a running cleanup to finish
+	Shutdown(ctx context.Context) error
+}
@@ -0,0 +1,334 @@
+package services
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+	"elang-backend/internal/model"
+	"elang-backend/internal/model/dto"
+	"elang-backend/internal/repository"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RemediationService struct {
+	appRepository         repository.ApplicationRepository
+	remediationRepository repository.RemediationRepository
+	scanResultRepository  repository.ScanResultRepository
+	now                   func() time.Time
+}
+
+// NewRemediationService tracks the remediation of the findings of applications
+func NewRemediationService(basicRepo dto.BasicRepositories) RemediationInterface {
+	return &RemediationService{
+		appRepository:         basicRepo.AppRepository,
+		remediationRepository: basicRepo.RemediationRepository,
+		scanResultRepository:  basicRepo.ScanResultRepository,
+		now:                   time.Now,
+	}
+}
+
+// CreateRemediation starts tracking the fix of a vulnerability of one of the application's dependencies, which
+// a scan of the application must have reported. Each vulnerability of a dependency has at most one remediation
+// that is not resolved yet.
+func (s *RemediationService) CreateRemediation(ctx context.Context, appUID string, req model.CreateRemediationRequest) (*model.RemediationResponse, error) {
+	app, err := s.remediationApp(ctx, appUID)
+	if err != nil {
+		return nil, err
+	}
+	dependency, vulnID := strings.TrimSpace(req.Dependency), strings.TrimSpace(req.VulnerabilityID)
+	if dependency == "" || vulnID == "" {
+		return nil, fmt.Errorf("dependency and vulnerability_id are required: %w", ErrInvalidInput)
+	}
+	status := entity.RemediationOpen
+	if req.Status != "" {
+		if status, err = parseRemediationStatus(req.Status); err != nil {
+			return nil, err
+		}
+	}
+	ticketURL, err := parseTicketURL(req.ExternalTicketURL)
+	if err != nil {
+		return nil, err
+	}
+	if dependency, vulnID, err = s.reportedFinding(ctx, app.ID, dependency, vulnID); err != nil {
+		return nil, err
+	}
+
+	unresolved, err := s.remediationRepository.GetByAppID(ctx, app.ID, entity.RemediationOpen, entity.RemediationInProgress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list remediations: %w", err)
+	}
+	for _, existing := range unresolved {
+		if remediationKey(existing.Dependency, existing.VulnerabilityID) == remediationKey(dependency, vulnID) {
+			return nil, fmt.Errorf("%s in %s is already tracked by remediation %s: %w", vulnID, dependency, existing.ID, ErrConflict)
+		}
+	}
+
+	remediation := &entity.Remediation{
+		ID:                uuid.New(),
+		AppID:             app.ID,
+		Dependency:        dependency,
+		VulnerabilityID:   vulnID,
+		ExternalTicketURL: ticketURL,
+		Notes:             req.Notes,
+		CreatedBy:         "api",
+	}
+	if ownerID, ok := repository.OwnerScope(ctx); ok {
+		remediation.CreatedBy = ownerID
+	}
+	s.setStatus(remediation, status)
+	if err := s.remediationRepository.Create(ctx, remediation); err != nil {
+		return nil, fmt.Errorf("failed to create remediation: %w", err)
+	}
+	return toRemediationResponse(remediation), nil
+}
+
+// ListRemediations lists the remediations of an application, only those in status when it is given
+func (s *RemediationService) ListRemediations(ctx context.Context, appUID, status string) (*model.ListRemediationsResponse, error) {
+	app, err := s.remediationApp(ctx, appUID)
+	if err != nil {
+		return nil, err
+	}
+	var statuses []string
+	if status != "" {
+		parsed, err := parseRemediationStatus(status)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, parsed)
+	}
+	remediations, err := s.remediationRepository.GetByAppID(ctx, app.ID, statuses...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list remediations: %w", err)
+	}
+	resp := &model.ListRemediationsResponse{Remediations: make([]model.RemediationResponse, 0, len(remediations))}
+	for _, remediation := range remediations {
+		resp.Remediations = append(resp.Remediations, *toRemediationResponse(remediation))
+	}
+	return resp, nil
+}
+
+// UpdateRemediation changes the status, ticket or notes of a remediation. Reopening a resolved remediation
+// clears when and by which scan it was resolved.
+func (s *RemediationService) UpdateRemediation(ctx context.Context, appUID, remediationUID string, req model.UpdateRemediationRequest) (*model.RemediationResponse, error) {
+	remediation, err := s.getRemediation(ctx, appUID, remediationUID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Status != nil {
+		status, err := parseRemediationStatus(*req.Status)
+		if err != nil {
+			return nil, err
+		}
+		s.setStatus(remediation, status)
+	}
+	if req.ExternalTicketURL != nil {
+		if remediation.ExternalTicketURL, err = parseTicketURL(*req.ExternalTicketURL); err != nil {
+			return nil, err
+		}
+	}
+	if req.Notes != nil {
+		remediation.Notes = *req.Notes
+	}
+	if err := s.remediationRepository.Update(ctx, remediation); err != nil {
+		return nil, fmt.Errorf("failed to update remediation: %w", err)
+	}
+	return toRemediationResponse(remediation), nil
+}
+
+// DeleteRemediation stops tracking a remediation
+func (s *RemediationService) DeleteRemediation(ctx context.Context, appUID, remediationUID string) error {
+	remediation, err := s.getRemediation(ctx, appUID, remediationUID)
+	if err != nil {
+		return err
+	}
+	if err := s.remediationRepository.Delete(ctx, remediation.ID); err != nil {
+		return fmt.Errorf("failed to delete remediation: %w", err)
+	}
+	return nil
+}
+
+// setStatus moves a remediation to status, recording when it was resolved by hand
+func (s *RemediationService) setStatus(remediation *entity.Remediation, status string) {
+	if status == remediation.Status {
+		return
+	}
+	remediation.Status = status
+	remediation.ResolvedAt, remediation.ResolvedByScanID = nil, nil
+	if status == entity.RemediationResolved {
+		resolvedAt := s.now().UTC()
+		remediation.ResolvedAt = &resolvedAt
+	}
+}
+
+// reportedFinding returns the dependency and vulnerability ID, spelled as stored, of a finding scans of the
+// application reported
+func (s *RemediationService) reportedFinding(ctx context.Context, appID uuid.UUID, dependency, vulnID string) (string, string, error) {
+	findings, _, err := s.scanResultRepository.QueryFindings(ctx, repository.FindingFilter{AppID: appID, VulnerabilityID: vulnID})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to fetch findings: %w", err)
+	}
+	for _, finding := range findings {
+		if strings.EqualFold(finding.Dependency, dependency) {
+			return finding.Dependency, finding.VulnerabilityID, nil
+		}
+	}
+	return "", "", fmt.Errorf("no scan of the application reported %s in %s: %w", vulnID, dependency, ErrInvalidInput)
+}
+
+// remediationApp returns the application a remediation request refers to, restricted to the caller's own
+func (s *RemediationService) remediationApp(ctx context.Context, appUID string) (*entity.App, error) {
+	appID, err := uuid.Parse(appUID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid app ID: %w", ErrInvalidInput)
+	}
+	app, err := s.appRepository.GetByID(ctx, appID)
+	if err != nil {
+		return nil, lookupError(err, "application "+appUID)
+	}
+	return app, nil
+}
+
+// getRemediation returns a remediation of the application; remediations of other applications are not found
+func (s *RemediationService) getRemediation(ctx context.Context, appUID, remediationUID string) (*entity.Remediation, error) {
+	app, err := s.remediationApp(ctx, appUID)
+	if err != nil {
+		return nil, err
+	}
+	remediationID, err := uuid.Parse(remediationUID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid remediation ID: %w", ErrInvalidInput)
+	}
+	remediation, err := s.remediationRepository.GetByID(ctx, remediationID)
+	if err != nil {
+		return nil, lookupError(err, "remediation "+remediationUID)
+	}
+	if remediation.AppID != app.ID {
+		return nil, fmt.Errorf("remediation %s: %w", remediationUID, ErrNotFound)
+	}
+	return remediation, nil
+}
+
+// parseRemediationStatus validates a remediation status; "in-progress" is accepted for in_progress
+func parseRemediationStatus(status string) (string, error) {
+	normalized := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(status)), "-", "_")
+	switch normalized {
+	case entity.RemediationOpen, entity.RemediationInProgress, entity.RemediationResolved:
+		return normalized, nil
+	}
+	return "", fmt.Errorf("unknown remediation status %q (expected open, in_progress or resolved): %w", status, ErrInvalidInput)
+}
+
+// parseTicketURL validates the link to an external ticket, which may be empty
+func parseTicketURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", nil
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return "", fmt.Errorf("external_ticket_url must be an http(s) URL: %w", ErrInvalidInput)
+	}
+	return raw, nil
+}
+
+// remediationKey identifies the vulnerability of a dependency a remediation tracks, ignoring case
+func remediationKey(dependency, vulnID string) string {
+	return strings.ToLower(dependency) + "\x00" + strings.ToLower(vulnID)
+}
+
+// findingRemediations returns the remediation to show next to each finding of an application, keyed by
+// remediationKey. The newest remediation of a vulnerability wins.
+func findingRemediations(ctx context.Context, repo repository.RemediationRepository, appID uuid.UUID) (map[string]*model.FindingRemediation, error) {
+	if repo == nil {
+		return nil, nil
+	}
+	remediations, err := repo.GetByAppID(ctx, appID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch remediations: %w", err)
+	}
+	byFinding := make(map[string]*model.FindingRemediation, len(remediations))
+	for _, remediation := range remediations {
+		byFinding[remediationKey(remediation.Dependency, remediation.VulnerabilityID)] = &model.FindingRemediation{
+			ID:                remediation.ID.String(),
+			Status:            remediation.Status,
+			ExternalTicketURL: remediation.ExternalTicketURL,
+		}
+	}
+	return byFinding, nil
+}
+
+// resolveFixedRemediations resolves the open and in-progress remediations of an application whose vulnerability
+// a completed scan no longer reports for the dependency. Only dependencies the scan checked without error count:
+// remediations of dependencies it skipped, failed or gave up on stay as they are, since their vulnerabilities
+// are unknown.
+func resolveFixedRemediations(ctx context.Context, repo repository.RemediationRepository, appID uuid.UUID, result model.ScanApplicationResult) {
+	if repo == nil {
+		return
+	}
+	unresolved, err := repo.GetByAppID(ctx, appID, entity.RemediationOpen, entity.RemediationInProgress)
+	if err != nil {
+		slog.Error("Failed to fetch remediations to resolve", "app_id", appID, "scan_id", result.ScanID, "error", err)
+		return
+	}
+	if len(unresolved) == 0 {
+		return
+	}
+
+	reported := make(map[string]bool)
+	// A dependency declared more than once counts as checked only when every occurrence was
+	checked := make(map[string]bool)
+	for _, finding := range result.Findings {
+		name := strings.ToLower(finding.Dependency)
+		if seen, ok := checked[name]; !ok || seen {
+			checked[name] = finding.Error == ""
+		}
+		for _, vulnID := range finding.VulnerabilityIDs {
+			reported[remediationKey(finding.Dependency, vulnID)] = true
+		}
+	}
+	resolvedAt := result.ScannedAt.UTC()
+	if resolvedAt.IsZero() {
+		resolvedAt = time.Now().UTC()
+	}
+	scanID, err := uuid.Parse(result.ScanID)
+	for _, remediation := range unresolved {
+		if reported[remediationKey(remediation.Dependency, remediation.VulnerabilityID)] || !checked[strings.ToLower(remediation.Dependency)] {
+			continue
+		}
+		remediation.Status = entity.RemediationResolved
+		remediation.ResolvedAt = &resolvedAt
+		if err == nil {
+			remediation.ResolvedByScanID = &scanID
+		}
+		if err := repo.Update(ctx, remediation); err != nil {
+			slog.Error("Failed to resolve remediation", "remediation_id", remediation.ID, "scan_id", result.ScanID, "error", err)
+			continue
+		}
+		slog.Info("Resolved remediation no longer reported by scan", "remediation_id", remediation.ID, "app_id", appID,
+			"dependency", remediation.Dependency, "vulnerability_id", remediation.VulnerabilityID, "scan_id", result.ScanID)
+	}
+}
+
+func toRemediationResponse(remediation *entity.Remediation) *model.RemediationResponse {
+	resp := &model.RemediationResponse{
+		ID:                remediation.ID.String(),
+		AppID:             remediation.AppID.String(),
+		Dependency:        remediation.Dependency,
+		VulnerabilityID:   remediation.VulnerabilityID,
+		Status:            remediation.Status,
+		ExternalTicketURL: remediation.ExternalTicketURL,
+		Notes:             remediation.Notes,
+		ResolvedAt:        remediation.ResolvedAt,
+		CreatedAt:         remediation.CreatedAt,
+		UpdatedAt:         remediation.UpdatedAt,
+	}
+	if remediation.ResolvedByScanID != nil {
+		resp.ResolvedByScanID = remediation.ResolvedByScanID.String()
+	}
+	return resp
+}
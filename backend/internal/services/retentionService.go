@@ -0,0 +1,205 @@
+package services
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+	"elang-backend/internal/model"
+	"elang-backend/internal/model/dto"
+	"elang-backend/internal/repository"
+	"elang-backend/internal/usecase"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RetentionConfig controls how long scan results and audit entries are kept
+type RetentionConfig struct {
+	ScanRetention   time.Duration // Finished scans older than this are pruned; zero disables scan cleanup
+	KeepScansPerApp int           // Newest scans per application kept regardless of age
+	AuditRetention  time.Duration // Non security-relevant audit entries older than this are pruned; zero disables
+	Interval        time.Duration // Time between cleanup runs
+}
+
+type RetentionService struct {
+	config               RetentionConfig
+	scanResultRepository repository.ScanResultRepository
+	auditTrailRepository repository.AuditTrailRepository
+	appRepository        repository.ApplicationRepository
+	objectStorageService usecase.ObjectStorageInterface
+
+	// The cleanup loop runs under rootCtx so Shutdown can stop it
+	rootCtx    context.Context
+	cancelRoot context.CancelFunc
+	startOnce  sync.Once
+	loop       sync.WaitGroup
+}
+
+func NewRetentionService(basicRepo dto.BasicRepositories,
+	objectStorageService usecase.ObjectStorageInterface,
+	config RetentionConfig,
+) RetentionInterface {
+	rootCtx, cancelRoot := context.WithCancel(context.Background())
+	return &RetentionService{
+		config:               config,
+		scanResultRepository: basicRepo.ScanResultRepository,
+		auditTrailRepository: basicRepo.AuditTrailRepository,
+		appRepository:        basicRepo.AppRepository,
+		objectStorageService: objectStorageService,
+		rootCtx:              rootCtx,
+		cancelRoot:           cancelRoot,
+	}
+}
+
+// Start launches the periodic cleanup loop. The first run happens after one interval.
+func (s *RetentionService) Start() {
+	if s.config.Interval <= 0 {
+		slog.Warn("Retention cleanup interval not set, periodic cleanup disabled")
+		return
+	}
+	s.startOnce.Do(func() {
+		s.loop.Add(1)
+		go func() {
+			defer s.loop.Done()
+			ticker := time.NewTicker(s.config.Interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-s.rootCtx.Done():
+					return
+				case <-ticker.C:
+					if _, err := s.RunCleanup(s.rootCtx); err != nil {
+						slog.Error("Retention cleanup failed", "error", err)
+					}
+				}
+			}
+		}()
+	})
+}
+
+// RunCleanup prunes expired scan results with their SBOM objects and old audit entries
+func (s *RetentionService) RunCleanup(ctx context.Context) (*model.RetentionCleanupResult, error) {
+	now := time.Now().UTC()
+	result := &model.RetentionCleanupResult{RanAt: now}
+
+	if s.config.ScanRetention > 0 && s.scanResultRepository != nil {
+		deleted, err := s.scanResultRepository.CleanupOldRecords(ctx, now.Add(-s.config.ScanRetention), s.config.KeepScansPerApp)
+		if err != nil {
+			return result, fmt.Errorf("failed to clean up scan results: %w", err)
+		}
+		result.ScansDeleted = len(deleted)
+
+		for _, scan := range deleted {
+			if scan.SBOMObjectKey == nil || *scan.SBOMObjectKey == "" || s.objectStorageService == nil {
+				continue
+			}
+			// A missing object must not block the cleanup; the row is already gone
+			if err := s.objectStorageService.DeleteSBOM(ctx, *scan.SBOMObjectKey); err != nil {
+				slog.Warn("Failed to delete SBOM of expired scan", "scan_id", scan.ID, "key", *scan.SBOMObjectKey, "error", err)
+				result.ArtifactErrors++
+				continue
+			}
+			result.ArtifactsDeleted++
+		}
+	}
+
+	if s.config.AuditRetention > 0 && s.auditTrailRepository != nil {
+		if err := s.auditTrailRepository.CleanupOldRecords(ctx, now.Add(-s.config.AuditRetention)); err != nil {
+			return result, fmt.Errorf("failed to clean up audit trail: %w", err)
+		}
+		result.AuditCleaned = true
+	}
+
+	slog.Info("Retention cleanup completed",
+		"scans_deleted", result.ScansDeleted,
+		"artifacts_deleted", result.ArtifactsDeleted,
+		"artifact_errors", result.ArtifactErrors,
+		"audit_cleaned", result.AuditCleaned)
+	return result, nil
+}
+
+// MigrateLegacyObjectKeys moves the SBOMs and vulnerability reports saved before object keys were prefixed
+// into the key space they are looked up in now: the key prefix, and for an application's scans its owner's
+// tenant. Scans recording a moved SBOM are pointed at its new key. Documents of ad-hoc scans and of scans no
+// longer stored name no owner and are moved under the key prefix only. It returns how many were moved.
+func (s *RetentionService) MigrateLegacyObjectKeys(ctx context.Context) (int, error) {
+	storage, ok := s.objectStorageService.(usecase.LegacyObjectStorage)
+	if !ok {
+		return 0, nil
+	}
+	objectKeys, err := storage.ListLegacyObjects(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	moved := 0
+	owners := map[uuid.UUID]string{}
+	for _, objectKey := range objectKeys {
+		scan, tenantID, err := s.legacyObjectOwner(ctx, objectKey, owners)
+		if err != nil {
+			return moved, err
+		}
+		newKey, err := storage.MoveLegacyObject(usecase.WithStorageTenant(ctx, tenantID), objectKey)
+		if err != nil {
+			return moved, err
+		}
+		if newKey == objectKey {
+			continue
+		}
+		moved++
+		if scan != nil && derefString(scan.SBOMObjectKey) == objectKey {
+			scan.SBOMObjectKey = &newKey
+			if err := s.scanResultRepository.Update(ctx, scan); err != nil {
+				return moved, fmt.Errorf("failed to point scan %s at moved SBOM %s: %w", scan.ID, newKey, err)
+			}
+		}
+	}
+	if moved > 0 {
+		slog.Info("Legacy object storage keys migrated", "moved", moved)
+	}
+	return moved, nil
+}
+
+// legacyObjectOwner finds the scan a legacy document was saved by, named by the "<scan id>_" its file name
+// starts with, and the tenant of the scan's application; nil and "" when there is none. owners caches the
+// tenant of every application looked up.
+func (s *RetentionService) legacyObjectOwner(ctx context.Context, objectKey string, owners map[uuid.UUID]string) (*entity.ScanResult, string, error) {
+	scanPart, _, _ := strings.Cut(path.Base(objectKey), "_")
+	scanID, err := uuid.Parse(scanPart)
+	if err != nil || s.scanResultRepository == nil {
+		return nil, "", nil
+	}
+	scan, err := s.scanResultRepository.GetByID(ctx, scanID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, "", nil
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to look up scan %s of %s: %w", scanID, objectKey, err)
+	}
+	if scan.AppID == nil || s.appRepository == nil {
+		return scan, "", nil
+	}
+	tenantID, ok := owners[*scan.AppID]
+	if !ok {
+		app, err := s.appRepository.GetByID(ctx, *scan.AppID)
+		if err != nil && !errors.Is(err, repository.ErrNotFound) {
+			return nil, "", fmt.Errorf("failed to look up application %s of %s: %w", *scan.AppID, objectKey, err)
+		}
+		if app != nil {
+			tenantID = derefString(app.OwnerID)
+		}
+		owners[*scan.AppID] = tenantID
+	}
+	return scan, tenantID, nil
+}
+
+// Shutdown stops the cleanup loop and waits for a running cleanup to finish
+func (s *RetentionService) Shutdown(ctx context.Context) error {
+	s.cancelRoot()
+	return waitWithContext(ctx, &s.loop)
+}
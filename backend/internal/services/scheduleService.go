@@ -0,0 +1,302 @@
+package services
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/model"
+	"elang-backend/internal/model/dto"
+	"elang-backend/internal/repository"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// scheduleCheckInterval is how often the scheduler looks for due schedules; cron has minute resolution
+const scheduleCheckInterval = time.Minute
+
+type ScheduleService struct {
+	appRepository      repository.ApplicationRepository
+	scheduleRepository repository.ScanScheduleRepository
+	scanner            ApplicationInterface
+	now                func() time.Time
+
+	// The scheduler loop runs under rootCtx so Shutdown can stop it
+	rootCtx    context.Context
+	cancelRoot context.CancelFunc
+	startOnce  sync.Once
+	loop       sync.WaitGroup
+	running    sync.Mutex // one pass over the due schedules at a time
+}
+
+// NewScheduleService manages cron schedules of application scans; scans are run and stored through scanner
+func NewScheduleService(basicRepo dto.BasicRepositories, scanner ApplicationInterface) ScheduleInterface {
+	rootCtx, cancelRoot := context.WithCancel(context.Background())
+	return &ScheduleService{
+		appRepository:      basicRepo.AppRepository,
+		scheduleRepository: basicRepo.ScanScheduleRepository,
+		scanner:            scanner,
+		now:                time.Now,
+		rootCtx:            rootCtx,
+		cancelRoot:         cancelRoot,
+	}
+}
+
+// Start launches the scheduler loop, which runs the due schedules every minute
+func (s *ScheduleService) Start() {
+	s.startOnce.Do(func() {
+		s.loop.Add(1)
+		go func() {
+			defer s.loop.Done()
+			ticker := time.NewTicker(scheduleCheckInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-s.rootCtx.Done():
+					return
+				case <-ticker.C:
+					if _, err := s.RunDueSchedules(s.rootCtx); err != nil {
+						slog.Error("Scheduled scans failed", "error", err)
+					}
+				}
+			}
+		}()
+	})
+}
+
+// Shutdown stops the scheduler loop and waits for running scheduled scans to finish
+func (s *ScheduleService) Shutdown(ctx context.Context) error {
+	s.cancelRoot()
+	return waitWithContext(ctx, &s.loop)
+}
+
+// CreateSchedule schedules scans of an application
+func (s *ScheduleService) CreateSchedule(ctx context.Context, appUID string, req model.CreateScanScheduleRequest) (*model.ScanScheduleResponse, error) {
+	app, err := s.scheduleApp(ctx, appUID)
+	if err != nil {
+		return nil, err
+	}
+	cron, err := parseScheduleCron(req.Cron)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := &entity.ScanSchedule{
+		ID:             uuid.New(),
+		AppID:          app.ID,
+		CronExpression: cron.String(),
+		Enabled:        req.Enabled == nil || *req.Enabled,
+		CreatedBy:      "api",
+	}
+	if ownerID, ok := repository.OwnerScope(ctx); ok {
+		schedule.CreatedBy = ownerID
+	}
+	s.planNextRun(schedule, cron)
+	if err := s.scheduleRepository.Create(ctx, schedule); err != nil {
+		return nil, fmt.Errorf("failed to create scan schedule: %w", err)
+	}
+	return toScanScheduleResponse(schedule), nil
+}
+
+// ListSchedules lists the schedules of an application
+func (s *ScheduleService) ListSchedules(ctx context.Context, appUID string) (*model.ListScanSchedulesResponse, error) {
+	app, err := s.scheduleApp(ctx, appUID)
+	if err != nil {
+		return nil, err
+	}
+	schedules, err := s.scheduleRepository.GetByAppID(ctx, app.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scan schedules: %w", err)
+	}
+	resp := &model.ListScanSchedulesResponse{Schedules: make([]model.ScanScheduleResponse, 0, len(schedules))}
+	for _, schedule := range schedules {
+		resp.Schedules = append(resp.Schedules, *toScanScheduleResponse(schedule))
+	}
+	return resp, nil
+}
+
+// UpdateSchedule changes the cron expression of a schedule or enables/disables it
+func (s *ScheduleService) UpdateSchedule(ctx context.Context, appUID, scheduleUID string, req model.UpdateScanScheduleRequest) (*model.ScanScheduleResponse, error) {
+	schedule, err := s.getSchedule(ctx, appUID, scheduleUID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Cron != nil {
+		schedule.CronExpression = *req.Cron
+	}
+	if req.Enabled != nil {
+		schedule.Enabled = *req.Enabled
+	}
+	cron, err := parseScheduleCron(schedule.CronExpression)
+	if err != nil {
+		return nil, err
+	}
+	schedule.CronExpression = cron.String()
+	s.planNextRun(schedule, cron)
+	if err := s.scheduleRepository.Update(ctx, schedule); err != nil {
+		return nil, fmt.Errorf("failed to update scan schedule: %w", err)
+	}
+	return toScanScheduleResponse(schedule), nil
+}
+
+// DeleteSchedule removes a schedule; scans it already ran are kept
+func (s *ScheduleService) DeleteSchedule(ctx context.Context, appUID, scheduleUID string) error {
+	schedule, err := s.getSchedule(ctx, appUID, scheduleUID)
+	if err != nil {
+		return err
+	}
+	if err := s.scheduleRepository.Delete(ctx, schedule.ID); err != nil {
+		return fmt.Errorf("failed to delete scan schedule: %w", err)
+	}
+	return nil
+}
+
+// RunDueSchedules scans the application of every schedule whose next run has come, one after the other,
+// and returns how many scans were started. Each schedule's next run is moved forward before its scan
+// starts, so a slow scan or a failing one does not make it run again straight away. Runs missed while the
+// service was down are not caught up: the schedule runs once and continues from now.
+func (s *ScheduleService) RunDueSchedules(ctx context.Context) (int, error) {
+	s.running.Lock()
+	defer s.running.Unlock()
+
+	now := s.now().UTC()
+	due, err := s.scheduleRepository.GetDue(ctx, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch due scan schedules: %w", err)
+	}
+
+	ran := 0
+	for _, schedule := range due {
+		if ctx.Err() != nil {
+			return ran, ctx.Err()
+		}
+		cron, err := helper.ParseCron(schedule.CronExpression)
+		if err != nil {
+			// Expressions are validated when saved, so this only happens after a parser change
+			slog.Error("Disabling scan schedule with an invalid cron expression", "schedule_id", schedule.ID, "cron", schedule.CronExpression, "error", err)
+			schedule.Enabled = false
+			schedule.NextRunAt = nil
+			message := err.Error()
+			schedule.LastError = &message
+			if err := s.scheduleRepository.Update(ctx, schedule); err != nil {
+				slog.Error("Failed to disable scan schedule", "schedule_id", schedule.ID, "error", err)
+			}
+			continue
+		}
+
+		schedule.LastRunAt = &now
+		s.planNextRun(schedule, cron)
+		if err := s.scheduleRepository.Update(ctx, schedule); err != nil {
+			slog.Error("Failed to advance scan schedule", "schedule_id", schedule.ID, "error", err)
+			continue
+		}
+
+		ran++
+		lastScanID, lastError := s.runScheduledScan(ctx, schedule)
+
+		// The schedule may have been changed or removed while the scan ran; only its outcome is recorded
+		current, err := s.scheduleRepository.GetByID(ctx, schedule.ID)
+		if err != nil {
+			continue
+		}
+		current.LastScanID, current.LastError = lastScanID, lastError
+		if err := s.scheduleRepository.Update(ctx, current); err != nil {
+			slog.Error("Failed to record scheduled scan", "schedule_id", schedule.ID, "error", err)
+		}
+	}
+	return ran, nil
+}
+
+// runScheduledScan scans the schedule's application and returns the stored scan or the error
+func (s *ScheduleService) runScheduledScan(ctx context.Context, schedule *entity.ScanSchedule) (*uuid.UUID, *string) {
+	slog.Info("Running scheduled scan", "schedule_id", schedule.ID, "app_id", schedule.AppID, "cron", schedule.CronExpression)
+
+	result, err := s.scanner.ScanApplicationDependencies(ctx, schedule.AppID.String())
+	if err != nil {
+		slog.Warn("Scheduled scan failed", "schedule_id", schedule.ID, "app_id", schedule.AppID, "error", err)
+		message := err.Error()
+		return nil, &message
+	}
+	if scan, ok := result.(model.ScanApplicationResult); ok {
+		if scanID, err := uuid.Parse(scan.ScanID); err == nil {
+			return &scanID, nil
+		}
+	}
+	return nil, nil
+}
+
+// planNextRun sets when schedule runs next, or clears it while the schedule is disabled
+func (s *ScheduleService) planNextRun(schedule *entity.ScanSchedule, cron *helper.CronSchedule) {
+	schedule.NextRunAt = nil
+	if schedule.Enabled {
+		next := cron.Next(s.now())
+		schedule.NextRunAt = &next
+	}
+}
+
+// scheduleApp returns the application a schedule request refers to, restricted to the caller's own
+func (s *ScheduleService) scheduleApp(ctx context.Context, appUID string) (*entity.App, error) {
+	appID, err := uuid.Parse(appUID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid app ID: %w", ErrInvalidInput)
+	}
+	app, err := s.appRepository.GetByID(ctx, appID)
+	if err != nil {
+		return nil, lookupError(err, "application "+appUID)
+	}
+	return app, nil
+}
+
+// getSchedule returns a schedule of the application; schedules of other applications are not found
+func (s *ScheduleService) getSchedule(ctx context.Context, appUID, scheduleUID string) (*entity.ScanSchedule, error) {
+	app, err := s.scheduleApp(ctx, appUID)
+	if err != nil {
+		return nil, err
+	}
+	scheduleID, err := uuid.Parse(scheduleUID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule ID: %w", ErrInvalidInput)
+	}
+	schedule, err := s.scheduleRepository.GetByID(ctx, scheduleID)
+	if err != nil {
+		return nil, lookupError(err, "scan schedule "+scheduleUID)
+	}
+	if schedule.AppID != app.ID {
+		return nil, fmt.Errorf("scan schedule %s: %w", scheduleUID, ErrNotFound)
+	}
+	return schedule, nil
+}
+
+// parseScheduleCron validates a schedule's cron expression
+func parseScheduleCron(expression string) (*helper.CronSchedule, error) {
+	cron, err := helper.ParseCron(expression)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", err, ErrInvalidInput)
+	}
+	if cron.Next(time.Now()).IsZero() {
+		return nil, fmt.Errorf("cron expression %q never matches: %w", expression, ErrInvalidInput)
+	}
+	return cron, nil
+}
+
+func toScanScheduleResponse(schedule *entity.ScanSchedule) *model.ScanScheduleResponse {
+	resp := &model.ScanScheduleResponse{
+		ID:        schedule.ID.String(),
+		AppID:     schedule.AppID.String(),
+		Cron:      schedule.CronExpression,
+		Enabled:   schedule.Enabled,
+		NextRunAt: schedule.NextRunAt,
+		LastRunAt: schedule.LastRunAt,
+		CreatedAt: schedule.CreatedAt,
+	}
+	if schedule.LastScanID != nil {
+		resp.LastScanID = schedule.LastScanID.String()
+	}
+	if schedule.LastError != nil {
+		resp.LastError = *schedule.LastError
+	}
+	return resp
+}
@@ -0,0 +1,202 @@
+package services
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+	"elang-backend/internal/model"
+	"elang-backend/internal/model/dto"
+	"elang-backend/internal/repository"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SeedRuntimes are the runtimes every installation starts with
+var SeedRuntimes = []string{"Node.js", "Python", "Java", "Go", "Ruby", "PHP", "DotNet", "Gradle", "Manual"}
+
+// SeedFramework is a framework every installation starts with and the names of the runtimes it is used with
+type SeedFramework struct {
+	Name     string
+	Runtimes []string
+}
+
+// SeedFrameworks are the frameworks every installation starts with
+var SeedFrameworks = []SeedFramework{
+	{Name: "Express", Runtimes: []string{"Node.js"}},
+	{Name: "Django", Runtimes: []string{"Python"}},
+	{Name: "Spring", Runtimes: []string{"Java", "Gradle"}},
+	{Name: "Gin", Runtimes: []string{"Go"}},
+	{Name: "Rails", Runtimes: []string{"Ruby"}},
+	{Name: "Laravel", Runtimes: []string{"PHP"}},
+	{Name: "ASP.NET", Runtimes: []string{"DotNet"}},
+	{Name: "Flask", Runtimes: []string{"Python"}},
+	{Name: "React", Runtimes: []string{"Node.js"}},
+	{Name: "Vue.js", Runtimes: []string{"Node.js"}},
+	{Name: "Angular", Runtimes: []string{"Node.js"}},
+	{Name: "Spring Boot", Runtimes: []string{"Java", "Gradle"}},
+	{Name: "Echo", Runtimes: []string{"Go"}},
+	{Name: "Symfony", Runtimes: []string{"PHP"}},
+	{Name: "Ruby on Rails", Runtimes: []string{"Ruby"}},
+	{Name: "CodeIgniter", Runtimes: []string{"PHP"}},
+	{Name: "Native", Runtimes: []string{"Gradle"}},
+	{Name: "None", Runtimes: []string{"Manual"}},
+}
+
+type SeedService struct {
+	runtimeRepository   repository.RuntimeRepository
+	frameworkRepository repository.FrameworkRepository
+
+	// Seed runs one at a time so concurrent runs cannot both create the same entry
+	mu sync.Mutex
+}
+
+func NewSeedService(basicRepo dto.BasicRepositories) SeedInterface {
+	return &SeedService{
+		runtimeRepository:   basicRepo.RunTimeRepository,
+		frameworkRepository: basicRepo.FrameWorkRepository,
+	}
+}
+
+// Seed creates the seed runtimes and frameworks that do not exist yet, matching names case-insensitively, and
+// links frameworks seeded before the runtime association existed to their runtime. Running it again only
+// reports everything as present.
+func (s *SeedService) Seed(ctx context.Context) (*model.SeedResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := &model.SeedResult{
+		CreatedRuntimes:    []string{},
+		ExistingRuntimes:   []string{},
+		CreatedFrameworks:  []string{},
+		LinkedFrameworks:   []string{},
+		ExistingFrameworks: []string{},
+	}
+
+	runtimeIDs := make(map[string]int, len(SeedRuntimes))
+	for _, name := range SeedRuntimes {
+		existing, err := s.runtimeRepository.GetByNameCI(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up runtime %s: %w", name, err)
+		}
+		if existing != nil {
+			runtimeIDs[name] = existing.ID
+			result.ExistingRuntimes = append(result.ExistingRuntimes, name)
+			continue
+		}
+		runtime := &entity.Runtime{Name: name}
+		if err := s.runtimeRepository.Create(ctx, runtime); err != nil {
+			return nil, fmt.Errorf("failed to create runtime %s: %w", name, err)
+		}
+		runtimeIDs[name] = runtime.ID
+		result.CreatedRuntimes = append(result.CreatedRuntimes, name)
+		slog.Info("Seeded runtime", "runtime", name)
+	}
+
+	for _, fw := range SeedFrameworks {
+		name := strings.TrimSpace(fw.Name)
+		framework, err := s.frameworkRepository.GetByNameCI(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up framework %s: %w", name, err)
+		}
+		created := framework == nil
+		if created {
+			framework = &entity.Framework{Name: name}
+			if err := s.frameworkRepository.Create(ctx, framework); err != nil {
+				return nil, fmt.Errorf("failed to create framework %s: %w", name, err)
+			}
+			result.CreatedFrameworks = append(result.CreatedFrameworks, name)
+			slog.Info("Seeded framework", "framework", name, "runtimes", fw.Runtimes)
+		}
+
+		// Existing frameworks get the seed runtimes they are not linked to yet, such as Gradle for Spring Boot
+		linked := false
+		for _, runtimeName := range fw.Runtimes {
+			runtimeID, ok := runtimeIDs[runtimeName]
+			if !ok || runtimeID == 0 {
+				slog.Warn("Runtime of seed framework not found, not linking it", "framework", name, "runtime", runtimeName)
+				continue
+			}
+			if slices.ContainsFunc(framework.Runtimes, func(r entity.Runtime) bool { return r.ID == runtimeID }) {
+				continue
+			}
+			if err := s.frameworkRepository.LinkRuntime(ctx, framework.ID, runtimeID); err != nil {
+				return nil, fmt.Errorf("failed to link framework %s to runtime %s: %w", name, runtimeName, err)
+			}
+			framework.Runtimes = append(framework.Runtimes, entity.Runtime{ID: runtimeID, Name: runtimeName})
+			linked = true
+			if !created {
+				slog.Info("Linked existing framework to a runtime", "framework", name, "runtime", runtimeName)
+			}
+		}
+		switch {
+		case created:
+		case linked:
+			result.LinkedFrameworks = append(result.LinkedFrameworks, name)
+		default:
+			result.ExistingFrameworks = append(result.ExistingFrameworks, name)
+		}
+	}
+
+	result.SeededAt = time.Now().UTC()
+	return result, nil
+}
+
+// SeedStatus lists the runtimes and frameworks in the database, grouped by runtime, and the seed entries that
+// a seed run would create
+func (s *SeedService) SeedStatus(ctx context.Context) (*model.SeedStatusResponse, error) {
+	runtimes, err := s.runtimeRepository.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list runtimes: %w", err)
+	}
+	frameworks, err := s.frameworkRepository.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list frameworks: %w", err)
+	}
+
+	resp := &model.SeedStatusResponse{
+		Runtimes:             make([]model.SeedRuntimeStatus, 0, len(runtimes)),
+		UnassignedFrameworks: []string{},
+		MissingRuntimes:      []string{},
+		MissingFrameworks:    []string{},
+	}
+	sort.Slice(runtimes, func(i, j int) bool { return runtimes[i].Name < runtimes[j].Name })
+	sort.Slice(frameworks, func(i, j int) bool { return frameworks[i].Name < frameworks[j].Name })
+
+	present := map[string]bool{}
+	index := map[int]int{}
+	for _, runtime := range runtimes {
+		present[strings.ToLower(runtime.Name)] = true
+		index[runtime.ID] = len(resp.Runtimes)
+		resp.Runtimes = append(resp.Runtimes, model.SeedRuntimeStatus{ID: runtime.ID, Name: runtime.Name, Frameworks: []string{}})
+	}
+	for _, name := range SeedRuntimes {
+		if !present[strings.ToLower(name)] {
+			resp.MissingRuntimes = append(resp.MissingRuntimes, name)
+		}
+	}
+
+	present = map[string]bool{}
+	for _, fw := range frameworks {
+		present[strings.ToLower(fw.Name)] = true
+		assigned := false
+		for _, runtime := range fw.Runtimes {
+			if i, ok := index[runtime.ID]; ok {
+				resp.Runtimes[i].Frameworks = append(resp.Runtimes[i].Frameworks, fw.Name)
+				assigned = true
+			}
+		}
+		if !assigned {
+			resp.UnassignedFrameworks = append(resp.UnassignedFrameworks, fw.Name)
+		}
+	}
+	for _, fw := range SeedFrameworks {
+		if !present[strings.ToLower(fw.Name)] {
+			resp.MissingFrameworks = append(resp.MissingFrameworks, fw.Name)
+		}
+	}
+	return resp, nil
+}
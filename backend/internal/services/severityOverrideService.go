@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/model"
+	"elang-backend/internal/model/dto"
+	"elang-backend/internal/repository"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type SeverityOverrideService struct {
+	appRepository              repository.ApplicationRepository
+	severityOverrideRepository repository.SeverityOverrideRepository
+}
+
+// NewSeverityOverrideService manages the custom severities scans give matching vulnerabilities
+func NewSeverityOverrideService(basicRepo dto.BasicRepositories) SeverityOverrideInterface {
+	return &SeverityOverrideService{
+		appRepository:              basicRepo.AppRepository,
+		severityOverrideRepository: basicRepo.SeverityOverrideRepository,
+	}
+}
+
+// CreateSeverityOverride stores an override for the caller's applications, or for one of them when the
+// request names it. It applies from the next scan on.
+func (s *SeverityOverrideService) CreateSeverityOverride(ctx context.Context, req model.CreateSeverityOverrideRequest) (*model.SeverityOverrideResponse, error) {
+	override := &entity.SeverityOverride{
+		ID:         uuid.New(),
+		CVEPattern: strings.TrimSpace(req.CVEPattern),
+		Reason:     req.Reason,
+		CreatedBy:  "api",
+	}
+	if ownerID, ok := repository.OwnerScope(ctx); ok {
+		override.OwnerID, override.CreatedBy = ownerID, ownerID
+	}
+	if req.AppID != "" {
+		app, err := s.overrideApp(ctx, req.AppID)
+		if err != nil {
+			return nil, err
+		}
+		override.AppID, override.OwnerID = &app.ID, derefString(app.OwnerID)
+	}
+
+	if strings.TrimSpace(req.CWE) == "" && override.CVEPattern == "" && strings.TrimSpace(req.Ecosystem) == "" {
+		return nil, fmt.Errorf("at least one of cwe, cve_pattern and ecosystem is required: %w", ErrInvalidInput)
+	}
+	var err error
+	if strings.TrimSpace(req.CWE) != "" {
+		if override.CWE, err = helper.NormalizeCWE(req.CWE); err != nil {
+			return nil, fmt.Errorf("%s: %w", err.Error(), ErrInvalidInput)
+		}
+	}
+	if override.CVEPattern != "" {
+		if err := helper.ValidateCVEPattern(override.CVEPattern); err != nil {
+			return nil, fmt.Errorf("%s: %w", err.Error(), ErrInvalidInput)
+		}
+	}
+	if strings.TrimSpace(req.Ecosystem) != "" {
+		if override.Ecosystem, err = helper.ParseEcosystem(req.Ecosystem); err != nil {
+			return nil, fmt.Errorf("%s: %w", err.Error(), ErrInvalidInput)
+		}
+	}
+	severity := helper.ParseSeverity(req.Severity)
+	if severity.Rank() == 0 {
+		return nil, fmt.Errorf("unknown severity %q (expected critical, high, medium or low): %w", req.Severity, ErrInvalidInput)
+	}
+	override.Severity = severity.String()
+
+	if err := s.severityOverrideRepository.Create(ctx, override); err != nil {
+		return nil, fmt.Errorf("failed to create severity override: %w", err)
+	}
+	return toSeverityOverrideResponse(override), nil
+}
+
+// ListSeverityOverrides lists the caller's overrides that apply to every application, preceded by those of
+// the application when one is given
+func (s *SeverityOverrideService) ListSeverityOverrides(ctx context.Context, appUID string) (*model.ListSeverityOverridesResponse, error) {
+	ownerID, _ := repository.OwnerScope(ctx)
+	var appID *uuid.UUID
+	if appUID != "" {
+		app, err := s.overrideApp(ctx, appUID)
+		if err != nil {
+			return nil, err
+		}
+		appID, ownerID = &app.ID, derefString(app.OwnerID)
+	}
+	overrides, err := scopedSeverityOverrides(ctx, s.severityOverrideRepository, ownerID, appID)
+	if err != nil {
+		return nil, err
+	}
+	resp := &model.ListSeverityOverridesResponse{Overrides: make([]model.SeverityOverrideResponse, 0, len(overrides))}
+	for _, override := range overrides {
+		resp.Overrides = append(resp.Overrides, *toSeverityOverrideResponse(override))
+	}
+	return resp, nil
+}
+
+// DeleteSeverityOverride removes an override; overrides of other owners are not found
+func (s *SeverityOverrideService) DeleteSeverityOverride(ctx context.Context, overrideUID string) error {
+	overrideID, err := uuid.Parse(overrideUID)
+	if err != nil {
+		return fmt.Errorf("invalid severity override ID: %w", ErrInvalidInput)
+	}
+	override, err := s.severityOverrideRepository.GetByID(ctx, overrideID)
+	if err != nil {
+		return lookupError(err, "severity override "+overrideUID)
+	}
+	if ownerID, ok := repository.OwnerScope(ctx); ok && override.OwnerID != ownerID {
+		return fmt.Errorf("severity override %s: %w", overrideUID, ErrNotFound)
+	}
+	if err := s.severityOverrideRepository.Delete(ctx, override.ID); err != nil {
+		return fmt.Errorf("failed to delete severity override: %w", err)
+	}
+	return nil
+}
+
+// overrideApp returns the application an override request refers to, restricted to the caller's own
+func (s *SeverityOverrideService) overrideApp(ctx context.Context, appUID string) (*entity.App, error) {
+	appID, err := uuid.Parse(appUID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid app ID: %w", ErrInvalidInput)
+	}
+	app, err := s.appRepository.GetByID(ctx, appID)
+	if err != nil {
+		return nil, lookupError(err, "application "+appUID)
+	}
+	return app, nil
+}
+
+// scopedSeverityOverrides returns the overrides scans of an owner's application apply, in the order that breaks
+// ties between equally specific ones: the application's own, then the owner's for every application. Without
+// appID only the latter are returned.
+func scopedSeverityOverrides(ctx context.Context, repo repository.SeverityOverrideRepository, ownerID string, appID *uuid.UUID) ([]*entity.SeverityOverride, error) {
+	var overrides []*entity.SeverityOverride
+	if appID != nil {
+		appOverrides, err := repo.GetByAppID(ctx, *appID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch severity overrides: %w", err)
+		}
+		overrides = append(overrides, appOverrides...)
+	}
+	ownerOverrides, err := repo.GetByOwner(ctx, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch severity overrides: %w", err)
+	}
+	return append(overrides, ownerOverrides...), nil
+}
+
+// withSeverityOverrides attaches the severity overrides of an owner's application to ctx, so the scans run
+// with it apply them before their summary and policy are computed
+func withSeverityOverrides(ctx context.Context, repo repository.SeverityOverrideRepository, ownerID string, appID *uuid.UUID) (context.Context, error) {
+	if repo == nil {
+		return ctx, nil
+	}
+	overrides, err := scopedSeverityOverrides(ctx, repo, ownerID, appID)
+	if err != nil {
+		return ctx, err
+	}
+	rules := make([]helper.SeverityOverrideRule, 0, len(overrides))
+	for _, override := range overrides {
+		rules = append(rules, helper.SeverityOverrideRule{
+			ID:         override.ID.String(),
+			CWE:        override.CWE,
+			CVEPattern: override.CVEPattern,
+			Ecosystem:  override.Ecosystem,
+			Severity:   helper.ParseSeverity(override.Severity),
+		})
+	}
+	return helper.WithSeverityOverrides(ctx, rules), nil
+}
+
+func toSeverityOverrideResponse(override *entity.SeverityOverride) *model.SeverityOverrideResponse {
+	resp := &model.SeverityOverrideResponse{
+		ID:         override.ID.String(),
+		CWE:        override.CWE,
+		CVEPattern: override.CVEPattern,
+		Ecosystem:  override.Ecosystem,
+		Severity:   override.Severity,
+		Reason:     override.Reason,
+		CreatedBy:  override.CreatedBy,
+		CreatedAt:  override.CreatedAt,
+	}
+	if override.AppID != nil {
+		resp.AppID = override.AppID.String()
+	}
+	return resp
+}
@@ -2,41 +2,135 @@ package usecase
 
 import (
 	"bytes"
+	"context"
+	"elang-backend/internal/helper"
 	"elang-backend/internal/model"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
+	"net/url"
 	"strings"
 )
 
 type GithubAPIusecase struct {
 	// Add necessary fields, e.g., HTTP client, authentication tokens, etc.
-	Token      string
-	HTTPClient *http.Client
+	TokenProvider GitHubTokenProvider // Asked for a token before every request; nil or an empty token means unauthenticated
+	HTTPClient    *http.Client
+	Endpoints     GitHubEndpoints // API locations; the zero value is github.com
+
+	anonymousLimit anonymousRateLimit
+}
+
+// DefaultGitHubAPIURL is the REST API root of github.com
+const DefaultGitHubAPIURL = "https://api.github.com"
+
+// GitHubEndpoints locates the GitHub REST and GraphQL APIs. GitHub Enterprise Server serves them under its own
+// host, at https://HOST/api/v3 and https://HOST/api/graphql.
+type GitHubEndpoints struct {
+	REST    string // REST API root; empty means DefaultGitHubAPIURL
+	GraphQL string // GraphQL endpoint; empty derives it from REST
+}
+
+// RESTURL returns the REST API root without a trailing slash
+func (e GitHubEndpoints) RESTURL() string {
+	if e.REST == "" {
+		return DefaultGitHubAPIURL
+	}
+	return strings.TrimRight(e.REST, "/")
 }
 
+// GraphQLURL returns the GraphQL endpoint: GraphQL when set, otherwise https://HOST/api/graphql for an
+// enterprise REST root ending in /api/v3 and REST + "/graphql" for any other
+func (e GitHubEndpoints) GraphQLURL() string {
+	if e.GraphQL != "" {
+		return e.GraphQL
+	}
+	rest := e.RESTURL()
+	if strings.HasSuffix(rest, "/api/v3") {
+		return strings.TrimSuffix(rest, "/v3") + "/graphql"
+	}
+	return rest + "/graphql"
+}
+
+// WebHost returns the host repositories are browsed on: github.com for the public API, the API's own host
+// for GitHub Enterprise Server
+func (e GitHubEndpoints) WebHost() string {
+	parsed, err := url.Parse(e.RESTURL())
+	if err != nil || parsed.Hostname() == "" || strings.EqualFold(parsed.Hostname(), "api.github.com") {
+		return "github.com"
+	}
+	return parsed.Hostname()
+}
+
+// NewGitHubAPIusecase authenticates with a static personal access token; an empty token calls GitHub anonymously
 func NewGitHubAPIusecase(token string) GitHubAPIInterface {
+	return NewGitHubAPIusecaseWithTokenProvider(NewStaticTokenProvider(token))
+}
+
+// NewGitHubAPIusecaseWithTokenProvider authenticates every request with the token currently returned by provider,
+// e.g. a GitHubAppTokenProvider whose installation tokens expire hourly
+func NewGitHubAPIusecaseWithTokenProvider(provider GitHubTokenProvider) GitHubAPIInterface {
+	return NewGitHubAPIusecaseWithEndpoints(provider, GitHubEndpoints{})
+}
+
+// NewGitHubAPIusecaseWithEndpoints is NewGitHubAPIusecaseWithTokenProvider for a GitHub Enterprise Server or
+// any other API location than github.com
+func NewGitHubAPIusecaseWithEndpoints(provider GitHubTokenProvider, endpoints GitHubEndpoints) GitHubAPIInterface {
 	return &GithubAPIusecase{
-		Token:      token,
-		HTTPClient: &http.Client{},
+		TokenProvider: provider,
+		HTTPClient:    &http.Client{Transport: helper.DefaultOutboundTransport()},
+		Endpoints:     endpoints,
+	}
+}
+
+// restURL formats a REST API path such as "/repos/%s/%s" under the configured API root
+func (g *GithubAPIusecase) restURL(format string, args ...interface{}) string {
+	return g.Endpoints.RESTURL() + fmt.Sprintf(format, args...)
+}
+
+// currentToken returns the token for the next request, or "" when requests are unauthenticated
+func (g *GithubAPIusecase) currentToken() (string, error) {
+	if g.TokenProvider == nil {
+		return "", nil
+	}
+	token, err := g.TokenProvider.Token(context.Background())
+	if err != nil {
+		return "", fmt.Errorf("failed to obtain GitHub token: %w", err)
 	}
+	return token, nil
+}
+
+// authorize sets the Authorization header ("token" for REST, "bearer" for GraphQL) when a token is available
+func (g *GithubAPIusecase) authorize(request *http.Request, scheme string) error {
+	token, err := g.currentToken()
+	if err != nil {
+		return err
+	}
+	if token != "" {
+		request.Header.Set("Authorization", scheme+" "+token)
+	}
+	return nil
 }
 
 // GetDefaultBranch fetches the default branch of a given repository.
 // Uses REST API if no token is provided, otherwise uses GraphQL API.
 func (g *GithubAPIusecase) GetDefaultBranch(owner, repo string) (string, error) {
+	token, err := g.currentToken()
+	if err != nil {
+		return "", err
+	}
 	// If no token, use REST API instead of GraphQL
-	if g.Token == "" {
-		url := fmt.Sprintf("https://api.github.com/repos/%s/%s", owner, repo)
+	if token == "" {
+		url := g.restURL("/repos/%s/%s", owner, repo)
 		log.Println("Request URL:", url)
 		request, err := http.NewRequest("GET", url, nil)
 		if err != nil {
 			return "", err
 		}
 		request.Header.Set("Accept", "application/vnd.github.v3+json")
-		resp, err := g.HTTPClient.Do(request)
+		resp, err := g.do(request)
 		if err != nil {
 			return "", err
 		}
@@ -65,124 +159,208 @@ func (g *GithubAPIusecase) GetDefaultBranch(owner, repo string) (string, error)
 	if resp.StatusCode != http.StatusOK {
 		return "", fmt.Errorf("GitHub GraphQL API returned status: %s", resp.Status)
 	}
-	var result struct {
-		Data struct {
-			Repository struct {
-				DefaultBranchRef struct {
-					Name string `json:"name"`
-				} `json:"defaultBranchRef"`
-			} `json:"repository"`
-		} `json:"data"`
+	var data struct {
+		Repository struct {
+			DefaultBranchRef struct {
+				Name string `json:"name"`
+			} `json:"defaultBranchRef"`
+		} `json:"repository"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := decodeGraphQLResponse(resp.Body, &data); err != nil {
 		return "", err
 	}
-	return result.Data.Repository.DefaultBranchRef.Name, nil
+	return data.Repository.DefaultBranchRef.Name, nil
 }
 
-// GetListCommits fetches the list of commits for a given branch.
+const (
+	// DefaultCommitListLimit is how many commits GetListCommits returns when no limit is given
+	DefaultCommitListLimit = 10
+	// MaxCommitListLimit bounds the commits one GetListCommits call pages through
+	MaxCommitListLimit = 1000
+	// commitPageSize is the largest page GitHub serves for commit lists
+	commitPageSize = 100
+)
+
+// clampCommitListLimit applies DefaultCommitListLimit to a missing limit and MaxCommitListLimit to a large one
+func clampCommitListLimit(limit int) int {
+	if limit <= 0 {
+		return DefaultCommitListLimit
+	}
+	if limit > MaxCommitListLimit {
+		return MaxCommitListLimit
+	}
+	return limit
+}
+
+// GetListCommits fetches up to limit of the latest commits of a branch, newest first, paging through the
+// history when limit exceeds one page. A limit of 0 or less means DefaultCommitListLimit; it is capped at
+// MaxCommitListLimit.
 // Uses REST API if no token is provided, otherwise uses GraphQL API.
-func (g *GithubAPIusecase) GetListCommits(owner, repo, branch string) ([]map[string]interface{}, error) {
+func (g *GithubAPIusecase) GetListCommits(owner, repo, branch string, limit int) ([]map[string]interface{}, error) {
+	limit = clampCommitListLimit(limit)
+	token, err := g.currentToken()
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []map[string]interface{}
 	// If no token, use REST API instead of GraphQL
-	if g.Token == "" {
-		url := fmt.Sprintf("https://api.github.com/repos/%s/%s/commits?sha=%s&per_page=10", owner, repo, branch)
-		log.Println("Request URL:", url)
-		request, err := http.NewRequest("GET", url, nil)
-		if err != nil {
-			return nil, err
+	if token == "" {
+		// Pages are offsets of page*per_page, so the page size stays fixed and the last page is trimmed
+		perPage := min(limit, commitPageSize)
+		for page := 1; len(commits) < limit; page++ {
+			pageCommits, err := g.listCommitsPageREST(owner, repo, branch, page, perPage)
+			if err != nil {
+				return nil, err
+			}
+			commits = append(commits, pageCommits...)
+			if len(pageCommits) < perPage {
+				break
+			}
 		}
-		request.Header.Set("Accept", "application/vnd.github.v3+json")
-		resp, err := g.HTTPClient.Do(request)
+		return commits[:min(len(commits), limit)], nil
+	}
+
+	// Use GraphQL API when token is available
+	var cursor interface{}
+	for len(commits) < limit {
+		pageCommits, next, err := g.listCommitsPageGraphQL(owner, repo, branch, min(limit-len(commits), commitPageSize), cursor)
 		if err != nil {
 			return nil, err
 		}
-		defer resp.Body.Close()
-		log.Println("Response Status:", resp.Status)
-		if resp.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("GitHub API returned status: %s", resp.Status)
-		}
-		var rawCommits []struct {
-			SHA    string `json:"sha"`
-			Commit struct {
-				Message string `json:"message"`
-				Author  struct {
-					Name  string `json:"name"`
-					Email string `json:"email"`
-					Date  string `json:"date"`
-				} `json:"author"`
-				Committer struct {
-					Name  string `json:"name"`
-					Email string `json:"email"`
-					Date  string `json:"date"`
-				} `json:"committer"`
-			} `json:"commit"`
+		commits = append(commits, pageCommits...)
+		if next == "" {
+			break
 		}
-		if err := json.NewDecoder(resp.Body).Decode(&rawCommits); err != nil {
-			return nil, err
-		}
-		var commits []map[string]interface{}
-		for _, rc := range rawCommits {
-			commit := map[string]interface{}{
-				"oid":             rc.SHA,
-				"message":         rc.Commit.Message,
-				"author_name":     rc.Commit.Author.Name,
-				"author_email":    rc.Commit.Author.Email,
-				"author_date":     rc.Commit.Author.Date,
-				"committer_name":  rc.Commit.Committer.Name,
-				"committer_email": rc.Commit.Committer.Email,
-				"committer_date":  rc.Commit.Committer.Date,
-				"changed_files":   0, // REST API doesn't provide this in list view
-			}
-			commits = append(commits, commit)
-		}
-		return commits, nil
+		cursor = next
 	}
+	return commits, nil
+}
 
-	// Use GraphQL API when token is available
-	query := fmt.Sprintf(`query { repository(owner: "%s", name: "%s") { ref(qualifiedName: "%s") { target { ... on Commit { history(first: 10) { edges { node { oid message author { name email date } committer { name email date } changedFiles } } } } } } } }`, owner, repo, branch)
-	resp, err := g.doGraphQLRequest(query)
+// listCommitsPageREST fetches one page of a branch's commits through the REST API
+func (g *GithubAPIusecase) listCommitsPageREST(owner, repo, branch string, page, perPage int) ([]map[string]interface{}, error) {
+	url := g.restURL("/repos/%s/%s/commits?sha=%s&per_page=%d&page=%d", owner, repo, branch, perPage, page)
+	log.Println("Request URL:", url)
+	request, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Accept", "application/vnd.github.v3+json")
+	resp, err := g.do(request)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 	log.Println("Response Status:", resp.Status)
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("GitHub GraphQL API returned status: %s", resp.Status)
-	}
-	var result struct {
-		Data struct {
-			Repository struct {
-				Ref struct {
-					Target struct {
-						History struct {
-							Edges []struct {
-								Node struct {
-									Oid     string `json:"oid"`
-									Message string `json:"message"`
-									Author  struct {
-										Name  string `json:"name"`
-										Email string `json:"email"`
-										Date  string `json:"date"`
-									} `json:"author"`
-									Committer struct {
-										Name  string `json:"name"`
-										Email string `json:"email"`
-										Date  string `json:"date"`
-									} `json:"committer"`
-									ChangedFiles int `json:"changedFiles"`
-								} `json:"node"`
-							} `json:"edges"`
-						} `json:"history"`
-					} `json:"target"`
-				} `json:"ref"`
-			} `json:"repository"`
-		} `json:"data"`
+		return nil, fmt.Errorf("GitHub API returned status: %s", resp.Status)
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	var rawCommits []struct {
+		SHA    string `json:"sha"`
+		Commit struct {
+			Message string `json:"message"`
+			Author  struct {
+				Name  string `json:"name"`
+				Email string `json:"email"`
+				Date  string `json:"date"`
+			} `json:"author"`
+			Committer struct {
+				Name  string `json:"name"`
+				Email string `json:"email"`
+				Date  string `json:"date"`
+			} `json:"committer"`
+		} `json:"commit"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&rawCommits); err != nil {
 		return nil, err
 	}
 	var commits []map[string]interface{}
-	for _, edge := range result.Data.Repository.Ref.Target.History.Edges {
+	for _, rc := range rawCommits {
+		commit := map[string]interface{}{
+			"oid":             rc.SHA,
+			"message":         rc.Commit.Message,
+			"author_name":     rc.Commit.Author.Name,
+			"author_email":    rc.Commit.Author.Email,
+			"author_date":     rc.Commit.Author.Date,
+			"committer_name":  rc.Commit.Committer.Name,
+			"committer_email": rc.Commit.Committer.Email,
+			"committer_date":  rc.Commit.Committer.Date,
+			"changed_files":   0, // REST API doesn't provide this in list view
+		}
+		commits = append(commits, commit)
+	}
+	return commits, nil
+}
+
+const commitHistoryQuery = `query($owner: String!, $name: String!, $ref: String!, $first: Int!, $cursor: String) {
+  repository(owner: $owner, name: $name) {
+    ref(qualifiedName: $ref) {
+      target {
+        ... on Commit {
+          history(first: $first, after: $cursor) {
+            edges { node { oid message author { name email date } committer { name email date } changedFiles } }
+            pageInfo { hasNextPage endCursor }
+          }
+        }
+      }
+    }
+  }
+}`
+
+// listCommitsPageGraphQL fetches one page of a branch's commits and the cursor of the next page, "" on the last one
+func (g *GithubAPIusecase) listCommitsPageGraphQL(owner, repo, branch string, first int, cursor interface{}) ([]map[string]interface{}, string, error) {
+	resp, err := g.doGraphQLRequestWithVariables(context.Background(), commitHistoryQuery, map[string]interface{}{
+		"owner":  owner,
+		"name":   repo,
+		"ref":    branch,
+		"first":  first,
+		"cursor": cursor,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+	log.Println("Response Status:", resp.Status)
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("GitHub GraphQL API returned status: %s", resp.Status)
+	}
+	var data struct {
+		Repository struct {
+			Ref struct {
+				Target struct {
+					History struct {
+						Edges []struct {
+							Node struct {
+								Oid     string `json:"oid"`
+								Message string `json:"message"`
+								Author  struct {
+									Name  string `json:"name"`
+									Email string `json:"email"`
+									Date  string `json:"date"`
+								} `json:"author"`
+								Committer struct {
+									Name  string `json:"name"`
+									Email string `json:"email"`
+									Date  string `json:"date"`
+								} `json:"committer"`
+								ChangedFiles int `json:"changedFiles"`
+							} `json:"node"`
+						} `json:"edges"`
+						PageInfo struct {
+							HasNextPage bool   `json:"hasNextPage"`
+							EndCursor   string `json:"endCursor"`
+						} `json:"pageInfo"`
+					} `json:"history"`
+				} `json:"target"`
+			} `json:"ref"`
+		} `json:"repository"`
+	}
+	if err := decodeGraphQLResponse(resp.Body, &data); err != nil {
+		return nil, "", err
+	}
+	history := data.Repository.Ref.Target.History
+	var commits []map[string]interface{}
+	for _, edge := range history.Edges {
 		commit := map[string]interface{}{
 			"oid":             edge.Node.Oid,
 			"message":         edge.Node.Message,
@@ -196,22 +374,26 @@ func (g *GithubAPIusecase) GetListCommits(owner, repo, branch string) ([]map[str
 		}
 		commits = append(commits, commit)
 	}
-	return commits, nil
+	next := ""
+	if history.PageInfo.HasNextPage {
+		next = history.PageInfo.EndCursor
+	}
+	return commits, next, nil
 }
 
 // GetCommitsDetail fetches commit details using the GitHub REST API for a given commit SHA.
 func (g *GithubAPIusecase) GetCommitsDetail(owner, repo, sha string) (*model.CommitDetail, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/commits/%s", owner, repo, sha)
+	url := g.restURL("/repos/%s/%s/commits/%s", owner, repo, sha)
 	log.Println("Request URL:", url)
 	request, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
-	if g.Token != "" {
-		request.Header.Set("Authorization", "token "+g.Token)
+	if err := g.authorize(request, "token"); err != nil {
+		return nil, err
 	}
 	request.Header.Set("Accept", "application/vnd.github.v3+json")
-	resp, err := g.HTTPClient.Do(request)
+	resp, err := g.do(request)
 	if err != nil {
 		return nil, err
 	}
@@ -247,17 +429,17 @@ func (g *GithubAPIusecase) GetCommitsDetail(owner, repo, sha string) (*model.Com
 
 // GetFileContent fetches the raw content of a file at a specific commit (ref) using the GitHub REST API.
 func (g *GithubAPIusecase) GetFileContent(owner, repo, path, ref string) (string, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/contents/%s?ref=%s", owner, repo, path, ref)
+	url := g.restURL("/repos/%s/%s/contents/%s?ref=%s", owner, repo, path, ref)
 	log.Println("Request URL:", url)
 	request, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return "", err
 	}
-	if g.Token != "" {
-		request.Header.Set("Authorization", "token "+g.Token)
+	if err := g.authorize(request, "token"); err != nil {
+		return "", err
 	}
 	request.Header.Set("Accept", "application/vnd.github.v3.raw") // Get raw file content
-	resp, err := g.HTTPClient.Do(request)
+	resp, err := g.do(request)
 	if err != nil {
 		return "", err
 	}
@@ -274,45 +456,25 @@ func (g *GithubAPIusecase) GetFileContent(owner, repo, path, ref string) (string
 }
 
 // GetRepoInfo fetches repository information using the GitHub REST API.
+// Renamed or transferred repositories answer with a 301 to their new location, which the
+// HTTP client follows; the returned full_name is the canonical post-redirect owner/repo.
 func (g *GithubAPIusecase) GetRepoInfo(owner, repo string) (map[string]interface{}, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s", owner, repo)
-	log.Println("Request URL:", url)
-	request, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, err
-	}
-	if g.Token != "" {
-		request.Header.Set("Authorization", "token "+g.Token)
-	}
-	request.Header.Set("Accept", "application/vnd.github.v3+json")
-	resp, err := g.HTTPClient.Do(request)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-	log.Println("Response Status:", resp.Status)
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("GitHub API returned status: %s", resp.Status)
-	}
-	var result map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, err
-	}
-	return result, nil
+	result, _, err := g.GetRepoInfoIfModified(owner, repo, GitHubValidators{})
+	return result, err
 }
 
 // ListBranches lists all branches in a repository.
 func (g *GithubAPIusecase) ListBranches(owner, repo string) ([]string, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/branches", owner, repo)
+	url := g.restURL("/repos/%s/%s/branches", owner, repo)
 	request, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
-	if g.Token != "" {
-		request.Header.Set("Authorization", "token "+g.Token)
+	if err := g.authorize(request, "token"); err != nil {
+		return nil, err
 	}
 	request.Header.Set("Accept", "application/vnd.github.v3+json")
-	resp, err := g.HTTPClient.Do(request)
+	resp, err := g.do(request)
 	if err != nil {
 		return nil, err
 	}
@@ -336,16 +498,16 @@ func (g *GithubAPIusecase) ListBranches(owner, repo string) ([]string, error) {
 // ListTags lists all tags in a repository.
 func (g *GithubAPIusecase) ListTags(owner, repo string) ([]map[string]interface{}, error) {
 	var defaultNumberOfTags = "100"
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/tags?per_page=%s", owner, repo, defaultNumberOfTags)
+	url := g.restURL("/repos/%s/%s/tags?per_page=%s", owner, repo, defaultNumberOfTags)
 	request, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
-	if g.Token != "" {
-		request.Header.Set("Authorization", "token "+g.Token)
+	if err := g.authorize(request, "token"); err != nil {
+		return nil, err
 	}
 	request.Header.Set("Accept", "application/vnd.github.v3+json")
-	resp, err := g.HTTPClient.Do(request)
+	resp, err := g.do(request)
 	if err != nil {
 		return nil, err
 	}
@@ -374,16 +536,16 @@ func (g *GithubAPIusecase) ListTags(owner, repo string) ([]map[string]interface{
 
 // ListPullRequests lists pull requests for a repository.
 func (g *GithubAPIusecase) ListPullRequests(owner, repo, state string) ([]map[string]interface{}, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/pulls?state=%s", owner, repo, state)
+	url := g.restURL("/repos/%s/%s/pulls?state=%s", owner, repo, state)
 	request, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
-	if g.Token != "" {
-		request.Header.Set("Authorization", "token "+g.Token)
+	if err := g.authorize(request, "token"); err != nil {
+		return nil, err
 	}
 	request.Header.Set("Accept", "application/vnd.github.v3+json")
-	resp, err := g.HTTPClient.Do(request)
+	resp, err := g.do(request)
 	if err != nil {
 		return nil, err
 	}
@@ -400,16 +562,16 @@ func (g *GithubAPIusecase) ListPullRequests(owner, repo, state string) ([]map[st
 
 // GetPullRequestDetail gets details of a specific pull request.
 func (g *GithubAPIusecase) GetPullRequestDetail(owner, repo string, number int) (map[string]interface{}, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/pulls/%d", owner, repo, number)
+	url := g.restURL("/repos/%s/%s/pulls/%d", owner, repo, number)
 	request, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
-	if g.Token != "" {
-		request.Header.Set("Authorization", "token "+g.Token)
+	if err := g.authorize(request, "token"); err != nil {
+		return nil, err
 	}
 	request.Header.Set("Accept", "application/vnd.github.v3+json")
-	resp, err := g.HTTPClient.Do(request)
+	resp, err := g.do(request)
 	if err != nil {
 		return nil, err
 	}
@@ -426,16 +588,16 @@ func (g *GithubAPIusecase) GetPullRequestDetail(owner, repo string, number int)
 
 // ListIssues lists issues for a repository.
 func (g *GithubAPIusecase) ListIssues(owner, repo, state string) ([]map[string]interface{}, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/issues?state=%s", owner, repo, state)
+	url := g.restURL("/repos/%s/%s/issues?state=%s", owner, repo, state)
 	request, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
-	if g.Token != "" {
-		request.Header.Set("Authorization", "token "+g.Token)
+	if err := g.authorize(request, "token"); err != nil {
+		return nil, err
 	}
 	request.Header.Set("Accept", "application/vnd.github.v3+json")
-	resp, err := g.HTTPClient.Do(request)
+	resp, err := g.do(request)
 	if err != nil {
 		return nil, err
 	}
@@ -452,16 +614,16 @@ func (g *GithubAPIusecase) ListIssues(owner, repo, state string) ([]map[string]i
 
 // GetIssueDetail gets details of a specific issue.
 func (g *GithubAPIusecase) GetIssueDetail(owner, repo string, number int) (map[string]interface{}, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/issues/%d", owner, repo, number)
+	url := g.restURL("/repos/%s/%s/issues/%d", owner, repo, number)
 	request, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
-	if g.Token != "" {
-		request.Header.Set("Authorization", "token "+g.Token)
+	if err := g.authorize(request, "token"); err != nil {
+		return nil, err
 	}
 	request.Header.Set("Accept", "application/vnd.github.v3+json")
-	resp, err := g.HTTPClient.Do(request)
+	resp, err := g.do(request)
 	if err != nil {
 		return nil, err
 	}
@@ -478,16 +640,16 @@ func (g *GithubAPIusecase) GetIssueDetail(owner, repo string, number int) (map[s
 
 // ListDirectoryContents lists files and directories at a given path/ref.
 func (g *GithubAPIusecase) ListDirectoryContents(owner, repo, path, ref string) ([]map[string]interface{}, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/contents/%s?ref=%s", owner, repo, path, ref)
+	url := g.restURL("/repos/%s/%s/contents/%s?ref=%s", owner, repo, path, ref)
 	request, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
-	if g.Token != "" {
-		request.Header.Set("Authorization", "token "+g.Token)
+	if err := g.authorize(request, "token"); err != nil {
+		return nil, err
 	}
 	request.Header.Set("Accept", "application/vnd.github.v3+json")
-	resp, err := g.HTTPClient.Do(request)
+	resp, err := g.do(request)
 	if err != nil {
 		return nil, err
 	}
@@ -504,16 +666,16 @@ func (g *GithubAPIusecase) ListDirectoryContents(owner, repo, path, ref string)
 
 // GetUserInfo gets information about a GitHub user.
 func (g *GithubAPIusecase) GetUserInfo(username string) (map[string]interface{}, error) {
-	url := fmt.Sprintf("https://api.github.com/users/%s", username)
+	url := g.restURL("/users/%s", username)
 	request, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
-	if g.Token != "" {
-		request.Header.Set("Authorization", "token "+g.Token)
+	if err := g.authorize(request, "token"); err != nil {
+		return nil, err
 	}
 	request.Header.Set("Accept", "application/vnd.github.v3+json")
-	resp, err := g.HTTPClient.Do(request)
+	resp, err := g.do(request)
 	if err != nil {
 		return nil, err
 	}
@@ -530,16 +692,16 @@ func (g *GithubAPIusecase) GetUserInfo(username string) (map[string]interface{},
 
 // ListCollaborators lists collaborators for a repository.
 func (g *GithubAPIusecase) ListCollaborators(owner, repo string) ([]map[string]interface{}, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/collaborators", owner, repo)
+	url := g.restURL("/repos/%s/%s/collaborators", owner, repo)
 	request, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
-	if g.Token != "" {
-		request.Header.Set("Authorization", "token "+g.Token)
+	if err := g.authorize(request, "token"); err != nil {
+		return nil, err
 	}
 	request.Header.Set("Accept", "application/vnd.github.v3+json")
-	resp, err := g.HTTPClient.Do(request)
+	resp, err := g.do(request)
 	if err != nil {
 		return nil, err
 	}
@@ -556,16 +718,16 @@ func (g *GithubAPIusecase) ListCollaborators(owner, repo string) ([]map[string]i
 
 // ListWebhooks lists webhooks configured for a repository.
 func (g *GithubAPIusecase) ListWebhooks(owner, repo string) ([]map[string]interface{}, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/hooks", owner, repo)
+	url := g.restURL("/repos/%s/%s/hooks", owner, repo)
 	request, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
-	if g.Token != "" {
-		request.Header.Set("Authorization", "token "+g.Token)
+	if err := g.authorize(request, "token"); err != nil {
+		return nil, err
 	}
 	request.Header.Set("Accept", "application/vnd.github.v3+json")
-	resp, err := g.HTTPClient.Do(request)
+	resp, err := g.do(request)
 	if err != nil {
 		return nil, err
 	}
@@ -582,17 +744,17 @@ func (g *GithubAPIusecase) ListWebhooks(owner, repo string) ([]map[string]interf
 
 // CompareCommits compares two commits (base and head) in a repository using GitHub's REST API.
 func (g *GithubAPIusecase) CompareCommits(owner, repo, base, head string) (*model.CompareCommitResult, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/compare/%s...%s", owner, repo, base, head)
+	url := g.restURL("/repos/%s/%s/compare/%s...%s", owner, repo, base, head)
 	log.Println("CompareCommits request URL:", url)
 	request, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
-	if g.Token != "" {
-		request.Header.Set("Authorization", "token "+g.Token)
+	if err := g.authorize(request, "token"); err != nil {
+		return nil, err
 	}
 	request.Header.Set("Accept", "application/vnd.github.v3+json")
-	resp, err := g.HTTPClient.Do(request)
+	resp, err := g.do(request)
 	if err != nil {
 		return nil, err
 	}
@@ -607,6 +769,38 @@ func (g *GithubAPIusecase) CompareCommits(owner, repo, base, head string) (*mode
 	return &result, nil
 }
 
+// ListReleases lists the newest published releases of a repository with their notes; drafts are left out.
+func (g *GithubAPIusecase) ListReleases(owner, repo string) ([]model.Release, error) {
+	url := g.restURL("/repos/%s/%s/releases?per_page=100", owner, repo)
+	request, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.authorize(request, "token"); err != nil {
+		return nil, err
+	}
+	request.Header.Set("Accept", "application/vnd.github.v3+json")
+	resp, err := g.do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GitHub API returned status: %s", resp.Status)
+	}
+	var releases []model.Release
+	if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
+		return nil, err
+	}
+	published := releases[:0]
+	for _, release := range releases {
+		if !release.Draft {
+			published = append(published, release)
+		}
+	}
+	return published, nil
+}
+
 // FindMatchingTag returns the tag name that matches or is most similar to the given version string
 func (g *GithubAPIusecase) FindMatchingTag(owner, repo, version string) (string, error) {
 	tags, err := g.ListTags(owner, repo)
@@ -651,25 +845,33 @@ func (g *GithubAPIusecase) FindMatchingTag(owner, repo, version string) (string,
 
 // doGraphQLRequest is a reusable helper for sending GraphQL queries to GitHub
 func (g *GithubAPIusecase) doGraphQLRequest(query string) (*http.Response, error) {
-	graphqlURL := "https://api.github.com/graphql"
+	return g.doGraphQLRequestWithVariables(context.Background(), query, nil)
+}
+
+// doGraphQLRequestWithVariables sends a GraphQL query with variables, so user supplied values need no escaping
+func (g *GithubAPIusecase) doGraphQLRequestWithVariables(ctx context.Context, query string, variables map[string]interface{}) (*http.Response, error) {
+	graphqlURL := g.Endpoints.GraphQLURL()
 	body := map[string]interface{}{
 		"query": query,
 	}
+	if len(variables) > 0 {
+		body["variables"] = variables
+	}
 	jsonBody, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	log.Println("GraphQL Request URL:", graphqlURL)
 	log.Printf("GraphQL Query: %s\n", query)
-	request, err := http.NewRequest("POST", graphqlURL, bytes.NewBuffer(jsonBody))
+	request, err := http.NewRequestWithContext(ctx, "POST", graphqlURL, bytes.NewBuffer(jsonBody))
 	if err != nil {
 		return nil, err
 	}
 	request.Header.Set("Content-Type", "application/json")
-	if g.Token != "" {
-		request.Header.Set("Authorization", "bearer "+g.Token)
+	if err := g.authorize(request, "bearer"); err != nil {
+		return nil, err
 	}
-	resp, err := g.HTTPClient.Do(request)
+	resp, err := g.do(request)
 	if err != nil {
 		return nil, err
 	}
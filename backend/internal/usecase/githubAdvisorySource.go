@@ -0,0 +1,209 @@
+package usecase
+
+import (
+	"context"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/helper/parser"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// githubAdvisoryMaxPages bounds how many pages of advisories are read for one package
+const githubAdvisoryMaxPages = 10
+
+// githubAdvisoryEcosystems maps OSV ecosystem names to GitHub's SecurityAdvisoryEcosystem enum
+var githubAdvisoryEcosystems = map[string]string{
+	"Go":        "GO",
+	"npm":       "NPM",
+	"PyPI":      "PIP",
+	"Maven":     "MAVEN",
+	"NuGet":     "NUGET",
+	"RubyGems":  "RUBYGEMS",
+	"Packagist": "COMPOSER",
+	"crates.io": "RUST",
+}
+
+const securityVulnerabilitiesQuery = `query($ecosystem: SecurityAdvisoryEcosystem!, $package: String!, $cursor: String) {
+  securityVulnerabilities(first: 100, ecosystem: $ecosystem, package: $package, after: $cursor) {
+    nodes {
+      vulnerableVersionRange
+      firstPatchedVersion { identifier }
+      advisory {
+        ghsaId summary description severity publishedAt updatedAt withdrawnAt permalink
+        identifiers { type value }
+        cvss { score vectorString }
+        cwes(first: 10) { nodes { cweId } }
+        references { url }
+      }
+    }
+    pageInfo { hasNextPage endCursor }
+  }
+}`
+
+// GitHubAdvisorySource queries GitHub's Security Advisory database through the GraphQL
+// securityVulnerabilities query. GitHub's GraphQL API requires authentication.
+type GitHubAdvisorySource struct {
+	github *GithubAPIusecase
+}
+
+// NewGitHubAdvisorySource creates an advisory source that authenticates like the given GitHub client
+func NewGitHubAdvisorySource(github *GithubAPIusecase) *GitHubAdvisorySource {
+	return &GitHubAdvisorySource{github: github}
+}
+
+func (s *GitHubAdvisorySource) Name() string {
+	return "github"
+}
+
+type githubSecurityVulnerability struct {
+	VulnerableVersionRange string `json:"vulnerableVersionRange"`
+	FirstPatchedVersion    *struct {
+		Identifier string `json:"identifier"`
+	} `json:"firstPatchedVersion"`
+	Advisory struct {
+		GHSAID      string     `json:"ghsaId"`
+		Summary     string     `json:"summary"`
+		Description string     `json:"description"`
+		Severity    string     `json:"severity"`
+		PublishedAt time.Time  `json:"publishedAt"`
+		UpdatedAt   time.Time  `json:"updatedAt"`
+		WithdrawnAt *time.Time `json:"withdrawnAt"`
+		Permalink   string     `json:"permalink"`
+		Identifiers []struct {
+			Type  string `json:"type"`
+			Value string `json:"value"`
+		} `json:"identifiers"`
+		CVSS struct {
+			Score        float64 `json:"score"`
+			VectorString string  `json:"vectorString"`
+		} `json:"cvss"`
+		CWEs struct {
+			Nodes []struct {
+				CWEID string `json:"cweId"`
+			} `json:"nodes"`
+		} `json:"cwes"`
+		References []struct {
+			URL string `json:"url"`
+		} `json:"references"`
+	} `json:"advisory"`
+}
+
+// QueryVulnerabilities lists the advisories for dep's package and keeps the ones whose vulnerable range
+// contains dep's version; GitHub does not filter by version itself
+func (s *GitHubAdvisorySource) QueryVulnerabilities(ctx context.Context, dep parser.DependencyInfo) ([]helper.VulnerabilityInfo, error) {
+	ecosystem, ok := githubAdvisoryEcosystems[helper.EcosystemForDependency(dep)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported ecosystem: %s", helper.EcosystemForDependency(dep))
+	}
+	token, err := s.github.currentToken()
+	if err != nil {
+		return nil, err
+	}
+	if token == "" {
+		return nil, errors.New("GitHub advisory database requires a GitHub token or app")
+	}
+
+	vulns := []helper.VulnerabilityInfo{}
+	var cursor interface{}
+	for page := 0; page < githubAdvisoryMaxPages; page++ {
+		nodes, next, err := s.fetchPage(ctx, ecosystem, dep.Name, cursor)
+		if err != nil {
+			return nil, err
+		}
+		for _, node := range nodes {
+			if node.Advisory.WithdrawnAt != nil || !helper.VersionInRange(dep.Version, node.VulnerableVersionRange) {
+				continue
+			}
+			vulns = append(vulns, convertGitHubAdvisory(node))
+		}
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+	return vulns, nil
+}
+
+// fetchPage returns one page of vulnerabilities and the cursor of the next page, "" on the last one
+func (s *GitHubAdvisorySource) fetchPage(ctx context.Context, ecosystem, pkg string, cursor interface{}) ([]githubSecurityVulnerability, string, error) {
+	resp, err := s.github.doGraphQLRequestWithVariables(ctx, securityVulnerabilitiesQuery, map[string]interface{}{
+		"ecosystem": ecosystem,
+		"package":   pkg,
+		"cursor":    cursor,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("GitHub GraphQL API returned status: %s", resp.Status)
+	}
+
+	var data struct {
+		SecurityVulnerabilities struct {
+			Nodes    []githubSecurityVulnerability `json:"nodes"`
+			PageInfo struct {
+				HasNextPage bool   `json:"hasNextPage"`
+				EndCursor   string `json:"endCursor"`
+			} `json:"pageInfo"`
+		} `json:"securityVulnerabilities"`
+	}
+	if err := decodeGraphQLResponse(resp.Body, &data); err != nil {
+		return nil, "", err
+	}
+
+	vulnerabilities := data.SecurityVulnerabilities
+	next := ""
+	if vulnerabilities.PageInfo.HasNextPage {
+		next = vulnerabilities.PageInfo.EndCursor
+	}
+	return vulnerabilities.Nodes, next, nil
+}
+
+func convertGitHubAdvisory(node githubSecurityVulnerability) helper.VulnerabilityInfo {
+	advisory := node.Advisory
+	vuln := helper.VulnerabilityInfo{
+		ID:               advisory.GHSAID,
+		Summary:          advisory.Summary,
+		Description:      advisory.Description,
+		Severity:         helper.ParseSeverity(advisory.Severity), // GitHub calls medium "MODERATE"
+		Score:            advisory.CVSS.Score,
+		VectorString:     advisory.CVSS.VectorString,
+		AffectedVersions: []string{node.VulnerableVersionRange},
+		AffectedRanges:   []helper.VersionRange{helper.VersionRangeFromConstraints(node.VulnerableVersionRange)},
+		PatchedVersions:  []string{},
+		Cwes:             []string{},
+		References:       []string{},
+		PublishedDate:    advisory.PublishedAt,
+		ModifiedDate:     advisory.UpdatedAt,
+		Source:           "github",
+	}
+	if vuln.Score == 0 {
+		vuln.Score = 5.0 // same default the OSV source uses when no score is known
+	}
+	for _, identifier := range advisory.Identifiers {
+		if identifier.Value == advisory.GHSAID {
+			continue
+		}
+		if identifier.Type == "CVE" && vuln.CVE == "" {
+			vuln.CVE = identifier.Value
+		}
+		vuln.Aliases = append(vuln.Aliases, identifier.Value)
+	}
+	if node.FirstPatchedVersion != nil && node.FirstPatchedVersion.Identifier != "" {
+		vuln.PatchedVersions = append(vuln.PatchedVersions, node.FirstPatchedVersion.Identifier)
+	}
+	for _, cwe := range advisory.CWEs.Nodes {
+		vuln.Cwes = append(vuln.Cwes, strings.ToUpper(cwe.CWEID))
+	}
+	if advisory.Permalink != "" {
+		vuln.References = append(vuln.References, advisory.Permalink)
+	}
+	for _, ref := range advisory.References {
+		vuln.References = append(vuln.References, ref.URL)
+	}
+	return vuln
+}
@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+// ErrGitHubNotModified is returned by conditional lookups when GitHub reports that the resource has not changed
+// since the response the validators sent with the request came from
+var ErrGitHubNotModified = errors.New("GitHub resource not modified")
+
+// ErrGitHubRepositoryNotFound is returned by repository lookups when GitHub answers 404: the repository does not
+// exist, or is private and the configured credentials cannot see it
+var ErrGitHubRepositoryNotFound = errors.New("GitHub repository not found")
+
+// GitHubValidators are the ETag and Last-Modified headers of a GitHub response. Sent back with the next request
+// for the same resource, they let GitHub answer an unchanged resource with a bodyless 304 Not Modified, which
+// does not count against the rate limit. Callers keep them next to the data fetched with them.
+type GitHubValidators struct {
+	ETag         string
+	LastModified string
+}
+
+// IsZero reports whether there are no validators to send
+func (v GitHubValidators) IsZero() bool {
+	return v.ETag == "" && v.LastModified == ""
+}
+
+// setConditional adds the If-None-Match and If-Modified-Since headers for since to request
+func (v GitHubValidators) setConditional(request *http.Request) {
+	if v.ETag != "" {
+		request.Header.Set("If-None-Match", v.ETag)
+	}
+	if v.LastModified != "" {
+		request.Header.Set("If-Modified-Since", v.LastModified)
+	}
+}
+
+// responseValidators returns the validators of a GitHub response
+func responseValidators(resp *http.Response) GitHubValidators {
+	return GitHubValidators{ETag: resp.Header.Get("ETag"), LastModified: resp.Header.Get("Last-Modified")}
+}
+
+// GetRepoInfoIfModified is GetRepoInfo sent with the validators of an earlier response for the repository. It
+// returns the repository info with the validators of the new response, or ErrGitHubNotModified when the
+// repository is unchanged. Any push to the repository changes it, as do changes to its settings or star count.
+func (g *GithubAPIusecase) GetRepoInfoIfModified(owner, repo string, since GitHubValidators) (map[string]interface{}, GitHubValidators, error) {
+	url := g.restURL("/repos/%s/%s", owner, repo)
+	log.Println("Request URL:", url)
+	request, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, GitHubValidators{}, err
+	}
+	if err := g.authorize(request, "token"); err != nil {
+		return nil, GitHubValidators{}, err
+	}
+	request.Header.Set("Accept", "application/vnd.github.v3+json")
+	since.setConditional(request)
+	resp, err := g.do(request)
+	if err != nil {
+		return nil, GitHubValidators{}, err
+	}
+	defer resp.Body.Close()
+	log.Println("Response Status:", resp.Status)
+	if resp.StatusCode == http.StatusNotModified && !since.IsZero() {
+		return nil, since, fmt.Errorf("repository %s/%s: %w", owner, repo, ErrGitHubNotModified)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, GitHubValidators{}, fmt.Errorf("repository %s/%s: %w", owner, repo, ErrGitHubRepositoryNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, GitHubValidators{}, fmt.Errorf("GitHub API returned status: %s", resp.Status)
+	}
+	var result map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, GitHubValidators{}, err
+	}
+	return result, responseValidators(resp), nil
+}
@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// GraphQLError is one entry of the errors GitHub's GraphQL API reports in the response body. GitHub answers
+// rate limiting, bad queries and missing repositories with HTTP 200 and such errors, and no or partial data.
+type GraphQLError struct {
+	Type    string        `json:"type"` // e.g. RATE_LIMITED, NOT_FOUND or FORBIDDEN; empty for query errors
+	Message string        `json:"message"`
+	Path    []interface{} `json:"path,omitempty"`
+}
+
+func (e GraphQLError) Error() string {
+	if e.Type == "" {
+		return e.Message
+	}
+	return e.Type + ": " + e.Message
+}
+
+// GraphQLErrors are the errors of one GraphQL response, returned as the error of the request so callers can
+// inspect them with errors.As
+type GraphQLErrors []GraphQLError
+
+func (e GraphQLErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+	return "GitHub GraphQL API error: " + strings.Join(messages, "; ")
+}
+
+// HasType reports whether any of the errors is of the given type, e.g. RATE_LIMITED
+func (e GraphQLErrors) HasType(errorType string) bool {
+	for _, err := range e {
+		if err.Type == errorType {
+			return true
+		}
+	}
+	return false
+}
+
+// decodeGraphQLResponse decodes the data of a GraphQL response into data. A response carrying errors returns
+// them as GraphQLErrors instead, since its data is missing or incomplete.
+func decodeGraphQLResponse(body io.Reader, data interface{}) error {
+	var envelope struct {
+		Data   json.RawMessage `json:"data"`
+		Errors GraphQLErrors   `json:"errors"`
+	}
+	if err := json.NewDecoder(body).Decode(&envelope); err != nil {
+		return fmt.Errorf("failed to decode GitHub GraphQL response: %w", err)
+	}
+	if len(envelope.Errors) > 0 {
+		return envelope.Errors
+	}
+	if len(envelope.Data) == 0 || string(envelope.Data) == "null" {
+		return fmt.Errorf("GitHub GraphQL API returned no data")
+	}
+	return json.Unmarshal(envelope.Data, data)
+}
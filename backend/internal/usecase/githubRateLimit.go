@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrGitHubAnonymousRateLimit is returned for unauthenticated GitHub requests once the anonymous limit of 60
+// requests an hour is used up. Until the limit resets further anonymous requests fail with it without being sent,
+// so callers can skip the remaining GitHub work instead of failing each item on its own.
+var ErrGitHubAnonymousRateLimit = errors.New("GitHub token not configured; anonymous rate limit exhausted")
+
+// defaultAnonymousLimitWait is assumed when GitHub does not say when the anonymous limit resets
+const defaultAnonymousLimitWait = time.Hour
+
+// anonymousRateLimit remembers until when GitHub refuses unauthenticated requests
+type anonymousRateLimit struct {
+	mu      sync.Mutex
+	resetAt time.Time
+}
+
+// exhausted reports whether the anonymous limit is still used up at now
+func (l *anonymousRateLimit) exhausted(now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return now.Before(l.resetAt)
+}
+
+// record notes that the limit is used up until resetAt and reports whether this starts a new exhaustion,
+// so the warning is logged once per window rather than for every refused request
+func (l *anonymousRateLimit) record(now, resetAt time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	first := !now.Before(l.resetAt)
+	l.resetAt = resetAt
+	return first
+}
+
+// isRateLimited reports whether GitHub refused a response because the caller's rate limit is used up, as
+// opposed to a 403 for a private repository
+func isRateLimited(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return false
+	}
+	return resp.Header.Get("X-RateLimit-Remaining") == "0"
+}
+
+// rateLimitReset returns when the limit of a rate limited response resets, from its X-RateLimit-Reset epoch
+func rateLimitReset(resp *http.Response, now time.Time) time.Time {
+	if epoch, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil && epoch > 0 {
+		return time.Unix(epoch, 0).UTC()
+	}
+	return now.Add(defaultAnonymousLimitWait)
+}
+
+// do sends a GitHub request. Unauthenticated requests are not sent while the anonymous rate limit is exhausted,
+// and the response that exhausts it is turned into ErrGitHubAnonymousRateLimit.
+func (g *GithubAPIusecase) do(request *http.Request) (*http.Response, error) {
+	anonymous := request.Header.Get("Authorization") == ""
+	now := time.Now()
+	if anonymous && g.anonymousLimit.exhausted(now) {
+		return nil, ErrGitHubAnonymousRateLimit
+	}
+
+	resp, err := g.HTTPClient.Do(request)
+	if err != nil || !anonymous || !isRateLimited(resp) {
+		return resp, err
+	}
+	resp.Body.Close()
+
+	resetAt := rateLimitReset(resp, now)
+	if g.anonymousLimit.record(now, resetAt) {
+		slog.Warn("GitHub token not configured; anonymous rate limit exhausted, skipping GitHub requests until it resets",
+			"reset_at", resetAt.Format(time.RFC3339))
+	}
+	return nil, fmt.Errorf("%w until %s", ErrGitHubAnonymousRateLimit, resetAt.Format(time.RFC3339))
+}
@@ -0,0 +1,177 @@
+package usecase
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"elang-backend/internal/helper"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// githubAppJWTLifetime stays under GitHub's 10 minute maximum for app JWTs
+	githubAppJWTLifetime = 9 * time.Minute
+	// githubAppClockSkew backdates the JWT issue time to tolerate clock drift
+	githubAppClockSkew = 60 * time.Second
+	// githubTokenRefreshMargin renews installation tokens this long before they expire
+	githubTokenRefreshMargin = 5 * time.Minute
+)
+
+// StaticTokenProvider always returns the same personal access token
+type StaticTokenProvider struct {
+	token string
+}
+
+// NewStaticTokenProvider wraps a personal access token; an empty token means unauthenticated requests
+func NewStaticTokenProvider(token string) *StaticTokenProvider {
+	return &StaticTokenProvider{token: token}
+}
+
+func (p *StaticTokenProvider) Token(ctx context.Context) (string, error) {
+	return p.token, nil
+}
+
+// GitHubAppTokenProvider mints installation access tokens for a GitHub App and caches them until shortly
+// before they expire (GitHub issues them for one hour)
+type GitHubAppTokenProvider struct {
+	AppID          int64
+	InstallationID int64
+	BaseURL        string // GitHub REST API root, https://api.github.com by default
+	HTTPClient     *http.Client
+
+	privateKey *rsa.PrivateKey
+	now        func() time.Time
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// NewGitHubAppTokenProvider creates a provider from the app ID, installation ID and the app's PEM encoded private key
+func NewGitHubAppTokenProvider(appID, installationID int64, privateKeyPEM []byte) (*GitHubAppTokenProvider, error) {
+	if appID <= 0 || installationID <= 0 {
+		return nil, errors.New("GitHub App ID and installation ID are required")
+	}
+	key, err := parseRSAPrivateKey(privateKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+	return &GitHubAppTokenProvider{
+		AppID:          appID,
+		InstallationID: installationID,
+		BaseURL:        DefaultGitHubAPIURL,
+		HTTPClient:     &http.Client{Transport: helper.DefaultOutboundTransport(), Timeout: 30 * time.Second},
+		privateKey:     key,
+		now:            time.Now,
+	}, nil
+}
+
+// Token returns the cached installation token, minting a new one when it is missing or about to expire
+func (p *GitHubAppTokenProvider) Token(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.token != "" && p.now().Add(githubTokenRefreshMargin).Before(p.expiresAt) {
+		return p.token, nil
+	}
+
+	token, expiresAt, err := p.mintInstallationToken(ctx)
+	if err != nil {
+		return "", err
+	}
+	p.token, p.expiresAt = token, expiresAt
+	return token, nil
+}
+
+// mintInstallationToken exchanges an app JWT for an installation access token
+func (p *GitHubAppTokenProvider) mintInstallationToken(ctx context.Context) (string, time.Time, error) {
+	appJWT, err := p.appJWT()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	url := fmt.Sprintf("%s/app/installations/%d/access_tokens", strings.TrimRight(p.BaseURL, "/"), p.InstallationID)
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	request.Header.Set("Authorization", "Bearer "+appJWT)
+	request.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := p.HTTPClient.Do(request)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to request GitHub installation token: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", time.Time{}, fmt.Errorf("GitHub installation token request returned status %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var result struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to decode GitHub installation token: %w", err)
+	}
+	if result.Token == "" {
+		return "", time.Time{}, errors.New("GitHub returned an empty installation token")
+	}
+	return result.Token, result.ExpiresAt, nil
+}
+
+// appJWT signs the short-lived RS256 JWT that identifies the app itself
+func (p *GitHubAppTokenProvider) appJWT() (string, error) {
+	now := p.now()
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256","typ":"JWT"}`))
+	claims, err := json.Marshal(map[string]interface{}{
+		"iat": now.Add(-githubAppClockSkew).Unix(),
+		"exp": now.Add(githubAppJWTLifetime).Unix(),
+		"iss": strconv.FormatInt(p.AppID, 10),
+	})
+	if err != nil {
+		return "", err
+	}
+	signingInput := header + "." + base64.RawURLEncoding.EncodeToString(claims)
+
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, p.privateKey, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign GitHub App JWT: %w", err)
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// parseRSAPrivateKey accepts PKCS#1 keys as downloaded from GitHub as well as PKCS#8 keys
+func parseRSAPrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("GitHub App private key is not PEM encoded")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse GitHub App private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("GitHub App private key must be an RSA key")
+	}
+	return key, nil
+}
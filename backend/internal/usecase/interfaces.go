@@ -16,10 +16,12 @@ type MessagingInterface interface {
  */
 type GitHubAPIInterface interface {
 	GetDefaultBranch(owner, repo string) (string, error)
-	GetListCommits(owner, repo, branch string) ([]map[string]interface{}, error)
+	GetListCommits(owner, repo, branch string, limit int) ([]map[string]interface{}, error)
 	GetCommitsDetail(owner, repo, sha string) (*model.CommitDetail, error)
 	GetFileContent(owner, repo, path, ref string) (string, error)
 	GetRepoInfo(owner, repo string) (map[string]interface{}, error)
+	// GetRepoInfoIfModified returns ErrGitHubNotModified when the repository is unchanged since the response since came from
+	GetRepoInfoIfModified(owner, repo string, since GitHubValidators) (map[string]interface{}, GitHubValidators, error)
 	ListBranches(owner, repo string) ([]string, error)
 	ListTags(owner, repo string) ([]map[string]interface{}, error)
 	ListPullRequests(owner, repo string, state string) ([]map[string]interface{}, error)
@@ -31,9 +33,16 @@ type GitHubAPIInterface interface {
 	ListCollaborators(owner, repo string) ([]map[string]interface{}, error)
 	ListWebhooks(owner, repo string) ([]map[string]interface{}, error)
 	CompareCommits(owner, repo, base, head string) (*model.CompareCommitResult, error)
+	ListReleases(owner, repo string) ([]model.Release, error)
 	FindMatchingTag(owner, repo, version string) (string, error)
 }
 
+// GitHubTokenProvider returns a token that is valid for the next GitHub API request.
+// An empty token means requests are sent unauthenticated.
+type GitHubTokenProvider interface {
+	Token(ctx context.Context) (string, error)
+}
+
 // ObjectStorageInterface defines methods for object storage operations
 type ObjectStorageInterface interface {
 	// Analysis results
@@ -49,9 +58,22 @@ type ObjectStorageInterface interface {
 	SaveSBOM(ctx context.Context, appID string, appName string, sbomData []byte, format string) (string, error)
 	GetSBOM(ctx context.Context, objectKey string) ([]byte, error)
 	ListSBOMs(ctx context.Context, appName string) ([]string, error)
+	DeleteSBOM(ctx context.Context, objectKey string) error
 
 	// Vulnerability report operations
 	SaveVulnerabilityReport(ctx context.Context, appID string, appName string, reportData []byte, format string) (string, error)
 	GetVulnerabilityReport(ctx context.Context, objectKey string) ([]byte, error)
 	ListVulnerabilityReports(ctx context.Context, appName string) ([]string, error)
+
+	// Dependency manifests applications were added from
+	SaveManifest(ctx context.Context, appID string, fileName string, content []byte) (string, error)
+	GetManifest(ctx context.Context, objectKey string) ([]byte, error)
+	DeleteManifest(ctx context.Context, objectKey string) error
+}
+
+// LegacyObjectStorage is implemented by object storages holding documents saved before keys were prefixed
+// and kept apart by tenant, so they can be moved where they are found now
+type LegacyObjectStorage interface {
+	ListLegacyObjects(ctx context.Context) ([]string, error)
+	MoveLegacyObject(ctx context.Context, objectKey string) (string, error)
 }
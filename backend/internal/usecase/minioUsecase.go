@@ -5,6 +5,9 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net/url"
+	"path"
+	"strings"
 	"time"
 
 	"github.com/minio/minio-go/v7"
@@ -14,40 +17,71 @@ import (
 type MinioUsecase struct {
 	client     *minio.Client
 	bucketName string
+	keyPrefix  string // prepended to every object key, e.g. the deployment environment; empty for none
 }
 
-func NewMinioUsecase(endpoint, accessKey, secretKey, bucketName string, useSSL bool) ObjectStorageInterface {
+// storageTenantKey is the context key under which the tenant of stored objects is kept
+type storageTenantKey struct{}
+
+// WithStorageTenant keeps the SBOMs and reports saved, listed and read with ctx in tenantID's own key space,
+// so applications of different tenants with the same name never see each other's documents. An empty
+// tenantID leaves ctx unchanged.
+func WithStorageTenant(ctx context.Context, tenantID string) context.Context {
+	if tenantID == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, storageTenantKey{}, tenantID)
+}
+
+// StorageTenant returns the tenant WithStorageTenant kept in ctx, "" when none
+func StorageTenant(ctx context.Context) string {
+	tenantID, _ := ctx.Value(storageTenantKey{}).(string)
+	return tenantID
+}
+
+// minioStartupTimeout bounds the bucket check NewMinioUsecase makes, so an unreachable endpoint delays startup
+// by at most this long
+const minioStartupTimeout = 15 * time.Second
+
+// NewMinioUsecase connects to the bucket, creating it when missing. keyPrefix, e.g. "staging", is
+// prepended to every object key so several environments can share one bucket. It returns an error instead
+// of a client when the endpoint is invalid or the bucket cannot be checked or created, so callers can run
+// without object storage.
+func NewMinioUsecase(endpoint, accessKey, secretKey, bucketName string, useSSL bool, keyPrefix string) (ObjectStorageInterface, error) {
 	// Initialize MinIO client
 	minioClient, err := minio.New(endpoint, &minio.Options{
 		Creds:  credentials.NewStaticV4(accessKey, secretKey, ""),
 		Secure: useSSL,
 	})
 	if err != nil {
-		panic(fmt.Sprintf("Failed to initialize MinIO client: %v", err))
+		return nil, fmt.Errorf("failed to initialize MinIO client: %w", err)
 	}
 
 	// Ensure the bucket exists
 	mu := &MinioUsecase{
 		client:     minioClient,
 		bucketName: bucketName,
+		keyPrefix:  strings.Trim(keyPrefix, "/"),
 	}
-	if err := mu.ensureBucketExists(context.Background()); err != nil {
-		panic(fmt.Sprintf("Failed to ensure bucket exists: %v", err))
+	ctx, cancel := context.WithTimeout(context.Background(), minioStartupTimeout)
+	defer cancel()
+	if err := mu.ensureBucketExists(ctx); err != nil {
+		return nil, fmt.Errorf("failed to ensure bucket exists: %w", err)
 	}
 
-	return mu
+	return mu, nil
 }
 
 // SaveSBOM saves an SBOM (Software Bill of Materials) to object storage
 func (s *MinioUsecase) SaveSBOM(ctx context.Context, appID string, appName string, sbomData []byte, format string) (string, error) {
-	timestamp := time.Now().Format("2006-01-02")
+	timestamp := time.Now().UTC().Format("2006-01-02")
 	fileExtension := "json"
 	if format == "xml" {
 		fileExtension = "xml"
 	}
 
-	objectKey := fmt.Sprintf("sbom/%s/%s/%s_sbom.%s",
-		appName,
+	objectKey := fmt.Sprintf("%s%s/%s_sbom.%s",
+		s.documentPrefix(ctx, "sbom", appName),
 		timestamp,
 		appID,
 		fileExtension)
@@ -65,7 +99,7 @@ func (s *MinioUsecase) SaveSBOM(ctx context.Context, appID string, appName strin
 			"app-name":      appName,
 			"document-type": "sbom",
 			"format":        format,
-			"generated-at":  time.Now().Format(time.RFC3339),
+			"generated-at":  time.Now().UTC().Format(time.RFC3339),
 		},
 	})
 	if err != nil {
@@ -83,7 +117,7 @@ func (s *MinioUsecase) SaveSBOM(ctx context.Context, appID string, appName strin
 
 // SaveVulnerabilityReport saves a vulnerability report to object storage
 func (s *MinioUsecase) SaveVulnerabilityReport(ctx context.Context, appID string, appName string, reportData []byte, format string) (string, error) {
-	timestamp := time.Now().Format("2006-01-02")
+	timestamp := time.Now().UTC().Format("2006-01-02")
 	fileExtension := "json"
 	if format == "pdf" {
 		fileExtension = "pdf"
@@ -91,8 +125,8 @@ func (s *MinioUsecase) SaveVulnerabilityReport(ctx context.Context, appID string
 		fileExtension = "html"
 	}
 
-	objectKey := fmt.Sprintf("vulnerability-reports/%s/%s/%s_vuln_report.%s",
-		appName,
+	objectKey := fmt.Sprintf("%s%s/%s_vuln_report.%s",
+		s.documentPrefix(ctx, "vulnerability-reports", appName),
 		timestamp,
 		appID,
 		fileExtension)
@@ -112,7 +146,7 @@ func (s *MinioUsecase) SaveVulnerabilityReport(ctx context.Context, appID string
 			"app-name":      appName,
 			"document-type": "vulnerability-report",
 			"format":        format,
-			"generated-at":  time.Now().Format(time.RFC3339),
+			"generated-at":  time.Now().UTC().Format(time.RFC3339),
 		},
 	})
 	if err != nil {
@@ -130,6 +164,9 @@ func (s *MinioUsecase) SaveVulnerabilityReport(ctx context.Context, appID string
 
 // GetSBOM retrieves an SBOM from object storage
 func (s *MinioUsecase) GetSBOM(ctx context.Context, objectKey string) ([]byte, error) {
+	if !s.ownsKey(ctx, objectKey) {
+		return nil, fmt.Errorf("failed to get SBOM: %s is outside this tenant's storage", objectKey)
+	}
 	object, err := s.client.GetObject(ctx, s.bucketName, objectKey, minio.GetObjectOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get SBOM: %w", err)
@@ -147,6 +184,9 @@ func (s *MinioUsecase) GetSBOM(ctx context.Context, objectKey string) ([]byte, e
 
 // GetVulnerabilityReport retrieves a vulnerability report from object storage
 func (s *MinioUsecase) GetVulnerabilityReport(ctx context.Context, objectKey string) ([]byte, error) {
+	if !s.ownsKey(ctx, objectKey) {
+		return nil, fmt.Errorf("failed to get vulnerability report: %s is outside this tenant's storage", objectKey)
+	}
 	object, err := s.client.GetObject(ctx, s.bucketName, objectKey, minio.GetObjectOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get vulnerability report: %w", err)
@@ -164,7 +204,7 @@ func (s *MinioUsecase) GetVulnerabilityReport(ctx context.Context, objectKey str
 
 // ListSBOMs lists all SBOMs for an application
 func (s *MinioUsecase) ListSBOMs(ctx context.Context, appName string) ([]string, error) {
-	prefix := fmt.Sprintf("sbom/%s/", appName)
+	prefix := s.documentPrefix(ctx, "sbom", appName)
 
 	objectCh := s.client.ListObjects(ctx, s.bucketName, minio.ListObjectsOptions{
 		Prefix:    prefix,
@@ -182,9 +222,18 @@ func (s *MinioUsecase) ListSBOMs(ctx context.Context, appName string) ([]string,
 	return objectKeys, nil
 }
 
+// DeleteSBOM removes an SBOM from object storage. Unlike the other operations it is not limited to ctx's
+// tenant: retention cleanup removes the SBOMs of expired scans of every tenant by the keys the scans recorded.
+func (s *MinioUsecase) DeleteSBOM(ctx context.Context, objectKey string) error {
+	if err := s.client.RemoveObject(ctx, s.bucketName, objectKey, minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("failed to delete SBOM: %w", err)
+	}
+	return nil
+}
+
 // ListVulnerabilityReports lists all vulnerability reports for an application
 func (s *MinioUsecase) ListVulnerabilityReports(ctx context.Context, appName string) ([]string, error) {
-	prefix := fmt.Sprintf("vulnerability-reports/%s/", appName)
+	prefix := s.documentPrefix(ctx, "vulnerability-reports", appName)
 
 	objectCh := s.client.ListObjects(ctx, s.bucketName, minio.ListObjectsOptions{
 		Prefix:    prefix,
@@ -201,6 +250,149 @@ func (s *MinioUsecase) ListVulnerabilityReports(ctx context.Context, appName str
 	return objectKeys, nil
 }
 
+// SaveManifest stores the dependency manifest an application was added from under manifests/<app id>/,
+// replacing the one stored before under the same file name
+func (s *MinioUsecase) SaveManifest(ctx context.Context, appID string, fileName string, content []byte) (string, error) {
+	objectKey := fmt.Sprintf("%smanifests/%s/%s", s.rootPrefix(ctx), appID, url.PathEscape(path.Base(fileName)))
+	_, err := s.client.PutObject(ctx, s.bucketName, objectKey, bytes.NewReader(content), int64(len(content)), minio.PutObjectOptions{
+		ContentType: "text/plain",
+		UserMetadata: map[string]string{
+			"app-id":        appID,
+			"file-name":     fileName,
+			"document-type": "manifest",
+			"stored-at":     time.Now().UTC().Format(time.RFC3339),
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to upload manifest: %w", err)
+	}
+
+	slog.Info("Manifest saved to object storage",
+		"object_key", objectKey,
+		"app_id", appID,
+		"size_bytes", len(content))
+
+	return objectKey, nil
+}
+
+// GetManifest retrieves a stored dependency manifest
+func (s *MinioUsecase) GetManifest(ctx context.Context, objectKey string) ([]byte, error) {
+	if !s.ownsKey(ctx, objectKey) {
+		return nil, fmt.Errorf("failed to get manifest: %s is outside this tenant's storage", objectKey)
+	}
+	object, err := s.client.GetObject(ctx, s.bucketName, objectKey, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get manifest: %w", err)
+	}
+	defer object.Close()
+
+	buf := new(bytes.Buffer)
+	if _, err := buf.ReadFrom(object); err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// DeleteManifest removes a stored dependency manifest
+func (s *MinioUsecase) DeleteManifest(ctx context.Context, objectKey string) error {
+	if !s.ownsKey(ctx, objectKey) {
+		return fmt.Errorf("failed to delete manifest: %s is outside this tenant's storage", objectKey)
+	}
+	if err := s.client.RemoveObject(ctx, s.bucketName, objectKey, minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("failed to delete manifest: %w", err)
+	}
+	return nil
+}
+
+// legacyDocumentKinds are the documents saved before keys were prefixed, at the top of the bucket
+var legacyDocumentKinds = []string{"sbom", "vulnerability-reports"}
+
+// ListLegacyObjects lists the SBOMs and vulnerability reports at the top of the bucket, where they were saved
+// before keys were prefixed and kept apart by tenant. Without a key prefix this includes the documents still
+// saved there, those of callers without a tenant.
+func (s *MinioUsecase) ListLegacyObjects(ctx context.Context) ([]string, error) {
+	var objectKeys []string
+	for _, kind := range legacyDocumentKinds {
+		objectCh := s.client.ListObjects(ctx, s.bucketName, minio.ListObjectsOptions{
+			Prefix:    kind + "/",
+			Recursive: true,
+		})
+		for object := range objectCh {
+			if object.Err != nil {
+				return nil, fmt.Errorf("error listing legacy %s objects: %w", kind, object.Err)
+			}
+			objectKeys = append(objectKeys, object.Key)
+		}
+	}
+	return objectKeys, nil
+}
+
+// MoveLegacyObject moves a document listed by ListLegacyObjects under the key prefix and ctx's tenant and
+// returns its new key. A document already where it belongs is left as it is and its key returned.
+func (s *MinioUsecase) MoveLegacyObject(ctx context.Context, objectKey string) (string, error) {
+	if !isLegacyKey(objectKey) {
+		return "", fmt.Errorf("failed to move %s: not a legacy object key", objectKey)
+	}
+	newKey := s.rootPrefix(ctx) + objectKey
+	if newKey == objectKey {
+		return objectKey, nil
+	}
+	_, err := s.client.CopyObject(ctx,
+		minio.CopyDestOptions{Bucket: s.bucketName, Object: newKey},
+		minio.CopySrcOptions{Bucket: s.bucketName, Object: objectKey})
+	if err != nil {
+		return "", fmt.Errorf("failed to copy %s to %s: %w", objectKey, newKey, err)
+	}
+	if err := s.client.RemoveObject(ctx, s.bucketName, objectKey, minio.RemoveObjectOptions{}); err != nil {
+		return "", fmt.Errorf("failed to remove %s after copying it to %s: %w", objectKey, newKey, err)
+	}
+	slog.Info("Legacy object moved", "from", objectKey, "to", newKey)
+	return newKey, nil
+}
+
+// isLegacyKey reports whether objectKey lies where documents were saved before keys were prefixed
+func isLegacyKey(objectKey string) bool {
+	for _, kind := range legacyDocumentKinds {
+		if strings.HasPrefix(objectKey, kind+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// ownsKey reports whether objectKey lies in ctx's key space. The tenants' key spaces are nested in the one of
+// callers without a tenant, so such callers are refused keys under "tenants/" explicitly.
+func (s *MinioUsecase) ownsKey(ctx context.Context, objectKey string) bool {
+	root := s.rootPrefix(ctx)
+	if !strings.HasPrefix(objectKey, root) {
+		return false
+	}
+	if StorageTenant(ctx) == "" {
+		return !strings.HasPrefix(objectKey, root+"tenants/")
+	}
+	return true
+}
+
+// rootPrefix is the start of every key visible with ctx: the configured prefix followed, when ctx carries
+// a tenant, by "tenants/<tenant>/"
+func (s *MinioUsecase) rootPrefix(ctx context.Context) string {
+	var prefix string
+	if s.keyPrefix != "" {
+		prefix = s.keyPrefix + "/"
+	}
+	if tenantID := StorageTenant(ctx); tenantID != "" {
+		prefix += "tenants/" + url.PathEscape(tenantID) + "/"
+	}
+	return prefix
+}
+
+// documentPrefix is the key prefix of one application's documents of a kind, "sbom" or
+// "vulnerability-reports"
+func (s *MinioUsecase) documentPrefix(ctx context.Context, kind, appName string) string {
+	return fmt.Sprintf("%s%s/%s/", s.rootPrefix(ctx), kind, appName)
+}
+
 // ensureBucketExists creates the bucket if it doesn't exist
 func (s *MinioUsecase) ensureBucketExists(ctx context.Context) error {
 	exists, err := s.client.BucketExists(ctx, s.bucketName)
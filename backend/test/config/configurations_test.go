@@ -0,0 +1,121 @@
+package config_test
+
+import (
+	"elang-backend/internal/config"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate_DefaultsAreValid(t *testing.T) {
+	t.Setenv("DEVELOPER_HOST", "")
+
+	assert.NoError(t, config.LoadConfigurations().Validate())
+}
+
+func TestValidate_ListsEveryProblem(t *testing.T) {
+	t.Setenv("DEVELOPER_HOST", "")
+	t.Setenv("DB_PORT", "postgres")
+	t.Setenv("DB_SSLMODE", "sometimes")
+	t.Setenv("STORAGE_ENDPOINT", "https://minio:9000")
+	t.Setenv("STRORAGE_SSL", "yes")
+	t.Setenv("RATE_LIMIT_PER_MINUTE", "-1")
+	t.Setenv("DEPENDENCY_SCAN_TIMEOUT", "15")
+	t.Setenv("MESSAGING_SERVICE_URL", "messaging:8081")
+	t.Setenv("GITHUB_APP_ID", "42")
+
+	configs := config.LoadConfigurations()
+	configs.DB_HOST = ""
+	configs.MINIO_SECRET_KEY = ""
+
+	err := configs.Validate()
+	require.Error(t, err)
+
+	var configErr *config.ConfigError
+	require.True(t, errors.As(err, &configErr))
+	for _, setting := range []string{
+		"DB_HOST", "DB_PORT", "DB_SSLMODE", "STORAGE_ENDPOINT", "STORAGE_SECRET_KEY", "STRORAGE_SSL",
+		"RATE_LIMIT_PER_MINUTE", "DEPENDENCY_SCAN_TIMEOUT", "MESSAGING_SERVICE_URL",
+		"GITHUB_APP_INSTALLATION_ID", "GITHUB_APP_PRIVATE_KEY_PATH",
+	} {
+		assert.Contains(t, err.Error(), setting)
+	}
+	assert.Len(t, configErr.Problems, 11)
+}
+
+func TestValidate_AcceptsEndpointWithoutPort(t *testing.T) {
+	t.Setenv("DEVELOPER_HOST", "")
+	t.Setenv("STORAGE_ENDPOINT", "minio.internal")
+	t.Setenv("PORT", "8080")
+
+	assert.NoError(t, config.LoadConfigurations().Validate())
+}
+
+func TestValidate_CORSOrigins(t *testing.T) {
+	t.Setenv("DEVELOPER_HOST", "")
+	t.Setenv("CORS_ALLOWED_ORIGINS", "https://app.example.com, http://localhost:3000/")
+	t.Setenv("CORS_ALLOW_CREDENTIALS", "true")
+	assert.NoError(t, config.LoadConfigurations().Validate())
+
+	t.Setenv("CORS_ALLOWED_ORIGINS", "app.example.com,https://app.example.com/ui")
+	err := config.LoadConfigurations().Validate()
+	var configErr *config.ConfigError
+	require.True(t, errors.As(err, &configErr))
+	assert.Len(t, configErr.Problems, 2)
+
+	// Credentials cannot be offered to every origin
+	t.Setenv("CORS_ALLOWED_ORIGINS", "*")
+	err = config.LoadConfigurations().Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "CORS_ALLOW_CREDENTIALS")
+}
+
+func TestLoadConfigurations_ReadsSecretsFromFiles(t *testing.T) {
+	t.Setenv("DEVELOPER_HOST", "")
+	dir := t.TempDir()
+	writeSecret := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GITHUB_TOKEN_FILE", writeSecret("github_token", "ghp_fromfile\n"))
+	t.Setenv("DB_PASSWORD", "")
+	t.Setenv("DB_PASSWORD_FILE", writeSecret("db_password", "s3cret"))
+	t.Setenv("STORAGE_SECRET_KEY", "")
+	t.Setenv("STORAGE_SECRET_KEY_FILE", writeSecret("storage_secret", "minio-secret\r\n"))
+
+	configs := config.LoadConfigurations()
+
+	assert.Equal(t, "ghp_fromfile", configs.GITHUB_TOKEN)
+	assert.Equal(t, "s3cret", configs.DB_PASSWORD)
+	assert.Equal(t, "minio-secret", configs.MINIO_SECRET_KEY)
+	assert.NoError(t, configs.Validate())
+}
+
+func TestLoadConfigurations_EnvironmentSecretOverridesFile(t *testing.T) {
+	t.Setenv("DEVELOPER_HOST", "")
+	path := filepath.Join(t.TempDir(), "github_token")
+	require.NoError(t, os.WriteFile(path, []byte("ghp_fromfile"), 0o600))
+	t.Setenv("GITHUB_TOKEN", "ghp_fromenv")
+	t.Setenv("GITHUB_TOKEN_FILE", path)
+
+	assert.Equal(t, "ghp_fromenv", config.LoadConfigurations().GITHUB_TOKEN)
+}
+
+func TestValidate_ReportsUnreadableSecretFile(t *testing.T) {
+	t.Setenv("DEVELOPER_HOST", "")
+	t.Setenv("DB_PASSWORD", "")
+	t.Setenv("DB_PASSWORD_FILE", filepath.Join(t.TempDir(), "missing"))
+
+	configs := config.LoadConfigurations()
+	assert.Empty(t, configs.DB_PASSWORD)
+
+	err := configs.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "DB_PASSWORD_FILE")
+}
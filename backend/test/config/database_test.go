@@ -0,0 +1,44 @@
+package config_test
+
+import (
+	"context"
+	"elang-backend/internal/config"
+	"elang-backend/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestDatabase_AutoMigrate_RenamesLegacyFindingDependencies(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file:"+uuid.NewString()+"?mode=memory&cache=shared"), &gorm.Config{})
+	require.NoError(t, err)
+	database := &config.Database{Connection: db}
+	require.NoError(t, database.AutoMigrate())
+
+	ctx := context.Background()
+	app := &entity.App{ID: uuid.New(), Name: "legacy-app", Status: "active"}
+	require.NoError(t, db.WithContext(ctx).Create(app).Error)
+	require.NoError(t, db.WithContext(ctx).Create(&entity.Dependency{ID: uuid.New(), Name: "github.com/gin-gonic/gin", Owner: "gin-gonic", Repo: "gin"}).Error)
+	scan := &entity.ScanResult{ID: uuid.New(), AppID: &app.ID, AppName: app.Name, ScanType: "application", Status: "completed"}
+	require.NoError(t, db.WithContext(ctx).Create(scan).Error)
+	finding := func(dependency string) *entity.Finding {
+		return &entity.Finding{ID: uuid.New(), ScanID: scan.ID, AppID: app.ID, Dependency: dependency, Version: "1.9.1",
+			VulnerabilityID: "GO-2023-2001", Severity: "high", ScannedAt: time.Now().UTC()}
+	}
+	legacy, current := finding("github.com/gin-gonic/gin:gin"), finding("github.com/gin-gonic/gin")
+	require.NoError(t, db.WithContext(ctx).Create(legacy).Error)
+	require.NoError(t, db.WithContext(ctx).Create(current).Error)
+
+	// Migrations run on every start, so the rename must be repeatable
+	require.NoError(t, database.AutoMigrate())
+	require.NoError(t, database.AutoMigrate())
+
+	var names []string
+	require.NoError(t, db.Model(&entity.Finding{}).Order("dependency").Pluck("dependency", &names).Error)
+	assert.Equal(t, []string{"github.com/gin-gonic/gin", "github.com/gin-gonic/gin"}, names)
+}
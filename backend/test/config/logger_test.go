@@ -0,0 +1,47 @@
+package config_test
+
+import (
+	"bytes"
+	"elang-backend/internal/config"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLogger_LevelGate(t *testing.T) {
+	var out bytes.Buffer
+	logger, err := config.NewLogger("warn", "json", &out)
+	require.NoError(t, err)
+
+	logger.Debug("scanner progress")
+	logger.Info("server started")
+	assert.Empty(t, out.String(), "records below the level are dropped")
+
+	logger.Warn("osv slow", "dependency", "lodash")
+	var record map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &record))
+	assert.Equal(t, "WARN", record["level"])
+	assert.Equal(t, "lodash", record["dependency"])
+}
+
+func TestNewLogger_TextFormatAtDebug(t *testing.T) {
+	var out bytes.Buffer
+	logger, err := config.NewLogger("DEBUG", "text", &out)
+	require.NoError(t, err)
+
+	logger.Debug("scanner progress", "done", 3)
+	assert.Contains(t, out.String(), `level=DEBUG msg="scanner progress" done=3`)
+}
+
+func TestValidate_RejectsUnknownLogSettings(t *testing.T) {
+	t.Setenv("DEVELOPER_HOST", "")
+	t.Setenv("LOG_LEVEL", "verbose")
+	t.Setenv("LOG_FORMAT", "xml")
+
+	err := config.LoadConfigurations().Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "LOG_LEVEL")
+	assert.Contains(t, err.Error(), "LOG_FORMAT")
+}
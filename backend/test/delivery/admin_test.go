@@ -0,0 +1,74 @@
+package delivery_test
+
+import (
+	"context"
+	delivery "elang-backend/internal/delivery/http"
+	"elang-backend/internal/model"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// countingSeedService counts the seed runs it was asked for
+type countingSeedService struct {
+	runs int
+}
+
+func (s *countingSeedService) Seed(ctx context.Context) (*model.SeedResult, error) {
+	s.runs++
+	return &model.SeedResult{}, nil
+}
+
+func (s *countingSeedService) SeedStatus(ctx context.Context) (*model.SeedStatusResponse, error) {
+	return &model.SeedStatusResponse{}, nil
+}
+
+func setupAdminRouter(seedService *countingSeedService, auth delivery.AuthConfig) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	routes := delivery.RouteConfig{
+		Router:              gin.New(),
+		AppHandler:          *delivery.NewApplicationHandler(&recordingApplicationService{}),
+		DependenciesHandler: *delivery.NewDependenciesHandler(&recordingDependenciesService{}),
+		AdminHandler:        *delivery.NewAdminHandler(seedService),
+		Auth:                auth,
+	}
+	routes.Setup()
+	return routes.Router
+}
+
+func TestAdminRoutes_RequireAdminSubject(t *testing.T) {
+	seedService := &countingSeedService{}
+	router := setupAdminRouter(seedService, delivery.AuthConfig{Secret: testJWTSecret, AdminSubjects: []string{"ops"}})
+
+	seed := func(subject string) int {
+		claims := validClaims()
+		claims["sub"] = subject
+		req := httptest.NewRequest(http.MethodPost, "/api/admin/seed", nil)
+		req.Header.Set("Authorization", "Bearer "+signTestJWT(hs256Header, claims, testJWTSecret))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusForbidden, seed("alice"))
+	assert.Equal(t, 0, seedService.runs)
+	assert.Equal(t, http.StatusOK, seed("ops"))
+	assert.Equal(t, 1, seedService.runs)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/admin/seed/status", nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestAdminRoutes_OpenWithoutAuthentication(t *testing.T) {
+	seedService := &countingSeedService{}
+	router := setupAdminRouter(seedService, delivery.AuthConfig{})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/admin/seed", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 1, seedService.runs)
+}
@@ -0,0 +1,137 @@
+package delivery_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	delivery "elang-backend/internal/delivery/http"
+	"elang-backend/internal/model"
+	"elang-backend/internal/repository"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+const testJWTSecret = "test-secret"
+
+// ownerRecordingApplicationService records the owner scope ListApplications was called with
+type ownerRecordingApplicationService struct {
+	recordingApplicationService
+	owner  string
+	scoped bool
+}
+
+func (s *ownerRecordingApplicationService) ListApplications(ctx context.Context, tags []string) (*model.ListApplicationsResponse, error) {
+	s.owner, s.scoped = repository.OwnerScope(ctx)
+	return &model.ListApplicationsResponse{}, nil
+}
+
+func signTestJWT(header, claims map[string]interface{}, secret string) string {
+	encode := func(v interface{}) string {
+		raw, _ := json.Marshal(v)
+		return base64.RawURLEncoding.EncodeToString(raw)
+	}
+	unsigned := encode(header) + "." + encode(claims)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unsigned))
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func validClaims() map[string]interface{} {
+	return map[string]interface{}{"sub": "alice", "iss": "elang-test", "exp": time.Now().Add(time.Hour).Unix()}
+}
+
+var hs256Header = map[string]interface{}{"alg": "HS256", "typ": "JWT"}
+
+func setupAuthRouter(appService *ownerRecordingApplicationService, auth delivery.AuthConfig) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	routes := delivery.RouteConfig{
+		Router:              gin.New(),
+		AppHandler:          *delivery.NewApplicationHandler(appService),
+		DependenciesHandler: *delivery.NewDependenciesHandler(&recordingDependenciesService{}),
+		Auth:                auth,
+	}
+	routes.Setup()
+	return routes.Router
+}
+
+func getWithToken(router *gin.Engine, path, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestJWTAuth_ScopesRequestsToTokenSubject(t *testing.T) {
+	appService := &ownerRecordingApplicationService{}
+	router := setupAuthRouter(appService, delivery.AuthConfig{Secret: testJWTSecret, Issuer: "elang-test"})
+
+	rec := getWithToken(router, "/api/applications/list", signTestJWT(hs256Header, validClaims(), testJWTSecret))
+
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.True(t, appService.scoped)
+	assert.Equal(t, "alice", appService.owner)
+}
+
+func TestJWTAuth_RejectsInvalidTokens(t *testing.T) {
+	expired := validClaims()
+	expired["exp"] = time.Now().Add(-time.Minute).Unix()
+	wrongIssuer := validClaims()
+	wrongIssuer["iss"] = "someone-else"
+	noSubject := validClaims()
+	delete(noSubject, "sub")
+	noExpiry := validClaims()
+	delete(noExpiry, "exp")
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"missing token", ""},
+		{"malformed token", "not-a-jwt"},
+		{"wrong secret", signTestJWT(hs256Header, validClaims(), "other-secret")},
+		{"alg none", signTestJWT(map[string]interface{}{"alg": "none"}, validClaims(), testJWTSecret)},
+		{"expired", signTestJWT(hs256Header, expired, testJWTSecret)},
+		{"wrong issuer", signTestJWT(hs256Header, wrongIssuer, testJWTSecret)},
+		{"missing subject", signTestJWT(hs256Header, noSubject, testJWTSecret)},
+		{"missing expiry", signTestJWT(hs256Header, noExpiry, testJWTSecret)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appService := &ownerRecordingApplicationService{}
+			router := setupAuthRouter(appService, delivery.AuthConfig{Secret: testJWTSecret, Issuer: "elang-test"})
+
+			rec := getWithToken(router, "/api/applications/list", tt.token)
+
+			assert.Equal(t, http.StatusUnauthorized, rec.Code)
+			assert.False(t, appService.scoped, "handler must not run for rejected tokens")
+		})
+	}
+}
+
+func TestJWTAuth_HealthCheckIsPublic(t *testing.T) {
+	router := setupAuthRouter(&ownerRecordingApplicationService{}, delivery.AuthConfig{Secret: testJWTSecret})
+
+	rec := getWithToken(router, "/health", "")
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestJWTAuth_DisabledWithoutSecret(t *testing.T) {
+	appService := &ownerRecordingApplicationService{}
+	router := setupAuthRouter(appService, delivery.AuthConfig{})
+
+	rec := getWithToken(router, "/api/applications/list", "")
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.False(t, appService.scoped)
+}
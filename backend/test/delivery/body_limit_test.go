@@ -0,0 +1,103 @@
+package delivery_test
+
+import (
+	"bytes"
+	delivery "elang-backend/internal/delivery/http"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMaxBodyBytes = 64 << 10
+
+func setupBodyLimitedRouter(appService *recordingApplicationService, depService *recordingDependenciesService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	routes := delivery.RouteConfig{
+		Router:              gin.New(),
+		AppHandler:          *delivery.NewApplicationHandler(appService),
+		DependenciesHandler: *delivery.NewDependenciesHandler(depService),
+		MaxBodyBytes:        testMaxBodyBytes,
+	}
+	routes.Setup()
+	return routes.Router
+}
+
+// multipartManifest builds a multipart upload of fields plus a go.mod padded to size bytes
+func multipartManifest(t *testing.T, fields map[string]string, size int) (*bytes.Buffer, string) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for name, value := range fields {
+		require.NoError(t, writer.WriteField(name, value))
+	}
+	part, err := writer.CreateFormFile("file", "go.mod")
+	require.NoError(t, err)
+	_, err = part.Write([]byte(goModContent))
+	require.NoError(t, err)
+	if padding := size - len(goModContent); padding > 0 {
+		_, err = part.Write(bytes.Repeat([]byte("\n"), padding))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	return &body, writer.FormDataContentType()
+}
+
+func TestBodyLimit_RejectsOversizedUploads(t *testing.T) {
+	appService, depService := &recordingApplicationService{}, &recordingDependenciesService{}
+	router := setupBodyLimitedRouter(appService, depService)
+
+	tests := []struct {
+		path   string
+		fields map[string]string
+	}{
+		{"/api/applications/add", map[string]string{"app_name": "big", "runtime_type": "go", "framework": "gin"}},
+		{"/api/scan/dependencies", map[string]string{"name": "big", "runtime": "go"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			body, contentType := multipartManifest(t, tt.fields, 2*testMaxBodyBytes)
+			req := httptest.NewRequest(http.MethodPost, tt.path, body)
+			req.Header.Set("Content-Type", contentType)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code, rec.Body.String())
+		})
+	}
+	assert.Empty(t, appService.content, "the handler never ran")
+	assert.Empty(t, depService.content, "the handler never ran")
+}
+
+func TestBodyLimit_CutsOffBodiesOfUnknownLength(t *testing.T) {
+	depService := &recordingDependenciesService{}
+	router := setupBodyLimitedRouter(&recordingApplicationService{}, depService)
+
+	body, contentType := multipartManifest(t, map[string]string{"name": "big", "runtime": "go"}, 2*testMaxBodyBytes)
+	// Hiding the length makes the request look chunked, so only the reader can enforce the limit
+	req := httptest.NewRequest(http.MethodPost, "/api/scan/dependencies", io.MultiReader(body))
+	req.ContentLength = -1
+	req.Header.Set("Content-Type", contentType)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code, rec.Body.String())
+	assert.Empty(t, depService.content)
+}
+
+func TestBodyLimit_AcceptsUploadsWithinLimit(t *testing.T) {
+	depService := &recordingDependenciesService{}
+	router := setupBodyLimitedRouter(&recordingApplicationService{}, depService)
+
+	body, contentType := multipartManifest(t, map[string]string{"name": "small", "runtime": "go"}, 0)
+	req := httptest.NewRequest(http.MethodPost, "/api/scan/dependencies", body)
+	req.Header.Set("Content-Type", contentType)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, goModContent, depService.content)
+}
@@ -0,0 +1,78 @@
+package delivery_test
+
+import (
+	"context"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/model"
+	"elang-backend/internal/services"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// checkingDependenciesService records the CheckDependency request and returns a canned result
+type checkingDependenciesService struct {
+	services.DependenciesInterface
+	req *model.CheckDependencyRequest
+	err error
+}
+
+func (s *checkingDependenciesService) CheckDependency(ctx context.Context, req *model.CheckDependencyRequest) (*helper.DependencyVulnerabilityResult, error) {
+	s.req = req
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &helper.DependencyVulnerabilityResult{
+		IsVulnerable:    true,
+		TotalCount:      1,
+		RiskScore:       7.5,
+		Recommendations: []string{"Upgrade lodash to version 4.17.21 or later to fix all known vulnerabilities."},
+	}, nil
+}
+
+func TestCheckDependency(t *testing.T) {
+	depService := &checkingDependenciesService{}
+	router := setupRouter(&recordingApplicationService{}, depService)
+
+	rec := postJSON(router, "/api/check", map[string]string{
+		"name":    "lodash",
+		"version": "4.17.20",
+		"runtime": "Node.js",
+	})
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.NotNil(t, depService.req)
+	assert.Equal(t, "lodash", depService.req.Name)
+	assert.Equal(t, "Node.js", depService.req.Runtime)
+
+	var body struct {
+		Data helper.DependencyVulnerabilityResult `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.True(t, body.Data.IsVulnerable)
+	assert.Equal(t, 7.5, body.Data.RiskScore)
+	assert.NotEmpty(t, body.Data.Recommendations)
+}
+
+func TestCheckDependency_MissingFields(t *testing.T) {
+	depService := &checkingDependenciesService{}
+	router := setupRouter(&recordingApplicationService{}, depService)
+
+	rec := postJSON(router, "/api/check", map[string]string{"name": "lodash"})
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Nil(t, depService.req, "service must not be called for an invalid body")
+}
+
+func TestCheckDependency_UnsupportedRuntime(t *testing.T) {
+	depService := &checkingDependenciesService{err: fmt.Errorf("runtime Cobol is not supported or disabled: %w", services.ErrInvalidInput)}
+	router := setupRouter(&recordingApplicationService{}, depService)
+
+	rec := postJSON(router, "/api/check", map[string]string{"name": "x", "version": "1.0.0", "runtime": "Cobol"})
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,93 @@
+package delivery_test
+
+import (
+	delivery "elang-backend/internal/delivery/http"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupCORSRouter(cors delivery.CORSConfig) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	routes := delivery.RouteConfig{
+		Router:              gin.New(),
+		AppHandler:          *delivery.NewApplicationHandler(&ownerRecordingApplicationService{}),
+		DependenciesHandler: *delivery.NewDependenciesHandler(&checkingDependenciesService{}),
+		Auth:                delivery.AuthConfig{Secret: testJWTSecret},
+		CORS:                cors,
+	}
+	routes.Setup()
+	return routes.Router
+}
+
+func preflight(router *gin.Engine, origin string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodOptions, "/api/check", nil)
+	req.Header.Set("Origin", origin)
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	req.Header.Set("Access-Control-Request-Headers", "Authorization, Content-Type")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestCORS_PreflightReturnsConfiguredHeaders(t *testing.T) {
+	router := setupCORSRouter(delivery.CORSConfig{
+		AllowedOrigins:   []string{"https://app.example.com"},
+		AllowedMethods:   []string{"GET", "POST"},
+		AllowedHeaders:   []string{"Authorization", "Content-Type"},
+		AllowCredentials: true,
+	})
+
+	// Preflight requests carry no token and are answered before authentication
+	rec := preflight(router, "https://app.example.com")
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "https://app.example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "GET, POST", rec.Header().Get("Access-Control-Allow-Methods"))
+	assert.Equal(t, "Authorization, Content-Type", rec.Header().Get("Access-Control-Allow-Headers"))
+	assert.Equal(t, "true", rec.Header().Get("Access-Control-Allow-Credentials"))
+	assert.Equal(t, "Origin", rec.Header().Get("Vary"))
+
+	// The actual request is marked readable by the origin too
+	req := httptest.NewRequest(http.MethodGet, "/health", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "https://app.example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestCORS_RejectsOtherOrigins(t *testing.T) {
+	router := setupCORSRouter(delivery.CORSConfig{AllowedOrigins: []string{"https://app.example.com"}})
+
+	rec := preflight(router, "https://evil.example.com")
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+
+	req := httptest.NewRequest(http.MethodGet, "/health", nil)
+	req.Header.Set("Origin", "https://evil.example.com")
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestCORS_DefaultAllowsNoOrigin(t *testing.T) {
+	router := setupCORSRouter(delivery.CORSConfig{})
+
+	rec := preflight(router, "https://app.example.com")
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestCORS_WildcardWhenConfigured(t *testing.T) {
+	router := setupCORSRouter(delivery.CORSConfig{AllowedOrigins: []string{"*"}, AllowCredentials: true})
+
+	rec := preflight(router, "https://anywhere.example.com")
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "*", rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Credentials"), "credentials are never offered to every origin")
+	assert.Contains(t, rec.Header().Get("Access-Control-Allow-Methods"), "DELETE")
+}
@@ -0,0 +1,79 @@
+package delivery_test
+
+import (
+	"context"
+	"elang-backend/internal/model"
+	"elang-backend/internal/services"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// findingsExportService streams a fixed list of findings, or fails with err before the first one
+type findingsExportService struct {
+	services.DependenciesInterface
+	findings []model.ScanFinding
+	err      error
+}
+
+func (s *findingsExportService) StreamLatestFindings(ctx context.Context, appUID string, write func(model.ScanFinding) error) error {
+	if s.err != nil {
+		return s.err
+	}
+	for _, finding := range s.findings {
+		if err := write(finding); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func getFindingsCSV(t *testing.T, depService services.DependenciesInterface) *httptest.ResponseRecorder {
+	t.Helper()
+	router := setupRouter(&recordingApplicationService{}, depService)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/applications/0b6f4c7e-0000-4000-8000-000000000001/findings.csv", nil))
+	return rec
+}
+
+func TestExportFindingsCSV(t *testing.T) {
+	rec := getFindingsCSV(t, &findingsExportService{findings: []model.ScanFinding{
+		{Dependency: "lodash", Version: "4.17.20", Severity: "high", VulnerabilityIDs: []string{"GHSA-35jh-r3h4-6jhm", "CVE-2021-23337"},
+			Recommendation: "Upgrade to 4.17.21, which fixes command injection", RecommendedVersion: "4.17.21"},
+		{Dependency: "@babel/traverse", Version: "7.22.0", Severity: "critical", VulnerabilityIDs: []string{"GHSA-67hx-6x53-jw92"}},
+		{Dependency: "=HYPERLINK(\"http://example.com\")", Version: "1.0.0", Severity: "none"},
+	}})
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="findings-0b6f4c7e-0000-4000-8000-000000000001.csv"`, rec.Header().Get("Content-Disposition"))
+
+	records, err := csv.NewReader(strings.NewReader(rec.Body.String())).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"dependency", "version", "severity", "vulnerability_ids", "recommendation", "recommended_version"},
+		{"lodash", "4.17.20", "high", "GHSA-35jh-r3h4-6jhm;CVE-2021-23337", "Upgrade to 4.17.21, which fixes command injection", "4.17.21"},
+		{"'@babel/traverse", "7.22.0", "critical", "GHSA-67hx-6x53-jw92", "", ""},
+		{"'=HYPERLINK(\"http://example.com\")", "1.0.0", "none", "", "", ""},
+	}, records)
+}
+
+func TestExportFindingsCSV_NoFindings(t *testing.T) {
+	rec := getFindingsCSV(t, &findingsExportService{})
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "dependency,version,severity,vulnerability_ids,recommendation,recommended_version\n", rec.Body.String())
+}
+
+func TestExportFindingsCSV_NoCompletedScan(t *testing.T) {
+	rec := getFindingsCSV(t, &findingsExportService{err: fmt.Errorf("application has no completed scan: %w", services.ErrNotFound)})
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Contains(t, rec.Header().Get("Content-Type"), "application/json")
+}
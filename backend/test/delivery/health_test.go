@@ -0,0 +1,44 @@
+package delivery_test
+
+import (
+	delivery "elang-backend/internal/delivery/http"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealth_ReportsDegradedStorage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	for _, tc := range []struct {
+		degraded        bool
+		status, storage string
+	}{
+		{false, "healthy", "available"},
+		{true, "degraded", "degraded"},
+	} {
+		routes := delivery.RouteConfig{
+			Router:              gin.New(),
+			AppHandler:          *delivery.NewApplicationHandler(&ownerRecordingApplicationService{}),
+			DependenciesHandler: *delivery.NewDependenciesHandler(&checkingDependenciesService{}),
+			StorageDegraded:     tc.degraded,
+		}
+		routes.Setup()
+
+		rec := httptest.NewRecorder()
+		routes.Router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+		require.Equal(t, http.StatusOK, rec.Code, "a degraded service keeps serving")
+
+		var body struct {
+			Status     string            `json:"status"`
+			Components map[string]string `json:"components"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, tc.status, body.Status)
+		assert.Equal(t, tc.storage, body.Components["storage"])
+	}
+}
@@ -0,0 +1,101 @@
+package delivery_test
+
+import (
+	"bytes"
+	"context"
+	delivery "elang-backend/internal/delivery/http"
+	"elang-backend/internal/model"
+	"elang-backend/internal/services"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingApplicationService creates a differently named application on every AddApplication call, or
+// fails while err is set
+type countingApplicationService struct {
+	services.ApplicationInterface
+	created int
+	err     error
+}
+
+func (s *countingApplicationService) AddApplication(ctx context.Context, appName, runtimeType, framework, description, fileName, content string, autoScan *bool) (*model.AddApplicationResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.created++
+	return &model.AddApplicationResponse{AppName: fmt.Sprintf("%s-%d", appName, s.created)}, nil
+}
+
+func setupIdempotentRouter(appService *countingApplicationService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	routes := delivery.RouteConfig{
+		Router:              gin.New(),
+		AppHandler:          *delivery.NewApplicationHandler(appService),
+		DependenciesHandler: *delivery.NewDependenciesHandler(&recordingDependenciesService{}),
+		IdempotencyTTL:      time.Hour,
+	}
+	routes.Setup()
+	return routes.Router
+}
+
+func postAddApplication(router *gin.Engine, idempotencyKey string) *httptest.ResponseRecorder {
+	payload, _ := json.Marshal(map[string]string{
+		"app_name":       "ci-app",
+		"runtime":        "go",
+		"framework":      "gin",
+		"file_name":      "go.mod",
+		"content_base64": base64.StdEncoding.EncodeToString([]byte(goModContent)),
+	})
+	req := httptest.NewRequest(http.MethodPost, "/api/applications/add", bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	if idempotencyKey != "" {
+		req.Header.Set(delivery.IdempotencyKeyHeader, idempotencyKey)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestIdempotencyKey_ReplaysFirstResponse(t *testing.T) {
+	appService := &countingApplicationService{}
+	router := setupIdempotentRouter(appService)
+
+	first := postAddApplication(router, "ci-run-42")
+	require.Equal(t, http.StatusOK, first.Code, first.Body.String())
+	retry := postAddApplication(router, "ci-run-42")
+	require.Equal(t, http.StatusOK, retry.Code, retry.Body.String())
+
+	assert.Equal(t, 1, appService.created, "the retry must not create a second application")
+	assert.JSONEq(t, first.Body.String(), retry.Body.String())
+	assert.Equal(t, "true", retry.Header().Get("Idempotent-Replayed"))
+	assert.Empty(t, first.Header().Get("Idempotent-Replayed"))
+
+	other := postAddApplication(router, "ci-run-43")
+	require.Equal(t, http.StatusOK, other.Code)
+	postAddApplication(router, "")
+	assert.Equal(t, 3, appService.created, "other keys and requests without a key run normally")
+}
+
+func TestIdempotencyKey_ServerErrorsAreNotReplayed(t *testing.T) {
+	appService := &countingApplicationService{err: errors.New("database unavailable")}
+	router := setupIdempotentRouter(appService)
+
+	failed := postAddApplication(router, "ci-run-7")
+	require.GreaterOrEqual(t, failed.Code, http.StatusInternalServerError, failed.Body.String())
+
+	appService.err = nil
+	retry := postAddApplication(router, "ci-run-7")
+	require.Equal(t, http.StatusOK, retry.Code, retry.Body.String())
+	assert.Equal(t, 1, appService.created)
+	assert.Empty(t, retry.Header().Get("Idempotent-Replayed"))
+}
@@ -0,0 +1,101 @@
+package delivery_test
+
+import (
+	"bytes"
+	"context"
+	"elang-backend/internal/model"
+	"elang-backend/internal/services"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// diffingDependenciesService records the manifests passed to DiffManifests and returns a canned diff
+type diffingDependenciesService struct {
+	services.DependenciesInterface
+	runtime, oldContent, newContent string
+	err                             error
+}
+
+func (s *diffingDependenciesService) DiffManifests(ctx context.Context, runtime, oldFileName, oldContent, newFileName, newContent string) (*model.ManifestDiffResult, error) {
+	s.runtime, s.oldContent, s.newContent = runtime, oldContent, newContent
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &model.ManifestDiffResult{
+		Runtime:                   runtime,
+		Changed:                   []model.ManifestDependencyChange{{Name: "github.com/google/uuid", OldVersion: "v1.5.0", NewVersion: "v1.6.0"}},
+		NewVulnerabilities:        []model.ManifestVulnerability{{ID: "GO-2099-0001", Dependency: "github.com/google/uuid", Version: "v1.6.0", Severity: "high"}},
+		IntroducesVulnerabilities: true,
+	}, nil
+}
+
+func postManifestDiff(t *testing.T, depService services.DependenciesInterface, fields map[string]string, files map[string]string) *httptest.ResponseRecorder {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for name, value := range fields {
+		require.NoError(t, writer.WriteField(name, value))
+	}
+	for field, content := range files {
+		part, err := writer.CreateFormFile(field, "go.mod")
+		require.NoError(t, err)
+		_, err = part.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/api/manifests/diff", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	rec := httptest.NewRecorder()
+	setupRouter(&recordingApplicationService{}, depService).ServeHTTP(rec, req)
+	return rec
+}
+
+func TestDiffManifests(t *testing.T) {
+	depService := &diffingDependenciesService{}
+	newMod := "module example.com/demo\n\nrequire github.com/google/uuid v1.6.0\n"
+
+	rec := postManifestDiff(t, depService, map[string]string{"runtime": "go"},
+		map[string]string{"old_file": goModContent, "new_file": newMod})
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "go", depService.runtime)
+	assert.Equal(t, goModContent, depService.oldContent)
+	assert.Equal(t, newMod, depService.newContent)
+
+	var body struct {
+		Data model.ManifestDiffResult `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.True(t, body.Data.IntroducesVulnerabilities)
+	require.Len(t, body.Data.NewVulnerabilities, 1)
+	assert.Equal(t, "GO-2099-0001", body.Data.NewVulnerabilities[0].ID)
+}
+
+func TestDiffManifests_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		fields     map[string]string
+		files      map[string]string
+		serviceErr error
+		want       int
+	}{
+		{"missing new file", map[string]string{"runtime": "go"}, map[string]string{"old_file": goModContent}, nil, http.StatusBadRequest},
+		{"missing runtime", nil, map[string]string{"old_file": goModContent, "new_file": goModContent}, nil, http.StatusBadRequest},
+		{"empty file", map[string]string{"runtime": "go"}, map[string]string{"old_file": "", "new_file": goModContent}, nil, http.StatusBadRequest},
+		{"unparsable manifest", map[string]string{"runtime": "go"}, map[string]string{"old_file": goModContent, "new_file": goModContent},
+			fmt.Errorf("failed to parse new manifest go.mod: %w", services.ErrInvalidInput), http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := postManifestDiff(t, &diffingDependenciesService{err: tt.serviceErr}, tt.fields, tt.files)
+			assert.Equal(t, tt.want, rec.Code, rec.Body.String())
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package delivery_test
+
+import (
+	"bytes"
+	"context"
+	delivery "elang-backend/internal/delivery/http"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/model"
+	"elang-backend/internal/model/dto"
+	"elang-backend/internal/services"
+	"encoding/base64"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingApplicationService captures the manifest passed to AddApplication
+type recordingApplicationService struct {
+	services.ApplicationInterface
+	fileName string
+	content  string
+}
+
+func (s *recordingApplicationService) AddApplication(ctx context.Context, appName, runtimeType, framework, description, fileName, content string, autoScan *bool) (*model.AddApplicationResponse, error) {
+	s.fileName, s.content = fileName, content
+	return &model.AddApplicationResponse{AppName: appName, RuntimeType: runtimeType, Framework: framework}, nil
+}
+
+// recordingDependenciesService captures the manifests passed to ScanDependencies and ScanMultipleDependencies
+type recordingDependenciesService struct {
+	services.DependenciesInterface
+	fileName string
+	content  string
+	files    []helper.ManifestFile
+}
+
+func (s *recordingDependenciesService) ScanDependencies(ctx context.Context, appName, runtime, version, description, fileName, content string) (interface{}, error) {
+	s.fileName, s.content = fileName, content
+	return map[string]string{"app_name": appName}, nil
+}
+
+func (s *recordingDependenciesService) ScanMultipleDependencies(ctx context.Context, appName, runtime, version, description string, files []helper.ManifestFile) (interface{}, error) {
+	s.files = files
+	return map[string]string{"app_name": appName}, nil
+}
+
+func setupRouter(appService services.ApplicationInterface, depService services.DependenciesInterface) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	routes := delivery.RouteConfig{
+		Router:              gin.New(),
+		AppHandler:          *delivery.NewApplicationHandler(appService),
+		DependenciesHandler: *delivery.NewDependenciesHandler(depService),
+	}
+	routes.Setup()
+	return routes.Router
+}
+
+func postJSON(router *gin.Engine, path string, body interface{}) *httptest.ResponseRecorder {
+	payload, _ := json.Marshal(body)
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+const goModContent = "module example.com/demo\n\nrequire github.com/google/uuid v1.6.0\n"
+
+func TestAddApplication_JSONBody(t *testing.T) {
+	appService := &recordingApplicationService{}
+	router := setupRouter(appService, &recordingDependenciesService{})
+
+	rec := postJSON(router, "/api/applications/add", map[string]string{
+		"app_name":       "json-app",
+		"runtime":        "go",
+		"framework":      "gin",
+		"file_name":      "go.mod",
+		"content_base64": base64.StdEncoding.EncodeToString([]byte(goModContent)),
+	})
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "go.mod", appService.fileName)
+	assert.Equal(t, goModContent, appService.content)
+}
+
+func TestScanDependencies_JSONBody(t *testing.T) {
+	depService := &recordingDependenciesService{}
+	router := setupRouter(&recordingApplicationService{}, depService)
+
+	rec := postJSON(router, "/api/scan/dependencies", map[string]string{
+		"app_name":       "json-scan",
+		"runtime":        "go",
+		"file_name":      "go.mod",
+		"content_base64": base64.StdEncoding.EncodeToString([]byte(goModContent)),
+	})
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "go.mod", depService.fileName)
+	assert.Equal(t, goModContent, depService.content)
+}
+
+func TestScanDependencies_SeveralManifests(t *testing.T) {
+	webPackage := `{"dependencies": {"lodash": "4.17.20"}}`
+	apiPackage := `{"dependencies": {"lodash": "4.17.21"}}`
+	expected := []helper.ManifestFile{
+		{FileName: "web/package.json", Content: webPackage},
+		{FileName: "api/package.json", Content: apiPackage},
+	}
+
+	t.Run("JSON", func(t *testing.T) {
+		depService := &recordingDependenciesService{}
+		router := setupRouter(&recordingApplicationService{}, depService)
+
+		rec := postJSON(router, "/api/scan/dependencies", map[string]interface{}{
+			"app_name":       "monorepo",
+			"runtime":        "Node.js",
+			"file_name":      "web/package.json",
+			"content_base64": base64.StdEncoding.EncodeToString([]byte(webPackage)),
+			"additional_files": []map[string]string{
+				{"file_name": "api/package.json", "content_base64": base64.StdEncoding.EncodeToString([]byte(apiPackage))},
+			},
+		})
+
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Equal(t, expected, depService.files)
+		assert.Empty(t, depService.fileName, "several manifests are scanned together")
+	})
+
+	t.Run("Multipart", func(t *testing.T) {
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		require.NoError(t, writer.WriteField("name", "monorepo"))
+		require.NoError(t, writer.WriteField("runtime", "Node.js"))
+		for _, file := range expected {
+			part, err := writer.CreateFormFile("file", file.FileName)
+			require.NoError(t, err)
+			_, err = part.Write([]byte(file.Content))
+			require.NoError(t, err)
+		}
+		require.NoError(t, writer.Close())
+
+		depService := &recordingDependenciesService{}
+		req := httptest.NewRequest(http.MethodPost, "/api/scan/dependencies", &body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		rec := httptest.NewRecorder()
+		setupRouter(&recordingApplicationService{}, depService).ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Equal(t, expected, depService.files)
+	})
+}
+
+func TestManifestJSONBody_Validation(t *testing.T) {
+	tests := []struct {
+		name          string
+		contentBase64 string
+		expected      int
+	}{
+		{"invalid base64", "not base64!", http.StatusBadRequest},
+		{"unpadded base64", strings.TrimRight(base64.StdEncoding.EncodeToString([]byte("ab")), "="), http.StatusBadRequest},
+		{"binary content", base64.StdEncoding.EncodeToString([]byte{0xff, 0xfe, 0x00}), http.StatusBadRequest},
+		{"oversized content", base64.StdEncoding.EncodeToString(bytes.Repeat([]byte("a"), 6<<20)), http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			depService := &recordingDependenciesService{}
+			router := setupRouter(&recordingApplicationService{}, depService)
+
+			rec := postJSON(router, "/api/scan/dependencies", map[string]string{
+				"app_name":       "json-scan",
+				"runtime":        "go",
+				"file_name":      "go.mod",
+				"content_base64": tt.contentBase64,
+			})
+
+			assert.Equal(t, tt.expected, rec.Code, rec.Body.String())
+			assert.Empty(t, depService.fileName, "service must not be called for invalid content")
+		})
+	}
+}
+
+func TestScanDependencies_TooManyDependencies(t *testing.T) {
+	depService := services.NewDependenciesService(dto.BasicRepositories{}, *helper.NewDependencyParser(), nil, services.ServiceConfig{MaxDependenciesPerApp: 1})
+	router := setupRouter(&recordingApplicationService{}, depService)
+
+	manifest := goModContent + "require github.com/gin-gonic/gin v1.9.1\n"
+	rec := postJSON(router, "/api/scan/dependencies", map[string]string{
+		"app_name":       "huge-scan",
+		"runtime":        "go",
+		"file_name":      "go.mod",
+		"content_base64": base64.StdEncoding.EncodeToString([]byte(manifest)),
+	})
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code, rec.Body.String())
+	assert.Contains(t, rec.Body.String(), "declares 2 dependencies")
+}
+
+func TestManifestJSONBody_MissingFields(t *testing.T) {
+	appService := &recordingApplicationService{}
+	router := setupRouter(appService, &recordingDependenciesService{})
+
+	rec := postJSON(router, "/api/applications/add", map[string]string{"app_name": "json-app"})
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Empty(t, appService.fileName)
+}
@@ -0,0 +1,106 @@
+package delivery_test
+
+import (
+	delivery "elang-backend/internal/delivery/http"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadOpenAPISpec(t *testing.T) map[string]interface{} {
+	t.Helper()
+	spec, err := delivery.OpenAPISpec()
+	require.NoError(t, err)
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(spec, &doc))
+	return doc
+}
+
+func TestOpenAPI_DocumentsEveryAPIRoute(t *testing.T) {
+	router := setupRouter(&recordingApplicationService{}, &recordingDependenciesService{})
+	paths := loadOpenAPISpec(t)["paths"].(map[string]interface{})
+
+	documented := 0
+	for _, route := range router.Routes() {
+		if !strings.HasPrefix(route.Path, "/api/") {
+			continue
+		}
+		segments := strings.Split(route.Path, "/")
+		for i, segment := range segments {
+			if strings.HasPrefix(segment, ":") {
+				segments[i] = "{" + segment[1:] + "}"
+			}
+		}
+		path := strings.Join(segments, "/")
+
+		item, ok := paths[path].(map[string]interface{})
+		if assert.True(t, ok, "route %s %s is missing from the OpenAPI document", route.Method, route.Path) {
+			assert.Contains(t, item, strings.ToLower(route.Method), "route %s %s is missing from the OpenAPI document", route.Method, route.Path)
+			documented++
+		}
+	}
+	assert.Positive(t, documented)
+
+	operations := 0
+	for _, item := range paths {
+		operations += len(item.(map[string]interface{}))
+	}
+	assert.Equal(t, documented, operations, "the OpenAPI document describes routes that are not registered")
+}
+
+func TestOpenAPI_RequestAndResponseModels(t *testing.T) {
+	doc := loadOpenAPISpec(t)
+	assert.Equal(t, "3.0.3", doc["openapi"])
+
+	schemas := doc["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	for _, name := range []string{"AddApplicationJSONRequest", "AddApplicationResponse", "ScanApplicationResult", "ScanJobStatus", "UpdateApplicationDependencyRequest", "DashboardSummary"} {
+		assert.Contains(t, schemas, name)
+	}
+
+	addRequest := schemas["AddApplicationJSONRequest"].(map[string]interface{})
+	assert.ElementsMatch(t, []interface{}{"app_name", "runtime", "framework", "file_name", "content_base64"}, addRequest["required"])
+
+	scan := doc["paths"].(map[string]interface{})["/api/applications/{app_id}/scan"].(map[string]interface{})["get"].(map[string]interface{})
+	responses := scan["responses"].(map[string]interface{})
+	assert.Contains(t, responses, "200")
+	assert.Contains(t, responses, "202")
+	assert.Contains(t, responses, "429")
+}
+
+func TestOpenAPI_ServedAtDocs(t *testing.T) {
+	router := setupRouter(&recordingApplicationService{}, &recordingDependenciesService{})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs/openapi.json", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	spec, err := delivery.OpenAPISpec()
+	require.NoError(t, err)
+	assert.JSONEq(t, string(spec), rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "swagger-ui")
+	assert.Contains(t, rec.Body.String(), "docs/openapi.json")
+
+	// Swagger UI is loaded from an exact release, never whichever one a floating version tag points at
+	for _, file := range delivery.SwaggerUIAssets {
+		assert.Contains(t, rec.Body.String(), `"`+delivery.SwaggerUIAssetURL(file)+`" crossorigin="anonymous"`)
+	}
+	assert.NotContains(t, rec.Body.String(), "swagger-ui-dist@5/")
+}
+
+func TestOpenAPI_CommittedSpecIsUpToDate(t *testing.T) {
+	committed, err := os.ReadFile(filepath.Join("..", "..", "docs", "openapi.json"))
+	require.NoError(t, err)
+	spec, err := delivery.OpenAPISpec()
+	require.NoError(t, err)
+	assert.Equal(t, string(spec), string(committed), "docs/openapi.json is stale; run `make openapi`")
+}
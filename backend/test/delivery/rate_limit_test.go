@@ -0,0 +1,98 @@
+package delivery_test
+
+import (
+	"bytes"
+	delivery "elang-backend/internal/delivery/http"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+var checkBody = map[string]string{"name": "lodash", "version": "4.17.20", "runtime": "Node.js"}
+
+func setupRateLimitedRouter(rateLimit delivery.RateLimitConfig, auth delivery.AuthConfig) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	routes := delivery.RouteConfig{
+		Router:              gin.New(),
+		AppHandler:          *delivery.NewApplicationHandler(&ownerRecordingApplicationService{}),
+		DependenciesHandler: *delivery.NewDependenciesHandler(&checkingDependenciesService{}),
+		Auth:                auth,
+		RateLimit:           rateLimit,
+	}
+	routes.Setup()
+	return routes.Router
+}
+
+func postCheckFrom(router *gin.Engine, remoteAddr, token string) *httptest.ResponseRecorder {
+	payload, _ := json.Marshal(checkBody)
+	req := httptest.NewRequest(http.MethodPost, "/api/check", bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	req.RemoteAddr = remoteAddr
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRateLimit_RejectsRequestsOverBurst(t *testing.T) {
+	router := setupRateLimitedRouter(delivery.RateLimitConfig{RequestsPerMinute: 6, Burst: 2}, delivery.AuthConfig{})
+
+	assert.Equal(t, http.StatusOK, postCheckFrom(router, "10.0.0.1:1234", "").Code)
+	assert.Equal(t, http.StatusOK, postCheckFrom(router, "10.0.0.1:1234", "").Code)
+
+	rec := postCheckFrom(router, "10.0.0.1:1234", "")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	retryAfter, err := strconv.Atoi(rec.Header().Get("Retry-After"))
+	assert.NoError(t, err)
+	// 6 requests per minute refill one token every 10 seconds
+	assert.InDelta(t, 10, retryAfter, 1)
+
+	// Another client has its own bucket
+	assert.Equal(t, http.StatusOK, postCheckFrom(router, "10.0.0.2:1234", "").Code)
+}
+
+func TestRateLimit_KeysByAuthenticatedUser(t *testing.T) {
+	auth := delivery.AuthConfig{Secret: testJWTSecret}
+	router := setupRateLimitedRouter(delivery.RateLimitConfig{RequestsPerMinute: 60, Burst: 1}, auth)
+
+	alice := signTestJWT(hs256Header, validClaims(), testJWTSecret)
+	bobClaims := validClaims()
+	bobClaims["sub"] = "bob"
+	bob := signTestJWT(hs256Header, bobClaims, testJWTSecret)
+
+	assert.Equal(t, http.StatusOK, postCheckFrom(router, "10.0.0.1:1234", alice).Code)
+	// Same user from a different address shares the bucket
+	assert.Equal(t, http.StatusTooManyRequests, postCheckFrom(router, "10.0.0.2:1234", alice).Code)
+	// Different user behind the same address does not
+	assert.Equal(t, http.StatusOK, postCheckFrom(router, "10.0.0.1:1234", bob).Code)
+}
+
+func TestRateLimit_OnlyAppliesToHeavyEndpoints(t *testing.T) {
+	router := setupRateLimitedRouter(delivery.RateLimitConfig{RequestsPerMinute: 1, Burst: 1}, delivery.AuthConfig{})
+
+	assert.Equal(t, http.StatusOK, postCheckFrom(router, "192.0.2.1:1234", "").Code)
+	assert.Equal(t, http.StatusTooManyRequests, postCheckFrom(router, "192.0.2.1:1234", "").Code)
+
+	for i := 0; i < 3; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/api/applications/list", nil)
+		req.RemoteAddr = "192.0.2.1:1234"
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+}
+
+func TestRateLimit_DisabledByDefault(t *testing.T) {
+	router := setupRateLimitedRouter(delivery.RateLimitConfig{}, delivery.AuthConfig{})
+
+	for i := 0; i < 20; i++ {
+		assert.Equal(t, http.StatusOK, postCheckFrom(router, "10.0.0.1:1234", "").Code)
+	}
+}
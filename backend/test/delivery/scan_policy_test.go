@@ -0,0 +1,100 @@
+package delivery_test
+
+import (
+	"context"
+	"elang-backend/internal/helper"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// policyRecordingService captures the policy a scan would be evaluated against
+type policyRecordingService struct {
+	recordingDependenciesService
+	called   bool
+	failOn   []string
+	minScore float64
+}
+
+func (s *policyRecordingService) ScanDependencies(ctx context.Context, appName, runtime, version, description, fileName, content string) (interface{}, error) {
+	s.called = true
+	s.failOn, s.minScore = helper.ScanPolicyFor(ctx)
+	return map[string]string{"app_name": appName}, nil
+}
+
+func TestScanDependencies_PolicyOverride(t *testing.T) {
+	body := func(extra map[string]interface{}) map[string]interface{} {
+		req := map[string]interface{}{
+			"app_name":       "json-scan",
+			"runtime":        "go",
+			"file_name":      "go.mod",
+			"content_base64": base64.StdEncoding.EncodeToString([]byte(goModContent)),
+		}
+		for k, v := range extra {
+			req[k] = v
+		}
+		return req
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		extra    map[string]interface{}
+		failOn   []string
+		minScore float64
+	}{
+		{"Default", "/api/scan/dependencies", nil, []string{"high", "critical"}, 0},
+		{"Body", "/api/scan/dependencies", map[string]interface{}{"fail_on": "Critical", "min_score": 9.5}, []string{"critical"}, 9.5},
+		{"Query", "/api/scan/dependencies?fail_on=critical,high,medium", nil, []string{"critical", "high", "medium"}, 0},
+		{"BodyBeforeQuery", "/api/scan/dependencies?fail_on=low&min_score=1", map[string]interface{}{"fail_on": "critical"}, []string{"critical"}, 1},
+		{"MinScoreOnly", "/api/scan/dependencies?min_score=7", nil, []string{"high", "critical"}, 7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			depService := &policyRecordingService{}
+			router := setupRouter(&recordingApplicationService{}, depService)
+
+			rec := postJSON(router, tt.path, body(tt.extra))
+
+			require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+			assert.Equal(t, tt.failOn, depService.failOn)
+			assert.Equal(t, tt.minScore, depService.minScore)
+		})
+	}
+
+	invalid := []struct {
+		name  string
+		path  string
+		extra map[string]interface{}
+	}{
+		{"UnknownSeverity", "/api/scan/dependencies", map[string]interface{}{"fail_on": "critical,severe"}},
+		{"MinScoreOutOfRange", "/api/scan/dependencies?min_score=11", nil},
+		{"MinScoreNotANumber", "/api/scan/dependencies?min_score=high", nil},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			depService := &policyRecordingService{}
+			router := setupRouter(&recordingApplicationService{}, depService)
+
+			rec := postJSON(router, tt.path, body(tt.extra))
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+			assert.False(t, depService.called, "an invalid policy must not start a scan")
+		})
+	}
+}
+
+func TestScanApplication_RejectsInvalidPolicy(t *testing.T) {
+	router := setupRouter(&recordingApplicationService{}, &recordingDependenciesService{})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/applications/00000000-0000-0000-0000-000000000001/scan?fail_on=severe", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+	assert.Contains(t, rec.Body.String(), "unknown fail_on severity")
+}
@@ -0,0 +1,130 @@
+package delivery_test
+
+import (
+	"bufio"
+	"context"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/model"
+	"elang-backend/internal/services"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// streamingDependenciesService reports its findings through the context listener like the shared scanner does
+type streamingDependenciesService struct {
+	services.DependenciesInterface
+	findings []model.ScanFinding
+	err      error
+}
+
+func (s *streamingDependenciesService) ScanDependencies(ctx context.Context, appName, runtime, version, description, fileName, content string) (interface{}, error) {
+	for _, finding := range s.findings {
+		helper.NotifyFinding(ctx, finding)
+	}
+	if s.err != nil {
+		return nil, s.err
+	}
+	return model.ScanApplicationResult{AppName: appName, ScanStatus: "completed", Findings: s.findings, DependencyCount: len(s.findings)}, nil
+}
+
+type streamLine struct {
+	Type    string                       `json:"type"`
+	Finding *model.ScanFinding           `json:"finding"`
+	Summary *model.ScanApplicationResult `json:"summary"`
+	Message string                       `json:"message"`
+}
+
+func postScan(t *testing.T, depService services.DependenciesInterface, accept string) *httptest.ResponseRecorder {
+	router := setupRouter(&recordingApplicationService{}, depService)
+	payload, _ := json.Marshal(map[string]string{
+		"app_name":       "stream-app",
+		"runtime":        "go",
+		"file_name":      "go.mod",
+		"content_base64": base64.StdEncoding.EncodeToString([]byte(goModContent)),
+	})
+	req := httptest.NewRequest(http.MethodPost, "/api/scan/dependencies", strings.NewReader(string(payload)))
+	req.Header.Set("Content-Type", "application/json")
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func readStreamLines(t *testing.T, rec *httptest.ResponseRecorder) []streamLine {
+	var lines []streamLine
+	scanner := bufio.NewScanner(rec.Body)
+	for scanner.Scan() {
+		var line streamLine
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line), scanner.Text())
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+var streamFindings = []model.ScanFinding{
+	{Dependency: "github.com/google/uuid", Version: "1.6.0", Severity: "NONE"},
+	{Dependency: "golang.org/x/net", Version: "0.1.0", Severity: "HIGH", VulnerabilityIDs: []string{"GO-2023-1571"}},
+}
+
+func TestScanDependencies_StreamsNDJSON(t *testing.T) {
+	rec := postScan(t, &streamingDependenciesService{findings: streamFindings}, "application/x-ndjson")
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "application/x-ndjson", rec.Header().Get("Content-Type"))
+
+	lines := readStreamLines(t, rec)
+	require.Len(t, lines, 3)
+	assert.Equal(t, "finding", lines[0].Type)
+	assert.Equal(t, "github.com/google/uuid", lines[0].Finding.Dependency)
+	assert.Equal(t, "finding", lines[1].Type)
+	assert.Equal(t, []string{"GO-2023-1571"}, lines[1].Finding.VulnerabilityIDs)
+
+	assert.Equal(t, "summary", lines[2].Type)
+	require.NotNil(t, lines[2].Summary)
+	assert.Equal(t, "stream-app", lines[2].Summary.AppName)
+	assert.Equal(t, 2, lines[2].Summary.DependencyCount)
+	assert.Empty(t, lines[2].Summary.Findings, "findings are not repeated in the summary")
+}
+
+func TestScanDependencies_StreamErrors(t *testing.T) {
+	t.Run("BeforeFirstFinding", func(t *testing.T) {
+		rec := postScan(t, &streamingDependenciesService{err: services.ErrInvalidInput}, "application/x-ndjson")
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Header().Get("Content-Type"), "application/json")
+	})
+
+	t.Run("AfterFindings", func(t *testing.T) {
+		rec := postScan(t, &streamingDependenciesService{findings: streamFindings[:1], err: errors.New("storage unavailable")}, "application/x-ndjson")
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		lines := readStreamLines(t, rec)
+		require.Len(t, lines, 2)
+		assert.Equal(t, "finding", lines[0].Type)
+		assert.Equal(t, "error", lines[1].Type)
+		assert.Contains(t, lines[1].Message, "storage unavailable")
+	})
+}
+
+func TestScanDependencies_DefaultBufferedJSON(t *testing.T) {
+	rec := postScan(t, &streamingDependenciesService{findings: streamFindings}, "")
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Contains(t, rec.Header().Get("Content-Type"), "application/json")
+
+	var body struct {
+		Data model.ScanApplicationResult `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Len(t, body.Data.Findings, 2)
+}
@@ -0,0 +1,185 @@
+package helper_test
+
+import (
+	"elang-backend/internal/helper"
+	"elang-backend/internal/model"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOSVVulnerability_DecodesGHSAFields(t *testing.T) {
+	payload := `{
+		"id": "GHSA-xxxx-yyyy-zzzz",
+		"summary": "XSS in template rendering",
+		"affected": [{
+			"package": {"name": "example", "ecosystem": "npm"},
+			"ranges": [{"type": "SEMVER", "events": [{"introduced": "0"}, {"fixed": "1.4.2"}]}]
+		}],
+		"database_specific": {"cwe_ids": ["CWE-79", "CWE-116"], "severity": "HIGH"}
+	}`
+
+	var vuln helper.OSVVulnerability
+	require.NoError(t, json.Unmarshal([]byte(payload), &vuln))
+
+	assert.Equal(t, []string{"CWE-79", "CWE-116"}, vuln.DatabaseSpecific.CWEIDs)
+	require.Len(t, vuln.Affects, 1)
+	assert.Equal(t, "1.4.2", vuln.Affects[0].Ranges[0].Events[1].Fixed)
+}
+
+func TestRecommendedVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  string
+		vulns    []helper.VulnerabilityInfo
+		expected string
+	}{
+		{
+			name:     "single vulnerability",
+			current:  "1.2.0",
+			vulns:    []helper.VulnerabilityInfo{{PatchedVersions: []string{"1.2.5"}}},
+			expected: "1.2.5",
+		},
+		{
+			name:    "highest fix across vulnerabilities",
+			current: "1.2.0",
+			vulns: []helper.VulnerabilityInfo{
+				{PatchedVersions: []string{"1.2.5"}},
+				{PatchedVersions: []string{"1.10.0"}},
+			},
+			expected: "1.10.0",
+		},
+		{
+			name:     "lowest fix above current version on the same vulnerability",
+			current:  "2.1.0",
+			vulns:    []helper.VulnerabilityInfo{{PatchedVersions: []string{"1.9.3", "2.1.4", "3.0.0"}}},
+			expected: "2.1.4",
+		},
+		{
+			name:     "go style versions",
+			current:  "v0.17.0",
+			vulns:    []helper.VulnerabilityInfo{{PatchedVersions: []string{"v0.23.0"}}},
+			expected: "v0.23.0",
+		},
+		{
+			name:    "vulnerability without fix",
+			current: "1.0.0",
+			vulns: []helper.VulnerabilityInfo{
+				{PatchedVersions: []string{"1.0.1"}},
+				{PatchedVersions: []string{}},
+			},
+			expected: "",
+		},
+		{
+			// 1.5.0 fixes the first vulnerability but the second was introduced in 1.4.0
+			name:    "overlapping ranges skip a fix that is affected by another vulnerability",
+			current: "1.2.0",
+			vulns: []helper.VulnerabilityInfo{
+				{PatchedVersions: []string{"1.5.0"}, AffectedRanges: []helper.VersionRange{{Introduced: "1.0.0", Fixed: "1.5.0"}}},
+				{PatchedVersions: []string{"1.6.2"}, AffectedRanges: []helper.VersionRange{{Introduced: "1.4.0", Fixed: "1.6.2"}}},
+			},
+			expected: "1.6.2",
+		},
+		{
+			// The 1.x fix of the first vulnerability is inside the second one's range; 2.0.3 clears both
+			name:    "backported fixes across release lines",
+			current: "1.8.0",
+			vulns: []helper.VulnerabilityInfo{
+				{PatchedVersions: []string{"1.9.1", "2.0.3"}, AffectedRanges: []helper.VersionRange{
+					{Introduced: "0", Fixed: "1.9.1"},
+					{Introduced: "2.0.0", Fixed: "2.0.3"},
+				}},
+				{PatchedVersions: []string{"2.0.1"}, AffectedRanges: []helper.VersionRange{{Introduced: "1.7.0", Fixed: "2.0.1"}}},
+			},
+			expected: "2.0.3",
+		},
+		{
+			name:    "last affected bound",
+			current: "3.0.0",
+			vulns: []helper.VulnerabilityInfo{
+				{PatchedVersions: []string{"3.1.0"}, AffectedRanges: []helper.VersionRange{{Introduced: "3.0.0", Fixed: "3.1.0"}}},
+				{PatchedVersions: []string{"3.2.0"}, AffectedRanges: []helper.VersionRange{{Introduced: "2.0.0", LastAffected: "3.1.0"}}},
+			},
+			expected: "3.2.0",
+		},
+		{
+			name:    "fix reintroduced later",
+			current: "1.0.0",
+			vulns: []helper.VulnerabilityInfo{
+				{PatchedVersions: []string{"1.1.0", "1.3.0"}, AffectedRanges: []helper.VersionRange{
+					{Introduced: "0", Fixed: "1.1.0"},
+					{Introduced: "1.2.0", Fixed: "1.3.0"},
+				}},
+				{PatchedVersions: []string{"1.2.5"}, AffectedRanges: []helper.VersionRange{{Introduced: "0", Fixed: "1.2.5"}}},
+			},
+			expected: "1.3.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recommended := helper.RecommendedVersion(tt.current, tt.vulns)
+			assert.Equal(t, tt.expected, recommended)
+			// The recommended version must clear every vulnerability
+			for _, vuln := range tt.vulns {
+				for _, r := range vuln.AffectedRanges {
+					if recommended != "" {
+						assert.False(t, r.Contains(recommended), "%s is still in %+v", recommended, r)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestRemediationImpact(t *testing.T) {
+	tests := []struct {
+		current, recommended, expected string
+	}{
+		{"1.2.0", "1.2.5", helper.RemediationPatch},
+		{"v1.2.0", "v1.4.0", helper.RemediationMinor},
+		{"1.2.0", "2.0.1", helper.RemediationMajor},
+		{"4.17", "4.17.21", helper.RemediationPatch},
+		{"2.0.0-rc1", "2.0.0", helper.RemediationPatch},
+		{"1.2.0", "", helper.RemediationUnknown},
+		{"1.2.0", "1.1.0", helper.RemediationUnknown},
+		{"1.2.0.1", "1.2.0.4", helper.RemediationUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.current+"->"+tt.recommended, func(t *testing.T) {
+			assert.Equal(t, tt.expected, helper.RemediationImpact(tt.current, tt.recommended))
+		})
+	}
+}
+
+func TestEvaluatePolicy_UnsupportedEcosystems(t *testing.T) {
+	findings := []model.ScanFinding{
+		{Dependency: "lodash", Version: "4.17.21", Severity: "none"},
+		{Dependency: "payroll-copybooks", Version: "2.1.0", Severity: "none", Error: "no vulnerability database covers runtime 'cobol'", UncheckedReason: helper.UncheckedUnsupportedEcosystem},
+	}
+
+	summary := helper.AggregateVulnerabilitySummary(findings)
+	assert.Equal(t, 1, summary.Unsupported)
+	assert.Zero(t, summary.Unchecked)
+	assert.Equal(t, 1, summary.None, "the unsupported dependency must not count as clean")
+	assert.Equal(t, []model.UncheckedDependency{{
+		Dependency: "payroll-copybooks",
+		Version:    "2.1.0",
+		Reason:     helper.UncheckedUnsupportedEcosystem,
+		Detail:     "no vulnerability database covers runtime 'cobol'",
+	}}, helper.UncheckedDependencies(findings))
+
+	t.Run("AsWarning", func(t *testing.T) {
+		status, reason := helper.EvaluatePolicy(summary, []string{"high"}, helper.PolicyConfig{FailClosed: true})
+		assert.Equal(t, "pass", status)
+		assert.Contains(t, reason, "1 of 2 dependencies use an ecosystem no vulnerability database covers")
+	})
+
+	t.Run("AsUnchecked", func(t *testing.T) {
+		status, reason := helper.EvaluatePolicy(summary, []string{"high"}, helper.PolicyConfig{FailClosed: true, UnsupportedAsUnchecked: true})
+		assert.Equal(t, "fail", status)
+		assert.Equal(t, "1 of 2 dependencies could not be checked for vulnerabilities", reason)
+	})
+}
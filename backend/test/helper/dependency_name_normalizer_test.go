@@ -0,0 +1,72 @@
+package helper_test
+
+import (
+	"elang-backend/internal/helper"
+	"elang-backend/internal/helper/parser"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeName_GoMajorVersionSuffix(t *testing.T) {
+	normalizer := helper.NewDependencyNameNormalizer()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"v1 module unchanged", "github.com/gin-gonic/gin", "github.com/gin-gonic/gin"},
+		// github.com/go-git/go-git/v5 has OSV advisories (e.g. GO-2024-2456) filed under the /v5 path
+		{"v5 module keeps suffix", "github.com/go-git/go-git/v5", "github.com/go-git/go-git/v5"},
+		{"v2 module keeps suffix", "github.com/foo/bar/v2", "github.com/foo/bar/v2"},
+		{"non-github v3 module keeps suffix", "gopkg.in/yaml.v3", "gopkg.in/yaml.v3"},
+		{"prefixed github path keeps suffix", "proxy/github.com/foo/bar/v2/pkg", "github.com/foo/bar/v2"},
+		{"prefixed github path without suffix", "proxy/github.com/foo/bar/pkg", "github.com/foo/bar"},
+		{"surrounding whitespace trimmed", "  github.com/foo/bar/v3 ", "github.com/foo/bar/v3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, normalizer.NormalizeName(tt.input, "Go"))
+		})
+	}
+}
+
+func TestGetCVECompatibleName_GoV2Module(t *testing.T) {
+	normalizer := helper.NewDependencyNameNormalizer()
+	dep := parser.DependencyInfo{Name: "github.com/foo/bar/v2", Version: "v2.1.0", Runtime: "go"}
+
+	assert.Equal(t, "github.com/foo/bar/v2", normalizer.GetCVECompatibleName(dep))
+	assert.NotContains(t, normalizer.GetSuggestedNames(dep), "github.com/foo/bar",
+		"the v0/v1 module path is a different module and must not be queried for a v2 dependency")
+}
+
+func TestNormalizeName_NodeStripsVersion(t *testing.T) {
+	normalizer := helper.NewDependencyNameNormalizer()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"plain name", "express", "express"},
+		{"plain name with version", "express@4.18.2", "express"},
+		{"plain name with range", "lodash@^4.17.0", "lodash"},
+		{"scoped name", "@babel/core", "@babel/core"},
+		{"scoped name with version", "@babel/core@7.22.9", "@babel/core"},
+		{"scoped name with caret range", "@scope/name@^1.0.0", "@scope/name"},
+		{"scoped name with tilde range", "@types/node@~20.4.0", "@types/node"},
+		{"scoped name with tag", "@angular/cli@latest", "@angular/cli"},
+		{"scoped name with empty version", "@scope/name@", "@scope/name"},
+		{"npm alias keeps the alias name", "@scope/name@npm:@other/pkg@2.0.0", "@scope/name"},
+		{"surrounding whitespace trimmed", "  @scope/name@1.2.3 ", "@scope/name"},
+		{"scope without package left alone", "@scope", "@scope"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, normalizer.NormalizeName(tt.input, "node"))
+		})
+	}
+}
@@ -0,0 +1,595 @@
+package helper_test
+
+import (
+	"context"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/helper/parser"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPackageJSON = `{
+  "name": "demo",
+  "dependencies": {
+    "lodash": "^4.17.21",
+    "@acme/internal-utils": "1.0.0",
+    "express": "4.18.2"
+  }
+}`
+
+func TestDependencyParser_EnabledRuntimes(t *testing.T) {
+	dp := helper.NewDependencyParser()
+	require.NoError(t, dp.SetEnabledRuntimes([]string{"Go", "node"}))
+
+	assert.True(t, dp.IsRuntimeEnabled("go"))
+	assert.True(t, dp.IsRuntimeEnabled("Node.js"))
+	assert.False(t, dp.IsRuntimeEnabled("Python"))
+	assert.False(t, dp.IsRuntimeEnabled("cobol"))
+
+	result := dp.ParseDependencyFile("requirements.txt", "requests==2.31.0\n")
+	assert.False(t, result.Success)
+	assert.Contains(t, result.Error, "disabled")
+
+	result = dp.ParseDependencyFile("package.json", testPackageJSON, parser.RuntimeType("Node.js"))
+	assert.True(t, result.Success)
+	assert.Len(t, result.Dependencies, 3)
+}
+
+func TestDependencyParser_EnabledRuntimes_Invalid(t *testing.T) {
+	dp := helper.NewDependencyParser()
+	assert.Error(t, dp.SetEnabledRuntimes([]string{"go", "cobol"}))
+}
+
+func TestDependencyParser_EnabledRuntimes_EmptyAllowsAll(t *testing.T) {
+	dp := helper.NewDependencyParser()
+	require.NoError(t, dp.SetEnabledRuntimes(nil))
+
+	for _, runtime := range []string{"Go", "Node.js", "Python", "Java", "Gradle", "DotNet", "Ruby", "PHP", "Rust"} {
+		assert.True(t, dp.IsRuntimeEnabled(runtime), runtime)
+	}
+}
+
+func TestDependencyParser_Denylist(t *testing.T) {
+	dp := helper.NewDependencyParser()
+	require.NoError(t, dp.SetDenylist([]string{`^@acme/`, `^express$`}))
+
+	result := dp.ParseDependencyFile("package.json", testPackageJSON)
+	require.True(t, result.Success)
+
+	var names []string
+	for _, dep := range result.Dependencies {
+		names = append(names, dep.Name)
+	}
+	assert.Equal(t, []string{"lodash"}, names)
+}
+
+func TestDependencyParser_Denylist_InvalidPattern(t *testing.T) {
+	dp := helper.NewDependencyParser()
+	assert.Error(t, dp.SetDenylist([]string{"(unclosed"}))
+}
+
+func TestDependencyParser_RejectsOversizedContent(t *testing.T) {
+	dp := helper.NewDependencyParser()
+	content := strings.Repeat("x", parser.MaxContentSize+1)
+
+	result := dp.ParseDependencyFile("requirements.txt", content)
+	assert.False(t, result.Success)
+	assert.Contains(t, result.Error, "exceeding")
+}
+
+const testPomXML = `<project>
+  <dependencies>
+    <dependency>
+      <groupId>org.springframework</groupId>
+      <artifactId>spring-core</artifactId>
+      <version>6.1.2</version>
+    </dependency>
+    <dependency>
+      <groupId>javax.servlet</groupId>
+      <artifactId>javax.servlet-api</artifactId>
+      <version>4.0.1</version>
+      <scope>provided</scope>
+    </dependency>
+    <dependency>
+      <groupId>junit</groupId>
+      <artifactId>junit</artifactId>
+      <version>4.13.2</version>
+      <scope>test</scope>
+    </dependency>
+  </dependencies>
+</project>`
+
+func TestDependencyParser_MavenScopes(t *testing.T) {
+	dp := helper.NewDependencyParser()
+
+	result := dp.ParseDependencyFile("pom.xml", testPomXML)
+	require.True(t, result.Success)
+	require.Len(t, result.Dependencies, 3)
+
+	byName := make(map[string]parser.DependencyInfo)
+	for _, dep := range result.Dependencies {
+		byName[dep.Name] = dep
+	}
+	assert.Equal(t, "compile", byName["org.springframework:spring-core"].Scope)
+	assert.False(t, byName["org.springframework:spring-core"].Dev)
+	assert.Equal(t, "provided", byName["javax.servlet:javax.servlet-api"].Scope)
+	assert.False(t, byName["javax.servlet:javax.servlet-api"].Dev)
+	assert.Equal(t, "test", byName["junit:junit"].Scope)
+	assert.True(t, byName["junit:junit"].Dev)
+	assert.Equal(t, "4.13.2", byName["junit:junit"].Version)
+}
+
+func TestDependencyParser_GradleConfigurations(t *testing.T) {
+	dp := helper.NewDependencyParser()
+
+	content := "dependencies {\n" +
+		"    implementation 'com.google.guava:guava:32.1.3-jre'\n" +
+		"    testImplementation 'org.junit.jupiter:junit-jupiter:5.10.1'\n" +
+		"    androidTestImplementation(\"androidx.test:runner:1.5.2\")\n" +
+		"}\n"
+	result := dp.ParseDependencyFile("build.gradle", content)
+	require.True(t, result.Success)
+	require.Len(t, result.Dependencies, 3)
+
+	assert.Equal(t, "implementation", result.Dependencies[0].Scope)
+	assert.False(t, result.Dependencies[0].Dev)
+	assert.Equal(t, "testImplementation", result.Dependencies[1].Scope)
+	assert.True(t, result.Dependencies[1].Dev)
+	assert.Equal(t, "androidTestImplementation", result.Dependencies[2].Scope)
+	assert.True(t, result.Dependencies[2].Dev)
+}
+
+func TestDependencyParser_GradleVariableVersions(t *testing.T) {
+	content := "ext {\n" +
+		"    springVersion = '5.3.31'\n" +
+		"}\n" +
+		"ext.jacksonVersion = \"2.15.3\"\n" +
+		"dependencies {\n" +
+		"    implementation \"org.springframework:spring-core:$springVersion\"\n" +
+		"    implementation(\"com.fasterxml.jackson.core:jackson-databind:${rootProject.ext.jacksonVersion}\")\n" +
+		"    implementation \"com.google.guava:guava:${guavaVersion}-jre\"\n" +
+		"    implementation \"io.netty:netty-all:$nettyVersion\"\n" +
+		"}\n"
+
+	t.Run("FromBuildFile", func(t *testing.T) {
+		result := helper.NewDependencyParser().ParseDependencyFile("build.gradle", content)
+		require.True(t, result.Success)
+		require.Len(t, result.Dependencies, 4)
+		assert.Equal(t, "5.3.31", result.Dependencies[0].Version)
+		assert.Equal(t, "2.15.3", result.Dependencies[1].Version)
+		assert.Equal(t, parser.UnresolvedVersion, result.Dependencies[2].Version)
+		assert.Equal(t, parser.UnresolvedVersion, result.Dependencies[3].Version)
+	})
+
+	t.Run("FromGradleProperties", func(t *testing.T) {
+		properties := "# versions\nguavaVersion=32.1.3\nspringVersion = 6.0.0\n"
+		result := helper.NewDependencyParser().WithGradleProperties(properties).ParseDependencyFile("build.gradle", content)
+		require.True(t, result.Success)
+		require.Len(t, result.Dependencies, 4)
+		assert.Equal(t, "5.3.31", result.Dependencies[0].Version, "the build file's own assignment wins")
+		assert.Equal(t, "32.1.3-jre", result.Dependencies[2].Version)
+		assert.Equal(t, parser.UnresolvedVersion, result.Dependencies[3].Version)
+	})
+}
+
+func TestDependencyParser_NuGetDevelopmentDependencies(t *testing.T) {
+	dp := helper.NewDependencyParser()
+
+	csproj := `<Project Sdk="Microsoft.NET.Sdk">
+  <ItemGroup>
+    <PackageReference Include="Newtonsoft.Json" Version="13.0.3" />
+    <PackageReference Include="StyleCop.Analyzers" Version="1.1.118" PrivateAssets="all" />
+    <PackageReference Include="coverlet.collector" Version="6.0.0">
+      <PrivateAssets>all</PrivateAssets>
+      <IncludeAssets>runtime; build</IncludeAssets>
+    </PackageReference>
+  </ItemGroup>
+</Project>`
+	result := dp.ParseDependencyFile("app.csproj", csproj)
+	require.True(t, result.Success)
+	require.Len(t, result.Dependencies, 3)
+	assert.False(t, result.Dependencies[0].Dev)
+	assert.True(t, result.Dependencies[1].Dev)
+	assert.Equal(t, "coverlet.collector", result.Dependencies[2].Name)
+	assert.Equal(t, "6.0.0", result.Dependencies[2].Version)
+	assert.True(t, result.Dependencies[2].Dev)
+
+	packagesConfig := `<?xml version="1.0" encoding="utf-8"?>
+<packages>
+  <package id="Serilog" version="3.1.1" targetFramework="net48" />
+  <package id="Microsoft.CodeAnalysis.FxCopAnalyzers" version="3.3.2" developmentDependency="true" />
+</packages>`
+	result = dp.ParseDependencyFile("packages.config", packagesConfig)
+	require.True(t, result.Success)
+	require.Len(t, result.Dependencies, 2)
+	assert.False(t, result.Dependencies[0].Dev)
+	assert.True(t, result.Dependencies[1].Dev)
+}
+
+func TestDependencyParser_ExcludeDev(t *testing.T) {
+	dp := helper.NewDependencyParser()
+	dp.SetExcludeDev(true)
+
+	result := dp.ParseDependencyFile("pom.xml", testPomXML)
+	require.True(t, result.Success)
+	require.Len(t, result.Dependencies, 2)
+	for _, dep := range result.Dependencies {
+		assert.NotEqual(t, "junit:junit", dep.Name)
+	}
+
+	result = dp.ParseDependencyFile("package.json", `{"name":"demo","dependencies":{"lodash":"4.17.21"},"devDependencies":{"jest":"29.7.0"}}`)
+	require.True(t, result.Success)
+	require.Len(t, result.Dependencies, 1)
+	assert.Equal(t, "lodash", result.Dependencies[0].Name)
+}
+
+const testGoModWithIndirect = `module example.com/demo
+
+go 1.23
+
+require github.com/gin-gonic/gin v1.10.0
+
+require (
+	github.com/stretchr/testify v1.9.0
+	golang.org/x/crypto v0.26.0 // indirect
+	github.com/bytedance/sonic v1.11.6 // indirect; pinned for arm64
+)
+
+require gopkg.in/yaml.v3 v3.0.1 // indirect
+`
+
+func TestDependencyParser_GoIndirectDependencies(t *testing.T) {
+	dp := helper.NewDependencyParser()
+
+	result := dp.ParseDependencyFile("go.mod", testGoModWithIndirect)
+	require.True(t, result.Success)
+	require.Len(t, result.Dependencies, 2, "indirect requirements are left out by default")
+	for _, dep := range result.Dependencies {
+		assert.False(t, dep.Transitive, dep.Name)
+	}
+
+	dp.SetIncludeGoIndirect(true)
+	result = dp.ParseDependencyFile("go.mod", testGoModWithIndirect)
+	require.True(t, result.Success)
+	transitive := map[string]bool{}
+	for _, dep := range result.Dependencies {
+		transitive[dep.Name+"@"+dep.Version] = dep.Transitive
+	}
+	assert.Equal(t, map[string]bool{
+		"github.com/gin-gonic/gin@v1.10.0":   false,
+		"github.com/stretchr/testify@v1.9.0": false,
+		"golang.org/x/crypto@v0.26.0":        true,
+		"github.com/bytedance/sonic@v1.11.6": true,
+		"gopkg.in/yaml.v3@v3.0.1":            true,
+	}, transitive)
+
+	// A request can turn indirect requirements off again, leaving the configured parser untouched
+	ctx := helper.WithGoIndirectDependencies(context.Background(), false)
+	result = dp.WithGoIndirect(ctx).ParseDependencyFile("go.mod", testGoModWithIndirect)
+	require.True(t, result.Success)
+	assert.Len(t, result.Dependencies, 2)
+	assert.Len(t, dp.WithGoIndirect(context.Background()).ParseDependencyFile("go.mod", testGoModWithIndirect).Dependencies, 5)
+}
+
+const testManualList = `# vendored C libraries
+madler/zlib@v1.3.1
+openssl 3.0.7 debian:12   # system package
+@types/node@20.11.0 npm
+nlohmann/json@9cca280a4d0ccf0c08f47a99aa71d1b0e52f8d03
+libpng 1.6.40 Rocky Linux:9
+`
+
+func TestDependencyParser_ManualList(t *testing.T) {
+	dp := helper.NewDependencyParser()
+
+	result := dp.ParseDependencyFile("vendored.txt", testManualList, parser.RuntimeType("Manual"))
+	require.True(t, result.Success, result.Error)
+	assert.Equal(t, "manual", result.Runtime)
+	require.Len(t, result.Dependencies, 5)
+
+	byName := make(map[string]parser.DependencyInfo)
+	for _, dep := range result.Dependencies {
+		byName[dep.Name] = dep
+	}
+	zlib := byName["madler/zlib"]
+	assert.Equal(t, "v1.3.1", zlib.Version)
+	assert.Equal(t, "madler", zlib.Owner)
+	assert.Equal(t, "zlib", zlib.Repo)
+	assert.True(t, zlib.IsGitHubRepo)
+	assert.Empty(t, zlib.Ecosystem)
+
+	assert.Equal(t, "3.0.7", byName["openssl"].Version)
+	assert.Equal(t, "Debian:12", byName["openssl"].Ecosystem, "ecosystems are rewritten to OSV's spelling")
+	assert.False(t, byName["openssl"].IsGitHubRepo)
+	assert.Equal(t, "20.11.0", byName["@types/node"].Version)
+	assert.Equal(t, "npm", byName["@types/node"].Ecosystem)
+	assert.Equal(t, "Rocky Linux:9", byName["libpng"].Ecosystem)
+	assert.Equal(t, "9cca280a4d0ccf0c08f47a99aa71d1b0e52f8d03", byName["nlohmann/json"].Version)
+}
+
+func TestDependencyParser_ManualList_Invalid(t *testing.T) {
+	dp := helper.NewDependencyParser()
+
+	result := dp.ParseDependencyFile("vendored.txt", "madler/zlib@v1.3.1\nopenssl\n", parser.RuntimeManual)
+	assert.False(t, result.Success)
+	assert.Contains(t, result.Error, "line 2")
+
+	result = dp.ParseDependencyFile("vendored.txt", "openssl 3.0.7 Debain\n", parser.RuntimeManual)
+	assert.False(t, result.Success)
+	assert.Contains(t, result.Error, `unknown OSV ecosystem "Debain"`)
+}
+
+func TestDependencyParser_ParseMultipleDependencyFiles_VersionConflicts(t *testing.T) {
+	files := []helper.ManifestFile{
+		{FileName: "packages/web/package.json", Content: `{"dependencies": {"lodash": "4.17.20", "express": "4.18.2"}}`},
+		{FileName: "packages/api/package.json", Content: `{"dependencies": {"lodash": "4.17.21", "express": "4.18.2"}}`},
+		{FileName: "packages/admin/package.json", Content: `{"dependencies": {"lodash": "4.17.20"}}`},
+	}
+
+	result, err := helper.NewDependencyParser().ParseMultipleDependencyFiles(files, parser.RuntimeNode)
+	require.NoError(t, err)
+
+	// Each distinct version is kept so it is scanned on its own; the shared express version is kept once
+	var versions []string
+	for _, dep := range result.Dependencies {
+		versions = append(versions, dep.Name+"@"+dep.Version)
+	}
+	assert.ElementsMatch(t, []string{"lodash@4.17.20", "lodash@4.17.21", "express@4.18.2"}, versions)
+
+	require.Len(t, result.Conflicts, 1)
+	conflict := result.Conflicts[0]
+	assert.Equal(t, "lodash", conflict.Name)
+	assert.Equal(t, "npm", conflict.Ecosystem)
+	assert.Equal(t, []helper.ConflictingVersion{
+		{Version: "4.17.20", Files: []string{"packages/web/package.json", "packages/admin/package.json"}},
+		{Version: "4.17.21", Files: []string{"packages/api/package.json"}},
+	}, conflict.Versions)
+}
+
+func TestDependencyParser_ParseMultipleDependencyFiles_InvalidManifest(t *testing.T) {
+	files := []helper.ManifestFile{
+		{FileName: "vendor/core/deps.txt", Content: "madler/zlib@v1.3.1\n"},
+		{FileName: "vendor/net/deps.txt", Content: "openssl\n"},
+	}
+
+	_, err := helper.NewDependencyParser().ParseMultipleDependencyFiles(files, parser.RuntimeManual)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "vendor/net/deps.txt")
+}
+
+func TestDependencyParser_DetectRuntime_FromContent(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    parser.RuntimeType
+	}{
+		{"GoModule", "module example.com/demo\n\ngo 1.22\n\nrequire github.com/google/uuid v1.6.0\n", parser.RuntimeGo},
+		{"PackageJSONWithoutName", `{"private": true, "devDependencies": {"eslint": "8.0.0"}}`, parser.RuntimeNode},
+		{"ComposerJSON", `{"require": {"php": ">=8.1", "monolog/monolog": "^3.0"}}`, parser.RuntimePHP},
+		{"ComposerJSONWithoutPHPConstraint", `{"require": {"symfony/console": "^6.4"}}`, parser.RuntimePHP},
+		{"PomXML", "<project>\n  <modelVersion>4.0.0</modelVersion>\n  <artifactId>demo</artifactId>\n</project>", parser.RuntimeJava},
+		{"CsProj", `<Project Sdk="Microsoft.NET.Sdk"><ItemGroup><PackageReference Include="Newtonsoft.Json" Version="13.0.1" /></ItemGroup></Project>`, parser.RuntimeDotNet},
+		{"PackagesConfig", `<?xml version="1.0"?><packages><package id="NUnit" version="3.13.3" /></packages>`, parser.RuntimeDotNet},
+		{"CargoToml", "[package]\nname = \"demo\"\nversion = \"0.1.0\"\n\n[dependencies]\nserde = \"1.0\"\n", parser.RuntimeRust},
+		{"PoetryPyproject", "[tool.poetry]\nname = \"demo\"\n\n[tool.poetry.dependencies]\nrequests = \"^2.31\"\n", parser.RuntimePython},
+		{"PEP621Pyproject", "[project]\nname = \"demo\"\ndependencies = [\"requests>=2.31\"]\n", parser.RuntimePython},
+		{"Pipfile", "[[source]]\nurl = \"https://pypi.org/simple\"\n\n[packages]\nrequests = \"*\"\n", parser.RuntimePython},
+		{"Requirements", "# pinned\nrequests==2.31.0\nflask>=2.0\n", parser.RuntimePython},
+		{"Gemfile", "source \"https://rubygems.org\"\n\ngem \"rails\", \"7.1.0\"\ngem 'puma'\n", parser.RuntimeRuby},
+		{"BuildGradle", "plugins { id 'java' }\n\ndependencies {\n    implementation 'com.google.guava:guava:32.1.2-jre'\n}\n", parser.RuntimeGradle},
+		{"PlainText", "just some notes\n", parser.RuntimeUnknown},
+	}
+
+	dp := helper.NewDependencyParser()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, filename := range []string{"upload", "manifest.txt"} {
+				assert.Equal(t, tt.want, dp.DetectRuntime(filename, tt.content), filename)
+			}
+		})
+	}
+}
+
+func TestDependencyParser_ParsesMislabeledUpload(t *testing.T) {
+	result := helper.NewDependencyParser().ParseDependencyFile("deps", "[package]\nname = \"demo\"\n\n[dependencies]\nserde = \"1.0.190\"\n")
+	require.True(t, result.Success, result.Error)
+	assert.Equal(t, string(parser.RuntimeRust), result.Runtime)
+	require.Len(t, result.Dependencies, 1)
+	assert.Equal(t, "serde", result.Dependencies[0].Name)
+}
+
+const testBOMImportPom = `<project>
+  <properties>
+    <spring-boot.version>3.2.1</spring-boot.version>
+    <guava.version>32.1.3-jre</guava.version>
+  </properties>
+  <dependencyManagement>
+    <dependencies>
+      <dependency>
+        <groupId>org.apache.commons</groupId>
+        <artifactId>commons-lang3</artifactId>
+        <version>3.14.0</version>
+      </dependency>
+      <dependency>
+        <groupId>org.springframework.boot</groupId>
+        <artifactId>spring-boot-dependencies</artifactId>
+        <version>${spring-boot.version}</version>
+        <type>pom</type>
+        <scope>import</scope>
+      </dependency>
+    </dependencies>
+  </dependencyManagement>
+  <dependencies>
+    <dependency>
+      <groupId>org.springframework</groupId>
+      <artifactId>spring-core</artifactId>
+    </dependency>
+    <dependency>
+      <groupId>com.fasterxml.jackson.core</groupId>
+      <artifactId>jackson-databind</artifactId>
+    </dependency>
+    <dependency>
+      <groupId>com.google.guava</groupId>
+      <artifactId>guava</artifactId>
+      <version>${guava.version}</version>
+    </dependency>
+    <dependency>
+      <groupId>org.apache.commons</groupId>
+      <artifactId>commons-lang3</artifactId>
+    </dependency>
+    <dependency>
+      <groupId>org.example</groupId>
+      <artifactId>unmanaged</artifactId>
+    </dependency>
+  </dependencies>
+</project>`
+
+// mavenRepository serves BOMs under their standard repository paths and counts the requests for each. It
+// returns a parser fetching BOMs from it.
+func mavenRepository(t *testing.T, poms map[string]string) (*helper.DependencyParser, map[string]int) {
+	t.Helper()
+	requests := map[string]int{}
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests[r.URL.Path]++
+		mu.Unlock()
+		pom, ok := poms[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(pom))
+	}))
+	t.Cleanup(server.Close)
+	dp := helper.NewDependencyParser()
+	dp.SetMavenRepository(server.URL, nil)
+	return dp, requests
+}
+
+func TestDependencyParser_MavenBOMImports(t *testing.T) {
+	dp, requests := mavenRepository(t, map[string]string{
+		"/org/springframework/boot/spring-boot-dependencies/3.2.1/spring-boot-dependencies-3.2.1.pom": `<project>
+  <groupId>org.springframework.boot</groupId>
+  <artifactId>spring-boot-dependencies</artifactId>
+  <version>3.2.1</version>
+  <properties>
+    <spring-framework.version>6.1.2</spring-framework.version>
+    <jackson-bom.version>2.15.3</jackson-bom.version>
+  </properties>
+  <dependencyManagement>
+    <dependencies>
+      <dependency>
+        <groupId>org.springframework</groupId>
+        <artifactId>spring-core</artifactId>
+        <version>${spring-framework.version}</version>
+      </dependency>
+      <dependency>
+        <groupId>org.apache.commons</groupId>
+        <artifactId>commons-lang3</artifactId>
+        <version>3.13.0</version>
+      </dependency>
+      <dependency>
+        <groupId>com.fasterxml.jackson</groupId>
+        <artifactId>jackson-bom</artifactId>
+        <version>${jackson-bom.version}</version>
+        <type>pom</type>
+        <scope>import</scope>
+      </dependency>
+    </dependencies>
+  </dependencyManagement>
+</project>`,
+		"/com/fasterxml/jackson/jackson-bom/2.15.3/jackson-bom-2.15.3.pom": `<project>
+  <version>2.15.3</version>
+  <dependencyManagement>
+    <dependencies>
+      <dependency>
+        <groupId>com.fasterxml.jackson.core</groupId>
+        <artifactId>jackson-databind</artifactId>
+        <version>${project.version}</version>
+      </dependency>
+    </dependencies>
+  </dependencyManagement>
+</project>`,
+	})
+
+	parse := func() map[string]string {
+		result := dp.ParseDependencyFile("pom.xml", testBOMImportPom)
+		require.True(t, result.Success, result.Error)
+		versions := map[string]string{}
+		for _, dep := range result.Dependencies {
+			versions[dep.Name] = dep.Version
+		}
+		return versions
+	}
+
+	versions := parse()
+	assert.Equal(t, "6.1.2", versions["org.springframework:spring-core"], "managed by the imported BOM")
+	assert.Equal(t, "2.15.3", versions["com.fasterxml.jackson.core:jackson-databind"], "managed by a BOM the imported BOM imports")
+	assert.Equal(t, "32.1.3-jre", versions["com.google.guava:guava"], "property of the pom")
+	assert.Equal(t, "3.14.0", versions["org.apache.commons:commons-lang3"], "the pom's own management wins over the BOM")
+	assert.Equal(t, parser.UnresolvedVersion, versions["org.example:unmanaged"], "managed by no BOM")
+
+	parse()
+	for path, count := range requests {
+		assert.Equal(t, 1, count, "%s is fetched once and then cached", path)
+	}
+
+	t.Run("RequestCancelled", func(t *testing.T) {
+		// The cache belongs to the parser, so a fresh one has to fetch and stops with the request
+		dp, requests := mavenRepository(t, map[string]string{})
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		result := dp.ParseDependencyFileContext(ctx, "pom.xml", testBOMImportPom)
+		require.True(t, result.Success, result.Error)
+		for _, dep := range result.Dependencies {
+			if dep.Name == "org.springframework:spring-core" {
+				assert.Equal(t, parser.UnresolvedVersion, dep.Version)
+			}
+		}
+		assert.Empty(t, requests)
+	})
+}
+
+func TestDependencyParser_MavenBOMUnavailable(t *testing.T) {
+	dp, _ := mavenRepository(t, map[string]string{})
+
+	result := dp.ParseDependencyFile("pom.xml", testBOMImportPom)
+	require.True(t, result.Success, result.Error)
+
+	versions := map[string]string{}
+	for _, dep := range result.Dependencies {
+		versions[dep.Name] = dep.Version
+	}
+	assert.Equal(t, parser.UnresolvedVersion, versions["org.springframework:spring-core"])
+	assert.Equal(t, parser.UnresolvedVersion, versions["com.fasterxml.jackson.core:jackson-databind"])
+	assert.Equal(t, "32.1.3-jre", versions["com.google.guava:guava"])
+	assert.Equal(t, "3.14.0", versions["org.apache.commons:commons-lang3"])
+}
+
+func TestDependencyParser_MavenWithoutBOMKeepsVersionlessDependencies(t *testing.T) {
+	content := `<project>
+  <dependencies>
+    <dependency>
+      <groupId>org.springframework</groupId>
+      <artifactId>spring-core</artifactId>
+    </dependency>
+    <dependency>
+      <groupId>com.google.guava</groupId>
+      <artifactId>guava</artifactId>
+      <version>${guava.version}</version>
+    </dependency>
+  </dependencies>
+</project>`
+	result := helper.NewDependencyParser().ParseDependencyFile("pom.xml", content)
+	require.True(t, result.Success)
+	require.Len(t, result.Dependencies, 2)
+	assert.Equal(t, "", result.Dependencies[0].Version, "a version a parent pom may manage is left to it")
+	assert.Equal(t, parser.UnresolvedVersion, result.Dependencies[1].Version)
+}
@@ -0,0 +1,185 @@
+package helper_test
+
+import (
+	"context"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/helper/parser"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEcosystem(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "npm", want: "npm"},
+		{input: "pypi", want: "PyPI"},
+		{input: " CRATES.IO ", want: "crates.io"},
+		{input: "debian:12", want: "Debian:12"},
+		{input: "Debian:", wantErr: true},
+		{input: "node", wantErr: true},
+		{input: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := helper.ParseEcosystem(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// ecosystemRecordingSource remembers the ecosystem of every dependency it is asked about
+type ecosystemRecordingSource struct {
+	ecosystems []string
+}
+
+func (s *ecosystemRecordingSource) Name() string { return "recording" }
+
+func (s *ecosystemRecordingSource) QueryVulnerabilities(ctx context.Context, dep parser.DependencyInfo) ([]helper.VulnerabilityInfo, error) {
+	s.ecosystems = append(s.ecosystems, helper.EcosystemForDependency(dep))
+	return nil, nil
+}
+
+func TestCVEHelper_EcosystemOverride(t *testing.T) {
+	t.Run("OverridesRuntime", func(t *testing.T) {
+		source := &ecosystemRecordingSource{}
+		dep := parser.DependencyInfo{Name: "eslint", Version: "8.0.0", Runtime: "java", Ecosystem: "npm"}
+
+		result, err := helper.NewCVEHelperWithSources(false, source).CheckDependencyVulnerabilities(context.Background(), dep)
+		require.NoError(t, err)
+		assert.Empty(t, result.Error)
+		assert.Equal(t, []string{"npm"}, source.ecosystems)
+	})
+
+	t.Run("AllowsUnsupportedRuntime", func(t *testing.T) {
+		source := &ecosystemRecordingSource{}
+		dep := parser.DependencyInfo{Name: "openssl", Version: "3.0.0", Runtime: "c", Ecosystem: "ConanCenter"}
+
+		result, err := helper.NewCVEHelperWithSources(false, source).CheckDependencyVulnerabilities(context.Background(), dep)
+		require.NoError(t, err)
+		assert.Empty(t, result.Error)
+		assert.Equal(t, []string{"ConanCenter"}, source.ecosystems)
+	})
+
+	t.Run("DefaultsToRuntime", func(t *testing.T) {
+		assert.Equal(t, "Maven", helper.EcosystemForDependency(parser.DependencyInfo{Runtime: "gradle"}))
+	})
+}
+
+func TestCVEHelper_UncheckedReasons(t *testing.T) {
+	t.Run("RuntimeWithoutEcosystem", func(t *testing.T) {
+		var requests atomic.Int32
+		osv := helper.NewOSVSourceWithClient(osvTestServer(t, &requests))
+		dep := parser.DependencyInfo{Name: "payroll-copybooks", Version: "2.1.0", Runtime: "cobol"}
+
+		result, err := helper.NewCVEHelperWithSources(false, osv).CheckDependencyVulnerabilities(context.Background(), dep)
+		require.NoError(t, err)
+		assert.Equal(t, helper.UncheckedUnsupportedEcosystem, result.UncheckedReason)
+		assert.Contains(t, result.Error, "cobol")
+		assert.Zero(t, requests.Load(), "an unmapped runtime must not reach OSV")
+	})
+
+	t.Run("SourceWithoutEcosystem", func(t *testing.T) {
+		source := &stubSource{name: "osv", err: fmt.Errorf("%w: go", helper.ErrUnsupportedEcosystem)}
+		dep := parser.DependencyInfo{Name: "github.com/google/uuid", Version: "1.6.0", Runtime: "go"}
+
+		result, err := helper.NewCVEHelperWithSources(false, source).CheckDependencyVulnerabilities(context.Background(), dep)
+		require.NoError(t, err)
+		assert.Equal(t, helper.UncheckedUnsupportedEcosystem, result.UncheckedReason)
+	})
+
+	t.Run("MissingVersion", func(t *testing.T) {
+		dep := parser.DependencyInfo{Name: "lodash", Runtime: "node"}
+
+		result, err := helper.NewCVEHelperWithSources(false, &ecosystemRecordingSource{}).CheckDependencyVulnerabilities(context.Background(), dep)
+		require.NoError(t, err)
+		assert.Equal(t, helper.UncheckedInvalidDependency, result.UncheckedReason)
+	})
+
+	t.Run("SourceFailure", func(t *testing.T) {
+		source := &stubSource{name: "osv", err: errors.New("connection refused")}
+
+		result, err := helper.NewCVEHelperWithSources(false, source).CheckDependencyVulnerabilities(context.Background(), lodash)
+		require.NoError(t, err)
+		assert.Equal(t, helper.UncheckedCheckFailed, result.UncheckedReason)
+	})
+}
+
+func TestEcosystemsForRuntime(t *testing.T) {
+	assert.Equal(t, []string{"Maven", "Android"}, helper.EcosystemsForRuntime("Gradle"))
+	assert.Equal(t, "Maven", helper.EcosystemForRuntime("gradle"), "the first candidate is the primary ecosystem")
+	assert.Equal(t, []string{"npm"}, helper.EcosystemsForDependency(parser.DependencyInfo{Runtime: "gradle", Ecosystem: "npm"}),
+		"an override replaces the candidates")
+	assert.Nil(t, helper.EcosystemsForRuntime("cobol"))
+}
+
+// ecosystemOSVServer answers OSV queries with one vulnerability for the ecosystems in vulnerable and none
+// otherwise, recording the ecosystem of every query
+func ecosystemOSVServer(t *testing.T, queried *[]string, vulnerable ...string) *http.Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var query helper.OSVQuery
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&query))
+		*queried = append(*queried, query.Package.Ecosystem)
+		for _, ecosystem := range vulnerable {
+			if ecosystem == query.Package.Ecosystem {
+				_, _ = w.Write([]byte(`{"vulns": [{"id": "GHSA-` + ecosystem + `"}]}`))
+				return
+			}
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+	target, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	return &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		req = req.Clone(req.Context())
+		req.URL.Scheme, req.URL.Host = target.Scheme, target.Host
+		return http.DefaultTransport.RoundTrip(req)
+	})}
+}
+
+func TestOSVSource_FallsBackAcrossEcosystems(t *testing.T) {
+	dep := parser.DependencyInfo{Name: "androidx.core:core", Version: "1.9.0", Runtime: "gradle"}
+
+	t.Run("TriesNextEcosystemWithoutResults", func(t *testing.T) {
+		var queried []string
+		vulns, err := helper.NewOSVSourceWithClient(ecosystemOSVServer(t, &queried, "Android")).QueryVulnerabilities(context.Background(), dep)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Maven", "Android"}, queried)
+		require.Len(t, vulns, 1)
+		assert.Equal(t, "GHSA-Android", vulns[0].ID)
+	})
+
+	t.Run("StopsAtFirstHit", func(t *testing.T) {
+		var queried []string
+		vulns, err := helper.NewOSVSourceWithClient(ecosystemOSVServer(t, &queried, "Maven", "Android")).QueryVulnerabilities(context.Background(), dep)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Maven"}, queried)
+		require.Len(t, vulns, 1)
+		assert.Equal(t, "GHSA-Maven", vulns[0].ID)
+	})
+
+	t.Run("CleanInEveryEcosystem", func(t *testing.T) {
+		var queried []string
+		vulns, err := helper.NewOSVSourceWithClient(ecosystemOSVServer(t, &queried)).QueryVulnerabilities(context.Background(), dep)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Maven", "Android"}, queried)
+		assert.Empty(t, vulns)
+	})
+}
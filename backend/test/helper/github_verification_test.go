@@ -0,0 +1,69 @@
+package helper_test
+
+import (
+	"context"
+	"elang-backend/internal/helper"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// slowGitHubAPI answers every repository lookup after a fixed latency, like a remote GitHub, and counts them
+type slowGitHubAPI struct {
+	latency time.Duration
+	calls   atomic.Int64
+}
+
+func (s *slowGitHubAPI) GetDefaultBranch(owner, repo string) (string, error) {
+	s.calls.Add(1)
+	time.Sleep(s.latency)
+	return "main", nil
+}
+
+func (s *slowGitHubAPI) GetRepositoryInfo(owner, repo string) (map[string]interface{}, error) {
+	s.calls.Add(1)
+	time.Sleep(s.latency)
+	return map[string]interface{}{}, nil
+}
+
+func TestDependencyParser_ParseWithGitHubVerifiesOnlyWhenAsked(t *testing.T) {
+	github := &slowGitHubAPI{}
+	dp := helper.NewDependencyParserWithGitHub(github)
+	content := largeGoMod(10)
+
+	result := dp.ParseDependencyFileWithGitHub(context.Background(), "go.mod", content, false)
+	require.True(t, result.Success)
+	assert.Zero(t, github.calls.Load(), "repositories are not looked up without verification")
+	for _, dep := range result.Dependencies {
+		assert.NotEmpty(t, dep.GitHubURL, dep.Name)
+		assert.True(t, dep.IsGitHubRepo, dep.Name)
+	}
+
+	result = dp.ParseDependencyFileWithGitHub(context.Background(), "go.mod", content, true)
+	require.True(t, result.Success)
+	assert.Equal(t, int64(len(result.Dependencies)), github.calls.Load())
+}
+
+// BenchmarkDependencyParser_GitHubVerification compares parsing a 200-module go.mod with and without
+// verifying each repository against a GitHub answering in 1ms, the inline work AddApplication no longer does
+func BenchmarkDependencyParser_GitHubVerification(b *testing.B) {
+	content := largeGoMod(200)
+	for _, verify := range []bool{false, true} {
+		name := "skip"
+		if verify {
+			name = "verify"
+		}
+		b.Run(name, func(b *testing.B) {
+			dp := helper.NewDependencyParserWithGitHub(&slowGitHubAPI{latency: time.Millisecond})
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if result := dp.ParseDependencyFileWithGitHub(context.Background(), "go.mod", content, verify); !result.Success {
+					b.Fatal(result.Error)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package helper_test
+
+import (
+	"elang-backend/internal/helper"
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeJSONColumn(t *testing.T) {
+	encoded, err := helper.EncodeJSONColumn(map[string]int{"count": 2})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"count":2}`, string(encoded))
+
+	encoded, err = helper.EncodeJSONColumn(nil)
+	require.NoError(t, err)
+	assert.Nil(t, encoded)
+
+	// A value that cannot be marshalled leaves a placeholder rather than an empty column
+	encoded, err = helper.EncodeJSONColumn(map[string]float64{"score": math.NaN()})
+	require.Error(t, err)
+	var placeholder map[string]string
+	require.NoError(t, json.Unmarshal(encoded, &placeholder))
+	assert.Contains(t, placeholder["marshal_error"], "NaN")
+}
+
+func TestDecodeJSONColumn(t *testing.T) {
+	assert.Nil(t, helper.DecodeJSONColumn(nil))
+	assert.Equal(t, json.RawMessage(`{"a":1}`), helper.DecodeJSONColumn([]byte(`{"a":1}`)))
+
+	var marker map[string]string
+	require.NoError(t, json.Unmarshal(helper.DecodeJSONColumn([]byte(`{"a":`)), &marker))
+	assert.Equal(t, `{"a":`, marker["raw"])
+	assert.NotEmpty(t, marker["decode_error"])
+}
@@ -0,0 +1,140 @@
+package helper_test
+
+import (
+	"elang-backend/internal/helper/parser"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+const largeManifestEntries = 20000
+
+// largeGoMod builds a go.mod with a require block of n modules plus a few single-line requires
+func largeGoMod(n int) string {
+	var sb strings.Builder
+	sb.WriteString("module example.com/large\n\ngo 1.22\n\nrequire (\n")
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&sb, "\tgithub.com/owner%d/repo%d v1.%d.0\n", i, i, i%100)
+		if i%10 == 0 {
+			fmt.Fprintf(&sb, "\tgithub.com/indirect%d/repo v0.1.0 // indirect\n", i)
+		}
+	}
+	sb.WriteString(")\n\nrequire github.com/single/line v2.0.0\n")
+	return sb.String()
+}
+
+// largeGoSum builds a go.sum with two hash lines per module
+func largeGoSum(n int) string {
+	var sb strings.Builder
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&sb, "github.com/owner%d/repo%d v1.%d.0 h1:abcdefghijklmnopqrstuvwxyz0123456789ABCDEFG=\n", i, i, i%100)
+		fmt.Fprintf(&sb, "github.com/owner%d/repo%d v1.%d.0/go.mod h1:abcdefghijklmnopqrstuvwxyz0123456789ABCDEFG=\n", i, i, i%100)
+	}
+	return sb.String()
+}
+
+// largeRequirements builds a requirements.txt mixing pinned, ranged and bare requirements
+func largeRequirements(n int) string {
+	var sb strings.Builder
+	sb.WriteString("# generated\n-r base.txt\n")
+	for i := 0; i < n; i++ {
+		switch i % 3 {
+		case 0:
+			fmt.Fprintf(&sb, "package-%d==1.%d.0\n", i, i%50)
+		case 1:
+			fmt.Fprintf(&sb, "package-%d>=2.0,<3.0 ; python_version >= \"3.8\"\n", i)
+		default:
+			fmt.Fprintf(&sb, "package-%d\n", i)
+		}
+	}
+	return sb.String()
+}
+
+// largeGradle builds a build.gradle using every supported dependency notation
+func largeGradle(n int) string {
+	var sb strings.Builder
+	sb.WriteString("plugins {\n    id 'java'\n}\n\ndependencies {\n")
+	for i := 0; i < n; i++ {
+		switch i % 5 {
+		case 0:
+			fmt.Fprintf(&sb, "    implementation 'com.example%d:lib%d:1.%d.0'\n", i, i, i%20)
+		case 1:
+			fmt.Fprintf(&sb, "    testImplementation(\"org.test%d:junit%d:5.%d.0\")\n", i, i, i%20)
+		case 2:
+			fmt.Fprintf(&sb, "    implementation platform('com.bom%d:bom%d:3.0.0')\n", i, i)
+		case 3:
+			fmt.Fprintf(&sb, "    api \"io.var%d:core%d:$coreVersion\"\n", i, i)
+		default:
+			fmt.Fprintf(&sb, "    implementation project(':module%d')\n", i)
+		}
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+func TestGoParser_LargeManifest(t *testing.T) {
+	deps, err := parser.NewGoParser().Parse(largeGoMod(largeManifestEntries))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Indirect requirements inside the block are skipped; the single-line require is kept
+	if len(deps) != largeManifestEntries+1 {
+		t.Fatalf("expected %d dependencies, got %d", largeManifestEntries+1, len(deps))
+	}
+	if deps[len(deps)-1].Name != "github.com/single/line" || deps[len(deps)-1].Version != "v2.0.0" {
+		t.Fatalf("unexpected single-line require: %+v", deps[len(deps)-1])
+	}
+}
+
+func TestPythonParser_LargeManifest(t *testing.T) {
+	deps, err := parser.NewPythonParser().Parse(largeRequirements(largeManifestEntries))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deps) != largeManifestEntries {
+		t.Fatalf("expected %d dependencies, got %d", largeManifestEntries, len(deps))
+	}
+	if deps[1].Name != "package-1" || deps[1].Version != "2.0" {
+		t.Fatalf("unexpected ranged requirement: %+v", deps[1])
+	}
+}
+
+func TestGradleParser_LargeManifest(t *testing.T) {
+	deps, err := parser.NewGradleParser().Parse(largeGradle(largeManifestEntries))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deps) < largeManifestEntries {
+		t.Fatalf("expected at least %d dependencies, got %d", largeManifestEntries, len(deps))
+	}
+}
+
+func BenchmarkGoParser_LargeGoMod(b *testing.B) {
+	benchmarkParse(b, parser.NewGoParser(), largeGoMod(largeManifestEntries))
+}
+
+func BenchmarkGoParser_LargeGoSum(b *testing.B) {
+	benchmarkParse(b, parser.NewGoParser(), largeGoSum(largeManifestEntries))
+}
+
+func BenchmarkPythonParser_LargeRequirements(b *testing.B) {
+	benchmarkParse(b, parser.NewPythonParser(), largeRequirements(largeManifestEntries))
+}
+
+func BenchmarkGradleParser_LargeBuildFile(b *testing.B) {
+	benchmarkParse(b, parser.NewGradleParser(), largeGradle(largeManifestEntries))
+}
+
+// benchmarkParse parses the content from parallel goroutines, as concurrent uploads would
+func benchmarkParse(b *testing.B, p parser.RuntimeParser, content string) {
+	b.SetBytes(int64(len(content)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := p.Parse(content); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,41 @@
+package helper_test
+
+import (
+	"elang-backend/internal/helper"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssessMaintenanceRisk(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) *time.Time {
+		at := now.AddDate(0, 0, -days)
+		return &at
+	}
+	yes, no := true, false
+	one, five := 1, 5
+
+	tests := []struct {
+		name    string
+		signals helper.MaintenanceSignals
+		risk    string
+		reasons int
+	}{
+		{"never fetched", helper.MaintenanceSignals{}, helper.MaintenanceRiskUnknown, 0},
+		{"active", helper.MaintenanceSignals{Archived: &no, LastCommitAt: daysAgo(10), Collaborators: &five}, helper.MaintenanceRiskLow, 0},
+		{"archived", helper.MaintenanceSignals{Archived: &yes, LastCommitAt: daysAgo(10)}, helper.MaintenanceRiskHigh, 1},
+		{"inactive", helper.MaintenanceSignals{Archived: &no, LastCommitAt: daysAgo(200)}, helper.MaintenanceRiskMedium, 1},
+		{"abandoned", helper.MaintenanceSignals{Archived: &no, LastCommitAt: daysAgo(400)}, helper.MaintenanceRiskHigh, 1},
+		{"single collaborator", helper.MaintenanceSignals{Archived: &no, LastCommitAt: daysAgo(10), Collaborators: &one}, helper.MaintenanceRiskMedium, 1},
+		{"medium signals do not lower high", helper.MaintenanceSignals{Archived: &yes, LastCommitAt: daysAgo(200), Collaborators: &one}, helper.MaintenanceRiskHigh, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			risk, reasons := helper.AssessMaintenanceRisk(tt.signals, now)
+			assert.Equal(t, tt.risk, risk)
+			assert.Len(t, reasons, tt.reasons)
+		})
+	}
+}
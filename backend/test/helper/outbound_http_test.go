@@ -0,0 +1,111 @@
+package helper_test
+
+import (
+	"context"
+	"elang-backend/internal/helper"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingProxy refuses every request but remembers which hosts clients tried to reach through it
+type recordingProxy struct {
+	mu    sync.Mutex
+	hosts []string
+}
+
+func (p *recordingProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.mu.Lock()
+	p.hosts = append(p.hosts, r.Host)
+	p.mu.Unlock()
+	http.Error(w, "egress denied", http.StatusForbidden)
+}
+
+func (p *recordingProxy) Hosts() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.hosts...)
+}
+
+func TestOutboundHTTP_CVEHelperUsesInjectedProxy(t *testing.T) {
+	proxy := &recordingProxy{}
+	proxyServer := httptest.NewServer(proxy)
+	defer proxyServer.Close()
+	proxyURL, err := url.Parse(proxyServer.URL)
+	require.NoError(t, err)
+
+	var proxied []string
+	transport, err := helper.NewOutboundTransport(helper.OutboundHTTPConfig{
+		Proxy: func(r *http.Request) (*url.URL, error) {
+			proxied = append(proxied, r.URL.Host)
+			return proxyURL, nil
+		},
+	})
+	require.NoError(t, err)
+
+	cve := helper.NewCVEHelperWithSources(false, helper.NewOSVSourceWithClient(&http.Client{Transport: transport}))
+	_, _ = cve.CheckDependencyVulnerabilities(context.Background(), helper.DependencyInfo{Name: "lodash", Version: "4.17.20", Runtime: "node"})
+
+	assert.Contains(t, proxied, "api.osv.dev")
+	assert.Contains(t, proxy.Hosts(), "api.osv.dev:443")
+}
+
+func TestOutboundHTTP_InvalidProxyURL(t *testing.T) {
+	for _, proxyURL := range []string{"ftp://proxy:21", "http://", "://bad"} {
+		_, err := helper.NewOutboundTransport(helper.OutboundHTTPConfig{ProxyURL: proxyURL})
+		assert.Error(t, err, proxyURL)
+	}
+
+	transport, err := helper.NewOutboundTransport(helper.OutboundHTTPConfig{ProxyURL: "http://proxy.corp:3128"})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodGet, "https://api.github.com/repos/a/b", nil)
+	proxyURL, err := transport.Proxy(req)
+	require.NoError(t, err)
+	assert.Equal(t, "proxy.corp:3128", proxyURL.Host)
+}
+
+func TestOutboundHTTP_CABundle(t *testing.T) {
+	// Stands in for an intercepting proxy whose certificate is signed by a private CA
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	bundle := filepath.Join(t.TempDir(), "corp-ca.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	require.NoError(t, os.WriteFile(bundle, certPEM, 0o600))
+
+	t.Run("Trusted", func(t *testing.T) {
+		transport, err := helper.NewOutboundTransport(helper.OutboundHTTPConfig{CABundleFile: bundle})
+		require.NoError(t, err)
+		resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+
+	t.Run("UntrustedWithoutBundle", func(t *testing.T) {
+		transport, err := helper.NewOutboundTransport(helper.OutboundHTTPConfig{})
+		require.NoError(t, err)
+		_, err = (&http.Client{Transport: transport}).Get(server.URL)
+		assert.Error(t, err)
+	})
+
+	t.Run("InvalidBundle", func(t *testing.T) {
+		empty := filepath.Join(t.TempDir(), "empty.pem")
+		require.NoError(t, os.WriteFile(empty, []byte("not a certificate"), 0o600))
+		_, err := helper.NewOutboundTransport(helper.OutboundHTTPConfig{CABundleFile: empty})
+		assert.Error(t, err)
+
+		_, err = helper.NewOutboundTransport(helper.OutboundHTTPConfig{CABundleFile: filepath.Join(t.TempDir(), "missing.pem")})
+		assert.Error(t, err)
+	})
+}
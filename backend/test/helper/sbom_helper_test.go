@@ -0,0 +1,186 @@
+package helper_test
+
+import (
+	"elang-backend/internal/helper"
+	"encoding/json"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// purlPattern is the general purl shape: pkg:type/[namespace/]name[@version]
+var purlPattern = regexp.MustCompile(`^pkg:[a-z][a-z0-9.+-]*/([^/@?#]+/)*[^/@?#]+(@[^?#]+)?$`)
+
+func TestGeneratePurl(t *testing.T) {
+	tests := []struct {
+		name     string
+		runtime  string
+		owner    string
+		repo     string
+		depName  string
+		version  string
+		expected string
+	}{
+		{"go module path", "go", "gin-gonic", "gin", "github.com/gin-gonic/gin", "v1.9.1", "pkg:golang/github.com/gin-gonic/gin@v1.9.1"},
+		{"go major version module", "Go", "go-git", "go-git", "github.com/go-git/go-git/v5", "v5.11.0", "pkg:golang/github.com/go-git/go-git/v5@v5.11.0"},
+		{"go short name falls back to github path", "go", "foo", "bar", "bar", "v1.0.0", "pkg:golang/github.com/foo/bar@v1.0.0"},
+		{"npm unscoped ignores github owner", "node", "lodash", "lodash", "lodash", "4.17.21", "pkg:npm/lodash@4.17.21"},
+		{"npm scoped is encoded", "Node.js", "babel", "babel", "@babel/core", "7.23.0", "pkg:npm/%40babel/core@7.23.0"},
+		{"pypi normalized", "python", "", "", "Django_Rest", "3.14.0", "pkg:pypi/django-rest@3.14.0"},
+		{"maven coordinates", "java", "spring-projects", "spring-framework", "org.springframework:spring-core", "5.3.0", "pkg:maven/org.springframework/spring-core@5.3.0"},
+		{"gradle maps to maven", "gradle", "", "", "com.google.guava:guava", "32.1.0-jre", "pkg:maven/com.google.guava/guava@32.1.0-jre"},
+		{"composer vendor package", "php", "", "", "Monolog/Monolog", "3.5.0", "pkg:composer/monolog/monolog@3.5.0"},
+		{"cargo is not golang", "cargo", "", "", "serde", "1.0.193", "pkg:cargo/serde@1.0.193"},
+		{"rubygems", "ruby", "", "", "rails", "7.1.2", "pkg:gem/rails@7.1.2"},
+		{"nuget", "dotnet", "", "", "Newtonsoft.Json", "13.0.3", "pkg:nuget/Newtonsoft.Json@13.0.3"},
+		{"unknown runtime with repo", "cpp", "Foo", "Bar", "bar", "1.0", "pkg:github/foo/bar@1.0"},
+		{"unknown runtime without repo", "cpp", "", "", "bar", "1.0", "pkg:generic/bar@1.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			purl := helper.GeneratePurl(tt.runtime, tt.owner, tt.repo, tt.depName, tt.version)
+			assert.Equal(t, tt.expected, purl)
+			assert.Regexp(t, purlPattern, purl)
+		})
+	}
+}
+
+func TestGenerateEnhancedCycloneDXSBOM_PopulatesCwes(t *testing.T) {
+	data := helper.EnhancedSBOMData{
+		AppName: "demo",
+		Dependencies: []helper.DependencyWithVulnerabilities{{
+			Name:    "lodash",
+			Version: "4.17.20",
+			Runtime: "node",
+			Vulnerabilities: []helper.VulnerabilityInfo{{
+				ID:              "GHSA-35jh-r3h4-6jhm",
+				CVE:             "CVE-2021-23337",
+				PatchedVersions: []string{"4.17.21"},
+				Cwes:            []string{"CWE-77", "CWE-94", "not-a-cwe"},
+			}},
+		}},
+	}
+
+	sbomBytes, err := helper.GenerateEnhancedCycloneDXSBOM(data)
+	assert.NoError(t, err)
+
+	var bom helper.CycloneDXSBOM
+	assert.NoError(t, json.Unmarshal(sbomBytes, &bom))
+	if assert.Len(t, bom.Vulnerabilities, 1) {
+		assert.Equal(t, []int{77, 94}, bom.Vulnerabilities[0].Cwes)
+	}
+}
+
+func deterministicSBOMInput(deps []helper.DependencyWithVulnerabilities) helper.EnhancedSBOMData {
+	return helper.EnhancedSBOMData{
+		AppID:         "7d0e3b0c-1f7e-4a55-9f1a-2d7c4b1e9a10",
+		AppName:       "demo",
+		Runtime:       "Node.js",
+		Dependencies:  deps,
+		ScanTimestamp: time.Date(2025, 10, 16, 10, 0, 0, 0, time.UTC),
+		Deterministic: true,
+	}
+}
+
+func TestGenerateEnhancedCycloneDXSBOM_DeterministicIsByteIdentical(t *testing.T) {
+	deps := []helper.DependencyWithVulnerabilities{
+		{Name: "lodash", Version: "4.17.20", Runtime: "node", Vulnerabilities: []helper.VulnerabilityInfo{{ID: "GHSA-35jh-r3h4-6jhm", CVE: "CVE-2021-23337"}}},
+		{Name: "express", Version: "4.18.2", Runtime: "node"},
+		{Name: "axios", Version: "0.21.0", Runtime: "node", Vulnerabilities: []helper.VulnerabilityInfo{{ID: "GHSA-4w2v-q235-vp99", CVE: "CVE-2020-28168"}}},
+	}
+	// Same dependencies in a different order
+	reordered := []helper.DependencyWithVulnerabilities{deps[2], deps[0], deps[1]}
+
+	first, err := helper.GenerateEnhancedCycloneDXSBOM(deterministicSBOMInput(deps))
+	require.NoError(t, err)
+	second, err := helper.GenerateEnhancedCycloneDXSBOM(deterministicSBOMInput(deps))
+	require.NoError(t, err)
+	third, err := helper.GenerateEnhancedCycloneDXSBOM(deterministicSBOMInput(reordered))
+	require.NoError(t, err)
+
+	assert.Equal(t, string(first), string(second))
+	assert.Equal(t, string(first), string(third))
+
+	var bom helper.CycloneDXSBOM
+	require.NoError(t, json.Unmarshal(first, &bom))
+	assert.Equal(t, []string{"pkg:axios@0.21.0", "pkg:express@4.18.2", "pkg:lodash@4.17.20"}, bom.Dependencies[0].DependsOn)
+	assert.Equal(t, "pkg:axios@0.21.0", bom.Components[0].BomRef)
+
+	// A different scan timestamp yields a different serial number
+	later := deterministicSBOMInput(deps)
+	later.ScanTimestamp = later.ScanTimestamp.Add(time.Hour)
+	laterBytes, err := helper.GenerateEnhancedCycloneDXSBOM(later)
+	require.NoError(t, err)
+	var laterBOM helper.CycloneDXSBOM
+	require.NoError(t, json.Unmarshal(laterBytes, &laterBOM))
+	assert.NotEqual(t, bom.SerialNumber, laterBOM.SerialNumber)
+}
+
+func TestGenerateEnhancedCycloneDXSBOM_RandomSerialByDefault(t *testing.T) {
+	data := deterministicSBOMInput([]helper.DependencyWithVulnerabilities{{Name: "express", Version: "4.18.2", Runtime: "node"}})
+	data.Deterministic = false
+
+	first, err := helper.GenerateEnhancedCycloneDXSBOM(data)
+	require.NoError(t, err)
+	second, err := helper.GenerateEnhancedCycloneDXSBOM(data)
+	require.NoError(t, err)
+	assert.NotEqual(t, string(first), string(second))
+}
+
+func TestGenerateEnhancedCycloneDXSBOM_AffectedRangesIncludeFixedVersions(t *testing.T) {
+	data := helper.EnhancedSBOMData{
+		AppName: "demo",
+		Dependencies: []helper.DependencyWithVulnerabilities{{
+			Name:    "lodash",
+			Version: "4.17.20",
+			Runtime: "Node.js",
+			Vulnerabilities: []helper.VulnerabilityInfo{{
+				ID:               "GHSA-35jh-r3h4-6jhm",
+				AffectedVersions: []string{"0"},
+				AffectedRanges:   []helper.VersionRange{{Introduced: "0", Fixed: "4.17.21"}},
+				PatchedVersions:  []string{"4.17.21"},
+			}},
+		}},
+	}
+
+	sbomBytes, err := helper.GenerateEnhancedCycloneDXSBOM(data)
+	require.NoError(t, err)
+
+	var bom helper.CycloneDXSBOM
+	require.NoError(t, json.Unmarshal(sbomBytes, &bom))
+	require.Len(t, bom.Vulnerabilities, 1)
+	require.Len(t, bom.Vulnerabilities[0].Affects, 1)
+	assert.Equal(t, []helper.CycloneDXVersionRange{
+		{Range: "vers:npm/<4.17.21", Status: "affected"},
+		{Version: "4.17.21", Status: "unaffected"},
+	}, bom.Vulnerabilities[0].Affects[0].Versions)
+}
+
+func TestGenerateEnhancedCycloneDXSBOM_RecordsConfiguredTool(t *testing.T) {
+	previous := helper.Version
+	helper.Version = "v1.4.2"
+	t.Cleanup(func() { helper.Version = previous })
+
+	sbomBytes, err := helper.GenerateEnhancedCycloneDXSBOM(helper.EnhancedSBOMData{
+		AppID: "app-1", AppName: "payments", Tool: helper.NewSBOMTool("Acme Security", "elang"),
+	})
+	require.NoError(t, err)
+	var bom helper.CycloneDXSBOM
+	require.NoError(t, json.Unmarshal(sbomBytes, &bom))
+	assert.Equal(t, []helper.CycloneDXTool{{Vendor: "Acme Security", Name: "elang", Version: "v1.4.2"}}, bom.Metadata.Tools)
+
+	assert.Equal(t, helper.DefaultSBOMToolVendor, helper.NewSBOMTool("", "").Vendor)
+	assert.Equal(t, helper.DefaultSBOMToolName, helper.NewSBOMTool("", "").Name)
+}
+
+func TestBuildVersion_FallsBackWhenNotStamped(t *testing.T) {
+	previous := helper.Version
+	helper.Version = ""
+	t.Cleanup(func() { helper.Version = previous })
+
+	assert.NotEmpty(t, helper.BuildVersion())
+}
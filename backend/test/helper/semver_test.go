@@ -0,0 +1,75 @@
+package helper_test
+
+import (
+	"elang-backend/internal/helper"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeSemVer(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Plain", "1.2.3", "1.2.3"},
+		{"VPrefix", "v1.2.3", "1.2.3"},
+		{"PreRelease", "1.0.0-rc.1", "1.0.0-rc.1"},
+		{"PreReleaseWithVPrefix", "v1.0.0-rc.1", "1.0.0-rc.1"},
+		{"PreReleaseWithHyphens", "2.0.0-alpha-beta.3", "2.0.0-alpha-beta.3"},
+		{"BuildMetadata", "1.0.0+20240101.sha.abc123", "1.0.0+20240101.sha.abc123"},
+		{"PreReleaseAndBuild", "v1.0.0-rc.1+build.5", "1.0.0-rc.1+build.5"},
+		{"Caret", "^1.2.0-beta.2", "1.2.0-beta.2"},
+		{"Tilde", "~1.2.0", "1.2.0"},
+		{"GreaterOrEqual", ">=1.2.0", "1.2.0"},
+		{"OperatorWithSpace", ">= 1.2.0", "1.2.0"},
+		{"PythonExact", "==2.31.0", "2.31.0"},
+		{"PythonCompatible", "~=1.4.2", "1.4.2"},
+		{"RubyPessimistic", "~> 7.1.0", "7.1.0"},
+		{"SpaceSeparatedRange", ">=1.2.0 <2.0.0", "1.2.0"},
+		{"CommaSeparatedRange", ">=1.2.0-rc.1, <2.0.0", "1.2.0-rc.1"},
+		{"HyphenRange", "1.2.0 - 2.0.0", "1.2.0"},
+		{"Alternatives", "^1.0.0-rc.1 || ^2.0.0", "1.0.0-rc.1"},
+		{"Whitespace", "  1.2.3  ", "1.2.3"},
+		{"Empty", "", ""},
+		{"OperatorOnly", ">=", ""},
+		{"NonVersionWordKeepsV", "vendor", "vendor"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, helper.NormalizeSemVer(tc.input))
+		})
+	}
+}
+
+func TestDependencyNameNormalizer_NormalizeVersionKeepsPreRelease(t *testing.T) {
+	n := helper.NewDependencyNameNormalizer()
+
+	assert.Equal(t, "1.0.0-rc.1", n.NormalizeVersion("1.0.0-rc.1"))
+	assert.Equal(t, "3.0.0-beta.1+exp.sha.5114f85", n.NormalizeVersion("^v3.0.0-beta.1+exp.sha.5114f85"))
+}
+
+func TestNormalizeVersion_TagMatching(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"v1.0.0-RC.1", "1.0.0-rc.1"},
+		{"release-v2.1.0", "2.1.0"},
+		{"r12", "12"},
+		{"rc1", "rc1"},
+		{"^1.4.0", "1.4.0"},
+		{"1.0.0+build.7", "1.0.0+build.7"},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, helper.NormalizeVersion(tc.input), tc.input)
+	}
+
+	assert.True(t, helper.VersionsMatch("v1.0.0-rc.1", "1.0.0-rc.1"))
+	assert.False(t, helper.VersionsMatch("1.0.0-rc.1", "1.0.0"))
+
+	tags := []map[string]interface{}{{"name": "v1.0.0"}, {"name": "v1.0.0-rc.1"}}
+	assert.Equal(t, "v1.0.0-rc.1", helper.FindBestMatchingTag("1.0.0-rc.1", tags))
+}
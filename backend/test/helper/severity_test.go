@@ -0,0 +1,79 @@
+package helper_test
+
+import (
+	"elang-backend/internal/helper"
+	"elang-backend/internal/model"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSeverity(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected helper.CVESeverity
+	}{
+		{"critical", helper.SeverityCritical},
+		{"CRITICAL", helper.SeverityCritical},
+		{"High", helper.SeverityHigh},
+		{" high ", helper.SeverityHigh},
+		{"MODERATE", helper.SeverityMedium},
+		{"medium", helper.SeverityMedium},
+		{"Low", helper.SeverityLow},
+		{"informational", helper.SeverityInfo},
+		{"NONE", helper.SeverityNone},
+		{"", helper.SeverityUnknown},
+		{"severe", helper.SeverityUnknown},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, helper.ParseSeverity(tc.input), tc.input)
+	}
+}
+
+func TestAggregateVulnerabilitySummary_IgnoresSeverityCasing(t *testing.T) {
+	for _, severity := range []string{"high", "High", "HIGH", " high"} {
+		t.Run(severity, func(t *testing.T) {
+			summary := helper.AggregateVulnerabilitySummary([]model.ScanFinding{
+				{Dependency: "lodash", Severity: severity, VulnerabilityIDs: []string{"GHSA-35jh-r3h4-6jhm"}},
+			})
+
+			assert.Equal(t, model.ScanSummary{TotalDependencies: 1, TotalVulnerabilities: 1, High: 1}, summary)
+			status, _ := helper.EvaluatePolicy(summary, []string{"HIGH"}, helper.PolicyConfig{})
+			assert.Equal(t, "fail", status)
+		})
+	}
+}
+
+func TestCVESeverity_DecodesLegacyUpperCase(t *testing.T) {
+	var vuln helper.VulnerabilityInfo
+	require.NoError(t, json.Unmarshal([]byte(`{"id":"GHSA-35jh-r3h4-6jhm","severity":"CRITICAL"}`), &vuln))
+	assert.Equal(t, helper.SeverityCritical, vuln.Severity)
+
+	encoded, err := json.Marshal(vuln)
+	require.NoError(t, err)
+	assert.Contains(t, string(encoded), `"severity":"critical"`)
+}
+
+func TestNewSeverityPalette(t *testing.T) {
+	palette, err := helper.NewSeverityPalette([]string{"medium=Moderate"}, []string{"CRITICAL=#ff0000"})
+	require.NoError(t, err)
+	assert.Equal(t, "Moderate", palette.Style(helper.SeverityMedium).Label)
+	assert.Equal(t, "#ff0000", palette.Style(helper.SeverityCritical).Color)
+	assert.Equal(t, "Critical", palette.Style(helper.SeverityCritical).Label, "unlisted fields keep their defaults")
+	assert.Equal(t, "Medium", helper.SeverityMedium.Label(), "the defaults are left untouched")
+
+	_, reason := helper.EvaluatePolicy(model.ScanSummary{Medium: 1}, []string{"medium"}, helper.PolicyConfig{Severities: palette})
+	assert.Equal(t, "Moderate severity vulnerabilities found", reason)
+
+	styles := palette.Styles()
+	require.NotEmpty(t, styles)
+	assert.Equal(t, helper.SeverityCritical, styles[0].Severity)
+	assert.Equal(t, 4, styles[0].Rank)
+
+	_, err = helper.NewSeverityPalette([]string{"severe=Severe"}, nil)
+	assert.Error(t, err)
+	_, err = helper.NewSeverityPalette(nil, []string{"high"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,90 @@
+package helper_test
+
+import (
+	"context"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/helper/parser"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// hangingSource never answers for the names in hang until the request is cancelled
+type hangingSource struct {
+	hang  map[string]bool
+	vulns []helper.VulnerabilityInfo
+}
+
+func (s *hangingSource) Name() string { return "osv" }
+
+func (s *hangingSource) QueryVulnerabilities(ctx context.Context, dep parser.DependencyInfo) ([]helper.VulnerabilityInfo, error) {
+	if s.hang[dep.Name] {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return s.vulns, nil
+}
+
+func TestSharedScanner_AbandonsDependencyAfterTimeout(t *testing.T) {
+	scanner := helper.NewSharedScanner(helper.ScannerConfig{
+		Sources: []helper.VulnerabilitySource{&hangingSource{
+			hang:  map[string]bool{"slow-package": true},
+			vulns: []helper.VulnerabilityInfo{{ID: "GHSA-35jh-r3h4-6jhm", Severity: helper.SeverityHigh, Score: 7.2}},
+		}},
+		MaxConcurrent:     2,
+		DependencyTimeout: 100 * time.Millisecond,
+	})
+
+	deps := []helper.DependencyInfo{
+		{Name: "slow-package", Version: "1.0.0", Runtime: "node"},
+		{Name: "lodash", Version: "4.17.20", Runtime: "node"},
+	}
+
+	start := time.Now()
+	findings, _, _, high, _, _ := scanner.ScanDependenciesWithControl(context.Background(), deps)
+	assert.Less(t, time.Since(start), 5*time.Second, "the hung dependency must not block the scan")
+
+	require.Len(t, findings, 2)
+	byName := map[string]int{}
+	for i, finding := range findings {
+		byName[finding.Dependency] = i
+	}
+	slow := findings[byName["slow-package"]]
+	assert.Contains(t, slow.Error, "timed out after 100ms")
+	assert.Empty(t, slow.VulnerabilityIDs)
+
+	fast := findings[byName["lodash"]]
+	assert.Empty(t, fast.Error)
+	assert.Equal(t, "high", fast.Severity)
+	assert.Equal(t, 1, high)
+}
+
+func TestSharedScanner_SeverityOverridesReachTotalsAndSBOM(t *testing.T) {
+	advisories := []helper.VulnerabilityInfo{
+		{ID: "GHSA-rce", CVE: "CVE-2024-0042", Severity: helper.SeverityMedium, Cwes: []string{"CWE-94"}, Score: 5.5},
+		{ID: "GHSA-dos", CVE: "CVE-2023-0007", Severity: helper.SeverityLow, Score: 3.1},
+	}
+	scanner := helper.NewSharedScanner(helper.ScannerConfig{Sources: []helper.VulnerabilitySource{&hangingSource{vulns: advisories}}})
+
+	// The rule setting more criteria wins over the broader one listed before it
+	ctx := helper.WithSeverityOverrides(context.Background(), []helper.SeverityOverrideRule{
+		{ID: "broad", CWE: "CWE-94", Severity: helper.SeverityHigh},
+		{ID: "narrow", CWE: "CWE-94", Ecosystem: "npm", Severity: helper.SeverityCritical},
+	})
+	findings, depsWithVulns, critical, high, medium, low := scanner.ScanDependenciesWithControl(ctx,
+		[]helper.DependencyInfo{{Name: "lodash", Version: "4.17.20", Runtime: "node"}})
+
+	require.Len(t, findings, 1)
+	assert.Equal(t, "critical", findings[0].Severity)
+	assert.Equal(t, "medium", findings[0].OriginalSeverity)
+	assert.Equal(t, []string{"narrow"}, findings[0].SeverityOverrideIDs)
+	assert.Equal(t, []int{1, 0, 0, 1}, []int{critical, high, medium, low}, "totals count the overridden severities")
+
+	require.Len(t, depsWithVulns, 1)
+	require.Len(t, depsWithVulns[0].Vulnerabilities, 2)
+	assert.Equal(t, helper.SeverityCritical, depsWithVulns[0].Vulnerabilities[0].Severity, "the SBOM carries the overridden severity")
+	assert.Equal(t, helper.SeverityLow, depsWithVulns[0].Vulnerabilities[1].Severity)
+	assert.Equal(t, helper.SeverityMedium, advisories[0].Severity, "the advisory itself is left as it was")
+}
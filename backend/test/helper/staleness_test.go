@@ -0,0 +1,62 @@
+package helper_test
+
+import (
+	"elang-backend/internal/helper"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var stalenessTags = []map[string]interface{}{
+	{"name": "v2.0.0-rc.1", "commit_sha": "rc"},
+	{"name": "v1.9.0", "commit_sha": "c190"},
+	{"name": "v1.10.0", "commit_sha": "c1100"},
+	{"name": "v1.8.0", "commit_sha": "c180"},
+	{"name": "nightly", "commit_sha": "nightly"},
+	{"name": "v1.7.0", "commit_sha": "c170"},
+}
+
+var stalenessCommitDates = map[string]time.Time{
+	"c170":  time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+	"c1100": time.Date(2023, 4, 11, 12, 0, 0, 0, time.UTC),
+}
+
+func stalenessCommitDate(sha string) (time.Time, error) {
+	if date, ok := stalenessCommitDates[sha]; ok {
+		return date, nil
+	}
+	return time.Time{}, errors.New("unknown commit")
+}
+
+func TestMeasureStaleness(t *testing.T) {
+	// Pre-releases and non-version tags are not releases; 1.10.0 is newer than 1.9.0
+	staleness, ok := helper.MeasureStaleness("1.7.0", stalenessTags, stalenessCommitDate)
+	assert.True(t, ok)
+	assert.Equal(t, helper.DependencyStaleness{ReleasesBehind: 3, Days: 100}, staleness)
+	assert.False(t, staleness.IsStale())
+
+	staleness, ok = helper.MeasureStaleness("v1.10.0", stalenessTags, func(string) (time.Time, error) {
+		t.Fatal("commit dates are not needed for the latest release")
+		return time.Time{}, nil
+	})
+	assert.True(t, ok)
+	assert.Equal(t, helper.DependencyStaleness{}, staleness)
+}
+
+func TestMeasureStaleness_UnknownDatesStillCountReleases(t *testing.T) {
+	staleness, ok := helper.MeasureStaleness("v1.8.0", stalenessTags, stalenessCommitDate)
+	assert.True(t, ok)
+	assert.Equal(t, helper.DependencyStaleness{ReleasesBehind: 2}, staleness)
+}
+
+func TestMeasureStaleness_UnmatchedVersion(t *testing.T) {
+	_, ok := helper.MeasureStaleness("1.2.3", stalenessTags, stalenessCommitDate)
+	assert.False(t, ok)
+}
+
+func TestDependencyStaleness_IsStale(t *testing.T) {
+	assert.True(t, helper.DependencyStaleness{ReleasesBehind: helper.StaleReleasesBehind}.IsStale())
+	assert.False(t, helper.DependencyStaleness{ReleasesBehind: helper.StaleReleasesBehind - 1}.IsStale())
+}
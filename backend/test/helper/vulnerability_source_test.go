@@ -0,0 +1,592 @@
+package helper_test
+
+import (
+	"context"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/helper/parser"
+	"elang-backend/internal/model"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubSource answers every query with the same vulnerabilities or error and counts the calls
+type stubSource struct {
+	name  string
+	vulns []helper.VulnerabilityInfo
+	err   error
+	calls int
+}
+
+func (s *stubSource) Name() string { return s.name }
+
+func (s *stubSource) QueryVulnerabilities(ctx context.Context, dep parser.DependencyInfo) ([]helper.VulnerabilityInfo, error) {
+	s.calls++
+	return s.vulns, s.err
+}
+
+var lodash = parser.DependencyInfo{Name: "lodash", Version: "4.17.20", Runtime: "node"}
+
+func TestCVEHelper_FallsBackToNextSource(t *testing.T) {
+	osv := &stubSource{name: "osv", err: errors.New("OSV API returned status 503")}
+	github := &stubSource{name: "github", vulns: []helper.VulnerabilityInfo{
+		{ID: "GHSA-35jh-r3h4-6jhm", CVE: "CVE-2021-23337", Severity: helper.SeverityHigh, Score: 7.2, Source: "github"},
+	}}
+
+	result, err := helper.NewCVEHelperWithSources(false, osv, github).CheckDependencyVulnerabilities(context.Background(), lodash)
+	require.NoError(t, err)
+
+	assert.Empty(t, result.Error)
+	require.Len(t, result.Vulnerabilities, 1)
+	assert.Equal(t, "github", result.Vulnerabilities[0].Source)
+	assert.Equal(t, 1, result.HighCount)
+}
+
+func TestCVEHelper_StopsAtFirstAnsweringSource(t *testing.T) {
+	osv := &stubSource{name: "osv"}
+	github := &stubSource{name: "github"}
+
+	_, err := helper.NewCVEHelperWithSources(false, osv, github).CheckDependencyVulnerabilities(context.Background(), lodash)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, osv.calls)
+	assert.Zero(t, github.calls)
+}
+
+func TestCVEHelper_MergesSourcesByAlias(t *testing.T) {
+	osv := &stubSource{name: "osv", vulns: []helper.VulnerabilityInfo{
+		{ID: "GHSA-35jh-r3h4-6jhm", Aliases: []string{"CVE-2021-23337"}, CVE: "CVE-2021-23337", Severity: helper.SeverityMedium, Score: 5,
+			PatchedVersions: []string{"4.17.21"}, Source: "osv"},
+	}}
+	github := &stubSource{name: "github", vulns: []helper.VulnerabilityInfo{
+		{ID: "GHSA-35jh-r3h4-6jhm", CVE: "CVE-2021-23337", Severity: helper.SeverityHigh, Score: 7.2,
+			PatchedVersions: []string{"4.17.21"}, References: []string{"https://github.com/advisories/GHSA-35jh-r3h4-6jhm"}, Source: "github"},
+		{ID: "GHSA-29mw-wpgm-hmr9", Aliases: []string{"CVE-2020-28500"}, Severity: helper.SeverityMedium, Score: 5.3, Source: "github"},
+	}}
+
+	result, err := helper.NewCVEHelperWithSources(true, osv, github).CheckDependencyVulnerabilities(context.Background(), lodash)
+	require.NoError(t, err)
+
+	require.Len(t, result.Vulnerabilities, 2)
+	merged := result.Vulnerabilities[0]
+	assert.Equal(t, "osv", merged.Source)
+	assert.Equal(t, helper.SeverityHigh, merged.Severity, "the more severe rating wins")
+	assert.Equal(t, []string{"4.17.21"}, merged.PatchedVersions)
+	assert.Contains(t, merged.References, "https://github.com/advisories/GHSA-35jh-r3h4-6jhm")
+	assert.Equal(t, "GHSA-29mw-wpgm-hmr9", result.Vulnerabilities[1].ID)
+}
+
+func TestCVEHelper_MergeToleratesOneSourceDown(t *testing.T) {
+	osv := &stubSource{name: "osv", vulns: []helper.VulnerabilityInfo{{ID: "GHSA-35jh-r3h4-6jhm", Severity: helper.SeverityHigh}}}
+	github := &stubSource{name: "github", err: errors.New("GitHub GraphQL API returned status: 502 Bad Gateway")}
+
+	result, err := helper.NewCVEHelperWithSources(true, osv, github).CheckDependencyVulnerabilities(context.Background(), lodash)
+	require.NoError(t, err)
+
+	assert.Empty(t, result.Error)
+	assert.Len(t, result.Vulnerabilities, 1)
+}
+
+func TestCVEHelper_AllSourcesDown(t *testing.T) {
+	osv := &stubSource{name: "osv", err: errors.New("OSV API returned status 503")}
+	github := &stubSource{name: "github", err: errors.New("GitHub GraphQL API returned status: 502 Bad Gateway")}
+
+	result, err := helper.NewCVEHelperWithSources(true, osv, github).CheckDependencyVulnerabilities(context.Background(), lodash)
+	require.NoError(t, err)
+
+	assert.Contains(t, result.Error, "osv: OSV API returned status 503")
+	assert.Contains(t, result.Error, "github: GitHub GraphQL API returned status")
+	assert.Empty(t, result.Vulnerabilities)
+}
+
+// slowNameSource answers after delay, with vulnerabilities only for the names in vulnsByName
+type slowNameSource struct {
+	delay       time.Duration
+	vulnsByName map[string][]helper.VulnerabilityInfo
+	calls       atomic.Int32
+}
+
+func (s *slowNameSource) Name() string { return "osv" }
+
+func (s *slowNameSource) QueryVulnerabilities(ctx context.Context, dep parser.DependencyInfo) ([]helper.VulnerabilityInfo, error) {
+	s.calls.Add(1)
+	select {
+	case <-time.After(s.delay):
+		return s.vulnsByName[dep.Name], nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func TestCVEHelper_QueriesAlternativeNamesConcurrently(t *testing.T) {
+	const delay = 200 * time.Millisecond
+	source := &slowNameSource{delay: delay, vulnsByName: map[string][]helper.VulnerabilityInfo{
+		"typing_extensions": {{ID: "PYSEC-2099-1", Severity: helper.SeverityHigh, Score: 7.5}},
+	}}
+	dep := parser.DependencyInfo{Name: "typing-extensions", Version: "4.0.0", Runtime: "python"}
+
+	start := time.Now()
+	result, err := helper.NewCVEHelperWithSources(false, source).CheckDependencyVulnerabilities(context.Background(), dep)
+	elapsed := time.Since(start)
+	require.NoError(t, err)
+
+	require.Len(t, result.Vulnerabilities, 1, "the underscore spelling is found although the hyphen one has no vulnerabilities")
+	assert.Equal(t, "PYSEC-2099-1", result.Vulnerabilities[0].ID)
+	assert.Equal(t, int32(2), source.calls.Load())
+	assert.Less(t, elapsed, 2*delay, "alternative names are queried in parallel, not one after another")
+}
+
+func TestCVEHelper_MergesOverlappingAlternativeNames(t *testing.T) {
+	source := &slowNameSource{delay: 10 * time.Millisecond, vulnsByName: map[string][]helper.VulnerabilityInfo{
+		"typing-extensions": {
+			{ID: "PYSEC-2099-1", Severity: helper.SeverityHigh, Score: 7.5},
+			{ID: "GHSA-aaaa-bbbb-cccc", Severity: helper.SeverityMedium, Score: 5.0},
+		},
+		"typing_extensions": {
+			{ID: "GHSA-dddd-eeee-ffff", Aliases: []string{"PYSEC-2099-1"}, Severity: helper.SeverityHigh, Score: 7.5},
+			{ID: "PYSEC-2099-2", Severity: helper.SeverityLow, Score: 2.0},
+		},
+	}}
+	dep := parser.DependencyInfo{Name: "typing-extensions", Version: "4.0.0", Runtime: "python"}
+
+	result, err := helper.NewCVEHelperWithSources(false, source).CheckDependencyVulnerabilities(context.Background(), dep)
+	require.NoError(t, err)
+
+	ids := make([]string, 0, len(result.Vulnerabilities))
+	for _, vuln := range result.Vulnerabilities {
+		ids = append(ids, vuln.ID)
+	}
+	assert.ElementsMatch(t, []string{"PYSEC-2099-1", "GHSA-aaaa-bbbb-cccc", "PYSEC-2099-2"}, ids,
+		"both spellings' answers are merged and the advisory filed under both is counted once")
+	assert.Equal(t, int32(2), source.calls.Load())
+}
+
+func TestCVEHelper_AlternativeNamesRespectCancellation(t *testing.T) {
+	source := &slowNameSource{delay: time.Minute}
+	dep := parser.DependencyInfo{Name: "typing-extensions", Version: "4.0.0", Runtime: "python"}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	result, err := helper.NewCVEHelperWithSources(false, source).CheckDependencyVulnerabilities(ctx, dep)
+	require.NoError(t, err)
+
+	assert.Contains(t, result.Error, context.DeadlineExceeded.Error())
+	assert.Empty(t, result.Vulnerabilities)
+}
+
+func TestVersionInRange(t *testing.T) {
+	testCases := []struct {
+		version  string
+		ranges   string
+		expected bool
+	}{
+		{"4.17.20", "< 4.17.21", true},
+		{"4.17.21", "< 4.17.21", false},
+		{"1.5.0", ">= 1.0.0, < 2.0.0", true},
+		{"2.0.0", ">= 1.0.0, < 2.0.0", false},
+		{"0.9.9", ">= 1.0.0, < 2.0.0", false},
+		{"2.0.0", "= 2.0.0", true},
+		{"2.0.1", "<= 2.0.0", false},
+		{"", "< 1.0.0", false},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, helper.VersionInRange(tc.version, tc.ranges), "%s %s", tc.version, tc.ranges)
+		if tc.version != "" {
+			// The structured range used for upgrade recommendations agrees with the constraint check
+			assert.Equal(t, tc.expected, helper.VersionRangeFromConstraints(tc.ranges).Contains(tc.version), "%s %s", tc.version, tc.ranges)
+		}
+	}
+}
+
+func TestNewOSVQuery(t *testing.T) {
+	normalizer := helper.NewDependencyNameNormalizer()
+	queryJSON := func(dep parser.DependencyInfo) string {
+		encoded, err := json.Marshal(helper.NewOSVQuery(normalizer.NormalizeDependencyInfo(dep), "Go"))
+		require.NoError(t, err)
+		return string(encoded)
+	}
+
+	t.Run("Release", func(t *testing.T) {
+		dep := parser.DependencyInfo{Name: "github.com/gin-gonic/gin", Version: "v1.9.1", Runtime: "go"}
+		assert.JSONEq(t, `{"package":{"name":"github.com/gin-gonic/gin","ecosystem":"Go"},"version":"1.9.1"}`, queryJSON(dep))
+	})
+
+	t.Run("PseudoVersion", func(t *testing.T) {
+		// Go advisories only carry SEMVER ranges, so pseudo-versions are matched by package and version
+		dep := parser.DependencyInfo{Name: "golang.org/x/net", Version: "v0.0.0-20220906165146-f3363e06e74c", Runtime: "go"}
+		assert.JSONEq(t, `{"package":{"name":"golang.org/x/net","ecosystem":"Go"},"version":"0.0.0-20220906165146-f3363e06e74c"}`, queryJSON(dep))
+		assert.Empty(t, helper.CommitForDependency(dep), "OSV only matches full commit hashes")
+
+		dep.Version = "v0.1.1-0.20220906165146-f3363e06e74c+incompatible"
+		assert.Empty(t, helper.CommitForDependency(dep))
+	})
+
+	t.Run("PseudoVersionWithFullCommit", func(t *testing.T) {
+		full := "f3363e06e74cf8d7b7e0a2a9d3a5e1bd8e3b6c21"
+		dep := parser.DependencyInfo{Name: "golang.org/x/net", Version: "v0.0.0-20220906165146-f3363e06e74c", Runtime: "go", Commit: full}
+		assert.Equal(t, full, helper.CommitForDependency(dep))
+
+		// A resolved commit that disagrees with the version is ignored
+		dep.Commit = "0123456789abcdef0123456789abcdef01234567"
+		assert.Empty(t, helper.CommitForDependency(dep))
+	})
+
+	t.Run("CommitSHAVersion", func(t *testing.T) {
+		dep := parser.DependencyInfo{Name: "left-pad", Version: "5C8E2B7A", Runtime: "node"}
+		assert.Empty(t, helper.CommitForDependency(dep), "abbreviated hashes are not queried")
+		dep.Version = "5C8E2B7A4D0CCF0C08F47A99AA71D1B0E52F8D03"
+		assert.Equal(t, "5c8e2b7a4d0ccf0c08f47a99aa71d1b0e52f8d03", helper.CommitForDependency(dep))
+	})
+
+	t.Run("NumericVersionIsNotACommit", func(t *testing.T) {
+		dep := parser.DependencyInfo{Name: "tzdata", Version: "20240101", Runtime: "python"}
+		assert.Empty(t, helper.CommitForDependency(dep))
+		// A release's resolved commit does not turn it into a commit query
+		dep = parser.DependencyInfo{Name: "lodash", Version: "4.17.21", Runtime: "node", Commit: "f3363e06e74cf8d7b7e0a2a9d3a5e1bd8e3b6c21"}
+		assert.Empty(t, helper.CommitForDependency(dep))
+	})
+}
+
+func TestCVEHelper_ManualDependencies(t *testing.T) {
+	osv := &stubSource{name: "osv"}
+	cve := helper.NewCVEHelperWithSources(false, osv)
+
+	// A vendored submodule pinned to a commit is checked without an ecosystem
+	submodule := parser.DependencyInfo{Name: "nlohmann/json", Version: "9cca280a4d0ccf0c08f47a99aa71d1b0e52f8d03", Runtime: "manual"}
+	result, err := cve.CheckDependencyVulnerabilities(context.Background(), submodule)
+	require.NoError(t, err)
+	assert.Empty(t, result.Error)
+	assert.Equal(t, 1, osv.calls)
+
+	// A release needs an ecosystem to be looked up in
+	release := parser.DependencyInfo{Name: "madler/zlib", Version: "v1.3.1", Runtime: "manual"}
+	result, err = cve.CheckDependencyVulnerabilities(context.Background(), release)
+	require.NoError(t, err)
+	assert.NotEmpty(t, result.Error)
+	assert.Equal(t, 1, osv.calls)
+
+	release = parser.DependencyInfo{Name: "openssl", Version: "3.0.7", Runtime: "manual", Ecosystem: "Debian:12"}
+	result, err = cve.CheckDependencyVulnerabilities(context.Background(), release)
+	require.NoError(t, err)
+	assert.Empty(t, result.Error)
+	assert.Equal(t, 2, osv.calls)
+}
+
+// osvTestServer answers every OSV request with no vulnerabilities and counts the requests
+func osvTestServer(t *testing.T, requests *atomic.Int32) *http.Client {
+	return osvResponseServer(t, requests, `{"vulns": []}`)
+}
+
+// osvResponseServer answers every OSV query with body and returns a client whose requests reach it
+func osvResponseServer(t *testing.T, requests *atomic.Int32, body string) *http.Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	target, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	return &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		req = req.Clone(req.Context())
+		req.URL.Scheme, req.URL.Host = target.Scheme, target.Host
+		return http.DefaultTransport.RoundTrip(req)
+	})}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestOSVSource_RateLimitIsSharedAcrossScans(t *testing.T) {
+	const perSecond, total = 40, 60
+	var requests atomic.Int32
+	source := helper.NewOSVSourceWithClient(osvTestServer(t, &requests))
+	source.SetRateLimit(perSecond)
+	// Two scanners stand in for two concurrent scans; sharing the source, they draw on the same budget
+	scanners := []*helper.CVEHelper{helper.NewCVEHelperWithSources(false, source), helper.NewCVEHelperWithSources(false, source)}
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < total; i++ {
+		wg.Add(1)
+		go func(scanner *helper.CVEHelper) {
+			defer wg.Done()
+			_, err := scanner.CheckDependencyVulnerabilities(context.Background(), lodash)
+			assert.NoError(t, err)
+		}(scanners[i%2])
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	assert.Equal(t, int32(total), requests.Load())
+	// A full burst goes out at once; the remaining requests are spaced at the configured rate
+	minimum := time.Duration(total-perSecond) * time.Second / perSecond
+	assert.GreaterOrEqual(t, elapsed, minimum*9/10, "requests were not throttled")
+}
+
+func TestOSVSource_RateLimitWaitHonoursContext(t *testing.T) {
+	var requests atomic.Int32
+	source := helper.NewOSVSourceWithClient(osvTestServer(t, &requests))
+	source.SetRateLimit(1)
+
+	// The first request uses the burst; the next one would wait a second, past the deadline
+	_, err := source.QueryVulnerabilities(context.Background(), lodash)
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = source.QueryVulnerabilities(ctx, lodash)
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+func TestOSVSource_QueriesFullCommitAlongsideVersion(t *testing.T) {
+	var mu sync.Mutex
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		queries = append(queries, string(body))
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(string(body), `"commit"`) {
+			// The commit answer repeats the SEMVER advisory under its alias and adds one only GIT ranges match
+			_, _ = w.Write([]byte(`{"vulns": [{"id": "CVE-2022-41717"}, {"id": "OSV-2022-1"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"vulns": [{"id": "GO-2022-1144", "aliases": ["CVE-2022-41717"]}]}`))
+	}))
+	t.Cleanup(server.Close)
+	target, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	client := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		req = req.Clone(req.Context())
+		req.URL.Scheme, req.URL.Host = target.Scheme, target.Host
+		return http.DefaultTransport.RoundTrip(req)
+	})}
+
+	full := "f3363e06e74cf8d7b7e0a2a9d3a5e1bd8e3b6c21"
+	dep := parser.DependencyInfo{Name: "golang.org/x/net", Version: "v0.0.0-20220906165146-f3363e06e74c", Runtime: "go", Commit: full}
+	vulns, err := helper.NewOSVSourceWithClient(client).QueryVulnerabilities(context.Background(), dep)
+	require.NoError(t, err)
+
+	require.Len(t, queries, 2)
+	assert.JSONEq(t, `{"package":{"name":"golang.org/x/net","ecosystem":"Go"},"version":"0.0.0-20220906165146-f3363e06e74c"}`, queries[0])
+	assert.JSONEq(t, `{"commit":"`+full+`"}`, queries[1])
+	var ids []string
+	for _, vuln := range vulns {
+		ids = append(ids, vuln.ID)
+	}
+	assert.Equal(t, []string{"GO-2022-1144", "OSV-2022-1"}, ids)
+}
+
+func TestOSVSource_ExtractsAffectedRanges(t *testing.T) {
+	var requests atomic.Int32
+	client := osvResponseServer(t, &requests, `{"vulns": [{
+		"id": "GHSA-35jh-r3h4-6jhm",
+		"affected": [{
+			"package": {"name": "lodash", "ecosystem": "npm"},
+			"ranges": [
+				{"type": "SEMVER", "events": [{"introduced": "0"}, {"fixed": "4.17.12"}, {"introduced": "4.17.15"}, {"fixed": "4.17.21"}]},
+				{"type": "ECOSYSTEM", "events": [{"introduced": "5.0.0"}, {"last_affected": "5.0.2"}]},
+				{"type": "GIT", "events": [{"introduced": "0"}, {"fixed": "c4847ebe"}]}
+			]
+		}]
+	}]}`)
+
+	vulns, err := helper.NewOSVSourceWithClient(client).QueryVulnerabilities(context.Background(), lodash)
+	require.NoError(t, err)
+	require.Len(t, vulns, 1)
+	assert.Equal(t, []helper.VersionRange{
+		{Introduced: "0", Fixed: "4.17.12"},
+		{Introduced: "4.17.15", Fixed: "4.17.21"},
+		{Introduced: "5.0.0", LastAffected: "5.0.2"},
+	}, vulns[0].AffectedRanges, "GIT ranges hold commits and are left out")
+
+	ranges := helper.FindingAffectedRanges(lodash.Version, vulns)
+	require.Len(t, ranges, 3)
+	assert.Equal(t, model.AffectedRange{
+		VulnerabilityID: "GHSA-35jh-r3h4-6jhm", Introduced: "4.17.15", Fixed: "4.17.21", Range: ">=4.17.15, <4.17.21", IncludesVersion: true,
+	}, ranges[1])
+	assert.Equal(t, "<4.17.12", ranges[0].Range)
+	assert.False(t, ranges[0].IncludesVersion)
+	assert.Equal(t, ">=5.0.0, <=5.0.2", ranges[2].Range)
+	assert.False(t, ranges[2].IncludesVersion)
+}
+
+func TestVersionRange_Notation(t *testing.T) {
+	testCases := []struct {
+		name   string
+		r      helper.VersionRange
+		text   string
+		vers   string
+		inside string
+	}{
+		{"Bounded", helper.VersionRange{Introduced: "1.2.0", Fixed: "1.4.1"}, ">=1.2.0, <1.4.1", "vers:npm/>=1.2.0|<1.4.1", "1.3.0"},
+		{"FromStart", helper.VersionRange{Introduced: "0", Fixed: "2.0.0"}, "<2.0.0", "vers:npm/<2.0.0", "1.0.0"},
+		{"LastAffected", helper.VersionRange{Introduced: "1.0.0", LastAffected: "1.1.0"}, ">=1.0.0, <=1.1.0", "vers:npm/>=1.0.0|<=1.1.0", "1.1.0"},
+		{"SingleVersion", helper.VersionRange{Introduced: "3.0.7", LastAffected: "3.0.7"}, "=3.0.7", "vers:npm/3.0.7", "3.0.7"},
+		{"Unbounded", helper.VersionRange{Introduced: "0"}, "*", "vers:npm/*", "9.9.9"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.text, tc.r.String())
+			assert.Equal(t, tc.vers, tc.r.Vers("npm"))
+			assert.True(t, tc.r.Contains(tc.inside))
+			assert.Equal(t, tc.r.Contains(tc.inside), helper.VersionRangeFromConstraints(tc.r.String()).Contains(tc.inside))
+		})
+	}
+}
+
+func TestConstraintRange(t *testing.T) {
+	testCases := []struct {
+		constraint string
+		want       string
+	}{
+		{">=4.2.0,<5.0", ">=4.2.0, <5.0"},
+		{">= 1.0, != 1.3, <= 2.0", ">=1.0, <=2.0"},
+		{"~=1.4.2", ">=1.4.2, <1.5"},
+		{"~=2.2", ">=2.2, <3"},
+		{"==3.1.*", ">=3.1, <3.2"},
+		{"<2.0", "<2.0"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.constraint, func(t *testing.T) {
+			r, ok := helper.ConstraintRange(tc.constraint)
+			require.True(t, ok)
+			assert.Equal(t, tc.want, r.String())
+		})
+	}
+
+	_, ok := helper.ConstraintRange("!=1.0")
+	assert.False(t, ok, "an exclusion alone bounds nothing")
+}
+
+func TestVersionRange_OverlapsAndCovers(t *testing.T) {
+	declared := helper.VersionRange{Introduced: "4.2.0", Fixed: "5.0"}
+	testCases := []struct {
+		name             string
+		affected         helper.VersionRange
+		overlaps, covers bool
+	}{
+		{"Everything before the fix", helper.VersionRange{Introduced: "0", Fixed: "5.1"}, true, true},
+		{"Inside the range", helper.VersionRange{Introduced: "4.2.5", Fixed: "4.2.8"}, true, false},
+		{"Up to the lower bound", helper.VersionRange{Introduced: "0", LastAffected: "4.2.0"}, true, false},
+		{"Fixed at the lower bound", helper.VersionRange{Introduced: "3.0", Fixed: "4.2.0"}, false, false},
+		{"From the upper bound", helper.VersionRange{Introduced: "5.0"}, false, false},
+		{"Unbounded", helper.VersionRange{Introduced: "0"}, true, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.overlaps, tc.affected.Overlaps(declared))
+			assert.Equal(t, tc.overlaps, declared.Overlaps(tc.affected))
+			assert.Equal(t, tc.covers, tc.affected.Covers(declared))
+		})
+	}
+}
+
+// TestOSVSource_ChecksDeclaredRange scans a range-only requirements line and checks that OSV is asked for every
+// vulnerability of the package, which is then narrowed to those affecting the declared range
+func TestOSVSource_ChecksDeclaredRange(t *testing.T) {
+	deps, err := parser.NewPythonParser().Parse("django>=4.2.0,<5.0\nrequests==2.31.0\n")
+	require.NoError(t, err)
+	require.Len(t, deps, 2)
+	assert.Equal(t, ">=4.2.0,<5.0", deps[0].Constraint)
+	assert.Equal(t, "4.2.0", deps[0].Version)
+	assert.Empty(t, deps[1].Constraint, "an exact pin is not a range")
+
+	var query helper.OSVQuery
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&query))
+		_, _ = w.Write([]byte(`{"vulns": [
+			{"id": "PYSEC-ALL", "affected": [{"ranges": [{"type": "ECOSYSTEM", "events": [{"introduced": "0"}, {"fixed": "5.0.1"}]}]}]},
+			{"id": "PYSEC-PART", "affected": [{"ranges": [{"type": "ECOSYSTEM", "events": [{"introduced": "4.2.5"}, {"fixed": "4.2.8"}]}]}]},
+			{"id": "PYSEC-OLD", "affected": [{"ranges": [{"type": "ECOSYSTEM", "events": [{"introduced": "3.0"}, {"fixed": "3.2.20"}]}]}]}
+		]}`))
+	}))
+	t.Cleanup(server.Close)
+	target, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	client := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		req = req.Clone(req.Context())
+		req.URL.Scheme, req.URL.Host = target.Scheme, target.Host
+		return http.DefaultTransport.RoundTrip(req)
+	})}
+
+	source := helper.NewOSVSourceWithClient(client)
+	source.SetDeclaredRangeChecks(true)
+	vulns, err := source.QueryVulnerabilities(context.Background(), deps[0])
+	require.NoError(t, err)
+	require.NotNil(t, query.Package)
+	assert.Equal(t, "django", query.Package.Name)
+	assert.Empty(t, query.Version, "the whole package is queried")
+
+	require.Len(t, vulns, 2, "a vulnerability fixed before the range is dropped")
+	assert.Equal(t, "PYSEC-ALL", vulns[0].ID)
+	assert.False(t, vulns[0].PartialRange)
+	assert.Equal(t, "PYSEC-PART", vulns[1].ID)
+	assert.True(t, vulns[1].PartialRange)
+	assert.Equal(t, []string{"PYSEC-PART"}, helper.PartialRangeIDs(vulns))
+}
+
+func TestOSVSource_FollowsNextPageToken(t *testing.T) {
+	var tokens []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var query helper.OSVQuery
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&query))
+		tokens = append(tokens, query.PageToken)
+		if query.PageToken == "" {
+			_, _ = w.Write([]byte(`{"vulns": [{"id": "GHSA-1"}, {"id": "GHSA-2"}], "next_page_token": "page-2"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"vulns": [{"id": "GHSA-3"}]}`))
+	}))
+	t.Cleanup(server.Close)
+	target, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	client := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		req = req.Clone(req.Context())
+		req.URL.Scheme, req.URL.Host = target.Scheme, target.Host
+		return http.DefaultTransport.RoundTrip(req)
+	})}
+
+	vulns, err := helper.NewOSVSourceWithClient(client).QueryVulnerabilities(context.Background(), lodash)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"", "page-2"}, tokens)
+	ids := make([]string, 0, len(vulns))
+	for _, vuln := range vulns {
+		ids = append(ids, vuln.ID)
+	}
+	assert.Equal(t, []string{"GHSA-1", "GHSA-2", "GHSA-3"}, ids)
+}
+
+func TestOSVSource_StopsOnRepeatedPageToken(t *testing.T) {
+	var requests atomic.Int32
+	client := osvResponseServer(t, &requests, `{"vulns": [{"id": "GHSA-1"}], "next_page_token": "again"}`)
+
+	_, err := helper.NewOSVSourceWithClient(client).QueryVulnerabilities(context.Background(), lodash)
+	require.Error(t, err)
+	assert.Equal(t, int32(2), requests.Load())
+}
+
+func TestCVEHelper_SkipsUnresolvedVersions(t *testing.T) {
+	osv := &stubSource{name: "osv"}
+	dep := parser.DependencyInfo{Name: "org.springframework:spring-core", Version: parser.UnresolvedVersion, Runtime: "gradle"}
+
+	result, err := helper.NewCVEHelperWithSources(false, osv).CheckDependencyVulnerabilities(context.Background(), dep)
+	require.NoError(t, err)
+	assert.Zero(t, osv.calls, "an unresolved version is never sent to the database")
+	assert.Equal(t, helper.UncheckedUnresolvedVersion, result.UncheckedReason)
+	assert.NotEmpty(t, result.Error)
+}
@@ -0,0 +1,552 @@
+// Package mocks holds testify mocks of the repository and usecase interfaces, shared by the tests of every
+// layer so that a change to an interface is made in one place.
+package mocks
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+	"elang-backend/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// Compile-time checks: a method added to a repository interface breaks the build here, not in every test
+var (
+	_ repository.ApplicationRepository       = (*ApplicationRepository)(nil)
+	_ repository.RuntimeRepository           = (*RuntimeRepository)(nil)
+	_ repository.FrameworkRepository         = (*FrameworkRepository)(nil)
+	_ repository.TagRepository               = (*TagRepository)(nil)
+	_ repository.DependencyRepository        = (*DependencyRepository)(nil)
+	_ repository.AppDependencyRepository     = (*AppDependencyRepository)(nil)
+	_ repository.DependencyVersionRepository = (*DependencyVersionRepository)(nil)
+	_ repository.AuditTrailRepository        = (*AuditTrailRepository)(nil)
+	_ repository.ScanResultRepository        = (*ScanResultRepository)(nil)
+)
+
+// ApplicationRepository is a testify mock of repository.ApplicationRepository
+type ApplicationRepository struct {
+	mock.Mock
+}
+
+func (m *ApplicationRepository) Create(ctx context.Context, app *entity.App) error {
+	args := m.Called(ctx, app)
+	return args.Error(0)
+}
+
+func (m *ApplicationRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.App, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.App), args.Error(1)
+}
+
+func (m *ApplicationRepository) GetAll(ctx context.Context) ([]*entity.App, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.App), args.Error(1)
+}
+
+func (m *ApplicationRepository) Update(ctx context.Context, app *entity.App) error {
+	args := m.Called(ctx, app)
+	return args.Error(0)
+}
+
+func (m *ApplicationRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *ApplicationRepository) GetByName(ctx context.Context, name string) (*entity.App, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.App), args.Error(1)
+}
+
+func (m *ApplicationRepository) GetByStatus(ctx context.Context, status string) ([]*entity.App, error) {
+	args := m.Called(ctx, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.App), args.Error(1)
+}
+
+func (m *ApplicationRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status string) error {
+	args := m.Called(ctx, id, status)
+	return args.Error(0)
+}
+
+func (m *ApplicationRepository) GetByTags(ctx context.Context, tags []string) ([]*entity.App, error) {
+	args := m.Called(ctx, tags)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.App), args.Error(1)
+}
+
+// RuntimeRepository is a testify mock of repository.RuntimeRepository
+type RuntimeRepository struct {
+	mock.Mock
+}
+
+func (m *RuntimeRepository) Create(ctx context.Context, runtime *entity.Runtime) error {
+	args := m.Called(ctx, runtime)
+	return args.Error(0)
+}
+
+func (m *RuntimeRepository) GetByID(ctx context.Context, id int) (*entity.Runtime, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Runtime), args.Error(1)
+}
+
+func (m *RuntimeRepository) GetAll(ctx context.Context) ([]*entity.Runtime, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Runtime), args.Error(1)
+}
+
+func (m *RuntimeRepository) Update(ctx context.Context, runtime *entity.Runtime) error {
+	args := m.Called(ctx, runtime)
+	return args.Error(0)
+}
+
+func (m *RuntimeRepository) Delete(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *RuntimeRepository) GetByName(ctx context.Context, name string) (*entity.Runtime, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Runtime), args.Error(1)
+}
+
+func (m *RuntimeRepository) GetByNameCI(ctx context.Context, name string) (*entity.Runtime, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Runtime), args.Error(1)
+}
+
+// FrameworkRepository is a testify mock of repository.FrameworkRepository
+type FrameworkRepository struct {
+	mock.Mock
+}
+
+func (m *FrameworkRepository) Create(ctx context.Context, framework *entity.Framework) error {
+	args := m.Called(ctx, framework)
+	return args.Error(0)
+}
+
+func (m *FrameworkRepository) GetByID(ctx context.Context, id int) (*entity.Framework, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Framework), args.Error(1)
+}
+
+func (m *FrameworkRepository) GetAll(ctx context.Context) ([]*entity.Framework, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Framework), args.Error(1)
+}
+
+func (m *FrameworkRepository) Update(ctx context.Context, framework *entity.Framework) error {
+	args := m.Called(ctx, framework)
+	return args.Error(0)
+}
+
+func (m *FrameworkRepository) Delete(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *FrameworkRepository) GetByName(ctx context.Context, name string) (*entity.Framework, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Framework), args.Error(1)
+}
+
+func (m *FrameworkRepository) GetByNameCI(ctx context.Context, name string) (*entity.Framework, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Framework), args.Error(1)
+}
+
+func (m *FrameworkRepository) GetByRuntimeID(ctx context.Context, runtimeID int) ([]*entity.Framework, error) {
+	args := m.Called(ctx, runtimeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Framework), args.Error(1)
+}
+
+func (m *FrameworkRepository) LinkRuntime(ctx context.Context, frameworkID, runtimeID int) error {
+	args := m.Called(ctx, frameworkID, runtimeID)
+	return args.Error(0)
+}
+
+// TagRepository is a testify mock of repository.TagRepository
+type TagRepository struct {
+	mock.Mock
+}
+
+func (m *TagRepository) GetOrCreate(ctx context.Context, name string) (*entity.Tag, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Tag), args.Error(1)
+}
+
+func (m *TagRepository) GetByName(ctx context.Context, name string) (*entity.Tag, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Tag), args.Error(1)
+}
+
+func (m *TagRepository) AddToApp(ctx context.Context, appID, tagID uuid.UUID) error {
+	args := m.Called(ctx, appID, tagID)
+	return args.Error(0)
+}
+
+func (m *TagRepository) RemoveFromApp(ctx context.Context, appID, tagID uuid.UUID) error {
+	args := m.Called(ctx, appID, tagID)
+	return args.Error(0)
+}
+
+func (m *TagRepository) GetByAppID(ctx context.Context, appID uuid.UUID) ([]*entity.Tag, error) {
+	args := m.Called(ctx, appID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Tag), args.Error(1)
+}
+
+func (m *TagRepository) GetNamesByAppIDs(ctx context.Context, appIDs []uuid.UUID) (map[uuid.UUID][]string, error) {
+	args := m.Called(ctx, appIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID][]string), args.Error(1)
+}
+
+// DependencyRepository is a testify mock of repository.DependencyRepository
+type DependencyRepository struct {
+	mock.Mock
+}
+
+func (m *DependencyRepository) Create(ctx context.Context, dep *entity.Dependency) error {
+	args := m.Called(ctx, dep)
+	return args.Error(0)
+}
+
+func (m *DependencyRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Dependency, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Dependency), args.Error(1)
+}
+
+func (m *DependencyRepository) GetByOwnerRepo(ctx context.Context, owner, repo string) (*entity.Dependency, error) {
+	args := m.Called(ctx, owner, repo)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Dependency), args.Error(1)
+}
+
+func (m *DependencyRepository) GetAll(ctx context.Context) ([]*entity.Dependency, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Dependency), args.Error(1)
+}
+
+func (m *DependencyRepository) Update(ctx context.Context, dep *entity.Dependency) error {
+	args := m.Called(ctx, dep)
+	return args.Error(0)
+}
+
+func (m *DependencyRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *DependencyRepository) GetByNameCI(ctx context.Context, name string) (*entity.Dependency, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Dependency), args.Error(1)
+}
+
+func (m *DependencyRepository) SearchByName(ctx context.Context, name string) ([]*entity.Dependency, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Dependency), args.Error(1)
+}
+
+func (m *DependencyRepository) SearchPage(ctx context.Context, name string, limit, offset int) ([]*repository.DependencyUsage, int64, error) {
+	args := m.Called(ctx, name, limit, offset)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*repository.DependencyUsage), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *DependencyRepository) GetByOwnerRepoCI(ctx context.Context, owner, repo string) (*entity.Dependency, error) {
+	args := m.Called(ctx, owner, repo)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Dependency), args.Error(1)
+}
+
+// AppDependencyRepository is a testify mock of repository.AppDependencyRepository
+type AppDependencyRepository struct {
+	mock.Mock
+}
+
+func (m *AppDependencyRepository) Create(ctx context.Context, appDep *entity.AppDependency) error {
+	args := m.Called(ctx, appDep)
+	return args.Error(0)
+}
+
+func (m *AppDependencyRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.AppDependency, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.AppDependency), args.Error(1)
+}
+
+func (m *AppDependencyRepository) GetByAppID(ctx context.Context, appID uuid.UUID) ([]*entity.AppDependency, error) {
+	args := m.Called(ctx, appID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.AppDependency), args.Error(1)
+}
+
+func (m *AppDependencyRepository) GetByDependencyID(ctx context.Context, depID uuid.UUID) ([]*entity.AppDependency, error) {
+	args := m.Called(ctx, depID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.AppDependency), args.Error(1)
+}
+
+func (m *AppDependencyRepository) Update(ctx context.Context, appDep *entity.AppDependency) error {
+	args := m.Called(ctx, appDep)
+	return args.Error(0)
+}
+
+func (m *AppDependencyRepository) UpdateIfUnchanged(ctx context.Context, appDep *entity.AppDependency, expectedUpdatedAt time.Time) (bool, error) {
+	args := m.Called(ctx, appDep, expectedUpdatedAt)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *AppDependencyRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *AppDependencyRepository) GetByStatus(ctx context.Context, status string) ([]*entity.AppDependency, error) {
+	args := m.Called(ctx, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.AppDependency), args.Error(1)
+}
+
+func (m *AppDependencyRepository) GetByAppAndDependencyID(ctx context.Context, appID, depID uuid.UUID) (*entity.AppDependency, error) {
+	args := m.Called(ctx, appID, depID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.AppDependency), args.Error(1)
+}
+
+// DependencyVersionRepository is a testify mock of repository.DependencyVersionRepository
+type DependencyVersionRepository struct {
+	mock.Mock
+}
+
+func (m *DependencyVersionRepository) Create(ctx context.Context, ver *entity.DependencyVersion) error {
+	args := m.Called(ctx, ver)
+	return args.Error(0)
+}
+
+func (m *DependencyVersionRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.DependencyVersion, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.DependencyVersion), args.Error(1)
+}
+
+func (m *DependencyVersionRepository) GetByDependencyID(ctx context.Context, depID uuid.UUID) ([]*entity.DependencyVersion, error) {
+	args := m.Called(ctx, depID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.DependencyVersion), args.Error(1)
+}
+
+func (m *DependencyVersionRepository) Update(ctx context.Context, ver *entity.DependencyVersion) error {
+	args := m.Called(ctx, ver)
+	return args.Error(0)
+}
+
+func (m *DependencyVersionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *DependencyVersionRepository) GetByTag(ctx context.Context, tag string) ([]*entity.DependencyVersion, error) {
+	args := m.Called(ctx, tag)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.DependencyVersion), args.Error(1)
+}
+
+// AuditTrailRepository is a testify mock of repository.AuditTrailRepository
+type AuditTrailRepository struct {
+	mock.Mock
+}
+
+func (m *AuditTrailRepository) Create(ctx context.Context, audit *entity.AuditTrail) error {
+	args := m.Called(ctx, audit)
+	return args.Error(0)
+}
+
+func (m *AuditTrailRepository) LogAction(ctx context.Context, entityType string, entityID uuid.UUID, action string, oldValues, newValues interface{}, performedBy string) error {
+	args := m.Called(ctx, entityType, entityID, action, oldValues, newValues, performedBy)
+	return args.Error(0)
+}
+
+func (m *AuditTrailRepository) LogSecurityEvent(ctx context.Context, entityType string, entityID uuid.UUID, action string, riskLevel string, context interface{}, performedBy string) error {
+	args := m.Called(ctx, entityType, entityID, action, riskLevel, context, performedBy)
+	return args.Error(0)
+}
+
+func (m *AuditTrailRepository) GetByEntity(ctx context.Context, entityType string, entityID uuid.UUID, limit, offset int) ([]*entity.AuditTrail, error) {
+	args := m.Called(ctx, entityType, entityID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.AuditTrail), args.Error(1)
+}
+
+func (m *AuditTrailRepository) GetSecurityEvents(ctx context.Context, limit, offset int) ([]*entity.AuditTrail, error) {
+	args := m.Called(ctx, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.AuditTrail), args.Error(1)
+}
+
+func (m *AuditTrailRepository) GetByTimeRange(ctx context.Context, startTime, endTime time.Time, limit, offset int) ([]*entity.AuditTrail, error) {
+	args := m.Called(ctx, startTime, endTime, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.AuditTrail), args.Error(1)
+}
+
+func (m *AuditTrailRepository) CleanupOldRecords(ctx context.Context, olderThan time.Time) error {
+	args := m.Called(ctx, olderThan)
+	return args.Error(0)
+}
+
+// ScanResultRepository is a testify mock of repository.ScanResultRepository
+type ScanResultRepository struct {
+	mock.Mock
+}
+
+func (m *ScanResultRepository) Create(ctx context.Context, scan *entity.ScanResult) error {
+	args := m.Called(ctx, scan)
+	return args.Error(0)
+}
+
+func (m *ScanResultRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.ScanResult, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ScanResult), args.Error(1)
+}
+
+func (m *ScanResultRepository) GetByAppID(ctx context.Context, appID uuid.UUID, limit, offset int) ([]*entity.ScanResult, error) {
+	args := m.Called(ctx, appID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.ScanResult), args.Error(1)
+}
+
+func (m *ScanResultRepository) Update(ctx context.Context, scan *entity.ScanResult) error {
+	args := m.Called(ctx, scan)
+	return args.Error(0)
+}
+
+func (m *ScanResultRepository) GetLatestCompletedPerApp(ctx context.Context) ([]*entity.ScanResult, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.ScanResult), args.Error(1)
+}
+
+func (m *ScanResultRepository) GetLatestCompletedByAppID(ctx context.Context, appID uuid.UUID) (*entity.ScanResult, error) {
+	args := m.Called(ctx, appID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ScanResult), args.Error(1)
+}
+
+func (m *ScanResultRepository) CleanupOldRecords(ctx context.Context, olderThan time.Time, keepPerApp int) ([]*entity.ScanResult, error) {
+	args := m.Called(ctx, olderThan, keepPerApp)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.ScanResult), args.Error(1)
+}
+
+func (m *ScanResultRepository) QueryFindings(ctx context.Context, filter repository.FindingFilter) ([]*entity.Finding, int64, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*entity.Finding), args.Get(1).(int64), args.Error(2)
+}
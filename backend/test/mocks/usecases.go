@@ -0,0 +1,233 @@
+package mocks
+
+import (
+	"context"
+	"elang-backend/internal/model"
+	"elang-backend/internal/usecase"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// Compile-time checks: a method added to a usecase interface breaks the build here, not in every test
+var (
+	_ usecase.GitHubAPIInterface     = (*GitHubAPI)(nil)
+	_ usecase.ObjectStorageInterface = (*ObjectStorage)(nil)
+)
+
+// GitHubAPI is a testify mock of usecase.GitHubAPIInterface
+type GitHubAPI struct {
+	mock.Mock
+}
+
+func (m *GitHubAPI) GetDefaultBranch(owner, repo string) (string, error) {
+	args := m.Called(owner, repo)
+	return args.String(0), args.Error(1)
+}
+
+func (m *GitHubAPI) GetListCommits(owner, repo, branch string, limit int) ([]map[string]interface{}, error) {
+	args := m.Called(owner, repo, branch, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]map[string]interface{}), args.Error(1)
+}
+
+func (m *GitHubAPI) GetCommitsDetail(owner, repo, sha string) (*model.CommitDetail, error) {
+	args := m.Called(owner, repo, sha)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.CommitDetail), args.Error(1)
+}
+
+func (m *GitHubAPI) GetFileContent(owner, repo, path, ref string) (string, error) {
+	args := m.Called(owner, repo, path, ref)
+	return args.String(0), args.Error(1)
+}
+
+func (m *GitHubAPI) GetRepoInfo(owner, repo string) (map[string]interface{}, error) {
+	args := m.Called(owner, repo)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]interface{}), args.Error(1)
+}
+
+func (m *GitHubAPI) GetRepoInfoIfModified(owner, repo string, since usecase.GitHubValidators) (map[string]interface{}, usecase.GitHubValidators, error) {
+	args := m.Called(owner, repo, since)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(usecase.GitHubValidators), args.Error(2)
+	}
+	return args.Get(0).(map[string]interface{}), args.Get(1).(usecase.GitHubValidators), args.Error(2)
+}
+
+func (m *GitHubAPI) ListBranches(owner, repo string) ([]string, error) {
+	args := m.Called(owner, repo)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *GitHubAPI) ListTags(owner, repo string) ([]map[string]interface{}, error) {
+	args := m.Called(owner, repo)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]map[string]interface{}), args.Error(1)
+}
+
+func (m *GitHubAPI) ListPullRequests(owner, repo string, state string) ([]map[string]interface{}, error) {
+	args := m.Called(owner, repo, state)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]map[string]interface{}), args.Error(1)
+}
+
+func (m *GitHubAPI) GetPullRequestDetail(owner, repo string, number int) (map[string]interface{}, error) {
+	args := m.Called(owner, repo, number)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]interface{}), args.Error(1)
+}
+
+func (m *GitHubAPI) ListIssues(owner, repo string, state string) ([]map[string]interface{}, error) {
+	args := m.Called(owner, repo, state)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]map[string]interface{}), args.Error(1)
+}
+
+func (m *GitHubAPI) GetIssueDetail(owner, repo string, number int) (map[string]interface{}, error) {
+	args := m.Called(owner, repo, number)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]interface{}), args.Error(1)
+}
+
+func (m *GitHubAPI) ListDirectoryContents(owner, repo, path, ref string) ([]map[string]interface{}, error) {
+	args := m.Called(owner, repo, path, ref)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]map[string]interface{}), args.Error(1)
+}
+
+func (m *GitHubAPI) GetUserInfo(username string) (map[string]interface{}, error) {
+	args := m.Called(username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]interface{}), args.Error(1)
+}
+
+func (m *GitHubAPI) ListCollaborators(owner, repo string) ([]map[string]interface{}, error) {
+	args := m.Called(owner, repo)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]map[string]interface{}), args.Error(1)
+}
+
+func (m *GitHubAPI) ListWebhooks(owner, repo string) ([]map[string]interface{}, error) {
+	args := m.Called(owner, repo)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]map[string]interface{}), args.Error(1)
+}
+
+func (m *GitHubAPI) CompareCommits(owner, repo, base, head string) (*model.CompareCommitResult, error) {
+	args := m.Called(owner, repo, base, head)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.CompareCommitResult), args.Error(1)
+}
+
+func (m *GitHubAPI) ListReleases(owner, repo string) ([]model.Release, error) {
+	args := m.Called(owner, repo)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Release), args.Error(1)
+}
+
+func (m *GitHubAPI) FindMatchingTag(owner, repo, version string) (string, error) {
+	args := m.Called(owner, repo, version)
+	return args.String(0), args.Error(1)
+}
+
+// ObjectStorage is a testify mock of usecase.ObjectStorageInterface
+type ObjectStorage struct {
+	mock.Mock
+}
+
+func (m *ObjectStorage) SaveSBOM(ctx context.Context, appID string, appName string, sbomData []byte, format string) (string, error) {
+	args := m.Called(ctx, appID, appName, sbomData, format)
+	return args.String(0), args.Error(1)
+}
+
+func (m *ObjectStorage) GetSBOM(ctx context.Context, objectKey string) ([]byte, error) {
+	args := m.Called(ctx, objectKey)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *ObjectStorage) ListSBOMs(ctx context.Context, appName string) ([]string, error) {
+	args := m.Called(ctx, appName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *ObjectStorage) DeleteSBOM(ctx context.Context, objectKey string) error {
+	args := m.Called(ctx, objectKey)
+	return args.Error(0)
+}
+
+func (m *ObjectStorage) SaveVulnerabilityReport(ctx context.Context, appID string, appName string, reportData []byte, format string) (string, error) {
+	args := m.Called(ctx, appID, appName, reportData, format)
+	return args.String(0), args.Error(1)
+}
+
+func (m *ObjectStorage) GetVulnerabilityReport(ctx context.Context, objectKey string) ([]byte, error) {
+	args := m.Called(ctx, objectKey)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *ObjectStorage) ListVulnerabilityReports(ctx context.Context, appName string) ([]string, error) {
+	args := m.Called(ctx, appName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *ObjectStorage) SaveManifest(ctx context.Context, appID string, fileName string, content []byte) (string, error) {
+	args := m.Called(ctx, appID, fileName, content)
+	return args.String(0), args.Error(1)
+}
+
+func (m *ObjectStorage) GetManifest(ctx context.Context, objectKey string) ([]byte, error) {
+	args := m.Called(ctx, objectKey)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *ObjectStorage) DeleteManifest(ctx context.Context, objectKey string) error {
+	args := m.Called(ctx, objectKey)
+	return args.Error(0)
+}
@@ -152,6 +152,43 @@ func TestAppDependencyRepository_Update(t *testing.T) {
 	assert.Equal(t, "2.0.0", found.UsedVersion)
 }
 
+func TestAppDependencyRepository_UpdateIfUnchanged(t *testing.T) {
+	db := setupTestDB(t)
+	appRepo := repository.NewAppRepository(db)
+	depRepo := repository.NewDependencyRepository(db)
+	appDepRepo := repository.NewAppDependencyRepository(db)
+	ctx := context.Background()
+
+	app := &entity.App{ID: uuid.New(), Name: "test-app", Status: "active"}
+	require.NoError(t, appRepo.Create(ctx, app))
+	dep := &entity.Dependency{ID: uuid.New(), Name: "test-dep", Owner: "owner", Repo: "repo"}
+	require.NoError(t, depRepo.Create(ctx, dep))
+	require.NoError(t, appDepRepo.Create(ctx, &entity.AppDependency{ID: uuid.New(), AppID: app.ID, DependencyID: dep.ID, UsedVersion: "1.0.0"}))
+
+	// Two writers read the same row
+	first, err := appDepRepo.GetByAppAndDependencyID(ctx, app.ID, dep.ID)
+	require.NoError(t, err)
+	second, err := appDepRepo.GetByAppAndDependencyID(ctx, app.ID, dep.ID)
+	require.NoError(t, err)
+
+	time.Sleep(time.Millisecond) // make sure the first write moves updated_at
+	first.UsedVersion = "2.0.0"
+	saved, err := appDepRepo.UpdateIfUnchanged(ctx, first, first.UpdatedAt)
+	require.NoError(t, err)
+	assert.True(t, saved)
+
+	// The second writer's copy is now stale and must not overwrite the first write
+	second.UsedVersion = "3.0.0"
+	saved, err = appDepRepo.UpdateIfUnchanged(ctx, second, second.UpdatedAt)
+	require.NoError(t, err)
+	assert.False(t, saved)
+
+	found, err := appDepRepo.GetByID(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "2.0.0", found.UsedVersion)
+	assert.True(t, found.UpdatedAt.Equal(first.UpdatedAt))
+}
+
 func TestAppDependencyRepository_Delete(t *testing.T) {
 	db := setupTestDB(t)
 	appRepo := repository.NewAppRepository(db)
@@ -180,7 +217,7 @@ func TestAppDependencyRepository_Delete(t *testing.T) {
 	assert.NoError(t, err)
 
 	found, err := appDepRepo.GetByID(ctx, appDep.ID)
-	assert.NoError(t, err)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
 	assert.Nil(t, found)
 }
 
@@ -26,6 +26,10 @@ func setupTestDB(t *testing.T) *gorm.DB {
 		&entity.AppDependency{},
 		&entity.DependencyVersion{},
 		&entity.AuditTrail{},
+		&entity.ScanResult{},
+		&entity.Finding{},
+		&entity.Tag{},
+		&entity.AppTag{},
 	)
 	require.NoError(t, err)
 
@@ -76,7 +80,7 @@ func TestApplicationRepository_GetByID(t *testing.T) {
 
 	t.Run("NotFound", func(t *testing.T) {
 		found, err := repo.GetByID(ctx, uuid.New())
-		assert.NoError(t, err)
+		assert.ErrorIs(t, err, repository.ErrNotFound)
 		assert.Nil(t, found)
 	})
 }
@@ -140,7 +144,7 @@ func TestApplicationRepository_Delete(t *testing.T) {
 	assert.NoError(t, err)
 
 	found, err := repo.GetByID(ctx, app.ID)
-	assert.NoError(t, err)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
 	assert.Nil(t, found)
 }
 
@@ -213,6 +217,44 @@ func TestApplicationRepository_UpdateStatus(t *testing.T) {
 	assert.Equal(t, "inactive", found.Status)
 }
 
+func TestApplicationRepository_OwnerScope(t *testing.T) {
+	db := setupTestDB(t)
+	repo := repository.NewAppRepository(db)
+	ctx := context.Background()
+
+	aliceApp := &entity.App{ID: uuid.New(), Name: "shared-name", Status: "active", OwnerID: stringPtr("alice")}
+	bobApp := &entity.App{ID: uuid.New(), Name: "shared-name", Status: "active", OwnerID: stringPtr("bob")}
+	require.NoError(t, repo.Create(ctx, aliceApp))
+	require.NoError(t, repo.Create(ctx, bobApp))
+
+	aliceCtx := repository.WithOwnerScope(ctx, "alice")
+
+	apps, err := repo.GetAll(aliceCtx)
+	require.NoError(t, err)
+	require.Len(t, apps, 1)
+	assert.Equal(t, aliceApp.ID, apps[0].ID)
+
+	found, err := repo.GetByID(aliceCtx, bobApp.ID)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	assert.Nil(t, found, "another owner's app must not be visible")
+
+	byName, err := repo.GetByName(aliceCtx, "shared-name")
+	require.NoError(t, err)
+	require.NotNil(t, byName)
+	assert.Equal(t, aliceApp.ID, byName.ID)
+
+	// Status changes cannot reach another owner's app
+	require.NoError(t, repo.UpdateStatus(aliceCtx, bobApp.ID, "inactive"))
+	stored, err := repo.GetByID(ctx, bobApp.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "active", stored.Status)
+
+	// Unscoped contexts (background jobs) see every app
+	all, err := repo.GetAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+}
+
 func stringPtr(s string) *string {
 	return &s
 }
@@ -110,6 +110,87 @@ func TestDependencyRepository_SearchByName(t *testing.T) {
 	assert.Len(t, results, 2)
 }
 
+func TestDependencyRepository_SearchPage(t *testing.T) {
+	db := setupTestDB(t)
+	repo := repository.NewDependencyRepository(db)
+	appRepo := repository.NewAppRepository(db)
+	appDepRepo := repository.NewAppDependencyRepository(db)
+	ctx := context.Background()
+
+	deps := []*entity.Dependency{
+		{ID: uuid.New(), Name: "express", Owner: "owner1", Repo: "repo1"},
+		{ID: uuid.New(), Name: "Express-Validator", Owner: "owner2", Repo: "repo2"},
+		{ID: uuid.New(), Name: "lodash", Owner: "owner3", Repo: "repo3"},
+	}
+	for _, dep := range deps {
+		require.NoError(t, repo.Create(ctx, dep))
+	}
+
+	alice, bob := "alice", "bob"
+	apps := []*entity.App{
+		{ID: uuid.New(), Name: "app-1", Status: "active", OwnerID: &alice},
+		{ID: uuid.New(), Name: "app-2", Status: "active", OwnerID: &bob},
+		{ID: uuid.New(), Name: "deleted-app", Status: "active", OwnerID: &alice, IsDeleted: true},
+	}
+	for _, app := range apps {
+		require.NoError(t, appRepo.Create(ctx, app))
+	}
+	for _, link := range []struct{ app, dep int }{{0, 0}, {1, 0}, {2, 0}, {0, 2}} {
+		require.NoError(t, appDepRepo.Create(ctx, &entity.AppDependency{
+			ID:           uuid.New(),
+			AppID:        apps[link.app].ID,
+			DependencyID: deps[link.dep].ID,
+			UsedVersion:  "1.0.0",
+		}))
+	}
+
+	t.Run("SearchCountsApplications", func(t *testing.T) {
+		results, total, err := repo.SearchPage(ctx, "express", 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+		require.Len(t, results, 2)
+		assert.Equal(t, "express", results[0].Name)
+		assert.Equal(t, 2, results[0].AppCount, "deleted applications are not counted")
+		assert.Equal(t, "Express-Validator", results[1].Name)
+		assert.Equal(t, 0, results[1].AppCount)
+	})
+
+	t.Run("Paging", func(t *testing.T) {
+		results, total, err := repo.SearchPage(ctx, "", 1, 1)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), total)
+		require.Len(t, results, 1)
+		assert.Equal(t, "Express-Validator", results[0].Name)
+	})
+
+	t.Run("OwnerScope", func(t *testing.T) {
+		results, total, err := repo.SearchPage(repository.WithOwnerScope(ctx, bob), "express", 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), total, "dependencies none of bob's applications use are not listed")
+		require.Len(t, results, 1)
+		assert.Equal(t, "express", results[0].Name)
+		assert.Equal(t, 1, results[0].AppCount)
+
+		_, total, err = repo.SearchPage(repository.WithOwnerScope(ctx, bob), "lodash", 10, 0)
+		require.NoError(t, err)
+		assert.Zero(t, total)
+	})
+
+	t.Run("WildcardsMatchLiterally", func(t *testing.T) {
+		require.NoError(t, repo.Create(ctx, &entity.Dependency{ID: uuid.New(), Name: "ts_node", Owner: "owner4", Repo: "repo4"}))
+		for search, want := range map[string][]string{"_": {"ts_node"}, "%": nil, `s\_n`: nil} {
+			results, total, err := repo.SearchPage(ctx, search, 10, 0)
+			require.NoError(t, err)
+			var names []string
+			for _, result := range results {
+				names = append(names, result.Name)
+			}
+			assert.Equal(t, want, names, search)
+			assert.Equal(t, int64(len(want)), total, search)
+		}
+	})
+}
+
 func TestDependencyRepository_GetByNameCI(t *testing.T) {
 	db := setupTestDB(t)
 	repo := repository.NewDependencyRepository(db)
@@ -182,7 +263,7 @@ func TestDependencyRepository_Delete(t *testing.T) {
 	assert.NoError(t, err)
 
 	found, err := repo.GetByID(ctx, dep.ID)
-	assert.NoError(t, err)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
 	assert.Nil(t, found)
 }
 
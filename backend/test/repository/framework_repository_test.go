@@ -45,7 +45,7 @@ func TestFrameworkRepository_GetByID(t *testing.T) {
 
 	t.Run("NotFound", func(t *testing.T) {
 		found, err := repo.GetByID(ctx, 99999)
-		assert.NoError(t, err)
+		assert.ErrorIs(t, err, repository.ErrNotFound)
 		assert.Nil(t, found)
 	})
 }
@@ -106,7 +106,7 @@ func TestFrameworkRepository_Delete(t *testing.T) {
 	assert.NoError(t, err)
 
 	found, err := repo.GetByID(ctx, framework.ID)
-	assert.NoError(t, err)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
 	assert.Nil(t, found)
 }
 
@@ -172,6 +172,50 @@ func TestFrameworkRepository_GetByNameCI(t *testing.T) {
 	}
 }
 
+func TestFrameworkRepository_GetByRuntimeID(t *testing.T) {
+	db := setupTestDB(t)
+	repo := repository.NewFrameworkRepository(db)
+	ctx := context.Background()
+
+	goRuntime, nodeRuntime, gradleRuntime := entity.Runtime{Name: "Go"}, entity.Runtime{Name: "Node.js"}, entity.Runtime{Name: "Gradle"}
+	for _, runtime := range []*entity.Runtime{&goRuntime, &nodeRuntime, &gradleRuntime} {
+		require.NoError(t, db.Create(runtime).Error)
+	}
+	for _, fw := range []*entity.Framework{
+		{Name: "Gin", Runtimes: []entity.Runtime{goRuntime}},
+		{Name: "Echo", Runtimes: []entity.Runtime{goRuntime}},
+		{Name: "Express", Runtimes: []entity.Runtime{nodeRuntime}},
+		{Name: "Unassigned"},
+	} {
+		require.NoError(t, repo.Create(ctx, fw))
+	}
+
+	frameworks, err := repo.GetByRuntimeID(ctx, goRuntime.ID)
+	require.NoError(t, err)
+	var names []string
+	for _, fw := range frameworks {
+		names = append(names, fw.Name)
+	}
+	assert.Equal(t, []string{"Echo", "Gin"}, names)
+
+	frameworks, err = repo.GetByRuntimeID(ctx, 99)
+	require.NoError(t, err)
+	assert.Empty(t, frameworks)
+
+	// A framework can be linked to further runtimes, once each
+	express, err := repo.GetByName(ctx, "Express")
+	require.NoError(t, err)
+	require.NoError(t, repo.LinkRuntime(ctx, express.ID, gradleRuntime.ID))
+	require.NoError(t, repo.LinkRuntime(ctx, express.ID, gradleRuntime.ID))
+	express, err = repo.GetByID(ctx, express.ID)
+	require.NoError(t, err)
+	assert.Len(t, express.Runtimes, 2)
+	frameworks, err = repo.GetByRuntimeID(ctx, gradleRuntime.ID)
+	require.NoError(t, err)
+	require.Len(t, frameworks, 1)
+	assert.Equal(t, "Express", frameworks[0].Name)
+}
+
 func TestFrameworkRepository_ConcurrentCreates(t *testing.T) {
 	// Skip concurrent test as it requires more complex setup
 	t.Skip("Skipping concurrent test - requires more complex database setup")
@@ -49,7 +49,7 @@ func TestRuntimeRepository_GetByID(t *testing.T) {
 
 	t.Run("NotFound", func(t *testing.T) {
 		found, err := repo.GetByID(ctx, 99999)
-		assert.NoError(t, err)
+		assert.ErrorIs(t, err, repository.ErrNotFound)
 		assert.Nil(t, found)
 	})
 }
@@ -110,7 +110,7 @@ func TestRuntimeRepository_Delete(t *testing.T) {
 	assert.NoError(t, err)
 
 	found, err := repo.GetByID(ctx, runtime.ID)
-	assert.NoError(t, err)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
 	assert.Nil(t, found)
 }
 
@@ -0,0 +1,211 @@
+package repository_test
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+	"elang-backend/internal/repository"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanResultRepository_CreateAndGetByID(t *testing.T) {
+	db := setupTestDB(t)
+	repo := repository.NewScanResultRepository(db)
+	ctx := context.Background()
+
+	appID := uuid.New()
+	scan := &entity.ScanResult{
+		ID:           uuid.New(),
+		AppID:        &appID,
+		AppName:      "test-app",
+		ScanType:     "application",
+		Status:       "completed",
+		PolicyStatus: "passed",
+		Critical:     1,
+		Result:       []byte(`{"scan_id":"x"}`),
+	}
+	err := repo.Create(ctx, scan)
+	require.NoError(t, err)
+
+	t.Run("Found", func(t *testing.T) {
+		found, err := repo.GetByID(ctx, scan.ID)
+		assert.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, "test-app", found.AppName)
+		assert.Equal(t, 1, found.Critical)
+		assert.JSONEq(t, `{"scan_id":"x"}`, string(found.Result))
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		found, err := repo.GetByID(ctx, uuid.New())
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+		assert.Nil(t, found)
+	})
+}
+
+func TestScanResultRepository_GetByAppID(t *testing.T) {
+	db := setupTestDB(t)
+	repo := repository.NewScanResultRepository(db)
+	ctx := context.Background()
+
+	appID := uuid.New()
+	now := time.Now().UTC()
+	for i := 0; i < 3; i++ {
+		err := repo.Create(ctx, &entity.ScanResult{
+			ID:        uuid.New(),
+			AppID:     &appID,
+			AppName:   "test-app",
+			CreatedAt: now.Add(time.Duration(i) * time.Minute),
+		})
+		require.NoError(t, err)
+	}
+
+	scans, err := repo.GetByAppID(ctx, appID, 2, 0)
+	assert.NoError(t, err)
+	require.Len(t, scans, 2)
+	assert.True(t, scans[0].CreatedAt.After(scans[1].CreatedAt), "scans should be ordered newest first")
+}
+
+func TestScanResultRepository_CleanupOldRecords(t *testing.T) {
+	db := setupTestDB(t)
+	repo := repository.NewScanResultRepository(db)
+	ctx := context.Background()
+
+	now := time.Now()
+	appID := uuid.New()
+	newScan := func(appID *uuid.UUID, status string, age time.Duration) *entity.ScanResult {
+		scan := &entity.ScanResult{ID: uuid.New(), AppID: appID, AppName: "retention-app", Status: status, CreatedAt: now.Add(-age)}
+		require.NoError(t, repo.Create(ctx, scan))
+		return scan
+	}
+
+	recent := newScan(&appID, "completed", 24*time.Hour)
+	oldKept := newScan(&appID, "completed", 100*24*time.Hour)
+	oldDeleted := newScan(&appID, "failed", 200*24*time.Hour)
+	oldRunning := newScan(&appID, "running", 300*24*time.Hour)
+	oldAdhoc := newScan(nil, "completed", 150*24*time.Hour)
+
+	// Keep the two newest scans per app; everything else older than 90 days goes
+	deleted, err := repo.CleanupOldRecords(ctx, now.Add(-90*24*time.Hour), 2)
+	require.NoError(t, err)
+
+	var deletedIDs []uuid.UUID
+	for _, scan := range deleted {
+		deletedIDs = append(deletedIDs, scan.ID)
+	}
+	assert.ElementsMatch(t, []uuid.UUID{oldDeleted.ID, oldAdhoc.ID}, deletedIDs)
+
+	for _, kept := range []*entity.ScanResult{recent, oldKept, oldRunning} {
+		found, err := repo.GetByID(ctx, kept.ID)
+		require.NoError(t, err)
+		assert.NotNil(t, found, "scan %s should be kept", kept.ID)
+	}
+	for _, id := range deletedIDs {
+		found, err := repo.GetByID(ctx, id)
+		require.ErrorIs(t, err, repository.ErrNotFound)
+		assert.Nil(t, found)
+	}
+}
+
+func TestScanResultRepository_GetLatestCompletedPerApp(t *testing.T) {
+	db := setupTestDB(t)
+	appRepo := repository.NewAppRepository(db)
+	repo := repository.NewScanResultRepository(db)
+	ctx := context.Background()
+
+	alice, bob := "alice", "bob"
+	first := &entity.App{ID: uuid.New(), Name: "first", Status: "active", OwnerID: &alice}
+	second := &entity.App{ID: uuid.New(), Name: "second", Status: "active", OwnerID: &bob}
+	deleted := &entity.App{ID: uuid.New(), Name: "deleted", Status: "inactive", IsDeleted: true}
+	for _, app := range []*entity.App{first, second, deleted} {
+		require.NoError(t, appRepo.Create(ctx, app))
+	}
+
+	now := time.Now().UTC()
+	newScan := func(appID uuid.UUID, status string, age time.Duration, critical int) *entity.ScanResult {
+		scan := &entity.ScanResult{
+			ID:        uuid.New(),
+			AppID:     &appID,
+			ScanType:  "application",
+			Status:    status,
+			Critical:  critical,
+			CreatedAt: now.Add(-age),
+		}
+		require.NoError(t, repo.Create(ctx, scan))
+		return scan
+	}
+	newScan(first.ID, "completed", 2*time.Hour, 5)
+	latestFirst := newScan(first.ID, "completed", time.Hour, 1)
+	newScan(first.ID, "running", time.Minute, 0) // unfinished scans are ignored
+	latestSecond := newScan(second.ID, "completed", 3*time.Hour, 2)
+	newScan(second.ID, "failed", time.Hour, 0)
+	newScan(deleted.ID, "completed", time.Hour, 9)
+
+	t.Run("Unscoped", func(t *testing.T) {
+		scans, err := repo.GetLatestCompletedPerApp(ctx)
+		require.NoError(t, err)
+		ids := make([]uuid.UUID, 0, len(scans))
+		for _, scan := range scans {
+			ids = append(ids, scan.ID)
+		}
+		assert.ElementsMatch(t, []uuid.UUID{latestFirst.ID, latestSecond.ID}, ids)
+	})
+
+	t.Run("OwnerScoped", func(t *testing.T) {
+		scans, err := repo.GetLatestCompletedPerApp(repository.WithOwnerScope(ctx, "bob"))
+		require.NoError(t, err)
+		require.Len(t, scans, 1)
+		assert.Equal(t, latestSecond.ID, scans[0].ID)
+	})
+}
+
+func TestScanResultRepository_GetLatestCompletedByAppID(t *testing.T) {
+	db := setupTestDB(t)
+	repo := repository.NewScanResultRepository(db)
+	ctx := context.Background()
+
+	appID := uuid.New()
+	now := time.Now().UTC()
+	completed := &entity.ScanResult{ID: uuid.New(), AppID: &appID, AppName: "app", ScanType: "application", Status: "completed", CreatedAt: now.Add(-time.Hour)}
+	running := &entity.ScanResult{ID: uuid.New(), AppID: &appID, AppName: "app", ScanType: "application", Status: "running", CreatedAt: now}
+	older := &entity.ScanResult{ID: uuid.New(), AppID: &appID, AppName: "app", ScanType: "application", Status: "completed", CreatedAt: now.Add(-2 * time.Hour)}
+	for _, scan := range []*entity.ScanResult{completed, running, older} {
+		require.NoError(t, repo.Create(ctx, scan))
+	}
+
+	latest, err := repo.GetLatestCompletedByAppID(ctx, appID)
+	require.NoError(t, err)
+	assert.Equal(t, completed.ID, latest.ID)
+
+	_, err = repo.GetLatestCompletedByAppID(ctx, uuid.New())
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
+
+func TestScanResultRepository_QueryFindings_DependencyWildcardsMatchLiterally(t *testing.T) {
+	db := setupTestDB(t)
+	repo := repository.NewScanResultRepository(db)
+	ctx := context.Background()
+
+	appID := uuid.New()
+	scan := &entity.ScanResult{ID: uuid.New(), AppID: &appID, AppName: "app", ScanType: "application", Status: "completed"}
+	for _, dependency := range []string{"ts_node", "100%-coverage", "lodash"} {
+		scan.Findings = append(scan.Findings, entity.Finding{ID: uuid.New(), AppID: appID, Dependency: dependency,
+			Version: "1.0.0", VulnerabilityID: "GHSA-" + dependency, Severity: "high", ScannedAt: time.Now().UTC()})
+	}
+	require.NoError(t, repo.Create(ctx, scan))
+
+	for search, want := range map[string][]string{"_": {"ts_node"}, "%": {"100%-coverage"}, "S_N": {"ts_node"}, "s%n": nil} {
+		findings, total, err := repo.QueryFindings(ctx, repository.FindingFilter{AppID: appID, Dependency: search})
+		require.NoError(t, err)
+		var names []string
+		for _, finding := range findings {
+			names = append(names, finding.Dependency)
+		}
+		assert.Equal(t, want, names, search)
+		assert.Equal(t, int64(len(want)), total, search)
+	}
+}
@@ -0,0 +1,92 @@
+package repository_test
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+	"elang-backend/internal/repository"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// setupSharedTestDB opens an in-memory database shared by all pooled connections,
+// which transactions need since they run on a dedicated connection
+func setupSharedTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open("file:"+uuid.NewString()+"?mode=memory&cache=shared"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&entity.App{}, &entity.AuditTrail{}))
+	return db
+}
+
+func TestUnitOfWork_CommitsOnSuccess(t *testing.T) {
+	db := setupSharedTestDB(t)
+	uow := repository.NewUnitOfWork(db)
+	appRepo := repository.NewAppRepository(db)
+	auditRepo := repository.NewAuditTrailRepository(db)
+	ctx := context.Background()
+
+	app := &entity.App{ID: uuid.New(), Name: "committed-app", Status: "inactive"}
+	err := uow.Do(ctx, func(txCtx context.Context) error {
+		if err := appRepo.Create(txCtx, app); err != nil {
+			return err
+		}
+		return auditRepo.Create(txCtx, &entity.AuditTrail{ID: uuid.New(), EntityType: "app", EntityID: app.ID, Action: "application_created", PerformedBy: "test"})
+	})
+	require.NoError(t, err)
+
+	stored, err := appRepo.GetByID(ctx, app.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, stored)
+
+	audits, err := auditRepo.GetByEntity(ctx, "app", app.ID, 10, 0)
+	require.NoError(t, err)
+	assert.Len(t, audits, 1)
+}
+
+func TestUnitOfWork_RollsBackOnError(t *testing.T) {
+	db := setupSharedTestDB(t)
+	uow := repository.NewUnitOfWork(db)
+	appRepo := repository.NewAppRepository(db)
+	ctx := context.Background()
+
+	app := &entity.App{ID: uuid.New(), Name: "rolled-back-app", Status: "inactive"}
+	failure := errors.New("audit write failed")
+	err := uow.Do(ctx, func(txCtx context.Context) error {
+		if err := appRepo.Create(txCtx, app); err != nil {
+			return err
+		}
+		return failure
+	})
+	assert.ErrorIs(t, err, failure)
+
+	stored, err := appRepo.GetByID(ctx, app.ID)
+	require.ErrorIs(t, err, repository.ErrNotFound)
+	assert.Nil(t, stored)
+}
+
+func TestUnitOfWork_NestedJoinsOuterTransaction(t *testing.T) {
+	db := setupSharedTestDB(t)
+	uow := repository.NewUnitOfWork(db)
+	appRepo := repository.NewAppRepository(db)
+	ctx := context.Background()
+
+	app := &entity.App{ID: uuid.New(), Name: "nested-app", Status: "inactive"}
+	err := uow.Do(ctx, func(txCtx context.Context) error {
+		if err := uow.Do(txCtx, func(innerCtx context.Context) error {
+			return appRepo.Create(innerCtx, app)
+		}); err != nil {
+			return err
+		}
+		return errors.New("outer failure")
+	})
+	assert.Error(t, err)
+
+	stored, err := appRepo.GetByID(ctx, app.ID)
+	require.ErrorIs(t, err, repository.ErrNotFound)
+	assert.Nil(t, stored, "inner work must roll back with the outer transaction")
+}
@@ -0,0 +1,172 @@
+package services_test
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/model/dto"
+	"elang-backend/internal/services"
+	"elang-backend/internal/usecase"
+	"elang-backend/test/mocks"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const singleDependencyGoMod = `module example.com/demo
+
+go 1.22
+
+require github.com/google/uuid v1.6.0
+`
+
+// TestApplicationService_AddApplication_WithMocks runs AddApplication against mocked repositories and GitHub,
+// including the background processing that links the new dependency, and checks every call it makes
+func TestApplicationService_AddApplication_WithMocks(t *testing.T) {
+	ctx := context.Background()
+	runtimeID := 1
+
+	appRepo := new(mocks.ApplicationRepository)
+	runtimeRepo := new(mocks.RuntimeRepository)
+	frameworkRepo := new(mocks.FrameworkRepository)
+	depRepo := new(mocks.DependencyRepository)
+	appDepRepo := new(mocks.AppDependencyRepository)
+	depVersionRepo := new(mocks.DependencyVersionRepository)
+	auditRepo := new(mocks.AuditTrailRepository)
+	github := new(mocks.GitHubAPI)
+
+	runtimeRepo.On("GetByNameCI", ctx, "Go").Return(&entity.Runtime{ID: runtimeID, Name: "Go"}, nil)
+	frameworkRepo.On("GetByNameCI", ctx, "Gin").Return(&entity.Framework{ID: 2, Name: "Gin", Runtimes: []entity.Runtime{{ID: runtimeID, Name: "Go"}}}, nil)
+	appRepo.On("GetByName", ctx, "mocked-app").Return(nil, nil)
+	appRepo.On("Create", ctx, mock.MatchedBy(func(app *entity.App) bool {
+		return app.Name == "mocked-app" && app.Status == "inactive"
+	})).Return(nil)
+	auditRepo.On("Create", mock.Anything, mock.MatchedBy(func(audit *entity.AuditTrail) bool {
+		return audit.Action == "application_created"
+	})).Return(nil)
+
+	// Background processing of github.com/google/uuid
+	github.On("GetRepoInfo", "google", "uuid").Return(nil, errors.New("offline"))
+	github.On("GetRepoInfoIfModified", "google", "uuid", usecase.GitHubValidators{}).
+		Return(map[string]interface{}{"full_name": "google/uuid"}, usecase.GitHubValidators{ETag: `"v1"`}, nil)
+	github.On("GetDefaultBranch", "google", "uuid").Return("master", nil)
+	github.On("GetListCommits", "google", "uuid", "master", mock.Anything).Return([]map[string]interface{}{
+		{"oid": "0c7d1a4", "author_date": "2024-01-23T10:00:00Z"},
+	}, nil)
+	github.On("ListTags", "google", "uuid").Return([]map[string]interface{}{
+		{"name": "v1.6.0", "commit_sha": "0c7d1a4"},
+	}, nil)
+	github.On("FindMatchingTag", "google", "uuid", mock.Anything).Return("v1.6.0", nil)
+	depRepo.On("GetByOwnerRepoCI", mock.Anything, "google", "uuid").Return(nil, nil)
+	depRepo.On("Create", mock.Anything, mock.MatchedBy(func(dep *entity.Dependency) bool {
+		return dep.Owner == "google" && dep.Repo == "uuid"
+	})).Return(nil)
+	depRepo.On("Update", mock.Anything, mock.MatchedBy(func(dep *entity.Dependency) bool {
+		return dep.LastCommitSHA != nil && *dep.LastCommitSHA == "0c7d1a4" && dep.GitHubETag != nil && *dep.GitHubETag == `"v1"`
+	})).Return(nil)
+	depVersionRepo.On("Create", mock.Anything, mock.MatchedBy(func(version *entity.DependencyVersion) bool {
+		return version.CommitSHA == "0c7d1a4"
+	})).Return(nil)
+	appDepRepo.On("GetByAppAndDependencyID", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+	appDepRepo.On("Create", mock.Anything, mock.MatchedBy(func(appDep *entity.AppDependency) bool {
+		return appDep.UsedVersion == "v1.6.0" && appDep.UsedCommitSHA != nil && *appDep.UsedCommitSHA == "0c7d1a4"
+	})).Return(nil)
+	appRepo.On("UpdateStatus", mock.Anything, mock.Anything, "active").Return(nil)
+
+	svc := services.NewApplicationService(dto.BasicRepositories{
+		AppRepository:              appRepo,
+		RunTimeRepository:          runtimeRepo,
+		FrameWorkRepository:        frameworkRepo,
+		DepedencyRepository:        depRepo,
+		AppToDepedencyRepository:   appDepRepo,
+		DepedencyVersionRepository: depVersionRepo,
+		AuditTrailRepository:       auditRepo,
+	}, *helper.NewDependencyParser(), nil, github, services.ServiceConfig{})
+
+	resp, err := svc.AddApplication(ctx, "mocked-app", "Go", "Gin", "", "go.mod", singleDependencyGoMod, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, resp.DependencyCount)
+	assert.Equal(t, "inactive", resp.Status)
+
+	waitCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	require.NoError(t, svc.(*services.ApplicationService).Wait(waitCtx))
+
+	for _, m := range []interface{ AssertExpectations(mock.TestingT) bool }{
+		appRepo, runtimeRepo, frameworkRepo, depRepo, appDepRepo, depVersionRepo, auditRepo, github,
+	} {
+		m.AssertExpectations(t)
+	}
+	appRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, "inactive")
+}
+
+// TestApplicationService_AddApplication_CollectsEveryDependencyError fails more dependencies than there are
+// processing workers and checks that background processing finishes and reports each failure
+func TestApplicationService_AddApplication_CollectsEveryDependencyError(t *testing.T) {
+	ctx := context.Background()
+	runtimeID := 1
+	const dependencyCount = 25
+
+	var goMod strings.Builder
+	goMod.WriteString("module example.com/demo\n\ngo 1.22\n\nrequire (\n")
+	for i := 0; i < dependencyCount; i++ {
+		fmt.Fprintf(&goMod, "\tgithub.com/example/lib%d v1.0.%d\n", i, i)
+	}
+	goMod.WriteString(")\n")
+
+	appRepo := new(mocks.ApplicationRepository)
+	runtimeRepo := new(mocks.RuntimeRepository)
+	frameworkRepo := new(mocks.FrameworkRepository)
+	depRepo := new(mocks.DependencyRepository)
+	auditRepo := new(mocks.AuditTrailRepository)
+
+	runtimeRepo.On("GetByNameCI", ctx, "Go").Return(&entity.Runtime{ID: runtimeID, Name: "Go"}, nil)
+	frameworkRepo.On("GetByNameCI", ctx, "Gin").Return(&entity.Framework{ID: 2, Name: "Gin", Runtimes: []entity.Runtime{{ID: runtimeID, Name: "Go"}}}, nil)
+	appRepo.On("GetByName", ctx, "failing-app").Return(nil, nil)
+	appRepo.On("Create", ctx, mock.Anything).Return(nil)
+	appRepo.On("UpdateStatus", mock.Anything, mock.Anything, "inactive").Return(nil)
+	depRepo.On("GetByOwnerRepoCI", mock.Anything, "example", mock.Anything).Return(nil, errors.New("database unavailable"))
+
+	var failure *entity.AuditTrail
+	auditRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		if audit := args.Get(1).(*entity.AuditTrail); audit.Action == "dependency_processing_failed" {
+			failure = audit
+		}
+	}).Return(nil)
+
+	svc := services.NewApplicationService(dto.BasicRepositories{
+		AppRepository:        appRepo,
+		RunTimeRepository:    runtimeRepo,
+		FrameWorkRepository:  frameworkRepo,
+		DepedencyRepository:  depRepo,
+		AuditTrailRepository: auditRepo,
+	}, *helper.NewDependencyParser(), nil, nil, services.ServiceConfig{})
+
+	resp, err := svc.AddApplication(ctx, "failing-app", "Go", "Gin", "", "go.mod", goMod.String(), nil)
+	require.NoError(t, err)
+	require.Equal(t, dependencyCount, resp.DependencyCount)
+
+	waitCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	require.NoError(t, svc.(*services.ApplicationService).Wait(waitCtx), "background processing must not block on failures")
+
+	require.NotNil(t, failure)
+	var details struct {
+		FailedCount int      `json:"failed_count"`
+		TotalCount  int      `json:"total_count"`
+		Errors      []string `json:"errors"`
+	}
+	require.NoError(t, json.Unmarshal(failure.NewValues, &details))
+	assert.Equal(t, dependencyCount, details.FailedCount)
+	assert.Equal(t, dependencyCount, details.TotalCount)
+	assert.Len(t, details.Errors, dependencyCount)
+	depRepo.AssertNumberOfCalls(t, "GetByOwnerRepoCI", dependencyCount)
+	appRepo.AssertCalled(t, "UpdateStatus", mock.Anything, mock.Anything, "inactive")
+}
@@ -0,0 +1,89 @@
+package services_test
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/model"
+	"elang-backend/internal/services"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// slowGitHubAPI answers repository lookups after a delay and records how many were in flight at once
+type slowGitHubAPI struct {
+	offlineGitHubAPI
+	inFlight, maxInFlight *atomic.Int32
+}
+
+func (g slowGitHubAPI) GetRepoInfo(owner, repo string) (map[string]interface{}, error) {
+	current := g.inFlight.Add(1)
+	defer g.inFlight.Add(-1)
+	for {
+		seen := g.maxInFlight.Load()
+		if current <= seen || g.maxInFlight.CompareAndSwap(seen, current) {
+			break
+		}
+	}
+	time.Sleep(20 * time.Millisecond)
+	return map[string]interface{}{"full_name": owner + "/" + repo}, nil
+}
+
+func (slowGitHubAPI) GetDefaultBranch(owner, repo string) (string, error) {
+	return "main", nil
+}
+
+func (slowGitHubAPI) FindMatchingTag(owner, repo, version string) (string, error) {
+	return "v" + version, nil
+}
+
+func TestApplicationService_AddApplicationDependency_EnrichesConcurrently(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+	app := &entity.App{ID: uuid.New(), Name: "billing", Status: "active"}
+	require.NoError(t, repos.AppRepository.Create(ctx, app))
+
+	github := slowGitHubAPI{inFlight: &atomic.Int32{}, maxInFlight: &atomic.Int32{}}
+	appService := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, github, services.ServiceConfig{DependencyProcessingWorkers: 4})
+	t.Cleanup(func() { _ = appService.Shutdown(context.Background()) })
+
+	var deps []model.DependencyInfoRequest
+	for i := 0; i < 20; i++ {
+		deps = append(deps, model.DependencyInfoRequest{Name: fmt.Sprintf("lib-%d", i), Owner: "acme", Repo: fmt.Sprintf("lib-%d", i), Version: "1.0.0", IsGitHubRepo: true})
+	}
+	// The same repository twice in one batch is created once and then reported as already linked
+	deps = append(deps, model.DependencyInfoRequest{Name: "lib-0", Owner: "acme", Repo: "lib-0", Version: "1.0.0", IsGitHubRepo: true})
+
+	result, err := appService.AddApplicationDependency(ctx, app.ID.String(), deps)
+	require.NoError(t, err)
+
+	assert.Greater(t, github.maxInFlight.Load(), int32(1), "lookups run concurrently")
+	assert.LessOrEqual(t, github.maxInFlight.Load(), int32(4), "lookups are bounded by the worker count")
+
+	response := result.(map[string]interface{})
+	summary := response["summary"].(map[string]interface{})
+	assert.Equal(t, 21, summary["total"])
+	assert.Equal(t, 20, summary["successful"])
+	assert.Equal(t, 0, summary["failed"])
+	assert.Equal(t, "skipped", response["results"].(map[string]interface{})["acme/lib-0"].(map[string]interface{})["status"])
+
+	links, err := repos.AppToDepedencyRepository.GetByAppID(ctx, app.ID)
+	require.NoError(t, err)
+	require.Len(t, links, 20)
+	for _, link := range links {
+		assert.Equal(t, "v1.0.0", link.UsedVersion)
+	}
+	stored, err := repos.DepedencyRepository.GetAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, stored, 20)
+	for _, dep := range stored {
+		assert.Equal(t, "main", *dep.DefaultBranch)
+		assert.Equal(t, fmt.Sprintf("https://github.com/acme/%s", dep.Repo), *dep.RepositoryURL)
+	}
+}
@@ -0,0 +1,84 @@
+package services_test
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/services"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestApplicationService_ListApplicationAudit_CorruptedRow stores an audit row whose new values were
+// truncated and checks that the listing still returns it, and the intact row, instead of failing
+func TestApplicationService_ListApplicationAudit_CorruptedRow(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+	app, _ := seedDependencyWithMetadata(t, repos)
+
+	performedAt := time.Now().UTC()
+	require.NoError(t, repos.AuditTrailRepository.Create(ctx, &entity.AuditTrail{
+		ID:          uuid.New(),
+		EntityType:  "app",
+		EntityID:    app.ID,
+		Action:      "application_updated",
+		OldValues:   []byte(`{"name":"billing"}`),
+		NewValues:   []byte(`{"name":"billi`),
+		PerformedBy: "user",
+		PerformedAt: performedAt,
+	}))
+	require.NoError(t, repos.AuditTrailRepository.Create(ctx, &entity.AuditTrail{
+		ID:          uuid.New(),
+		EntityType:  "app",
+		EntityID:    app.ID,
+		Action:      "application_created",
+		NewValues:   []byte(`{"name":"billing"}`),
+		PerformedBy: "user",
+		PerformedAt: performedAt.Add(-time.Minute),
+	}))
+
+	svc := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, nil, services.ServiceConfig{})
+	resp, err := svc.ListApplicationAudit(ctx, app.ID.String(), 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, services.DefaultAuditPageSize, resp.Limit)
+	require.Len(t, resp.Entries, 2)
+
+	corrupted := resp.Entries[0]
+	assert.Equal(t, "application_updated", corrupted.Action)
+	assert.JSONEq(t, `{"name":"billing"}`, string(corrupted.OldValues))
+	var marker struct {
+		DecodeError string `json:"decode_error"`
+		Raw         string `json:"raw"`
+	}
+	require.NoError(t, json.Unmarshal(corrupted.NewValues, &marker))
+	assert.NotEmpty(t, marker.DecodeError)
+	assert.Equal(t, `{"name":"billi`, marker.Raw)
+	assert.Nil(t, corrupted.Context, "an empty column is returned as null")
+
+	assert.Equal(t, "application_created", resp.Entries[1].Action)
+	assert.JSONEq(t, `{"name":"billing"}`, string(resp.Entries[1].NewValues))
+
+	// The whole response must still encode
+	_, err = json.Marshal(resp)
+	assert.NoError(t, err)
+}
+
+func TestApplicationService_ListApplicationAudit_InvalidInput(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+	svc := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, nil, services.ServiceConfig{})
+
+	_, err := svc.ListApplicationAudit(ctx, "not-a-uuid", 0, 0)
+	assert.ErrorIs(t, err, services.ErrInvalidInput)
+
+	_, err = svc.ListApplicationAudit(ctx, uuid.New().String(), services.MaxAuditPageSize+1, 0)
+	assert.ErrorIs(t, err, services.ErrInvalidInput)
+
+	_, err = svc.ListApplicationAudit(ctx, uuid.New().String(), 0, 0)
+	assert.ErrorIs(t, err, services.ErrNotFound)
+}
@@ -0,0 +1,87 @@
+package services_test
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/model"
+	"elang-backend/internal/services"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplicationService_CompareApplications(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+
+	newApp := func(name string) *entity.App {
+		app := &entity.App{ID: uuid.New(), Name: name, Status: "active"}
+		require.NoError(t, repos.AppRepository.Create(ctx, app))
+		return app
+	}
+	newDep := func(name string) *entity.Dependency {
+		dep := &entity.Dependency{ID: uuid.New(), Name: name}
+		require.NoError(t, repos.DepedencyRepository.Create(ctx, dep))
+		return dep
+	}
+	use := func(app *entity.App, dep *entity.Dependency, version string) {
+		require.NoError(t, repos.AppToDepedencyRepository.Create(ctx, &entity.AppDependency{ID: uuid.New(), AppID: app.ID, DependencyID: dep.ID, UsedVersion: version}))
+	}
+
+	billing, checkout := newApp("billing"), newApp("checkout")
+	gin, uuidDep, gorm, redis := newDep("github.com/gin-gonic/gin"), newDep("github.com/google/uuid"), newDep("gorm.io/gorm"), newDep("github.com/redis/go-redis")
+	use(billing, gin, "v1.9.0")
+	use(checkout, gin, "v1.10.0")
+	use(billing, uuidDep, "v1.6.0")
+	use(checkout, uuidDep, "v1.6.0")
+	use(billing, gorm, "v1.25.0")
+	use(checkout, redis, "v9.5.0")
+
+	svc := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, nil, services.ServiceConfig{})
+
+	comparison, err := svc.CompareApplications(ctx, billing.ID.String(), checkout.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, "billing", comparison.A.AppName)
+	assert.Equal(t, 3, comparison.A.Dependencies)
+	assert.Nil(t, comparison.A.LatestScan)
+	assert.Equal(t, "unknown", comparison.HigherRisk, "neither application has been scanned")
+	assert.Equal(t, []model.SharedDependency{
+		{DependencyID: gin.ID.String(), Name: gin.Name, VersionA: "v1.9.0", VersionB: "v1.10.0"},
+		{DependencyID: uuidDep.ID.String(), Name: uuidDep.Name, VersionA: "v1.6.0", VersionB: "v1.6.0", SameVersion: true},
+	}, comparison.Shared)
+	assert.Equal(t, []model.ComparedDependency{{DependencyID: gorm.ID.String(), Name: gorm.Name, Version: "v1.25.0"}}, comparison.OnlyInA)
+	assert.Equal(t, []model.ComparedDependency{{DependencyID: redis.ID.String(), Name: redis.Name, Version: "v9.5.0"}}, comparison.OnlyInB)
+
+	// Equal critical counts are decided on high ones
+	seedDashboardScan(t, repos, billing, "fail", []model.ScanFinding{
+		{Dependency: gin.Name, Version: "v1.9.0", Severity: "critical", VulnerabilityIDs: []string{"GHSA-1"}},
+	})
+	seedDashboardScan(t, repos, checkout, "fail", []model.ScanFinding{
+		{Dependency: gin.Name, Version: "v1.10.0", Severity: "critical", VulnerabilityIDs: []string{"GHSA-2"}},
+		{Dependency: redis.Name, Version: "v9.5.0", Severity: "high", VulnerabilityIDs: []string{"GHSA-3"}},
+	})
+	comparison, err = svc.CompareApplications(ctx, billing.ID.String(), checkout.ID.String())
+	require.NoError(t, err)
+	require.NotNil(t, comparison.B.LatestScan)
+	assert.Equal(t, 1, comparison.B.LatestScan.Severities.High)
+	assert.Equal(t, "b", comparison.HigherRisk)
+}
+
+func TestApplicationService_CompareApplications_InvalidInput(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+	svc := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, nil, services.ServiceConfig{})
+	id := uuid.New().String()
+
+	_, err := svc.CompareApplications(ctx, id, "")
+	assert.ErrorIs(t, err, services.ErrInvalidInput)
+	_, err = svc.CompareApplications(ctx, id, id)
+	assert.ErrorIs(t, err, services.ErrInvalidInput)
+	_, err = svc.CompareApplications(ctx, "not-a-uuid", id)
+	assert.ErrorIs(t, err, services.ErrInvalidInput)
+	_, err = svc.CompareApplications(ctx, id, uuid.New().String())
+	assert.ErrorIs(t, err, services.ErrNotFound)
+}
@@ -0,0 +1,183 @@
+package services_test
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/model"
+	"elang-backend/internal/services"
+	"elang-backend/internal/usecase"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryManifestStorage keeps stored manifests in memory
+type memoryManifestStorage struct {
+	usecase.ObjectStorageInterface
+	mu        sync.Mutex
+	manifests map[string][]byte
+	failSave  bool
+}
+
+func (s *memoryManifestStorage) SaveManifest(ctx context.Context, appID string, fileName string, content []byte) (string, error) {
+	if s.failSave {
+		return "", errors.New("storage unavailable")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := "manifests/" + appID + "/" + fileName
+	s.manifests[key] = append([]byte(nil), content...)
+	return key, nil
+}
+
+func (s *memoryManifestStorage) GetManifest(ctx context.Context, objectKey string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	content, ok := s.manifests[objectKey]
+	if !ok {
+		return nil, errors.New("object not found")
+	}
+	return content, nil
+}
+
+func (s *memoryManifestStorage) DeleteManifest(ctx context.Context, objectKey string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.manifests, objectKey)
+	return nil
+}
+
+func TestApplicationService_StoredManifestRoundTrips(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+	require.NoError(t, repos.RunTimeRepository.Create(ctx, &entity.Runtime{Name: "python"}))
+	require.NoError(t, repos.FrameWorkRepository.Create(ctx, &entity.Framework{Name: "Default"}))
+	storage := &memoryManifestStorage{manifests: map[string][]byte{}}
+	appService := services.NewApplicationService(repos, *helper.NewDependencyParser(), storage, offlineGitHubAPI{}, services.ServiceConfig{DependencyProcessingWorkers: 1})
+	t.Cleanup(func() { _ = appService.Shutdown(context.Background()) })
+
+	content := "# pinned by CI\r\nrequests==2.31.0  # HTTP\n\nflask==3.0.0\n"
+	resp, err := appService.AddApplication(ctx, "stored-app", "python", "Default", "", "requirements.txt", content, nil)
+	require.NoError(t, err)
+	assert.Empty(t, resp.Warnings)
+
+	manifest, err := appService.GetApplicationManifest(ctx, resp.AppID)
+	require.NoError(t, err)
+	assert.Equal(t, resp.AppID, manifest.AppID)
+	assert.Equal(t, "requirements.txt", manifest.FileName)
+	assert.Equal(t, content, manifest.Content)
+	assert.Equal(t, len(content), manifest.Size)
+
+	t.Run("NotStored", func(t *testing.T) {
+		storage.failSave = true
+		t.Cleanup(func() { storage.failSave = false })
+		resp, err := appService.AddApplication(ctx, "unstored-app", "python", "Default", "", "requirements.txt", content, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{services.ManifestNotStoredWarning}, resp.Warnings)
+
+		_, err = appService.GetApplicationManifest(ctx, resp.AppID)
+		assert.ErrorIs(t, err, services.ErrNotFound)
+		_, err = appService.ReparseApplication(ctx, resp.AppID)
+		assert.ErrorIs(t, err, services.ErrNotFound)
+	})
+}
+
+func TestApplicationService_AddApplication_DeletesManifestOnRollback(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+	seedRuntimeAndFramework(t, ctx, repos.RunTimeRepository, repos.FrameWorkRepository)
+	repos.AuditTrailRepository = failingAuditTrailRepository{repos.AuditTrailRepository}
+	storage := &memoryManifestStorage{manifests: map[string][]byte{}}
+	appService := services.NewApplicationService(repos, *helper.NewDependencyParser(), storage, offlineGitHubAPI{}, services.ServiceConfig{})
+	t.Cleanup(func() { _ = appService.Shutdown(context.Background()) })
+
+	_, err := appService.AddApplication(ctx, "rolled-back-app", "Go", "Gin", "", "go.mod", transactionTestGoMod, nil)
+	require.Error(t, err)
+	assert.Empty(t, storage.manifests, "the stored manifest belongs to no application")
+}
+
+func TestApplicationService_ReparseApplication_UsesStoredGradleProperties(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+	require.NoError(t, repos.RunTimeRepository.Create(ctx, &entity.Runtime{Name: "Gradle"}))
+	require.NoError(t, repos.FrameWorkRepository.Create(ctx, &entity.Framework{Name: "Native"}))
+	storage := &memoryManifestStorage{manifests: map[string][]byte{}}
+	appService := services.NewApplicationService(repos, *helper.NewDependencyParser(), storage, offlineGitHubAPI{}, services.ServiceConfig{DependencyProcessingWorkers: 1})
+	t.Cleanup(func() { _ = appService.Shutdown(context.Background()) })
+
+	content := "dependencies {\n    implementation \"com.google.guava:guava:${guavaVersion}-jre\"\n}\n"
+	addCtx := helper.WithGradlePropertiesFile(ctx, "guavaVersion=32.1.3\n")
+	resp, err := appService.AddApplication(addCtx, "gradle-app", "Gradle", "Native", "", "build.gradle", content, nil)
+	require.NoError(t, err)
+	parsed := resp.DependencyParse.([]helper.DependencyInfo)
+	require.Len(t, parsed, 1)
+	assert.Equal(t, "32.1.3-jre", parsed[0].Version)
+	require.Eventually(t, func() bool {
+		linked, err := appService.ListApplicationDependency(ctx, resp.AppID)
+		return err == nil && len(linked.Dependencies) == 1
+	}, 5*time.Second, 20*time.Millisecond)
+
+	reparsed, err := appService.ReparseApplication(ctx, resp.AppID)
+	require.NoError(t, err)
+	assert.Empty(t, reparsed.Changed, "the variable resolves as it did on add")
+	assert.Equal(t, 1, reparsed.Unchanged)
+}
+
+func TestApplicationService_ReparseApplication(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+	runtime := &entity.Runtime{Name: "python"}
+	require.NoError(t, repos.RunTimeRepository.Create(ctx, runtime))
+
+	// The application was added before the parser read flask, and left-pad was added through the API
+	fileName, key := "requirements.txt", "manifests/reparse-app/requirements.txt"
+	storage := &memoryManifestStorage{manifests: map[string][]byte{key: []byte("requests==2.31.0\nflask==3.0.0\n")}}
+	app := &entity.App{ID: uuid.New(), Name: "reparse-app", RuntimeID: &runtime.ID, Status: "active", ManifestFileName: &fileName, ManifestObjectKey: &key}
+	require.NoError(t, repos.AppRepository.Create(ctx, app))
+	link := func(owner, name, version string) {
+		dep := &entity.Dependency{ID: uuid.New(), Name: name, Owner: owner, Repo: name}
+		require.NoError(t, repos.DepedencyRepository.Create(ctx, dep))
+		require.NoError(t, repos.AppToDepedencyRepository.Create(ctx, &entity.AppDependency{ID: uuid.New(), AppID: app.ID, DependencyID: dep.ID, UsedVersion: version}))
+	}
+	link("psf", "requests", "2.30.0")
+	link("stevemao", "left-pad", "1.3.0")
+
+	// One worker keeps the two dependencies from writing at once, which the shared-cache test database can reject
+	appService := services.NewApplicationService(repos, *helper.NewDependencyParser(), storage, offlineGitHubAPI{}, services.ServiceConfig{DependencyProcessingWorkers: 1})
+	t.Cleanup(func() { _ = appService.Shutdown(context.Background()) })
+
+	resp, err := appService.ReparseApplication(ctx, app.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, fileName, resp.FileName)
+	assert.Equal(t, 2, resp.DependencyCount)
+	assert.Equal(t, []model.ManifestDependencyChange{{Name: "flask", NewVersion: "3.0.0"}}, resp.Added)
+	assert.Equal(t, []model.ManifestDependencyChange{{Name: "requests", OldVersion: "2.30.0", NewVersion: "2.31.0"}}, resp.Changed)
+	assert.Equal(t, []model.ManifestDependencyChange{{Name: "left-pad", OldVersion: "1.3.0"}}, resp.Missing)
+	assert.Zero(t, resp.Unchanged)
+
+	// Added and changed dependencies are linked in the background; left-pad stays
+	require.Eventually(t, func() bool {
+		linked, err := appService.ListApplicationDependency(ctx, app.ID.String())
+		if err != nil || len(linked.Dependencies) != 3 {
+			return false
+		}
+		for _, dep := range linked.Dependencies {
+			if dep.Name == "requests" && dep.UsedVersion != "2.31.0" {
+				return false
+			}
+		}
+		return true
+	}, 5*time.Second, 20*time.Millisecond)
+
+	resp, err = appService.ReparseApplication(ctx, app.ID.String())
+	require.NoError(t, err)
+	assert.Empty(t, resp.Added)
+	assert.Empty(t, resp.Changed)
+	assert.Equal(t, 2, resp.Unchanged)
+}
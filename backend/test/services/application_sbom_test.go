@@ -0,0 +1,83 @@
+package services_test
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/services"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplicationService_GenerateApplicationSBOM(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+
+	runtime := &entity.Runtime{Name: "Go"}
+	require.NoError(t, repos.RunTimeRepository.Create(ctx, runtime))
+	app := &entity.App{ID: uuid.New(), Name: "payments", RuntimeID: &runtime.ID, Status: "active"}
+	require.NoError(t, repos.AppRepository.Create(ctx, app))
+
+	ginURL := "https://github.com/gin-gonic/gin"
+	gin := &entity.Dependency{ID: uuid.New(), Name: "github.com/gin-gonic/gin", Owner: "gin-gonic", Repo: "gin", RepositoryURL: &ginURL}
+	jwt := &entity.Dependency{ID: uuid.New(), Name: "github.com/golang-jwt/jwt", Owner: "golang-jwt", Repo: "jwt"}
+	for _, dep := range []*entity.Dependency{gin, jwt} {
+		require.NoError(t, repos.DepedencyRepository.Create(ctx, dep))
+	}
+	require.NoError(t, repos.AppToDepedencyRepository.Create(ctx, &entity.AppDependency{ID: uuid.New(), AppID: app.ID, DependencyID: gin.ID, UsedVersion: "v1.9.1"}))
+	require.NoError(t, repos.AppToDepedencyRepository.Create(ctx, &entity.AppDependency{ID: uuid.New(), AppID: app.ID, DependencyID: jwt.ID, UsedVersion: "v4.5.1"}))
+
+	seedFindingsScan(t, repos, app, time.Now().UTC().Add(-48*time.Hour),
+		entity.Finding{Dependency: gin.Name, Version: "v1.9.1", VulnerabilityID: "GO-2020-0001", Severity: "low", RiskScore: 2.0},
+	)
+	seedFindingsScan(t, repos, app, time.Now().UTC().Add(-time.Hour),
+		entity.Finding{Dependency: gin.Name, Version: "v1.9.1", VulnerabilityID: "GO-2023-2001", Severity: "high", RiskScore: 7.5},
+		// jwt was upgraded since this scan, so its finding no longer applies
+		entity.Finding{Dependency: jwt.Name, Version: "v4.4.0", VulnerabilityID: "GO-2022-0001", Severity: "critical", RiskScore: 9.1},
+	)
+
+	appService := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, nil, services.ServiceConfig{})
+	t.Cleanup(func() { _ = appService.Shutdown(context.Background()) })
+
+	raw, err := appService.GenerateApplicationSBOM(ctx, app.ID.String())
+	require.NoError(t, err)
+	var bom helper.CycloneDXSBOM
+	require.NoError(t, json.Unmarshal(raw, &bom))
+
+	assert.Equal(t, "payments", bom.Metadata.Component.Name)
+	require.Len(t, bom.Components, 2)
+	versions := map[string]string{}
+	for _, component := range bom.Components {
+		versions[component.Name] = component.Version
+	}
+	assert.Equal(t, map[string]string{gin.Name: "v1.9.1", jwt.Name: "v4.5.1"}, versions)
+
+	require.Len(t, bom.Vulnerabilities, 1, "only the latest scan's findings for the versions in use")
+	assert.Equal(t, "GO-2023-2001", bom.Vulnerabilities[0].ID)
+	assert.Equal(t, "high", bom.Vulnerabilities[0].Ratings[0].Severity)
+
+	t.Run("FollowsEdits", func(t *testing.T) {
+		link, err := repos.AppToDepedencyRepository.GetByAppAndDependencyID(ctx, app.ID, gin.ID)
+		require.NoError(t, err)
+		link.UsedVersion = "v1.10.0"
+		require.NoError(t, repos.AppToDepedencyRepository.Update(ctx, link))
+
+		raw, err := appService.GenerateApplicationSBOM(ctx, app.ID.String())
+		require.NoError(t, err)
+		var bom helper.CycloneDXSBOM
+		require.NoError(t, json.Unmarshal(raw, &bom))
+		assert.Empty(t, bom.Vulnerabilities)
+	})
+
+	t.Run("UnknownApp", func(t *testing.T) {
+		_, err := appService.GenerateApplicationSBOM(ctx, uuid.NewString())
+		assert.ErrorIs(t, err, services.ErrNotFound)
+		_, err = appService.GenerateApplicationSBOM(ctx, "not-a-uuid")
+		assert.ErrorIs(t, err, services.ErrInvalidInput)
+	})
+}
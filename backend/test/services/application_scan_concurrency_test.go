@@ -0,0 +1,76 @@
+package services_test
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/helper/parser"
+	"elang-backend/internal/model"
+	"elang-backend/internal/services"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// inFlightSource records how many vulnerability lookups run at once
+type inFlightSource struct {
+	mu          sync.Mutex
+	inFlight    int
+	maxInFlight int
+}
+
+func (s *inFlightSource) Name() string { return "osv" }
+
+func (s *inFlightSource) QueryVulnerabilities(ctx context.Context, dep parser.DependencyInfo) ([]helper.VulnerabilityInfo, error) {
+	s.mu.Lock()
+	s.inFlight++
+	if s.inFlight > s.maxInFlight {
+		s.maxInFlight = s.inFlight
+	}
+	s.mu.Unlock()
+
+	time.Sleep(20 * time.Millisecond)
+
+	s.mu.Lock()
+	s.inFlight--
+	s.mu.Unlock()
+	return nil, nil
+}
+
+func TestApplicationService_ScanApplicationDependencies_BoundsConcurrency(t *testing.T) {
+	ctx := context.Background()
+	source := &inFlightSource{}
+	scanner := helper.ScannerConfig{Sources: []helper.VulnerabilitySource{source}}
+
+	repos := setupScanTestRepos(t)
+	runtime := &entity.Runtime{Name: "Go"}
+	require.NoError(t, repos.RunTimeRepository.Create(ctx, runtime))
+	app := &entity.App{ID: uuid.New(), Name: "large-app", RuntimeID: &runtime.ID, Status: "active"}
+	require.NoError(t, repos.AppRepository.Create(ctx, app))
+
+	const dependencies = 25
+	for i := 0; i < dependencies; i++ {
+		repo := fmt.Sprintf("lib%d", i)
+		dep := &entity.Dependency{ID: uuid.New(), Name: "github.com/example/" + repo, Owner: "example", Repo: repo}
+		require.NoError(t, repos.DepedencyRepository.Create(ctx, dep))
+		require.NoError(t, repos.AppToDepedencyRepository.Create(ctx, &entity.AppDependency{ID: uuid.New(), AppID: app.ID, DependencyID: dep.ID, UsedVersion: "v1.0.0"}))
+	}
+
+	appService := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, nil, services.ServiceConfig{Scanner: scanner})
+	t.Cleanup(func() { _ = appService.Shutdown(context.Background()) })
+
+	resp, err := appService.ScanApplicationDependencies(ctx, app.ID.String())
+	require.NoError(t, err)
+	result, ok := resp.(model.ScanApplicationResult)
+	require.True(t, ok)
+	assert.Len(t, result.Findings, dependencies)
+	assert.Equal(t, dependencies, result.Summary.TotalDependencies)
+
+	assert.Greater(t, source.maxInFlight, 1, "dependencies are checked concurrently")
+	assert.LessOrEqual(t, source.maxInFlight, 10, "no more dependencies are checked at once than the scanner allows")
+}
@@ -3,8 +3,12 @@ package services_test
 import (
 	"context"
 	"elang-backend/internal/entity"
+	"elang-backend/internal/helper"
 	"elang-backend/internal/model"
+	"elang-backend/internal/model/dto"
+	"elang-backend/internal/repository"
 	"elang-backend/internal/services"
+	"elang-backend/test/mocks"
 	"errors"
 	"testing"
 
@@ -13,167 +17,8 @@ import (
 	"github.com/stretchr/testify/mock"
 )
 
-// Mock repositories
-type MockApplicationRepository struct {
-	mock.Mock
-}
-
-func (m *MockApplicationRepository) Create(ctx context.Context, app *entity.App) error {
-	args := m.Called(ctx, app)
-	return args.Error(0)
-}
-
-func (m *MockApplicationRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.App, error) {
-	args := m.Called(ctx, id)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*entity.App), args.Error(1)
-}
-
-func (m *MockApplicationRepository) GetAll(ctx context.Context) ([]*entity.App, error) {
-	args := m.Called(ctx)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).([]*entity.App), args.Error(1)
-}
-
-func (m *MockApplicationRepository) Update(ctx context.Context, app *entity.App) error {
-	args := m.Called(ctx, app)
-	return args.Error(0)
-}
-
-func (m *MockApplicationRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	args := m.Called(ctx, id)
-	return args.Error(0)
-}
-
-func (m *MockApplicationRepository) GetByName(ctx context.Context, name string) (*entity.App, error) {
-	args := m.Called(ctx, name)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*entity.App), args.Error(1)
-}
-
-func (m *MockApplicationRepository) GetByStatus(ctx context.Context, status string) ([]*entity.App, error) {
-	args := m.Called(ctx, status)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).([]*entity.App), args.Error(1)
-}
-
-func (m *MockApplicationRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status string) error {
-	args := m.Called(ctx, id, status)
-	return args.Error(0)
-}
-
-type MockRuntimeRepository struct {
-	mock.Mock
-}
-
-func (m *MockRuntimeRepository) Create(ctx context.Context, runtime *entity.Runtime) error {
-	args := m.Called(ctx, runtime)
-	return args.Error(0)
-}
-
-func (m *MockRuntimeRepository) GetByID(ctx context.Context, id int) (*entity.Runtime, error) {
-	args := m.Called(ctx, id)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*entity.Runtime), args.Error(1)
-}
-
-func (m *MockRuntimeRepository) GetAll(ctx context.Context) ([]*entity.Runtime, error) {
-	args := m.Called(ctx)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).([]*entity.Runtime), args.Error(1)
-}
-
-func (m *MockRuntimeRepository) Update(ctx context.Context, runtime *entity.Runtime) error {
-	args := m.Called(ctx, runtime)
-	return args.Error(0)
-}
-
-func (m *MockRuntimeRepository) Delete(ctx context.Context, id int) error {
-	args := m.Called(ctx, id)
-	return args.Error(0)
-}
-
-func (m *MockRuntimeRepository) GetByName(ctx context.Context, name string) (*entity.Runtime, error) {
-	args := m.Called(ctx, name)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*entity.Runtime), args.Error(1)
-}
-
-func (m *MockRuntimeRepository) GetByNameCI(ctx context.Context, name string) (*entity.Runtime, error) {
-	args := m.Called(ctx, name)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*entity.Runtime), args.Error(1)
-}
-
-type MockFrameworkRepository struct {
-	mock.Mock
-}
-
-func (m *MockFrameworkRepository) Create(ctx context.Context, framework *entity.Framework) error {
-	args := m.Called(ctx, framework)
-	return args.Error(0)
-}
-
-func (m *MockFrameworkRepository) GetByID(ctx context.Context, id int) (*entity.Framework, error) {
-	args := m.Called(ctx, id)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*entity.Framework), args.Error(1)
-}
-
-func (m *MockFrameworkRepository) GetAll(ctx context.Context) ([]*entity.Framework, error) {
-	args := m.Called(ctx)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).([]*entity.Framework), args.Error(1)
-}
-
-func (m *MockFrameworkRepository) Update(ctx context.Context, framework *entity.Framework) error {
-	args := m.Called(ctx, framework)
-	return args.Error(0)
-}
-
-func (m *MockFrameworkRepository) Delete(ctx context.Context, id int) error {
-	args := m.Called(ctx, id)
-	return args.Error(0)
-}
-
-func (m *MockFrameworkRepository) GetByName(ctx context.Context, name string) (*entity.Framework, error) {
-	args := m.Called(ctx, name)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*entity.Framework), args.Error(1)
-}
-
-func (m *MockFrameworkRepository) GetByNameCI(ctx context.Context, name string) (*entity.Framework, error) {
-	args := m.Called(ctx, name)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*entity.Framework), args.Error(1)
-}
-
 func TestApplicationService_ListApplications(t *testing.T) {
-	mockAppRepo := new(MockApplicationRepository)
+	mockAppRepo := new(mocks.ApplicationRepository)
 	ctx := context.Background()
 
 	expectedApps := []*entity.App{
@@ -191,18 +36,18 @@ func TestApplicationService_ListApplications(t *testing.T) {
 
 	mockAppRepo.On("GetAll", ctx).Return(expectedApps, nil)
 
-	// Note: This is a simplified test. You'll need to create the actual service
-	// with all required dependencies
-	apps, err := mockAppRepo.GetAll(ctx)
+	svc := services.NewApplicationService(dto.BasicRepositories{AppRepository: mockAppRepo}, *helper.NewDependencyParser(), nil, nil, services.ServiceConfig{})
+	resp, err := svc.ListApplications(ctx, nil)
 
 	assert.NoError(t, err)
-	assert.Len(t, apps, 2)
-	assert.Equal(t, "app1", apps[0].Name)
+	assert.Len(t, resp.Applications, 2)
+	assert.Equal(t, "app1", resp.Applications[0].AppName)
+	assert.Equal(t, []string{}, resp.Applications[0].Tags)
 	mockAppRepo.AssertExpectations(t)
 }
 
 func TestApplicationService_GetByID_Success(t *testing.T) {
-	mockAppRepo := new(MockApplicationRepository)
+	mockAppRepo := new(mocks.ApplicationRepository)
 	ctx := context.Background()
 	appID := uuid.New()
 
@@ -223,15 +68,15 @@ func TestApplicationService_GetByID_Success(t *testing.T) {
 }
 
 func TestApplicationService_GetByID_NotFound(t *testing.T) {
-	mockAppRepo := new(MockApplicationRepository)
+	mockAppRepo := new(mocks.ApplicationRepository)
 	ctx := context.Background()
 	appID := uuid.New()
 
-	mockAppRepo.On("GetByID", ctx, appID).Return(nil, nil)
+	mockAppRepo.On("GetByID", ctx, appID).Return(nil, repository.ErrNotFound)
 
 	app, err := mockAppRepo.GetByID(ctx, appID)
 
-	assert.NoError(t, err)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
 	assert.Nil(t, app)
 	mockAppRepo.AssertExpectations(t)
 }
@@ -249,7 +94,7 @@ func TestApplicationService_Create_EmptyName(t *testing.T) {
 }
 
 func TestApplicationService_UpdateStatus(t *testing.T) {
-	mockAppRepo := new(MockApplicationRepository)
+	mockAppRepo := new(mocks.ApplicationRepository)
 	ctx := context.Background()
 	appID := uuid.New()
 	newStatus := "inactive"
@@ -263,7 +108,7 @@ func TestApplicationService_UpdateStatus(t *testing.T) {
 }
 
 func TestApplicationService_Delete(t *testing.T) {
-	mockAppRepo := new(MockApplicationRepository)
+	mockAppRepo := new(mocks.ApplicationRepository)
 	ctx := context.Background()
 	appID := uuid.New()
 
@@ -276,7 +121,7 @@ func TestApplicationService_Delete(t *testing.T) {
 }
 
 func TestRuntimeRepository_GetByNameCI(t *testing.T) {
-	mockRuntimeRepo := new(MockRuntimeRepository)
+	mockRuntimeRepo := new(mocks.RuntimeRepository)
 	ctx := context.Background()
 
 	expectedRuntime := &entity.Runtime{
@@ -295,7 +140,7 @@ func TestRuntimeRepository_GetByNameCI(t *testing.T) {
 }
 
 func TestFrameworkRepository_GetByNameCI(t *testing.T) {
-	mockFrameworkRepo := new(MockFrameworkRepository)
+	mockFrameworkRepo := new(mocks.FrameworkRepository)
 	ctx := context.Background()
 
 	expectedFramework := &entity.Framework{
@@ -325,14 +170,27 @@ type mockApplicationService struct {
 	mock.Mock
 }
 
-func (m *mockApplicationService) AddApplication(ctx context.Context, appName, runtimeType, framework, description, fileName, content string) (*model.AddApplicationResponse, error) {
-	args := m.Called(ctx, appName, runtimeType, framework, description, fileName, content)
+func (m *mockApplicationService) Shutdown(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *mockApplicationService) AddApplication(ctx context.Context, appName, runtimeType, framework, description, fileName, content string, autoScan *bool) (*model.AddApplicationResponse, error) {
+	args := m.Called(ctx, appName, runtimeType, framework, description, fileName, content, autoScan)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.AddApplicationResponse), args.Error(1)
 }
 
+func (m *mockApplicationService) UpdateApplication(ctx context.Context, appUID string, req *model.UpdateApplicationRequest) (*model.UpdateApplicationResponse, error) {
+	args := m.Called(ctx, appUID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.UpdateApplicationResponse), args.Error(1)
+}
+
 func (m *mockApplicationService) AddApplicationDependency(ctx context.Context, appUID string, deps []model.DependencyInfoRequest) (interface{}, error) {
 	args := m.Called(ctx, appUID, deps)
 	return args.Get(0), args.Error(1)
@@ -369,14 +227,94 @@ func (m *mockApplicationService) RecoverApplication(ctx context.Context, appUID
 	return args.Error(0)
 }
 
-func (m *mockApplicationService) ListApplications(ctx context.Context) (*model.ListApplicationsResponse, error) {
-	args := m.Called(ctx)
+func (m *mockApplicationService) ListApplications(ctx context.Context, tags []string) (*model.ListApplicationsResponse, error) {
+	args := m.Called(ctx, tags)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.ListApplicationsResponse), args.Error(1)
 }
 
+func (m *mockApplicationService) CloneApplication(ctx context.Context, sourceAppUID, newName string) (*model.CloneApplicationResponse, error) {
+	args := m.Called(ctx, sourceAppUID, newName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.CloneApplicationResponse), args.Error(1)
+}
+
+func (m *mockApplicationService) AddApplicationTags(ctx context.Context, appUID string, tags []string) (*model.ApplicationTagsResponse, error) {
+	args := m.Called(ctx, appUID, tags)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ApplicationTagsResponse), args.Error(1)
+}
+
+func (m *mockApplicationService) RemoveApplicationTags(ctx context.Context, appUID string, tags []string) (*model.ApplicationTagsResponse, error) {
+	args := m.Called(ctx, appUID, tags)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ApplicationTagsResponse), args.Error(1)
+}
+
+func (m *mockApplicationService) GetFrameworksByRuntime(ctx context.Context, runtime string) (*model.ListRuntimeFrameworksResponse, error) {
+	args := m.Called(ctx, runtime)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ListRuntimeFrameworksResponse), args.Error(1)
+}
+
+func (m *mockApplicationService) BulkUpdateDependencyVersion(ctx context.Context, owner, repo, fromVersion, toVersion string) (*model.BulkBumpResponse, error) {
+	args := m.Called(ctx, owner, repo, fromVersion, toVersion)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.BulkBumpResponse), args.Error(1)
+}
+
+func (m *mockApplicationService) GetDependencyChangelog(ctx context.Context, depUID, fromVersion, toVersion string) (*model.DependencyChangelogResponse, error) {
+	args := m.Called(ctx, depUID, fromVersion, toVersion)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.DependencyChangelogResponse), args.Error(1)
+}
+
+func (m *mockApplicationService) GetDependencyMaintenance(ctx context.Context, depUID string) (*model.DependencyMaintenanceResponse, error) {
+	args := m.Called(ctx, depUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.DependencyMaintenanceResponse), args.Error(1)
+}
+
+func (m *mockApplicationService) RefreshDependencyMetadata(ctx context.Context, depUID string) (*model.DependencyMetadataResponse, error) {
+	args := m.Called(ctx, depUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.DependencyMetadataResponse), args.Error(1)
+}
+
+func (m *mockApplicationService) CompareApplications(ctx context.Context, appA, appB string) (*model.ApplicationComparison, error) {
+	args := m.Called(ctx, appA, appB)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ApplicationComparison), args.Error(1)
+}
+
+func (m *mockApplicationService) ListApplicationAudit(ctx context.Context, appUID string, limit, offset int) (*model.ApplicationAuditResponse, error) {
+	args := m.Called(ctx, appUID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ApplicationAuditResponse), args.Error(1)
+}
+
 func (m *mockApplicationService) GetApplicationStatus(ctx context.Context, appUID string) (map[string]interface{}, error) {
 	args := m.Called(ctx, appUID)
 	if args.Get(0) == nil {
@@ -390,6 +328,14 @@ func (m *mockApplicationService) ScanApplicationDependencies(ctx context.Context
 	return args.Get(0), args.Error(1)
 }
 
+func (m *mockApplicationService) StartApplicationScan(ctx context.Context, appUID string) (*model.ScanJobStatus, error) {
+	args := m.Called(ctx, appUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ScanJobStatus), args.Error(1)
+}
+
 func (m *mockApplicationService) GetApplicationSBOM(ctx context.Context, appUID string) ([]byte, error) {
 	args := m.Called(ctx, appUID)
 	if args.Get(0) == nil {
@@ -398,6 +344,22 @@ func (m *mockApplicationService) GetApplicationSBOM(ctx context.Context, appUID
 	return args.Get(0).([]byte), args.Error(1)
 }
 
+func (m *mockApplicationService) GenerateApplicationSBOM(ctx context.Context, appUID string) ([]byte, error) {
+	args := m.Called(ctx, appUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *mockApplicationService) GetDependencyTree(ctx context.Context, appUID string) (*model.DependencyTree, error) {
+	args := m.Called(ctx, appUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.DependencyTree), args.Error(1)
+}
+
 func (m *mockApplicationService) ListApplicationSBOMs(ctx context.Context, appUID string) ([]string, error) {
 	args := m.Called(ctx, appUID)
 	if args.Get(0) == nil {
@@ -405,3 +367,19 @@ func (m *mockApplicationService) ListApplicationSBOMs(ctx context.Context, appUI
 	}
 	return args.Get(0).([]string), args.Error(1)
 }
+
+func (m *mockApplicationService) GetApplicationManifest(ctx context.Context, appUID string) (*model.ApplicationManifest, error) {
+	args := m.Called(ctx, appUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ApplicationManifest), args.Error(1)
+}
+
+func (m *mockApplicationService) ReparseApplication(ctx context.Context, appUID string) (*model.ReparseApplicationResponse, error) {
+	args := m.Called(ctx, appUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ReparseApplicationResponse), args.Error(1)
+}
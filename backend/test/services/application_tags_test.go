@@ -0,0 +1,86 @@
+package services_test
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/services"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplicationService_Tags(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+
+	payments := &entity.App{ID: uuid.New(), Name: "payments-api", Status: "active"}
+	checkout := &entity.App{ID: uuid.New(), Name: "checkout-web", Status: "active"}
+	untagged := &entity.App{ID: uuid.New(), Name: "internal-tool", Status: "active"}
+	for _, app := range []*entity.App{payments, checkout, untagged} {
+		require.NoError(t, repos.AppRepository.Create(ctx, app))
+	}
+
+	appService := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, nil, services.ServiceConfig{})
+
+	resp, err := appService.AddApplicationTags(ctx, payments.ID.String(), []string{"Team:Payments", "env:prod", " env:prod "})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"env:prod", "team:payments"}, resp.Tags, "tags are lower-cased, deduplicated and sorted")
+
+	_, err = appService.AddApplicationTags(ctx, checkout.ID.String(), []string{"team:payments", "env:staging"})
+	require.NoError(t, err)
+
+	t.Run("SummaryIncludesTags", func(t *testing.T) {
+		list, err := appService.ListApplications(ctx, nil)
+		require.NoError(t, err)
+		require.Len(t, list.Applications, 3)
+		tagsByName := map[string][]string{}
+		for _, summary := range list.Applications {
+			tagsByName[summary.AppName] = summary.Tags
+		}
+		assert.Equal(t, []string{"env:prod", "team:payments"}, tagsByName["payments-api"])
+		assert.Equal(t, []string{"env:staging", "team:payments"}, tagsByName["checkout-web"])
+		assert.Equal(t, []string{}, tagsByName["internal-tool"])
+	})
+
+	t.Run("FilterRequiresEveryTag", func(t *testing.T) {
+		list, err := appService.ListApplications(ctx, []string{"team:payments"})
+		require.NoError(t, err)
+		assert.Len(t, list.Applications, 2)
+
+		list, err = appService.ListApplications(ctx, []string{"team:payments", "ENV:PROD"})
+		require.NoError(t, err)
+		require.Len(t, list.Applications, 1)
+		assert.Equal(t, "payments-api", list.Applications[0].AppName)
+
+		list, err = appService.ListApplications(ctx, []string{"env:dev"})
+		require.NoError(t, err)
+		assert.Empty(t, list.Applications)
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		resp, err := appService.RemoveApplicationTags(ctx, payments.ID.String(), []string{"env:prod", "unknown"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"team:payments"}, resp.Tags)
+
+		list, err := appService.ListApplications(ctx, []string{"env:prod"})
+		require.NoError(t, err)
+		assert.Empty(t, list.Applications)
+	})
+
+	t.Run("InvalidInput", func(t *testing.T) {
+		_, err := appService.AddApplicationTags(ctx, payments.ID.String(), []string{"team payments"})
+		assert.ErrorIs(t, err, services.ErrInvalidInput)
+
+		_, err = appService.AddApplicationTags(ctx, payments.ID.String(), []string{" "})
+		assert.ErrorIs(t, err, services.ErrInvalidInput)
+
+		_, err = appService.ListApplications(ctx, []string{"env:prod!"})
+		assert.ErrorIs(t, err, services.ErrInvalidInput)
+
+		_, err = appService.AddApplicationTags(ctx, uuid.NewString(), []string{"env:prod"})
+		assert.ErrorIs(t, err, services.ErrNotFound)
+	})
+}
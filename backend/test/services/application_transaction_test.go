@@ -0,0 +1,172 @@
+package services_test
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/model"
+	"elang-backend/internal/repository"
+	"elang-backend/internal/services"
+	"elang-backend/internal/usecase"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const transactionTestGoMod = `module example.com/demo
+
+go 1.22
+
+require (
+	github.com/gin-gonic/gin v1.9.1
+	github.com/google/uuid v1.6.0
+)
+`
+
+// offlineGitHubAPI answers the metadata lookups made while linking dependencies without network access
+type offlineGitHubAPI struct {
+	usecase.GitHubAPIInterface
+}
+
+func (offlineGitHubAPI) GetDefaultBranch(owner, repo string) (string, error) {
+	return "", errors.New("offline")
+}
+
+func (offlineGitHubAPI) GetListCommits(owner, repo, branch string, limit int) ([]map[string]interface{}, error) {
+	return nil, errors.New("offline")
+}
+
+func (offlineGitHubAPI) ListTags(owner, repo string) ([]map[string]interface{}, error) {
+	return nil, errors.New("offline")
+}
+
+func (offlineGitHubAPI) FindMatchingTag(owner, repo, version string) (string, error) {
+	return "", errors.New("offline")
+}
+
+func (offlineGitHubAPI) GetRepoInfo(owner, repo string) (map[string]interface{}, error) {
+	return nil, errors.New("offline")
+}
+
+func (offlineGitHubAPI) GetRepoInfoIfModified(owner, repo string, since usecase.GitHubValidators) (map[string]interface{}, usecase.GitHubValidators, error) {
+	return nil, usecase.GitHubValidators{}, errors.New("offline")
+}
+
+func (offlineGitHubAPI) ListCollaborators(owner, repo string) ([]map[string]interface{}, error) {
+	return nil, errors.New("offline")
+}
+
+// failingAuditTrailRepository rejects every audit entry
+type failingAuditTrailRepository struct {
+	repository.AuditTrailRepository
+}
+
+func (failingAuditTrailRepository) Create(ctx context.Context, audit *entity.AuditTrail) error {
+	return errors.New("audit store unavailable")
+}
+
+// flakyAppDependencyRepository fails the first app-dependency link it is asked to create
+type flakyAppDependencyRepository struct {
+	repository.AppDependencyRepository
+	calls atomic.Int32
+}
+
+func (r *flakyAppDependencyRepository) Create(ctx context.Context, appDep *entity.AppDependency) error {
+	if r.calls.Add(1) == 1 {
+		return errors.New("transient write failure")
+	}
+	return r.AppDependencyRepository.Create(ctx, appDep)
+}
+
+func seedRuntimeAndFramework(t *testing.T, ctx context.Context, runtimeRepo repository.RuntimeRepository, frameworkRepo repository.FrameworkRepository) {
+	require.NoError(t, runtimeRepo.Create(ctx, &entity.Runtime{Name: "Go"}))
+	require.NoError(t, frameworkRepo.Create(ctx, &entity.Framework{Name: "Gin"}))
+}
+
+func TestApplicationService_AddApplication_RollsBackWhenAuditFails(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+	seedRuntimeAndFramework(t, ctx, repos.RunTimeRepository, repos.FrameWorkRepository)
+	repos.AuditTrailRepository = failingAuditTrailRepository{repos.AuditTrailRepository}
+
+	appService := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, offlineGitHubAPI{}, services.ServiceConfig{})
+
+	resp, err := appService.AddApplication(ctx, "atomic-app", "Go", "Gin", "", "go.mod", transactionTestGoMod, nil)
+	assert.Error(t, err)
+	assert.Nil(t, resp)
+
+	app, err := repos.AppRepository.GetByName(ctx, "atomic-app")
+	require.NoError(t, err)
+	assert.Nil(t, app, "application row must be rolled back with the failed audit entry")
+}
+
+func TestApplicationService_AddApplication_PartialFailureRecovery(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+	seedRuntimeAndFramework(t, ctx, repos.RunTimeRepository, repos.FrameWorkRepository)
+	flaky := &flakyAppDependencyRepository{AppDependencyRepository: repos.AppToDepedencyRepository}
+	repos.AppToDepedencyRepository = flaky
+
+	appService := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, offlineGitHubAPI{}, services.ServiceConfig{})
+	t.Cleanup(func() { _ = appService.Shutdown(context.Background()) })
+
+	resp, err := appService.AddApplication(ctx, "partial-app", "Go", "Gin", "", "go.mod", transactionTestGoMod, nil)
+	require.NoError(t, err)
+	require.Len(t, resp.DependencyParse, 2)
+
+	app, err := repos.AppRepository.GetByName(ctx, "partial-app")
+	require.NoError(t, err)
+	require.NotNil(t, app)
+
+	// Background processing records the failed link in the audit trail after setting the final status
+	var failureAudit *entity.AuditTrail
+	require.Eventually(t, func() bool {
+		audits, err := repos.AuditTrailRepository.GetByEntity(ctx, "app", app.ID, 10, 0)
+		if err != nil {
+			return false
+		}
+		for _, audit := range audits {
+			if audit.Action == "dependency_processing_failed" {
+				failureAudit = audit
+				return true
+			}
+		}
+		return false
+	}, 5*time.Second, 20*time.Millisecond)
+	assert.Contains(t, string(failureAudit.NewValues), "transient write failure")
+
+	// The successful link stays committed even though the other one failed
+	links, err := repos.AppToDepedencyRepository.GetByAppID(ctx, app.ID)
+	require.NoError(t, err)
+	require.Len(t, links, 1)
+	stored, err := repos.AppRepository.GetByID(ctx, app.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "inactive", stored.Status)
+
+	// Recover by re-submitting the dependency whose link failed, then re-activating the app
+	linked := map[string]bool{links[0].DependencyID.String(): true}
+	deps, err := repos.DepedencyRepository.GetAll(ctx)
+	require.NoError(t, err)
+	var retry []model.DependencyInfoRequest
+	for _, dep := range deps {
+		if !linked[dep.ID.String()] {
+			retry = append(retry, model.DependencyInfoRequest{Name: dep.Name, Owner: dep.Owner, Repo: dep.Repo, Version: "v1.0.0"})
+		}
+	}
+	require.Len(t, retry, 1)
+
+	_, err = appService.AddApplicationDependency(ctx, app.ID.String(), retry)
+	require.NoError(t, err)
+	require.NoError(t, appService.RecoverApplication(ctx, app.ID.String()))
+
+	links, err = repos.AppToDepedencyRepository.GetByAppID(ctx, app.ID)
+	require.NoError(t, err)
+	assert.Len(t, links, 2)
+	recovered, err := repos.AppRepository.GetByID(ctx, app.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "active", recovered.Status)
+}
@@ -0,0 +1,102 @@
+package services_test
+
+import (
+	"context"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/model"
+	"elang-backend/internal/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// autoScanTestGoMod declares a single dependency, so background processing makes no concurrent writes that
+// the shared-cache test database could reject
+const autoScanTestGoMod = `module example.com/demo
+
+go 1.22
+
+require github.com/gin-gonic/gin v1.9.1
+`
+
+func TestApplicationService_AddApplication_AutoScan(t *testing.T) {
+	ctx := context.Background()
+	source := &versionedSource{vulns: map[string][]helper.VulnerabilityInfo{
+		"github.com/gin-gonic/gin@1.9.1": {{ID: "GO-2023-2001", Severity: helper.SeverityHigh, Score: 7.5, Source: "osv"}},
+	}}
+	scanner := helper.ScannerConfig{Sources: []helper.VulnerabilitySource{source}}
+
+	repos := setupScanTestRepos(t)
+	seedRuntimeAndFramework(t, ctx, repos.RunTimeRepository, repos.FrameWorkRepository)
+	appService := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, offlineGitHubAPI{}, services.ServiceConfig{Scanner: scanner})
+	t.Cleanup(func() { _ = appService.Shutdown(context.Background()) })
+
+	enabled := true
+	resp, err := appService.AddApplication(ctx, "scanned-app", "Go", "Gin", "", "go.mod", autoScanTestGoMod, &enabled)
+	require.NoError(t, err)
+	assert.True(t, resp.AutoScan)
+
+	app, err := repos.AppRepository.GetByName(ctx, "scanned-app")
+	require.NoError(t, err)
+	require.NotNil(t, app)
+
+	// The first scan is stored once background processing has linked the dependencies
+	require.Eventually(t, func() bool {
+		scans, err := repos.ScanResultRepository.GetByAppID(ctx, app.ID, 10, 0)
+		return err == nil && len(scans) == 1
+	}, 5*time.Second, 20*time.Millisecond)
+	scans, err := repos.ScanResultRepository.GetByAppID(ctx, app.ID, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "completed", scans[0].Status)
+	assert.Equal(t, "fail", scans[0].PolicyStatus)
+
+	reported := func() string {
+		status, err := appService.GetApplicationStatus(ctx, resp.AppID)
+		require.NoError(t, err)
+		return status["status"].(model.ApplicationStatus).Status
+	}
+	require.Eventually(t, func() bool { return reported() == "vulnerable" }, 5*time.Second, 20*time.Millisecond,
+		"a failing first scan reports the application vulnerable")
+
+	// The status follows the latest scan, so a passing rescan clears it
+	source.mu.Lock()
+	source.vulns = nil
+	source.mu.Unlock()
+	_, err = appService.ScanApplicationDependencies(ctx, resp.AppID)
+	require.NoError(t, err)
+	assert.Equal(t, "active", reported())
+	listed, err := appService.ListApplications(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, listed.Applications, 1)
+	assert.Equal(t, "active", listed.Applications[0].Status)
+}
+
+func TestApplicationService_AddApplication_NoAutoScanByDefault(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+	seedRuntimeAndFramework(t, ctx, repos.RunTimeRepository, repos.FrameWorkRepository)
+	appService := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, offlineGitHubAPI{}, services.ServiceConfig{})
+
+	resp, err := appService.AddApplication(ctx, "unscanned-app", "Go", "Gin", "", "go.mod", autoScanTestGoMod, nil)
+	require.NoError(t, err)
+	assert.False(t, resp.AutoScan)
+
+	var appStatus string
+	require.Eventually(t, func() bool {
+		app, err := repos.AppRepository.GetByName(ctx, "unscanned-app")
+		if err == nil && app != nil {
+			appStatus = app.Status
+		}
+		return appStatus == "active"
+	}, 5*time.Second, 20*time.Millisecond)
+
+	// Once processing has finished and the background job is gone, no scan may have been stored
+	require.NoError(t, appService.Shutdown(context.Background()))
+	app, err := repos.AppRepository.GetByName(ctx, "unscanned-app")
+	require.NoError(t, err)
+	scans, err := repos.ScanResultRepository.GetByAppID(ctx, app.ID, 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, scans)
+}
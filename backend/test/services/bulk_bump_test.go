@@ -0,0 +1,78 @@
+package services_test
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/services"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestApplicationService_BulkUpdateDependencyVersion moves the applications on v1.5.0 to v1.6.0 and checks
+// that the one on another version is left alone
+func TestApplicationService_BulkUpdateDependencyVersion(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+	billing, dep := seedDependencyWithMetadata(t, repos)
+
+	seedApp := func(name, version string) *entity.App {
+		app := &entity.App{ID: uuid.New(), Name: name, Status: "active"}
+		require.NoError(t, repos.AppRepository.Create(ctx, app))
+		require.NoError(t, repos.AppToDepedencyRepository.Create(ctx, &entity.AppDependency{ID: uuid.New(), AppID: app.ID, DependencyID: dep.ID, UsedVersion: version}))
+		return app
+	}
+	checkout := seedApp("checkout", "1.5.0") // the same version without the tag prefix
+	legacy := seedApp("legacy", "v1.4.0")
+
+	appService := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, releasedGitHubAPI{}, services.ServiceConfig{})
+	t.Cleanup(func() { _ = appService.Shutdown(context.Background()) })
+
+	resp, err := appService.BulkUpdateDependencyVersion(ctx, "Google", "uuid", "v1.5.0", "v1.6.0")
+	require.NoError(t, err)
+	assert.Equal(t, "v1.6.0", resp.ToVersion)
+	assert.Equal(t, "sha-6", resp.ToCommitSHA)
+	assert.Equal(t, 2, resp.Updated)
+	assert.Zero(t, resp.Failed)
+
+	previous := map[string]string{}
+	for _, result := range resp.Applications {
+		assert.Equal(t, "updated", result.Status)
+		previous[result.AppName] = result.PreviousVersion
+	}
+	assert.Equal(t, map[string]string{"billing": "v1.5.0", "checkout": "1.5.0"}, previous)
+
+	for _, app := range []*entity.App{billing, checkout} {
+		appDep, err := repos.AppToDepedencyRepository.GetByAppAndDependencyID(ctx, app.ID, dep.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "v1.6.0", appDep.UsedVersion)
+		require.NotNil(t, appDep.UsedCommitSHA)
+		assert.Equal(t, "sha-6", *appDep.UsedCommitSHA)
+	}
+	untouched, err := repos.AppToDepedencyRepository.GetByAppAndDependencyID(ctx, legacy.ID, dep.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "v1.4.0", untouched.UsedVersion)
+
+	audit, err := repos.AuditTrailRepository.GetByEntity(ctx, "app", billing.ID, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, audit, 1)
+	assert.Equal(t, "dependency_version_bumped", audit[0].Action)
+}
+
+func TestApplicationService_BulkUpdateDependencyVersion_RejectsBadInput(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+	seedDependencyWithMetadata(t, repos)
+	appService := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, releasedGitHubAPI{}, services.ServiceConfig{})
+	t.Cleanup(func() { _ = appService.Shutdown(context.Background()) })
+
+	_, err := appService.BulkUpdateDependencyVersion(ctx, "google", "uuid", "v1.5.0", "1.5.0")
+	assert.ErrorIs(t, err, services.ErrInvalidInput)
+	_, err = appService.BulkUpdateDependencyVersion(ctx, "google", "uuid", "", "v1.6.0")
+	assert.ErrorIs(t, err, services.ErrInvalidInput)
+	_, err = appService.BulkUpdateDependencyVersion(ctx, "google", "missing", "v1.5.0", "v1.6.0")
+	assert.ErrorIs(t, err, services.ErrNotFound)
+}
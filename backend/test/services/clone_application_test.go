@@ -0,0 +1,87 @@
+package services_test
+
+import (
+	"context"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/services"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplicationService_CloneApplication(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+	source, dep := seedDependencyWithMetadata(t, repos)
+
+	sourceDeps, err := repos.AppToDepedencyRepository.GetByAppID(ctx, source.ID)
+	require.NoError(t, err)
+	require.Len(t, sourceDeps, 1)
+	sourceDeps[0].IsMonitored = true
+	sourceDeps[0].PollingIntervalMinutes = 15
+	sourceDeps[0].TotalChecksCount = 7
+	require.NoError(t, repos.AppToDepedencyRepository.Update(ctx, sourceDeps[0]))
+
+	svc := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, releasedGitHubAPI{}, services.ServiceConfig{})
+	t.Cleanup(func() { _ = svc.Shutdown(context.Background()) })
+
+	resp, err := svc.CloneApplication(ctx, source.ID.String(), "  billing-v2 ")
+	require.NoError(t, err)
+	assert.Equal(t, "billing-v2", resp.AppName)
+	assert.Equal(t, source.ID.String(), resp.SourceAppID)
+	assert.Equal(t, 1, resp.DependencyCount)
+	assert.Equal(t, "inactive", resp.Status)
+
+	waitCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	require.NoError(t, svc.(*services.ApplicationService).Wait(waitCtx))
+
+	clone, err := repos.AppRepository.GetByName(ctx, "billing-v2")
+	require.NoError(t, err)
+	require.NotNil(t, clone)
+	assert.Equal(t, "active", clone.Status, "the clone is activated once its metadata is refreshed")
+
+	cloneDeps, err := repos.AppToDepedencyRepository.GetByAppID(ctx, clone.ID)
+	require.NoError(t, err)
+	require.Len(t, cloneDeps, 1)
+	cloned := cloneDeps[0]
+	assert.NotEqual(t, sourceDeps[0].ID, cloned.ID)
+	assert.Equal(t, dep.ID, cloned.DependencyID)
+	assert.Equal(t, "v1.5.0", cloned.UsedVersion)
+	assert.True(t, cloned.IsMonitored)
+	assert.Equal(t, 15, cloned.PollingIntervalMinutes)
+	assert.Zero(t, cloned.TotalChecksCount, "monitoring history is not copied")
+	require.NotNil(t, cloned.UsedCommitSHA)
+	assert.Equal(t, "sha-5", *cloned.UsedCommitSHA)
+	require.NotNil(t, cloned.ReleasesBehind)
+	assert.Equal(t, 1, *cloned.ReleasesBehind)
+
+	// The source application is left untouched
+	sourceDeps, err = repos.AppToDepedencyRepository.GetByAppID(ctx, source.ID)
+	require.NoError(t, err)
+	require.Len(t, sourceDeps, 1)
+	assert.Equal(t, 7, sourceDeps[0].TotalChecksCount)
+}
+
+func TestApplicationService_CloneApplication_Rejections(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+	source, _ := seedDependencyWithMetadata(t, repos)
+	svc := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, nil, services.ServiceConfig{})
+	t.Cleanup(func() { _ = svc.Shutdown(context.Background()) })
+
+	_, err := svc.CloneApplication(ctx, source.ID.String(), source.Name)
+	assert.ErrorIs(t, err, services.ErrConflict)
+
+	_, err = svc.CloneApplication(ctx, source.ID.String(), " ")
+	assert.ErrorIs(t, err, services.ErrInvalidInput)
+
+	_, err = svc.CloneApplication(ctx, "not-a-uuid", "copy")
+	assert.ErrorIs(t, err, services.ErrInvalidInput)
+
+	_, err = svc.CloneApplication(ctx, uuid.NewString(), "copy")
+	assert.ErrorIs(t, err, services.ErrNotFound)
+}
@@ -0,0 +1,113 @@
+package services_test
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/model"
+	"elang-backend/internal/model/dto"
+	"elang-backend/internal/services"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seedDashboardScan stores a completed application scan with the given findings
+func seedDashboardScan(t *testing.T, repos dto.BasicRepositories, app *entity.App, policy string, findings []model.ScanFinding) {
+	t.Helper()
+	ctx := context.Background()
+
+	var summary model.ScanSummary
+	for _, finding := range findings {
+		summary.TotalVulnerabilities += len(finding.VulnerabilityIDs)
+		switch finding.Severity {
+		case "critical":
+			summary.Critical++
+		case "high":
+			summary.High++
+		case "medium":
+			summary.Medium++
+		case "low":
+			summary.Low++
+		}
+	}
+	payload, err := json.Marshal(model.ScanApplicationResult{AppID: app.ID.String(), AppName: app.Name, Summary: summary, Findings: findings})
+	require.NoError(t, err)
+
+	completedAt := time.Now().UTC()
+	require.NoError(t, repos.ScanResultRepository.Create(ctx, &entity.ScanResult{
+		ID:                   uuid.New(),
+		AppID:                &app.ID,
+		AppName:              app.Name,
+		ScanType:             "application",
+		Status:               "completed",
+		PolicyStatus:         policy,
+		TotalVulnerabilities: summary.TotalVulnerabilities,
+		Critical:             summary.Critical,
+		High:                 summary.High,
+		Medium:               summary.Medium,
+		Low:                  summary.Low,
+		Result:               payload,
+		CompletedAt:          &completedAt,
+		CreatedAt:            completedAt,
+	}))
+}
+
+func TestDependenciesService_GetDashboardSummary(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+
+	newApp := func(name string) *entity.App {
+		app := &entity.App{ID: uuid.New(), Name: name, Status: "active"}
+		require.NoError(t, repos.AppRepository.Create(ctx, app))
+		return app
+	}
+	payments, web, docs := newApp("payments"), newApp("web"), newApp("docs")
+	newApp("never-scanned")
+
+	seedDashboardScan(t, repos, payments, "fail", []model.ScanFinding{
+		{Dependency: "lodash", Version: "4.17.20", Severity: "critical", VulnerabilityIDs: []string{"GHSA-1", "GHSA-2"}},
+		{Dependency: "axios", Version: "0.21.0", Severity: "high", VulnerabilityIDs: []string{"GHSA-3"}},
+	})
+	seedDashboardScan(t, repos, web, "pass", []model.ScanFinding{
+		{Dependency: "lodash", Version: "4.17.15", Severity: "medium", VulnerabilityIDs: []string{"GHSA-4"}},
+		{Dependency: "react", Version: "18.2.0", Severity: "low"},
+	})
+	seedDashboardScan(t, repos, docs, "pass", nil)
+
+	svc := services.NewDependenciesService(repos, *helper.NewDependencyParser(), nil, services.ServiceConfig{})
+
+	summary, err := svc.GetDashboardSummary(ctx, 0)
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, summary.TotalApplications)
+	assert.Equal(t, 3, summary.ScannedApplications)
+	assert.Equal(t, 1, summary.FailingApplications)
+	assert.Equal(t, model.DashboardSeverityTotals{Total: 4, Critical: 1, High: 1, Medium: 1, Low: 1}, summary.Vulnerabilities)
+
+	require.Len(t, summary.TopVulnerableDependencies, 2)
+	assert.Equal(t, model.DashboardDependency{Dependency: "lodash", Applications: 2, Vulnerabilities: 3, HighestSeverity: "critical"}, summary.TopVulnerableDependencies[0])
+	assert.Equal(t, "axios", summary.TopVulnerableDependencies[1].Dependency)
+
+	// docs has no findings and is not at risk
+	require.Len(t, summary.MostAtRiskApplications, 2)
+	assert.Equal(t, "payments", summary.MostAtRiskApplications[0].AppName)
+	assert.Equal(t, "web", summary.MostAtRiskApplications[1].AppName)
+
+	t.Run("TopN", func(t *testing.T) {
+		summary, err := svc.GetDashboardSummary(ctx, 1)
+		require.NoError(t, err)
+		assert.Len(t, summary.TopVulnerableDependencies, 1)
+		assert.Len(t, summary.MostAtRiskApplications, 1)
+	})
+
+	t.Run("TopNTooLarge", func(t *testing.T) {
+		_, err := svc.GetDashboardSummary(ctx, services.MaxDashboardTopN+1)
+		assert.True(t, errors.Is(err, services.ErrInvalidInput))
+	})
+}
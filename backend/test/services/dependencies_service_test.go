@@ -2,11 +2,22 @@ package services_test
 
 import (
 	"context"
+	"elang-backend/internal/entity"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/model"
+	"elang-backend/internal/model/dto"
+	"elang-backend/internal/repository"
 	"elang-backend/internal/services"
+	"elang-backend/test/mocks"
+	"encoding/json"
+	"errors"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // Mock DependenciesService
@@ -14,11 +25,24 @@ type mockDependenciesService struct {
 	mock.Mock
 }
 
+func (m *mockDependenciesService) Shutdown(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
 func (m *mockDependenciesService) ScanDependencies(ctx context.Context, appName, runtime, version, description, fileName, content string) (interface{}, error) {
 	args := m.Called(ctx, appName, runtime, version, description, fileName, content)
 	return args.Get(0), args.Error(1)
 }
 
+func (m *mockDependenciesService) CheckDependency(ctx context.Context, req *model.CheckDependencyRequest) (*helper.DependencyVulnerabilityResult, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*helper.DependencyVulnerabilityResult), args.Error(1)
+}
+
 func (m *mockDependenciesService) GetSBOMById(ctx context.Context, appName, sbomID string) ([]byte, error) {
 	args := m.Called(ctx, appName, sbomID)
 	if args.Get(0) == nil {
@@ -27,6 +51,77 @@ func (m *mockDependenciesService) GetSBOMById(ctx context.Context, appName, sbom
 	return args.Get(0).([]byte), args.Error(1)
 }
 
+func (m *mockDependenciesService) GetScanResult(ctx context.Context, scanID string) (*model.ScanApplicationResult, error) {
+	args := m.Called(ctx, scanID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ScanApplicationResult), args.Error(1)
+}
+
+func (m *mockDependenciesService) DiffManifests(ctx context.Context, runtime, oldFileName, oldContent, newFileName, newContent string) (*model.ManifestDiffResult, error) {
+	args := m.Called(ctx, runtime, oldFileName, oldContent, newFileName, newContent)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ManifestDiffResult), args.Error(1)
+}
+
+func (m *mockDependenciesService) GetScanStatus(ctx context.Context, scanID string) (*model.ScanJobStatus, error) {
+	args := m.Called(ctx, scanID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ScanJobStatus), args.Error(1)
+}
+
+func (m *mockDependenciesService) EvaluateScanPolicy(ctx context.Context, scanID string, req *model.EvaluatePolicyRequest) (*model.PolicyEvaluation, error) {
+	args := m.Called(ctx, scanID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.PolicyEvaluation), args.Error(1)
+}
+
+func (m *mockDependenciesService) GetDashboardSummary(ctx context.Context, topN int) (*model.DashboardSummary, error) {
+	args := m.Called(ctx, topN)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.DashboardSummary), args.Error(1)
+}
+
+func (m *mockDependenciesService) SearchDependencies(ctx context.Context, search string, limit, offset int) (*model.DependencyCatalogResponse, error) {
+	args := m.Called(ctx, search, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.DependencyCatalogResponse), args.Error(1)
+}
+
+func (m *mockDependenciesService) ListFindings(ctx context.Context, appUID string, query model.FindingsQuery) (*model.FindingsResponse, error) {
+	args := m.Called(ctx, appUID, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.FindingsResponse), args.Error(1)
+}
+
+func (m *mockDependenciesService) StreamLatestFindings(ctx context.Context, appUID string, write func(model.ScanFinding) error) error {
+	args := m.Called(ctx, appUID, write)
+	return args.Error(0)
+}
+
+func (m *mockDependenciesService) ScanMultipleDependencies(ctx context.Context, appName, runtime, version, description string, files []helper.ManifestFile) (interface{}, error) {
+	args := m.Called(ctx, appName, runtime, version, description, files)
+	return args.Get(0), args.Error(1)
+}
+
+func (m *mockDependenciesService) SeverityStyles() []helper.SeverityStyle {
+	args := m.Called()
+	return args.Get(0).([]helper.SeverityStyle)
+}
+
 func (m *mockDependenciesService) StartMonitoringApplication(ctx context.Context, appUID string) error {
 	args := m.Called(ctx, appUID)
 	return args.Error(0)
@@ -169,3 +264,116 @@ func TestDependenciesService_GetSBOMById_EmptyParams(t *testing.T) {
 		assert.Error(t, err)
 	}
 }
+
+// Mock ScanResultRepository
+func TestDependenciesService_GetScanResult(t *testing.T) {
+	ctx := context.Background()
+	scanRepo := new(mocks.ScanResultRepository)
+	svc := services.NewDependenciesService(dto.BasicRepositories{ScanResultRepository: scanRepo}, *helper.NewDependencyParser(), nil, services.ServiceConfig{})
+
+	t.Run("Found", func(t *testing.T) {
+		scanID := uuid.New()
+		stored, err := json.Marshal(model.ScanApplicationResult{
+			ScanID:   scanID.String(),
+			AppName:  "test-app",
+			Findings: []model.ScanFinding{{Dependency: "lodash", Severity: "high"}},
+		})
+		require.NoError(t, err)
+		scanRepo.On("GetByID", ctx, scanID).Return(&entity.ScanResult{ID: scanID, Result: stored}, nil).Once()
+
+		result, err := svc.GetScanResult(ctx, scanID.String())
+		assert.NoError(t, err)
+		require.NotNil(t, result)
+		assert.Equal(t, scanID.String(), result.ScanID)
+		assert.Len(t, result.Findings, 1)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		scanID := uuid.New()
+		scanRepo.On("GetByID", ctx, scanID).Return(nil, repository.ErrNotFound).Once()
+
+		result, err := svc.GetScanResult(ctx, scanID.String())
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, services.ErrNotFound)
+	})
+
+	t.Run("RepositoryFailure", func(t *testing.T) {
+		scanID := uuid.New()
+		scanRepo.On("GetByID", ctx, scanID).Return(nil, errors.New("database is locked")).Once()
+
+		result, err := svc.GetScanResult(ctx, scanID.String())
+		assert.Nil(t, result)
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, services.ErrNotFound)
+	})
+
+	t.Run("InvalidID", func(t *testing.T) {
+		_, err := svc.GetScanResult(ctx, "not-a-uuid")
+		assert.ErrorIs(t, err, services.ErrInvalidInput)
+	})
+}
+
+func TestDependenciesService_Shutdown_StopsMonitoring(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+
+	runtime := &entity.Runtime{Name: "Go"}
+	require.NoError(t, repos.RunTimeRepository.Create(ctx, runtime))
+	app := &entity.App{ID: uuid.New(), Name: "monitored-app", RuntimeID: &runtime.ID, Status: "active"}
+	require.NoError(t, repos.AppRepository.Create(ctx, app))
+
+	svc := services.NewDependenciesService(repos, *helper.NewDependencyParser(), nil, services.ServiceConfig{})
+	require.NoError(t, svc.StartMonitoringApplication(ctx, app.ID.String()))
+
+	shutdownCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	assert.NoError(t, svc.Shutdown(shutdownCtx), "monitoring loop should exit on shutdown")
+	// Shutdown is idempotent
+	assert.NoError(t, svc.Shutdown(shutdownCtx))
+}
+
+func TestApplicationService_Shutdown_WaitsForScans(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+
+	runtime := &entity.Runtime{Name: "Go"}
+	require.NoError(t, repos.RunTimeRepository.Create(ctx, runtime))
+	framework := &entity.Framework{Name: "Gin"}
+	require.NoError(t, repos.FrameWorkRepository.Create(ctx, framework))
+	app := &entity.App{ID: uuid.New(), Name: "scan-app", RuntimeID: &runtime.ID, FrameworkID: &framework.ID, Status: "active"}
+	require.NoError(t, repos.AppRepository.Create(ctx, app))
+
+	svc := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, nil, services.ServiceConfig{})
+	job, err := svc.StartApplicationScan(ctx, app.ID.String())
+	require.NoError(t, err)
+
+	shutdownCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	require.NoError(t, svc.Shutdown(shutdownCtx))
+
+	// Once Shutdown returns the background scan has finished writing its record
+	scan, err := repos.ScanResultRepository.GetByID(ctx, uuid.MustParse(job.ScanID))
+	require.NoError(t, err)
+	assert.Contains(t, []string{"completed", "failed"}, scan.Status)
+}
+
+func TestDependenciesService_CheckDependency_InvalidInput(t *testing.T) {
+	svc := services.NewDependenciesService(dto.BasicRepositories{}, *helper.NewDependencyParser(), nil, services.ServiceConfig{})
+	t.Cleanup(func() { _ = svc.Shutdown(context.Background()) })
+
+	tests := []struct {
+		name string
+		req  model.CheckDependencyRequest
+	}{
+		{"missing version", model.CheckDependencyRequest{Name: "lodash", Runtime: "Node.js"}},
+		{"missing name", model.CheckDependencyRequest{Version: "4.17.20", Runtime: "Node.js"}},
+		{"unknown runtime", model.CheckDependencyRequest{Name: "lodash", Version: "4.17.20", Runtime: "Cobol"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := svc.CheckDependency(context.Background(), &tt.req)
+			assert.ErrorIs(t, err, services.ErrInvalidInput)
+			assert.Nil(t, result)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package services_test
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/services"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDependenciesService_SearchDependencies(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+
+	tag := "v4.18.2"
+	express := &entity.Dependency{ID: uuid.New(), Name: "express", Owner: "expressjs", Repo: "express", LastTag: &tag}
+	require.NoError(t, repos.DepedencyRepository.Create(ctx, express))
+	require.NoError(t, repos.DepedencyRepository.Create(ctx, &entity.Dependency{ID: uuid.New(), Name: "lodash", Owner: "lodash", Repo: "lodash"}))
+
+	app := &entity.App{ID: uuid.New(), Name: "web", Status: "active"}
+	require.NoError(t, repos.AppRepository.Create(ctx, app))
+	require.NoError(t, repos.AppToDepedencyRepository.Create(ctx, &entity.AppDependency{ID: uuid.New(), AppID: app.ID, DependencyID: express.ID, UsedVersion: "4.18.2"}))
+
+	svc := services.NewDependenciesService(repos, *helper.NewDependencyParser(), nil, services.ServiceConfig{})
+
+	catalog, err := svc.SearchDependencies(ctx, " EXPRESS ", 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), catalog.Total)
+	assert.Equal(t, services.DefaultDependencyPageSize, catalog.Limit)
+	require.Len(t, catalog.Dependencies, 1)
+	item := catalog.Dependencies[0]
+	assert.Equal(t, express.ID.String(), item.DependencyID)
+	assert.Equal(t, "expressjs", item.Owner)
+	assert.Equal(t, &tag, item.LastTag)
+	assert.Equal(t, 1, item.ApplicationCount)
+
+	t.Run("EmptySearchListsAll", func(t *testing.T) {
+		catalog, err := svc.SearchDependencies(ctx, "", 0, 0)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), catalog.Total)
+		assert.Len(t, catalog.Dependencies, 2)
+	})
+
+	t.Run("LimitTooLarge", func(t *testing.T) {
+		_, err := svc.SearchDependencies(ctx, "", services.MaxDependencyPageSize+1, 0)
+		assert.True(t, errors.Is(err, services.ErrInvalidInput))
+	})
+}
@@ -0,0 +1,111 @@
+package services_test
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/model"
+	"elang-backend/internal/services"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// changelogGitHubAPI serves a repository tagged v1.0.0 to v1.2.0, one commit per tag
+type changelogGitHubAPI struct {
+	offlineGitHubAPI
+	releasesErr error
+}
+
+func (changelogGitHubAPI) ListTags(owner, repo string) ([]map[string]interface{}, error) {
+	return []map[string]interface{}{
+		{"name": "v1.2.0", "commit_sha": "sha-120"},
+		{"name": "v1.1.0", "commit_sha": "sha-110"},
+		{"name": "v1.0.0", "commit_sha": "sha-100"},
+	}, nil
+}
+
+func (changelogGitHubAPI) CompareCommits(owner, repo, base, head string) (*model.CompareCommitResult, error) {
+	if base != "v1.0.0" || head != "v1.2.0" {
+		return nil, errors.New("unexpected comparison " + base + "..." + head)
+	}
+	result := &model.CompareCommitResult{Status: "ahead", TotalCommits: 2, HTMLURL: "https://github.com/google/uuid/compare/v1.0.0...v1.2.0"}
+	for _, commit := range []struct{ sha, message string }{{"sha-110", "Add NewV7"}, {"sha-120", "Fix parsing of braced UUIDs"}} {
+		summary := model.CommitSummary{SHA: commit.sha}
+		summary.Commit.Message = commit.message
+		summary.Commit.Author.Name = "gopher"
+		result.Commits = append(result.Commits, summary)
+	}
+	return result, nil
+}
+
+func (g changelogGitHubAPI) ListReleases(owner, repo string) ([]model.Release, error) {
+	if g.releasesErr != nil {
+		return nil, g.releasesErr
+	}
+	return []model.Release{
+		{TagName: "v1.2.0", Name: "v1.2.0", Body: "Bug fixes"},
+		{TagName: "v1.1.0", Name: "v1.1.0", Body: "UUIDv7 support"},
+		{TagName: "v1.0.0", Name: "v1.0.0", Body: "First stable release"},
+	}, nil
+}
+
+func TestApplicationService_GetDependencyChangelog(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+	dep := &entity.Dependency{ID: uuid.New(), Name: "github.com/google/uuid", Owner: "google", Repo: "uuid"}
+	require.NoError(t, repos.DepedencyRepository.Create(ctx, dep))
+
+	appService := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, changelogGitHubAPI{}, services.ServiceConfig{})
+	t.Cleanup(func() { _ = appService.Shutdown(context.Background()) })
+
+	changelog, err := appService.GetDependencyChangelog(ctx, dep.ID.String(), "1.0.0", "v1.2.0")
+	require.NoError(t, err)
+	assert.Equal(t, "v1.0.0", changelog.From)
+	assert.Equal(t, "v1.2.0", changelog.To)
+	assert.Equal(t, "ahead", changelog.Status)
+	assert.Equal(t, 2, changelog.TotalCommits)
+	require.Len(t, changelog.Commits, 2)
+	assert.Equal(t, "Add NewV7", changelog.Commits[0].Message)
+	assert.Equal(t, "gopher", changelog.Commits[0].Author)
+
+	// The release of the version upgraded from is not part of the changelog
+	var releaseTags []string
+	for _, release := range changelog.Releases {
+		releaseTags = append(releaseTags, release.Tag)
+	}
+	assert.Equal(t, []string{"v1.2.0", "v1.1.0"}, releaseTags)
+	assert.Equal(t, "UUIDv7 support", changelog.Releases[1].Body)
+
+	t.Run("ReleasesUnavailable", func(t *testing.T) {
+		degraded := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, changelogGitHubAPI{releasesErr: errors.New("rate limited")}, services.ServiceConfig{})
+		t.Cleanup(func() { _ = degraded.Shutdown(context.Background()) })
+
+		changelog, err := degraded.GetDependencyChangelog(ctx, dep.ID.String(), "v1.0.0", "v1.2.0")
+		require.NoError(t, err)
+		assert.Len(t, changelog.Commits, 2)
+		assert.Empty(t, changelog.Releases)
+	})
+}
+
+func TestApplicationService_GetDependencyChangelog_Errors(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+	dep := &entity.Dependency{ID: uuid.New(), Name: "github.com/google/uuid", Owner: "google", Repo: "uuid"}
+	require.NoError(t, repos.DepedencyRepository.Create(ctx, dep))
+
+	appService := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, changelogGitHubAPI{}, services.ServiceConfig{})
+	t.Cleanup(func() { _ = appService.Shutdown(context.Background()) })
+
+	_, err := appService.GetDependencyChangelog(ctx, "not-a-uuid", "v1.0.0", "v1.2.0")
+	assert.ErrorIs(t, err, services.ErrInvalidInput)
+	_, err = appService.GetDependencyChangelog(ctx, dep.ID.String(), "", "v1.2.0")
+	assert.ErrorIs(t, err, services.ErrInvalidInput)
+	_, err = appService.GetDependencyChangelog(ctx, uuid.NewString(), "v1.0.0", "v1.2.0")
+	assert.ErrorIs(t, err, services.ErrNotFound)
+	_, err = appService.GetDependencyChangelog(ctx, dep.ID.String(), "v1.0.0", "v9.9.9")
+	assert.ErrorIs(t, err, services.ErrNotFound, "versions without a tag are reported as not found")
+}
@@ -0,0 +1,43 @@
+package services_test
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/services"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServices_RejectManifestsOverDependencyLimit(t *testing.T) {
+	ctx := context.Background()
+	// transactionTestGoMod declares two dependencies
+
+	t.Run("AddApplication", func(t *testing.T) {
+		repos := setupScanTestRepos(t)
+		require.NoError(t, repos.RunTimeRepository.Create(ctx, &entity.Runtime{Name: "Go"}))
+		require.NoError(t, repos.FrameWorkRepository.Create(ctx, &entity.Framework{Name: "Default"}))
+		appService := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, offlineGitHubAPI{}, services.ServiceConfig{MaxDependenciesPerApp: 1})
+		t.Cleanup(func() { _ = appService.Shutdown(context.Background()) })
+
+		_, err := appService.AddApplication(ctx, "huge-app", "Go", "Default", "", "go.mod", transactionTestGoMod, nil)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, services.ErrTooManyDependencies))
+		assert.Contains(t, err.Error(), "declares 2 dependencies, more than the limit of 1")
+
+		app, err := repos.AppRepository.GetByName(ctx, "huge-app")
+		require.NoError(t, err)
+		assert.Nil(t, app, "nothing is created for a rejected manifest")
+	})
+
+	t.Run("ScanDependencies", func(t *testing.T) {
+		depService := services.NewDependenciesService(setupScanTestRepos(t), *helper.NewDependencyParser(), nil, services.ServiceConfig{MaxDependenciesPerApp: 1})
+		t.Cleanup(func() { _ = depService.Shutdown(context.Background()) })
+
+		_, err := depService.ScanDependencies(ctx, "huge-app", "go", "1.0.0", "", "go.mod", transactionTestGoMod)
+		assert.True(t, errors.Is(err, services.ErrTooManyDependencies))
+	})
+}
@@ -0,0 +1,108 @@
+package services_test
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/model"
+	"elang-backend/internal/services"
+	"elang-backend/internal/usecase"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// abandonedGitHubAPI reports an archived repository with one collaborator, last committed to in January 2022
+type abandonedGitHubAPI struct {
+	offlineGitHubAPI
+}
+
+func (abandonedGitHubAPI) GetRepoInfo(owner, repo string) (map[string]interface{}, error) {
+	return map[string]interface{}{"full_name": owner + "/" + repo, "archived": true, "stargazers_count": float64(42)}, nil
+}
+
+func (api abandonedGitHubAPI) GetRepoInfoIfModified(owner, repo string, since usecase.GitHubValidators) (map[string]interface{}, usecase.GitHubValidators, error) {
+	repoInfo, err := api.GetRepoInfo(owner, repo)
+	return repoInfo, usecase.GitHubValidators{ETag: `"v1"`}, err
+}
+
+func (abandonedGitHubAPI) GetDefaultBranch(owner, repo string) (string, error) {
+	return "main", nil
+}
+
+func (abandonedGitHubAPI) GetListCommits(owner, repo, branch string, limit int) ([]map[string]interface{}, error) {
+	return []map[string]interface{}{{"oid": "last-sha", "author_date": "2022-01-01T00:00:00Z"}}, nil
+}
+
+func (abandonedGitHubAPI) ListCollaborators(owner, repo string) ([]map[string]interface{}, error) {
+	return []map[string]interface{}{{"login": "solo-maintainer"}}, nil
+}
+
+func TestApplicationService_DependencyMaintenance(t *testing.T) {
+	ctx := context.Background()
+	scanner := helper.ScannerConfig{Sources: []helper.VulnerabilitySource{&versionedSource{}}}
+
+	repos := setupScanTestRepos(t)
+	app, dep := seedDependencyWithMetadata(t, repos)
+	runtime := &entity.Runtime{Name: "Go"}
+	require.NoError(t, repos.RunTimeRepository.Create(ctx, runtime))
+	app.RuntimeID = &runtime.ID
+	require.NoError(t, repos.AppRepository.Update(ctx, app))
+
+	appService := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, abandonedGitHubAPI{}, services.ServiceConfig{Scanner: scanner, CountCollaborators: true})
+	t.Cleanup(func() { _ = appService.Shutdown(context.Background()) })
+
+	before, err := appService.GetDependencyMaintenance(ctx, dep.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, helper.MaintenanceRiskUnknown, before.Risk, "nothing is known until the signals are fetched")
+	assert.Nil(t, before.CheckedAt)
+	assert.Empty(t, before.Reasons)
+
+	_, err = appService.RefreshDependencyMetadata(ctx, dep.ID.String())
+	require.NoError(t, err)
+
+	signals, err := appService.GetDependencyMaintenance(ctx, dep.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, helper.MaintenanceRiskHigh, signals.Risk)
+	require.NotNil(t, signals.Archived)
+	assert.True(t, *signals.Archived)
+	require.NotNil(t, signals.Stars)
+	assert.Equal(t, 42, *signals.Stars)
+	require.NotNil(t, signals.Collaborators)
+	assert.Equal(t, 1, *signals.Collaborators)
+	require.NotNil(t, signals.DaysSinceLastCommit)
+	assert.Greater(t, *signals.DaysSinceLastCommit, helper.AbandonedAfterDays)
+	assert.NotNil(t, signals.CheckedAt)
+	assert.Len(t, signals.Reasons, 3)
+
+	// Application scans flag the abandoned dependency in its finding
+	resp, err := appService.ScanApplicationDependencies(ctx, app.ID.String())
+	require.NoError(t, err)
+	result := resp.(model.ScanApplicationResult)
+	require.Len(t, result.Findings, 1)
+	assert.Equal(t, helper.MaintenanceRiskHigh, result.Findings[0].MaintenanceRisk)
+	assert.Contains(t, result.Findings[0].MaintenanceReasons, "repository is archived")
+
+	t.Run("CollaboratorsNotCountedByDefault", func(t *testing.T) {
+		repos := setupScanTestRepos(t)
+		_, dep := seedDependencyWithMetadata(t, repos)
+		appService := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, abandonedGitHubAPI{}, services.ServiceConfig{Scanner: scanner})
+		t.Cleanup(func() { _ = appService.Shutdown(context.Background()) })
+
+		_, err := appService.RefreshDependencyMetadata(ctx, dep.ID.String())
+		require.NoError(t, err)
+		signals, err := appService.GetDependencyMaintenance(ctx, dep.ID.String())
+		require.NoError(t, err)
+		require.NotNil(t, signals.Archived)
+		assert.Nil(t, signals.Collaborators)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		_, err := appService.GetDependencyMaintenance(ctx, "not-a-uuid")
+		assert.ErrorIs(t, err, services.ErrInvalidInput)
+		_, err = appService.GetDependencyMaintenance(ctx, uuid.NewString())
+		assert.ErrorIs(t, err, services.ErrNotFound)
+	})
+}
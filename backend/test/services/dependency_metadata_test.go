@@ -0,0 +1,310 @@
+package services_test
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/model"
+	"elang-backend/internal/model/dto"
+	"elang-backend/internal/services"
+	"elang-backend/internal/usecase"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// noDefaultBranchGitHubAPI cannot report default branches but still lists tags; commits are only
+// available on the branches in commitBranches
+type noDefaultBranchGitHubAPI struct {
+	offlineGitHubAPI
+	commitBranches map[string]string // branch -> head commit SHA
+}
+
+func (noDefaultBranchGitHubAPI) GetRepoInfo(owner, repo string) (map[string]interface{}, error) {
+	return map[string]interface{}{"full_name": owner + "/" + repo}, nil
+}
+
+func (noDefaultBranchGitHubAPI) ListTags(owner, repo string) ([]map[string]interface{}, error) {
+	return []map[string]interface{}{{"name": "v1.6.0", "commit_sha": "tag160"}}, nil
+}
+
+func (a noDefaultBranchGitHubAPI) GetListCommits(owner, repo, branch string, limit int) ([]map[string]interface{}, error) {
+	sha, ok := a.commitBranches[branch]
+	if !ok {
+		return nil, errors.New("branch not found")
+	}
+	return []map[string]interface{}{{"oid": sha, "author_date": "2024-05-01T10:00:00Z"}}, nil
+}
+
+func seedDependencyWithMetadata(t *testing.T, repos dto.BasicRepositories) (*entity.App, *entity.Dependency) {
+	t.Helper()
+	ctx := context.Background()
+	branch, sha, tag := "develop", "existing-sha", "v1.5.0"
+	app := &entity.App{ID: uuid.New(), Name: "billing", Status: "active"}
+	require.NoError(t, repos.AppRepository.Create(ctx, app))
+	dep := &entity.Dependency{ID: uuid.New(), Name: "github.com/google/uuid", Owner: "google", Repo: "uuid",
+		DefaultBranch: &branch, LastCommitSHA: &sha, LastTag: &tag}
+	require.NoError(t, repos.DepedencyRepository.Create(ctx, dep))
+	require.NoError(t, repos.AppToDepedencyRepository.Create(ctx, &entity.AppDependency{ID: uuid.New(), AppID: app.ID, DependencyID: dep.ID, UsedVersion: "v1.5.0"}))
+	return app, dep
+}
+
+func updateDependencyFromGitHub(t *testing.T, appService services.ApplicationInterface, appID, depID uuid.UUID) {
+	t.Helper()
+	resp, err := appService.UpdateApplicationDependency(context.Background(), appID.String(), &model.UpdateApplicationDependencyRequest{
+		Updates: []model.UpdateDependencyItem{{DependencyID: depID.String(), UsedVersion: "v1.6.0", RepositoryURL: "https://github.com/google/uuid"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{depID.String()}, resp.Updated)
+}
+
+func TestApplicationService_DependencyMetadata_KeepsExistingCommitWhenDefaultBranchFails(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+	app, dep := seedDependencyWithMetadata(t, repos)
+
+	appService := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, noDefaultBranchGitHubAPI{}, services.ServiceConfig{})
+	t.Cleanup(func() { _ = appService.Shutdown(context.Background()) })
+
+	updateDependencyFromGitHub(t, appService, app.ID, dep.ID)
+
+	stored, err := repos.DepedencyRepository.GetByID(ctx, dep.ID)
+	require.NoError(t, err)
+	require.NotNil(t, stored.LastTag)
+	assert.Equal(t, "v1.6.0", *stored.LastTag)
+	require.NotNil(t, stored.LastCommitSHA)
+	assert.Equal(t, "existing-sha", *stored.LastCommitSHA)
+	require.NotNil(t, stored.DefaultBranch)
+	assert.Equal(t, "develop", *stored.DefaultBranch)
+}
+
+func TestApplicationService_DependencyMetadata_FallsBackToCommonBranches(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+	app, dep := seedDependencyWithMetadata(t, repos)
+
+	github := noDefaultBranchGitHubAPI{commitBranches: map[string]string{"master": "master-head"}}
+	appService := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, github, services.ServiceConfig{})
+	t.Cleanup(func() { _ = appService.Shutdown(context.Background()) })
+
+	updateDependencyFromGitHub(t, appService, app.ID, dep.ID)
+
+	stored, err := repos.DepedencyRepository.GetByID(ctx, dep.ID)
+	require.NoError(t, err)
+	require.NotNil(t, stored.LastCommitSHA)
+	assert.Equal(t, "master-head", *stored.LastCommitSHA)
+	require.NotNil(t, stored.LastCommitAt)
+	// The fallback branch commits were found on is not stored as the repository's default branch
+	require.NotNil(t, stored.DefaultBranch)
+	assert.Equal(t, "develop", *stored.DefaultBranch)
+}
+
+// etagGitHubAPI answers conditional repository lookups like GitHub: the repository's ETag is "v1", and a lookup
+// still carrying it is answered as not modified. It counts the commit listings.
+type etagGitHubAPI struct {
+	noDefaultBranchGitHubAPI
+	commitListings *int
+}
+
+func (etagGitHubAPI) GetRepoInfoIfModified(owner, repo string, since usecase.GitHubValidators) (map[string]interface{}, usecase.GitHubValidators, error) {
+	if since.ETag == `"v1"` {
+		return nil, since, usecase.ErrGitHubNotModified
+	}
+	return map[string]interface{}{"full_name": owner + "/" + repo}, usecase.GitHubValidators{ETag: `"v1"`}, nil
+}
+
+func (a etagGitHubAPI) GetListCommits(owner, repo, branch string, limit int) ([]map[string]interface{}, error) {
+	*a.commitListings++
+	return a.noDefaultBranchGitHubAPI.GetListCommits(owner, repo, branch, limit)
+}
+
+func TestApplicationService_DependencyMetadata_ReusesStoredMetadataWhenNotModified(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+	app, dep := seedDependencyWithMetadata(t, repos)
+
+	commitListings := 0
+	github := etagGitHubAPI{noDefaultBranchGitHubAPI{commitBranches: map[string]string{"main": "main-head"}}, &commitListings}
+	appService := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, github, services.ServiceConfig{})
+	t.Cleanup(func() { _ = appService.Shutdown(context.Background()) })
+
+	updateDependencyFromGitHub(t, appService, app.ID, dep.ID)
+	stored, err := repos.DepedencyRepository.GetByID(ctx, dep.ID)
+	require.NoError(t, err)
+	require.NotNil(t, stored.GitHubETag)
+	assert.Equal(t, `"v1"`, *stored.GitHubETag)
+	listings := commitListings
+	require.Positive(t, listings)
+
+	// The repository is unchanged, so its commits are not listed again and the stored ones are kept
+	stored.LastCommitSHA = strPtr("stored-head")
+	require.NoError(t, repos.DepedencyRepository.Update(ctx, stored))
+	updateDependencyFromGitHub(t, appService, app.ID, dep.ID)
+	assert.Equal(t, listings, commitListings)
+	stored, err = repos.DepedencyRepository.GetByID(ctx, dep.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "stored-head", *stored.LastCommitSHA)
+
+	// The version the application uses is still resolved against the tags
+	appDeps, err := repos.AppToDepedencyRepository.GetByAppID(ctx, app.ID)
+	require.NoError(t, err)
+	require.Len(t, appDeps, 1)
+	require.NotNil(t, appDeps[0].UsedCommitSHA)
+	assert.Equal(t, "tag160", *appDeps[0].UsedCommitSHA)
+}
+
+func TestApplicationService_ListApplicationDependency_ReportsVersionResolution(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+
+	app := &entity.App{ID: uuid.New(), Name: "billing", Status: "active"}
+	require.NoError(t, repos.AppRepository.Create(ctx, app))
+	latest := "v2.0.0"
+	seed := func(name, usedVersion string, usedCommitSHA *string) {
+		dep := &entity.Dependency{ID: uuid.New(), Name: name, Owner: "acme", Repo: name, LastTag: &latest}
+		require.NoError(t, repos.DepedencyRepository.Create(ctx, dep))
+		require.NoError(t, repos.AppToDepedencyRepository.Create(ctx, &entity.AppDependency{
+			ID: uuid.New(), AppID: app.ID, DependencyID: dep.ID, UsedVersion: usedVersion, UsedCommitSHA: usedCommitSHA,
+		}))
+	}
+	seed("tagged", "v1.4.0", strPtr("abc123"))
+	seed("latest", "2.0.0", nil)
+	seed("unverified", "1.9.9-local", nil)
+
+	appService := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, offlineGitHubAPI{}, services.ServiceConfig{})
+	t.Cleanup(func() { _ = appService.Shutdown(context.Background()) })
+
+	listing, err := appService.ListApplicationDependency(ctx, app.ID.String())
+	require.NoError(t, err)
+	byName := map[string]model.ApplicationDependencyDetail{}
+	for _, dep := range listing.Dependencies {
+		byName[dep.Name] = dep
+	}
+	require.Len(t, byName, 3)
+
+	assert.True(t, byName["tagged"].VersionResolved)
+	assert.Equal(t, "v1.4.0", byName["tagged"].ResolvedTag)
+	assert.True(t, byName["latest"].VersionResolved)
+	assert.Equal(t, "v2.0.0", byName["latest"].ResolvedTag)
+	assert.False(t, byName["unverified"].VersionResolved)
+	assert.Empty(t, byName["unverified"].ResolvedTag)
+}
+
+// releasedGitHubAPI lists the tags v1.0.0 to v1.6.0, one release a month from January 2024
+type releasedGitHubAPI struct {
+	offlineGitHubAPI
+}
+
+func (releasedGitHubAPI) GetRepoInfo(owner, repo string) (map[string]interface{}, error) {
+	return map[string]interface{}{"full_name": owner + "/" + repo}, nil
+}
+
+func (releasedGitHubAPI) ListTags(owner, repo string) ([]map[string]interface{}, error) {
+	var tags []map[string]interface{}
+	for minor := 6; minor >= 0; minor-- {
+		tags = append(tags, map[string]interface{}{"name": fmt.Sprintf("v1.%d.0", minor), "commit_sha": fmt.Sprintf("sha-%d", minor)})
+	}
+	return tags, nil
+}
+
+func (releasedGitHubAPI) GetCommitsDetail(owner, repo, sha string) (*model.CommitDetail, error) {
+	var minor int
+	if _, err := fmt.Sscanf(sha, "sha-%d", &minor); err != nil {
+		return nil, err
+	}
+	date := time.Date(2024, time.Month(1+minor), 1, 0, 0, 0, 0, time.UTC)
+	return &model.CommitDetail{SHA: sha, Committer: model.CommitPerson{Date: date.Format(time.RFC3339)}}, nil
+}
+
+func TestApplicationService_DependencyMetadata_MeasuresStaleness(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+	app, dep := seedDependencyWithMetadata(t, repos)
+
+	appService := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, releasedGitHubAPI{}, services.ServiceConfig{})
+	t.Cleanup(func() { _ = appService.Shutdown(context.Background()) })
+
+	listing, err := appService.ListApplicationDependency(ctx, app.ID.String())
+	require.NoError(t, err)
+	require.Len(t, listing.Dependencies, 1)
+	assert.Nil(t, listing.Dependencies[0].ReleasesBehind, "staleness is unknown until metadata is fetched")
+	assert.False(t, listing.Dependencies[0].IsStale)
+	assert.False(t, listing.Dependencies[0].FirstSeenAt.IsZero())
+
+	resp, err := appService.UpdateApplicationDependency(ctx, app.ID.String(), &model.UpdateApplicationDependencyRequest{
+		Updates: []model.UpdateDependencyItem{{DependencyID: dep.ID.String(), UsedVersion: "v1.0.0", RepositoryURL: "https://github.com/google/uuid"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{dep.ID.String()}, resp.Updated)
+
+	listing, err = appService.ListApplicationDependency(ctx, app.ID.String())
+	require.NoError(t, err)
+	require.Len(t, listing.Dependencies, 1)
+	detail := listing.Dependencies[0]
+	require.NotNil(t, detail.ReleasesBehind)
+	assert.Equal(t, 6, *detail.ReleasesBehind)
+	require.NotNil(t, detail.StalenessDays)
+	assert.Equal(t, 182, *detail.StalenessDays) // 2024-01-01 to 2024-07-01
+	assert.True(t, detail.IsStale)
+	assert.NotNil(t, detail.StalenessCheckedAt)
+}
+
+func TestApplicationService_RefreshDependencyMetadata(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+	app, dep := seedDependencyWithMetadata(t, repos)
+
+	github := noDefaultBranchGitHubAPI{commitBranches: map[string]string{"main": "new-head"}}
+	appService := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, github, services.ServiceConfig{})
+	t.Cleanup(func() { _ = appService.Shutdown(context.Background()) })
+
+	refreshed, err := appService.RefreshDependencyMetadata(ctx, dep.ID.String())
+	require.NoError(t, err)
+	assert.True(t, refreshed.CommitChanged)
+	require.NotNil(t, refreshed.LastCommitSHA)
+	assert.Equal(t, "new-head", *refreshed.LastCommitSHA)
+	require.NotNil(t, refreshed.LastTag)
+	assert.Equal(t, "v1.6.0", *refreshed.LastTag)
+
+	// An unchanged latest commit records no new version
+	refreshed, err = appService.RefreshDependencyMetadata(ctx, dep.ID.String())
+	require.NoError(t, err)
+	assert.False(t, refreshed.CommitChanged)
+	versions, err := repos.DepedencyVersionRepository.GetByDependencyID(ctx, dep.ID)
+	require.NoError(t, err)
+	require.Len(t, versions, 1)
+	assert.Equal(t, "new-head", versions[0].CommitSHA)
+
+	// The application's link to the dependency is untouched
+	appDeps, err := repos.AppToDepedencyRepository.GetByAppID(ctx, app.ID)
+	require.NoError(t, err)
+	require.Len(t, appDeps, 1)
+	assert.Equal(t, "v1.5.0", appDeps[0].UsedVersion)
+}
+
+func TestApplicationService_RefreshDependencyMetadata_Errors(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+	_, dep := seedDependencyWithMetadata(t, repos)
+
+	appService := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, offlineGitHubAPI{}, services.ServiceConfig{})
+	t.Cleanup(func() { _ = appService.Shutdown(context.Background()) })
+
+	_, err := appService.RefreshDependencyMetadata(ctx, "not-a-uuid")
+	assert.ErrorIs(t, err, services.ErrInvalidInput)
+
+	_, err = appService.RefreshDependencyMetadata(ctx, uuid.NewString())
+	assert.ErrorIs(t, err, services.ErrNotFound)
+
+	// GitHub being unreachable is an error rather than a refresh that changed nothing
+	_, err = appService.RefreshDependencyMetadata(ctx, dep.ID.String())
+	require.Error(t, err)
+	stored, err := repos.DepedencyRepository.GetByID(ctx, dep.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "existing-sha", *stored.LastCommitSHA)
+}
@@ -0,0 +1,53 @@
+package services_test
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/services"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplicationService_GetDependencyTree(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+	seedRuntimeAndFramework(t, ctx, repos.RunTimeRepository, repos.FrameWorkRepository)
+
+	appService := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, offlineGitHubAPI{}, services.ServiceConfig{DependencyProcessingWorkers: 1})
+	t.Cleanup(func() { _ = appService.Shutdown(context.Background()) })
+
+	goMod := "module example.com/demo\n\ngo 1.21\n\nrequire (\n\tgithub.com/gin-gonic/gin v1.9.1\n\tgolang.org/x/net v0.17.0 // indirect\n)\n"
+	resp, err := appService.AddApplication(helper.WithGoIndirectDependencies(ctx, true), "tree-app", "Go", "Gin", "", "go.mod", goMod, nil)
+	require.NoError(t, err)
+	appID := uuid.MustParse(resp.AppID)
+	require.Eventually(t, func() bool {
+		links, err := repos.AppToDepedencyRepository.GetByAppID(ctx, appID)
+		return err == nil && len(links) == 2
+	}, 5*time.Second, 20*time.Millisecond)
+
+	app, err := repos.AppRepository.GetByID(ctx, appID)
+	require.NoError(t, err)
+	seedFindingsScan(t, repos, app, time.Now().UTC(),
+		entity.Finding{Dependency: "golang.org/x/net", Version: "v0.17.0", VulnerabilityID: "GO-2024-2687", Severity: "medium", RiskScore: 5.3},
+		entity.Finding{Dependency: "golang.org/x/net", Version: "v0.17.0", VulnerabilityID: "GO-2023-2102", Severity: "high", RiskScore: 7.5},
+	)
+
+	tree, err := appService.GetDependencyTree(ctx, resp.AppID)
+	require.NoError(t, err)
+	require.Len(t, tree.Direct, 1)
+	assert.Equal(t, "github.com/gin-gonic/gin", tree.Direct[0].Name)
+	assert.Zero(t, tree.Direct[0].Vulnerabilities)
+	require.Len(t, tree.Transitive, 1)
+	assert.Equal(t, "golang.org/x/net", tree.Transitive[0].Name)
+	assert.Equal(t, "v0.17.0", tree.Transitive[0].Version)
+	assert.Equal(t, 2, tree.Transitive[0].Vulnerabilities)
+	assert.Equal(t, "high", tree.Transitive[0].HighestSeverity)
+
+	_, err = appService.GetDependencyTree(ctx, uuid.NewString())
+	assert.ErrorIs(t, err, services.ErrNotFound)
+}
@@ -0,0 +1,75 @@
+package services_test
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/model"
+	"elang-backend/internal/services"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplicationService_UpdateApplicationDependency_OptimisticConcurrency(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+
+	app := &entity.App{ID: uuid.New(), Name: "billing", Status: "active"}
+	require.NoError(t, repos.AppRepository.Create(ctx, app))
+	dep := &entity.Dependency{ID: uuid.New(), Name: "github.com/google/uuid", Owner: "google", Repo: "uuid"}
+	require.NoError(t, repos.DepedencyRepository.Create(ctx, dep))
+	require.NoError(t, repos.AppToDepedencyRepository.Create(ctx, &entity.AppDependency{ID: uuid.New(), AppID: app.ID, DependencyID: dep.ID, UsedVersion: "v1.5.0"}))
+
+	appService := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, offlineGitHubAPI{}, services.ServiceConfig{})
+	t.Cleanup(func() { _ = appService.Shutdown(context.Background()) })
+
+	// Two users load the dependency listing at the same time
+	listing, err := appService.ListApplicationDependency(ctx, app.ID.String())
+	require.NoError(t, err)
+	require.Len(t, listing.Dependencies, 1)
+	loadedAt := listing.Dependencies[0].UpdatedAt
+	require.False(t, loadedAt.IsZero())
+
+	update := func(version string, updatedAt *time.Time) *model.UpdateApplicationDependencyResponse {
+		resp, err := appService.UpdateApplicationDependency(ctx, app.ID.String(), &model.UpdateApplicationDependencyRequest{
+			Updates: []model.UpdateDependencyItem{{DependencyID: dep.ID.String(), UsedVersion: version, UpdatedAt: updatedAt}},
+		})
+		require.NoError(t, err)
+		return resp
+	}
+
+	time.Sleep(time.Millisecond)
+	first := update("v1.6.0", &loadedAt)
+	assert.Equal(t, []string{dep.ID.String()}, first.Updated)
+	assert.Empty(t, first.Conflicts)
+
+	// The second user's edit is based on the same, now outdated, listing
+	second := update("v1.4.0", &loadedAt)
+	assert.Empty(t, second.Updated)
+	require.Len(t, second.Failed, 1)
+	assert.Equal(t, dep.ID.String(), second.Failed[0].DependencyID)
+	assert.Equal(t, model.UpdateFailureConflict, second.Failed[0].Reason)
+	require.Len(t, second.Conflicts, 1)
+	assert.Equal(t, 409, second.Conflicts[0].Status)
+	assert.Equal(t, dep.ID.String(), second.Conflicts[0].DependencyID)
+	assert.NotEmpty(t, second.Conflicts[0].Reason)
+
+	stored, err := repos.AppToDepedencyRepository.GetByAppAndDependencyID(ctx, app.ID, dep.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "v1.6.0", stored.UsedVersion)
+
+	// Reloading the listing gives a fresh token that is accepted
+	listing, err = appService.ListApplicationDependency(ctx, app.ID.String())
+	require.NoError(t, err)
+	refreshed := listing.Dependencies[0].UpdatedAt
+	third := update("v1.4.0", &refreshed)
+	assert.Equal(t, []string{dep.ID.String()}, third.Updated)
+
+	// Clients that send no token keep the previous last-write-wins behaviour
+	fourth := update("v1.6.0", nil)
+	assert.Equal(t, []string{dep.ID.String()}, fourth.Updated)
+}
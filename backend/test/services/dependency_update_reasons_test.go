@@ -0,0 +1,85 @@
+package services_test
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/model"
+	"elang-backend/internal/repository"
+	"elang-backend/internal/services"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingUpdateAppDependencyRepository cannot write app-dependency links
+type failingUpdateAppDependencyRepository struct {
+	repository.AppDependencyRepository
+}
+
+func (failingUpdateAppDependencyRepository) UpdateIfUnchanged(ctx context.Context, appDep *entity.AppDependency, expectedUpdatedAt time.Time) (bool, error) {
+	return false, errors.New("database is read-only")
+}
+
+func TestApplicationService_UpdateApplicationDependency_ReportsFailureReasons(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+
+	app := &entity.App{ID: uuid.New(), Name: "billing", Status: "active"}
+	require.NoError(t, repos.AppRepository.Create(ctx, app))
+	dep := &entity.Dependency{ID: uuid.New(), Name: "github.com/google/uuid", Owner: "google", Repo: "uuid"}
+	require.NoError(t, repos.DepedencyRepository.Create(ctx, dep))
+	require.NoError(t, repos.AppToDepedencyRepository.Create(ctx, &entity.AppDependency{ID: uuid.New(), AppID: app.ID, DependencyID: dep.ID, UsedVersion: "v1.5.0"}))
+
+	update := func(t *testing.T, appService services.ApplicationInterface, item model.UpdateDependencyItem) model.DependencyUpdateFailure {
+		t.Helper()
+		resp, err := appService.UpdateApplicationDependency(ctx, app.ID.String(), &model.UpdateApplicationDependencyRequest{
+			Updates: []model.UpdateDependencyItem{item},
+		})
+		require.NoError(t, err)
+		assert.Empty(t, resp.Updated)
+		require.Len(t, resp.Failed, 1)
+		assert.Equal(t, item.DependencyID, resp.Failed[0].DependencyID)
+		assert.NotEmpty(t, resp.Failed[0].Error)
+		return resp.Failed[0]
+	}
+
+	appService := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, offlineGitHubAPI{}, services.ServiceConfig{})
+	t.Cleanup(func() { _ = appService.Shutdown(context.Background()) })
+
+	tests := []struct {
+		name   string
+		item   model.UpdateDependencyItem
+		reason string
+	}{
+		{"invalid id", model.UpdateDependencyItem{DependencyID: "not-a-uuid", UsedVersion: "v1.6.0"}, model.UpdateFailureInvalidID},
+		{"empty version", model.UpdateDependencyItem{DependencyID: dep.ID.String()}, model.UpdateFailureEmptyVersion},
+		{"not found", model.UpdateDependencyItem{DependencyID: uuid.NewString(), UsedVersion: "v1.6.0"}, model.UpdateFailureNotFound},
+		{"untrusted repository url", model.UpdateDependencyItem{DependencyID: dep.ID.String(), UsedVersion: "v1.6.0", RepositoryURL: metadataEndpointURL}, model.UpdateFailureInvalidRepositoryURL},
+		{"github unreachable", model.UpdateDependencyItem{DependencyID: dep.ID.String(), UsedVersion: "v1.6.0", RepositoryURL: "https://github.com/google/uuid"}, model.UpdateFailureGitHubFetchFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			failure := update(t, appService, tt.item)
+			assert.Equal(t, tt.reason, failure.Reason)
+		})
+	}
+
+	t.Run("db error", func(t *testing.T) {
+		failingRepos := repos
+		failingRepos.AppToDepedencyRepository = failingUpdateAppDependencyRepository{repos.AppToDepedencyRepository}
+		failingService := services.NewApplicationService(failingRepos, *helper.NewDependencyParser(), nil, offlineGitHubAPI{}, services.ServiceConfig{})
+		t.Cleanup(func() { _ = failingService.Shutdown(context.Background()) })
+
+		failure := update(t, failingService, model.UpdateDependencyItem{DependencyID: dep.ID.String(), UsedVersion: "v1.6.0"})
+		assert.Equal(t, model.UpdateFailureDBError, failure.Reason)
+	})
+
+	stored, err := repos.AppToDepedencyRepository.GetByAppAndDependencyID(ctx, app.ID, dep.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "v1.5.0", stored.UsedVersion, "failed updates leave the dependency unchanged")
+}
@@ -0,0 +1,66 @@
+package services_test
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/model"
+	"elang-backend/internal/services"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var emptyManifests = []struct {
+	name     string
+	runtime  string
+	fileName string
+	content  string
+}{
+	{"empty package.json", "node", "package.json", "{}"},
+	{"comments-only requirements.txt", "python", "requirements.txt", "# pinned by CI\n# nothing here yet\n"},
+}
+
+func TestDependenciesService_ScanDependencies_EmptyManifest(t *testing.T) {
+	for _, tt := range emptyManifests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repos := setupScanTestRepos(t)
+			depService := services.NewDependenciesService(repos, *helper.NewDependencyParser(), nil, services.ServiceConfig{})
+			t.Cleanup(func() { _ = depService.Shutdown(context.Background()) })
+
+			resp, err := depService.ScanDependencies(ctx, "empty-app", tt.runtime, "1.0.0", "", tt.fileName, tt.content)
+			require.NoError(t, err)
+
+			result, ok := resp.(model.ScanApplicationResult)
+			require.True(t, ok)
+			assert.Equal(t, "completed", result.ScanStatus)
+			assert.Equal(t, 0, result.DependencyCount)
+			assert.Equal(t, []string{services.NoDependenciesWarning}, result.Warnings)
+			assert.Empty(t, result.Findings)
+		})
+	}
+}
+
+func TestApplicationService_AddApplication_EmptyManifest(t *testing.T) {
+	for _, tt := range emptyManifests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repos := setupScanTestRepos(t)
+			require.NoError(t, repos.RunTimeRepository.Create(ctx, &entity.Runtime{Name: tt.runtime}))
+			require.NoError(t, repos.FrameWorkRepository.Create(ctx, &entity.Framework{Name: "Default"}))
+			appService := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, offlineGitHubAPI{}, services.ServiceConfig{})
+			t.Cleanup(func() { _ = appService.Shutdown(context.Background()) })
+
+			resp, err := appService.AddApplication(ctx, "empty-app", tt.runtime, "Default", "", tt.fileName, tt.content, nil)
+			require.NoError(t, err)
+			assert.Equal(t, 0, resp.DependencyCount)
+			assert.Equal(t, []string{services.NoDependenciesWarning}, resp.Warnings)
+
+			app, err := repos.AppRepository.GetByName(ctx, "empty-app")
+			require.NoError(t, err)
+			assert.NotNil(t, app)
+		})
+	}
+}
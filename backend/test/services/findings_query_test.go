@@ -0,0 +1,252 @@
+package services_test
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/model"
+	"elang-backend/internal/model/dto"
+	"elang-backend/internal/services"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seedFindingsScan stores a completed scan of app made at scannedAt with the given findings
+func seedFindingsScan(t *testing.T, repos dto.BasicRepositories, app *entity.App, scannedAt time.Time, findings ...entity.Finding) uuid.UUID {
+	t.Helper()
+	for i := range findings {
+		findings[i].ID = uuid.New()
+		findings[i].AppID = app.ID
+		findings[i].ScannedAt = scannedAt
+	}
+	scan := &entity.ScanResult{
+		ID:          uuid.New(),
+		AppID:       &app.ID,
+		AppName:     app.Name,
+		ScanType:    "application",
+		Status:      "completed",
+		CompletedAt: &scannedAt,
+		CreatedAt:   scannedAt,
+		Findings:    findings,
+	}
+	require.NoError(t, repos.ScanResultRepository.Create(context.Background(), scan))
+	return scan.ID
+}
+
+func TestDependenciesService_ListFindings(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+
+	app := &entity.App{ID: uuid.New(), Name: "payments", Status: "active"}
+	require.NoError(t, repos.AppRepository.Create(ctx, app))
+	other := &entity.App{ID: uuid.New(), Name: "web", Status: "active"}
+	require.NoError(t, repos.AppRepository.Create(ctx, other))
+
+	lastMonth := time.Date(2026, 9, 1, 12, 0, 0, 0, time.UTC)
+	lastWeek := time.Date(2026, 10, 9, 12, 0, 0, 0, time.UTC)
+	oldScan := seedFindingsScan(t, repos, app, lastMonth,
+		entity.Finding{Dependency: "lodash", Version: "4.17.15", VulnerabilityID: "GHSA-1", Severity: "critical", RiskScore: 9.8},
+		entity.Finding{Dependency: "axios", Version: "0.21.0", VulnerabilityID: "GHSA-2", Severity: "medium", RiskScore: 5.3},
+	)
+	newScan := seedFindingsScan(t, repos, app, lastWeek,
+		entity.Finding{Dependency: "lodash", Version: "4.17.20", VulnerabilityID: "GHSA-1", Severity: "critical", RiskScore: 9.8, RecommendedVersion: "4.17.21"},
+		entity.Finding{Dependency: "axios", Version: "0.21.0", VulnerabilityID: "GHSA-2", Severity: "medium", RiskScore: 5.3},
+		entity.Finding{Dependency: "axios", Version: "0.21.0", VulnerabilityID: "GHSA-3", Severity: "high", RiskScore: 7.5},
+	)
+	seedFindingsScan(t, repos, other, lastWeek,
+		entity.Finding{Dependency: "lodash", Version: "4.17.20", VulnerabilityID: "GHSA-1", Severity: "critical", RiskScore: 9.8},
+	)
+
+	svc := services.NewDependenciesService(repos, *helper.NewDependencyParser(), nil, services.ServiceConfig{})
+	list := func(t *testing.T, query model.FindingsQuery) *model.FindingsResponse {
+		t.Helper()
+		resp, err := svc.ListFindings(ctx, app.ID.String(), query)
+		require.NoError(t, err)
+		return resp
+	}
+
+	t.Run("All", func(t *testing.T) {
+		resp := list(t, model.FindingsQuery{})
+		assert.Equal(t, app.ID.String(), resp.AppID)
+		assert.EqualValues(t, 5, resp.Total)
+		assert.Equal(t, services.DefaultFindingsPageSize, resp.Limit)
+		require.Len(t, resp.Findings, 5)
+		// Newest scan first
+		for _, finding := range resp.Findings[:3] {
+			assert.Equal(t, newScan.String(), finding.ScanID)
+		}
+		assert.Equal(t, oldScan.String(), resp.Findings[4].ScanID)
+		assert.Equal(t, "4.17.21", resp.Findings[2].RecommendedVersion)
+	})
+
+	t.Run("Severity", func(t *testing.T) {
+		resp := list(t, model.FindingsQuery{Severity: "Critical, high"})
+		assert.EqualValues(t, 3, resp.Total)
+		for _, finding := range resp.Findings {
+			assert.Contains(t, []string{"critical", "high"}, finding.Severity)
+		}
+	})
+
+	t.Run("Since", func(t *testing.T) {
+		resp := list(t, model.FindingsQuery{Since: "2026-10-01"})
+		assert.EqualValues(t, 3, resp.Total)
+		resp = list(t, model.FindingsQuery{Since: lastWeek.Add(time.Second).Format(time.RFC3339)})
+		assert.EqualValues(t, 0, resp.Total)
+		assert.NotNil(t, resp.Findings)
+	})
+
+	t.Run("DependencyAndVulnerability", func(t *testing.T) {
+		resp := list(t, model.FindingsQuery{Dependency: "LODA"})
+		assert.EqualValues(t, 2, resp.Total)
+		resp = list(t, model.FindingsQuery{Vulnerability: "ghsa-2"})
+		require.EqualValues(t, 2, resp.Total)
+		assert.Equal(t, []string{newScan.String(), oldScan.String()}, []string{resp.Findings[0].ScanID, resp.Findings[1].ScanID})
+	})
+
+	t.Run("Pagination", func(t *testing.T) {
+		resp := list(t, model.FindingsQuery{Limit: 2, Offset: 4})
+		assert.EqualValues(t, 5, resp.Total)
+		assert.Len(t, resp.Findings, 1)
+	})
+
+	t.Run("InvalidQuery", func(t *testing.T) {
+		for _, query := range []model.FindingsQuery{
+			{Severity: "urgent"},
+			{Since: "last week"},
+			{Limit: services.MaxFindingsPageSize + 1},
+			{Offset: -1},
+		} {
+			_, err := svc.ListFindings(ctx, app.ID.String(), query)
+			assert.ErrorIs(t, err, services.ErrInvalidInput, "%+v", query)
+		}
+		_, err := svc.ListFindings(ctx, "not-a-uuid", model.FindingsQuery{})
+		assert.ErrorIs(t, err, services.ErrInvalidInput)
+	})
+
+	t.Run("UnknownApp", func(t *testing.T) {
+		_, err := svc.ListFindings(ctx, uuid.NewString(), model.FindingsQuery{})
+		assert.ErrorIs(t, err, services.ErrNotFound)
+	})
+}
+
+func TestApplicationService_StartApplicationScan_StoresFindings(t *testing.T) {
+	ctx := context.Background()
+	scanner := helper.ScannerConfig{Sources: []helper.VulnerabilitySource{&versionedSource{vulns: map[string][]helper.VulnerabilityInfo{
+		"github.com/gin-gonic/gin@1.9.1": {{ID: "GO-2023-2001", Severity: helper.SeverityHigh, Score: 7.5, Source: "osv"}},
+	}}}}
+
+	repos := setupScanTestRepos(t)
+	runtime := &entity.Runtime{Name: "Go"}
+	require.NoError(t, repos.RunTimeRepository.Create(ctx, runtime))
+	app := &entity.App{ID: uuid.New(), Name: "findings-app", RuntimeID: &runtime.ID, Status: "active"}
+	require.NoError(t, repos.AppRepository.Create(ctx, app))
+	dep := &entity.Dependency{ID: uuid.New(), Name: "github.com/gin-gonic/gin", Owner: "gin-gonic", Repo: "gin"}
+	require.NoError(t, repos.DepedencyRepository.Create(ctx, dep))
+	require.NoError(t, repos.AppToDepedencyRepository.Create(ctx, &entity.AppDependency{ID: uuid.New(), AppID: app.ID, DependencyID: dep.ID, UsedVersion: "v1.9.1"}))
+
+	parser := helper.NewDependencyParser()
+	appService := services.NewApplicationService(repos, *parser, nil, nil, services.ServiceConfig{Scanner: scanner})
+	t.Cleanup(func() { _ = appService.Shutdown(context.Background()) })
+	depService := services.NewDependenciesService(repos, *parser, nil, services.ServiceConfig{Scanner: scanner})
+
+	job, err := appService.StartApplicationScan(ctx, app.ID.String())
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		status, err := depService.GetScanStatus(ctx, job.ScanID)
+		return err == nil && status.Status == "completed"
+	}, 5*time.Second, 20*time.Millisecond)
+
+	resp, err := depService.ListFindings(ctx, app.ID.String(), model.FindingsQuery{Severity: "high"})
+	require.NoError(t, err)
+	require.Len(t, resp.Findings, 1)
+	finding := resp.Findings[0]
+	assert.Equal(t, job.ScanID, finding.ScanID)
+	assert.Equal(t, "github.com/gin-gonic/gin", finding.Dependency)
+	assert.Equal(t, "GO-2023-2001", finding.VulnerabilityID)
+	assert.Equal(t, "high", finding.Severity)
+	assert.False(t, finding.ScannedAt.IsZero())
+}
+
+func TestDependenciesService_StreamLatestFindings(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+	app := &entity.App{ID: uuid.New(), Name: "export-app", Status: "active"}
+	require.NoError(t, repos.AppRepository.Create(ctx, app))
+	svc := services.NewDependenciesService(repos, *helper.NewDependencyParser(), nil, services.ServiceConfig{})
+
+	collect := func(t *testing.T) ([]model.ScanFinding, error) {
+		var findings []model.ScanFinding
+		err := svc.StreamLatestFindings(ctx, app.ID.String(), func(finding model.ScanFinding) error {
+			findings = append(findings, finding)
+			return nil
+		})
+		return findings, err
+	}
+
+	t.Run("NoCompletedScan", func(t *testing.T) {
+		_, err := collect(t)
+		assert.ErrorIs(t, err, services.ErrNotFound)
+	})
+
+	now := time.Now().UTC()
+	seedFindingsScan(t, repos, app, now.Add(-time.Hour),
+		entity.Finding{Dependency: "lodash", Version: "4.17.15", VulnerabilityID: "GHSA-p6mc-m468-83gw", Severity: "high"})
+	seedFindingsScan(t, repos, app, now,
+		entity.Finding{Dependency: "lodash", Version: "4.17.20", VulnerabilityID: "GHSA-29mw-wpgm-hmr9", Severity: "medium",
+			Recommendation: "Upgrade to 4.17.21", RecommendedVersion: "4.17.21"},
+		entity.Finding{Dependency: "lodash", Version: "4.17.20", VulnerabilityID: "GHSA-35jh-r3h4-6jhm", Severity: "medium",
+			Recommendation: "Upgrade to 4.17.21", RecommendedVersion: "4.17.21"},
+		entity.Finding{Dependency: "express", Version: "4.19.2", VulnerabilityID: "GHSA-qw6h-vgh9-j6wx", Severity: "low"},
+	)
+
+	t.Run("LatestScan", func(t *testing.T) {
+		findings, err := collect(t)
+		require.NoError(t, err)
+		assert.Equal(t, []model.ScanFinding{
+			{Dependency: "express", Version: "4.19.2", Severity: "low", VulnerabilityIDs: []string{"GHSA-qw6h-vgh9-j6wx"}},
+			{Dependency: "lodash", Version: "4.17.20", Severity: "medium", VulnerabilityIDs: []string{"GHSA-29mw-wpgm-hmr9", "GHSA-35jh-r3h4-6jhm"},
+				Recommendation: "Upgrade to 4.17.21", RecommendedVersion: "4.17.21"},
+		}, findings, "only the latest scan is exported, one row per dependency version")
+	})
+
+	t.Run("AcrossPages", func(t *testing.T) {
+		paged := &entity.App{ID: uuid.New(), Name: "paged-app", Status: "active"}
+		require.NoError(t, repos.AppRepository.Create(ctx, paged))
+		var records []entity.Finding
+		for i := range 600 {
+			records = append(records, entity.Finding{Dependency: "big", Version: "1.0.0", VulnerabilityID: fmt.Sprintf("GHSA-%04d", i), Severity: "high"})
+		}
+		records = append(records, entity.Finding{Dependency: "small", Version: "2.0.0", VulnerabilityID: "GHSA-9999", Severity: "low"})
+		seedFindingsScan(t, repos, paged, now, records...)
+
+		var findings []model.ScanFinding
+		require.NoError(t, svc.StreamLatestFindings(ctx, paged.ID.String(), func(finding model.ScanFinding) error {
+			findings = append(findings, finding)
+			return nil
+		}))
+		require.Len(t, findings, 2)
+		assert.Len(t, findings[0].VulnerabilityIDs, 600, "a dependency spanning two pages stays one row")
+		assert.Equal(t, "small", findings[1].Dependency)
+	})
+
+	t.Run("StopsOnWriteError", func(t *testing.T) {
+		writes := 0
+		err := svc.StreamLatestFindings(ctx, app.ID.String(), func(model.ScanFinding) error {
+			writes++
+			return errors.New("client went away")
+		})
+		assert.EqualError(t, err, "client went away")
+		assert.Equal(t, 1, writes)
+	})
+
+	t.Run("UnknownApplication", func(t *testing.T) {
+		err := svc.StreamLatestFindings(ctx, uuid.NewString(), func(model.ScanFinding) error { return nil })
+		assert.ErrorIs(t, err, services.ErrNotFound)
+	})
+}
@@ -0,0 +1,74 @@
+package services_test
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/model"
+	"elang-backend/internal/services"
+	"elang-backend/internal/usecase"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rateLimitedGitHubAPI refuses every lookup like GitHub does once the anonymous rate limit is exhausted
+type rateLimitedGitHubAPI struct {
+	offlineGitHubAPI
+}
+
+func (rateLimitedGitHubAPI) GetRepoInfo(owner, repo string) (map[string]interface{}, error) {
+	return nil, usecase.ErrGitHubAnonymousRateLimit
+}
+
+func TestApplicationService_AnonymousRateLimit(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+
+	app := &entity.App{ID: uuid.New(), Name: "billing", Status: "active"}
+	require.NoError(t, repos.AppRepository.Create(ctx, app))
+
+	appService := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, rateLimitedGitHubAPI{}, services.ServiceConfig{})
+	t.Cleanup(func() { _ = appService.Shutdown(context.Background()) })
+
+	t.Run("Add", func(t *testing.T) {
+		result, err := appService.AddApplicationDependency(ctx, app.ID.String(), []model.DependencyInfoRequest{
+			{Name: "github.com/google/uuid", Owner: "google", Repo: "uuid", Version: "v1.6.0", IsGitHubRepo: true},
+			{Name: "github.com/gin-gonic/gin", Owner: "gin-gonic", Repo: "gin", Version: "v1.9.1", IsGitHubRepo: true},
+		})
+		require.NoError(t, err)
+
+		response := result.(map[string]interface{})
+		summary := response["summary"].(map[string]interface{})
+		assert.Equal(t, 2, summary["successful"])
+		warnings, ok := response["warnings"].([]string)
+		require.True(t, ok)
+		require.Len(t, warnings, 1, "one warning covers every skipped dependency")
+		assert.Contains(t, warnings[0], "2 dependencies")
+
+		dep, err := repos.DepedencyRepository.GetByOwnerRepo(ctx, "google", "uuid")
+		require.NoError(t, err)
+		require.NotNil(t, dep.RepositoryURL)
+		assert.Equal(t, "https://github.com/google/uuid", *dep.RepositoryURL, "the repository is kept for a later refresh")
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		dep, err := repos.DepedencyRepository.GetByOwnerRepo(ctx, "google", "uuid")
+		require.NoError(t, err)
+
+		resp, err := appService.UpdateApplicationDependency(ctx, app.ID.String(), &model.UpdateApplicationDependencyRequest{
+			Updates: []model.UpdateDependencyItem{{DependencyID: dep.ID.String(), UsedVersion: "v1.6.1", RepositoryURL: "https://github.com/google/uuid"}},
+		})
+		require.NoError(t, err)
+		assert.Empty(t, resp.Failed)
+		assert.Equal(t, []string{dep.ID.String()}, resp.Updated)
+		require.Len(t, resp.Warnings, 1)
+		assert.Contains(t, resp.Warnings[0], "GitHub token not configured")
+
+		stored, err := repos.AppToDepedencyRepository.GetByAppAndDependencyID(ctx, app.ID, dep.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "v1.6.1", stored.UsedVersion)
+	})
+}
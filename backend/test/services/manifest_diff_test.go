@@ -0,0 +1,93 @@
+package services_test
+
+import (
+	"context"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/helper/parser"
+	"elang-backend/internal/model/dto"
+	"elang-backend/internal/services"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// versionedSource answers from a fixed table keyed by "module@version" and records what was queried
+type versionedSource struct {
+	vulns   map[string][]helper.VulnerabilityInfo
+	mu      sync.Mutex
+	queried []string
+}
+
+func (s *versionedSource) Name() string { return "osv" }
+
+func (s *versionedSource) QueryVulnerabilities(ctx context.Context, dep parser.DependencyInfo) ([]helper.VulnerabilityInfo, error) {
+	key := dep.Name + "@" + strings.TrimPrefix(dep.Version, "v")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.queried = append(s.queried, key)
+	return s.vulns[key], nil
+}
+
+func TestDependenciesService_DiffManifests(t *testing.T) {
+	source := &versionedSource{vulns: map[string][]helper.VulnerabilityInfo{
+		"github.com/gin-gonic/gin@1.9.0": {
+			{ID: "GHSA-old", Severity: helper.SeverityHigh},
+			{ID: "GHSA-shared", Aliases: []string{"CVE-2023-0001"}, Severity: helper.SeverityLow},
+		},
+		"github.com/gin-gonic/gin@1.9.1": {
+			{ID: "GO-2023-0001", Aliases: []string{"GHSA-shared"}, CVE: "CVE-2023-0001", Severity: helper.SeverityLow},
+			{ID: "GHSA-new", Severity: helper.SeverityMedium, Summary: "Header injection"},
+		},
+		"golang.org/x/net@0.1.0": {
+			{ID: "GO-2023-1571", Severity: helper.SeverityCritical},
+		},
+	}}
+	scanner := helper.ScannerConfig{Sources: []helper.VulnerabilitySource{source}}
+
+	svc := services.NewDependenciesService(dto.BasicRepositories{}, *helper.NewDependencyParser(), nil, services.ServiceConfig{Scanner: scanner})
+	t.Cleanup(func() { _ = svc.Shutdown(context.Background()) })
+
+	oldMod := "module example.com/demo\n\ngo 1.21\n\nrequire (\n\tgithub.com/google/uuid v1.5.0\n\tgithub.com/gin-gonic/gin v1.9.0\n\tgithub.com/sirupsen/logrus v1.9.0\n)\n"
+	newMod := "module example.com/demo\n\ngo 1.21\n\nrequire (\n\tgithub.com/google/uuid v1.5.0\n\tgithub.com/gin-gonic/gin v1.9.1\n\tgolang.org/x/net v0.1.0\n)\n"
+
+	result, err := svc.DiffManifests(context.Background(), "go", "go.mod", oldMod, "go.mod", newMod)
+	require.NoError(t, err)
+
+	require.Len(t, result.Added, 1)
+	assert.Equal(t, "golang.org/x/net", result.Added[0].Name)
+	require.Len(t, result.Removed, 1)
+	assert.Equal(t, "github.com/sirupsen/logrus", result.Removed[0].Name)
+	require.Len(t, result.Changed, 1)
+	assert.Equal(t, "github.com/gin-gonic/gin", result.Changed[0].Name)
+	assert.Equal(t, "v1.9.0", result.Changed[0].OldVersion)
+	assert.Equal(t, "v1.9.1", result.Changed[0].NewVersion)
+	assert.Equal(t, 1, result.Unchanged)
+
+	newIDs := []string{}
+	for _, vuln := range result.NewVulnerabilities {
+		newIDs = append(newIDs, vuln.ID)
+	}
+	assert.ElementsMatch(t, []string{"GHSA-new", "GO-2023-1571"}, newIDs, "a vulnerability the old version already had under an alias is not new")
+	require.Len(t, result.ResolvedVulnerabilities, 1)
+	assert.Equal(t, "GHSA-old", result.ResolvedVulnerabilities[0].ID)
+	assert.True(t, result.IntroducesVulnerabilities)
+	assert.Equal(t, "critical", result.HighestNewSeverity)
+
+	assert.ElementsMatch(t, []string{"github.com/gin-gonic/gin@1.9.0", "github.com/gin-gonic/gin@1.9.1", "golang.org/x/net@0.1.0"}, source.queried,
+		"unchanged and removed dependencies are not scanned")
+}
+
+func TestDependenciesService_DiffManifests_InvalidInput(t *testing.T) {
+	svc := services.NewDependenciesService(dto.BasicRepositories{}, *helper.NewDependencyParser(), nil, services.ServiceConfig{})
+	t.Cleanup(func() { _ = svc.Shutdown(context.Background()) })
+	ctx := context.Background()
+
+	_, err := svc.DiffManifests(ctx, "go", "go.mod", "", "go.mod", "module example.com/demo\n")
+	assert.ErrorIs(t, err, services.ErrInvalidInput)
+
+	_, err = svc.DiffManifests(ctx, "cobol", "go.mod", "module a\n", "go.mod", "module b\n")
+	assert.ErrorIs(t, err, services.ErrInvalidInput)
+}
@@ -0,0 +1,52 @@
+package services_test
+
+import (
+	"context"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/model"
+	"elang-backend/internal/model/dto"
+	"elang-backend/internal/services"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDependenciesService_ScanMultipleDependencies_ReportsConflicts(t *testing.T) {
+	source := &versionedSource{vulns: map[string][]helper.VulnerabilityInfo{
+		"lodash@4.17.20": {{ID: "GHSA-35jh-r3h4-6jhm", Severity: helper.SeverityHigh, Score: 7.2}},
+	}}
+	svc := services.NewDependenciesService(dto.BasicRepositories{}, *helper.NewDependencyParser(), nil,
+		services.ServiceConfig{Scanner: helper.ScannerConfig{Sources: []helper.VulnerabilitySource{source}}})
+	t.Cleanup(func() { _ = svc.Shutdown(context.Background()) })
+
+	files := []helper.ManifestFile{
+		{FileName: "packages/web/package.json", Content: `{"dependencies": {"lodash": "4.17.20", "express": "4.18.2"}}`},
+		{FileName: "packages/api/package.json", Content: `{"dependencies": {"lodash": "4.17.21", "express": "4.18.2"}}`},
+	}
+	result, err := svc.ScanMultipleDependencies(context.Background(), "monorepo", "Node.js", "1.0.0", "", files)
+	require.NoError(t, err)
+	scan := result.(model.ScanApplicationResult)
+
+	// Each lodash version is scanned on its own; the shared express version once
+	assert.Equal(t, 3, scan.DependencyCount)
+	assert.ElementsMatch(t, []string{"lodash@4.17.20", "lodash@4.17.21", "express@4.18.2"}, source.queried)
+	assert.Equal(t, 1, scan.Summary.High)
+
+	require.Len(t, scan.Conflicts, 1)
+	assert.Equal(t, "lodash", scan.Conflicts[0].Name)
+	assert.Equal(t, []model.ConflictingVersion{
+		{Version: "4.17.20", Files: []string{"packages/web/package.json"}},
+		{Version: "4.17.21", Files: []string{"packages/api/package.json"}},
+	}, scan.Conflicts[0].Versions)
+
+	t.Run("InvalidManifest", func(t *testing.T) {
+		files := []helper.ManifestFile{
+			{FileName: "vendor/core/deps.txt", Content: "madler/zlib@v1.3.1\n"},
+			{FileName: "vendor/net/deps.txt", Content: "openssl\n"},
+		}
+		_, err := svc.ScanMultipleDependencies(context.Background(), "firmware", "Manual", "1.0.0", "", files)
+		require.ErrorIs(t, err, services.ErrInvalidInput)
+		assert.Contains(t, err.Error(), "vendor/net/deps.txt")
+	})
+}
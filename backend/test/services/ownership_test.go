@@ -0,0 +1,107 @@
+package services_test
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/model"
+	"elang-backend/internal/repository"
+	"elang-backend/internal/services"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplicationService_ApplicationsAreScopedToOwner(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+	seedRuntimeAndFramework(t, ctx, repos.RunTimeRepository, repos.FrameWorkRepository)
+
+	appService := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, offlineGitHubAPI{}, services.ServiceConfig{})
+	t.Cleanup(func() { _ = appService.Shutdown(context.Background()) })
+
+	aliceCtx := repository.WithOwnerScope(ctx, "alice")
+	bobCtx := repository.WithOwnerScope(ctx, "bob")
+
+	resp, err := appService.AddApplication(aliceCtx, "alice-app", "Go", "Gin", "", "go.mod", transactionTestGoMod, nil)
+	require.NoError(t, err)
+
+	stored, err := repos.AppRepository.GetByName(ctx, "alice-app")
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	require.NotNil(t, stored.OwnerID)
+	assert.Equal(t, "alice", *stored.OwnerID)
+
+	aliceApps, err := appService.ListApplications(aliceCtx, nil)
+	require.NoError(t, err)
+	assert.Len(t, aliceApps.Applications, 1)
+
+	bobApps, err := appService.ListApplications(bobCtx, nil)
+	require.NoError(t, err)
+	assert.Empty(t, bobApps.Applications)
+
+	_, err = appService.UpdateApplication(bobCtx, resp.AppID, &model.UpdateApplicationRequest{AppName: strPtr("taken-over")})
+	assert.ErrorIs(t, err, services.ErrNotFound)
+}
+
+func TestDependenciesService_ScanResultsAreScopedToOwner(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+
+	app := &entity.App{ID: uuid.New(), Name: "alice-app", Status: "active", OwnerID: strPtr("alice")}
+	require.NoError(t, repos.AppRepository.Create(ctx, app))
+	scan := &entity.ScanResult{ID: uuid.New(), AppID: &app.ID, AppName: app.Name, ScanType: "application", Status: "queued", CreatedAt: time.Now().UTC()}
+	require.NoError(t, repos.ScanResultRepository.Create(ctx, scan))
+
+	depService := services.NewDependenciesService(repos, *helper.NewDependencyParser(), nil, services.ServiceConfig{})
+	t.Cleanup(func() { _ = depService.Shutdown(context.Background()) })
+
+	status, err := depService.GetScanStatus(repository.WithOwnerScope(ctx, "alice"), scan.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, "queued", status.Status)
+
+	_, err = depService.GetScanStatus(repository.WithOwnerScope(ctx, "bob"), scan.ID.String())
+	assert.ErrorIs(t, err, services.ErrNotFound)
+	_, err = depService.GetScanResult(repository.WithOwnerScope(ctx, "bob"), scan.ID.String())
+	assert.ErrorIs(t, err, services.ErrNotFound)
+}
+
+func TestDependenciesService_AdHocScansAreScopedToOwner(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+	source := &versionedSource{vulns: map[string][]helper.VulnerabilityInfo{}}
+	depService := services.NewDependenciesService(repos, *helper.NewDependencyParser(), nil,
+		services.ServiceConfig{Scanner: helper.ScannerConfig{Sources: []helper.VulnerabilitySource{source}}})
+	t.Cleanup(func() { _ = depService.Shutdown(context.Background()) })
+
+	aliceCtx := repository.WithOwnerScope(ctx, "alice")
+	bobCtx := repository.WithOwnerScope(ctx, "bob")
+	result, err := depService.ScanDependencies(aliceCtx, "alice-scan", "Node.js", "1.0.0", "", "package.json", `{"dependencies": {"lodash": "4.17.21"}}`)
+	require.NoError(t, err)
+	scanID := result.(model.ScanApplicationResult).ScanID
+
+	stored, err := repos.ScanResultRepository.GetByID(ctx, uuid.MustParse(scanID))
+	require.NoError(t, err)
+	require.NotNil(t, stored.OwnerID)
+	assert.Equal(t, "alice", *stored.OwnerID)
+
+	_, err = depService.GetScanResult(aliceCtx, scanID)
+	require.NoError(t, err)
+	status, err := depService.GetScanStatus(aliceCtx, scanID)
+	require.NoError(t, err)
+	assert.Equal(t, "completed", status.Status)
+
+	_, err = depService.GetScanResult(bobCtx, scanID)
+	assert.ErrorIs(t, err, services.ErrNotFound)
+	_, err = depService.GetScanStatus(bobCtx, scanID)
+	assert.ErrorIs(t, err, services.ErrNotFound)
+	_, err = depService.EvaluateScanPolicy(bobCtx, scanID, &model.EvaluatePolicyRequest{})
+	assert.ErrorIs(t, err, services.ErrNotFound)
+
+	// Callers without an owner scope, such as deployments without authentication, see every scan
+	_, err = depService.GetScanResult(ctx, scanID)
+	assert.NoError(t, err)
+}
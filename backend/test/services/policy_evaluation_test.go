@@ -0,0 +1,190 @@
+package services_test
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/helper/parser"
+	"elang-backend/internal/model"
+	"elang-backend/internal/model/dto"
+	"elang-backend/internal/repository"
+	"elang-backend/internal/services"
+	"elang-backend/test/mocks"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDependenciesService_EvaluateScanPolicy(t *testing.T) {
+	ctx := context.Background()
+	scanRepo := new(mocks.ScanResultRepository)
+	svc := services.NewDependenciesService(dto.BasicRepositories{ScanResultRepository: scanRepo}, *helper.NewDependencyParser(), nil, services.ServiceConfig{})
+
+	scanID := uuid.New()
+	stored, err := json.Marshal(model.ScanApplicationResult{
+		ScanID:     scanID.String(),
+		ScanStatus: "completed",
+		Summary:    model.ScanSummary{TotalDependencies: 3, TotalVulnerabilities: 2, Medium: 1, Low: 1, None: 1},
+		Policies:   model.ScanPolicy{FailOn: []string{"high", "critical"}, Status: "pass"},
+		Findings: []model.ScanFinding{
+			{Dependency: "lodash", Severity: "medium", VulnerabilityIDs: []string{"GHSA-29mw-wpgm-hmr9"}, RiskScore: 5.3},
+			{Dependency: "minimist", Severity: "low", VulnerabilityIDs: []string{"GHSA-vh95-rmgr-6w4m"}, RiskScore: 3.1},
+			{Dependency: "express", Severity: "none"},
+		},
+	})
+	require.NoError(t, err)
+	scanRepo.On("GetByID", ctx, scanID).Return(&entity.ScanResult{ID: scanID, Status: "completed", Result: stored}, nil)
+
+	testCases := []struct {
+		name           string
+		req            model.EvaluatePolicyRequest
+		expectedStatus string
+		reason         string
+	}{
+		{"StoredPolicyPasses", model.EvaluatePolicyRequest{FailOn: []string{"high", "critical"}}, "pass", "No blocking"},
+		{"StricterSeverityFails", model.EvaluatePolicyRequest{FailOn: []string{"critical", "high", "Medium"}}, "fail", "Medium severity"},
+		{"MinScoreFails", model.EvaluatePolicyRequest{FailOn: []string{"critical"}, MinScore: 5}, "fail", "lodash has risk score 5.3"},
+		{"MinScoreAboveAllFindings", model.EvaluatePolicyRequest{MinScore: 7}, "pass", "No blocking"},
+		{"EmptyPolicy", model.EvaluatePolicyRequest{}, "pass", "No blocking"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := svc.EvaluateScanPolicy(ctx, scanID.String(), &tc.req)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expectedStatus, result.Status)
+			assert.Contains(t, result.Reason, tc.reason)
+			assert.Equal(t, "pass", result.StoredStatus)
+			assert.Equal(t, 2, result.Summary.TotalVulnerabilities)
+		})
+	}
+
+	t.Run("DoesNotPersist", func(t *testing.T) {
+		_, err := svc.EvaluateScanPolicy(ctx, scanID.String(), &model.EvaluatePolicyRequest{FailOn: []string{"low"}})
+		require.NoError(t, err)
+		scanRepo.AssertNotCalled(t, "Update")
+		scanRepo.AssertNotCalled(t, "Create")
+	})
+}
+
+func TestDependenciesService_EvaluateScanPolicy_Errors(t *testing.T) {
+	ctx := context.Background()
+	scanRepo := new(mocks.ScanResultRepository)
+	svc := services.NewDependenciesService(dto.BasicRepositories{ScanResultRepository: scanRepo}, *helper.NewDependencyParser(), nil, services.ServiceConfig{})
+
+	t.Run("UnknownSeverity", func(t *testing.T) {
+		_, err := svc.EvaluateScanPolicy(ctx, uuid.NewString(), &model.EvaluatePolicyRequest{FailOn: []string{"severe"}})
+		assert.ErrorIs(t, err, services.ErrInvalidInput)
+	})
+
+	t.Run("MinScoreOutOfRange", func(t *testing.T) {
+		_, err := svc.EvaluateScanPolicy(ctx, uuid.NewString(), &model.EvaluatePolicyRequest{MinScore: 11})
+		assert.ErrorIs(t, err, services.ErrInvalidInput)
+	})
+
+	t.Run("ScanNotFound", func(t *testing.T) {
+		scanID := uuid.New()
+		scanRepo.On("GetByID", ctx, scanID).Return(nil, repository.ErrNotFound).Once()
+
+		_, err := svc.EvaluateScanPolicy(ctx, scanID.String(), &model.EvaluatePolicyRequest{FailOn: []string{"high"}})
+		assert.ErrorIs(t, err, services.ErrNotFound)
+	})
+
+	t.Run("ScanStillRunning", func(t *testing.T) {
+		scanID := uuid.New()
+		scanRepo.On("GetByID", ctx, scanID).Return(&entity.ScanResult{ID: scanID, Status: "running"}, nil).Once()
+
+		_, err := svc.EvaluateScanPolicy(ctx, scanID.String(), &model.EvaluatePolicyRequest{FailOn: []string{"high"}})
+		assert.ErrorIs(t, err, services.ErrConflict)
+	})
+}
+
+// unreachableSource fails every lookup of the names in down, like a database that cannot be reached
+type unreachableSource struct {
+	down map[string]bool
+}
+
+func (s *unreachableSource) Name() string { return "osv" }
+
+func (s *unreachableSource) QueryVulnerabilities(ctx context.Context, dep parser.DependencyInfo) ([]helper.VulnerabilityInfo, error) {
+	if s.down[dep.Name] {
+		return nil, errors.New("dial tcp: lookup api.osv.dev: no such host")
+	}
+	return nil, nil
+}
+
+func TestDependenciesService_ScanDependencies_UncheckedDependencies(t *testing.T) {
+	scanner := helper.ScannerConfig{Sources: []helper.VulnerabilitySource{&unreachableSource{down: map[string]bool{"github.com/gin-gonic/gin": true}}}}
+	goMod := "module example.com/demo\n\ngo 1.21\n\nrequire (\n\tgithub.com/google/uuid v1.5.0\n\tgithub.com/gin-gonic/gin v1.9.1\n)\n"
+
+	scan := func(t *testing.T, failClosed bool) model.ScanApplicationResult {
+		svc := services.NewDependenciesService(dto.BasicRepositories{}, *helper.NewDependencyParser(), nil,
+			services.ServiceConfig{Scanner: scanner, Policy: helper.PolicyConfig{FailClosed: failClosed}})
+		t.Cleanup(func() { _ = svc.Shutdown(context.Background()) })
+		result, err := svc.ScanDependencies(context.Background(), "demo", "go", "1.0.0", "", "go.mod", goMod)
+		require.NoError(t, err)
+		return result.(model.ScanApplicationResult)
+	}
+
+	t.Run("FailOpen", func(t *testing.T) {
+		result := scan(t, false)
+
+		assert.Equal(t, 2, result.Summary.TotalDependencies)
+		assert.Equal(t, 1, result.Summary.Unchecked)
+		assert.Equal(t, 1, result.Summary.None, "the unchecked dependency must not count as clean")
+		require.Len(t, result.Unchecked, 1)
+		assert.Equal(t, "github.com/gin-gonic/gin", result.Unchecked[0].Dependency)
+		assert.Equal(t, helper.UncheckedCheckFailed, result.Unchecked[0].Reason)
+		assert.False(t, result.Policies.FailClosed)
+		assert.Equal(t, "pass", result.Policies.Status)
+		assert.Contains(t, result.Policies.Reason, "1 of 2 dependencies could not be checked")
+	})
+
+	t.Run("FailClosed", func(t *testing.T) {
+		result := scan(t, true)
+
+		assert.Equal(t, 1, result.Summary.Unchecked)
+		assert.True(t, result.Policies.FailClosed)
+		assert.Equal(t, "fail", result.Policies.Status)
+		assert.Equal(t, "1 of 2 dependencies could not be checked for vulnerabilities", result.Policies.Reason)
+	})
+}
+
+func TestDependenciesService_ScanDependencies_PolicyOverride(t *testing.T) {
+	scanner := helper.ScannerConfig{Sources: []helper.VulnerabilitySource{&versionedSource{vulns: map[string][]helper.VulnerabilityInfo{
+		"github.com/gin-gonic/gin@1.9.1": {{ID: "GHSA-high", Severity: helper.SeverityHigh, Score: 7.5}},
+	}}}}
+	goMod := "module example.com/demo\n\ngo 1.21\n\nrequire github.com/gin-gonic/gin v1.9.1\n"
+
+	scan := func(t *testing.T, ctx context.Context) model.ScanPolicy {
+		svc := services.NewDependenciesService(dto.BasicRepositories{}, *helper.NewDependencyParser(), nil, services.ServiceConfig{Scanner: scanner})
+		t.Cleanup(func() { _ = svc.Shutdown(context.Background()) })
+		result, err := svc.ScanDependencies(ctx, "demo", "go", "1.0.0", "", "go.mod", goMod)
+		require.NoError(t, err)
+		return result.(model.ScanApplicationResult).Policies
+	}
+
+	t.Run("DefaultPolicy", func(t *testing.T) {
+		policy := scan(t, context.Background())
+		assert.Equal(t, []string{"high", "critical"}, policy.FailOn)
+		assert.Equal(t, "fail", policy.Status)
+	})
+
+	t.Run("CriticalOnly", func(t *testing.T) {
+		policy := scan(t, helper.WithScanPolicy(context.Background(), &helper.ScanPolicyOverride{FailOn: []string{"critical"}}))
+		assert.Equal(t, []string{"critical"}, policy.FailOn)
+		assert.Zero(t, policy.MinScore)
+		assert.Equal(t, "pass", policy.Status)
+	})
+
+	t.Run("MinScore", func(t *testing.T) {
+		policy := scan(t, helper.WithScanPolicy(context.Background(), &helper.ScanPolicyOverride{FailOn: []string{"critical"}, MinScore: 1}))
+		assert.Equal(t, 1.0, policy.MinScore)
+		assert.Equal(t, "fail", policy.Status)
+		assert.Contains(t, policy.Reason, "github.com/gin-gonic/gin has risk score")
+	})
+}
@@ -0,0 +1,183 @@
+package services_test
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/model"
+	"elang-backend/internal/services"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemediationService_CRUD(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+	app := &entity.App{ID: uuid.New(), Name: "payments", Status: "active"}
+	require.NoError(t, repos.AppRepository.Create(ctx, app))
+	other := &entity.App{ID: uuid.New(), Name: "web", Status: "active"}
+	require.NoError(t, repos.AppRepository.Create(ctx, other))
+	seedFindingsScan(t, repos, app, time.Date(2026, 10, 9, 12, 0, 0, 0, time.UTC),
+		entity.Finding{Dependency: "lodash", Version: "4.17.20", VulnerabilityID: "GHSA-1", Severity: "critical"},
+		entity.Finding{Dependency: "axios", Version: "0.21.0", VulnerabilityID: "GHSA-2", Severity: "medium"},
+	)
+	svc := services.NewRemediationService(repos)
+
+	created, err := svc.CreateRemediation(ctx, app.ID.String(), model.CreateRemediationRequest{
+		Dependency: "lodash", VulnerabilityID: "GHSA-1", ExternalTicketURL: "https://jira.example.com/browse/SEC-42", Notes: "upgrade to 4.17.21",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, entity.RemediationOpen, created.Status)
+	assert.Equal(t, app.ID.String(), created.AppID)
+	assert.Nil(t, created.ResolvedAt)
+
+	t.Run("Invalid", func(t *testing.T) {
+		for name, req := range map[string]model.CreateRemediationRequest{
+			"BlankDependency": {Dependency: " ", VulnerabilityID: "GHSA-2"},
+			"UnknownStatus":   {Dependency: "axios", VulnerabilityID: "GHSA-2", Status: "wontfix"},
+			"TicketNotURL":    {Dependency: "axios", VulnerabilityID: "GHSA-2", ExternalTicketURL: "SEC-43"},
+			"TicketScheme":    {Dependency: "axios", VulnerabilityID: "GHSA-2", ExternalTicketURL: "ftp://tickets/SEC-43"},
+			"NotReported":     {Dependency: "axios", VulnerabilityID: "GHSA-1"},
+			"UnknownDep":      {Dependency: "left-pad", VulnerabilityID: "GHSA-2"},
+		} {
+			_, err := svc.CreateRemediation(ctx, app.ID.String(), req)
+			assert.ErrorIs(t, err, services.ErrInvalidInput, name)
+		}
+		_, err := svc.CreateRemediation(ctx, uuid.NewString(), model.CreateRemediationRequest{Dependency: "axios", VulnerabilityID: "GHSA-2"})
+		assert.ErrorIs(t, err, services.ErrNotFound)
+		_, err = svc.CreateRemediation(ctx, other.ID.String(), model.CreateRemediationRequest{Dependency: "axios", VulnerabilityID: "GHSA-2"})
+		assert.ErrorIs(t, err, services.ErrInvalidInput, "findings of another application do not count")
+	})
+
+	t.Run("DuplicateUnresolved", func(t *testing.T) {
+		_, err := svc.CreateRemediation(ctx, app.ID.String(), model.CreateRemediationRequest{Dependency: "Lodash", VulnerabilityID: "ghsa-1"})
+		assert.ErrorIs(t, err, services.ErrConflict)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		inProgress := "in-progress"
+		updated, err := svc.UpdateRemediation(ctx, app.ID.String(), created.ID, model.UpdateRemediationRequest{Status: &inProgress})
+		require.NoError(t, err)
+		assert.Equal(t, entity.RemediationInProgress, updated.Status)
+		assert.Equal(t, "upgrade to 4.17.21", updated.Notes)
+
+		resolved := "resolved"
+		updated, err = svc.UpdateRemediation(ctx, app.ID.String(), created.ID, model.UpdateRemediationRequest{Status: &resolved})
+		require.NoError(t, err)
+		assert.NotNil(t, updated.ResolvedAt)
+		assert.Empty(t, updated.ResolvedByScanID)
+
+		open := "open"
+		updated, err = svc.UpdateRemediation(ctx, app.ID.String(), created.ID, model.UpdateRemediationRequest{Status: &open})
+		require.NoError(t, err)
+		assert.Nil(t, updated.ResolvedAt)
+
+		_, err = svc.UpdateRemediation(ctx, other.ID.String(), created.ID, model.UpdateRemediationRequest{Status: &open})
+		assert.ErrorIs(t, err, services.ErrNotFound)
+	})
+
+	t.Run("ListByStatus", func(t *testing.T) {
+		_, err := svc.CreateRemediation(ctx, app.ID.String(), model.CreateRemediationRequest{Dependency: "axios", VulnerabilityID: "GHSA-2", Status: "resolved"})
+		require.NoError(t, err)
+
+		all, err := svc.ListRemediations(ctx, app.ID.String(), "")
+		require.NoError(t, err)
+		assert.Len(t, all.Remediations, 2)
+		resolved, err := svc.ListRemediations(ctx, app.ID.String(), "resolved")
+		require.NoError(t, err)
+		require.Len(t, resolved.Remediations, 1)
+		assert.Equal(t, "axios", resolved.Remediations[0].Dependency)
+
+		_, err = svc.ListRemediations(ctx, app.ID.String(), "closed")
+		assert.ErrorIs(t, err, services.ErrInvalidInput)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		assert.ErrorIs(t, svc.DeleteRemediation(ctx, other.ID.String(), created.ID), services.ErrNotFound)
+		require.NoError(t, svc.DeleteRemediation(ctx, app.ID.String(), created.ID))
+		_, err := svc.UpdateRemediation(ctx, app.ID.String(), created.ID, model.UpdateRemediationRequest{})
+		assert.ErrorIs(t, err, services.ErrNotFound)
+	})
+}
+
+func TestDependenciesService_ListFindings_Remediation(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+	app := &entity.App{ID: uuid.New(), Name: "payments", Status: "active"}
+	require.NoError(t, repos.AppRepository.Create(ctx, app))
+	seedFindingsScan(t, repos, app, time.Date(2026, 10, 9, 12, 0, 0, 0, time.UTC),
+		entity.Finding{Dependency: "lodash", Version: "4.17.20", VulnerabilityID: "GHSA-1", Severity: "critical"},
+		entity.Finding{Dependency: "axios", Version: "0.21.0", VulnerabilityID: "GHSA-2", Severity: "medium"},
+	)
+	remediation, err := services.NewRemediationService(repos).CreateRemediation(ctx, app.ID.String(), model.CreateRemediationRequest{
+		Dependency: "lodash", VulnerabilityID: "GHSA-1", Status: "in_progress", ExternalTicketURL: "https://jira.example.com/browse/SEC-42",
+	})
+	require.NoError(t, err)
+
+	resp, err := services.NewDependenciesService(repos, *helper.NewDependencyParser(), nil, services.ServiceConfig{}).ListFindings(ctx, app.ID.String(), model.FindingsQuery{})
+	require.NoError(t, err)
+	require.Len(t, resp.Findings, 2)
+	byDependency := map[string]model.FindingRecord{}
+	for _, finding := range resp.Findings {
+		byDependency[finding.Dependency] = finding
+	}
+	require.NotNil(t, byDependency["lodash"].Remediation)
+	assert.Equal(t, remediation.ID, byDependency["lodash"].Remediation.ID)
+	assert.Equal(t, entity.RemediationInProgress, byDependency["lodash"].Remediation.Status)
+	assert.Equal(t, "https://jira.example.com/browse/SEC-42", byDependency["lodash"].Remediation.ExternalTicketURL)
+	assert.Nil(t, byDependency["axios"].Remediation)
+}
+
+func TestApplicationService_ScanResolvesFixedRemediations(t *testing.T) {
+	// The vulnerability source no longer reports anything for gin at the version in use
+	scanner := helper.ScannerConfig{Sources: []helper.VulnerabilitySource{&versionedSource{}}}
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+	runtime := &entity.Runtime{Name: "Go"}
+	require.NoError(t, repos.RunTimeRepository.Create(ctx, runtime))
+	app := &entity.App{ID: uuid.New(), Name: "fixed-app", RuntimeID: &runtime.ID, Status: "active"}
+	require.NoError(t, repos.AppRepository.Create(ctx, app))
+	gin := &entity.Dependency{ID: uuid.New(), Name: "github.com/gin-gonic/gin", Owner: "gin-gonic", Repo: "gin"}
+	require.NoError(t, repos.DepedencyRepository.Create(ctx, gin))
+	require.NoError(t, repos.AppToDepedencyRepository.Create(ctx, &entity.AppDependency{ID: uuid.New(), AppID: app.ID, DependencyID: gin.ID, UsedVersion: "1.9.1"}))
+	// Without a GitHub repository the scan skips this dependency, so its vulnerabilities are unknown
+	local := &entity.Dependency{ID: uuid.New(), Name: "example.com/internal/auth"}
+	require.NoError(t, repos.DepedencyRepository.Create(ctx, local))
+	require.NoError(t, repos.AppToDepedencyRepository.Create(ctx, &entity.AppDependency{ID: uuid.New(), AppID: app.ID, DependencyID: local.ID, UsedVersion: "1.0.0"}))
+	seedFindingsScan(t, repos, app, time.Now().UTC().Add(-24*time.Hour),
+		entity.Finding{Dependency: gin.Name, Version: "1.9.0", VulnerabilityID: "GHSA-1", Severity: "high"},
+		entity.Finding{Dependency: gin.Name, Version: "1.9.0", VulnerabilityID: "GHSA-2", Severity: "medium"},
+		entity.Finding{Dependency: local.Name, Version: "1.0.0", VulnerabilityID: "GHSA-3", Severity: "critical"},
+	)
+
+	remediations := services.NewRemediationService(repos)
+	open, err := remediations.CreateRemediation(ctx, app.ID.String(), model.CreateRemediationRequest{Dependency: gin.Name, VulnerabilityID: "GHSA-1"})
+	require.NoError(t, err)
+	inProgress, err := remediations.CreateRemediation(ctx, app.ID.String(), model.CreateRemediationRequest{Dependency: gin.Name, VulnerabilityID: "GHSA-2", Status: "in_progress"})
+	require.NoError(t, err)
+	unchecked, err := remediations.CreateRemediation(ctx, app.ID.String(), model.CreateRemediationRequest{Dependency: local.Name, VulnerabilityID: "GHSA-3"})
+	require.NoError(t, err)
+
+	appService := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, nil, services.ServiceConfig{Scanner: scanner})
+	t.Cleanup(func() { _ = appService.Shutdown(context.Background()) })
+	raw, err := appService.ScanApplicationDependencies(ctx, app.ID.String())
+	require.NoError(t, err)
+	result := raw.(model.ScanApplicationResult)
+
+	list, err := remediations.ListRemediations(ctx, app.ID.String(), "resolved")
+	require.NoError(t, err)
+	require.Len(t, list.Remediations, 2)
+	for _, remediation := range list.Remediations {
+		assert.Contains(t, []string{open.ID, inProgress.ID}, remediation.ID)
+		assert.Equal(t, result.ScanID, remediation.ResolvedByScanID)
+		assert.NotNil(t, remediation.ResolvedAt)
+	}
+	list, err = remediations.ListRemediations(ctx, app.ID.String(), "open")
+	require.NoError(t, err)
+	require.Len(t, list.Remediations, 1)
+	assert.Equal(t, unchecked.ID, list.Remediations[0].ID, "remediations of dependencies the scan skipped stay open")
+}
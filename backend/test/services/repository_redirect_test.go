@@ -0,0 +1,114 @@
+package services_test
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/services"
+	"elang-backend/internal/usecase"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// movedGitHubAPI reports gin-gonic/gin as transferred to gin-org/gin-web
+type movedGitHubAPI struct {
+	offlineGitHubAPI
+}
+
+func (movedGitHubAPI) GetRepoInfo(owner, repo string) (map[string]interface{}, error) {
+	if owner == "gin-gonic" && repo == "gin" {
+		return map[string]interface{}{"full_name": "gin-org/gin-web"}, nil
+	}
+	return map[string]interface{}{"full_name": owner + "/" + repo}, nil
+}
+
+// missingGitHubAPI does not know google/uuid
+type missingGitHubAPI struct {
+	offlineGitHubAPI
+}
+
+func (missingGitHubAPI) GetRepoInfo(owner, repo string) (map[string]interface{}, error) {
+	if owner == "google" && repo == "uuid" {
+		return nil, fmt.Errorf("repository %s/%s: %w", owner, repo, usecase.ErrGitHubRepositoryNotFound)
+	}
+	return map[string]interface{}{"full_name": owner + "/" + repo}, nil
+}
+
+func TestApplicationService_AddApplication_DropsRepositoriesGitHubDoesNotKnow(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+	seedRuntimeAndFramework(t, ctx, repos.RunTimeRepository, repos.FrameWorkRepository)
+
+	// A URL guessed earlier for the same repository is cleared too
+	guessedURL := "https://github.com/google/uuid"
+	existing := &entity.Dependency{ID: uuid.New(), Name: "github.com/google/uuid", Owner: "google", Repo: "uuid", RepositoryURL: &guessedURL}
+	require.NoError(t, repos.DepedencyRepository.Create(ctx, existing))
+
+	appService := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, missingGitHubAPI{}, services.ServiceConfig{DependencyProcessingWorkers: 1})
+	t.Cleanup(func() { _ = appService.Shutdown(context.Background()) })
+
+	_, err := appService.AddApplication(ctx, "missing-app", "Go", "Gin", "", "go.mod", transactionTestGoMod, nil)
+	require.NoError(t, err)
+	app, err := repos.AppRepository.GetByName(ctx, "missing-app")
+	require.NoError(t, err)
+	require.NotNil(t, app)
+
+	require.Eventually(t, func() bool {
+		links, err := repos.AppToDepedencyRepository.GetByAppID(ctx, app.ID)
+		return err == nil && len(links) == 2
+	}, 5*time.Second, 20*time.Millisecond)
+
+	missing, err := repos.DepedencyRepository.GetByID(ctx, existing.ID)
+	require.NoError(t, err)
+	require.NotNil(t, missing)
+	assert.Nil(t, missing.RepositoryURL)
+
+	found, err := repos.DepedencyRepository.GetByOwnerRepoCI(ctx, "gin-gonic", "gin")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	require.NotNil(t, found.RepositoryURL)
+	assert.Equal(t, "https://github.com/gin-gonic/gin", *found.RepositoryURL)
+}
+
+func TestApplicationService_AddApplication_RenamesMovedRepository(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+	seedRuntimeAndFramework(t, ctx, repos.RunTimeRepository, repos.FrameWorkRepository)
+
+	oldURL := "https://github.com/gin-gonic/gin"
+	existing := &entity.Dependency{ID: uuid.New(), Name: "github.com/gin-gonic/gin", Owner: "gin-gonic", Repo: "gin", RepositoryURL: &oldURL}
+	require.NoError(t, repos.DepedencyRepository.Create(ctx, existing))
+
+	// One worker keeps the two dependencies from writing at once, which the shared-cache test database can reject
+	appService := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, movedGitHubAPI{}, services.ServiceConfig{DependencyProcessingWorkers: 1})
+	t.Cleanup(func() { _ = appService.Shutdown(context.Background()) })
+
+	_, err := appService.AddApplication(ctx, "moved-app", "Go", "Gin", "", "go.mod", transactionTestGoMod, nil)
+	require.NoError(t, err)
+	app, err := repos.AppRepository.GetByName(ctx, "moved-app")
+	require.NoError(t, err)
+	require.NotNil(t, app)
+
+	require.Eventually(t, func() bool {
+		links, err := repos.AppToDepedencyRepository.GetByAppID(ctx, app.ID)
+		return err == nil && len(links) == 2
+	}, 5*time.Second, 20*time.Millisecond)
+
+	// The existing row is renamed in place rather than duplicated under the new name
+	renamed, err := repos.DepedencyRepository.GetByID(ctx, existing.ID)
+	require.NoError(t, err)
+	require.NotNil(t, renamed)
+	assert.Equal(t, "gin-org", renamed.Owner)
+	assert.Equal(t, "gin-web", renamed.Repo)
+	require.NotNil(t, renamed.RepositoryURL)
+	assert.Equal(t, "https://github.com/gin-org/gin-web", *renamed.RepositoryURL)
+
+	stale, err := repos.DepedencyRepository.GetByOwnerRepoCI(ctx, "gin-gonic", "gin")
+	require.NoError(t, err)
+	assert.Nil(t, stale)
+}
@@ -0,0 +1,77 @@
+package services_test
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/model"
+	"elang-backend/internal/services"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingGitHubAPI counts repository lookups, which must not happen for rejected URLs
+type countingGitHubAPI struct {
+	offlineGitHubAPI
+	lookups *atomic.Int32
+}
+
+func (g countingGitHubAPI) GetRepoInfo(owner, repo string) (map[string]interface{}, error) {
+	g.lookups.Add(1)
+	return g.offlineGitHubAPI.GetRepoInfo(owner, repo)
+}
+
+const metadataEndpointURL = "http://169.254.169.254/latest/meta-data/iam"
+
+func TestApplicationService_RejectsUntrustedRepositoryURLs(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+
+	app := &entity.App{ID: uuid.New(), Name: "billing", Status: "active"}
+	require.NoError(t, repos.AppRepository.Create(ctx, app))
+
+	lookups := &atomic.Int32{}
+	appService := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, countingGitHubAPI{lookups: lookups}, services.ServiceConfig{})
+	t.Cleanup(func() { _ = appService.Shutdown(context.Background()) })
+
+	t.Run("Add", func(t *testing.T) {
+		result, err := appService.AddApplicationDependency(ctx, app.ID.String(), []model.DependencyInfoRequest{
+			{Name: "internal", Owner: "latest", Repo: "meta-data", Version: "1.0.0", RepositoryURL: metadataEndpointURL, IsGitHubRepo: true},
+			{Name: "local", Owner: "admin", Repo: "panel", Version: "1.0.0", RepositoryURL: "http://localhost:2375/containers"},
+		})
+		require.NoError(t, err)
+
+		summary := result.(map[string]interface{})["summary"].(map[string]interface{})
+		assert.Equal(t, 2, summary["failed"])
+		links, err := repos.AppToDepedencyRepository.GetByAppID(ctx, app.ID)
+		require.NoError(t, err)
+		assert.Empty(t, links)
+		deps, err := repos.DepedencyRepository.GetAll(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, deps, "rejected URLs are not persisted")
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		dep := &entity.Dependency{ID: uuid.New(), Name: "github.com/google/uuid", Owner: "google", Repo: "uuid"}
+		require.NoError(t, repos.DepedencyRepository.Create(ctx, dep))
+		require.NoError(t, repos.AppToDepedencyRepository.Create(ctx, &entity.AppDependency{ID: uuid.New(), AppID: app.ID, DependencyID: dep.ID, UsedVersion: "v1.5.0"}))
+
+		resp, err := appService.UpdateApplicationDependency(ctx, app.ID.String(), &model.UpdateApplicationDependencyRequest{
+			Updates: []model.UpdateDependencyItem{{DependencyID: dep.ID.String(), UsedVersion: "v1.6.0", RepositoryURL: metadataEndpointURL}},
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Failed, 1)
+		assert.Equal(t, dep.ID.String(), resp.Failed[0].DependencyID)
+		assert.Equal(t, model.UpdateFailureInvalidRepositoryURL, resp.Failed[0].Reason)
+
+		stored, err := repos.AppToDepedencyRepository.GetByAppAndDependencyID(ctx, app.ID, dep.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "v1.5.0", stored.UsedVersion)
+	})
+
+	assert.Zero(t, lookups.Load(), "GitHub is never asked about rejected URLs")
+}
@@ -0,0 +1,165 @@
+package services_test
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+	"elang-backend/internal/repository"
+	"elang-backend/internal/services"
+	"elang-backend/internal/usecase"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingObjectStorage records the SBOM objects deleted by the retention job
+type recordingObjectStorage struct {
+	usecase.ObjectStorageInterface
+	mu      sync.Mutex
+	deleted []string
+	failFor string
+}
+
+func (s *recordingObjectStorage) DeleteSBOM(ctx context.Context, objectKey string) error {
+	if objectKey == s.failFor {
+		return errors.New("object not found")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deleted = append(s.deleted, objectKey)
+	return nil
+}
+
+func TestRetentionService_RunCleanup(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+	storage := &recordingObjectStorage{failFor: "sbom/missing.json"}
+
+	now := time.Now()
+	appID := uuid.New()
+	newScan := func(age time.Duration, sbomKey string) *entity.ScanResult {
+		scan := &entity.ScanResult{ID: uuid.New(), AppID: &appID, AppName: "retention-app", Status: "completed", CreatedAt: now.Add(-age)}
+		if sbomKey != "" {
+			scan.SBOMObjectKey = &sbomKey
+		}
+		require.NoError(t, repos.ScanResultRepository.Create(ctx, scan))
+		return scan
+	}
+	kept := newScan(time.Hour, "sbom/kept.json")
+	expired := newScan(40*24*time.Hour, "sbom/expired.json")
+	missing := newScan(50*24*time.Hour, "sbom/missing.json")
+
+	oldAudit := &entity.AuditTrail{ID: uuid.New(), EntityType: "app", EntityID: appID, Action: "application_created", PerformedBy: "test", PerformedAt: now.Add(-400 * 24 * time.Hour)}
+	newAudit := &entity.AuditTrail{ID: uuid.New(), EntityType: "app", EntityID: appID, Action: "application_updated", PerformedBy: "test", PerformedAt: now}
+	require.NoError(t, repos.AuditTrailRepository.Create(ctx, oldAudit))
+	require.NoError(t, repos.AuditTrailRepository.Create(ctx, newAudit))
+
+	retention := services.NewRetentionService(repos, storage, services.RetentionConfig{
+		ScanRetention:   30 * 24 * time.Hour,
+		KeepScansPerApp: 1,
+		AuditRetention:  365 * 24 * time.Hour,
+		Interval:        time.Hour,
+	})
+
+	result, err := retention.RunCleanup(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.ScansDeleted)
+	assert.Equal(t, 1, result.ArtifactsDeleted)
+	assert.Equal(t, 1, result.ArtifactErrors, "a missing object is reported but does not fail the run")
+	assert.True(t, result.AuditCleaned)
+	assert.Equal(t, []string{"sbom/expired.json"}, storage.deleted)
+
+	found, err := repos.ScanResultRepository.GetByID(ctx, kept.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, found)
+	for _, id := range []uuid.UUID{expired.ID, missing.ID} {
+		found, err := repos.ScanResultRepository.GetByID(ctx, id)
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+		assert.Nil(t, found)
+	}
+
+	audits, err := repos.AuditTrailRepository.GetByEntity(ctx, "app", appID, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, audits, 1)
+	assert.Equal(t, newAudit.ID, audits[0].ID)
+}
+
+func TestRetentionService_StartAndShutdown(t *testing.T) {
+	repos := setupScanTestRepos(t)
+	retention := services.NewRetentionService(repos, nil, services.RetentionConfig{
+		ScanRetention: 24 * time.Hour,
+		Interval:      10 * time.Millisecond,
+	})
+
+	retention.Start()
+	time.Sleep(30 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.NoError(t, retention.Shutdown(ctx))
+}
+
+// legacyObjectStorage holds documents saved before object keys were prefixed and moves them under
+// "<tenant>/", or "shared/" for callers without a tenant
+type legacyObjectStorage struct {
+	usecase.ObjectStorageInterface
+	legacy []string
+	moved  map[string]string // legacy key -> new key
+}
+
+func (s *legacyObjectStorage) ListLegacyObjects(ctx context.Context) ([]string, error) {
+	return s.legacy, nil
+}
+
+func (s *legacyObjectStorage) MoveLegacyObject(ctx context.Context, objectKey string) (string, error) {
+	tenantID := usecase.StorageTenant(ctx)
+	if tenantID == "" {
+		tenantID = "shared"
+	}
+	s.moved[objectKey] = tenantID + "/" + objectKey
+	return s.moved[objectKey], nil
+}
+
+func TestRetentionService_MigrateLegacyObjectKeys(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+
+	owner := "alice"
+	app := &entity.App{ID: uuid.New(), Name: "api", Status: "active", OwnerID: &owner}
+	require.NoError(t, repos.AppRepository.Create(ctx, app))
+	scan := &entity.ScanResult{ID: uuid.New(), AppID: &app.ID, AppName: app.Name, Status: "completed"}
+	sbomKey := "sbom/api/2024-01-01/" + scan.ID.String() + "_sbom.json"
+	scan.SBOMObjectKey = &sbomKey
+	require.NoError(t, repos.ScanResultRepository.Create(ctx, scan))
+	reportKey := "vulnerability-reports/api/2024-01-01/" + scan.ID.String() + "_vuln_report.json"
+	// An ad-hoc scan's SBOM whose scan is no longer stored
+	adhocKey := "sbom/adhoc/2024-01-01/" + uuid.NewString() + "_sbom.json"
+
+	storage := &legacyObjectStorage{legacy: []string{sbomKey, reportKey, adhocKey}, moved: map[string]string{}}
+	retention := services.NewRetentionService(repos, storage, services.RetentionConfig{})
+
+	moved, err := retention.MigrateLegacyObjectKeys(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, moved)
+	assert.Equal(t, map[string]string{
+		sbomKey:   "alice/" + sbomKey,
+		reportKey: "alice/" + reportKey,
+		adhocKey:  "shared/" + adhocKey,
+	}, storage.moved)
+
+	stored, err := repos.ScanResultRepository.GetByID(ctx, scan.ID)
+	require.NoError(t, err)
+	require.NotNil(t, stored.SBOMObjectKey)
+	assert.Equal(t, "alice/"+sbomKey, *stored.SBOMObjectKey)
+
+	t.Run("WithoutLegacySupport", func(t *testing.T) {
+		retention := services.NewRetentionService(repos, &recordingObjectStorage{}, services.RetentionConfig{})
+		moved, err := retention.MigrateLegacyObjectKeys(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, moved)
+	})
+}
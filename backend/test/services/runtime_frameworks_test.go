@@ -0,0 +1,124 @@
+package services_test
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/model"
+	"elang-backend/internal/model/dto"
+	"elang-backend/internal/services"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seedAssociatedFrameworks creates the Go and Node.js runtimes with one framework each
+func seedAssociatedFrameworks(t *testing.T, ctx context.Context, repos dto.BasicRepositories) (goRuntime, nodeRuntime *entity.Runtime) {
+	t.Helper()
+	goRuntime, nodeRuntime = &entity.Runtime{Name: "Go"}, &entity.Runtime{Name: "Node.js"}
+	require.NoError(t, repos.RunTimeRepository.Create(ctx, goRuntime))
+	require.NoError(t, repos.RunTimeRepository.Create(ctx, nodeRuntime))
+	require.NoError(t, repos.FrameWorkRepository.Create(ctx, &entity.Framework{Name: "Gin", Runtimes: []entity.Runtime{*goRuntime}}))
+	require.NoError(t, repos.FrameWorkRepository.Create(ctx, &entity.Framework{Name: "Express", Runtimes: []entity.Runtime{*nodeRuntime}}))
+	return goRuntime, nodeRuntime
+}
+
+func TestApplicationService_GetFrameworksByRuntime(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+	goRuntime, _ := seedAssociatedFrameworks(t, ctx, repos)
+
+	appService := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, offlineGitHubAPI{}, services.ServiceConfig{})
+	t.Cleanup(func() { _ = appService.Shutdown(context.Background()) })
+
+	byName, err := appService.GetFrameworksByRuntime(ctx, "go")
+	require.NoError(t, err)
+	assert.Equal(t, goRuntime.ID, byName.RuntimeID)
+	assert.Equal(t, "Go", byName.Runtime)
+	require.Len(t, byName.Frameworks, 1)
+	assert.Equal(t, "Gin", byName.Frameworks[0].Name)
+
+	byID, err := appService.GetFrameworksByRuntime(ctx, "1")
+	require.NoError(t, err)
+	assert.Equal(t, byName.Frameworks, byID.Frameworks)
+
+	_, err = appService.GetFrameworksByRuntime(ctx, "cobol")
+	assert.ErrorIs(t, err, services.ErrNotFound)
+}
+
+func TestApplicationService_RejectsFrameworkFromAnotherRuntime(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+	seedAssociatedFrameworks(t, ctx, repos)
+
+	appService := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, offlineGitHubAPI{}, services.ServiceConfig{})
+	t.Cleanup(func() { _ = appService.Shutdown(context.Background()) })
+
+	_, err := appService.AddApplication(ctx, "mismatched", "Go", "Express", "", "go.mod", transactionTestGoMod, nil)
+	assert.ErrorIs(t, err, services.ErrInvalidInput)
+	app, err := repos.AppRepository.GetByName(ctx, "mismatched")
+	require.NoError(t, err)
+	assert.Nil(t, app)
+
+	resp, err := appService.AddApplication(ctx, "matched", "Go", "Gin", "", "go.mod", transactionTestGoMod, nil)
+	require.NoError(t, err)
+
+	// Switching only the framework to one of another runtime is rejected as well
+	express := "Express"
+	_, err = appService.UpdateApplication(ctx, resp.AppID, &model.UpdateApplicationRequest{Framework: &express})
+	assert.ErrorIs(t, err, services.ErrInvalidInput)
+}
+
+func TestApplicationService_AcceptsFrameworkOfSeveralRuntimes(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+	_, err := services.NewSeedService(repos).Seed(ctx)
+	require.NoError(t, err)
+
+	appService := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, offlineGitHubAPI{}, services.ServiceConfig{DependencyProcessingWorkers: 1})
+	t.Cleanup(func() { _ = appService.Shutdown(context.Background()) })
+
+	buildGradle := "dependencies {\n    implementation 'org.springframework.boot:spring-boot-starter-web:3.2.0'\n}\n"
+	resp, err := appService.AddApplication(ctx, "gradle-boot", "Gradle", "Spring Boot", "", "build.gradle", buildGradle, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Spring Boot", resp.Framework)
+
+	pomXML := "<project><dependencies><dependency><groupId>org.springframework</groupId><artifactId>spring-core</artifactId><version>6.1.0</version></dependency></dependencies></project>"
+	resp, err = appService.AddApplication(ctx, "maven-boot", "Java", "Spring Boot", "", "pom.xml", pomXML, nil)
+	require.NoError(t, err)
+
+	// Moving the Maven application to Gradle keeps a framework both runtimes share
+	gradle := "Gradle"
+	_, err = appService.UpdateApplication(ctx, resp.AppID, &model.UpdateApplicationRequest{RuntimeType: &gradle})
+	require.NoError(t, err)
+
+	frameworks, err := appService.GetFrameworksByRuntime(ctx, "gradle")
+	require.NoError(t, err)
+	var names []string
+	for _, fw := range frameworks.Frameworks {
+		names = append(names, fw.Name)
+	}
+	assert.Equal(t, []string{"Native", "Spring", "Spring Boot"}, names)
+}
+
+func TestApplicationService_AddsManualApplication(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+	_, err := services.NewSeedService(repos).Seed(ctx)
+	require.NoError(t, err)
+
+	appService := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, offlineGitHubAPI{}, services.ServiceConfig{DependencyProcessingWorkers: 1})
+	t.Cleanup(func() { _ = appService.Shutdown(context.Background()) })
+
+	frameworks, err := appService.GetFrameworksByRuntime(ctx, "manual")
+	require.NoError(t, err)
+	require.Len(t, frameworks.Frameworks, 1)
+	assert.Equal(t, "None", frameworks.Frameworks[0].Name)
+
+	resp, err := appService.AddApplication(ctx, "firmware", "Manual", "None", "", "dependencies.txt", "madler/zlib@v1.3.1\nopenssl 3.0.7\n", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Manual", resp.RuntimeType)
+	assert.Equal(t, "None", resp.Framework)
+	assert.Equal(t, 2, resp.DependencyCount)
+}
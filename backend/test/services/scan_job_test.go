@@ -0,0 +1,154 @@
+package services_test
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/model"
+	"elang-backend/internal/model/dto"
+	"elang-backend/internal/repository"
+	"elang-backend/internal/services"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// setupScanTestRepos builds repositories backed by an in-memory database
+func setupScanTestRepos(t *testing.T) dto.BasicRepositories {
+	db, err := gorm.Open(sqlite.Open("file:"+uuid.NewString()+"?mode=memory&cache=shared"), &gorm.Config{})
+	require.NoError(t, err)
+	err = db.AutoMigrate(
+		&entity.Runtime{},
+		&entity.Framework{},
+		&entity.App{},
+		&entity.Dependency{},
+		&entity.AppDependency{},
+		&entity.DependencyVersion{},
+		&entity.AuditTrail{},
+		&entity.ScanResult{},
+		&entity.Finding{},
+		&entity.Tag{},
+		&entity.AppTag{},
+		&entity.ScanSchedule{},
+		&entity.Remediation{},
+		&entity.SeverityOverride{},
+	)
+	require.NoError(t, err)
+
+	return dto.BasicRepositories{
+		AppRepository:              repository.NewAppRepository(db),
+		DepedencyRepository:        repository.NewDependencyRepository(db),
+		AppToDepedencyRepository:   repository.NewAppDependencyRepository(db),
+		DepedencyVersionRepository: repository.NewDependencyVersionRepository(db),
+		RunTimeRepository:          repository.NewRuntimeRepository(db),
+		FrameWorkRepository:        repository.NewFrameworkRepository(db),
+		AuditTrailRepository:       repository.NewAuditTrailRepository(db),
+		ScanResultRepository:       repository.NewScanResultRepository(db),
+		TagRepository:              repository.NewTagRepository(db),
+		ScanScheduleRepository:     repository.NewScanScheduleRepository(db),
+		RemediationRepository:      repository.NewRemediationRepository(db),
+		SeverityOverrideRepository: repository.NewSeverityOverrideRepository(db),
+		UnitOfWork:                 repository.NewUnitOfWork(db),
+	}
+}
+
+func TestApplicationService_StartApplicationScan(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+
+	runtime := &entity.Runtime{Name: "Go"}
+	require.NoError(t, repos.RunTimeRepository.Create(ctx, runtime))
+	framework := &entity.Framework{Name: "Gin"}
+	require.NoError(t, repos.FrameWorkRepository.Create(ctx, framework))
+	app := &entity.App{ID: uuid.New(), Name: "scan-app", RuntimeID: &runtime.ID, FrameworkID: &framework.ID, Status: "active"}
+	require.NoError(t, repos.AppRepository.Create(ctx, app))
+
+	parser := helper.NewDependencyParser()
+	appService := services.NewApplicationService(repos, *parser, nil, nil, services.ServiceConfig{})
+	depService := services.NewDependenciesService(repos, *parser, nil, services.ServiceConfig{})
+
+	job, err := appService.StartApplicationScan(ctx, app.ID.String())
+	require.NoError(t, err)
+	require.NotNil(t, job)
+	assert.Equal(t, "queued", job.Status)
+	assert.Equal(t, app.ID.String(), job.AppID)
+
+	// The scan runs in the background; poll its status until it finishes
+	var status *model.ScanJobStatus
+	require.Eventually(t, func() bool {
+		status, err = depService.GetScanStatus(ctx, job.ScanID)
+		return err == nil && status.Status == "completed"
+	}, 5*time.Second, 20*time.Millisecond)
+	assert.NotNil(t, status.StartedAt)
+	assert.NotNil(t, status.CompletedAt)
+
+	result, err := depService.GetScanResult(ctx, job.ScanID)
+	require.NoError(t, err)
+	assert.Equal(t, job.ScanID, result.ScanID)
+	assert.Equal(t, "completed", result.ScanStatus)
+}
+
+func TestApplicationService_StartApplicationScan_UnknownApp(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+	appService := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, nil, services.ServiceConfig{})
+
+	job, err := appService.StartApplicationScan(ctx, uuid.NewString())
+	assert.Nil(t, job)
+	assert.ErrorIs(t, err, services.ErrNotFound)
+}
+
+func TestDependenciesService_GetScanStatus_NotFound(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+	depService := services.NewDependenciesService(repos, *helper.NewDependencyParser(), nil, services.ServiceConfig{})
+
+	status, err := depService.GetScanStatus(ctx, uuid.NewString())
+	assert.Nil(t, status)
+	assert.ErrorIs(t, err, services.ErrNotFound)
+}
+
+func TestApplicationService_ScanApplicationDependencies_NilFramework(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+
+	runtime := &entity.Runtime{Name: "Go"}
+	require.NoError(t, repos.RunTimeRepository.Create(ctx, runtime))
+	app := &entity.App{ID: uuid.New(), Name: "no-framework-app", RuntimeID: &runtime.ID, Status: "active"}
+	require.NoError(t, repos.AppRepository.Create(ctx, app))
+
+	appService := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, nil, services.ServiceConfig{})
+
+	var resp interface{}
+	var err error
+	require.NotPanics(t, func() {
+		resp, err = appService.ScanApplicationDependencies(ctx, app.ID.String())
+	})
+	require.NoError(t, err)
+	result, ok := resp.(model.ScanApplicationResult)
+	require.True(t, ok)
+	assert.Equal(t, "completed", result.ScanStatus)
+	assert.Equal(t, app.ID.String(), result.AppID)
+}
+
+func TestApplicationService_ScanApplicationDependencies_NilRuntime(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+
+	app := &entity.App{ID: uuid.New(), Name: "legacy-app", Status: "active"}
+	require.NoError(t, repos.AppRepository.Create(ctx, app))
+
+	appService := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, nil, services.ServiceConfig{})
+
+	_, err := appService.ScanApplicationDependencies(ctx, app.ID.String())
+	assert.ErrorIs(t, err, services.ErrInvalidInput)
+
+	job, err := appService.StartApplicationScan(ctx, app.ID.String())
+	assert.Nil(t, job)
+	assert.ErrorIs(t, err, services.ErrInvalidInput, "the scan is rejected before it is queued")
+}
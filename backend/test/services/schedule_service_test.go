@@ -0,0 +1,143 @@
+package services_test
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+	"elang-backend/internal/model"
+	"elang-backend/internal/services"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scheduledScanRecorder stands in for the application service, recording the applications it scans
+type scheduledScanRecorder struct {
+	services.ApplicationInterface
+	scanned []string
+	scanID  string
+	err     error
+}
+
+func (s *scheduledScanRecorder) ScanApplicationDependencies(ctx context.Context, appUID string) (interface{}, error) {
+	s.scanned = append(s.scanned, appUID)
+	if s.err != nil {
+		return nil, s.err
+	}
+	return model.ScanApplicationResult{ScanID: s.scanID, AppID: appUID}, nil
+}
+
+func TestScheduleService_RunsDueSchedules(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+	app := &entity.App{ID: uuid.New(), Name: "nightly", Status: "active"}
+	require.NoError(t, repos.AppRepository.Create(ctx, app))
+
+	scanner := &scheduledScanRecorder{scanID: uuid.NewString()}
+	scheduleService := services.NewScheduleService(repos, scanner)
+	t.Cleanup(func() { _ = scheduleService.Shutdown(context.Background()) })
+
+	created, err := scheduleService.CreateSchedule(ctx, app.ID.String(), model.CreateScanScheduleRequest{Cron: "0 2 * * MON"})
+	require.NoError(t, err)
+	assert.True(t, created.Enabled)
+	require.NotNil(t, created.NextRunAt)
+	assert.True(t, created.NextRunAt.After(time.Now()))
+	assert.Equal(t, time.Monday, created.NextRunAt.Weekday())
+	assert.Equal(t, 2, created.NextRunAt.Hour())
+
+	ran, err := scheduleService.RunDueSchedules(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, ran, "nothing is due yet")
+
+	// Pretend the scheduled time has passed
+	stored, err := repos.ScanScheduleRepository.GetByID(ctx, uuid.MustParse(created.ID))
+	require.NoError(t, err)
+	past := time.Now().UTC().Add(-time.Minute)
+	stored.NextRunAt = &past
+	require.NoError(t, repos.ScanScheduleRepository.Update(ctx, stored))
+
+	ran, err = scheduleService.RunDueSchedules(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, ran)
+	assert.Equal(t, []string{app.ID.String()}, scanner.scanned)
+
+	listed, err := scheduleService.ListSchedules(ctx, app.ID.String())
+	require.NoError(t, err)
+	require.Len(t, listed.Schedules, 1)
+	schedule := listed.Schedules[0]
+	assert.Equal(t, scanner.scanID, schedule.LastScanID)
+	assert.Empty(t, schedule.LastError)
+	require.NotNil(t, schedule.LastRunAt)
+	require.NotNil(t, schedule.NextRunAt)
+	assert.True(t, schedule.NextRunAt.After(time.Now()), "the next run moves to the following Monday")
+
+	ran, err = scheduleService.RunDueSchedules(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, ran, "a schedule runs once per matching time")
+}
+
+func TestScheduleService_RecordsFailedScan(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+	app := &entity.App{ID: uuid.New(), Name: "broken", Status: "active"}
+	require.NoError(t, repos.AppRepository.Create(ctx, app))
+
+	scanner := &scheduledScanRecorder{err: errors.New("application broken has no runtime")}
+	scheduleService := services.NewScheduleService(repos, scanner)
+	created, err := scheduleService.CreateSchedule(ctx, app.ID.String(), model.CreateScanScheduleRequest{Cron: "@daily"})
+	require.NoError(t, err)
+
+	stored, err := repos.ScanScheduleRepository.GetByID(ctx, uuid.MustParse(created.ID))
+	require.NoError(t, err)
+	past := time.Now().UTC().Add(-time.Minute)
+	stored.NextRunAt = &past
+	require.NoError(t, repos.ScanScheduleRepository.Update(ctx, stored))
+
+	ran, err := scheduleService.RunDueSchedules(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, ran)
+
+	listed, err := scheduleService.ListSchedules(ctx, app.ID.String())
+	require.NoError(t, err)
+	require.Len(t, listed.Schedules, 1)
+	assert.Equal(t, "application broken has no runtime", listed.Schedules[0].LastError)
+	assert.Empty(t, listed.Schedules[0].LastScanID)
+}
+
+func TestScheduleService_UpdateAndDelete(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+	app := &entity.App{ID: uuid.New(), Name: "weekly", Status: "active"}
+	require.NoError(t, repos.AppRepository.Create(ctx, app))
+	other := &entity.App{ID: uuid.New(), Name: "other", Status: "active"}
+	require.NoError(t, repos.AppRepository.Create(ctx, other))
+	scheduleService := services.NewScheduleService(repos, &scheduledScanRecorder{})
+
+	_, err := scheduleService.CreateSchedule(ctx, app.ID.String(), model.CreateScanScheduleRequest{Cron: "every monday"})
+	assert.ErrorIs(t, err, services.ErrInvalidInput)
+	_, err = scheduleService.CreateSchedule(ctx, app.ID.String(), model.CreateScanScheduleRequest{Cron: "0 0 30 2 *"})
+	assert.ErrorIs(t, err, services.ErrInvalidInput, "a schedule that never runs is rejected")
+	_, err = scheduleService.CreateSchedule(ctx, uuid.NewString(), model.CreateScanScheduleRequest{Cron: "@daily"})
+	assert.ErrorIs(t, err, services.ErrNotFound)
+
+	created, err := scheduleService.CreateSchedule(ctx, app.ID.String(), model.CreateScanScheduleRequest{Cron: "@daily"})
+	require.NoError(t, err)
+
+	disabled := false
+	updated, err := scheduleService.UpdateSchedule(ctx, app.ID.String(), created.ID, model.UpdateScanScheduleRequest{Enabled: &disabled})
+	require.NoError(t, err)
+	assert.False(t, updated.Enabled)
+	assert.Nil(t, updated.NextRunAt)
+	assert.Equal(t, "@daily", updated.Cron)
+
+	_, err = scheduleService.UpdateSchedule(ctx, other.ID.String(), created.ID, model.UpdateScanScheduleRequest{Enabled: &disabled})
+	assert.ErrorIs(t, err, services.ErrNotFound, "a schedule is only reachable through its own application")
+
+	require.NoError(t, scheduleService.DeleteSchedule(ctx, app.ID.String(), created.ID))
+	listed, err := scheduleService.ListSchedules(ctx, app.ID.String())
+	require.NoError(t, err)
+	assert.Empty(t, listed.Schedules)
+}
@@ -0,0 +1,51 @@
+package services_test
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+	"elang-backend/internal/services"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeedService_SeedIsIdempotent(t *testing.T) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+	// A framework seeded before frameworks were associated with runtimes, with other casing
+	require.NoError(t, repos.FrameWorkRepository.Create(ctx, &entity.Framework{Name: "gin"}))
+	seeder := services.NewSeedService(repos)
+
+	status, err := seeder.SeedStatus(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, services.SeedRuntimes, status.MissingRuntimes)
+	assert.Len(t, status.MissingFrameworks, len(services.SeedFrameworks)-1)
+	assert.Equal(t, []string{"gin"}, status.UnassignedFrameworks)
+
+	first, err := seeder.Seed(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, services.SeedRuntimes, first.CreatedRuntimes)
+	assert.Empty(t, first.ExistingRuntimes)
+	assert.Len(t, first.CreatedFrameworks, len(services.SeedFrameworks)-1)
+	assert.Equal(t, []string{"Gin"}, first.LinkedFrameworks)
+
+	second, err := seeder.Seed(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, second.CreatedRuntimes)
+	assert.Empty(t, second.CreatedFrameworks)
+	assert.Empty(t, second.LinkedFrameworks)
+	assert.Equal(t, services.SeedRuntimes, second.ExistingRuntimes)
+	assert.Len(t, second.ExistingFrameworks, len(services.SeedFrameworks))
+
+	status, err = seeder.SeedStatus(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, status.MissingRuntimes)
+	assert.Empty(t, status.MissingFrameworks)
+	assert.Empty(t, status.UnassignedFrameworks)
+	for _, runtime := range status.Runtimes {
+		if runtime.Name == "Go" {
+			assert.Equal(t, []string{"Echo", "gin"}, runtime.Frameworks)
+		}
+	}
+}
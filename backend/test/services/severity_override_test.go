@@ -0,0 +1,108 @@
+package services_test
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/model"
+	"elang-backend/internal/repository"
+	"elang-backend/internal/services"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeverityOverride_EscalatesMediumToHigh(t *testing.T) {
+	scanner := helper.ScannerConfig{Sources: []helper.VulnerabilitySource{&versionedSource{vulns: map[string][]helper.VulnerabilityInfo{
+		"github.com/gin-gonic/gin@1.9.1": {{ID: "GHSA-rce", CVE: "CVE-2024-0042", Severity: helper.SeverityMedium, Cwes: []string{"CWE-94"}, Score: 5.5}},
+	}}}}
+	goMod := "module example.com/demo\n\ngo 1.21\n\nrequire github.com/gin-gonic/gin v1.9.1\n"
+
+	repos := setupScanTestRepos(t)
+	overrideService := services.NewSeverityOverrideService(repos)
+	depService := services.NewDependenciesService(repos, *helper.NewDependencyParser(), nil, services.ServiceConfig{Scanner: scanner})
+	t.Cleanup(func() { _ = depService.Shutdown(context.Background()) })
+	acme := repository.WithOwnerScope(context.Background(), "acme")
+
+	override, err := overrideService.CreateSeverityOverride(acme, model.CreateSeverityOverrideRequest{
+		CWE: "94", Severity: "HIGH", Reason: "code injection is remotely exploitable here",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "CWE-94", override.CWE)
+	assert.Equal(t, "high", override.Severity)
+
+	scan := func(t *testing.T, ctx context.Context) model.ScanApplicationResult {
+		result, err := depService.ScanDependencies(ctx, "demo", "go", "1.0.0", "", "go.mod", goMod)
+		require.NoError(t, err)
+		return result.(model.ScanApplicationResult)
+	}
+
+	result := scan(t, acme)
+	require.Len(t, result.Findings, 1)
+	finding := result.Findings[0]
+	assert.Equal(t, "high", finding.Severity)
+	assert.Equal(t, "medium", finding.OriginalSeverity)
+	assert.Equal(t, []string{override.ID}, finding.SeverityOverrideIDs)
+	assert.Equal(t, 1, result.Summary.High)
+	assert.Zero(t, result.Summary.Medium)
+	assert.Equal(t, "fail", result.Policies.Status, "the default policy fails on the escalated severity")
+
+	t.Run("OtherOwner", func(t *testing.T) {
+		result := scan(t, repository.WithOwnerScope(context.Background(), "globex"))
+		require.Len(t, result.Findings, 1)
+		assert.Equal(t, "medium", result.Findings[0].Severity)
+		assert.Empty(t, result.Findings[0].OriginalSeverity)
+		assert.Equal(t, "pass", result.Policies.Status)
+
+		err := overrideService.DeleteSeverityOverride(repository.WithOwnerScope(context.Background(), "globex"), override.ID)
+		assert.ErrorIs(t, err, services.ErrNotFound)
+	})
+
+	t.Run("ApplicationOverrideComesFirst", func(t *testing.T) {
+		ctx := context.Background()
+		runtime := &entity.Runtime{Name: "Go"}
+		require.NoError(t, repos.RunTimeRepository.Create(ctx, runtime))
+		owner := "acme"
+		app := &entity.App{ID: uuid.New(), Name: "override-app", RuntimeID: &runtime.ID, Status: "active", OwnerID: &owner}
+		require.NoError(t, repos.AppRepository.Create(ctx, app))
+		dep := &entity.Dependency{ID: uuid.New(), Name: "github.com/gin-gonic/gin", Owner: "gin-gonic", Repo: "gin"}
+		require.NoError(t, repos.DepedencyRepository.Create(ctx, dep))
+		require.NoError(t, repos.AppToDepedencyRepository.Create(ctx, &entity.AppDependency{ID: uuid.New(), AppID: app.ID, DependencyID: dep.ID, UsedVersion: "1.9.1"}))
+
+		appOverride, err := overrideService.CreateSeverityOverride(acme, model.CreateSeverityOverrideRequest{
+			AppID: app.ID.String(), CVEPattern: "cve-2024-*", Ecosystem: "go", Severity: "low",
+		})
+		require.NoError(t, err)
+		listed, err := overrideService.ListSeverityOverrides(acme, app.ID.String())
+		require.NoError(t, err)
+		require.Len(t, listed.Overrides, 2)
+		assert.Equal(t, appOverride.ID, listed.Overrides[0].ID)
+		assert.Equal(t, override.ID, listed.Overrides[1].ID)
+
+		appService := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, offlineGitHubAPI{}, services.ServiceConfig{Scanner: scanner})
+		t.Cleanup(func() { _ = appService.Shutdown(context.Background()) })
+		resp, err := appService.ScanApplicationDependencies(acme, app.ID.String())
+		require.NoError(t, err)
+		result := resp.(model.ScanApplicationResult)
+		require.Len(t, result.Findings, 1)
+		assert.Equal(t, "low", result.Findings[0].Severity)
+		assert.Equal(t, "medium", result.Findings[0].OriginalSeverity)
+		assert.Equal(t, []string{appOverride.ID}, result.Findings[0].SeverityOverrideIDs)
+		assert.Equal(t, 1, result.Summary.Low)
+	})
+
+	t.Run("InvalidInput", func(t *testing.T) {
+		for _, req := range []model.CreateSeverityOverrideRequest{
+			{Severity: "high"},
+			{CWE: "injection", Severity: "high"},
+			{CVEPattern: "CVE-[", Severity: "high"},
+			{Ecosystem: "cobol", Severity: "high"},
+			{CWE: "CWE-79", Severity: "none"},
+		} {
+			_, err := overrideService.CreateSeverityOverride(acme, req)
+			assert.ErrorIs(t, err, services.ErrInvalidInput, "%+v", req)
+		}
+	})
+}
@@ -0,0 +1,85 @@
+package services_test
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/services"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// useNonUTCLocalTime makes time.Now() report a +07:00 offset for the duration of the test
+func useNonUTCLocalTime(t *testing.T) {
+	t.Helper()
+	previous := time.Local
+	time.Local = time.FixedZone("WIB", 7*60*60)
+	t.Cleanup(func() { time.Local = previous })
+}
+
+// assertUTCTimestamp checks that a JSON encoded timestamp is RFC3339 with the Z suffix
+func assertUTCTimestamp(t *testing.T, value interface{}, field string) {
+	t.Helper()
+	encoded, err := json.Marshal(value)
+	require.NoError(t, err)
+	var text string
+	require.NoError(t, json.Unmarshal(encoded, &text), "%s is not a string timestamp: %s", field, encoded)
+	_, err = time.Parse(time.RFC3339, text)
+	require.NoError(t, err, "%s is not RFC3339: %s", field, text)
+	assert.Regexp(t, `Z$`, text, "%s is not UTC", field)
+}
+
+func TestApplicationService_GetApplicationStatus_ReturnsUTC(t *testing.T) {
+	useNonUTCLocalTime(t)
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+
+	app := &entity.App{ID: uuid.New(), Name: "billing", Status: "active", UpdatedAt: time.Date(2024, 3, 1, 9, 30, 0, 0, time.Local)}
+	require.NoError(t, repos.AppRepository.Create(ctx, app))
+
+	appService := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, offlineGitHubAPI{}, services.ServiceConfig{})
+	t.Cleanup(func() { _ = appService.Shutdown(context.Background()) })
+
+	status, err := appService.GetApplicationStatus(ctx, app.ID.String())
+	require.NoError(t, err)
+	encoded, err := json.Marshal(status)
+	require.NoError(t, err)
+	var decoded struct {
+		Status struct {
+			LastUpdated string `json:"last_updated"`
+		} `json:"status"`
+	}
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	assertUTCTimestamp(t, decoded.Status.LastUpdated, "last_updated")
+	assert.Equal(t, "2024-03-01T02:30:00Z", decoded.Status.LastUpdated)
+}
+
+func TestDependenciesService_GetMonitoringStatus_ReturnsUTC(t *testing.T) {
+	useNonUTCLocalTime(t)
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+
+	runtime := &entity.Runtime{ID: 1, Name: "Go"}
+	require.NoError(t, repos.RunTimeRepository.Create(ctx, runtime))
+	app := &entity.App{ID: uuid.New(), Name: "billing", Status: "active", RuntimeID: &runtime.ID}
+	require.NoError(t, repos.AppRepository.Create(ctx, app))
+
+	depService := services.NewDependenciesService(repos, *helper.NewDependencyParser(), nil, services.ServiceConfig{})
+	t.Cleanup(func() { _ = depService.Shutdown(context.Background()) })
+	require.NoError(t, depService.StartMonitoringApplication(ctx, app.ID.String()))
+
+	var status map[string]interface{}
+	require.Eventually(t, func() bool {
+		var err error
+		status, err = depService.GetMonitoringStatus(ctx, app.ID.String())
+		return err == nil && status["monitoring"] == true
+	}, time.Second, 10*time.Millisecond)
+
+	assertUTCTimestamp(t, status["started_at"], "started_at")
+	assertUTCTimestamp(t, status["last_checked"], "last_checked")
+}
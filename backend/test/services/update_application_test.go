@@ -0,0 +1,99 @@
+package services_test
+
+import (
+	"context"
+	"elang-backend/internal/entity"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/model"
+	"elang-backend/internal/model/dto"
+	"elang-backend/internal/services"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupUpdateApplicationTest(t *testing.T) (context.Context, dto.BasicRepositories, services.ApplicationInterface, *entity.App, *entity.App) {
+	ctx := context.Background()
+	repos := setupScanTestRepos(t)
+	seedRuntimeAndFramework(t, ctx, repos.RunTimeRepository, repos.FrameWorkRepository)
+	require.NoError(t, repos.RunTimeRepository.Create(ctx, &entity.Runtime{Name: "Python"}))
+	require.NoError(t, repos.FrameWorkRepository.Create(ctx, &entity.Framework{Name: "Django"}))
+
+	goRuntime, err := repos.RunTimeRepository.GetByNameCI(ctx, "Go")
+	require.NoError(t, err)
+	gin, err := repos.FrameWorkRepository.GetByNameCI(ctx, "Gin")
+	require.NoError(t, err)
+
+	app := &entity.App{ID: uuid.New(), Name: "billing", RuntimeID: &goRuntime.ID, FrameworkID: &gin.ID, Status: "active"}
+	other := &entity.App{ID: uuid.New(), Name: "payments", RuntimeID: &goRuntime.ID, FrameworkID: &gin.ID, Status: "active"}
+	require.NoError(t, repos.AppRepository.Create(ctx, app))
+	require.NoError(t, repos.AppRepository.Create(ctx, other))
+
+	appService := services.NewApplicationService(repos, *helper.NewDependencyParser(), nil, offlineGitHubAPI{}, services.ServiceConfig{})
+	t.Cleanup(func() { _ = appService.Shutdown(context.Background()) })
+	return ctx, repos, appService, app, other
+}
+
+func strPtr(s string) *string { return &s }
+
+func TestApplicationService_UpdateApplication(t *testing.T) {
+	ctx, repos, appService, app, _ := setupUpdateApplicationTest(t)
+
+	resp, err := appService.UpdateApplication(ctx, app.ID.String(), &model.UpdateApplicationRequest{
+		AppName:     strPtr("billing-v2"),
+		Description: strPtr("Invoices and receipts"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "billing-v2", resp.AppName)
+	assert.Equal(t, "Invoices and receipts", resp.Description)
+	assert.Equal(t, "Go", resp.RuntimeType)
+	assert.Equal(t, "Gin", resp.Framework)
+	assert.False(t, resp.RescanRecommended)
+
+	audits, err := repos.AuditTrailRepository.GetByEntity(ctx, "app", app.ID, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, audits, 1)
+	assert.Equal(t, "application_updated", audits[0].Action)
+	assert.Contains(t, string(audits[0].OldValues), `"app_name":"billing"`)
+	assert.Contains(t, string(audits[0].NewValues), `"app_name":"billing-v2"`)
+}
+
+func TestApplicationService_UpdateApplication_RuntimeChangeRecommendsRescan(t *testing.T) {
+	ctx, _, appService, app, _ := setupUpdateApplicationTest(t)
+
+	resp, err := appService.UpdateApplication(ctx, app.ID.String(), &model.UpdateApplicationRequest{
+		RuntimeType: strPtr("python"),
+		Framework:   strPtr("django"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Python", resp.RuntimeType)
+	assert.Equal(t, "Django", resp.Framework)
+	assert.True(t, resp.RescanRecommended)
+	assert.NotEmpty(t, resp.Warnings)
+}
+
+func TestApplicationService_UpdateApplication_Errors(t *testing.T) {
+	ctx, _, appService, app, other := setupUpdateApplicationTest(t)
+
+	tests := []struct {
+		name    string
+		appUID  string
+		req     model.UpdateApplicationRequest
+		wantErr error
+	}{
+		{"duplicate name", app.ID.String(), model.UpdateApplicationRequest{AppName: strPtr(other.Name)}, services.ErrConflict},
+		{"empty name", app.ID.String(), model.UpdateApplicationRequest{AppName: strPtr("  ")}, services.ErrInvalidInput},
+		{"unknown runtime", app.ID.String(), model.UpdateApplicationRequest{RuntimeType: strPtr("Cobol")}, services.ErrInvalidInput},
+		{"unknown framework", app.ID.String(), model.UpdateApplicationRequest{Framework: strPtr("Rails")}, services.ErrInvalidInput},
+		{"invalid app ID", "not-a-uuid", model.UpdateApplicationRequest{}, services.ErrInvalidInput},
+		{"missing app", uuid.NewString(), model.UpdateApplicationRequest{}, services.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := appService.UpdateApplication(ctx, tt.appUID, &tt.req)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package usecase_test
+
+import (
+	"context"
+	"elang-backend/internal/helper"
+	"elang-backend/internal/helper/parser"
+	"elang-backend/internal/usecase"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const advisoryResponse = `{"data": {"securityVulnerabilities": {
+  "nodes": [
+    {"vulnerableVersionRange": "< 4.17.21", "firstPatchedVersion": {"identifier": "4.17.21"},
+     "advisory": {"ghsaId": "GHSA-35jh-r3h4-6jhm", "summary": "Command Injection in lodash", "severity": "HIGH",
+       "permalink": "https://github.com/advisories/GHSA-35jh-r3h4-6jhm",
+       "identifiers": [{"type": "GHSA", "value": "GHSA-35jh-r3h4-6jhm"}, {"type": "CVE", "value": "CVE-2021-23337"}],
+       "cvss": {"score": 7.2, "vectorString": "CVSS:3.1/AV:N/AC:L/PR:H/UI:N/S:U/C:H/I:H/A:H"},
+       "cwes": {"nodes": [{"cweId": "CWE-94"}]}, "references": [{"url": "https://nvd.nist.gov/vuln/detail/CVE-2021-23337"}]}},
+    {"vulnerableVersionRange": "< 4.17.12", "firstPatchedVersion": {"identifier": "4.17.12"},
+     "advisory": {"ghsaId": "GHSA-jf85-cpcp-j695", "summary": "Prototype Pollution in lodash", "severity": "CRITICAL",
+       "identifiers": [{"type": "CVE", "value": "CVE-2019-10744"}], "cvss": {"score": 9.1}}},
+    {"vulnerableVersionRange": "< 5.0.0", "firstPatchedVersion": null,
+     "advisory": {"ghsaId": "GHSA-wwww-xxxx-yyyy", "summary": "Withdrawn", "severity": "MODERATE", "withdrawnAt": "2024-01-01T00:00:00Z"}}
+  ],
+  "pageInfo": {"hasNextPage": false, "endCursor": "Y3Vyc29y"}
+}}}`
+
+func advisorySourceForServer(t *testing.T, token string, handler http.HandlerFunc) *usecase.GitHubAdvisorySource {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	target, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	api := usecase.NewGitHubAPIusecase(token).(*usecase.GithubAPIusecase)
+	api.HTTPClient = &http.Client{Transport: redirectToServer{target: target}}
+	return usecase.NewGitHubAdvisorySource(api)
+}
+
+func TestGitHubAdvisorySource_QueryVulnerabilities(t *testing.T) {
+	source := advisorySourceForServer(t, "test-token", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/graphql", r.URL.Path)
+		assert.Equal(t, "bearer test-token", r.Header.Get("Authorization"))
+
+		var body struct {
+			Variables map[string]interface{} `json:"variables"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "NPM", body.Variables["ecosystem"])
+		assert.Equal(t, "lodash", body.Variables["package"])
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(advisoryResponse))
+	})
+
+	vulns, err := source.QueryVulnerabilities(context.Background(), parser.DependencyInfo{Name: "lodash", Version: "4.17.20", Runtime: "node"})
+	require.NoError(t, err)
+
+	// 4.17.20 is outside the second advisory's range and the third one was withdrawn
+	require.Len(t, vulns, 1)
+	vuln := vulns[0]
+	assert.Equal(t, "GHSA-35jh-r3h4-6jhm", vuln.ID)
+	assert.Equal(t, "CVE-2021-23337", vuln.CVE)
+	assert.Equal(t, []string{"CVE-2021-23337"}, vuln.Aliases)
+	assert.Equal(t, helper.SeverityHigh, vuln.Severity)
+	assert.Equal(t, 7.2, vuln.Score)
+	assert.Equal(t, []string{"4.17.21"}, vuln.PatchedVersions)
+	assert.Equal(t, []string{"CWE-94"}, vuln.Cwes)
+	assert.Equal(t, "github", vuln.Source)
+}
+
+func TestGitHubAdvisorySource_Errors(t *testing.T) {
+	lodash := parser.DependencyInfo{Name: "lodash", Version: "4.17.20", Runtime: "node"}
+
+	t.Run("RequiresToken", func(t *testing.T) {
+		source := advisorySourceForServer(t, "", func(w http.ResponseWriter, r *http.Request) {
+			t.Error("no request expected without a token")
+		})
+		_, err := source.QueryVulnerabilities(context.Background(), lodash)
+		assert.ErrorContains(t, err, "requires a GitHub token")
+	})
+
+	t.Run("GraphQLErrors", func(t *testing.T) {
+		source := advisorySourceForServer(t, "test-token", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"data": null, "errors": [{"message": "API rate limit exceeded"}]}`))
+		})
+		_, err := source.QueryVulnerabilities(context.Background(), lodash)
+		assert.ErrorContains(t, err, "API rate limit exceeded")
+	})
+
+	t.Run("UnsupportedRuntime", func(t *testing.T) {
+		source := advisorySourceForServer(t, "test-token", func(w http.ResponseWriter, r *http.Request) {})
+		_, err := source.QueryVulnerabilities(context.Background(), parser.DependencyInfo{Name: "x", Version: "1", Runtime: "cobol"})
+		assert.Error(t, err)
+	})
+}
@@ -1,12 +1,17 @@
 package usecase_test
 
 import (
+	"elang-backend/internal/helper"
 	"elang-backend/internal/model"
 	"elang-backend/internal/usecase"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -106,7 +111,7 @@ func (g *testGitHubAPIUsecase) GetDefaultBranch(owner, repo string) (string, err
 	return result.Data.Repository.DefaultBranchRef.Name, nil
 }
 
-func (g *testGitHubAPIUsecase) GetListCommits(owner, repo, branch string) ([]map[string]interface{}, error) {
+func (g *testGitHubAPIUsecase) GetListCommits(owner, repo, branch string, limit int) ([]map[string]interface{}, error) {
 	return nil, nil
 }
 
@@ -122,6 +127,10 @@ func (g *testGitHubAPIUsecase) GetRepoInfo(owner, repo string) (map[string]inter
 	return nil, nil
 }
 
+func (g *testGitHubAPIUsecase) GetRepoInfoIfModified(owner, repo string, since usecase.GitHubValidators) (map[string]interface{}, usecase.GitHubValidators, error) {
+	return nil, usecase.GitHubValidators{}, nil
+}
+
 func (g *testGitHubAPIUsecase) ListBranches(owner, repo string) ([]string, error) {
 	return nil, nil
 }
@@ -166,6 +175,10 @@ func (g *testGitHubAPIUsecase) CompareCommits(owner, repo, base, head string) (*
 	return nil, nil
 }
 
+func (g *testGitHubAPIUsecase) ListReleases(owner, repo string) ([]model.Release, error) {
+	return nil, nil
+}
+
 func (g *testGitHubAPIUsecase) FindMatchingTag(owner, repo, version string) (string, error) {
 	return "", nil
 }
@@ -176,3 +189,340 @@ func TestGitHubAPIInterface(t *testing.T) {
 		require.True(t, true, "testGitHubAPIUsecase implements GitHubAPIInterface")
 	})
 }
+
+// redirectToServer sends every outbound request to the test server, keeping path and query
+type redirectToServer struct {
+	target *url.URL
+}
+
+func (r redirectToServer) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = r.target.Scheme
+	req.URL.Host = r.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestGitHubAPIUsecase_GetRepoInfo_FollowsRepositoryRedirect(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/old-owner/old-repo":
+			// GitHub answers requests for renamed/transferred repositories with a 301 to the repository ID
+			http.Redirect(w, r, "/repositories/42", http.StatusMovedPermanently)
+		case "/repositories/42":
+			assert.Equal(t, "token test-token", r.Header.Get("Authorization"))
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"id":        42,
+				"name":      "new-repo",
+				"full_name": "new-owner/new-repo",
+				"owner":     map[string]interface{}{"login": "new-owner"},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	target, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	api := usecase.NewGitHubAPIusecase("test-token")
+	api.(*usecase.GithubAPIusecase).HTTPClient = &http.Client{Transport: redirectToServer{target: target}}
+
+	info, err := api.GetRepoInfo("old-owner", "old-repo")
+	require.NoError(t, err)
+
+	canonical, ok := helper.CanonicalRepoFromInfo(info)
+	require.True(t, ok)
+	assert.Equal(t, "new-owner", canonical.Owner)
+	assert.Equal(t, "new-repo", canonical.Repo)
+	assert.Equal(t, "https://github.com/new-owner/new-repo", canonical.URL())
+}
+
+func TestGitHubAPIUsecase_UsesOutboundProxy(t *testing.T) {
+	var proxiedHosts []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedHosts = append(proxiedHosts, r.Host)
+		http.Error(w, "egress denied", http.StatusForbidden)
+	}))
+	defer proxy.Close()
+
+	transport, err := helper.NewOutboundTransport(helper.OutboundHTTPConfig{ProxyURL: proxy.URL})
+	require.NoError(t, err)
+
+	api := usecase.NewGitHubAPIusecase("")
+	api.(*usecase.GithubAPIusecase).HTTPClient.Transport = transport
+	_, err = api.GetDefaultBranch("octocat", "hello-world")
+
+	assert.Error(t, err)
+	assert.Equal(t, []string{"api.github.com:443"}, proxiedHosts)
+}
+
+func TestGitHubAPIUsecase_EnterpriseEndpoints(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v3/repos/platform/billing":
+			assert.Equal(t, "token test-token", r.Header.Get("Authorization"))
+			_, _ = w.Write([]byte(`{"name": "billing", "full_name": "platform/billing", "default_branch": "trunk"}`))
+		case "/api/graphql":
+			assert.Equal(t, "bearer test-token", r.Header.Get("Authorization"))
+			_, _ = w.Write([]byte(`{"data": {"repository": {"defaultBranchRef": {"name": "trunk"}}}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	api := usecase.NewGitHubAPIusecaseWithEndpoints(usecase.NewStaticTokenProvider("test-token"), usecase.GitHubEndpoints{REST: server.URL + "/api/v3/"})
+
+	info, err := api.GetRepoInfo("platform", "billing")
+	require.NoError(t, err)
+	assert.Equal(t, "platform/billing", info["full_name"])
+
+	branch, err := api.GetDefaultBranch("platform", "billing")
+	require.NoError(t, err)
+	assert.Equal(t, "trunk", branch)
+
+	assert.Equal(t, []string{"GET /api/v3/repos/platform/billing", "POST /api/graphql"}, paths)
+}
+
+func TestGitHubEndpoints(t *testing.T) {
+	public := usecase.GitHubEndpoints{}
+	assert.Equal(t, "https://api.github.com", public.RESTURL())
+	assert.Equal(t, "https://api.github.com/graphql", public.GraphQLURL())
+	assert.Equal(t, "github.com", public.WebHost())
+
+	enterprise := usecase.GitHubEndpoints{REST: "https://github.example.com/api/v3"}
+	assert.Equal(t, "https://github.example.com/api/graphql", enterprise.GraphQLURL())
+	assert.Equal(t, "github.example.com", enterprise.WebHost())
+
+	custom := usecase.GitHubEndpoints{REST: "https://github.example.com/api/v3", GraphQL: "https://graphql.example.com/"}
+	assert.Equal(t, "https://graphql.example.com/", custom.GraphQLURL())
+}
+
+func TestGitHubAPIUsecase_GetListCommits_PagesREST(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/octocat/hello-world/commits", r.URL.Path)
+		queries = append(queries, r.URL.RawQuery)
+		// The branch has 130 commits, c0 being the newest
+		perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		commits := []map[string]interface{}{}
+		for i := (page - 1) * perPage; i < min(page*perPage, 130); i++ {
+			commits = append(commits, map[string]interface{}{"sha": fmt.Sprintf("c%d", i)})
+		}
+		_ = json.NewEncoder(w).Encode(commits)
+	}))
+	defer server.Close()
+	api := usecase.NewGitHubAPIusecaseWithEndpoints(usecase.NewStaticTokenProvider(""), usecase.GitHubEndpoints{REST: server.URL})
+
+	commits, err := api.GetListCommits("octocat", "hello-world", "main", 150)
+	require.NoError(t, err)
+	assert.Len(t, commits, 130)
+	assert.Equal(t, "c0", commits[0]["oid"])
+	assert.Equal(t, "c129", commits[129]["oid"])
+	assert.Equal(t, []string{"sha=main&per_page=100&page=1", "sha=main&per_page=100&page=2"}, queries)
+
+	queries = nil
+	commits, err = api.GetListCommits("octocat", "hello-world", "main", 120)
+	require.NoError(t, err)
+	assert.Len(t, commits, 120, "the last page is trimmed to the limit")
+	assert.Equal(t, "c119", commits[119]["oid"])
+
+	queries = nil
+	commits, err = api.GetListCommits("octocat", "hello-world", "main", 0)
+	require.NoError(t, err)
+	assert.Len(t, commits, usecase.DefaultCommitListLimit)
+	assert.Equal(t, []string{"sha=main&per_page=10&page=1"}, queries)
+}
+
+func TestGitHubAPIUsecase_GetListCommits_PagesGraphQL(t *testing.T) {
+	var requests []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/graphql", r.URL.Path)
+		var body struct {
+			Variables map[string]interface{} `json:"variables"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		requests = append(requests, body.Variables)
+
+		first := int(body.Variables["first"].(float64))
+		page := len(requests)
+		edges := make([]map[string]interface{}, first)
+		for i := range edges {
+			edges[i] = map[string]interface{}{"node": map[string]interface{}{"oid": fmt.Sprintf("p%d-%d", page, i)}}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"repository": map[string]interface{}{
+			"ref": map[string]interface{}{"target": map[string]interface{}{"history": map[string]interface{}{
+				"edges":    edges,
+				"pageInfo": map[string]interface{}{"hasNextPage": true, "endCursor": fmt.Sprintf("cursor-%d", page)},
+			}}},
+		}}})
+	}))
+	defer server.Close()
+	api := usecase.NewGitHubAPIusecaseWithEndpoints(usecase.NewStaticTokenProvider("test-token"), usecase.GitHubEndpoints{REST: server.URL})
+
+	commits, err := api.GetListCommits("octocat", "hello-world", "main", 150)
+	require.NoError(t, err)
+	assert.Len(t, commits, 150)
+	assert.Equal(t, "p2-49", commits[149]["oid"])
+
+	require.Len(t, requests, 2)
+	assert.Equal(t, float64(100), requests[0]["first"])
+	assert.Nil(t, requests[0]["cursor"])
+	assert.Equal(t, "main", requests[0]["ref"])
+	assert.Equal(t, float64(50), requests[1]["first"])
+	assert.Equal(t, "cursor-1", requests[1]["cursor"])
+}
+
+func TestGitHubAPIUsecase_GraphQLErrors(t *testing.T) {
+	// GitHub reports GraphQL errors with HTTP 200 and no data
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/graphql", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": null, "errors": [{"type": "RATE_LIMITED", "message": "API rate limit exceeded for user ID 1."}]}`))
+	}))
+	defer server.Close()
+	api := usecase.NewGitHubAPIusecaseWithEndpoints(usecase.NewStaticTokenProvider("test-token"), usecase.GitHubEndpoints{REST: server.URL})
+
+	branch, err := api.GetDefaultBranch("octocat", "hello-world")
+	require.Error(t, err)
+	assert.Empty(t, branch)
+	var graphQLErrors usecase.GraphQLErrors
+	require.ErrorAs(t, err, &graphQLErrors)
+	assert.True(t, graphQLErrors.HasType("RATE_LIMITED"))
+	assert.ErrorContains(t, err, "RATE_LIMITED: API rate limit exceeded for user ID 1.")
+
+	commits, err := api.GetListCommits("octocat", "hello-world", "main", 10)
+	assert.ErrorAs(t, err, &graphQLErrors)
+	assert.Empty(t, commits)
+}
+
+func TestGitHubAPIUsecase_ListReleases_SkipsDrafts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/google/uuid/releases", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[
+			{"tag_name": "v1.3.0", "name": "v1.3.0", "body": "Work in progress", "draft": true},
+			{"tag_name": "v1.2.0", "name": "v1.2.0", "body": "Bug fixes", "html_url": "https://github.com/google/uuid/releases/tag/v1.2.0", "published_at": "2024-01-02T00:00:00Z"}
+		]`))
+	}))
+	defer server.Close()
+
+	api := usecase.NewGitHubAPIusecaseWithEndpoints(usecase.NewStaticTokenProvider(""), usecase.GitHubEndpoints{REST: server.URL})
+
+	releases, err := api.ListReleases("google", "uuid")
+	require.NoError(t, err)
+	require.Len(t, releases, 1)
+	assert.Equal(t, "v1.2.0", releases[0].TagName)
+	assert.Equal(t, "Bug fixes", releases[0].Body)
+}
+
+// rateLimitedServer answers like GitHub once the caller's rate limit is used up, counting the requests it receives
+func rateLimitedServer(t *testing.T, requests *int) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		w.Header().Set("X-RateLimit-Limit", "60")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(30*time.Minute).Unix(), 10))
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message": "API rate limit exceeded for 203.0.113.7."}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGitHubAPIUsecase_AnonymousRateLimit(t *testing.T) {
+	requests := 0
+	server := rateLimitedServer(t, &requests)
+	api := usecase.NewGitHubAPIusecaseWithEndpoints(usecase.NewStaticTokenProvider(""), usecase.GitHubEndpoints{REST: server.URL})
+
+	_, err := api.GetRepoInfo("google", "uuid")
+	require.ErrorIs(t, err, usecase.ErrGitHubAnonymousRateLimit)
+	assert.Contains(t, err.Error(), "GitHub token not configured")
+
+	// Later requests fail the same way without being sent until the limit resets
+	_, err = api.ListTags("google", "uuid")
+	assert.ErrorIs(t, err, usecase.ErrGitHubAnonymousRateLimit)
+	_, err = api.ListCollaborators("google", "uuid")
+	assert.ErrorIs(t, err, usecase.ErrGitHubAnonymousRateLimit)
+	assert.Equal(t, 1, requests)
+}
+
+func TestGitHubAPIUsecase_AuthenticatedRateLimitIsNotAnonymous(t *testing.T) {
+	requests := 0
+	server := rateLimitedServer(t, &requests)
+	api := usecase.NewGitHubAPIusecaseWithEndpoints(usecase.NewStaticTokenProvider("test-token"), usecase.GitHubEndpoints{REST: server.URL})
+
+	_, err := api.GetRepoInfo("google", "uuid")
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, usecase.ErrGitHubAnonymousRateLimit)
+	_, err = api.GetRepoInfo("google", "uuid")
+	require.Error(t, err)
+	assert.Equal(t, 2, requests)
+}
+
+// etagServer serves a repository like GitHub: every response carries an ETag and Last-Modified, and a request
+// whose If-None-Match or If-Modified-Since still matches is answered with a bodyless 304
+func etagServer(t *testing.T, conditional *int) (*httptest.Server, *string) {
+	t.Helper()
+	stars := "10"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/google/uuid" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		etag := fmt.Sprintf(`"stars-%s"`, stars)
+		lastModified := "Mon, 12 Oct 2026 10:00:00 GMT"
+		if stars != "10" {
+			lastModified = "Thu, 15 Oct 2026 10:00:00 GMT"
+		}
+		if r.Header.Get("If-None-Match") == etag || (r.Header.Get("If-None-Match") == "" && r.Header.Get("If-Modified-Since") == lastModified) {
+			*conditional++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Last-Modified", lastModified)
+		_, _ = w.Write([]byte(`{"full_name": "google/uuid", "stargazers_count": ` + stars + `}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &stars
+}
+
+func TestGitHubAPIUsecase_GetRepoInfoIfModified(t *testing.T) {
+	notModified := 0
+	server, stars := etagServer(t, &notModified)
+	api := usecase.NewGitHubAPIusecaseWithEndpoints(usecase.NewStaticTokenProvider("test-token"), usecase.GitHubEndpoints{REST: server.URL})
+
+	info, validators, err := api.GetRepoInfoIfModified("google", "uuid", usecase.GitHubValidators{})
+	require.NoError(t, err)
+	assert.Equal(t, float64(10), info["stargazers_count"])
+	assert.Equal(t, usecase.GitHubValidators{ETag: `"stars-10"`, LastModified: "Mon, 12 Oct 2026 10:00:00 GMT"}, validators)
+
+	_, same, err := api.GetRepoInfoIfModified("google", "uuid", validators)
+	assert.ErrorIs(t, err, usecase.ErrGitHubNotModified)
+	assert.Equal(t, validators, same)
+	_, _, err = api.GetRepoInfoIfModified("google", "uuid", usecase.GitHubValidators{LastModified: validators.LastModified})
+	assert.ErrorIs(t, err, usecase.ErrGitHubNotModified, "Last-Modified alone is enough")
+	assert.Equal(t, 2, notModified)
+
+	// Unconditional lookups are never answered with 304
+	_, err = api.GetRepoInfo("google", "uuid")
+	require.NoError(t, err)
+
+	// A changed repository gets new validators
+	*stars = "11"
+	changed, next, err := api.GetRepoInfoIfModified("google", "uuid", validators)
+	require.NoError(t, err)
+	assert.Equal(t, float64(11), changed["stargazers_count"])
+	assert.Equal(t, `"stars-11"`, next.ETag)
+	assert.Equal(t, 2, notModified)
+
+	_, err = api.GetRepoInfo("google", "missing")
+	assert.ErrorIs(t, err, usecase.ErrGitHubRepositoryNotFound)
+}
@@ -0,0 +1,158 @@
+package usecase_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"elang-backend/internal/usecase"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func generateAppKey(t *testing.T) (*rsa.PrivateKey, []byte) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return key, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+}
+
+// verifyAppJWT checks the RS256 signature of a GitHub App JWT and returns its claims
+func verifyAppJWT(t *testing.T, token string, key *rsa.PublicKey) map[string]interface{} {
+	t.Helper()
+	parts := strings.Split(token, ".")
+	require.Len(t, parts, 3)
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	require.NoError(t, err)
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	require.NoError(t, rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature))
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	require.NoError(t, err)
+	var claims map[string]interface{}
+	require.NoError(t, json.Unmarshal(payload, &claims))
+	return claims
+}
+
+func TestGitHubAppTokenProvider_MintsAndCachesInstallationTokens(t *testing.T) {
+	key, keyPEM := generateAppKey(t)
+
+	var minted atomic.Int32
+	expiresIn := time.Hour
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/app/installations/99/access_tokens", r.URL.Path)
+		appJWT, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		require.True(t, found)
+		claims := verifyAppJWT(t, appJWT, &key.PublicKey)
+		assert.Equal(t, "1234", claims["iss"])
+		assert.Less(t, claims["exp"].(float64)-claims["iat"].(float64), float64(11*60))
+
+		n := minted.Add(1)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"token":      fmt.Sprintf("ghs_installation_%d", n),
+			"expires_at": time.Now().Add(expiresIn).UTC().Format(time.RFC3339),
+		})
+	}))
+	defer server.Close()
+
+	provider, err := usecase.NewGitHubAppTokenProvider(1234, 99, keyPEM)
+	require.NoError(t, err)
+	provider.BaseURL = server.URL
+
+	token, err := provider.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "ghs_installation_1", token)
+
+	// Still valid for an hour: served from the cache
+	token, err = provider.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "ghs_installation_1", token)
+	assert.Equal(t, int32(1), minted.Load())
+
+	// Tokens close to expiry are renewed before use
+	expiresIn = time.Minute
+	provider2, err := usecase.NewGitHubAppTokenProvider(1234, 99, keyPEM)
+	require.NoError(t, err)
+	provider2.BaseURL = server.URL
+	first, err := provider2.Token(context.Background())
+	require.NoError(t, err)
+	second, err := provider2.Token(context.Background())
+	require.NoError(t, err)
+	assert.NotEqual(t, first, second)
+}
+
+func TestGitHubAppTokenProvider_Errors(t *testing.T) {
+	_, keyPEM := generateAppKey(t)
+
+	_, err := usecase.NewGitHubAppTokenProvider(0, 99, keyPEM)
+	assert.Error(t, err)
+	_, err = usecase.NewGitHubAppTokenProvider(1234, 99, []byte("not a key"))
+	assert.Error(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message":"Bad credentials"}`, http.StatusUnauthorized)
+	}))
+	defer server.Close()
+	provider, err := usecase.NewGitHubAppTokenProvider(1234, 99, keyPEM)
+	require.NoError(t, err)
+	provider.BaseURL = server.URL
+	_, err = provider.Token(context.Background())
+	assert.ErrorContains(t, err, "Bad credentials")
+}
+
+// sequenceTokenProvider hands out a new token on every call, like a provider whose tokens rotate
+type sequenceTokenProvider struct {
+	calls atomic.Int32
+	err   error
+}
+
+func (p *sequenceTokenProvider) Token(ctx context.Context) (string, error) {
+	if p.err != nil {
+		return "", p.err
+	}
+	return fmt.Sprintf("rotating-%d", p.calls.Add(1)), nil
+}
+
+func TestGitHubAPIUsecase_AsksTokenProviderPerRequest(t *testing.T) {
+	var authHeaders []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authHeaders = append(authHeaders, r.Header.Get("Authorization"))
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"full_name": "octocat/hello-world"})
+	}))
+	defer server.Close()
+	target, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	provider := &sequenceTokenProvider{}
+	api := usecase.NewGitHubAPIusecaseWithTokenProvider(provider)
+	api.(*usecase.GithubAPIusecase).HTTPClient = &http.Client{Transport: redirectToServer{target: target}}
+
+	for i := 0; i < 2; i++ {
+		_, err := api.GetRepoInfo("octocat", "hello-world")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []string{"token rotating-1", "token rotating-2"}, authHeaders)
+
+	// A provider that cannot produce a token fails the request instead of silently going anonymous
+	provider.err = errors.New("installation suspended")
+	_, err = api.GetRepoInfo("octocat", "hello-world")
+	assert.ErrorContains(t, err, "installation suspended")
+	assert.Len(t, authHeaders, 2)
+}
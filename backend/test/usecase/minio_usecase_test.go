@@ -3,6 +3,15 @@ package usecase_test
 import (
 	"context"
 	"elang-backend/internal/usecase"
+	"encoding/xml"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -47,6 +56,18 @@ func (m *mockMinioUsecase) ListVulnerabilityReports(ctx context.Context, appName
 	return []string{"vulnerability-reports/test-app/2024-01-01/test-app-id_vuln_report.json"}, nil
 }
 
+func (m *mockMinioUsecase) SaveManifest(ctx context.Context, appID string, fileName string, content []byte) (string, error) {
+	return "manifests/test-app-id/go.mod", nil
+}
+
+func (m *mockMinioUsecase) GetManifest(ctx context.Context, objectKey string) ([]byte, error) {
+	return []byte("module example.com/app\n"), nil
+}
+
+func (m *mockMinioUsecase) DeleteManifest(ctx context.Context, objectKey string) error {
+	return nil
+}
+
 func TestMinioUsecase_SaveSBOM(t *testing.T) {
 	ctx := context.Background()
 	mock := &mockMinioUsecase{}
@@ -122,3 +143,218 @@ func TestMinioUsecase_DeleteSBOM(t *testing.T) {
 
 	assert.NoError(t, err)
 }
+
+// fakeObjectStore is an in-memory S3 endpoint answering the requests MinioUsecase makes: bucket location
+// and existence, object uploads, copies and removals, and prefix listings
+type fakeObjectStore struct {
+	mu   sync.Mutex
+	keys map[string]bool
+}
+
+func newFakeObjectStore(t *testing.T) string {
+	endpoint, _ := newInspectableObjectStore(t)
+	return endpoint
+}
+
+// newInspectableObjectStore also returns the store, for tests looking at the keys it holds
+func newInspectableObjectStore(t *testing.T) (string, *fakeObjectStore) {
+	store := &fakeObjectStore{keys: map[string]bool{}}
+	server := httptest.NewServer(store)
+	t.Cleanup(server.Close)
+	return strings.TrimPrefix(server.URL, "http://"), store
+}
+
+// sortedKeys lists the keys the store holds
+func (s *fakeObjectStore) sortedKeys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var keys []string
+	for key := range s.keys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (s *fakeObjectStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	query := r.URL.Query()
+	_, _ = io.Copy(io.Discard, r.Body)
+
+	switch {
+	case query.Has("location"):
+		_, _ = io.WriteString(w, `<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/">us-east-1</LocationConstraint>`)
+	case r.Method == http.MethodHead && key == "":
+		w.WriteHeader(http.StatusOK)
+	case r.Method == http.MethodPut && key != "" && r.Header.Get("X-Amz-Copy-Source") != "":
+		source, _ := url.PathUnescape(r.Header.Get("X-Amz-Copy-Source"))
+		_, sourceKey, _ := strings.Cut(strings.TrimPrefix(source, "/"), "/")
+		s.mu.Lock()
+		found := s.keys[sourceKey]
+		if found {
+			s.keys[key] = true
+		}
+		s.mu.Unlock()
+		if !found {
+			http.Error(w, "no such key", http.StatusNotFound)
+			return
+		}
+		_, _ = io.WriteString(w, `<CopyObjectResult><LastModified>2024-01-01T00:00:00.000Z</LastModified><ETag>"d41d8cd98f00b204e9800998ecf8427e"</ETag></CopyObjectResult>`)
+	case r.Method == http.MethodPut && key != "":
+		s.mu.Lock()
+		s.keys[key] = true
+		s.mu.Unlock()
+		w.Header().Set("ETag", `"d41d8cd98f00b204e9800998ecf8427e"`)
+	case r.Method == http.MethodDelete && key != "":
+		s.mu.Lock()
+		delete(s.keys, key)
+		s.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodGet && key == "":
+		s.writeListing(w, bucket, query)
+	default:
+		http.Error(w, "unsupported request", http.StatusNotImplemented)
+	}
+}
+
+func (s *fakeObjectStore) writeListing(w http.ResponseWriter, bucket string, query url.Values) {
+	type object struct {
+		Key  string `xml:"Key"`
+		Size int64  `xml:"Size"`
+	}
+	listing := struct {
+		XMLName     xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListBucketResult"`
+		Name        string   `xml:"Name"`
+		Prefix      string   `xml:"Prefix"`
+		KeyCount    int      `xml:"KeyCount"`
+		MaxKeys     int      `xml:"MaxKeys"`
+		IsTruncated bool     `xml:"IsTruncated"`
+		Contents    []object `xml:"Contents"`
+	}{Name: bucket, Prefix: query.Get("prefix"), MaxKeys: 1000}
+
+	s.mu.Lock()
+	for key := range s.keys {
+		if strings.HasPrefix(key, listing.Prefix) {
+			listing.Contents = append(listing.Contents, object{Key: key, Size: 1})
+		}
+	}
+	s.mu.Unlock()
+	sort.Slice(listing.Contents, func(i, j int) bool { return listing.Contents[i].Key < listing.Contents[j].Key })
+	listing.KeyCount = len(listing.Contents)
+
+	w.Header().Set("Content-Type", "application/xml")
+	_ = xml.NewEncoder(w).Encode(listing)
+}
+
+func TestMinioUsecase_KeyPrefixIsolatesSameAppName(t *testing.T) {
+	ctx := context.Background()
+	endpoint := newFakeObjectStore(t)
+	staging, err := usecase.NewMinioUsecase(endpoint, "access", "secret", "sboms", false, "staging")
+	require.NoError(t, err)
+	production, err := usecase.NewMinioUsecase(endpoint, "access", "secret", "sboms", false, "/production/")
+	require.NoError(t, err)
+
+	stagingKey, err := staging.SaveSBOM(ctx, "scan-1", "api", []byte(`{}`), "json")
+	require.NoError(t, err)
+	productionKey, err := production.SaveSBOM(ctx, "scan-2", "api", []byte(`{}`), "json")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(stagingKey, "staging/sbom/api/"), stagingKey)
+	assert.True(t, strings.HasPrefix(productionKey, "production/sbom/api/"), productionKey)
+
+	stagingKeys, err := staging.ListSBOMs(ctx, "api")
+	require.NoError(t, err)
+	assert.Equal(t, []string{stagingKey}, stagingKeys)
+	productionKeys, err := production.ListSBOMs(ctx, "api")
+	require.NoError(t, err)
+	assert.Equal(t, []string{productionKey}, productionKeys)
+
+	_, err = staging.GetSBOM(ctx, productionKey)
+	assert.Error(t, err, "another prefix's SBOM must not be readable")
+}
+
+func TestMinioUsecase_TenantsDoNotShareSBOMs(t *testing.T) {
+	ctx := context.Background()
+	storage, err := usecase.NewMinioUsecase(newFakeObjectStore(t), "access", "secret", "sboms", false, "")
+	require.NoError(t, err)
+	alice := usecase.WithStorageTenant(ctx, "alice")
+	bob := usecase.WithStorageTenant(ctx, "bob")
+
+	aliceKey, err := storage.SaveSBOM(alice, "scan-1", "api", []byte(`{}`), "json")
+	require.NoError(t, err)
+	bobKey, err := storage.SaveSBOM(bob, "scan-2", "api", []byte(`{}`), "json")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(aliceKey, "tenants/alice/sbom/api/"), aliceKey)
+
+	aliceKeys, err := storage.ListSBOMs(alice, "api")
+	require.NoError(t, err)
+	assert.Equal(t, []string{aliceKey}, aliceKeys)
+	bobKeys, err := storage.ListSBOMs(bob, "api")
+	require.NoError(t, err)
+	assert.Equal(t, []string{bobKey}, bobKeys)
+
+	_, err = storage.GetSBOM(alice, bobKey)
+	assert.Error(t, err)
+
+	t.Run("CallerWithoutTenant", func(t *testing.T) {
+		// Every tenant's keys start with the key space of callers without a tenant, which must still not reach them
+		_, err := storage.GetSBOM(ctx, aliceKey)
+		assert.ErrorContains(t, err, "outside this tenant's storage")
+		_, err = storage.GetManifest(ctx, "tenants/alice/manifests/app-1/go.mod")
+		assert.ErrorContains(t, err, "outside this tenant's storage")
+		assert.ErrorContains(t, storage.DeleteManifest(ctx, "tenants/alice/manifests/app-1/go.mod"), "outside this tenant's storage")
+	})
+}
+
+func TestMinioUsecase_MoveLegacyObject(t *testing.T) {
+	ctx := context.Background()
+	endpoint, store := newInspectableObjectStore(t)
+	// Documents saved before keys were prefixed sit at the top of the bucket
+	legacy, err := usecase.NewMinioUsecase(endpoint, "access", "secret", "sboms", false, "")
+	require.NoError(t, err)
+	aliceKey, err := legacy.SaveSBOM(ctx, "scan-1", "api", []byte(`{}`), "json")
+	require.NoError(t, err)
+	sharedKey, err := legacy.SaveVulnerabilityReport(ctx, "scan-2", "api", []byte(`{}`), "json")
+	require.NoError(t, err)
+
+	storage, err := usecase.NewMinioUsecase(endpoint, "access", "secret", "sboms", false, "production")
+	require.NoError(t, err)
+	legacyStorage, ok := storage.(usecase.LegacyObjectStorage)
+	require.True(t, ok)
+	keys, err := legacyStorage.ListLegacyObjects(ctx)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{aliceKey, sharedKey}, keys)
+
+	alice := usecase.WithStorageTenant(ctx, "alice")
+	movedKey, err := legacyStorage.MoveLegacyObject(alice, aliceKey)
+	require.NoError(t, err)
+	assert.Equal(t, "production/tenants/alice/"+aliceKey, movedKey)
+	sharedMovedKey, err := legacyStorage.MoveLegacyObject(ctx, sharedKey)
+	require.NoError(t, err)
+	assert.Equal(t, "production/"+sharedKey, sharedMovedKey)
+	assert.Equal(t, []string{movedKey, sharedMovedKey}, store.sortedKeys())
+
+	aliceKeys, err := storage.ListSBOMs(alice, "api")
+	require.NoError(t, err)
+	assert.Equal(t, []string{movedKey}, aliceKeys)
+	keys, err = legacyStorage.ListLegacyObjects(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+
+	_, err = legacyStorage.MoveLegacyObject(ctx, movedKey)
+	assert.Error(t, err, "only legacy keys are moved")
+}
+
+func TestMinioUsecase_UnreachableEndpointReturnsError(t *testing.T) {
+	// A listener closed right away leaves a port nothing answers on
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	endpoint := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	var storage usecase.ObjectStorageInterface
+	require.NotPanics(t, func() {
+		storage, err = usecase.NewMinioUsecase(endpoint, "access", "secret", "sboms", false, "")
+	})
+	assert.Error(t, err)
+	assert.Nil(t, storage, "no client is returned, so services run with storage disabled")
+}
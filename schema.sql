@@ -7,16 +7,23 @@
 --  Core Entity Tables (must be created first)
 -- =========================
 
-CREATE TABLE IF NOT EXISTS framework (
+CREATE TABLE IF NOT EXISTS runtime (
     id SERIAL PRIMARY KEY,
     name TEXT NOT NULL
 );
 
-CREATE TABLE IF NOT EXISTS runtime (
+CREATE TABLE IF NOT EXISTS framework (
     id SERIAL PRIMARY KEY,
     name TEXT NOT NULL
 );
 
+-- Runtimes a framework can be used with; frameworks without any are accepted for every runtime
+CREATE TABLE IF NOT EXISTS framework_runtime (
+    framework_id INT NOT NULL REFERENCES framework(id) ON DELETE CASCADE,
+    runtime_id INT NOT NULL REFERENCES runtime(id) ON DELETE CASCADE,
+    PRIMARY KEY (framework_id, runtime_id)
+);
+
 CREATE TABLE IF NOT EXISTS app (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
     name TEXT NOT NULL,
@@ -25,10 +32,18 @@ CREATE TABLE IF NOT EXISTS app (
     description TEXT,
     is_deleted BOOLEAN DEFAULT FALSE,
     status TEXT,
+    owner_id TEXT,
+    manifest_file_name TEXT,     -- manifest the application was added from; NULL when it could not be stored
+    manifest_object_key TEXT,    -- its object in storage
+    gradle_properties_object_key TEXT, -- gradle.properties uploaded with the manifest, if any
     created_at TIMESTAMPTZ DEFAULT NOW(),
     updated_at TIMESTAMPTZ DEFAULT NOW()
 );
 
+-- Applications are scoped to the authenticated user (JWT subject) that created them
+CREATE INDEX IF NOT EXISTS idx_app_owner_id ON app(owner_id);
+CREATE INDEX IF NOT EXISTS idx_framework_runtime_runtime_id ON framework_runtime(runtime_id);
+
 -- =========================
 --  Enhanced Schema Tables
 -- =========================
@@ -45,6 +60,12 @@ CREATE TABLE IF NOT EXISTS dependencies (
     last_tag_at TIMESTAMPTZ,
     default_branch TEXT DEFAULT 'main',
     repository_url TEXT,
+    archived BOOLEAN,            -- repository signals judging whether the dependency is maintained; NULL until fetched
+    stars INT,
+    collaborators INT,
+    signals_checked_at TIMESTAMPTZ,
+    github_etag TEXT,            -- validators of the GitHub response the metadata was fetched after
+    github_last_modified TEXT,
     created_at TIMESTAMPTZ DEFAULT now(),
     updated_at TIMESTAMPTZ DEFAULT now(),
     UNIQUE(owner, repo)
@@ -58,6 +79,8 @@ CREATE TABLE IF NOT EXISTS app_dependencies (
     used_commit_sha  VARCHAR(64),
     used_version     VARCHAR(128) NOT NULL,
     used_tag         VARCHAR(128),
+    ecosystem        VARCHAR(64), -- OSV ecosystem override; NULL uses the application's runtime
+    transitive       BOOLEAN     NOT NULL DEFAULT FALSE, -- pulled in by other dependencies, e.g. go.mod "// indirect"
     
     -- Monitoring configuration
     is_monitored     BOOLEAN     NOT NULL DEFAULT FALSE,
@@ -76,6 +99,11 @@ CREATE TABLE IF NOT EXISTS app_dependencies (
     last_security_detection_at TIMESTAMPTZ,
     last_security_score INT DEFAULT 0,
     
+    -- Staleness of the used version, measured when GitHub metadata is fetched
+    releases_behind  INT,
+    staleness_days   INT,
+    staleness_checked_at TIMESTAMPTZ,
+    
     created_at       TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
     updated_at       TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
     UNIQUE(app_id, dependency_id)
@@ -182,6 +210,111 @@ CREATE TABLE IF NOT EXISTS audit_trail (
     risk_level TEXT              -- for security events
 );
 
+-- Scan Results table (one row per scan, queued until completed)
+CREATE TABLE IF NOT EXISTS scan_result (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    app_id UUID,                 -- NULL for ad-hoc manifest scans
+    owner_id TEXT,               -- user who ran an ad-hoc scan; application scans belong to the application's owner
+    app_name TEXT,
+    scan_type TEXT,              -- application, adhoc
+    status TEXT,                 -- queued, running, completed, failed
+
+    policy_status TEXT,
+    total_dependencies INT DEFAULT 0,
+    total_vulnerabilities INT DEFAULT 0,
+    critical INT DEFAULT 0,
+    high INT DEFAULT 0,
+    medium INT DEFAULT 0,
+    low INT DEFAULT 0,
+
+    result JSONB,                -- full scan result payload
+    error_message TEXT,
+    sbom_object_key TEXT,        -- SBOM object in storage, deleted with the row by retention cleanup
+    started_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ,
+
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- Findings are the vulnerabilities of completed application scans, copied out of scan_result.result so scan
+-- history can be queried; they are removed together with their scan
+CREATE TABLE IF NOT EXISTS finding (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    scan_id UUID NOT NULL REFERENCES scan_result(id) ON DELETE CASCADE,
+    app_id UUID NOT NULL REFERENCES app(id) ON DELETE CASCADE,
+    dependency TEXT NOT NULL,
+    version TEXT,
+    vulnerability_id VARCHAR(255) NOT NULL,
+    severity VARCHAR(32) NOT NULL,
+    original_severity VARCHAR(32),   -- advisory severity when a severity override changed severity
+    risk_score DOUBLE PRECISION,
+    recommended_version TEXT,
+    recommendation TEXT,
+    scanned_at TIMESTAMPTZ NOT NULL  -- when the scan completed
+);
+
+-- Remediations track the fix of a vulnerability of a dependency in an application
+CREATE TABLE IF NOT EXISTS remediation (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    app_id UUID NOT NULL REFERENCES app(id) ON DELETE CASCADE,
+    dependency TEXT NOT NULL,
+    vulnerability_id VARCHAR(255) NOT NULL,
+    status VARCHAR(32) NOT NULL,  -- open, in_progress, resolved
+    external_ticket_url TEXT,
+    notes TEXT,
+    resolved_at TIMESTAMPTZ,
+    resolved_by_scan_id UUID,     -- set when a scan no longer reporting the vulnerability resolved it
+    created_by VARCHAR(255),
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- Cron schedules scanning an application whenever their expression (UTC) matches
+CREATE TABLE IF NOT EXISTS scan_schedule (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    app_id UUID NOT NULL REFERENCES app(id) ON DELETE CASCADE,
+    cron_expression VARCHAR(128) NOT NULL,
+    enabled BOOLEAN NOT NULL,
+    next_run_at TIMESTAMPTZ,      -- NULL while the schedule is disabled
+    last_run_at TIMESTAMPTZ,
+    last_scan_id UUID,
+    last_error TEXT,
+    created_by VARCHAR(255),
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- Severity overrides set the effective severity of the vulnerabilities they match by CWE, CVE pattern or
+-- ecosystem; those without an application apply to every application of their owner
+CREATE TABLE IF NOT EXISTS severity_override (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id VARCHAR(255) NOT NULL DEFAULT '',
+    app_id UUID REFERENCES app(id) ON DELETE CASCADE,
+    cwe VARCHAR(32),
+    cve_pattern VARCHAR(255),
+    ecosystem VARCHAR(64),
+    severity VARCHAR(32) NOT NULL,
+    reason TEXT,
+    created_by VARCHAR(255),
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- Tags such as "team:payments" or "env:prod" group applications
+CREATE TABLE IF NOT EXISTS tag (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS app_tags (
+    app_id UUID NOT NULL REFERENCES app(id) ON DELETE CASCADE,
+    tag_id UUID NOT NULL REFERENCES tag(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY (app_id, tag_id)
+);
+
 -- Monitoring Configuration table
 CREATE TABLE IF NOT EXISTS monitoring_config (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
@@ -194,6 +327,35 @@ CREATE TABLE IF NOT EXISTS monitoring_config (
     updated_at TIMESTAMPTZ DEFAULT NOW()
 );
 
+-- =========================
+--  Columns added since the tables were introduced
+--  (CREATE TABLE IF NOT EXISTS leaves existing tables as they are)
+-- =========================
+
+ALTER TABLE app ADD COLUMN IF NOT EXISTS manifest_file_name TEXT;
+ALTER TABLE app ADD COLUMN IF NOT EXISTS manifest_object_key TEXT;
+ALTER TABLE app ADD COLUMN IF NOT EXISTS gradle_properties_object_key TEXT;
+
+ALTER TABLE dependencies ADD COLUMN IF NOT EXISTS archived BOOLEAN;
+ALTER TABLE dependencies ADD COLUMN IF NOT EXISTS stars INT;
+ALTER TABLE dependencies ADD COLUMN IF NOT EXISTS collaborators INT;
+ALTER TABLE dependencies ADD COLUMN IF NOT EXISTS signals_checked_at TIMESTAMPTZ;
+ALTER TABLE dependencies ADD COLUMN IF NOT EXISTS github_etag TEXT;
+ALTER TABLE dependencies ADD COLUMN IF NOT EXISTS github_last_modified TEXT;
+
+ALTER TABLE scan_result ADD COLUMN IF NOT EXISTS owner_id TEXT;
+
+ALTER TABLE app_dependencies ADD COLUMN IF NOT EXISTS transitive BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE finding ADD COLUMN IF NOT EXISTS original_severity VARCHAR(32);
+ALTER TABLE finding ADD COLUMN IF NOT EXISTS recommendation TEXT;
+
+-- Application scans once recorded a finding's dependency as "<name>:<repo>"; they report the name now
+UPDATE finding SET dependency = dependencies.name FROM dependencies
+    WHERE dependencies.repo <> '' AND finding.dependency = dependencies.name || ':' || dependencies.repo;
+UPDATE remediation SET dependency = dependencies.name FROM dependencies
+    WHERE dependencies.repo <> '' AND remediation.dependency = dependencies.name || ':' || dependencies.repo;
+
 -- =========================
 --  Indexes for Performance
 -- =========================
@@ -234,6 +396,32 @@ CREATE INDEX IF NOT EXISTS idx_audit_performed_at ON audit_trail(performed_at DE
 CREATE INDEX IF NOT EXISTS idx_audit_security ON audit_trail(security_relevant, risk_level);
 CREATE INDEX IF NOT EXISTS idx_audit_action ON audit_trail(action);
 
+-- Scan Results indexes
+CREATE INDEX IF NOT EXISTS idx_scan_result_app_id ON scan_result(app_id);
+CREATE INDEX IF NOT EXISTS idx_scan_result_owner_id ON scan_result(owner_id);
+CREATE INDEX IF NOT EXISTS idx_scan_result_created ON scan_result(created_at DESC);
+
+-- Finding indexes
+CREATE INDEX IF NOT EXISTS idx_finding_scan_id ON finding(scan_id);
+CREATE INDEX IF NOT EXISTS idx_finding_app_scanned ON finding(app_id, scanned_at);
+CREATE INDEX IF NOT EXISTS idx_finding_vulnerability_id ON finding(vulnerability_id);
+
+-- Remediation indexes
+CREATE INDEX IF NOT EXISTS idx_remediation_app_id ON remediation(app_id);
+CREATE INDEX IF NOT EXISTS idx_remediation_vulnerability_id ON remediation(vulnerability_id);
+CREATE INDEX IF NOT EXISTS idx_remediation_status ON remediation(status);
+
+-- Scan Schedule indexes
+CREATE INDEX IF NOT EXISTS idx_scan_schedule_app_id ON scan_schedule(app_id);
+CREATE INDEX IF NOT EXISTS idx_scan_schedule_due ON scan_schedule(enabled, next_run_at);
+
+-- Severity Override indexes
+CREATE INDEX IF NOT EXISTS idx_severity_override_owner_id ON severity_override(owner_id);
+CREATE INDEX IF NOT EXISTS idx_severity_override_app_id ON severity_override(app_id);
+
+-- Tag indexes
+CREATE INDEX IF NOT EXISTS idx_app_tags_tag_id ON app_tags(tag_id);
+
 -- Monitoring Config indexes
 CREATE INDEX IF NOT EXISTS idx_monitoring_config_key ON monitoring_config(config_key);
 CREATE INDEX IF NOT EXISTS idx_monitoring_config_system ON monitoring_config(is_system_config);